@@ -18,9 +18,10 @@ require (
 	github.com/spf13/pflag v1.0.5
 	github.com/src-d/enry/v2 v2.1.0
 	github.com/yuin/goldmark v1.4.13
-	golang.org/x/term v0.6.0
+	golang.org/x/term v0.8.0
 	gopkg.in/yaml.v3 v3.0.1
 	modernc.org/sqlite v1.28.0
+	mvdan.cc/sh/v3 v3.7.0
 )
 
 require (
@@ -44,7 +45,7 @@ require (
 	github.com/src-d/go-oniguruma v1.1.0 // indirect
 	github.com/toqueteos/trie v1.0.0 // indirect
 	golang.org/x/mod v0.6.0-dev.0.20220419223038-86c51ed26bb4 // indirect
-	golang.org/x/sync v0.1.0 // indirect
+	golang.org/x/sync v0.2.0 // indirect
 	golang.org/x/sys v0.12.0 // indirect
 	golang.org/x/text v0.4.0 // indirect
 	golang.org/x/tools v0.1.12 // indirect
@@ -1,12 +1,12 @@
 /**
  * Component: CLI Bridge Orchestrator
- * Block-UUID: afeb3ea5-a3b9-423e-a63b-a21dafe8b789
- * Parent-UUID: cfc76884-041e-4372-ad56-46fb1028ba31
- * Version: 1.8.0
- * Description: Refactored to use centralized settings.GetGSCHome for environment resolution, removing duplicate logic. Added BridgeCodeExpiredError to allow the exec command to detect expired codes and persist output for recovery. Updated Execute signature to accept exitCode for display in the bridge message.
+ * Block-UUID: 33230a95-ef3a-4dd9-bd54-fe4f511dd8fc
+ * Parent-UUID: d3c73ea4-2e59-4a90-ad6d-03ea5a34ab60
+ * Version: 1.13.0
+ * Description: Oversized output is handled by the oversize mode passed to Execute or a prompt: chunked into linked messages, summarized, saved as an attachment, forced, or aborted. The force mode also skips the confirmation prompt, replacing the separate force argument. Added InsertChainToChat, which inserts the whole chain in one transaction, and the oversize fields of the handshake result.
  * Language: Go
  * Created-at: 2026-02-19T17:50:00.000Z
- * Authors: Gemini 3 Flash (v1.0.0), Gemini 3 Flash (v1.1.0), Gemini 3 Flash (v1.2.0), GLM-4.7 (v1.3.0), Gemini 3 Flash (v1.3.1), GLM-4.7 (v1.4.0), Gemini 3 Flash (v1.5.0), Gemini 3 Flash (v1.6.0), Gemini 3 Flash (v1.7.0), Gemini 3 Flash (v1.8.0)
+ * Authors: Gemini 3 Flash (v1.0.0), Gemini 3 Flash (v1.1.0), Gemini 3 Flash (v1.2.0), GLM-4.7 (v1.3.0), Gemini 3 Flash (v1.3.1), GLM-4.7 (v1.4.0), Gemini 3 Flash (v1.5.0), Gemini 3 Flash (v1.6.0), Gemini 3 Flash (v1.7.0), Gemini 3 Flash (v1.8.0), agent (v1.9.0), agent (v1.10.0), agent (v1.11.0), agent (v1.12.0), agent (v1.13.0)
  */
 
 
@@ -93,13 +93,19 @@ type Error struct {
 }
 
 type Result struct {
-	MessageID  *int64  `json:"messageId"`
-	Output     *string `json:"output"`
-	OutputSize *int64  `json:"outputSize"`
+	MessageID      *int64  `json:"messageId"` // Last inserted message, the new leaf
+	Output         *string `json:"output"`
+	OutputSize     *int64  `json:"outputSize"`
+	OversizeMode   string  `json:"oversizeMode,omitempty"`   // How oversized output was handled
+	MessageIDs     []int64 `json:"messageIds,omitempty"`     // Every inserted message, in order
+	AttachmentPath *string `json:"attachmentPath,omitempty"` // Full output saved by attach mode
 }
 
-// Execute is the main entry point for the CLI Bridge.
-func Execute(code string, rawOutput string, format string, cmdStr string, duration time.Duration, dbName string, exitCode int, force bool) error {
+// Execute is the main entry point for the CLI Bridge. oversize is the
+// --oversize mode for output over the size limit (empty asks); see
+// ResolveOversizeMode. Any mode but ask also skips the confirmation prompt
+// for output within the limit.
+func Execute(code string, rawOutput string, format string, cmdStr string, duration time.Duration, dbName string, exitCode int, oversize string) error {
 	// 1. Resolve GSC_HOME and Load Handshake
 	gscHome, err := settings.GetGSCHome(false)
 	if err != nil {
@@ -139,16 +145,47 @@ func Execute(code string, rawOutput string, format string, cmdStr string, durati
 	outputSize := int64(len(markdown))
 
 	// 5. Size Validation & Confirmation
+	messages := []string{markdown}
+	mode := ""
 	if outputSize > h.MaxOutputSize {
 		h.UpdateStatus("oversized", nil)
-		fmt.Fprintf(os.Stderr, "\n⚠️  Output (%.2f MB) exceeds the %.2f MB limit. Proceed anyway? [y/N] ", 
-			float64(outputSize)/1024/1024, float64(h.MaxOutputSize)/1024/1024)
-		
-		if !askConfirmation(false) {
+		mode = chooseOversizeMode(oversize, outputSize, h.MaxOutputSize)
+		out := bridgeOutput{Command: cmdStr, Duration: duration, DBName: dbName, Format: format, ExitCode: exitCode, Raw: rawOutput}
+
+		switch mode {
+		case OversizeChunk:
+			messages, err = chunkMessages(out, h.MaxOutputSize)
+			if err != nil {
+				h.UpdateStatus("error", &Error{Code: "ERR_CHUNK", Message: err.Error()})
+				return &BridgeError{ExitCode: 4, Message: err.Error(), Err: err}
+			}
+		case OversizeSummary:
+			note := fmt.Sprintf("The output (%.2f MB) exceeded the %.2f MB limit and was summarized.", float64(len(rawOutput))/1024/1024, float64(h.MaxOutputSize)/1024/1024)
+			summary, err := summaryMessage(out, h.MaxOutputSize, "GSC CLI Output (summary)", note, summaryLimits{Head: 100, Tail: 100, ErrorLines: 200, Files: 50})
+			if err != nil {
+				h.UpdateStatus("error", &Error{Code: "ERR_SUMMARY", Message: err.Error()})
+				return &BridgeError{ExitCode: 4, Message: err.Error(), Err: err}
+			}
+			messages = []string{summary}
+		case OversizeAttach:
+			path, err := writeAttachment(h, out)
+			if err != nil {
+				h.UpdateStatus("error", &Error{Code: "ERR_ATTACHMENT", Message: err.Error()})
+				return &BridgeError{ExitCode: 3, Message: err.Error(), Err: err}
+			}
+			h.Result.AttachmentPath = &path
+			message, err := attachmentMessage(out, h.MaxOutputSize, path)
+			if err != nil {
+				h.UpdateStatus("error", &Error{Code: "ERR_ATTACHMENT", Message: err.Error()})
+				return &BridgeError{ExitCode: 4, Message: err.Error(), Err: err}
+			}
+			messages = []string{message}
+		case OversizeForce:
+		default:
 			h.UpdateStatus("error", &Error{Code: "USER_ABORTED_OVERSIZED", Message: "User declined oversized output"})
 			return &BridgeError{ExitCode: 4, Message: "message was not added to chat (size exceeded limit)"}
 		}
-	} else if !force {
+	} else if oversize == "" || oversize == OversizeAsk {
 		h.UpdateStatus("awaiting-confirmation", nil)
 		if strings.ToLower(format) == "human" {
 			fmt.Fprintln(os.Stderr, "\nHint: For better AI analysis, use '--format json' to provide structured data.")
@@ -169,19 +206,28 @@ func Execute(code string, rawOutput string, format string, cmdStr string, durati
 	}
 
 	// 7. Database Insertion
-	msgID, err := h.InsertToChat(markdown)
+	msgIDs, err := h.InsertChainToChat(messages)
 	if err != nil {
 		h.UpdateStatus("error", &Error{Code: "ERR_DB_INSERT", Message: err.Error()})
 		return &BridgeError{ExitCode: 3, Message: err.Error(), Err: err}
 	}
+	msgID := msgIDs[len(msgIDs)-1]
 
 	// 8. Success & Cleanup
+	insertedSize := int64(0)
+	for _, m := range messages {
+		insertedSize += int64(len(m))
+	}
 	h.Result.MessageID = &msgID
-	h.Result.OutputSize = &outputSize
+	h.Result.OutputSize = &insertedSize
+	if mode != "" {
+		h.Result.OversizeMode = mode
+		h.Result.MessageIDs = msgIDs
+	}
 	
-	preview := markdown
-	if outputSize > 102400 {
-		preview = markdown[:102400] + "\n\n[Output truncated in handshake file. Full content is in the chat database.]"
+	preview := messages[0]
+	if int64(len(preview)) > 102400 {
+		preview = preview[:102400] + "\n\n[Output truncated in handshake file. Full content is in the chat database.]"
 	}
 	h.Result.Output = &preview
 
@@ -189,7 +235,14 @@ func Execute(code string, rawOutput string, format string, cmdStr string, durati
 		return err
 	}
 
-	fmt.Fprintf(os.Stderr, "\n[BRIDGE] Success! Message ID: %d\n", msgID)
+	if len(msgIDs) > 1 {
+		fmt.Fprintf(os.Stderr, "\n[BRIDGE] Success! Added %d linked messages (IDs %d-%d)\n", len(msgIDs), msgIDs[0], msgID)
+	} else {
+		fmt.Fprintf(os.Stderr, "\n[BRIDGE] Success! Message ID: %d\n", msgID)
+	}
+	if h.Result.AttachmentPath != nil {
+		fmt.Fprintf(os.Stderr, "[BRIDGE] Full output saved to: %s\n", *h.Result.AttachmentPath)
+	}
 	fmt.Fprintln(os.Stderr, "[BRIDGE] Note: This bridge code has been consumed and cannot be reused.")
 
 	return nil
@@ -311,44 +364,70 @@ func (h *Handshake) UpdateStatus(status string, errObj *Error) error {
 
 // InsertToChat performs the database insertion logic.
 func (h *Handshake) InsertToChat(markdown string) (int64, error) {
+	ids, err := h.InsertChainToChat([]string{markdown})
+	if err != nil {
+		return 0, err
+	}
+	return ids[0], nil
+}
+
+// InsertChainToChat inserts messages as an ordered chain: the first replies
+// to the handshake's parent message and each later one to the one before.
+// The chain is inserted in one transaction, so a failure inserts nothing.
+func (h *Handshake) InsertChainToChat(messages []string) ([]int64, error) {
 	sqliteDB, err := db.OpenDB(h.DBPath)
 	if err != nil {
-		return 0, fmt.Errorf("failed to connect to chat database: %w", err)
+		return nil, fmt.Errorf("failed to connect to chat database: %w", err)
 	}
 	defer sqliteDB.Close()
 
 	parent, err := db.GetMessage(sqliteDB, h.ParentMessageID)
 	if err != nil {
-		return 0, fmt.Errorf("parent validation failed: %w", err)
+		return nil, fmt.Errorf("parent validation failed: %w", err)
 	}
 
 	isLeaf, err := db.IsLeafNode(sqliteDB, h.ParentMessageID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 	if !isLeaf {
-		return 0, fmt.Errorf("cannot reply to message %d: it already has replies", h.ParentMessageID)
+		return nil, fmt.Errorf("cannot reply to message %d: it already has replies", h.ParentMessageID)
 	}
 
-	msg := &db.Message{
-		Type:       "gsc-cli-output",
-		Deleted:    0,
-		Visibility: h.DefaultVisibility,
-		ChatID:     h.ChatID,
-		ParentID:   h.ParentMessageID,
-		Level:      parent.Level + 1,
-		Role:       "assistant",
-		RealModel:  sql.NullString{String: settings.RealModelNotes, Valid: true},
-		Temperature: sql.NullFloat64{Float64: 0, Valid: true},
-		Message:    sql.NullString{String: markdown, Valid: true},
+	tx, err := sqliteDB.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
+
+	ids := make([]int64, 0, len(messages))
+	parentID, level := h.ParentMessageID, parent.Level
+	for i, markdown := range messages {
+		msg := &db.Message{
+			Type:       "gsc-cli-output",
+			Deleted:    0,
+			Visibility: h.DefaultVisibility,
+			ChatID:     h.ChatID,
+			ParentID:   parentID,
+			Level:      level + 1,
+			Role:       "assistant",
+			RealModel:  sql.NullString{String: settings.RealModelNotes, Valid: true},
+			Temperature: sql.NullFloat64{Float64: 0, Valid: true},
+			Message:    sql.NullString{String: markdown, Valid: true},
+		}
 
-	msgID, err := db.InsertMessage(sqliteDB, msg)
-	if err != nil {
-		return 0, err
+		msgID, err := db.InsertMessage(tx, msg)
+		if err != nil {
+			return nil, fmt.Errorf("message %d of %d: %w", i+1, len(messages), err)
+		}
+		ids = append(ids, msgID)
+		parentID, level = msgID, level+1
 	}
 
-	return msgID, nil
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit messages: %w", err)
+	}
+	return ids, nil
 }
 
 // Cleanup deletes the handshake file upon success.
@@ -1,12 +1,12 @@
 /**
  * Component: Cat Formatter
- * Block-UUID: 9b34a743-e870-4d4a-abe5-b9d2b5b615d7
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Built-in formatter for the 'cat' command. Detects file language, extracts traceability metadata, escapes backticks, and wraps output in a Markdown code block.
+ * Block-UUID: 80fbefb9-01d5-43e4-8d7c-d048f90a2e7c
+ * Parent-UUID: 9b34a743-e870-4d4a-abe5-b9d2b5b615d7
+ * Version: 1.1.0
+ * Description: Added Description for 'gsc app formatters list'.
  * Language: Go
  * Created-at: 2026-02-28T16:47:12.000Z
- * Authors: GLM-4.7 (v1.0.0)
+ * Authors: GLM-4.7 (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -33,6 +33,11 @@ func (cf *CatFormatter) PreProcess(args []string) []string {
 	return args
 }
 
+// Description summarizes the formatter.
+func (cf *CatFormatter) Description() string {
+	return "File contents in a code block with language and traceability header"
+}
+
 // PostProcess enriches the raw file content with Markdown formatting and traceability info.
 func (cf *CatFormatter) PostProcess(rawOutput string) (string, error) {
 	// 1. Detect Language
@@ -1,12 +1,12 @@
 /**
  * Component: Formatter Framework
- * Block-UUID: 6b0d5338-bb10-4748-8d70-333b5db61eeb
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Defines the Formatter interface and the resolution logic for selecting between external scripts, internal implementations, and raw execution.
+ * Block-UUID: ed421b07-11f2-4f69-887c-12960da244bd
+ * Parent-UUID: 6b0d5338-bb10-4748-8d70-333b5db61eeb
+ * Version: 1.1.0
+ * Description: Formatters describe themselves, can receive the command's working directory, and return ErrNotApplicable to keep the raw output. Added Prepare, which resolves the formatter and applies its argument rewrite to plain commands, and ListFormatters for the built-in and external formatters.
  * Language: Go
  * Created-at: 2026-02-28T16:46:33.000Z
- * Authors: GLM-4.7 (v1.0.0)
+ * Authors: GLM-4.7 (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -14,8 +14,11 @@ package formatters
 
 import (
 	"bytes"
+	"errors"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/gitsense/gsc-cli/pkg/logger"
@@ -37,8 +40,94 @@ type Formatter interface {
 	PreProcess(args []string) []string
 
 	// PostProcess transforms the raw output after execution.
-	// Returns the formatted output.
+	// Returns the formatted output, or ErrNotApplicable to keep the raw output.
 	PostProcess(rawOutput string) (string, error)
+
+	// Description is a one-line summary shown by 'gsc app formatters list'.
+	Description() string
+}
+
+// ContextAware is implemented by formatters that need the working directory
+// of the command, e.g. to read the files a search matched.
+type ContextAware interface {
+	SetContext(ctx FormatterContext)
+}
+
+// ErrNotApplicable is returned by PostProcess when the formatter does not
+// handle this invocation (e.g. a git subcommand other than diff or show).
+var ErrNotApplicable = errors.New("formatter does not apply to this command")
+
+// FormatterInfo describes an available formatter.
+type FormatterInfo struct {
+	Command     string `json:"command"`
+	Source      string `json:"source"` // "built-in" or "external"
+	Description string `json:"description"`
+	Path        string `json:"path,omitempty"`
+}
+
+// shellMetaChars are characters that make a command more than one plain
+// simple command; such commands are never rewritten by PreProcess.
+const shellMetaChars = "|&;<>()$`\\\"'*?[]{}~#\n"
+
+// Prepare resolves the formatter for a command line and runs its PreProcess.
+// When the command is a single simple command of plain words, the arguments
+// PreProcess returns replace the original ones (e.g. go test gains -json).
+// It returns the command to run and the formatter, or nil if there is none.
+func Prepare(command string, workdir string) (string, Formatter) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return command, nil
+	}
+	f := ResolveFormatter(fields[0])
+	if f == nil {
+		return command, nil
+	}
+	if ca, ok := f.(ContextAware); ok {
+		ca.SetContext(FormatterContext{Command: fields[0], Args: fields[1:], WorkDir: workdir})
+	}
+
+	args := f.PreProcess(fields[1:])
+	if strings.ContainsAny(command, shellMetaChars) {
+		return command, f
+	}
+	rewritten := strings.Join(append([]string{fields[0]}, args...), " ")
+	if rewritten != strings.Join(fields, " ") {
+		logger.Debug("Formatter rewrote command", "from", command, "to", rewritten)
+		return rewritten, f
+	}
+	return command, f
+}
+
+// ListFormatters returns the built-in formatters and the external scripts in
+// $GSC_HOME/formatters. An external script overrides a built-in one.
+func ListFormatters() []FormatterInfo {
+	byCommand := map[string]FormatterInfo{}
+	for command, f := range internalRegistry {
+		byCommand[command] = FormatterInfo{Command: command, Source: "built-in", Description: f.Description()}
+	}
+
+	if gscHome, err := settings.GetGSCHome(false); err == nil {
+		dir := filepath.Join(gscHome, "formatters")
+		entries, _ := os.ReadDir(dir)
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			path, err := exec.LookPath(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				continue
+			}
+			ef := &ExternalFormatter{Path: path}
+			byCommand[entry.Name()] = FormatterInfo{Command: entry.Name(), Source: "external", Description: ef.Description(), Path: path}
+		}
+	}
+
+	infos := make([]FormatterInfo, 0, len(byCommand))
+	for _, info := range byCommand {
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Command < infos[j].Command })
+	return infos
 }
 
 // ResolveFormatter determines which formatter to use for a given command.
@@ -86,6 +175,11 @@ func (ef *ExternalFormatter) PreProcess(args []string) []string {
 	return args
 }
 
+// Description names the script.
+func (ef *ExternalFormatter) Description() string {
+	return "External script " + ef.Path
+}
+
 // PostProcess executes the external script, piping the raw output to stdin and capturing stdout.
 func (ef *ExternalFormatter) PostProcess(rawOutput string) (string, error) {
 	cmd := exec.Command(ef.Path)
@@ -0,0 +1,201 @@
+/**
+ * Component: Built-in Formatter Tests
+ * Block-UUID: b2d74e19-8c35-4f6a-9e07-5a1c3f82d6e0
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests the git diff, go test, gsc grep, and rg formatters against captured output, and that Prepare rewrites only plain commands.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package formatters
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleDiff = `diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,3 +1,4 @@
+ package main
+-// old
++// new
++--- not a header
+diff --git a/old.txt b/new.txt
+similarity index 90%
+rename from old.txt
+rename to new.txt
+diff --git a/logo.png b/logo.png
+new file mode 100644
+Binary files /dev/null and b/logo.png differ
+`
+
+func TestGitFormatterDiff(t *testing.T) {
+	gf := &GitFormatter{}
+	gf.PreProcess([]string{"-C", "repo", "--no-pager", "diff", "HEAD"})
+
+	out, err := gf.PostProcess(sampleDiff)
+	if err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+	for _, want := range []string{
+		"**3 file(s) changed**, 2 insertion(s)(+), 1 deletion(s)(-)",
+		"| `main.go` | modified | 2 | 1 |",
+		"| `old.txt → new.txt` | renamed | 0 | 0 |",
+		"| `logo.png` | binary | 0 | 0 |",
+		"#### `main.go` (+2 -1)\n\n```diff\nindex 1111111",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+
+	gf.PreProcess([]string{"status"})
+	if _, err := gf.PostProcess(sampleDiff); !errors.Is(err, ErrNotApplicable) {
+		t.Errorf("git status: err = %v, want ErrNotApplicable", err)
+	}
+	gf.PreProcess([]string{"diff"})
+	if _, err := gf.PostProcess(""); !errors.Is(err, ErrNotApplicable) {
+		t.Errorf("empty diff: err = %v, want ErrNotApplicable", err)
+	}
+}
+
+func TestGoTestFormatter(t *testing.T) {
+	gf := &GoTestFormatter{}
+	if args := gf.PreProcess([]string{"test", "./..."}); strings.Join(args, " ") != "test -json ./..." {
+		t.Fatalf("args = %q", args)
+	}
+	if args := gf.PreProcess([]string{"test", "-json", "./..."}); len(args) != 3 {
+		t.Fatalf("-json added twice: %q", args)
+	}
+
+	events := []testEvent{
+		{Action: "run", Package: "pkg/a", Test: "TestOK"},
+		{Action: "output", Package: "pkg/a", Test: "TestOK", Output: "=== RUN   TestOK\n"},
+		{Action: "pass", Package: "pkg/a", Test: "TestOK"},
+		{Action: "output", Package: "pkg/a", Test: "TestBad/case", Output: "    a_test.go:9: boom\n"},
+		{Action: "fail", Package: "pkg/a", Test: "TestBad/case"},
+		{Action: "fail", Package: "pkg/a", Test: "TestBad"},
+		{Action: "fail", Package: "pkg/a", Elapsed: 0.5},
+		{Action: "skip", Package: "pkg/b", Test: "TestLater"},
+		{Action: "pass", Package: "pkg/b", Elapsed: 0.1},
+	}
+	var raw strings.Builder
+	raw.WriteString("# pkg/c\nvet: something odd\n")
+	for _, ev := range events {
+		line, _ := json.Marshal(ev)
+		raw.Write(append(line, '\n'))
+	}
+
+	out, err := gf.PostProcess(raw.String())
+	if err != nil {
+		t.Fatalf("PostProcess: %v", err)
+	}
+	for _, want := range []string{
+		"**FAIL**: 1 passed, 2 failed, 1 skipped in 2 package(s)",
+		"| `pkg/a` | ✗ fail | 1 | 2 | 0 | 0.50s |",
+		"| `pkg/b` | ✓ pass | 0 | 0 | 1 | 0.10s |",
+		"#### ✗ `TestBad/case` pkg/a\n\n```text\n    a_test.go:9: boom\n```",
+		"#### Other output\n\n```text\n# pkg/c\nvet: something odd\n```",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output lacks %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "=== RUN   TestOK") || strings.Contains(out, "#### ✗ `TestBad` ") {
+		t.Errorf("output includes passing test output or the failing parent:\n%s", out)
+	}
+
+	if _, err := gf.PostProcess("no Go files in /tmp\n"); !errors.Is(err, ErrNotApplicable) {
+		t.Errorf("plain output: err = %v, want ErrNotApplicable", err)
+	}
+}
+
+func TestSearchFormatters(t *testing.T) {
+	dir := t.TempDir()
+	header := "/**\n * Component: Demo\n * Block-UUID: 11111111-2222-4333-8444-555555555555\n * Parent-UUID: N/A\n * Version: 1.0.0\n */\n"
+	os.WriteFile(filepath.Join(dir, "traced.go"), []byte(header+"package demo\n\n// TODO one\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "plain.py"), []byte("x = 1\n# TODO two\n"), 0644)
+
+	rf := &RipgrepFormatter{}
+	rf.SetContext(FormatterContext{WorkDir: dir})
+	if args := rf.PreProcess([]string{"-n", "TODO"}); args[0] != "--json" {
+		t.Fatalf("args = %q", args)
+	}
+	rgOutput := strings.Join([]string{
+		`{"type":"begin","data":{"path":{"text":"traced.go"}}}`,
+		`{"type":"context","data":{"path":{"text":"traced.go"},"lines":{"text":"\n"},"line_number":8}}`,
+		`{"type":"match","data":{"path":{"text":"traced.go"},"lines":{"text":"// TODO one\n"},"line_number":9}}`,
+		`{"type":"end","data":{"path":{"text":"traced.go"}}}`,
+		`{"type":"begin","data":{"path":{"text":"plain.py"}}}`,
+		`{"type":"match","data":{"path":{"text":"plain.py"},"lines":{"text":"# TODO two\n"},"line_number":2}}`,
+		`{"type":"summary","data":{}}`,
+	}, "\n")
+	out, err := rf.PostProcess(rgOutput)
+	if err != nil {
+		t.Fatalf("rg PostProcess: %v", err)
+	}
+	for _, want := range []string{
+		"**Search:** 2 match(es) in 2 file(s)",
+		"**File:** `traced.go` (Traceable: Yes | UUID: 11111111-2222-4333-8444-555555555555) — 1 match(es)\n\n```go\n    8- \n    9: // TODO one\n```",
+		"**File:** `plain.py` (Traceable: No) — 1 match(es)\n\n```python\n    2: # TODO two\n```",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("rg output lacks %q:\n%s", want, out)
+		}
+	}
+	rf.PreProcess([]string{"-l", "TODO"})
+	if _, err := rf.PostProcess("traced.go\n"); !errors.Is(err, ErrNotApplicable) {
+		t.Errorf("rg -l: err = %v, want ErrNotApplicable", err)
+	}
+
+	gf := &GscGrepFormatter{}
+	gf.SetContext(FormatterContext{WorkDir: "/nonexistent"})
+	if args := gf.PreProcess([]string{"grep", "TODO"}); strings.Join(args, " ") != "grep TODO --format json" {
+		t.Fatalf("args = %q", args)
+	}
+	grepOutput := `{"context":{"pattern":"TODO","system":{"project_root":"` + dir + `"}},
+		"summary":{"total_matches":2,"total_files":1},
+		"files":[{"file_path":"traced.go","matches":[
+			{"line_number":9,"line_text":"// TODO one","context_before":["package demo",""],"context_after":[]},
+			{"line_number":12,"line_text":"x := 1 // TODO","context_before":[],"context_after":["}"]}]}]}`
+	out, err = gf.PostProcess(grepOutput)
+	if err != nil {
+		t.Fatalf("gsc grep PostProcess: %v", err)
+	}
+	want := "**File:** `traced.go` (Traceable: Yes | UUID: 11111111-2222-4333-8444-555555555555) — 2 match(es)\n\n```go\n    7- package demo\n    8- \n    9: // TODO one\n--\n   12: x := 1 // TODO\n   13- }\n```"
+	if !strings.HasPrefix(out, "**Search:** `TODO` — 2 match(es) in 1 file(s)") || !strings.Contains(out, want) {
+		t.Errorf("gsc grep output:\n%s", out)
+	}
+}
+
+func TestPrepareRewritesPlainCommandsOnly(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"go test ./...", "go test -json ./..."},
+		{"go  build ./cmd/gsc", "go  build ./cmd/gsc"},
+		{"go test ./... | tail -5", "go test ./... | tail -5"},
+		{"gsc grep 'a b'", "gsc grep 'a b'"},
+		{"gsc grep TODO", "gsc grep TODO --format json"},
+		{"ls -la", "ls -la"},
+	}
+	for _, tt := range tests {
+		got, _ := Prepare(tt.command, "")
+		if got != tt.want {
+			t.Errorf("Prepare(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+	if _, f := Prepare("ls -la", ""); f != nil {
+		t.Error("ls has a formatter")
+	}
+}
@@ -0,0 +1,181 @@
+/**
+ * Component: Git Formatter
+ * Block-UUID: 5d2e8a41-7c03-4b9f-a6e1-0f3b8c94d275
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Built-in formatter for 'git diff' and 'git show'. Splits the patch per file, counts added and removed lines, and renders a stats table followed by one fenced diff block per file.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package formatters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GitFormatter implements the Formatter interface for the 'git' command.
+// Only the diff and show subcommands are formatted.
+type GitFormatter struct {
+	subcommand string
+}
+
+// fileDiff is the patch of one file.
+type fileDiff struct {
+	Path    string
+	OldPath string
+	Status  string // "modified", "added", "deleted", "renamed", "binary"
+	Added   int
+	Removed int
+	Lines   []string
+}
+
+// PreProcess records the git subcommand, skipping global options.
+func (gf *GitFormatter) PreProcess(args []string) []string {
+	gf.subcommand = ""
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "-C" || arg == "-c" || arg == "--git-dir" || arg == "--work-tree" {
+			i++
+			continue
+		}
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		gf.subcommand = arg
+		break
+	}
+	return args
+}
+
+// Description summarizes the formatter.
+func (gf *GitFormatter) Description() string {
+	return "git diff/show as per-file diff blocks with a change stats table"
+}
+
+// PostProcess renders the patch per file. Output without file headers, or a
+// subcommand other than diff or show, is not formatted.
+func (gf *GitFormatter) PostProcess(rawOutput string) (string, error) {
+	if gf.subcommand != "diff" && gf.subcommand != "show" {
+		return "", ErrNotApplicable
+	}
+	preamble, files := parseGitDiff(rawOutput)
+	if len(files) == 0 {
+		return "", ErrNotApplicable
+	}
+
+	totalAdded, totalRemoved := 0, 0
+	for _, f := range files {
+		totalAdded += f.Added
+		totalRemoved += f.Removed
+	}
+
+	var sb strings.Builder
+	if strings.TrimSpace(preamble) != "" {
+		sb.WriteString("```text\n")
+		sb.WriteString(escapeFences(strings.TrimRight(preamble, "\n")))
+		sb.WriteString("\n```\n\n")
+	}
+
+	sb.WriteString(fmt.Sprintf("**%d file(s) changed**, %d insertion(s)(+), %d deletion(s)(-)\n\n", len(files), totalAdded, totalRemoved))
+	sb.WriteString("| File | Status | + | - |\n")
+	sb.WriteString("|------|--------|---|---|\n")
+	for _, f := range files {
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %d | %d |\n", f.displayPath(), f.Status, f.Added, f.Removed))
+	}
+
+	for _, f := range files {
+		sb.WriteString(fmt.Sprintf("\n#### `%s` (+%d -%d)\n\n", f.displayPath(), f.Added, f.Removed))
+		sb.WriteString("```diff\n")
+		sb.WriteString(escapeFences(strings.Join(f.Lines, "\n")))
+		sb.WriteString("\n```\n")
+	}
+
+	return sb.String(), nil
+}
+
+// displayPath shows renames as old → new.
+func (f fileDiff) displayPath() string {
+	if f.OldPath != "" && f.OldPath != f.Path {
+		return f.OldPath + " → " + f.Path
+	}
+	return f.Path
+}
+
+// parseGitDiff splits git output on 'diff --git' headers. Anything before the
+// first header (the commit message of git show) is returned as the preamble.
+func parseGitDiff(raw string) (string, []*fileDiff) {
+	var preamble strings.Builder
+	var files []*fileDiff
+	var current *fileDiff
+	inHunk := false
+
+	for _, line := range strings.Split(strings.TrimRight(raw, "\n"), "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			current = &fileDiff{Status: "modified"}
+			current.OldPath, current.Path = parseDiffGitPaths(strings.TrimPrefix(line, "diff --git "))
+			files = append(files, current)
+			inHunk = false
+			continue
+		}
+		if current == nil {
+			preamble.WriteString(line + "\n")
+			continue
+		}
+
+		// A new commit in git show/log -p output ends the current file
+		if strings.HasPrefix(line, "commit ") && len(current.Lines) > 0 {
+			current = nil
+			preamble.WriteString(line + "\n")
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk && strings.HasPrefix(line, "new file mode"):
+			current.Status = "added"
+		case !inHunk && strings.HasPrefix(line, "deleted file mode"):
+			current.Status = "deleted"
+		case !inHunk && strings.HasPrefix(line, "rename from "):
+			current.Status = "renamed"
+			current.OldPath = strings.TrimPrefix(line, "rename from ")
+		case !inHunk && strings.HasPrefix(line, "rename to "):
+			current.Path = strings.TrimPrefix(line, "rename to ")
+		case !inHunk && (strings.HasPrefix(line, "Binary files ") || line == "GIT binary patch"):
+			current.Status = "binary"
+		case !inHunk:
+			// Extended header lines (index, ---, +++) are kept but not counted
+		case strings.HasPrefix(line, "+"):
+			current.Added++
+		case strings.HasPrefix(line, "-"):
+			current.Removed++
+		}
+		current.Lines = append(current.Lines, line)
+	}
+	return preamble.String(), files
+}
+
+// parseDiffGitPaths splits "a/old b/new" from a diff --git header.
+func parseDiffGitPaths(header string) (string, string) {
+	if i := strings.Index(header, " b/"); i >= 0 && strings.HasPrefix(header, "a/") {
+		return header[2:i], header[i+3:]
+	}
+	parts := strings.Fields(header)
+	if len(parts) == 2 {
+		return parts[0], parts[1]
+	}
+	return header, header
+}
+
+// escapeFences keeps embedded code fences from closing the block.
+func escapeFences(s string) string {
+	return strings.ReplaceAll(s, "```", "\\```")
+}
+
+// init registers the GitFormatter for the "git" command.
+func init() {
+	RegisterFormatter("git", &GitFormatter{})
+}
@@ -0,0 +1,206 @@
+/**
+ * Component: Go Test Formatter
+ * Block-UUID: 8a4f1c6e-2b93-4d07-9e58-c3d10f7b42a9
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Built-in formatter for 'go test'. Forces -json, then renders a pass/fail table per package and includes the output of failing tests only.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GoTestFormatter implements the Formatter interface for the 'go' command.
+// Only the test subcommand is formatted.
+type GoTestFormatter struct {
+	isTest bool
+}
+
+// testEvent is one line of 'go test -json' (test2json) output.
+type testEvent struct {
+	Action  string  `json:"Action"`
+	Package string  `json:"Package"`
+	Test    string  `json:"Test"`
+	Output  string  `json:"Output"`
+	Elapsed float64 `json:"Elapsed"`
+}
+
+// packageResult collects the results of one package.
+type packageResult struct {
+	Name     string
+	Action   string // final package action: pass, fail, skip
+	Elapsed  float64
+	Passed   int
+	Failed   int
+	Skipped  int
+	Failures []string
+	output   map[string][]string // test name ("" for the package) → output lines
+}
+
+// PreProcess adds -json to 'go test' so the output can be parsed.
+func (gf *GoTestFormatter) PreProcess(args []string) []string {
+	gf.isTest = len(args) > 0 && args[0] == "test"
+	if !gf.isTest {
+		return args
+	}
+	for _, arg := range args[1:] {
+		if arg == "-json" || arg == "--json" {
+			return args
+		}
+	}
+	out := make([]string, 0, len(args)+1)
+	out = append(out, "test", "-json")
+	return append(out, args[1:]...)
+}
+
+// Description summarizes the formatter.
+func (gf *GoTestFormatter) Description() string {
+	return "go test (forced -json) as a pass/fail table with failing output only"
+}
+
+// PostProcess renders the test2json events. Output without any events, such
+// as a build failure before tests start, is not formatted.
+func (gf *GoTestFormatter) PostProcess(rawOutput string) (string, error) {
+	if !gf.isTest {
+		return "", ErrNotApplicable
+	}
+	packages, other := parseTestEvents(rawOutput)
+	if len(packages) == 0 {
+		return "", ErrNotApplicable
+	}
+
+	var passed, failed, skipped int
+	failedPackages := 0
+	for _, p := range packages {
+		passed += p.Passed
+		failed += p.Failed
+		skipped += p.Skipped
+		if p.Action == "fail" {
+			failedPackages++
+		}
+	}
+
+	var sb strings.Builder
+	status := "PASS"
+	if failed > 0 || failedPackages > 0 {
+		status = "FAIL"
+	}
+	sb.WriteString(fmt.Sprintf("**%s**: %d passed, %d failed, %d skipped in %d package(s)\n\n", status, passed, failed, skipped, len(packages)))
+	sb.WriteString("| Package | Result | Passed | Failed | Skipped | Time |\n")
+	sb.WriteString("|---------|--------|--------|--------|---------|------|\n")
+	for _, p := range packages {
+		sb.WriteString(fmt.Sprintf("| `%s` | %s | %d | %d | %d | %.2fs |\n", p.Name, resultLabel(p.Action), p.Passed, p.Failed, p.Skipped, p.Elapsed))
+	}
+
+	for _, p := range packages {
+		for _, test := range p.Failures {
+			sb.WriteString(fmt.Sprintf("\n#### ✗ `%s` %s\n\n", test, p.Name))
+			writeTextBlock(&sb, p.output[test])
+		}
+		// Package output explains failures outside any test (build errors, panics in init)
+		if p.Action == "fail" && p.Failed == 0 {
+			sb.WriteString(fmt.Sprintf("\n#### ✗ `%s`\n\n", p.Name))
+			writeTextBlock(&sb, p.output[""])
+		}
+	}
+
+	if len(other) > 0 {
+		sb.WriteString("\n#### Other output\n\n")
+		writeTextBlock(&sb, other)
+	}
+	return sb.String(), nil
+}
+
+// parseTestEvents reads test2json events in package order of first
+// appearance. Lines that are not events are returned separately.
+func parseTestEvents(raw string) ([]*packageResult, []string) {
+	var order []*packageResult
+	byName := map[string]*packageResult{}
+	var other []string
+
+	for _, line := range strings.Split(raw, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var ev testEvent
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &ev) != nil || ev.Action == "" {
+			other = append(other, line)
+			continue
+		}
+
+		p, ok := byName[ev.Package]
+		if !ok {
+			p = &packageResult{Name: ev.Package, output: map[string][]string{}}
+			byName[ev.Package] = p
+			order = append(order, p)
+		}
+
+		switch ev.Action {
+		case "output":
+			p.output[ev.Test] = append(p.output[ev.Test], strings.TrimRight(ev.Output, "\n"))
+		case "pass", "fail", "skip":
+			if ev.Test == "" {
+				p.Action = ev.Action
+				p.Elapsed = ev.Elapsed
+				continue
+			}
+			switch ev.Action {
+			case "pass":
+				p.Passed++
+			case "fail":
+				p.Failed++
+				p.Failures = append(p.Failures, ev.Test)
+			case "skip":
+				p.Skipped++
+			}
+		}
+	}
+
+	// A failing subtest also fails its parent; show the most specific ones
+	for _, p := range order {
+		sort.Strings(p.Failures)
+		var leaves []string
+		for i, test := range p.Failures {
+			if i+1 < len(p.Failures) && strings.HasPrefix(p.Failures[i+1], test+"/") {
+				continue
+			}
+			leaves = append(leaves, test)
+		}
+		p.Failures = leaves
+	}
+	return order, other
+}
+
+// resultLabel renders a package action for the table.
+func resultLabel(action string) string {
+	switch action {
+	case "pass":
+		return "✓ pass"
+	case "fail":
+		return "✗ fail"
+	case "skip":
+		return "– no tests"
+	default:
+		return "? incomplete"
+	}
+}
+
+// writeTextBlock writes lines in a text code block.
+func writeTextBlock(sb *strings.Builder, lines []string) {
+	sb.WriteString("```text\n")
+	sb.WriteString(escapeFences(strings.Join(lines, "\n")))
+	sb.WriteString("\n```\n")
+}
+
+// init registers the GoTestFormatter for the "go" command.
+func init() {
+	RegisterFormatter("go", &GoTestFormatter{})
+}
@@ -0,0 +1,259 @@
+/**
+ * Component: Grep Formatter
+ * Block-UUID: 3f9b7d20-6e41-4a8c-b5d3-71c2e0a9f864
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Built-in formatters for 'gsc grep' and 'rg'. Forces JSON output, then groups matches by file under a header with the file's traceability UUID and renders line-numbered matches with their context.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gitsense/gsc-cli/internal/search"
+	"github.com/gitsense/gsc-cli/internal/traceability"
+)
+
+// traceabilityScanBytes is how much of a matched file is read to find its header.
+const traceabilityScanBytes = 8 * 1024
+
+// matchLine is one line of a file's search results.
+type matchLine struct {
+	Number  int
+	Text    string
+	IsMatch bool
+}
+
+// fileMatches is the search results of one file.
+type fileMatches struct {
+	Path    string
+	Matches int
+	Lines   []matchLine
+}
+
+// GscGrepFormatter implements the Formatter interface for 'gsc grep'.
+type GscGrepFormatter struct {
+	isGrep  bool
+	workDir string
+}
+
+// SetContext records the working directory used to resolve matched files.
+func (gf *GscGrepFormatter) SetContext(ctx FormatterContext) {
+	gf.workDir = ctx.WorkDir
+}
+
+// PreProcess forces --format json on 'gsc grep' unless a format was given.
+func (gf *GscGrepFormatter) PreProcess(args []string) []string {
+	gf.isGrep = len(args) > 0 && args[0] == "grep"
+	if !gf.isGrep {
+		return args
+	}
+	for _, arg := range args {
+		if arg == "--format" || strings.HasPrefix(arg, "--format=") {
+			return args
+		}
+	}
+	return append(append([]string{}, args...), "--format", "json")
+}
+
+// Description summarizes the formatter.
+func (gf *GscGrepFormatter) Description() string {
+	return "gsc grep matches grouped by file with traceability headers"
+}
+
+// PostProcess renders the JSON response of gsc grep.
+func (gf *GscGrepFormatter) PostProcess(rawOutput string) (string, error) {
+	if !gf.isGrep {
+		return "", ErrNotApplicable
+	}
+	var resp search.GrepResponse
+	if err := json.Unmarshal([]byte(rawOutput), &resp); err != nil || len(resp.Files) == 0 {
+		return "", ErrNotApplicable
+	}
+
+	files := make([]fileMatches, 0, len(resp.Files))
+	for _, f := range resp.Files {
+		fm := fileMatches{Path: f.FilePath, Matches: len(f.Matches)}
+		for _, m := range f.Matches {
+			first := m.LineNumber - len(m.ContextBefore)
+			for i, text := range m.ContextBefore {
+				fm.Lines = append(fm.Lines, matchLine{Number: first + i, Text: text})
+			}
+			fm.Lines = append(fm.Lines, matchLine{Number: m.LineNumber, Text: m.LineText, IsMatch: true})
+			for i, text := range m.ContextAfter {
+				fm.Lines = append(fm.Lines, matchLine{Number: m.LineNumber + 1 + i, Text: text})
+			}
+		}
+		files = append(files, fm)
+	}
+
+	root := resp.Context.System.ProjectRoot
+	if root == "" {
+		root = gf.workDir
+	}
+	title := fmt.Sprintf("**Search:** `%s` — %d match(es) in %d file(s)", resp.Context.Pattern, resp.Summary.TotalMatches, resp.Summary.TotalFiles)
+	return renderFileMatches(title, root, files), nil
+}
+
+// RipgrepFormatter implements the Formatter interface for 'rg'.
+type RipgrepFormatter struct {
+	applicable bool
+	workDir    string
+}
+
+// rgEvent is one line of 'rg --json' output.
+type rgEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		Path struct {
+			Text string `json:"text"`
+		} `json:"path"`
+		Lines struct {
+			Text string `json:"text"`
+		} `json:"lines"`
+		LineNumber int `json:"line_number"`
+	} `json:"data"`
+}
+
+// rgNoJSONFlags are rg modes that cannot be combined with --json.
+var rgNoJSONFlags = map[string]bool{
+	"-l": true, "--files-with-matches": true, "--files-without-match": true,
+	"-c": true, "--count": true, "--count-matches": true, "--files": true,
+	"--type-list": true, "--help": true, "-h": true, "--version": true, "-V": true,
+}
+
+// SetContext records the working directory used to resolve matched files.
+func (rf *RipgrepFormatter) SetContext(ctx FormatterContext) {
+	rf.workDir = ctx.WorkDir
+}
+
+// PreProcess adds --json unless the invocation uses a mode that conflicts with it.
+func (rf *RipgrepFormatter) PreProcess(args []string) []string {
+	rf.applicable = true
+	for _, arg := range args {
+		if arg == "--json" {
+			return args
+		}
+		if rgNoJSONFlags[arg] {
+			rf.applicable = false
+			return args
+		}
+	}
+	return append([]string{"--json"}, args...)
+}
+
+// Description summarizes the formatter.
+func (rf *RipgrepFormatter) Description() string {
+	return "rg (forced --json) matches grouped by file with traceability headers"
+}
+
+// PostProcess renders the JSON events of rg.
+func (rf *RipgrepFormatter) PostProcess(rawOutput string) (string, error) {
+	if !rf.applicable {
+		return "", ErrNotApplicable
+	}
+
+	var files []fileMatches
+	total := 0
+	for _, line := range strings.Split(rawOutput, "\n") {
+		var ev rgEvent
+		if !strings.HasPrefix(line, "{") || json.Unmarshal([]byte(line), &ev) != nil {
+			continue
+		}
+		switch ev.Type {
+		case "begin":
+			files = append(files, fileMatches{Path: ev.Data.Path.Text})
+		case "match", "context":
+			if len(files) == 0 {
+				continue
+			}
+			fm := &files[len(files)-1]
+			isMatch := ev.Type == "match"
+			if isMatch {
+				fm.Matches++
+				total++
+			}
+			fm.Lines = append(fm.Lines, matchLine{Number: ev.Data.LineNumber, Text: strings.TrimRight(ev.Data.Lines.Text, "\r\n"), IsMatch: isMatch})
+		}
+	}
+	if len(files) == 0 {
+		return "", ErrNotApplicable
+	}
+
+	title := fmt.Sprintf("**Search:** %d match(es) in %d file(s)", total, len(files))
+	return renderFileMatches(title, rf.workDir, files), nil
+}
+
+// renderFileMatches writes one section per file. Match lines are marked with
+// ':' and context lines with '-', as grep does; gaps between groups use '--'.
+func renderFileMatches(title string, root string, files []fileMatches) string {
+	var sb strings.Builder
+	sb.WriteString(title + "\n")
+
+	for _, f := range files {
+		sb.WriteString(fmt.Sprintf("\n**File:** `%s`", f.Path))
+		if uuid := fileBlockUUID(root, f.Path); uuid != "" {
+			sb.WriteString(fmt.Sprintf(" (Traceable: Yes | UUID: %s)", uuid))
+		} else {
+			sb.WriteString(" (Traceable: No)")
+		}
+		sb.WriteString(fmt.Sprintf(" — %d match(es)\n\n", f.Matches))
+
+		sb.WriteString(fmt.Sprintf("```%s\n", detectLanguage(f.Path)))
+		last := 0
+		for _, l := range f.Lines {
+			if l.Number <= last {
+				continue // context shared by adjacent matches
+			}
+			if last > 0 && l.Number > last+1 {
+				sb.WriteString("--\n")
+			}
+			sep := "-"
+			if l.IsMatch {
+				sep = ":"
+			}
+			sb.WriteString(fmt.Sprintf("%5d%s %s\n", l.Number, sep, escapeFences(l.Text)))
+			last = l.Number
+		}
+		sb.WriteString("```\n")
+	}
+	return sb.String()
+}
+
+// fileBlockUUID returns the Block-UUID of a file's traceability header, or ""
+// when the file cannot be read or has none.
+func fileBlockUUID(root string, path string) string {
+	if !filepath.IsAbs(path) && root != "" {
+		path = filepath.Join(root, path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	head, err := io.ReadAll(io.LimitReader(file, traceabilityScanBytes))
+	if err != nil {
+		return ""
+	}
+	metadata, _, err := traceability.ParseHeader(string(head))
+	if err != nil || metadata == nil {
+		return ""
+	}
+	return metadata.BlockUUID
+}
+
+// init registers the search formatters.
+func init() {
+	RegisterFormatter("gsc", &GscGrepFormatter{})
+	RegisterFormatter("rg", &RipgrepFormatter{})
+}
@@ -0,0 +1,472 @@
+/**
+ * Component: CLI Bridge Oversized Output
+ * Block-UUID: e43241fd-29c2-441f-8948-e1eddc68b0f5
+ * Parent-UUID: d40ebad1-d466-43f4-ac4d-17d895c14075
+ * Version: 1.4.0
+ * Description: Options for output over the handshake's size limit: split it into ordered linked messages, summarize it (head, tail, error lines, and the file summary of 'gsc grep --format json'), or save it as an attachment under the contract home and insert a compact message that references it. The mode comes from Execute's oversize argument; ValidateOversizeMode checks --oversize values. Chunks and summaries never exceed the limit: summaries are cut when halving their sections is not enough, and limits too small to chunk or summarize are refused. Code fences are longer than any backtick run in the output they wrap.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0), agent (v1.3.0), agent (v1.4.0)
+ */
+
+package bridge
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gitsense/gsc-cli/internal/output"
+	"github.com/gitsense/gsc-cli/internal/search"
+	typescontract "github.com/gitsense/gsc-cli/internal/types/contract"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+)
+
+// Oversize modes select what Execute does with output over the size limit.
+const (
+	OversizeAsk     = "ask"     // Prompt for one of the modes below
+	OversizeChunk   = "chunk"   // Split into ordered linked messages
+	OversizeSummary = "summary" // Insert a computed summary
+	OversizeAttach  = "attach"  // Save to a file and insert a compact message
+	OversizeForce   = "force"   // Insert the full output anyway
+	OversizeAbort   = "abort"   // Insert nothing
+)
+
+// OversizeModes lists the valid oversize modes.
+var OversizeModes = []string{OversizeAsk, OversizeChunk, OversizeSummary, OversizeAttach, OversizeForce, OversizeAbort}
+
+const (
+	// minChunkBudget is the smallest useful chunk; limits that leave less
+	// room after the message header cannot be chunked
+	minChunkBudget = 1024
+	// maxSummaryLineLength truncates long lines in summaries
+	maxSummaryLineLength = 400
+)
+
+// errorLineRe matches lines summaries call out as errors.
+var errorLineRe = regexp.MustCompile(`(?i)\b(error|errors|fail|failed|failure|fatal|panic|exception|traceback)\b`)
+
+// ResolveOversizeMode folds the --force flag into the oversize mode: --force
+// without an explicit --oversize mode inserts the output as is, whatever its
+// size.
+func ResolveOversizeMode(force bool, oversize string) string {
+	if force && (oversize == "" || oversize == OversizeAsk) {
+		return OversizeForce
+	}
+	return oversize
+}
+
+// ValidateOversizeMode checks an --oversize value. Empty means ask.
+func ValidateOversizeMode(mode string) error {
+	if mode == "" {
+		return nil
+	}
+	for _, m := range OversizeModes {
+		if m == mode {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid --oversize mode: %s (must be one of %s)", mode, strings.Join(OversizeModes, ", "))
+}
+
+// chooseOversizeMode returns mode, or asks when it is ask or empty.
+func chooseOversizeMode(mode string, outputSize int64, limit int64) string {
+	fmt.Fprintf(os.Stderr, "\n⚠️  Output (%.2f MB) exceeds the %.2f MB limit.\n",
+		float64(outputSize)/1024/1024, float64(limit)/1024/1024)
+	if mode != "" && mode != OversizeAsk {
+		fmt.Fprintf(os.Stderr, "Using --oversize %s\n", mode)
+		return mode
+	}
+
+	fmt.Fprintln(os.Stderr, "  [c] Split into linked messages")
+	fmt.Fprintln(os.Stderr, "  [s] Summarize (head, tail, error lines)")
+	fmt.Fprintln(os.Stderr, "  [a] Save as an attachment and insert a short message")
+	fmt.Fprintln(os.Stderr, "  [f] Insert the full output anyway")
+	fmt.Fprint(os.Stderr, "Choose [c/s/a/f/N] ")
+
+	reader := bufio.NewReader(os.Stdin)
+	input, _ := reader.ReadString('\n')
+	switch strings.TrimSpace(strings.ToLower(input)) {
+	case "c", "chunk":
+		return OversizeChunk
+	case "s", "summary":
+		return OversizeSummary
+	case "a", "attach":
+		return OversizeAttach
+	case "f", "force", "y", "yes":
+		return OversizeForce
+	}
+	return OversizeAbort
+}
+
+// bridgeOutput is an output on its way to the chat, with what produced it.
+type bridgeOutput struct {
+	Command  string
+	Duration time.Duration
+	DBName   string
+	Format   string
+	ExitCode int
+	Raw      string
+}
+
+// header renders the title and property table of a message.
+func (o bridgeOutput) header(title string) string {
+	return output.FormatBridgeHeader(title, o.Command, o.Duration, o.DBName, o.Format, o.ExitCode)
+}
+
+// lang is the code block language of the full output.
+func (o bridgeOutput) lang() string {
+	if strings.ToLower(o.Format) == "json" {
+		return "json"
+	}
+	return "text"
+}
+
+// chunkMessages splits the output into messages that each fit the limit.
+// Only the first carries the property table; each names its part. A limit
+// too small for the header plus minChunkBudget is an error rather than
+// chunks over the limit.
+func chunkMessages(o bridgeOutput, limit int64) ([]string, error) {
+	overhead := len(o.header("GSC CLI Output (part 9999 of 9999)")) + len(o.Command) + 2*longestBacktickRun(o.Raw) + 256
+	budget := int(limit) - overhead
+	if budget < minChunkBudget {
+		return nil, fmt.Errorf("the %d byte limit is too small to split the output into chunks; use --oversize summary or attach", limit)
+	}
+
+	pieces := splitOutput(o.Raw, budget)
+	messages := make([]string, len(pieces))
+	for i, piece := range pieces {
+		var sb strings.Builder
+		title := fmt.Sprintf("GSC CLI Output (part %d of %d)", i+1, len(pieces))
+		if i == 0 {
+			sb.WriteString(o.header(title))
+		} else {
+			sb.WriteString(fmt.Sprintf("## %s\n\n`%s`\n\n", title, o.Command))
+		}
+		writeCodeBlock(&sb, o.lang(), piece)
+		if i < len(pieces)-1 {
+			sb.WriteString(fmt.Sprintf("\n_Continued in part %d of %d._\n", i+2, len(pieces)))
+		}
+		messages[i] = sb.String()
+	}
+	return messages, nil
+}
+
+// splitOutput splits text at line boundaries into pieces of at most budget
+// bytes. Lines longer than the budget are split on rune boundaries.
+func splitOutput(text string, budget int) []string {
+	var pieces []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			pieces = append(pieces, current.String())
+			current.Reset()
+		}
+	}
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if current.Len()+len(line) > budget {
+			flush()
+		}
+		for len(line) > budget {
+			cut := budget
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			pieces = append(pieces, line[:cut])
+			line = line[cut:]
+		}
+		current.WriteString(line)
+	}
+	flush()
+	if len(pieces) == 0 {
+		pieces = []string{""}
+	}
+	return pieces
+}
+
+// summaryLimits caps each section of a summary.
+type summaryLimits struct {
+	Head, Tail, ErrorLines, Files int
+}
+
+// summaryMessage renders a summary of the output that fits the limit,
+// halving the sections until it does and cutting the smallest one if that is
+// not enough. note opens the message.
+func summaryMessage(o bridgeOutput, limit int64, title string, note string, limits summaryLimits) (string, error) {
+	lines := strings.Split(strings.TrimSuffix(o.Raw, "\n"), "\n")
+	grep := grepSummaryOf(o)
+	for {
+		message := renderSummary(o, lines, grep, title, note, limits)
+		if int64(len(message)) <= limit {
+			return message, nil
+		}
+		if limits.Head <= 5 {
+			return truncateSummary(message, limit)
+		}
+		limits = summaryLimits{limits.Head / 2, limits.Tail / 2, limits.ErrorLines / 2, limits.Files / 2}
+	}
+}
+
+// summaryTruncatedNote ends a summary that was cut to fit the limit.
+const summaryTruncatedNote = "\n_Summary truncated to fit the size limit._\n"
+
+// truncateSummary cuts a summary to the limit on a rune boundary, closing an
+// open code block. It refuses limits with no room for the closing fence and
+// note.
+func truncateSummary(message string, limit int64) (string, error) {
+	fence := max(3, longestBacktickRun(message))
+	reserve := int64(len("\n\n") + fence + len(summaryTruncatedNote))
+	if limit <= reserve {
+		return "", fmt.Errorf("the %d byte limit is too small for a summary; use --oversize attach or force", limit)
+	}
+	cut := int(limit - reserve)
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	kept := message[:cut]
+	if fence := openFence(kept); fence != "" {
+		kept += "\n" + fence + "\n"
+	}
+	return kept + summaryTruncatedNote, nil
+}
+
+// openFence returns the fence of the code block left open at the end of text,
+// or "" when every block is closed. A block is closed by a line holding only
+// backticks, at least as many as its opening fence.
+func openFence(text string) string {
+	fence := ""
+	for _, line := range strings.Split(text, "\n") {
+		run := len(line) - len(strings.TrimLeft(line, "`"))
+		if run < 3 {
+			continue
+		}
+		if fence == "" {
+			fence = line[:run]
+		} else if run >= len(fence) && run == len(line) {
+			fence = ""
+		}
+	}
+	return fence
+}
+
+// renderSummary renders one summary with the given section sizes.
+func renderSummary(o bridgeOutput, lines []string, grep *search.GrepSummary, title string, note string, limits summaryLimits) string {
+	var errorLines []int
+	for i, line := range lines {
+		if errorLineRe.MatchString(line) {
+			errorLines = append(errorLines, i)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(o.header(title))
+	sb.WriteString(fmt.Sprintf("> %s\n\n", note))
+	sb.WriteString(fmt.Sprintf("**Output:** %d lines, %.2f MB, %d error lines\n\n", len(lines), float64(len(o.Raw))/1024/1024, len(errorLines)))
+
+	if grep != nil {
+		sb.WriteString(fmt.Sprintf("**Search:** %d matches in %d files (%d analyzed)", grep.TotalMatches, grep.TotalFiles, grep.AnalyzedFiles))
+		if grep.IsTruncated {
+			sb.WriteString(", truncated")
+		}
+		sb.WriteString("\n\n")
+		files := append([]search.FileSummary(nil), grep.Files...)
+		sort.SliceStable(files, func(i, j int) bool { return files[i].MatchCount > files[j].MatchCount })
+		if len(files) > 0 {
+			sb.WriteString("| File | Matches | Analyzed |\n| :--- | ---: | :---: |\n")
+			for i, f := range files {
+				if i == limits.Files {
+					sb.WriteString(fmt.Sprintf("\n_…and %d more files_\n", len(files)-i))
+					break
+				}
+				analyzed := ""
+				if f.Analyzed {
+					analyzed = "✓"
+				}
+				sb.WriteString(fmt.Sprintf("| `%s` | %d | %s |\n", f.FilePath, f.MatchCount, analyzed))
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	if len(lines) <= limits.Head+limits.Tail {
+		sb.WriteString("### Output\n\n")
+		writeCodeBlock(&sb, "text", joinLines(lines))
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("### First %d lines\n\n", limits.Head))
+	writeCodeBlock(&sb, "text", joinLines(lines[:limits.Head]))
+
+	if len(errorLines) > 0 {
+		sb.WriteString(fmt.Sprintf("\n### Error lines (%d)\n\n", len(errorLines)))
+		writeCodeBlock(&sb, "text", errorSections(lines, errorLines, limits.ErrorLines))
+	}
+
+	sb.WriteString(fmt.Sprintf("\n### Last %d lines\n\n", limits.Tail))
+	writeCodeBlock(&sb, "text", joinLines(lines[len(lines)-limits.Tail:]))
+	return sb.String()
+}
+
+// errorSections renders error lines with one line of context and their
+// line numbers, up to max lines.
+func errorSections(lines []string, errorLines []int, max int) string {
+	var sb strings.Builder
+	written, last := 0, -2
+	for _, n := range errorLines {
+		for i := n - 1; i <= n+1; i++ {
+			if i < 0 || i >= len(lines) || i <= last {
+				continue
+			}
+			if written == max {
+				sb.WriteString("…\n")
+				return sb.String()
+			}
+			if last >= 0 && i > last+1 {
+				sb.WriteString("…\n")
+			}
+			sb.WriteString(fmt.Sprintf("%6d | %s\n", i+1, truncateLine(lines[i])))
+			written++
+			last = i
+		}
+	}
+	return sb.String()
+}
+
+// grepSummaryOf returns the file summary of 'gsc grep --format json' output.
+func grepSummaryOf(o bridgeOutput) *search.GrepSummary {
+	if o.lang() != "json" {
+		return nil
+	}
+	var response struct {
+		Summary *search.GrepSummary `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(o.Raw), &response); err != nil || response.Summary == nil {
+		return nil
+	}
+	if response.Summary.TotalFiles == 0 && len(response.Summary.Files) == 0 {
+		return nil
+	}
+	return response.Summary
+}
+
+// attachmentMessage renders the compact message that references a saved
+// attachment.
+func attachmentMessage(o bridgeOutput, limit int64, path string) (string, error) {
+	note := fmt.Sprintf("The full output (%.2f MB) was saved to `%s`.", float64(len(o.Raw))/1024/1024, path)
+	return summaryMessage(o, limit, "GSC CLI Output (attachment)", note, summaryLimits{Head: 20, Tail: 20, ErrorLines: 20, Files: 20})
+}
+
+// writeAttachment saves the full output under the home of the chat's active
+// contract, or under the bridge attachments directory when the chat has none.
+func writeAttachment(h *Handshake, o bridgeOutput) (string, error) {
+	gscHome := h.GSCHome
+	if gscHome == "" {
+		var err error
+		if gscHome, err = settings.GetGSCHome(false); err != nil {
+			return "", fmt.Errorf("failed to resolve GSC_HOME: %w", err)
+		}
+	}
+
+	dir := filepath.Join(gscHome, settings.BridgeAttachmentsRelPath, fmt.Sprintf("chat-%d", h.ChatID))
+	if uuid := activeContractForChat(gscHome, h.ChatID); uuid != "" {
+		dir = filepath.Join(gscHome, settings.HomesRelPath, uuid, settings.AttachmentsDirName)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create attachments directory: %w", err)
+	}
+
+	ext := ".txt"
+	if o.lang() == "json" {
+		ext = ".json"
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s%s", time.Now().Format("20060102-150405"), h.Code, ext))
+	if err := os.WriteFile(path, []byte(o.Raw), 0644); err != nil {
+		return "", fmt.Errorf("failed to write attachment: %w", err)
+	}
+	return path, nil
+}
+
+// activeContractForChat returns the UUID of the active contract bound to a
+// chat, if there is exactly one.
+func activeContractForChat(gscHome string, chatID int64) string {
+	files, _ := filepath.Glob(filepath.Join(gscHome, settings.ContractsRelPath, "*.json"))
+	found := ""
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		var c struct {
+			UUID      string
+			Status    typescontract.ContractStatus
+			ExpiresAt time.Time
+			ChatID    int64 `json:"chat_id"`
+		}
+		if json.Unmarshal(data, &c) != nil || c.ChatID != chatID {
+			continue
+		}
+		if c.Status != typescontract.ContractActive || time.Now().After(c.ExpiresAt) {
+			continue
+		}
+		if found != "" {
+			return ""
+		}
+		found = c.UUID
+	}
+	return found
+}
+
+// writeCodeBlock writes text as a fenced code block. The fence is longer than
+// any run of backticks in the text, so fences inside it stay content.
+func writeCodeBlock(sb *strings.Builder, lang string, text string) {
+	fence := strings.Repeat("`", max(3, longestBacktickRun(text)+1))
+	sb.WriteString(fence + lang + "\n")
+	sb.WriteString(text)
+	if !strings.HasSuffix(text, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fence + "\n")
+}
+
+// longestBacktickRun returns the length of the longest run of backticks in text.
+func longestBacktickRun(text string) int {
+	longest, run := 0, 0
+	for i := 0; i < len(text); i++ {
+		if text[i] != '`' {
+			run = 0
+			continue
+		}
+		run++
+		longest = max(longest, run)
+	}
+	return longest
+}
+
+// joinLines joins lines, truncating long ones.
+func joinLines(lines []string) string {
+	var sb strings.Builder
+	for _, line := range lines {
+		sb.WriteString(truncateLine(line))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// truncateLine shortens a line to maxSummaryLineLength bytes.
+func truncateLine(line string) string {
+	if len(line) <= maxSummaryLineLength {
+		return line
+	}
+	cut := maxSummaryLineLength
+	for cut > 0 && !utf8.RuneStart(line[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s… (+%d bytes)", line[:cut], len(line)-cut)
+}
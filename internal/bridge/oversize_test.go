@@ -0,0 +1,279 @@
+/**
+ * Component: CLI Bridge Oversized Output Tests
+ * Block-UUID: 404c2107-486d-4cc6-8a54-0b8b85fb0917
+ * Parent-UUID: 058df437-a549-4946-8429-96ebca0cba42
+ * Version: 1.5.0
+ * Description: Tests that chunks fit the limit (including limits under 16KB) and reassemble to the original output, that summaries fit the limit (cut or refused when halving is not enough) and call out error lines and the gsc grep file summary, that code blocks around output with its own fences stay closed, that attachments land in the home of the chat's active contract, that --oversize values are validated and --force folds into them, and that a failed chain insert leaves the chat untouched.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0), agent (v1.3.0), agent (v1.4.0), agent (v1.5.0)
+ */
+
+package bridge
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gitsense/gsc-cli/pkg/settings"
+)
+
+// largeOutput builds n lines with an error every 1000 lines.
+func largeOutput(n int) string {
+	var sb strings.Builder
+	for i := 1; i <= n; i++ {
+		if i%1000 == 0 {
+			fmt.Fprintf(&sb, "FAIL: TestCase%d failed\n", i)
+			continue
+		}
+		fmt.Fprintf(&sb, "ok  line %d of the build log with some padding text\n", i)
+	}
+	return sb.String()
+}
+
+func TestChunkMessagesFitAndReassemble(t *testing.T) {
+	raw := largeOutput(20000) + strings.Repeat("é", 40000) + "\n"
+	out := bridgeOutput{Command: "go test ./...", Format: "text", Raw: raw}
+	limit := int64(64 * 1024)
+
+	messages, err := chunkMessages(out, limit)
+	if err != nil || len(messages) < 10 {
+		t.Fatalf("got %d chunks, %v", len(messages), err)
+	}
+
+	for i, m := range messages {
+		if int64(len(m)) > limit {
+			t.Errorf("chunk %d is %d bytes, over %d", i+1, len(m), limit)
+		}
+		if !strings.Contains(m, fmt.Sprintf("(part %d of %d)", i+1, len(messages))) {
+			t.Errorf("chunk %d does not name its part", i+1)
+		}
+	}
+
+	// Limits under 16KB still hold; ones with no room for a chunk are refused
+	small, err := chunkMessages(out, 4*1024)
+	if err != nil {
+		t.Fatalf("4KB limit: %v", err)
+	}
+	for i, m := range small {
+		if len(m) > 4*1024 {
+			t.Fatalf("chunk %d is %d bytes, over the 4KB limit", i+1, len(m))
+		}
+	}
+	if _, err := chunkMessages(out, 1024); err == nil {
+		t.Fatal("1KB limit chunked")
+	}
+
+	// Pieces split on lines, and on rune boundaries within long lines
+	pieces := splitOutput(raw, 16*1024)
+	for i, piece := range pieces {
+		if len(piece) > 16*1024 || !utf8.ValidString(piece) {
+			t.Fatalf("piece %d is %d bytes or not valid UTF-8", i, len(piece))
+		}
+	}
+	if strings.Join(pieces, "") != raw {
+		t.Fatal("pieces do not reassemble to the original output")
+	}
+	if !strings.Contains(messages[0], "| **Command** | `go test ./...` |") {
+		t.Error("first chunk lacks the property table")
+	}
+}
+
+func TestSummaryMessage(t *testing.T) {
+	raw := largeOutput(50000)
+	out := bridgeOutput{Command: "make", Format: "text", Raw: raw, ExitCode: 2}
+	limit := int64(32 * 1024)
+
+	message, err := summaryMessage(out, limit, "GSC CLI Output (summary)", "summarized", summaryLimits{Head: 100, Tail: 100, ErrorLines: 200, Files: 50})
+	if err != nil {
+		t.Fatalf("summary: %v", err)
+	}
+	if int64(len(message)) > limit {
+		t.Fatalf("summary is %d bytes, over %d", len(message), limit)
+	}
+	for _, want := range []string{"**Output:** 50000 lines", "50 error lines", "### Error lines (50)", "  1000 | FAIL: TestCase1000 failed", "### Last", "line 49999 of"} {
+		if !strings.Contains(message, want) {
+			t.Errorf("summary lacks %q", want)
+		}
+	}
+
+	// gsc grep --format json gets its file summary
+	files := []map[string]interface{}{}
+	for i := 0; i < 3000; i++ {
+		files = append(files, map[string]interface{}{"file_path": fmt.Sprintf("pkg/f%04d.go", i), "analyzed": i%2 == 0, "match_count": i % 7})
+	}
+	grep, _ := json.MarshalIndent(map[string]interface{}{
+		"context": map[string]interface{}{"pattern": "TODO"},
+		"summary": map[string]interface{}{"total_matches": 9000, "total_files": 3000, "analyzed_files": 1500, "files": files},
+	}, "", "  ")
+	out = bridgeOutput{Command: "gsc grep TODO --format json", Format: "json", Raw: string(grep)}
+	message, _ = summaryMessage(out, limit, "GSC CLI Output (summary)", "summarized", summaryLimits{Head: 100, Tail: 100, ErrorLines: 200, Files: 50})
+	if !strings.Contains(message, "**Search:** 9000 matches in 3000 files (1500 analyzed)") || !strings.Contains(message, "| `pkg/f0006.go` | 6 | ✓ |") {
+		t.Fatalf("grep summary missing:\n%s", message[:2000])
+	}
+
+	// Limits below the smallest summary cut it; ones with no room are refused
+	out = bridgeOutput{Command: "make", Format: "text", Raw: raw, ExitCode: 2}
+	for _, small := range []int64{1024, 512} {
+		message, err := summaryMessage(out, small, "GSC CLI Output (summary)", "summarized", summaryLimits{Head: 100, Tail: 100, ErrorLines: 200, Files: 50})
+		if err != nil {
+			t.Fatalf("%d byte limit: %v", small, err)
+		}
+		if int64(len(message)) > small || openFence(message) != "" {
+			t.Fatalf("%d byte limit: summary is %d bytes or not closed:\n%s", small, len(message), message)
+		}
+	}
+	if message, _ := summaryMessage(out, 512, "GSC CLI Output (summary)", "summarized", summaryLimits{Head: 100, Tail: 100, ErrorLines: 200, Files: 50}); !strings.HasSuffix(message, summaryTruncatedNote) {
+		t.Fatal("512 byte summary was not marked as truncated")
+	}
+	if _, err := summaryMessage(out, 32, "GSC CLI Output (summary)", "summarized", summaryLimits{Head: 100, Tail: 100, ErrorLines: 200, Files: 50}); err == nil {
+		t.Fatal("32 byte limit summarized")
+	}
+}
+
+func TestCodeBlocksKeepEmbeddedFences(t *testing.T) {
+	// Output that is itself markdown, such as a README, holds its own fences
+	var raw strings.Builder
+	for i := 0; i < 400; i++ {
+		fmt.Fprintf(&raw, "## Example %d\n\n```go\nfmt.Println(%d)\n```\n\n", i, i)
+	}
+	raw.WriteString("FAIL: ```` four backticks\n")
+	out := bridgeOutput{Command: "cat README.md", Format: "text", Raw: raw.String(), ExitCode: 1}
+
+	var sb strings.Builder
+	writeCodeBlock(&sb, "text", out.Raw)
+	block := sb.String()
+	if !strings.HasPrefix(block, "`````text\n") || !strings.HasSuffix(block, "\n`````\n") {
+		t.Fatalf("fence is not longer than the embedded ones:\n%s", block[:40])
+	}
+	if openFence(block) != "" {
+		t.Fatal("code block left open")
+	}
+
+	messages, err := chunkMessages(out, 4*1024)
+	if err != nil {
+		t.Fatalf("chunk: %v", err)
+	}
+	for i, m := range messages {
+		if len(m) > 4*1024 || openFence(m) != "" {
+			t.Fatalf("chunk %d is %d bytes or leaves a code block open", i+1, len(m))
+		}
+	}
+
+	for _, limit := range []int64{1024, 512} {
+		message, err := summaryMessage(out, limit, "GSC CLI Output (summary)", "summarized", summaryLimits{Head: 100, Tail: 100, ErrorLines: 200, Files: 50})
+		if err != nil {
+			t.Fatalf("%d byte limit: %v", limit, err)
+		}
+		if int64(len(message)) > limit || openFence(message) != "" {
+			t.Fatalf("%d byte limit: summary is %d bytes or leaves a code block open:\n%s", limit, len(message), message)
+		}
+	}
+}
+
+func TestWriteAttachmentUsesContractHome(t *testing.T) {
+	gscHome := t.TempDir()
+	contracts := filepath.Join(gscHome, settings.ContractsRelPath)
+	os.MkdirAll(contracts, 0755)
+	contract := fmt.Sprintf(`{"UUID":"c-1","Status":"active","ExpiresAt":%q,"chat_id":42}`, time.Now().Add(time.Hour).Format(time.RFC3339))
+	os.WriteFile(filepath.Join(contracts, "c-1.json"), []byte(contract), 0644)
+
+	out := bridgeOutput{Command: "cat big.log", Format: "text", Raw: largeOutput(100)}
+	path, err := writeAttachment(&Handshake{GSCHome: gscHome, ChatID: 42, Code: "123456"}, out)
+	if err != nil {
+		t.Fatalf("attach: %v", err)
+	}
+	if want := filepath.Join(gscHome, settings.HomesRelPath, "c-1", settings.AttachmentsDirName); filepath.Dir(path) != want {
+		t.Fatalf("path = %s, want under %s", path, want)
+	}
+	if data, _ := os.ReadFile(path); string(data) != out.Raw {
+		t.Fatal("attachment does not hold the full output")
+	}
+
+	// A chat without an active contract falls back to the bridge directory
+	path, err = writeAttachment(&Handshake{GSCHome: gscHome, ChatID: 7, Code: "654321"}, out)
+	if err != nil || !strings.HasPrefix(path, filepath.Join(gscHome, settings.BridgeAttachmentsRelPath, "chat-7")) {
+		t.Fatalf("fallback path = %s, %v", path, err)
+	}
+	if message, _ := attachmentMessage(out, 1<<20, path); !strings.Contains(message, path) {
+		t.Fatal("attachment message does not reference the file")
+	}
+}
+
+func TestValidateOversizeMode(t *testing.T) {
+	for _, mode := range []string{"", OversizeAttach, OversizeAbort} {
+		if err := ValidateOversizeMode(mode); err != nil {
+			t.Fatalf("%q: %v", mode, err)
+		}
+	}
+	if err := ValidateOversizeMode("truncate"); err == nil {
+		t.Fatal("invalid mode accepted")
+	}
+}
+
+func TestResolveOversizeMode(t *testing.T) {
+	for _, tc := range []struct {
+		force    bool
+		oversize string
+		want     string
+	}{
+		{false, OversizeAsk, OversizeAsk},
+		{true, OversizeAsk, OversizeForce},
+		{true, "", OversizeForce},
+		{true, OversizeChunk, OversizeChunk},
+		{false, OversizeSummary, OversizeSummary},
+	} {
+		if got := ResolveOversizeMode(tc.force, tc.oversize); got != tc.want {
+			t.Errorf("ResolveOversizeMode(%v, %q) = %q, want %q", tc.force, tc.oversize, got, tc.want)
+		}
+	}
+}
+
+func TestInsertChainToChatIsAtomic(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "chat.sqlite3")
+	chatDB, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer chatDB.Close()
+	for _, statement := range []string{
+		`CREATE TABLE chats (id INTEGER PRIMARY KEY, main_model TEXT)`,
+		`CREATE TABLE messages (
+			id INTEGER PRIMARY KEY AUTOINCREMENT, type TEXT, deleted INTEGER, visibility TEXT,
+			chat_id INTEGER, parent_id INTEGER, level INTEGER, model TEXT, real_model TEXT,
+			temperature REAL, role TEXT, message TEXT, hash TEXT, meta TEXT, created_at DATETIME, updated_at DATETIME)`,
+		`CREATE TRIGGER reject_part AFTER INSERT ON messages WHEN NEW.message = 'bad part' BEGIN SELECT RAISE(ABORT, 'rejected'); END`,
+		`INSERT INTO chats (id, main_model) VALUES (1, 'm')`,
+		`INSERT INTO messages (id, type, deleted, visibility, chat_id, parent_id, level, role, message, created_at, updated_at)
+			VALUES (10, 'regular', 0, 'public', 1, 0, 1, 'user', 'run it', '2026-10-18T00:00:00Z', '2026-10-18T00:00:00Z')`,
+	} {
+		if _, err := chatDB.Exec(statement); err != nil {
+			t.Fatalf("schema: %v", err)
+		}
+	}
+	h := &Handshake{ChatID: 1, ParentMessageID: 10, DBPath: dbPath, DefaultVisibility: "public"}
+
+	if ids, err := h.InsertChainToChat([]string{"part 1", "bad part", "part 3"}); err == nil || !strings.Contains(err.Error(), "message 2 of 3") {
+		t.Fatalf("failed chain = %v, %v", ids, err)
+	}
+	var count int
+	if err := chatDB.QueryRow(`SELECT COUNT(*) FROM messages`).Scan(&count); err != nil || count != 1 {
+		t.Fatalf("messages after failed chain = %d, %v", count, err)
+	}
+
+	ids, err := h.InsertChainToChat([]string{"part 1", "part 2"})
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("chain = %v, %v", ids, err)
+	}
+	var parent int64
+	if err := chatDB.QueryRow(`SELECT parent_id FROM messages WHERE id = ?`, ids[1]).Scan(&parent); err != nil || parent != ids[0] {
+		t.Fatalf("part 2 parent = %d, %v", parent, err)
+	}
+}
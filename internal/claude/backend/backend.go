@@ -0,0 +1,284 @@
+/**
+ * Component: Agent Backend Interface
+ * Block-UUID: b916a44f-0f91-45e5-a773-08388bfaf342
+ * Parent-UUID: 5fe05089-14ea-41cd-aaff-9d02f9b616cf
+ * Version: 1.2.0
+ * Description: Defines the AgentBackend interface used by intent-workflow and chat to spawn an agent CLI, configure its tool permissions, and normalize its streamed output (result text, usage, cost), plus the backend registry resolved from GSC_AGENT_BACKEND. Events now carry per-message usage, message IDs, and models so budgets can meter and price a run live.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0)
+ */
+
+package backend
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EnvBackend selects the agent backend when a session does not name one.
+// The value is a backend name, optionally followed by ":" and an argument,
+// for example "claude" or "scripted:/path/to/recordings".
+const EnvBackend = "GSC_AGENT_BACKEND"
+
+// DefaultBackend is used when neither the session nor EnvBackend names one.
+const DefaultBackend = "claude"
+
+// Normalized stream event types. Backends map their own event names onto
+// these; anything else keeps its native type.
+const (
+	EventPing      = "ping"
+	EventSystem    = "system"
+	EventAssistant = "assistant"
+	EventResult    = "result"
+	EventError     = "error"
+)
+
+// AgentBackend runs one agent CLI. Implementations own everything that is
+// specific to the CLI: flags, wrapper scripts, permission files, and the
+// shape of the streamed output.
+type AgentBackend interface {
+	// Name returns the registry name of the backend.
+	Name() string
+
+	// Check reports why the backend cannot run (CLI not installed,
+	// recordings missing), or nil.
+	Check() error
+
+	// Start launches the agent for req. The caller must drain Stdout and
+	// Stderr before calling Wait.
+	Start(req SpawnRequest) (*Process, error)
+
+	// ConfigurePermissions writes whatever the CLI reads to restrict its
+	// tools when run from dir.
+	ConfigurePermissions(dir string, perms Permissions) error
+
+	// ParseEvent normalizes one line of streamed output. It reports false
+	// for lines that are not events (banners, blank lines, invalid JSON).
+	// Cost and usage extraction happen here, on the result event.
+	ParseEvent(line string) (Event, bool)
+}
+
+// Permissions is the runtime-neutral tool policy for an agent run.
+type Permissions struct {
+	// Tools the agent may use (Read, Write, Bash).
+	Tools []string
+	// Commands the shell tool may run, as prefixes ("gsc:*", "head").
+	Commands []string
+	// SkipPrompts runs without interactive approval of tool calls.
+	SkipPrompts bool
+}
+
+// SpawnRequest describes one agent run.
+type SpawnRequest struct {
+	// Label names the kind of run (discovery, change, correction, chat);
+	// the scripted backend picks its recording by label.
+	Label     string
+	Turn      int
+	SessionID string
+
+	// Dir is the working directory of the agent.
+	Dir              string
+	Prompt           string
+	SystemPromptFile string
+	Model            string
+	AddDirs          []string
+	Permissions      Permissions
+
+	// ResumeSessionID continues an earlier agent conversation.
+	ResumeSessionID string
+	ThinkingBudget  int
+	// FileReadMaxTokens caps a single file read, when the CLI supports it.
+	FileReadMaxTokens int
+
+	// PathCommands must resolve on PATH; their directories are prepended to
+	// the agent's PATH so its shell tool finds them.
+	PathCommands []string
+	// ScriptName, when set, asks CLI backends to run through a wrapper
+	// script with that name in Dir (Unix only) so the exact invocation can
+	// be inspected and re-run by hand.
+	ScriptName string
+}
+
+// Process is a running agent.
+type Process struct {
+	PID     int // 0 when the backend does not run an OS process
+	Command string
+	Stdout  io.Reader
+	Stderr  io.Reader
+	wait    func() (int, error)
+}
+
+// Wait blocks until the agent exits and returns its exit code.
+func (p *Process) Wait() (int, error) {
+	if p.wait == nil {
+		return 0, nil
+	}
+	return p.wait()
+}
+
+// Usage is token usage reported on a result event, or so far for one
+// assistant message.
+type Usage struct {
+	InputTokens         int
+	OutputTokens        int
+	CacheCreationTokens int
+	CacheReadTokens     int
+}
+
+// Event is one normalized line of agent output.
+type Event struct {
+	Type string
+	Raw  string
+
+	// Text is the final text of an assistant message or result event.
+	Text    string
+	IsError bool
+
+	// ErrorType and ErrorMessage are set on error events.
+	ErrorType    string
+	ErrorMessage string
+
+	// MessageID identifies the model message an assistant event belongs
+	// to; one message may be streamed as several events.
+	MessageID string
+	// Model is the model that produced an assistant message, when known.
+	Model string
+
+	SessionID  string
+	DurationMS int64
+	CostUSD    float64
+	Usage      Usage
+}
+
+// Factory builds a backend from the argument after ":" in its spec.
+type Factory func(arg string) (AgentBackend, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]Factory{
+		"claude": func(string) (AgentBackend, error) { return NewClaudeBackend(), nil },
+		"scripted": func(arg string) (AgentBackend, error) {
+			if arg == "" {
+				return nil, fmt.Errorf("scripted backend needs a recordings directory (scripted:/path)")
+			}
+			return NewScriptedBackend(arg), nil
+		},
+	}
+)
+
+// Register adds or replaces a backend factory.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = factory
+}
+
+// Names returns the registered backend names.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultSpec returns the backend spec from EnvBackend, or DefaultBackend.
+func DefaultSpec() string {
+	if spec := strings.TrimSpace(os.Getenv(EnvBackend)); spec != "" {
+		return spec
+	}
+	return DefaultBackend
+}
+
+// Resolve builds the backend named by spec ("name" or "name:arg"). An empty
+// spec resolves DefaultSpec.
+func Resolve(spec string) (AgentBackend, error) {
+	if spec == "" {
+		spec = DefaultSpec()
+	}
+	name, arg, _ := strings.Cut(spec, ":")
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown agent backend %q (available: %s)", name, strings.Join(Names(), ", "))
+	}
+	return factory(arg)
+}
+
+// Run starts req, collects its stdout, and waits for it to exit. A non-zero
+// exit is an error that includes the captured stderr.
+func Run(b AgentBackend, req SpawnRequest) ([]byte, error) {
+	proc, err := b.Start(req)
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		io.Copy(&stderr, proc.Stderr)
+		close(done)
+	}()
+	output, readErr := io.ReadAll(proc.Stdout)
+	<-done
+	code, waitErr := proc.Wait()
+	if readErr != nil {
+		return output, readErr
+	}
+	if waitErr != nil || code != 0 {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" && waitErr != nil {
+			msg = waitErr.Error()
+		}
+		return output, fmt.Errorf("%s exited with code %d: %s", b.Name(), code, msg)
+	}
+	return output, nil
+}
+
+// Events parses every event line in output.
+func Events(b AgentBackend, output []byte) []Event {
+	var events []Event
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		if event, ok := b.ParseEvent(scanner.Text()); ok {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// ResultText returns the final text the agent produced: the result event's
+// text, or the last assistant text when there is no result text.
+func ResultText(b AgentBackend, output []byte) (string, error) {
+	var text string
+	for _, event := range Events(b, output) {
+		if (event.Type == EventAssistant || event.Type == EventResult) && event.Text != "" {
+			text = event.Text
+		}
+	}
+	if text == "" {
+		return "", fmt.Errorf("no text content found in %s output", b.Name())
+	}
+	return text, nil
+}
+
+// Cost returns the total cost reported by the result event in output, or 0.
+func Cost(b AgentBackend, output []byte) float64 {
+	for _, event := range Events(b, output) {
+		if event.Type == EventResult {
+			return event.CostUSD
+		}
+	}
+	return 0
+}
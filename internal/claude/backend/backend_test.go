@@ -0,0 +1,97 @@
+/**
+ * Component: Agent Backend Tests
+ * Block-UUID: 8a2d6f14-3c71-4e95-a0b8-5e9c7d1f2b36
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests Claude stream-json event parsing, backend spec resolution, and per-attempt recording selection in the scripted backend.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package backend
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseClaudeEvent(t *testing.T) {
+	b := NewClaudeBackend()
+
+	event, ok := b.ParseEvent(`{"type":"result","is_error":false,"duration_ms":1200,"total_cost_usd":0.42,"session_id":"s1","result":"done","usage":{"input_tokens":10,"output_tokens":5}}`)
+	if !ok || event.Type != EventResult || event.CostUSD != 0.42 || event.Text != "done" || event.SessionID != "s1" || event.Usage.InputTokens != 10 {
+		t.Fatalf("result event = %+v", event)
+	}
+
+	event, ok = b.ParseEvent(`{"type":"result","usage":{"total_cost_usd":0.07}}`)
+	if !ok || event.CostUSD != 0.07 {
+		t.Fatalf("legacy cost = %+v", event)
+	}
+
+	event, ok = b.ParseEvent(`{"type":"assistant","message":{"content":[{"type":"text","text":"hello"}]}}`)
+	if !ok || event.Type != EventAssistant || event.Text != "hello" {
+		t.Fatalf("assistant event = %+v", event)
+	}
+
+	event, ok = b.ParseEvent(`{"type":"error","error":{"type":"overloaded_error","message":"busy"}}`)
+	if !ok || event.Type != EventError || event.ErrorType != "overloaded_error" || event.ErrorMessage != "busy" {
+		t.Fatalf("error event = %+v", event)
+	}
+
+	if _, ok := b.ParseEvent("Starting Claude..."); ok {
+		t.Fatal("banner line parsed as event")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	agent, err := Resolve("scripted:" + dir)
+	if err != nil || agent.Name() != "scripted" {
+		t.Fatalf("Resolve scripted = %v, %v", agent, err)
+	}
+	if _, err := Resolve("scripted"); err == nil {
+		t.Fatal("scripted without a directory resolved")
+	}
+	if _, err := Resolve("nope"); err == nil {
+		t.Fatal("unknown backend resolved")
+	}
+
+	t.Setenv(EnvBackend, "")
+	if agent, err := Resolve(""); err != nil || agent.Name() != DefaultBackend {
+		t.Fatalf("Resolve default = %v, %v", agent, err)
+	}
+}
+
+func TestScriptedBackendPerAttemptRecordings(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, text string) {
+		line := `{"type":"result","total_cost_usd":0.1,"result":"` + text + `"}` + "\n"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(line), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("correction-1.ndjson", "first")
+	write("correction.ndjson", "fallback")
+
+	s := NewScriptedBackend(dir)
+	for _, want := range []string{"first", "fallback"} {
+		output, err := Run(s, SpawnRequest{Label: "correction"})
+		if err != nil {
+			t.Fatalf("Run: %v", err)
+		}
+		if text, err := ResultText(s, output); err != nil || text != want {
+			t.Fatalf("ResultText = %q, %v, want %q", text, err, want)
+		}
+		if cost := Cost(s, output); cost != 0.1 {
+			t.Fatalf("Cost = %v", cost)
+		}
+	}
+	if _, err := Run(s, SpawnRequest{Label: "discovery"}); err == nil {
+		t.Fatal("missing recording did not fail")
+	}
+	if n := len(s.Requests()); n != 3 {
+		t.Fatalf("requests = %d, want 3", n)
+	}
+}
@@ -0,0 +1,349 @@
+/**
+ * Component: Claude Code Agent Backend
+ * Block-UUID: 9754aa76-7980-4cc7-9017-9fdf796d1062
+ * Parent-UUID: 7a5a2ba9-c7de-43b9-bf5b-4bf21be36f33
+ * Version: 1.2.0
+ * Description: AgentBackend for the Claude Code CLI: builds claude -p stream-json invocations (directly or through a bash wrapper that forwards SIGTERM), writes .claude/settings.json permissions, and parses stream-json result, assistant, and error events. Assistant events now report the message ID, model, and running token usage.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0)
+ */
+
+package backend
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// claudeFileReadEnv caps a single Read tool result in Claude Code.
+const claudeFileReadEnv = "CLAUDE_CODE_FILE_READ_MAX_OUTPUT_TOKENS"
+
+// ClaudeBackend runs the claude CLI with --output-format stream-json.
+type ClaudeBackend struct {
+	// Binary is the CLI to run; "claude" when empty.
+	Binary string
+}
+
+// NewClaudeBackend returns a backend that runs claude from PATH.
+func NewClaudeBackend() *ClaudeBackend {
+	return &ClaudeBackend{Binary: "claude"}
+}
+
+// Name implements AgentBackend.
+func (c *ClaudeBackend) Name() string {
+	return "claude"
+}
+
+// Check implements AgentBackend.
+func (c *ClaudeBackend) Check() error {
+	if _, err := exec.LookPath(c.binary()); err != nil {
+		return fmt.Errorf("claude CLI not found in PATH. Please install Claude Code CLI first")
+	}
+	return nil
+}
+
+func (c *ClaudeBackend) binary() string {
+	if c.Binary == "" {
+		return "claude"
+	}
+	return c.Binary
+}
+
+// args returns the CLI flags for req, without the prompt.
+func (c *ClaudeBackend) args(req SpawnRequest) []string {
+	args := []string{"--verbose", "--include-partial-messages", "--output-format", "stream-json"}
+	if len(req.Permissions.Tools) > 0 {
+		args = append(args, "--allowedTools", strings.Join(req.Permissions.Tools, ","))
+	}
+	if req.Permissions.SkipPrompts {
+		args = append(args, "--dangerously-skip-permissions")
+	}
+	if req.SystemPromptFile != "" {
+		args = append(args, "--append-system-prompt-file", req.SystemPromptFile)
+	}
+	for _, dir := range req.AddDirs {
+		args = append(args, "--add-dir", dir)
+	}
+	if req.Model != "" {
+		args = append(args, "--model", req.Model)
+	}
+	if req.ResumeSessionID != "" {
+		args = append(args, "--resume", req.ResumeSessionID)
+	}
+	if req.ThinkingBudget > 0 {
+		args = append(args, "--thinking", strconv.Itoa(req.ThinkingBudget))
+	}
+	return args
+}
+
+// pathDirs resolves req.PathCommands to the directories holding them.
+func pathDirs(req SpawnRequest) ([]string, error) {
+	var dirs []string
+	for _, name := range req.PathCommands {
+		path, err := exec.LookPath(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s not found in PATH: %w", name, err)
+		}
+		dirs = append(dirs, filepath.Dir(path))
+	}
+	return dirs, nil
+}
+
+// Start implements AgentBackend. With a ScriptName on Unix, the invocation
+// is written to a bash script that passes the prompt through a heredoc and
+// forwards SIGTERM to claude so a stopped turn shuts down cleanly.
+func (c *ClaudeBackend) Start(req SpawnRequest) (*Process, error) {
+	dirs, err := pathDirs(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var cmd *exec.Cmd
+	if req.ScriptName != "" && runtime.GOOS != "windows" {
+		scriptPath := filepath.Join(req.Dir, req.ScriptName)
+		if err := os.WriteFile(scriptPath, []byte(c.script(req, dirs)), 0755); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", req.ScriptName, err)
+		}
+		cmd = exec.Command("/bin/bash", scriptPath)
+	} else {
+		cmd = exec.Command(c.binary(), append(c.args(req), "-p", req.Prompt)...)
+		env := os.Environ()
+		if len(dirs) > 0 {
+			env = append(env, "PATH="+strings.Join(append(dirs, os.Getenv("PATH")), string(os.PathListSeparator)))
+		}
+		if req.FileReadMaxTokens > 0 && os.Getenv(claudeFileReadEnv) == "" {
+			env = append(env, fmt.Sprintf("%s=%d", claudeFileReadEnv, req.FileReadMaxTokens))
+		}
+		cmd.Env = env
+	}
+	cmd.Dir = req.Dir
+	return startCommand(cmd)
+}
+
+// script renders the bash wrapper for req.
+func (c *ClaudeBackend) script(req SpawnRequest, dirs []string) string {
+	var b strings.Builder
+	b.WriteString("#!/bin/bash\nset -e\n\n")
+	if req.FileReadMaxTokens > 0 {
+		fmt.Fprintf(&b, "# Set Claude Code file reading max tokens (user-overridable)\nexport %s=\"${%s:-%d}\"\n\n", claudeFileReadEnv, claudeFileReadEnv, req.FileReadMaxTokens)
+	}
+	if len(dirs) > 0 {
+		fmt.Fprintf(&b, "export PATH=%s:\"$PATH\"\n\n", shellQuote(strings.Join(dirs, ":")))
+	}
+	b.WriteString("# Trap SIGTERM and forward to child process for graceful shutdown\n")
+	b.WriteString("trap 'echo \"Received SIGTERM, forwarding to Claude process...\"; kill -TERM $PID 2>/dev/null; wait $PID; exit 143' TERM\n\n")
+	for _, name := range req.PathCommands {
+		fmt.Fprintf(&b, "if ! command -v %s &> /dev/null; then\n    echo \"ERROR: %s command not found in PATH\"\n    echo \"Current PATH: $PATH\"\n    exit 1\nfi\n\n", shellQuote(name), name)
+	}
+	b.WriteString("echo \"=== Starting Claude Agent subprocess ===\"\n")
+	b.WriteString("echo \"Working directory: $(pwd)\"\n")
+	if req.Turn > 0 {
+		fmt.Fprintf(&b, "echo \"Turn: %d\"\n", req.Turn)
+	}
+	fmt.Fprintf(&b, "echo %s\n", shellQuote("Turn Type: "+req.Label))
+	if req.SessionID != "" {
+		fmt.Fprintf(&b, "echo %s\n", shellQuote("Session ID: "+req.SessionID))
+	}
+	b.WriteString("echo \"=== Executing Claude command (PID: $$) ===\"\n\n")
+
+	quoted := []string{shellQuote(c.binary())}
+	for _, arg := range c.args(req) {
+		quoted = append(quoted, shellQuote(arg))
+	}
+	b.WriteString(strings.Join(quoted, " \\\n"))
+	b.WriteString(" \\\n-p <<'__GSC_PROMPT_END__' &\n")
+	b.WriteString(req.Prompt)
+	if !strings.HasSuffix(req.Prompt, "\n") {
+		b.WriteString("\n")
+	}
+	b.WriteString("__GSC_PROMPT_END__\n\n")
+	b.WriteString("PID=$!\necho \"Claude process started with PID: $PID\"\nwait $PID\nCLAUDE_EXIT_CODE=$?\n")
+	b.WriteString("echo \"=== Claude subprocess completed with exit code: $CLAUDE_EXIT_CODE ===\"\nexit $CLAUDE_EXIT_CODE\n")
+	return b.String()
+}
+
+// ConfigurePermissions implements AgentBackend by writing
+// .claude/settings.json in dir: each command becomes Bash(cmd) and every
+// other tool is allowed on any path.
+func (c *ClaudeBackend) ConfigurePermissions(dir string, perms Permissions) error {
+	claudeDir := filepath.Join(dir, ".claude")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		return fmt.Errorf("failed to create .claude directory: %w", err)
+	}
+
+	var allow []string
+	for _, command := range perms.Commands {
+		allow = append(allow, "Bash("+command+")")
+	}
+	for _, tool := range perms.Tools {
+		if tool != "Bash" {
+			allow = append(allow, tool+"(*)")
+		}
+	}
+	settings := map[string]interface{}{
+		"permissions": map[string]interface{}{
+			"allow":       allow,
+			"defaultMode": "default",
+		},
+	}
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal permissions: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(claudeDir, "settings.json"), data, 0644); err != nil {
+		return fmt.Errorf("failed to write settings.json: %w", err)
+	}
+	return nil
+}
+
+// claudeStreamEvent covers the stream-json fields gsc reads.
+type claudeStreamEvent struct {
+	Type         string   `json:"type"`
+	Result       string   `json:"result"`
+	IsError      bool     `json:"is_error"`
+	DurationMS   float64  `json:"duration_ms"`
+	TotalCostUSD *float64 `json:"total_cost_usd"`
+	SessionID    string   `json:"session_id"`
+	Usage        struct {
+		InputTokens              int      `json:"input_tokens"`
+		OutputTokens             int      `json:"output_tokens"`
+		CacheCreationInputTokens int      `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int      `json:"cache_read_input_tokens"`
+		TotalCostUSD             *float64 `json:"total_cost_usd"`
+	} `json:"usage"`
+	// Message and Error change shape between event types, so they are
+	// decoded only for the events that use them.
+	Message json.RawMessage `json:"message"`
+	Error   json.RawMessage `json:"error"`
+}
+
+type claudeMessage struct {
+	ID    string `json:"id"`
+	Model string `json:"model"`
+	Usage struct {
+		InputTokens              int `json:"input_tokens"`
+		OutputTokens             int `json:"output_tokens"`
+		CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+		CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+	} `json:"usage"`
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+}
+
+type claudeError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// ParseEvent implements AgentBackend for Claude Code stream-json. The cost
+// is read from the root of the result event, falling back to the legacy
+// location inside usage.
+func (c *ClaudeBackend) ParseEvent(line string) (Event, bool) {
+	return parseClaudeEvent(line)
+}
+
+func parseClaudeEvent(line string) (Event, bool) {
+	trimmed := strings.TrimSpace(line)
+	if !strings.HasPrefix(trimmed, "{") {
+		return Event{}, false
+	}
+	var raw claudeStreamEvent
+	if err := json.Unmarshal([]byte(trimmed), &raw); err != nil || raw.Type == "" {
+		return Event{}, false
+	}
+
+	event := Event{Type: raw.Type, Raw: line, SessionID: raw.SessionID}
+	switch raw.Type {
+	case EventResult:
+		event.Text = raw.Result
+		event.IsError = raw.IsError
+		event.DurationMS = int64(raw.DurationMS)
+		event.Usage = Usage{
+			InputTokens:         raw.Usage.InputTokens,
+			OutputTokens:        raw.Usage.OutputTokens,
+			CacheCreationTokens: raw.Usage.CacheCreationInputTokens,
+			CacheReadTokens:     raw.Usage.CacheReadInputTokens,
+		}
+		switch {
+		case raw.TotalCostUSD != nil:
+			event.CostUSD = *raw.TotalCostUSD
+		case raw.Usage.TotalCostUSD != nil:
+			event.CostUSD = *raw.Usage.TotalCostUSD
+		}
+	case EventAssistant:
+		var message claudeMessage
+		if json.Unmarshal(raw.Message, &message) == nil {
+			event.MessageID = message.ID
+			event.Model = message.Model
+			event.Usage = Usage{
+				InputTokens:         message.Usage.InputTokens,
+				OutputTokens:        message.Usage.OutputTokens,
+				CacheCreationTokens: message.Usage.CacheCreationInputTokens,
+				CacheReadTokens:     message.Usage.CacheReadInputTokens,
+			}
+			for _, block := range message.Content {
+				if block.Type == "text" && block.Text != "" {
+					event.Text = block.Text
+				}
+			}
+		}
+	case EventError:
+		event.IsError = true
+		var detail claudeError
+		if json.Unmarshal(raw.Error, &detail) == nil {
+			event.ErrorType = detail.Type
+			event.ErrorMessage = detail.Message
+		}
+	}
+	return event, true
+}
+
+// startCommand starts cmd with piped stdout and stderr.
+func startCommand(cmd *exec.Cmd) (*Process, error) {
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start subprocess: %w", err)
+	}
+	return &Process{
+		PID:     cmd.Process.Pid,
+		Command: cmd.String(),
+		Stdout:  stdout,
+		Stderr:  stderr,
+		wait: func() (int, error) {
+			err := cmd.Wait()
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				return exitErr.ExitCode(), err
+			}
+			if err != nil {
+				return -1, err
+			}
+			return 0, nil
+		},
+	}, nil
+}
+
+// shellQuote quotes s for bash.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:,=@+%", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
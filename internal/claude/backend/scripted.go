@@ -0,0 +1,116 @@
+/**
+ * Component: Scripted Agent Backend
+ * Block-UUID: 5f0a7c92-4e3b-4d18-9b6a-2c8d1e7f3a05
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Deterministic AgentBackend that replays recorded stream-json files by run label instead of launching an agent, recording every request so the discovery, change, and correction state machine can be tested offline.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package backend
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ScriptedBackend replays recordings from a directory. The n-th run with a
+// given label reads <label>-<n>.ndjson, falling back to <label>.ndjson, so
+// a recording can be shared by every run of a label or scripted per attempt
+// (correction-1.ndjson, correction-2.ndjson). Recordings are parsed as
+// Claude Code stream-json.
+type ScriptedBackend struct {
+	dir string
+
+	mu          sync.Mutex
+	runs        map[string]int
+	requests    []SpawnRequest
+	permissions map[string]Permissions
+}
+
+// NewScriptedBackend replays the recordings in dir.
+func NewScriptedBackend(dir string) *ScriptedBackend {
+	return &ScriptedBackend{
+		dir:         dir,
+		runs:        map[string]int{},
+		permissions: map[string]Permissions{},
+	}
+}
+
+// Name implements AgentBackend.
+func (s *ScriptedBackend) Name() string {
+	return "scripted"
+}
+
+// Check implements AgentBackend.
+func (s *ScriptedBackend) Check() error {
+	if info, err := os.Stat(s.dir); err != nil || !info.IsDir() {
+		return fmt.Errorf("scripted backend recordings directory %s not found", s.dir)
+	}
+	return nil
+}
+
+// Start implements AgentBackend. The process has no PID and exits with
+// code 0 once its recording has been read.
+func (s *ScriptedBackend) Start(req SpawnRequest) (*Process, error) {
+	s.mu.Lock()
+	s.runs[req.Label]++
+	run := s.runs[req.Label]
+	s.requests = append(s.requests, req)
+	s.mu.Unlock()
+
+	candidates := []string{
+		filepath.Join(s.dir, fmt.Sprintf("%s-%d.ndjson", req.Label, run)),
+		filepath.Join(s.dir, req.Label+".ndjson"),
+	}
+	for _, path := range candidates {
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &Process{
+			Command: "scripted " + path,
+			Stdout:  bytes.NewReader(data),
+			Stderr:  strings.NewReader(""),
+		}, nil
+	}
+	return nil, fmt.Errorf("no recording for %s run %d in %s", req.Label, run, s.dir)
+}
+
+// ConfigurePermissions implements AgentBackend. Nothing is written; the
+// permissions are kept for Permissions.
+func (s *ScriptedBackend) ConfigurePermissions(dir string, perms Permissions) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.permissions[dir] = perms
+	return nil
+}
+
+// ParseEvent implements AgentBackend.
+func (s *ScriptedBackend) ParseEvent(line string) (Event, bool) {
+	return parseClaudeEvent(line)
+}
+
+// Requests returns every request started so far, in order.
+func (s *ScriptedBackend) Requests() []SpawnRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]SpawnRequest(nil), s.requests...)
+}
+
+// Permissions returns the permissions configured for dir.
+func (s *ScriptedBackend) Permissions(dir string) (Permissions, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	perms, ok := s.permissions[dir]
+	return perms, ok
+}
@@ -0,0 +1,275 @@
+/**
+ * Component: Agent Budget Guardrails
+ * Block-UUID: 1edec014-a50a-4637-adc0-d9e4276db399
+ * Parent-UUID: 0c6e3b94-7d21-4f58-a9e2-4b8f1d6c2a73
+ * Version: 1.1.0
+ * Description: Cost, token, and turn limits for agent sessions, and the Meter that tracks a running turn live from normalized backend events, estimating cost from the pricing table until a run reports it, and reports the first limit it crosses.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package budget
+
+import (
+	"fmt"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/internal/pi/sessions"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+)
+
+// StatusExceeded is the session and turn status recorded when a run is
+// stopped or refused because a limit was reached.
+const StatusExceeded = "budget_exceeded"
+
+// Limit names, as reported in Exceeded.Limit
+const (
+	LimitCost      = "max_cost"
+	LimitTokens    = "max_tokens"
+	LimitTurns     = "max_turns"
+	LimitTurnCost  = "max_turn_cost"
+	LimitTurnToken = "max_turn_tokens"
+)
+
+// Limits caps what a session may spend. Zero fields are unlimited. Session
+// limits count every turn and correction; turn limits count one turn and
+// its corrections.
+type Limits struct {
+	MaxCost       float64 `json:"max_cost,omitempty"`
+	MaxTokens     int     `json:"max_tokens,omitempty"`
+	MaxTurns      int     `json:"max_turns,omitempty"`
+	MaxTurnCost   float64 `json:"max_turn_cost,omitempty"`
+	MaxTurnTokens int     `json:"max_turn_tokens,omitempty"`
+}
+
+// IsZero reports whether no limit is set.
+func (l Limits) IsZero() bool {
+	return l == Limits{}
+}
+
+// Merge returns l with every limit set in other replacing its own.
+func (l Limits) Merge(other Limits) Limits {
+	if other.MaxCost > 0 {
+		l.MaxCost = other.MaxCost
+	}
+	if other.MaxTokens > 0 {
+		l.MaxTokens = other.MaxTokens
+	}
+	if other.MaxTurns > 0 {
+		l.MaxTurns = other.MaxTurns
+	}
+	if other.MaxTurnCost > 0 {
+		l.MaxTurnCost = other.MaxTurnCost
+	}
+	if other.MaxTurnTokens > 0 {
+		l.MaxTurnTokens = other.MaxTurnTokens
+	}
+	return l
+}
+
+// Validate rejects negative limits.
+func (l Limits) Validate() error {
+	if l.MaxCost < 0 || l.MaxTokens < 0 || l.MaxTurns < 0 || l.MaxTurnCost < 0 || l.MaxTurnTokens < 0 {
+		return fmt.Errorf("budget limits must not be negative")
+	}
+	return nil
+}
+
+// Spend is what a session or turn has consumed.
+type Spend struct {
+	Cost   float64 `json:"cost"`
+	Tokens int     `json:"tokens"`
+	Turns  int     `json:"turns"`
+}
+
+// Add returns the sum of s and other.
+func (s Spend) Add(other Spend) Spend {
+	return Spend{Cost: s.Cost + other.Cost, Tokens: s.Tokens + other.Tokens, Turns: s.Turns + other.Turns}
+}
+
+// Exceeded describes the limit a run crossed. It is also the error returned
+// when a run is refused.
+type Exceeded struct {
+	Limit string  `json:"limit"`
+	Spent float64 `json:"spent"`
+	Max   float64 `json:"max"`
+	Turn  int     `json:"turn,omitempty"`
+}
+
+// Error implements error.
+func (e *Exceeded) Error() string {
+	switch e.Limit {
+	case LimitCost, LimitTurnCost:
+		return fmt.Sprintf("budget exceeded: %s $%.4f of $%.4f", e.Limit, e.Spent, e.Max)
+	default:
+		return fmt.Sprintf("budget exceeded: %s %d of %d", e.Limit, int64(e.Spent), int64(e.Max))
+	}
+}
+
+// Tokens counts the tokens in u that a budget charges for: input, output,
+// and cache writes. Cache reads are left out; an agentic run re-reads its
+// context on every request and would otherwise dominate the count.
+func Tokens(u backend.Usage) int {
+	return u.InputTokens + u.OutputTokens + u.CacheCreationTokens
+}
+
+// CheckStart reports the session limit that forbids starting another turn
+// after spent, or nil.
+func (l Limits) CheckStart(spent Spend) *Exceeded {
+	switch {
+	case l.MaxTurns > 0 && spent.Turns >= l.MaxTurns:
+		return &Exceeded{Limit: LimitTurns, Spent: float64(spent.Turns), Max: float64(l.MaxTurns)}
+	case l.MaxCost > 0 && spent.Cost >= l.MaxCost:
+		return &Exceeded{Limit: LimitCost, Spent: spent.Cost, Max: l.MaxCost}
+	case l.MaxTokens > 0 && spent.Tokens >= l.MaxTokens:
+		return &Exceeded{Limit: LimitTokens, Spent: float64(spent.Tokens), Max: float64(l.MaxTokens)}
+	}
+	return nil
+}
+
+// CheckTurn reports the limit crossed by a turn that has spent turn on top
+// of the session's earlier spend prior, or nil.
+func (l Limits) CheckTurn(prior, turn Spend) *Exceeded {
+	switch {
+	case l.MaxTurnTokens > 0 && turn.Tokens > l.MaxTurnTokens:
+		return &Exceeded{Limit: LimitTurnToken, Spent: float64(turn.Tokens), Max: float64(l.MaxTurnTokens)}
+	case l.MaxTokens > 0 && prior.Tokens+turn.Tokens > l.MaxTokens:
+		return &Exceeded{Limit: LimitTokens, Spent: float64(prior.Tokens + turn.Tokens), Max: float64(l.MaxTokens)}
+	case l.MaxTurnCost > 0 && turn.Cost > l.MaxTurnCost:
+		return &Exceeded{Limit: LimitTurnCost, Spent: turn.Cost, Max: l.MaxTurnCost}
+	case l.MaxCost > 0 && prior.Cost+turn.Cost > l.MaxCost:
+		return &Exceeded{Limit: LimitCost, Spent: prior.Cost + turn.Cost, Max: l.MaxCost}
+	}
+	return nil
+}
+
+// Meter tracks one running turn. Tokens are counted live from assistant
+// messages, each message counted once at its latest usage. Cost is estimated
+// live from the same usage and a per-model price table, so cost limits stop
+// a runaway turn while it runs; when a run reports its result, the reported
+// cost replaces the estimate for that run. Messages from a model without a
+// price add no estimated cost.
+type Meter struct {
+	limits    Limits
+	prior     Spend
+	pricing   *sessions.PricingTable
+	model     string
+	messages  map[string]backend.Usage
+	anonymous backend.Usage
+	result    backend.Usage
+	cost      float64
+	done      bool
+
+	// Estimated cost of the run in progress, per message and for messages
+	// without an id; cleared when the run reports its result.
+	estimates         map[string]float64
+	anonymousEstimate float64
+}
+
+// NewMeter meters a turn of a session that had already spent prior, pricing
+// messages with LoadPricing.
+func NewMeter(limits Limits, prior Spend) *Meter {
+	return &Meter{
+		limits:    limits,
+		prior:     prior,
+		pricing:   LoadPricing(),
+		messages:  map[string]backend.Usage{},
+		estimates: map[string]float64{},
+	}
+}
+
+// LoadPricing returns the price table used to estimate cost: the editable
+// table shared with 'gsc pi sessions usage', or the built-in defaults when it
+// cannot be read.
+func LoadPricing() *sessions.PricingTable {
+	gscHome, err := settings.GetGSCHome(false)
+	if err != nil {
+		return sessions.DefaultPricingTable()
+	}
+	table, err := sessions.LoadPricingTable(settings.GetPiPricingPath(gscHome))
+	if err != nil {
+		return sessions.DefaultPricingTable()
+	}
+	return table
+}
+
+// Observe records event and reports the first limit now crossed, or nil.
+func (m *Meter) Observe(event backend.Event) *Exceeded {
+	switch event.Type {
+	case backend.EventAssistant:
+		if event.Model != "" {
+			m.model = event.Model
+		}
+		if Tokens(event.Usage) == 0 {
+			return nil
+		}
+		if event.MessageID == "" {
+			m.anonymous = addUsage(m.anonymous, event.Usage)
+			m.anonymousEstimate += m.estimate(event.Usage)
+		} else {
+			m.messages[event.MessageID] = event.Usage
+			m.estimates[event.MessageID] = m.estimate(event.Usage)
+		}
+	case backend.EventResult:
+		m.result = addUsage(m.result, event.Usage)
+		if event.CostUSD > 0 {
+			m.cost += event.CostUSD
+		} else {
+			m.cost += m.pendingEstimate()
+		}
+		m.estimates = map[string]float64{}
+		m.anonymousEstimate = 0
+		m.done = true
+	default:
+		return nil
+	}
+	return m.limits.CheckTurn(m.prior, m.Turn())
+}
+
+// estimate prices u at the current model's rates.
+func (m *Meter) estimate(u backend.Usage) float64 {
+	price, ok := m.pricing.Lookup(m.model)
+	if !ok {
+		return 0
+	}
+	return price.Cost(int64(u.InputTokens), int64(u.OutputTokens), int64(u.CacheReadTokens), int64(u.CacheCreationTokens))
+}
+
+// pendingEstimate is the estimated cost of the run in progress.
+func (m *Meter) pendingEstimate() float64 {
+	total := m.anonymousEstimate
+	for _, cost := range m.estimates {
+		total += cost
+	}
+	return total
+}
+
+// Usage returns the token usage of the metered turn so far. Once the run
+// has reported its result, the larger of the result and the live count
+// wins.
+func (m *Meter) Usage() backend.Usage {
+	live := m.anonymous
+	for _, u := range m.messages {
+		live = addUsage(live, u)
+	}
+	if m.done && Tokens(m.result) >= Tokens(live) {
+		return m.result
+	}
+	return live
+}
+
+// Turn returns what the metered turn has spent so far: reported cost of
+// finished runs plus the estimate for the run in progress.
+func (m *Meter) Turn() Spend {
+	return Spend{Cost: m.cost + m.pendingEstimate(), Tokens: Tokens(m.Usage()), Turns: 1}
+}
+
+func addUsage(a, b backend.Usage) backend.Usage {
+	return backend.Usage{
+		InputTokens:         a.InputTokens + b.InputTokens,
+		OutputTokens:        a.OutputTokens + b.OutputTokens,
+		CacheCreationTokens: a.CacheCreationTokens + b.CacheCreationTokens,
+		CacheReadTokens:     a.CacheReadTokens + b.CacheReadTokens,
+	}
+}
@@ -0,0 +1,98 @@
+/**
+ * Component: Agent Budget Guardrails Tests
+ * Block-UUID: 31ed2184-f033-43ec-9a7f-8ea3ef0e4aab
+ * Parent-UUID: 8d3f6a21-49c7-4e0b-a5d2-7c1e9b04f386
+ * Version: 1.1.0
+ * Description: Tests that the Meter counts each streamed message once, prefers the result usage once known, stops a running turn on its estimated cost, and that start and turn checks report the limit crossed.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package budget
+
+import (
+	"testing"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/internal/pi/sessions"
+)
+
+func assistant(id string, input, output int) backend.Event {
+	return backend.Event{Type: backend.EventAssistant, MessageID: id, Usage: backend.Usage{InputTokens: input, OutputTokens: output, CacheReadTokens: 10000}}
+}
+
+func TestMeterCountsEachMessageOnce(t *testing.T) {
+	meter := NewMeter(Limits{MaxTurnTokens: 500}, Spend{})
+
+	// A message streamed as several events reports its running usage
+	for _, event := range []backend.Event{assistant("msg_1", 100, 10), assistant("msg_1", 100, 50), assistant("msg_2", 200, 20)} {
+		if exceeded := meter.Observe(event); exceeded != nil {
+			t.Fatalf("exceeded early: %v", exceeded)
+		}
+	}
+	if got := meter.Turn().Tokens; got != 370 {
+		t.Fatalf("tokens = %d, want 370 (cache reads excluded)", got)
+	}
+
+	exceeded := meter.Observe(assistant("msg_3", 100, 50))
+	if exceeded == nil || exceeded.Limit != LimitTurnToken || exceeded.Spent != 520 {
+		t.Fatalf("exceeded = %+v", exceeded)
+	}
+}
+
+func TestMeterResultCost(t *testing.T) {
+	meter := NewMeter(Limits{MaxCost: 1}, Spend{Cost: 0.75, Turns: 2})
+	meter.Observe(assistant("msg_1", 100, 10))
+	exceeded := meter.Observe(backend.Event{Type: backend.EventResult, CostUSD: 0.5, Usage: backend.Usage{InputTokens: 400, OutputTokens: 40}})
+	if exceeded == nil || exceeded.Limit != LimitCost || exceeded.Spent != 1.25 {
+		t.Fatalf("exceeded = %+v", exceeded)
+	}
+	if got := meter.Turn(); got.Tokens != 440 || got.Cost != 0.5 {
+		t.Fatalf("turn = %+v", got)
+	}
+}
+
+func TestCheckStart(t *testing.T) {
+	limits := Limits{MaxTurns: 2, MaxCost: 1}
+	if exceeded := limits.CheckStart(Spend{Cost: 0.5, Turns: 1}); exceeded != nil {
+		t.Fatalf("refused under budget: %v", exceeded)
+	}
+	if exceeded := limits.CheckStart(Spend{Cost: 0.5, Turns: 2}); exceeded == nil || exceeded.Limit != LimitTurns {
+		t.Fatalf("turn limit = %+v", exceeded)
+	}
+	if exceeded := limits.CheckStart(Spend{Cost: 1, Turns: 1}); exceeded == nil || exceeded.Error() != "budget exceeded: max_cost $1.0000 of $1.0000" {
+		t.Fatalf("cost limit = %v", exceeded)
+	}
+	if (Limits{}).Merge(Limits{MaxTokens: 10}).Merge(Limits{MaxCost: 2}) != (Limits{MaxTokens: 10, MaxCost: 2}) {
+		t.Fatal("merge dropped a limit")
+	}
+}
+
+func TestMeterStopsTurnOnEstimatedCost(t *testing.T) {
+	meter := NewMeter(Limits{MaxTurnCost: 0.05}, Spend{})
+	meter.pricing = sessions.DefaultPricingTable()
+
+	// claude-sonnet-4: $3 input, $15 output per million tokens
+	event := backend.Event{Type: backend.EventAssistant, Model: "claude-sonnet-4-20250514", MessageID: "msg_1", Usage: backend.Usage{InputTokens: 5000, OutputTokens: 1000}}
+	if exceeded := meter.Observe(event); exceeded != nil {
+		t.Fatalf("exceeded early: %v", exceeded)
+	}
+	if got := meter.Turn().Cost; got < 0.0299 || got > 0.0301 {
+		t.Fatalf("estimated cost = %f, want 0.03", got)
+	}
+
+	// The same message growing replaces its estimate; a second one pushes
+	// the still-running turn over the limit before any result arrives.
+	meter.Observe(backend.Event{Type: backend.EventAssistant, MessageID: "msg_1", Usage: backend.Usage{InputTokens: 5000, OutputTokens: 2000}})
+	exceeded := meter.Observe(backend.Event{Type: backend.EventAssistant, MessageID: "msg_2", Usage: backend.Usage{InputTokens: 5000}})
+	if exceeded == nil || exceeded.Limit != LimitTurnCost || exceeded.Spent < 0.0599 || exceeded.Spent > 0.0601 {
+		t.Fatalf("exceeded = %+v", exceeded)
+	}
+
+	// The reported cost replaces the estimate once the run ends
+	meter.Observe(backend.Event{Type: backend.EventResult, CostUSD: 0.04})
+	if got := meter.Turn().Cost; got != 0.04 {
+		t.Fatalf("cost after result = %f", got)
+	}
+}
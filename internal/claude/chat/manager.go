@@ -1,12 +1,12 @@
 /**
  * Component: Claude Code Chat Execution Manager
- * Block-UUID: 5186d61d-f18a-453e-b20a-111e3668e22c
- * Parent-UUID: 4ab90416-e6ef-4067-810b-43dd4c7250f7
- * Version: 1.62.0
- * Description: Updated executeCommand to use conditional prompt logic based on mode (analyze vs coding-assistant).
+ * Block-UUID: a5381329-0769-4fca-a1c9-b135e136d530
+ * Parent-UUID: 6083932d-c031-49c3-ab13-106beb9d1733
+ * Version: 1.64.0
+ * Description: Chat execution through the agent backend. ExecuteChat takes budget limits, refuses a completion once the chat's recorded spend reaches them, stops a completion that crosses one with SIGTERM then SIGKILL, and does not save a response stopped over budget.
  * Language: Go
  * Created-at: 2026-05-08T03:25:30.789Z
- * Authors: claude-haiku-4-5-20251001 (v1.53.2), claude-haiku-4-5-20251001 (v1.53.3), GLM-4.7 (v1.54.0), GLM-4.7 (v1.54.1), GLM-4.7 (v1.54.2), GLM-4.7 (v1.55.0), Gemini 2.5 Flash (v1.56.0), GLM-4.7 (v1.57.0), GLM-4.7 (v1.57.1), GLM-4.7 (v1.58.0), GLM-4.7 (v1.59.0), GLM-4.7 (v1.60.0), GLM-4.7 (v1.61.0), GLM-4.7 (v1.62.0)
+ * Authors: claude-haiku-4-5-20251001 (v1.53.2), claude-haiku-4-5-20251001 (v1.53.3), GLM-4.7 (v1.54.0), GLM-4.7 (v1.54.1), GLM-4.7 (v1.54.2), GLM-4.7 (v1.55.0), Gemini 2.5 Flash (v1.56.0), GLM-4.7 (v1.57.0), GLM-4.7 (v1.57.1), GLM-4.7 (v1.58.0), GLM-4.7 (v1.59.0), GLM-4.7 (v1.60.0), GLM-4.7 (v1.61.0), GLM-4.7 (v1.62.0), agent (v1.63.0), agent (v1.64.0)
  */
 
 
@@ -17,15 +17,17 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gitsense/gsc-cli/internal/claude"
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 	"github.com/gitsense/gsc-cli/internal/db"
 	"github.com/gitsense/gsc-cli/internal/git"
 	"github.com/gitsense/gsc-cli/pkg/logger"
@@ -35,7 +37,9 @@ import (
 
 // ExecuteChat is the main entry point for executing a Claude Code chat session.
 // assistantMessageID is the ID of the assistant message (placeholder) in the database.
-func ExecuteChat(chatUUID string, assistantMessageID int64, userMessage string, format string, appendMsg bool, save bool, appendSave bool, model string, thinkingBudget int, mode string, noEvents bool) error {
+// limits, when set, bound the chat's total spend across completions; a
+// completion that crosses a token limit is stopped and its response not saved.
+func ExecuteChat(chatUUID string, assistantMessageID int64, userMessage string, format string, appendMsg bool, save bool, appendSave bool, model string, thinkingBudget int, mode string, noEvents bool, limits budget.Limits) error {
 	startTime := time.Now()
 
 	// Phase 1: Setup & Prepare
@@ -48,9 +52,22 @@ func ExecuteChat(chatUUID string, assistantMessageID int64, userMessage string,
 	defer chatDB.Close()
 	defer metricsDB.Close()
 
+	// Refuse to start a completion once the chat has spent its budget
+	var meter *budget.Meter
+	if !limits.IsZero() {
+		spent, err := GetChatSpend(metricsDB, chatUUID)
+		if err != nil {
+			return err
+		}
+		if exceeded := limits.CheckStart(spent); exceeded != nil {
+			return exceeded
+		}
+		meter = budget.NewMeter(limits, spent)
+	}
+
 	// Phase 2: Execute Command & Process Stream
 	streamResult, err := executeCommand(
-		chatDir, systemPromptPath, effectiveModel, storedSessionID, mode, thinkingBudget, format, archiveSettings, noEvents,
+		chatDir, systemPromptPath, effectiveModel, storedSessionID, mode, thinkingBudget, format, archiveSettings, noEvents, meter,
 	)
 	if err != nil {
 		return err
@@ -63,9 +80,15 @@ func ExecuteChat(chatUUID string, assistantMessageID int64, userMessage string,
 	}
 
 	duration := time.Since(startTime)
-	return finalizeAndSave(
+	if err := finalizeAndSave(
 		chatDB, metricsDB, chat, chatUUID, assistantMessageID, streamResult, effectiveModel, save, duration, noEvents,
-	)
+	); err != nil {
+		return err
+	}
+	if streamResult.BudgetExceeded != nil {
+		return streamResult.BudgetExceeded
+	}
+	return nil
 }
 
 // setupAndPrepare handles all setup, preparation, and context resolution.
@@ -89,9 +112,13 @@ func setupAndPrepare(
 	claude.Settings,  // archiveSettings
 	error,
 ) {
-	// 1. Pre-flight Check: Ensure 'claude' binary is in PATH
-	if _, err := exec.LookPath("claude"); err != nil {
-		return nil, nil, "", "", "", "", "", nil, claude.Settings{}, fmt.Errorf("claude CLI not found in PATH. Please install Claude Code CLI first")
+	// 1. Pre-flight Check: Ensure the agent backend (claude by default) can run
+	agent, err := backend.Resolve("")
+	if err != nil {
+		return nil, nil, "", "", "", "", "", nil, claude.Settings{}, err
+	}
+	if err := agent.Check(); err != nil {
+		return nil, nil, "", "", "", "", "", nil, claude.Settings{}, err
 	}
 
 	// 2. Resolve GSC_HOME
@@ -367,6 +394,7 @@ func executeCommand(
 	format string,
 	archiveSettings claude.Settings,
 	noEvents bool,
+	meter *budget.Meter,
 ) (StreamResult, error) {
 	emptyResult := StreamResult{}
 
@@ -441,47 +469,39 @@ asks about code, or mentions the codebase. Then read only the specific
  	prompt += "Follow the protocol in CLAUDE.md."
 	}
 
-	// Build CLI flags
-	flags := []string{
-		"-p", fmt.Sprintf("%q", prompt),
-		"--append-system-prompt-file", "./messages/system-prompt.md",
-		"--allowedTools", "Read",
-		"--verbose",
-		"--include-partial-messages",
-		"--output-format", "stream-json",
-	}
-
-	if effectiveModel != "" {
-		flags = append(flags, "--model", effectiveModel)
-	}
-
-	if storedSessionID != "" {
-		flags = append(flags, "--resume", storedSessionID)
-		logger.Info("Reusing session", "session_id", storedSessionID)
+	agent, err := backend.Resolve("")
+	if err != nil {
+		return emptyResult, err
 	}
-
-	if thinkingBudget > 0 {
-		flags = append(flags, "--thinking", strconv.Itoa(thinkingBudget))
+	if err := agent.ConfigurePermissions(chatDir, chatPermissions); err != nil {
+		return emptyResult, err
 	}
 
-	// Execute Command
-	cmd := exec.Command("claude", flags...)
-	cmd.Dir = chatDir
-
-	logger.Debug("Executing Claude CLI command", "command", strings.Join(cmd.Args, " "))
-
-	stdout, err := cmd.StdoutPipe()
+	proc, err := agent.Start(backend.SpawnRequest{
+		Label:            "chat",
+		Dir:              chatDir,
+		Prompt:           prompt,
+		SystemPromptFile: "./messages/system-prompt.md",
+		Model:            effectiveModel,
+		Permissions:      chatPermissions,
+		ResumeSessionID:  storedSessionID,
+		ThinkingBudget:   thinkingBudget,
+	})
 	if err != nil {
-		return emptyResult, fmt.Errorf("failed to create stdout pipe: %w", err)
+		return emptyResult, fmt.Errorf("failed to start %s: %w", agent.Name(), err)
 	}
-
-	if err := cmd.Start(); err != nil {
-		return emptyResult, fmt.Errorf("failed to start claude command: %w", err)
+	if storedSessionID != "" {
+		logger.Info("Reusing session", "session_id", storedSessionID)
 	}
+	logger.Debug("Executing agent command", "backend", agent.Name(), "command", proc.Command)
 
 	// Capture stderr
 	var stderrBuf bytes.Buffer
-	cmd.Stderr = &stderrBuf
+	stderrDone := make(chan struct{})
+	go func() {
+		io.Copy(&stderrBuf, proc.Stderr)
+		close(stderrDone)
+	}()
 
 	// Setup logging directory
 	logDir := filepath.Join(chatDir, "logs")
@@ -496,6 +516,28 @@ asks about code, or mentions the codebase. Then read only the specific
 		Silent:         noEvents,
 	}
 
+	// Stop the agent once the completion crosses a budget limit: SIGTERM,
+	// then SIGKILL if it has not exited in time
+	var budgetKill *time.Timer
+	if meter != nil {
+		processor.Agent = agent
+		processor.Meter = meter
+		processor.OnBudgetExceeded = func(exceeded *budget.Exceeded) {
+			logger.Warning("Stopping chat completion", "reason", exceeded.Error())
+			if proc.PID <= 0 {
+				return
+			}
+			process, err := os.FindProcess(proc.PID)
+			if err != nil {
+				return
+			}
+			process.Signal(syscall.SIGTERM)
+			budgetKill = time.AfterFunc(budgetKillTimeout, func() {
+				process.Signal(syscall.SIGKILL)
+			})
+		}
+	}
+
 	// Open log file
 	logFileName := fmt.Sprintf("raw-stream-%s.ndjson", time.Now().Format("20060102-150405"))
 	logFilePath := filepath.Join(logDir, logFileName)
@@ -507,13 +549,17 @@ asks about code, or mentions the codebase. Then read only the specific
 	processor.LogFile = logFile
 
 	// Process stream
-	streamResult, err := processor.processStream(stdout, logDir)
+	streamResult, err := processor.processStream(proc.Stdout, logDir)
 	if err != nil {
 		return emptyResult, err
 	}
 
 	// Wait for command to complete
-	waitErr := cmd.Wait()
+	<-stderrDone
+	exitCode, waitErr := proc.Wait()
+	if budgetKill != nil {
+		budgetKill.Stop()
+	}
 	streamResult.StderrOutput = stderrBuf.String()
 
 	if streamResult.StderrOutput != "" {
@@ -521,14 +567,9 @@ asks about code, or mentions the codebase. Then read only the specific
 		logger.Error("Claude CLI stderr output", "output", streamResult.StderrOutput)
 	}
 
-	if waitErr != nil {
-		if exitError, ok := waitErr.(*exec.ExitError); ok {
-			streamResult.ExitCode = exitError.ExitCode()
-		} else {
-			streamResult.ExitCode = 1
-		}
-	} else {
-		streamResult.ExitCode = 0
+	streamResult.ExitCode = exitCode
+	if waitErr != nil && exitCode == 0 {
+		streamResult.ExitCode = 1
 	}
 
 	return streamResult, nil
@@ -590,8 +631,11 @@ func finalizeAndSave(
 		logger.Error("Failed to upsert session metrics", "error", err)
 	}
 
-	// 14. Save Response to Database (if --save flag is set)
-	if save {
+	// 14. Save Response to Database (if --save flag is set). A completion
+	// stopped over budget is incomplete and is not saved
+	if save && streamResult.BudgetExceeded != nil {
+		logger.Warning("Not saving response stopped over budget", "reason", streamResult.BudgetExceeded.Error())
+	} else if save {
 		logger.Info("Saving response to database", "parent_id", assistantMessageID)
 
 		responseContent := streamResult.FullResponse
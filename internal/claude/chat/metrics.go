@@ -1,12 +1,12 @@
 /**
  * Component: Claude Code Chat Metrics Database
- * Block-UUID: 9222f7a0-bec7-4c4b-ac67-04b9cd6c9812
- * Parent-UUID: 50d6d90e-97cb-4dc3-83c0-001258e34a04
- * Version: 1.1.2
- * Description: Re-added import of parent claude package for Usage type. Usage is a shared type defined in internal/claude/models.go.
+ * Block-UUID: 6983c4de-e54b-43f8-aa0c-0e9ad40dc31b
+ * Parent-UUID: 9222f7a0-bec7-4c4b-ac67-04b9cd6c9812
+ * Version: 1.2.0
+ * Description: Completion and session metrics for chat turns using the shared claude Usage type. Added GetChatSpend, summing a chat's recorded cost and tokens for budget checks.
  * Language: Go
  * Created-at: 2026-03-24T15:54:35.067Z
- * Authors: Gemini 3 Flash (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.1.1), GLM-4.7 (v1.1.2)
+ * Authors: Gemini 3 Flash (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.1.1), GLM-4.7 (v1.1.2), agent (v1.2.0)
  */
 
 package chat
@@ -21,6 +21,7 @@ import (
 	_ "modernc.org/sqlite" // Pure Go SQLite driver
 
 	"github.com/gitsense/gsc-cli/internal/claude"
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 	"github.com/gitsense/gsc-cli/pkg/logger"
 	"github.com/gitsense/gsc-cli/pkg/settings"
 )
@@ -179,3 +180,22 @@ func GetSessionByChatUUID(db *sql.DB, chatUUID string) (string, error) {
 
 	return sessionID, nil
 }
+
+// GetChatSpend sums the cost and budgeted tokens of every completion recorded
+// for a chat; Turns is the number of completions.
+func GetChatSpend(db *sql.DB, chatUUID string) (budget.Spend, error) {
+	query := `
+	SELECT
+		COALESCE(SUM(cost_usd), 0),
+		COALESCE(SUM(input_tokens + output_tokens + cache_creation_tokens), 0),
+		COUNT(*)
+	FROM completions
+	WHERE chat_uuid = ?
+	`
+
+	var spent budget.Spend
+	if err := db.QueryRow(query, chatUUID).Scan(&spent.Cost, &spent.Tokens, &spent.Turns); err != nil {
+		return budget.Spend{}, fmt.Errorf("failed to query chat spend: %w", err)
+	}
+	return spent, nil
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Claude Code Chat Stream Event Processor
- * Block-UUID: fcced212-5d7b-4410-bd64-e876f0d64091
- * Parent-UUID: 7f1d4e7f-1c2d-4751-b903-06e28e0d59ea
- * Version: 1.2.4
- * Description: Extract stream processing logic into dedicated processor module for improved separation of concerns and reusability. Moved StreamResult and StreamProcessor to types.go. Added structured event logging, dual output streams, and comprehensive debug logging for improved stream handling.
+ * Block-UUID: 69a89d27-c5ca-467a-9934-7ec6645fc21e
+ * Parent-UUID: fcced212-5d7b-4410-bd64-e876f0d64091
+ * Version: 1.3.0
+ * Description: Stream processing for chat completions with structured event output. Meters events through the agent backend against the chat budget, emits a budget_exceeded event and stops the agent on a breach, and keeps the metered usage when no result arrives.
  * Language: Go
  * Created-at: 2026-05-08T01:06:32.566Z
- * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.2.1), Gemini 2.5 Flash Lite (v1.2.2), GLM-4.7 (v1.2.3), GLM-4.7 (v1.2.4)
+ * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.2.1), Gemini 2.5 Flash Lite (v1.2.2), GLM-4.7 (v1.2.3), GLM-4.7 (v1.2.4), agent (v1.3.0)
  */
 
 
@@ -24,6 +24,8 @@ import (
 	"time"
 
 	"github.com/gitsense/gsc-cli/internal/claude"
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 	"github.com/gitsense/gsc-cli/pkg/logger"
 )
 
@@ -184,6 +186,26 @@ func (sp *StreamProcessor) processStream(stdout io.Reader, logDir string) (Strea
 			logger.Warning("Failed to write to raw stream log", "error", err)
 		}
 
+		// Meter the completion against the chat budget. Once over, the agent
+		// is asked to stop and the rest of its output is still drained
+		if sp.Meter != nil && result.BudgetExceeded == nil {
+			if event, ok := sp.Agent.ParseEvent(line); ok {
+				if exceeded := sp.Meter.Observe(event); exceeded != nil && event.Type != backend.EventResult {
+					result.BudgetExceeded = exceeded
+					sp.DebugLogger.LogStreamEvent("BUDGET_EXCEEDED", exceeded.Error())
+					if sp.EventWriter != nil {
+						sp.EventWriter.WriteErrorEvent(ChatErrorEvent{
+							Type:    budget.StatusExceeded,
+							Message: exceeded.Error(),
+						})
+					}
+					if sp.OnBudgetExceeded != nil {
+						sp.OnBudgetExceeded(exceeded)
+					}
+				}
+			}
+		}
+
 		// Parse event
 		var baseEvent claude.StreamEvent
 		if err := json.Unmarshal([]byte(line), &baseEvent); err != nil {
@@ -307,6 +329,18 @@ func (sp *StreamProcessor) processStream(stdout io.Reader, logDir string) (Strea
 	result.FullResponse = fullResponse.String()
 	metricsWritten = true
 
+	// A completion stopped over budget never reports its usage; record what
+	// was metered so the chat's spend still counts it
+	if result.BudgetExceeded != nil && result.Usage == (claude.Usage{}) {
+		usage := sp.Meter.Usage()
+		result.Usage = claude.Usage{
+			InputTokens:         usage.InputTokens,
+			OutputTokens:        usage.OutputTokens,
+			CacheCreationTokens: usage.CacheCreationTokens,
+			CacheReadTokens:     usage.CacheReadTokens,
+		}
+	}
+
 	// NEW: Log final metrics
 	sp.DebugLogger.LogMetrics(fmt.Sprintf(
 		"Final Metrics - Duration: %dms, Cost: $%.6f, InputTokens: %d, OutputTokens: %d",
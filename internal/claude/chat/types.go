@@ -1,12 +1,12 @@
 /**
  * Component: Claude Code Chat Types
- * Block-UUID: be30393e-7d04-4dd7-9f17-a5d655b15f6e
- * Parent-UUID: N/A
- * Version: 1.2.0
- * Description: Added Silent field to StreamProcessor for batch processing support (--no-events flag).
+ * Block-UUID: 8b03b398-823b-49f6-b205-87421c67aa67
+ * Parent-UUID: 6b98f0fb-ca9a-4f69-a524-7f9e915cc07d
+ * Version: 1.4.0
+ * Description: Stream processing types and chat permissions. StreamResult records the budget limit a completion crossed, and StreamProcessor carries the meter, backend, and stop callback used to enforce it.
  * Language: Go
  * Created-at: 2026-04-01T15:26:44.195Z
- * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0)
+ * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), agent (v1.3.0), agent (v1.4.0)
  */
 
 
@@ -15,8 +15,11 @@ package chat
 import (
 	"bytes"
 	"os"
+	"time"
 
 	"github.com/gitsense/gsc-cli/internal/claude"
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 )
 
 // StreamResult holds the output from stream processing
@@ -27,6 +30,9 @@ type StreamResult struct {
 	SessionID    string
 	ExitCode     int
 	StderrOutput string
+
+	// BudgetExceeded is the limit that stopped the completion, if any
+	BudgetExceeded *budget.Exceeded
 }
 
 // StreamProcessor handles the stream event processing logic
@@ -47,6 +53,12 @@ type StreamProcessor struct {
 	
 	// Track last assistant message for fallback processing
 	LastAssistantMessage string
+
+	// Meter, when set, tracks the completion against the chat budget using
+	// events normalized by Agent; OnBudgetExceeded stops the agent
+	Agent            backend.AgentBackend
+	Meter            *budget.Meter
+	OnBudgetExceeded func(*budget.Exceeded)
 }
 
 // HistoryEntry represents a single execution record in history.jsonl
@@ -66,4 +78,14 @@ const (
 	InitialBufSize  = 64 * 1024        // 64KB initial buffer
 	DirPermissions  = 0755
 	FilePermissions = 0644
+
+	// budgetKillTimeout is how long a completion stopped over budget has to
+	// exit after SIGTERM before it is killed
+	budgetKillTimeout = 10 * time.Second
 )
+
+// chatPermissions lets the chat agent read the message and context files
+// in the chat directory and nothing else.
+var chatPermissions = backend.Permissions{
+	Tools: []string{"Read"},
+}
@@ -0,0 +1,225 @@
+/**
+ * Component: Intent Workflow Budget Guardrails
+ * Block-UUID: 71cb11a5-4653-427c-b617-2330945eeef9
+ * Parent-UUID: 5b7e2c19-3d84-4a6f-b0c7-e91f2d6a4c58
+ * Version: 1.1.0
+ * Description: Applies a session's cost, token, and turn budget: persists limits set on the command line, sums the session's spend from turn state (also exposed as Session.Spend for dashboards), refuses turns that would start over budget, and stops a running turn that crosses a limit with the same SIGTERM then SIGKILL sequence as StopSession.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package intent_workflow
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
+)
+
+// budgetKillTimeout is how long a turn stopped over budget has to exit after
+// SIGTERM before it is killed; it matches StopSession.
+const budgetKillTimeout = 10 * time.Second
+
+// SetBudget merges limits into the session budget and persists it, so
+// background workers and later turns enforce the same limits.
+func (m *Manager) SetBudget(limits budget.Limits) error {
+	if m.session == nil {
+		return fmt.Errorf("session not initialized")
+	}
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	if limits.IsZero() {
+		return nil
+	}
+	current := budget.Limits{}
+	if m.session.Budget != nil {
+		current = *m.session.Budget
+	}
+	merged := current.Merge(limits)
+	m.session.Budget = &merged
+	return m.writeSessionState()
+}
+
+// CheckBudget reports the session limit that forbids starting another turn,
+// or nil.
+func (m *Manager) CheckBudget() error {
+	if m.session == nil || m.session.Budget == nil {
+		return nil
+	}
+	if exceeded := m.session.Budget.CheckStart(m.sessionSpend(0)); exceeded != nil {
+		return exceeded
+	}
+	return nil
+}
+
+// BudgetExceeded returns the limit that stopped the last turn this manager
+// ran, or nil.
+func (m *Manager) BudgetExceeded() *budget.Exceeded {
+	return m.budgetExceeded
+}
+
+// sessionSpend sums the cost, tokens, and turns of every turn except
+// exclude. Skipped turns never ran an agent and are not counted.
+func (m *Manager) sessionSpend(exclude int) budget.Spend {
+	return spendOf(m.session, exclude)
+}
+
+// Spend returns what the session has spent across all its turns.
+func (s *Session) Spend() budget.Spend {
+	return spendOf(s, 0)
+}
+
+func spendOf(session *Session, exclude int) budget.Spend {
+	var spent budget.Spend
+	for _, turn := range session.Turns {
+		if turn.TurnNumber == exclude || turn.Status == "skipped" {
+			continue
+		}
+		spent.Turns++
+		if turn.Cost != nil {
+			spent.Cost += *turn.Cost
+		}
+		if turn.CorrectionCost != nil {
+			spent.Cost += *turn.CorrectionCost
+		}
+		if turn.Usage != nil {
+			spent.Tokens += budget.Tokens(backend.Usage{
+				InputTokens:         turn.Usage.InputTokens,
+				OutputTokens:        turn.Usage.OutputTokens,
+				CacheCreationTokens: turn.Usage.CacheCreationTokens,
+			})
+		}
+	}
+	return spent
+}
+
+// usageFromBackend converts usage reported by the agent backend.
+func usageFromBackend(u backend.Usage) Usage {
+	return Usage{
+		InputTokens:         u.InputTokens,
+		OutputTokens:        u.OutputTokens,
+		CacheCreationTokens: u.CacheCreationTokens,
+		CacheReadTokens:     u.CacheReadTokens,
+	}
+}
+
+// checkTurnStart clears the budget state of the previous turn and refuses a
+// new one when a session limit is already reached.
+func (m *Manager) checkTurnStart() error {
+	m.budgetExceeded = nil
+	if err := m.CheckBudget(); err != nil {
+		m.debugLogger.Log("BUDGET", fmt.Sprintf("Refusing to start turn: %v", err))
+		return err
+	}
+	return nil
+}
+
+// newTurnMeter meters turn against the session budget, or returns nil when
+// the session has none.
+func (m *Manager) newTurnMeter(turn int) *budget.Meter {
+	if m.session.Budget == nil || m.session.Budget.IsZero() {
+		return nil
+	}
+	return budget.NewMeter(*m.session.Budget, m.sessionSpend(turn))
+}
+
+// checkCorrectionBudget reports the limit that forbids another correction
+// attempt for turn, given what the turn itself has already cost.
+func (m *Manager) checkCorrectionBudget(turn int, usage Usage, cost float64) *budget.Exceeded {
+	if m.session.Budget == nil {
+		return nil
+	}
+	spent := budget.Spend{Cost: cost, Tokens: budget.Tokens(backend.Usage{
+		InputTokens:         usage.InputTokens,
+		OutputTokens:        usage.OutputTokens,
+		CacheCreationTokens: usage.CacheCreationTokens,
+	}), Turns: 1}
+	if turnState := m.getTurnState(turn); turnState != nil && turnState.CorrectionCost != nil {
+		spent.Cost += *turnState.CorrectionCost
+	}
+	limits := *m.session.Budget
+	prior := m.sessionSpend(turn)
+	if exceeded := limits.CheckTurn(prior, spent); exceeded != nil {
+		return exceeded
+	}
+	// Cost is only known once a run ends, so a turn that lands exactly on a
+	// cost limit has nothing left for a correction
+	switch {
+	case limits.MaxTurnCost > 0 && spent.Cost >= limits.MaxTurnCost:
+		return &budget.Exceeded{Limit: budget.LimitTurnCost, Spent: spent.Cost, Max: limits.MaxTurnCost}
+	case limits.MaxCost > 0 && prior.Cost+spent.Cost >= limits.MaxCost:
+		return &budget.Exceeded{Limit: budget.LimitCost, Spent: prior.Cost + spent.Cost, Max: limits.MaxCost}
+	}
+	return nil
+}
+
+// recordBudgetExceeded marks the current turn and the session as over
+// budget. The turn keeps the usage metered so far so later turns count it.
+func (m *Manager) recordBudgetExceeded(exceeded *budget.Exceeded, usage *Usage) {
+	exceeded.Turn = m.currentTurn
+	m.budgetExceeded = exceeded
+	m.debugLogger.Log("BUDGET", exceeded.Error())
+
+	message := exceeded.Error()
+	m.session.Status = budget.StatusExceeded
+	m.session.BudgetExceeded = exceeded
+	m.session.Error = &message
+	if turnState := m.getTurnState(m.currentTurn); turnState != nil {
+		turnState.Error = &message
+		if usage != nil && turnState.Usage == nil {
+			turnState.Usage = usage
+		}
+	}
+
+	if m.eventWriter != nil {
+		m.eventWriter.WriteErrorEvent(ErrorEvent{
+			Phase:     fmt.Sprintf("turn-%d", m.currentTurn),
+			ErrorCode: "BUDGET_EXCEEDED",
+			Message:   message,
+		})
+	}
+	if err := m.writeSessionState(); err != nil {
+		m.debugLogger.LogError("Failed to write session state", err)
+	}
+}
+
+// stopForBudget stops the running turn after it crossed a limit. Like
+// StopSession it sends SIGTERM and escalates to SIGKILL after a timeout;
+// the process reaper then finalizes the turn as budget_exceeded.
+func (m *Manager) stopForBudget(exceeded *budget.Exceeded, usage Usage) {
+	m.recordBudgetExceeded(exceeded, &usage)
+
+	if m.processInfo == nil || m.processInfo.PID <= 0 {
+		return
+	}
+	process, err := os.FindProcess(m.processInfo.PID)
+	if err != nil {
+		m.debugLogger.LogError("Process not found", err)
+		return
+	}
+
+	m.debugLogger.Log("BUDGET", fmt.Sprintf("Sending SIGTERM to PID %d", m.processInfo.PID))
+	stopStatus := map[string]interface{}{
+		"status":     "stopping",
+		"reason":     budget.StatusExceeded,
+		"started_at": time.Now().UTC().Format(time.RFC3339Nano),
+		"agent_pid":  m.processInfo.PID,
+		"message":    exceeded.Error(),
+	}
+	if err := m.writeStopStatus(stopStatus); err != nil {
+		m.debugLogger.LogError("Failed to write stop status", err)
+	}
+	if err := process.Signal(syscall.SIGTERM); err != nil {
+		m.debugLogger.LogError("Failed to send SIGTERM", err)
+	}
+	m.budgetKill = time.AfterFunc(budgetKillTimeout, func() {
+		m.debugLogger.Log("BUDGET", "Graceful shutdown timeout, forcing kill")
+		process.Signal(syscall.SIGKILL)
+	})
+}
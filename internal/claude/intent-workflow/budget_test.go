@@ -0,0 +1,122 @@
+/**
+ * Component: Intent Workflow Budget Guardrails Tests
+ * Block-UUID: 3a9e5d07-c2b6-4f18-9e73-6d0b1f8a2c45
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests that a turn crossing a token limit is stopped and recorded as budget_exceeded, and that a spent cost budget refuses corrections and further turns.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intent_workflow
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
+)
+
+// newBudgetManager returns a session over an empty repository that replays
+// recordings and enforces limits.
+func newBudgetManager(t *testing.T, recordings string, limits budget.Limits) *Manager {
+	t.Helper()
+	gscHome := t.TempDir()
+	t.Setenv("GSC_HOME", gscHome)
+	copyTemplates(t, gscHome)
+
+	manager, err := NewManager("budget-test")
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	manager.SetBackend(backend.NewScriptedBackend(recordings))
+	workdirs := []WorkingDirectory{{ID: 1, Name: "app", Path: t.TempDir()}}
+	if err := manager.InitializeSession("find the entry point", workdirs, nil, false, "sonnet", true); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	if err := manager.SetBudget(limits); err != nil {
+		t.Fatalf("set budget: %v", err)
+	}
+	return manager
+}
+
+func TestBudgetStopsTurnOverTokenLimit(t *testing.T) {
+	recordings := t.TempDir()
+	var lines []string
+	for i, output := range []int{200, 900, 900} {
+		line, _ := json.Marshal(map[string]interface{}{
+			"type": "assistant",
+			"message": map[string]interface{}{
+				"id":      []string{"msg_1", "msg_1", "msg_2"}[i],
+				"usage":   map[string]int{"input_tokens": 1000, "output_tokens": output},
+				"content": []map[string]string{{"type": "text", "text": "reading files"}},
+			},
+		})
+		lines = append(lines, string(line))
+	}
+	lines = append(lines, `{"type":"result","result":"{}","total_cost_usd":0.4}`)
+	if err := os.WriteFile(filepath.Join(recordings, "discovery.ndjson"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	manager := newBudgetManager(t, recordings, budget.Limits{MaxTurnTokens: 2500})
+	err := manager.StartDiscoveryTurn()
+	var exceeded *budget.Exceeded
+	if !errors.As(err, &exceeded) || exceeded.Limit != budget.LimitTurnToken || exceeded.Turn != 1 {
+		t.Fatalf("discovery error = %v", err)
+	}
+
+	session := manager.GetSession()
+	turn := manager.getTurnState(1)
+	if session.Status != budget.StatusExceeded || turn.Status != budget.StatusExceeded {
+		t.Fatalf("status = %s, turn status = %s", session.Status, turn.Status)
+	}
+	// The second message pushed the turn over; the result never arrived
+	if turn.Usage == nil || turn.Usage.InputTokens+turn.Usage.OutputTokens != 3800 || turn.Cost != nil {
+		t.Fatalf("usage = %+v, cost = %v", turn.Usage, turn.Cost)
+	}
+
+	status, err := manager.GetSessionStatus()
+	if err != nil {
+		t.Fatalf("status: %v", err)
+	}
+	if status.Budget == nil || status.Budget.Exceeded == nil || status.Budget.Spent.Tokens != 3800 {
+		t.Fatalf("budget report = %+v", status.Budget)
+	}
+}
+
+func TestBudgetRefusesCorrectionAndNextTurn(t *testing.T) {
+	recordings := t.TempDir()
+	// discovery_mode is missing, which would normally trigger a correction
+	malformed := `{"candidates":[{"workdir_id":1,"workdir_name":"app","file_path":"main.go","score":0.9,"reasoning":"entry point"}],"total_found":1,"coverage":"full"}`
+	writeRecording(t, recordings, "discovery", malformed, 0.5)
+
+	manager := newBudgetManager(t, recordings, budget.Limits{MaxCost: 0.5})
+	err := manager.StartDiscoveryTurn()
+	var exceeded *budget.Exceeded
+	if !errors.As(err, &exceeded) || exceeded.Limit != budget.LimitCost {
+		t.Fatalf("discovery error = %v", err)
+	}
+	turn := manager.getTurnState(1)
+	if turn.CorrectionAttempts != 0 || turn.Cost == nil || *turn.Cost != 0.5 {
+		t.Fatalf("correction attempts = %d, cost = %v", turn.CorrectionAttempts, turn.Cost)
+	}
+	if manager.GetSession().Status != budget.StatusExceeded {
+		t.Fatalf("status = %s", manager.GetSession().Status)
+	}
+
+	// Even from discovery_complete the spent budget refuses the change turn
+	manager.GetSession().Status = "discovery_complete"
+	if err := manager.StartChangeTurn("log startup"); !errors.As(err, &exceeded) {
+		t.Fatalf("change started over budget: %v", err)
+	}
+	if len(manager.GetSession().Turns) != 1 {
+		t.Fatal("refused turn was recorded")
+	}
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Session Configuration Helper
- * Block-UUID: 65297147-0adf-4587-aef6-c824162579a3
- * Parent-UUID: b5527092-51ea-441e-8efd-7e37554a2594
- * Version: 1.5.0
- * Description: Generic session configuration helper for agent packages including path resolution, session directory management, and file operations. Updated to use generic agent constants.
+ * Block-UUID: fa9294d2-c61e-4684-888c-444863aa8592
+ * Parent-UUID: 65297147-0adf-4587-aef6-c824162579a3
+ * Version: 1.6.0
+ * Description: Generic session configuration helper for agent packages including path resolution, session directory management, and file operations. Added the state log (state.wal) path.
  * Language: Go
  * Created-at: 2026-04-05T15:47:01.233Z
- * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.0.2), GLM-4.7 (v1.0.3), GLM-4.7 (v1.0.4), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0)
+ * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.0.2), GLM-4.7 (v1.0.3), GLM-4.7 (v1.0.4), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), agent (v1.6.0)
  */
 
 
@@ -49,6 +49,11 @@ func (sc *SessionConfig) GetSessionFile() string {
 	return filepath.Join(sc.GetSessionDir(), "session.json")
 }
 
+// GetStateLogFile returns the absolute path to the session's state.wal log
+func (sc *SessionConfig) GetStateLogFile() string {
+	return filepath.Join(sc.GetSessionDir(), "state.wal")
+}
+
 // GetIntentFile returns the absolute path to the intent.json file
 func (sc *SessionConfig) GetIntentFile(turn int) string {
 	return filepath.Join(sc.GetTurnDir(turn), settings.SessionIntentFileName)
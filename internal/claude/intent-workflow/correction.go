@@ -1,28 +1,26 @@
 /**
  * Component: Intent Workflow Correction
- * Block-UUID: db12a45c-4092-48b7-b2b7-febc1836b1ab
- * Parent-UUID: b412d44f-9261-495b-95f7-e9cd534792af
- * Version: 1.4.0
- * Description: Spawning and handling correction subprocesses - building prompts, executing the correction turn, and parsing results. Fixed extractStreamCost to check root level for total_cost_usd before checking inside usage object. FIXED response_format.md copy logic to always copy the file (not just if it doesn't exist) to ensure turn directory has the latest version.
+ * Block-UUID: 4fda7aa0-fbdf-4d99-9a84-29cc2b178efe
+ * Parent-UUID: 6e9f0c98-2fdb-446e-b328-1b6c1a35a543
+ * Version: 1.6.0
+ * Description: Spawning and handling correction subprocesses - building prompts, executing the correction turn, and parsing results. Correction and metadata-correction runs go through the agent backend and use the correction phase of the session's permission profile instead of a fixed Read-only policy.
  * Language: Go
  * Created-at: 2026-04-25T12:47:40.091Z
- * Authors: Gemini 2.5 Flash Lite (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), Gemini 3 Flash (v1.3.0), GLM-4.7 (v1.4.0)
+ * Authors: Gemini 2.5 Flash Lite (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), Gemini 3 Flash (v1.3.0), GLM-4.7 (v1.4.0), agent (v1.5.0), agent (v1.6.0)
  */
 
 
 package intent_workflow
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
 	"strings"
 	"time"
 
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
 	"github.com/gitsense/gsc-cli/pkg/settings"
 )
 
@@ -91,39 +89,12 @@ func buildCorrectionPrompt(attempt int, formatErrorsJSON, badResponseJSON string
 	)
 }
 
-// extractCorrectionResultText scans NDJSON output from a stream-json Claude
-// subprocess and returns the assistant's final text block, which is expected
-// to contain the CorrectionResult JSON. Returns an error if no text is found.
-func extractCorrectionResultText(ndjsonOutput []byte) (string, error) {
-	var lastText string
-	for _, raw := range strings.Split(string(ndjsonOutput), "\n") {
-		raw = strings.TrimSpace(raw)
-		if raw == "" {
-			continue
-		}
-		var event map[string]interface{}
-		if err := json.Unmarshal([]byte(raw), &event); err != nil {
-			continue
-		}
-		switch event["type"] {
-		case "assistant":
-			msg, _ := event["message"].(map[string]interface{})
-			content, _ := msg["content"].([]interface{})
-			for _, block := range content {
-				b, _ := block.(map[string]interface{})
-				if b["type"] == "text" {
-					if text, ok := b["text"].(string); ok {
-						lastText = text
-					}
-				}
-			}
-		case "result":
-			if result, ok := event["result"].(string); ok && result != "" {
-				lastText = result
-			}
-		}
-	}
-	if lastText == "" {
+// extractCorrectionResultText returns the agent's final text from a
+// correction run, which is expected to contain the CorrectionResult JSON,
+// with any markdown fence removed. Returns an error if no text is found.
+func extractCorrectionResultText(agent backend.AgentBackend, output []byte) (string, error) {
+	lastText, err := backend.ResultText(agent, output)
+	if err != nil {
 		return "", fmt.Errorf("no text content found in correction subprocess output")
 	}
 	lastText = strings.TrimSpace(lastText)
@@ -137,39 +108,6 @@ func extractCorrectionResultText(ndjsonOutput []byte) (string, error) {
 	return lastText, nil
 }
 
-// extractStreamCost returns the total cost in USD from the result event in
-// stream-json NDJSON output, or 0 if the event is absent or malformed.
-// It checks the root level first (current CLI format) and falls back to
-// checking inside the usage object (legacy format).
-func extractStreamCost(ndjsonOutput []byte) float64 {
-	for _, raw := range strings.Split(string(ndjsonOutput), "\n") {
-		raw = strings.TrimSpace(raw)
-		if raw == "" {
-			continue
-		}
-		var event map[string]interface{}
-		if err := json.Unmarshal([]byte(raw), &event); err != nil {
-			continue
-		}
-		if t, _ := event["type"].(string); t != "result" {
-			continue
-		}
-		
-		// Check root level first (current CLI format)
-		if cost, ok := event["total_cost_usd"].(float64); ok {
-			return cost
-		}
-		
-		// Fallback: Check inside usage (legacy format)
-		if usage, ok := event["usage"].(map[string]interface{}); ok {
-			if cost, ok := usage["total_cost_usd"].(float64); ok {
-				return cost
-			}
-		}
-	}
-	return 0
-}
-
 // spawnCorrectionSubprocess prepares correction-turn files, executes the
 // Claude correction subprocess synchronously, and on success calls
 // updateSessionWithCorrectedResults to persist the corrected data.
@@ -222,60 +160,42 @@ func (m *Manager) spawnCorrectionSubprocess(turnNumber int, modelID string) erro
 		return fmt.Errorf("failed to write correction prompt: %w", err)
 	}
 
-	// Build the subprocess command.
-	var cmd *exec.Cmd
-	if runtime.GOOS == "windows" {
-		promptContent, readErr := os.ReadFile(promptPath)
-		if readErr != nil {
-			return fmt.Errorf("failed to read correction prompt: %w", readErr)
-		}
-		args := []string{
-			"--allowedTools", "Read",
-			"--verbose",
-			"--include-partial-messages",
-			"--output-format", "stream-json",
-			"--append-system-prompt-file", systemPromptPath,
-			"--model", modelID,
-			"-p", string(promptContent),
-		}
-		cmd = exec.Command("claude", args...)
-	} else {
-		logPath := filepath.Join(turnDir,
-			fmt.Sprintf("correction-raw-stream-%d.ndjson", time.Now().UnixNano()))
-		scriptContent := fmt.Sprintf(`#!/bin/bash
-set -e
-cd "%s"
-claude --allowedTools Read \
---verbose \
---include-partial-messages \
---output-format stream-json \
---append-system-prompt-file correction-system-prompt.md \
---model %s \
--p "$(cat correction-prompt.md)" | tee "%s"
-`, turnDir, modelID, logPath)
-		scriptPath := filepath.Join(turnDir, "run-correction.sh")
-		if err := os.WriteFile(scriptPath, []byte(scriptContent), 0755); err != nil {
-			return fmt.Errorf("failed to write correction script: %w", err)
-		}
-		cmd = exec.Command("/bin/bash", scriptPath)
+	agent, err := m.agentBackend()
+	if err != nil {
+		return fmt.Errorf("failed to resolve agent backend: %w", err)
+	}
+	permissions := m.permissionsFor(PhaseCorrection).toBackend()
+	if err := agent.ConfigurePermissions(turnDir, permissions); err != nil {
+		return fmt.Errorf("failed to write correction permissions: %w", err)
 	}
-	cmd.Dir = turnDir
-
-	var outputBuf bytes.Buffer
-	cmd.Stdout = &outputBuf
-	cmd.Stderr = os.Stderr
 
 	m.debugLogger.Log("DEBUG", fmt.Sprintf(
-		"Starting correction subprocess for turn %d (attempt %d, model %s)",
-		turnNumber, turnState.CorrectionAttempts, modelID,
+		"Starting correction subprocess for turn %d (attempt %d, model %s, backend %s)",
+		turnNumber, turnState.CorrectionAttempts, modelID, agent.Name(),
 	))
 
-	if err := cmd.Run(); err != nil {
+	output, err := backend.Run(agent, backend.SpawnRequest{
+		Label:            "correction",
+		Turn:             turnNumber,
+		SessionID:        m.session.SessionID,
+		Dir:              turnDir,
+		Prompt:           prompt,
+		SystemPromptFile: "correction-system-prompt.md",
+		Model:            modelID,
+		Permissions:      permissions,
+	})
+
+	// Keep the raw stream of every attempt for auditability.
+	logPath := filepath.Join(turnDir,
+		fmt.Sprintf("correction-raw-stream-%d.ndjson", time.Now().UnixNano()))
+	_ = os.WriteFile(logPath, output, 0644)
+
+	if err != nil {
 		return fmt.Errorf("correction subprocess exited with error: %w", err)
 	}
 
-	// Extract Claude's text response from the NDJSON stream.
-	resultText, err := extractCorrectionResultText(outputBuf.Bytes())
+	// Extract the agent's text response from the stream.
+	resultText, err := extractCorrectionResultText(agent, output)
 	if err != nil {
 		return fmt.Errorf("failed to extract correction result text: %w", err)
 	}
@@ -305,12 +225,14 @@ claude --allowedTools Read \
 		return fmt.Errorf("corrected output has %d remaining format errors", len(validationErrs))
 	}
 
-	return m.updateSessionWithCorrectedResults(turnNumber, correctedResults, extractStreamCost(outputBuf.Bytes()))
+	return m.updateSessionWithCorrectedResults(turnNumber, correctedResults, backend.Cost(agent, output))
 }
 
-// SpawnMetadataCorrectionSubprocess spawns a Haiku subprocess to fix malformed metadata files.
-// It reads the bad-metadata-files.json and writes corrected content back to the original files.
-func SpawnMetadataCorrectionSubprocess(turnDir, badMetaPath string) error {
+// SpawnMetadataCorrectionSubprocess runs the correction model on the given
+// backend to fix malformed metadata files. It reads the bad-metadata-files.json
+// and writes corrected content back to the original files, running under the
+// given correction-phase permissions.
+func SpawnMetadataCorrectionSubprocess(agent backend.AgentBackend, turnDir, badMetaPath string, permissions backend.Permissions) error {
 	// Resolve the correction model ID from the default family
 	modelID, err := GetModelID(DefaultCorrectionModel)
 	if err != nil {
@@ -322,18 +244,24 @@ For each file, fix the JSON syntax and structure to match the GSCFileData schema
 Return a JSON object mapping file paths to corrected JSON content.
 Do NOT wrap the output in markdown code blocks.`, badMetaPath)
 
-	cmd := exec.Command("claude", "-p", prompt, "--model", modelID)
-	cmd.Dir = turnDir
-
-	// Use Output instead of CombinedOutput to prevent stderr from corrupting JSON
-	output, err := cmd.Output()
+	output, err := backend.Run(agent, backend.SpawnRequest{
+		Label:       "metadata-correction",
+		Dir:         turnDir,
+		Prompt:      prompt,
+		Model:       modelID,
+		Permissions: permissions,
+	})
+	if err != nil {
+		return fmt.Errorf("correction command failed: %w", err)
+	}
+	resultText, err := backend.ResultText(agent, output)
 	if err != nil {
-		return fmt.Errorf("correction command failed: %w, output: %s", err, string(output))
+		return fmt.Errorf("correction command failed: %w", err)
 	}
 
 	// Parse correction result
 	var corrections map[string]string
-	if err := json.Unmarshal(output, &corrections); err != nil {
+	if err := json.Unmarshal([]byte(extractJSONPayload(resultText)), &corrections); err != nil {
 		return fmt.Errorf("failed to parse correction output: %w", err)
 	}
 
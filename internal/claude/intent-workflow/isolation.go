@@ -0,0 +1,407 @@
+/**
+ * Component: Isolated Change Worktrees
+ * Block-UUID: e4d134bd-93e9-47e4-be80-a90b3271350c
+ * Parent-UUID: 6d1f8b27-4a3e-4c95-9e70-b2c5a8d3f416
+ * Version: 1.2.0
+ * Description: Runs change turns in per-repository git worktrees (--isolated) by pointing the session's working directories at the worktrees for the turn, collects a patch per repository afterwards, warns when a checkout has uncommitted changes the worktree leaves out, and lands or discards the patches with apply, cherry-pick, and reject, recording files that needed a three-way merge. Cherry-picked paths are escaped so git apply matches them literally.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0)
+ */
+
+package intent_workflow
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/git"
+)
+
+// Isolated change statuses
+const (
+	IsolatedStatusRunning  = "running"
+	IsolatedStatusPending  = "pending"
+	IsolatedStatusPartial  = "partial"
+	IsolatedStatusApplied  = "applied"
+	IsolatedStatusRejected = "rejected"
+)
+
+// Pending returns the files in the patch that have not been applied yet.
+func (w IsolatedWorktree) Pending() []string {
+	applied := make(map[string]bool, len(w.Applied))
+	for _, f := range w.Applied {
+		applied[f] = true
+	}
+	var pending []string
+	for _, f := range w.Files {
+		if !applied[f] {
+			pending = append(pending, f)
+		}
+	}
+	return pending
+}
+
+// ValidateIsolation checks that every working directory is inside a git
+// repository with at least one commit and that no earlier isolated change
+// is still waiting for review.
+func (m *Manager) ValidateIsolation() error {
+	if ic := m.unresolvedIsolatedChange(); ic != nil {
+		return fmt.Errorf("turn %d has isolated changes awaiting review; run 'gsc claude change apply' or 'gsc claude change reject' first", ic.Turn)
+	}
+	for _, wd := range m.session.WorkingDirectories {
+		root, err := git.FindGitRootFrom(wd.Path)
+		if err != nil {
+			return fmt.Errorf("working directory %s is not in a git repository; --isolated requires git", wd.Path)
+		}
+		if _, err := runGitCombined(root, "rev-parse", "--verify", "HEAD"); err != nil {
+			return fmt.Errorf("repository %s has no commits; --isolated needs a HEAD to branch the worktree from", root)
+		}
+	}
+	return nil
+}
+
+// BeginIsolatedChange creates a detached worktree at HEAD for every
+// repository behind the session's working directories and points the
+// working directories at them, so the change turn and its post-processing
+// never touch the user's checkout. FinishIsolatedChange must be called once
+// the turn is over, even if it failed.
+func (m *Manager) BeginIsolatedChange(turn int) error {
+	if err := m.ValidateIsolation(); err != nil {
+		return err
+	}
+
+	worktreesDir := filepath.Join(m.config.GetSessionDir(), "worktrees", fmt.Sprintf("turn-%d", turn))
+	ic := IsolatedChange{
+		Turn:             turn,
+		Status:           IsolatedStatusRunning,
+		OriginalWorkdirs: append([]WorkingDirectory(nil), m.session.WorkingDirectories...),
+		CreatedAt:        time.Now(),
+	}
+
+	byRoot := make(map[string]int)
+	isolated := make([]WorkingDirectory, 0, len(m.session.WorkingDirectories))
+	for _, wd := range m.session.WorkingDirectories {
+		root, err := git.FindGitRootFrom(wd.Path)
+		if err != nil {
+			m.removeWorktrees(ic.Worktrees)
+			return fmt.Errorf("working directory %s is not in a git repository: %w", wd.Path, err)
+		}
+
+		idx, ok := byRoot[root]
+		if !ok {
+			head, err := runGitCombined(root, "rev-parse", "HEAD")
+			if err != nil {
+				m.removeWorktrees(ic.Worktrees)
+				return fmt.Errorf("failed to resolve HEAD in %s: %w", root, err)
+			}
+			// The worktree starts from HEAD, so uncommitted work in the
+			// checkout is not visible to the turn
+			if status, err := runGitOutput(root, "status", "--porcelain"); err == nil && strings.TrimSpace(status) != "" {
+				ic.DirtyRoots = append(ic.DirtyRoots, root)
+				fmt.Fprintf(os.Stderr, "WARNING: %s has uncommitted changes; the isolated turn starts from HEAD and will not see them\n", root)
+				m.debugLogger.Log("ISOLATION", fmt.Sprintf("Uncommitted changes in %s left out of the worktree", root))
+			}
+			path := filepath.Join(worktreesDir, fmt.Sprintf("%d-%s", len(ic.Worktrees)+1, filepath.Base(root)))
+			if _, err := runGitCombined(root, "worktree", "add", "--detach", path, strings.TrimSpace(head)); err != nil {
+				m.removeWorktrees(ic.Worktrees)
+				return fmt.Errorf("failed to create worktree for %s: %w", root, err)
+			}
+			idx = len(ic.Worktrees)
+			byRoot[root] = idx
+			ic.Worktrees = append(ic.Worktrees, IsolatedWorktree{
+				RepoRoot:     root,
+				WorktreePath: path,
+				BaseSHA:      strings.TrimSpace(head),
+			})
+			m.debugLogger.Log("ISOLATION", fmt.Sprintf("Created worktree %s for %s at %s", path, root, strings.TrimSpace(head)))
+		}
+
+		abs, err := filepath.Abs(wd.Path)
+		if err != nil {
+			abs = wd.Path
+		}
+		rel, err := filepath.Rel(root, abs)
+		if err != nil {
+			rel = "."
+		}
+		isolated = append(isolated, WorkingDirectory{
+			ID:   wd.ID,
+			Name: wd.Name,
+			Path: filepath.Join(ic.Worktrees[idx].WorktreePath, rel),
+		})
+	}
+
+	m.session.WorkingDirectories = isolated
+	m.session.IsolatedChanges = append(m.session.IsolatedChanges, ic)
+	return m.writeSessionState()
+}
+
+// FinishIsolatedChange restores the original working directories and
+// collects one patch per worktree into the turn directory. The worktrees are
+// kept until the patches are applied or rejected.
+func (m *Manager) FinishIsolatedChange(turn int) error {
+	var ic *IsolatedChange
+	for i := range m.session.IsolatedChanges {
+		if m.session.IsolatedChanges[i].Turn == turn && m.session.IsolatedChanges[i].Status == IsolatedStatusRunning {
+			ic = &m.session.IsolatedChanges[i]
+		}
+	}
+	if ic == nil {
+		return fmt.Errorf("no running isolated change for turn %d", turn)
+	}
+
+	m.session.WorkingDirectories = ic.OriginalWorkdirs
+	ic.Status = IsolatedStatusPending
+
+	patchDir := filepath.Join(m.config.GetTurnDir(turn), "isolated")
+	var collectErr error
+	if err := os.MkdirAll(patchDir, 0755); err != nil {
+		collectErr = fmt.Errorf("failed to create patch directory: %w", err)
+	}
+	for i := range ic.Worktrees {
+		if collectErr != nil {
+			break
+		}
+		collectErr = collectWorktreePatch(&ic.Worktrees[i], patchDir)
+	}
+
+	if err := m.writeSessionState(); err != nil {
+		return err
+	}
+	return collectErr
+}
+
+// collectWorktreePatch stages everything the turn changed in the worktree,
+// except leftover .change-meta.json files, and writes it as a binary patch
+// against the base commit.
+func collectWorktreePatch(wt *IsolatedWorktree, patchDir string) error {
+	if _, err := runGitCombined(wt.WorktreePath, "add", "-A", "--", ".", ":(exclude,glob)**/.*.change-meta.json"); err != nil {
+		return fmt.Errorf("failed to stage changes in %s: %w", wt.WorktreePath, err)
+	}
+	names, err := runGitOutput(wt.WorktreePath, "-c", "core.quotePath=false", "diff", "--cached", "--name-only", wt.BaseSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list changes in %s: %w", wt.WorktreePath, err)
+	}
+	wt.Files = nil
+	for _, line := range strings.Split(names, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			wt.Files = append(wt.Files, line)
+		}
+	}
+	if len(wt.Files) == 0 {
+		return nil
+	}
+
+	patch, err := runGitOutput(wt.WorktreePath, "diff", "--cached", "--binary", wt.BaseSHA)
+	if err != nil {
+		return fmt.Errorf("failed to build patch in %s: %w", wt.WorktreePath, err)
+	}
+	wt.PatchPath = filepath.Join(patchDir, filepath.Base(wt.WorktreePath)+".patch")
+	if err := os.WriteFile(wt.PatchPath, []byte(patch), 0644); err != nil {
+		return fmt.Errorf("failed to write patch: %w", err)
+	}
+	return nil
+}
+
+// PendingIsolatedChange returns the isolated change awaiting review.
+func (m *Manager) PendingIsolatedChange() (*IsolatedChange, error) {
+	ic := m.unresolvedIsolatedChange()
+	if ic == nil {
+		return nil, fmt.Errorf("no isolated change awaiting review in session %s", m.session.SessionID)
+	}
+	if ic.Status == IsolatedStatusRunning {
+		return nil, fmt.Errorf("isolated change for turn %d is still running", ic.Turn)
+	}
+	return ic, nil
+}
+
+// ApplyIsolatedChange applies the pending isolated patches to the original
+// repositories. With no paths every file not yet applied is landed;
+// otherwise only the named files are (cherry-pick). Paths may be relative
+// to a working directory or repository root, or absolute. Once every file
+// is applied the worktrees are removed. It returns the applied files as
+// absolute paths; files that needed a three-way merge are also recorded in
+// the worktree's ThreeWay list.
+func (m *Manager) ApplyIsolatedChange(paths []string) ([]string, error) {
+	ic, err := m.PendingIsolatedChange()
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make(map[string]bool, len(paths))
+	var applied []string
+	for i := range ic.Worktrees {
+		wt := &ic.Worktrees[i]
+		var selected []string
+		for _, f := range wt.Pending() {
+			if len(paths) == 0 {
+				selected = append(selected, f)
+				continue
+			}
+			for _, p := range paths {
+				if isolatedPathMatches(p, f, wt.RepoRoot, ic.OriginalWorkdirs) {
+					selected = append(selected, f)
+					matched[p] = true
+					break
+				}
+			}
+		}
+		if len(selected) == 0 {
+			continue
+		}
+		threeWay, err := applyPatchFiles(wt.RepoRoot, wt.PatchPath, selected)
+		if err != nil {
+			m.writeSessionState()
+			return applied, err
+		}
+		if threeWay {
+			wt.ThreeWay = append(wt.ThreeWay, selected...)
+			m.debugLogger.Log("ISOLATION", fmt.Sprintf("Applied %d file(s) to %s with a three-way merge", len(selected), wt.RepoRoot))
+		}
+		wt.Applied = append(wt.Applied, selected...)
+		for _, f := range selected {
+			applied = append(applied, filepath.Join(wt.RepoRoot, f))
+		}
+	}
+
+	for _, p := range paths {
+		if !matched[p] {
+			return applied, fmt.Errorf("%s is not a pending file in the isolated change for turn %d", p, ic.Turn)
+		}
+	}
+
+	remaining := 0
+	for _, wt := range ic.Worktrees {
+		remaining += len(wt.Pending())
+	}
+	if remaining == 0 {
+		m.resolveIsolatedChange(ic, IsolatedStatusApplied)
+	} else if len(applied) > 0 {
+		ic.Status = IsolatedStatusPartial
+	}
+	return applied, m.writeSessionState()
+}
+
+// RejectIsolatedChange discards the pending isolated change and removes its
+// worktrees. The patches stay in the turn directory for reference.
+func (m *Manager) RejectIsolatedChange() (*IsolatedChange, error) {
+	ic, err := m.PendingIsolatedChange()
+	if err != nil {
+		return nil, err
+	}
+	m.resolveIsolatedChange(ic, IsolatedStatusRejected)
+	return ic, m.writeSessionState()
+}
+
+func (m *Manager) resolveIsolatedChange(ic *IsolatedChange, status string) {
+	now := time.Now()
+	ic.Status = status
+	ic.ResolvedAt = &now
+	m.removeWorktrees(ic.Worktrees)
+}
+
+func (m *Manager) unresolvedIsolatedChange() *IsolatedChange {
+	for i := len(m.session.IsolatedChanges) - 1; i >= 0; i-- {
+		switch m.session.IsolatedChanges[i].Status {
+		case IsolatedStatusRunning, IsolatedStatusPending, IsolatedStatusPartial:
+			return &m.session.IsolatedChanges[i]
+		}
+	}
+	return nil
+}
+
+func (m *Manager) removeWorktrees(worktrees []IsolatedWorktree) {
+	for _, wt := range worktrees {
+		if _, err := runGitCombined(wt.RepoRoot, "worktree", "remove", "--force", wt.WorktreePath); err != nil {
+			m.debugLogger.Log("ISOLATION", fmt.Sprintf("git worktree remove failed for %s: %v", wt.WorktreePath, err))
+			os.RemoveAll(wt.WorktreePath)
+			runGitCombined(wt.RepoRoot, "worktree", "prune")
+		}
+	}
+}
+
+// applyPatchFiles applies the selected files of a patch to the working tree
+// of repoRoot, falling back to a three-way merge when the checkout has moved
+// on from the base commit. It reports whether the fallback was used; git
+// then also stages the files in the index.
+func applyPatchFiles(repoRoot, patchPath string, files []string) (bool, error) {
+	args := []string{"apply", "--whitespace=nowarn"}
+	for _, f := range files {
+		args = append(args, "--include="+escapeGlob(f))
+	}
+	args = append(args, patchPath)
+
+	if _, err := runGitCombined(repoRoot, args...); err == nil {
+		return false, nil
+	}
+	threeWay := append([]string{"apply", "--3way"}, args[1:]...)
+	if _, err := runGitCombined(repoRoot, threeWay...); err != nil {
+		return false, fmt.Errorf("failed to apply %s to %s: %w", patchPath, repoRoot, err)
+	}
+	return true, nil
+}
+
+// escapeGlob quotes the wildcard characters in a path so git apply --include,
+// which takes a glob, matches only that path.
+func escapeGlob(path string) string {
+	var b strings.Builder
+	for _, r := range path {
+		if strings.ContainsRune(`\*?[`, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isolatedPathMatches reports whether the user-supplied path names the
+// repository-relative file f.
+func isolatedPathMatches(p, f, repoRoot string, workdirs []WorkingDirectory) bool {
+	p = filepath.Clean(p)
+	if filepath.ToSlash(p) == f {
+		return true
+	}
+	target := filepath.Join(repoRoot, f)
+	if filepath.IsAbs(p) {
+		return p == target
+	}
+	if abs, err := filepath.Abs(p); err == nil && abs == target {
+		return true
+	}
+	for _, wd := range workdirs {
+		if filepath.Join(wd.Path, p) == target {
+			return true
+		}
+	}
+	return false
+}
+
+// runGitCombined runs git in dir and includes its stderr in the error.
+func runGitCombined(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return string(out), fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
+
+// runGitOutput runs git in dir and returns its stdout only, for output that
+// must not pick up warnings; stderr is included in the error.
+func runGitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return string(out), fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
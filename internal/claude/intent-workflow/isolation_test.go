@@ -0,0 +1,267 @@
+/**
+ * Component: Isolated Change Worktree Tests
+ * Block-UUID: 9bafb8bf-a290-41cf-9c6e-efa7c7fa724d
+ * Parent-UUID: 2f9c6a48-0b17-4d3e-8e52-c7a1d4b9f630
+ * Version: 1.2.0
+ * Description: Tests that isolated change turns leave the checkout untouched, collect a patch per repository, and land or discard it with cherry-pick, apply, and reject, including the dirty-checkout warning, the three-way fallback, and cherry-picked paths that contain glob characters.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0)
+ */
+
+package intent_workflow
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newIsolationManager returns a session over a committed repository with
+// main.go and util.go.
+func newIsolationManager(t *testing.T) (*Manager, string) {
+	t.Helper()
+	gscHome := t.TempDir()
+	t.Setenv("GSC_HOME", gscHome)
+	copyTemplates(t, gscHome)
+
+	repo := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Skipf("git %v: %v %s", args, err, out)
+		}
+	}
+	git("init", "-q")
+	for _, name := range []string{"main.go", "util.go"} {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte("package main\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+
+	manager, err := NewManager("isolation-test")
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	workdirs := []WorkingDirectory{{ID: 1, Name: "app", Path: repo}}
+	if err := manager.InitializeSession("edit files", workdirs, nil, false, "sonnet", true); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	return manager, repo
+}
+
+// runIsolatedTurn edits both files and adds a new one inside the worktree,
+// the way an agent would during the turn.
+func runIsolatedTurn(t *testing.T, manager *Manager, repo string) {
+	t.Helper()
+	if err := manager.BeginIsolatedChange(2); err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	wd := manager.GetSession().WorkingDirectories[0].Path
+	if wd == repo || !strings.HasPrefix(wd, manager.GetConfig().GetSessionDir()) {
+		t.Fatalf("working directory not moved into a worktree: %s", wd)
+	}
+	for name, content := range map[string]string{
+		"main.go":                   "package main\n\nfunc main() {}\n",
+		"util.go":                   "package main\n\nfunc util() {}\n",
+		"new.go":                    "package main\n",
+		".main.go.change-meta.json": "{}",
+	} {
+		if err := os.WriteFile(filepath.Join(wd, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := manager.FinishIsolatedChange(2); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if got := manager.GetSession().WorkingDirectories[0].Path; got != repo {
+		t.Fatalf("working directory not restored: %s", got)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+func TestIsolatedChangeCherryPickThenApply(t *testing.T) {
+	manager, repo := newIsolationManager(t)
+	runIsolatedTurn(t, manager, repo)
+
+	if got := readFile(t, filepath.Join(repo, "main.go")); got != "package main\n" {
+		t.Fatalf("checkout modified during turn: %q", got)
+	}
+	ic, err := manager.PendingIsolatedChange()
+	if err != nil {
+		t.Fatalf("pending: %v", err)
+	}
+	if files := strings.Join(ic.Worktrees[0].Files, ","); files != "main.go,new.go,util.go" {
+		t.Fatalf("patch files = %s", files)
+	}
+	if err := manager.ValidateIsolation(); err == nil {
+		t.Fatal("second isolated turn allowed while one is pending")
+	}
+
+	applied, err := manager.ApplyIsolatedChange([]string{"util.go"})
+	if err != nil || len(applied) != 1 {
+		t.Fatalf("cherry-pick = %v, %v", applied, err)
+	}
+	if !strings.Contains(readFile(t, filepath.Join(repo, "util.go")), "func util") || readFile(t, filepath.Join(repo, "main.go")) != "package main\n" {
+		t.Fatal("cherry-pick applied the wrong files")
+	}
+	if ic.Status != IsolatedStatusPartial {
+		t.Fatalf("status after cherry-pick = %s", ic.Status)
+	}
+	if _, err := manager.ApplyIsolatedChange([]string{"missing.go"}); err == nil {
+		t.Fatal("cherry-pick of a file outside the patch succeeded")
+	}
+
+	if _, err := manager.ApplyIsolatedChange(nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if !strings.Contains(readFile(t, filepath.Join(repo, "main.go")), "func main") || readFile(t, filepath.Join(repo, "new.go")) == "" {
+		t.Fatal("apply did not land the remaining files")
+	}
+	if _, err := os.Stat(filepath.Join(repo, ".main.go.change-meta.json")); err == nil {
+		t.Fatal("change-meta file leaked into the patch")
+	}
+	if ic.Status != IsolatedStatusApplied {
+		t.Fatalf("status after apply = %s", ic.Status)
+	}
+	if _, err := os.Stat(ic.Worktrees[0].WorktreePath); !os.IsNotExist(err) {
+		t.Fatal("worktree not removed after apply")
+	}
+}
+
+func TestIsolatedChangeReject(t *testing.T) {
+	manager, repo := newIsolationManager(t)
+	runIsolatedTurn(t, manager, repo)
+
+	ic, err := manager.RejectIsolatedChange()
+	if err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if ic.Status != IsolatedStatusRejected || ic.ResolvedAt == nil {
+		t.Fatalf("status after reject = %s", ic.Status)
+	}
+	if readFile(t, filepath.Join(repo, "main.go")) != "package main\n" || readFile(t, filepath.Join(repo, "new.go")) != "" {
+		t.Fatal("reject touched the checkout")
+	}
+	if _, err := os.Stat(ic.Worktrees[0].PatchPath); err != nil {
+		t.Fatalf("patch not kept: %v", err)
+	}
+	if _, err := manager.PendingIsolatedChange(); err == nil {
+		t.Fatal("rejected change still pending")
+	}
+}
+
+func TestIsolatedChangeDirtyTreeAndThreeWay(t *testing.T) {
+	manager, repo := newIsolationManager(t)
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v %s", args, err, out)
+		}
+	}
+	lines := "l1\nl2\nl3\nl4\nl5\nl6\nl7\n"
+	if err := os.WriteFile(filepath.Join(repo, "big.txt"), []byte(lines), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "big.txt")
+	git("commit", "-q", "-m", "big")
+	if err := os.WriteFile(filepath.Join(repo, "notes.txt"), []byte("uncommitted\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := manager.BeginIsolatedChange(2); err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	ic := manager.unresolvedIsolatedChange()
+	if len(ic.DirtyRoots) != 1 {
+		t.Fatalf("dirty roots = %v", ic.DirtyRoots)
+	}
+	wd := manager.GetSession().WorkingDirectories[0].Path
+	if _, err := os.Stat(filepath.Join(wd, "notes.txt")); err == nil {
+		t.Fatal("uncommitted file appeared in the worktree")
+	}
+	if err := os.WriteFile(filepath.Join(wd, "big.txt"), []byte(strings.Replace(lines, "l2", "L2", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.FinishIsolatedChange(2); err != nil {
+		t.Fatalf("finish: %v", err)
+	}
+	if patch := readFile(t, ic.Worktrees[0].PatchPath); !strings.HasPrefix(patch, "diff --git") {
+		t.Fatalf("patch does not start with a diff: %q", patch)
+	}
+
+	// Move the checkout on inside the patch context so a plain apply fails
+	if err := os.WriteFile(filepath.Join(repo, "big.txt"), []byte(strings.Replace(lines, "l5", "L5", 1)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("commit", "-q", "-am", "moved on")
+
+	if _, err := manager.ApplyIsolatedChange(nil); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if got := readFile(t, filepath.Join(repo, "big.txt")); got != "l1\nL2\nl3\nl4\nL5\nl6\nl7\n" {
+		t.Fatalf("merged big.txt = %q", got)
+	}
+	if got := strings.Join(ic.Worktrees[0].ThreeWay, ","); got != "big.txt" {
+		t.Fatalf("three-way files = %q", got)
+	}
+}
+
+func TestApplyPatchFilesMatchesPathsLiterally(t *testing.T) {
+	repo := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repo
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Skipf("git %v: %v %s", args, err, out)
+		}
+		return string(out)
+	}
+	git("init", "-q")
+	names := []string{"a1.go", "a[1].go", "b*.go", "bb.go"}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte("package main\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte("package main\n\n// changed\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	patchPath := filepath.Join(t.TempDir(), "change.patch")
+	if err := os.WriteFile(patchPath, []byte(git("diff")), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("checkout", "--", ".")
+
+	// As globs, a[1].go would match a1.go and b*.go would match bb.go
+	if _, err := applyPatchFiles(repo, patchPath, []string{"a[1].go", "b*.go"}); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	for name, changed := range map[string]bool{"a1.go": false, "a[1].go": true, "b*.go": true, "bb.go": false} {
+		if got := strings.Contains(readFile(t, filepath.Join(repo, name)), "changed"); got != changed {
+			t.Errorf("%s changed = %v, want %v", name, got, changed)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+/**
+ * Component: Intent Workflow Discovery Learning
+ * Block-UUID: 1e8b4d92-5f3a-4c07-b6e1-d4a7c2f90e38
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Aggregates keyword suggestions across sessions: the suggested keywords change turns report for files discovery missed, attributed to the file, and the new keywords discovery and validation turns report, kept unattributed for review.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intent_workflow
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LearnedKeyword is a keyword suggestion and the sessions that made it.
+type LearnedKeyword struct {
+	Keyword  string   `json:"keyword"`
+	Sessions []string `json:"sessions"`
+}
+
+// LearnedFile collects the keywords suggested for a file discovery missed.
+type LearnedFile struct {
+	Path     string           `json:"path"` // Absolute path in the original working directory
+	Keywords []LearnedKeyword `json:"keywords"`
+	Reasons  []string         `json:"reasons,omitempty"` // Why change turns needed the file
+}
+
+// LearnReport aggregates keyword suggestions across sessions.
+type LearnReport struct {
+	SessionsScanned int              `json:"sessions_scanned"`
+	Files           []LearnedFile    `json:"files"`
+	Unattributed    []LearnedKeyword `json:"unattributed,omitempty"` // New keywords not tied to a file
+}
+
+// LearnOptions controls which suggestions LearnFromSessions keeps.
+type LearnOptions struct {
+	MinSessions int // Keep keywords suggested by at least this many sessions (default 1)
+}
+
+// LearnFromSessions aggregates keyword suggestions from the given sessions,
+// or from every readable session under gscHome when none are given.
+func LearnFromSessions(gscHome string, sessionIDs []string, opts LearnOptions) (*LearnReport, error) {
+	explicit := len(sessionIDs) > 0
+	if !explicit {
+		all, err := ListSessions(gscHome)
+		if err != nil {
+			return nil, err
+		}
+		sessionIDs = all
+	}
+
+	var sessions []*Session
+	for _, sessionID := range sessionIDs {
+		manager, err := LoadSession(sessionID)
+		if err != nil {
+			if !explicit {
+				continue
+			}
+			return nil, fmt.Errorf("failed to load session %s: %w", sessionID, err)
+		}
+		sessions = append(sessions, manager.GetSession())
+	}
+	return learnFrom(sessions, opts), nil
+}
+
+// learnFrom aggregates suggestions from loaded sessions.
+func learnFrom(sessions []*Session, opts LearnOptions) *LearnReport {
+	minSessions := opts.MinSessions
+	if minSessions < 1 {
+		minSessions = 1
+	}
+
+	report := &LearnReport{SessionsScanned: len(sessions)}
+	fileVotes := make(map[string]map[string]map[string]bool) // path -> keyword -> sessions
+	fileReasons := make(map[string][]string)
+	unattributed := make(map[string]map[string]bool)
+
+	vote := func(votes map[string]map[string]bool, keyword, sessionID string) {
+		keyword = normalizeKeyword(keyword)
+		if keyword == "" {
+			return
+		}
+		if votes[keyword] == nil {
+			votes[keyword] = make(map[string]bool)
+		}
+		votes[keyword][sessionID] = true
+	}
+
+	for _, session := range sessions {
+		for _, turn := range session.Turns {
+			if turn.Status != "complete" || turn.Result == nil {
+				continue
+			}
+			if change := turn.Result.Change; change != nil {
+				for _, gap := range change.DiscoveryGap.Files {
+					dir := originalWorkdir(session, gap.WorkingDir)
+					if dir == "" || gap.Path == "" {
+						continue
+					}
+					path := filepath.Clean(gap.Path)
+					if !filepath.IsAbs(path) {
+						path = filepath.Join(dir, path)
+					}
+					if fileVotes[path] == nil {
+						fileVotes[path] = make(map[string]map[string]bool)
+					}
+					for _, keyword := range gap.SuggestedKeywords {
+						vote(fileVotes[path], keyword, session.SessionID)
+					}
+					if gap.Reason != "" {
+						fileReasons[path] = append(fileReasons[path], gap.Reason)
+					}
+				}
+			}
+			if discovery := turn.Result.Discovery; discovery != nil {
+				if discovery.KeywordAssessment != nil {
+					for _, keyword := range discovery.KeywordAssessment.NewKeywords {
+						vote(unattributed, keyword, session.SessionID)
+					}
+				}
+				if summary := discovery.ValidationSummary; summary != nil && summary.ValidationLog != nil {
+					for _, keyword := range summary.ValidationLog.KeywordAssessment.NewKeywords {
+						vote(unattributed, keyword, session.SessionID)
+					}
+				}
+			}
+		}
+	}
+
+	for path, votes := range fileVotes {
+		keywords := collectVotes(votes, minSessions)
+		if len(keywords) == 0 {
+			continue
+		}
+		report.Files = append(report.Files, LearnedFile{Path: path, Keywords: keywords, Reasons: fileReasons[path]})
+	}
+	sort.Slice(report.Files, func(i, j int) bool { return report.Files[i].Path < report.Files[j].Path })
+	report.Unattributed = collectVotes(unattributed, minSessions)
+	return report
+}
+
+// collectVotes returns keywords with at least minSessions sessions, most
+// suggested first.
+func collectVotes(votes map[string]map[string]bool, minSessions int) []LearnedKeyword {
+	var keywords []LearnedKeyword
+	for keyword, sessions := range votes {
+		if len(sessions) < minSessions {
+			continue
+		}
+		learned := LearnedKeyword{Keyword: keyword}
+		for sessionID := range sessions {
+			learned.Sessions = append(learned.Sessions, sessionID)
+		}
+		sort.Strings(learned.Sessions)
+		keywords = append(keywords, learned)
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if len(keywords[i].Sessions) != len(keywords[j].Sessions) {
+			return len(keywords[i].Sessions) > len(keywords[j].Sessions)
+		}
+		return keywords[i].Keyword < keywords[j].Keyword
+	})
+	return keywords
+}
+
+var keywordSeparators = regexp.MustCompile(`[\s_]+`)
+
+// normalizeKeyword lowercases a keyword and joins its words with hyphens,
+// the Action-Object form Brain keywords use.
+func normalizeKeyword(keyword string) string {
+	keyword = strings.ToLower(strings.TrimSpace(keyword))
+	return strings.Trim(keywordSeparators.ReplaceAllString(keyword, "-"), "-")
+}
+
+// originalWorkdir resolves the working directory a change turn reported,
+// an absolute path or a workdir name, to the directory in the user's
+// checkout. Isolated change turns report their worktree, which maps back to
+// the repository it was created from.
+func originalWorkdir(session *Session, workingDir string) string {
+	clean := filepath.Clean(workingDir)
+	for _, isolated := range session.IsolatedChanges {
+		for _, wt := range isolated.Worktrees {
+			rel, err := filepath.Rel(wt.WorktreePath, clean)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				return filepath.Join(wt.RepoRoot, rel)
+			}
+		}
+	}
+	for _, wd := range session.WorkingDirectories {
+		if filepath.Clean(wd.Path) == clean || wd.Name == workingDir {
+			return wd.Path
+		}
+	}
+	if filepath.IsAbs(clean) {
+		return clean
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+/**
+ * Component: Intent Workflow Discovery Learning Tests
+ * Block-UUID: 9b2f6e17-4c83-4a5d-b0e9-3d1c7a8f52e4
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests that discovery gap keywords are normalized and aggregated per file across sessions, filtered by session count, mapped back from isolated worktrees, and that unattributed new keywords are reported separately.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intent_workflow
+
+import (
+	"testing"
+)
+
+// gapSession returns a session whose one change turn reported the given gaps.
+func gapSession(id string, gaps ...DiscoveryGapEntry) *Session {
+	return &Session{
+		SessionID:          id,
+		WorkingDirectories: []WorkingDirectory{{ID: 1, Name: "api", Path: "/src/api"}},
+		Turns: []TurnState{
+			{TurnNumber: 1, TurnType: "change", Status: "complete", Result: &TurnResult{Change: &ChangeResult{
+				DiscoveryGap: DiscoveryGap{FilesAdded: len(gaps), Files: gaps},
+			}}},
+		},
+	}
+}
+
+func TestLearnAggregatesGapKeywordsPerFile(t *testing.T) {
+	s1 := gapSession("s1", DiscoveryGapEntry{WorkingDir: "/src/api", Path: "auth/ttl.go", Reason: "enforces the TTL", SuggestedKeywords: []string{"Max TTL", "token_expiry"}})
+	s2 := gapSession("s2", DiscoveryGapEntry{WorkingDir: "api", Path: "auth/ttl.go", SuggestedKeywords: []string{"max-ttl"}})
+	s2.Turns[0].Result.Discovery = &DiscoveryResult{KeywordAssessment: &KeywordAssessment{NewKeywords: []string{"Rate Limit"}}}
+
+	// A failed change turn contributes nothing
+	s3 := gapSession("s3", DiscoveryGapEntry{WorkingDir: "/src/api", Path: "auth/ttl.go", SuggestedKeywords: []string{"ignored"}})
+	s3.Turns[0].Status = "error"
+
+	report := learnFrom([]*Session{s1, s2, s3}, LearnOptions{})
+	if report.SessionsScanned != 3 || len(report.Files) != 1 {
+		t.Fatalf("report = %+v", report)
+	}
+	file := report.Files[0]
+	if file.Path != "/src/api/auth/ttl.go" || len(file.Reasons) != 1 {
+		t.Fatalf("file = %+v", file)
+	}
+	if len(file.Keywords) != 2 || file.Keywords[0].Keyword != "max-ttl" || len(file.Keywords[0].Sessions) != 2 || file.Keywords[1].Keyword != "token-expiry" {
+		t.Fatalf("keywords = %+v", file.Keywords)
+	}
+	if len(report.Unattributed) != 1 || report.Unattributed[0].Keyword != "rate-limit" {
+		t.Fatalf("unattributed = %+v", report.Unattributed)
+	}
+
+	// Only keywords two sessions agree on survive --min-sessions 2
+	report = learnFrom([]*Session{s1, s2, s3}, LearnOptions{MinSessions: 2})
+	if len(report.Files) != 1 || len(report.Files[0].Keywords) != 1 || len(report.Unattributed) != 0 {
+		t.Fatalf("filtered report = %+v", report)
+	}
+}
+
+func TestLearnMapsIsolatedWorktreesToCheckout(t *testing.T) {
+	session := gapSession("iso", DiscoveryGapEntry{WorkingDir: "/tmp/wt/turn-2/api", Path: "auth/ttl.go", SuggestedKeywords: []string{"ttl"}})
+	session.IsolatedChanges = []IsolatedChange{{
+		Turn:      1,
+		Worktrees: []IsolatedWorktree{{RepoRoot: "/src/api", WorktreePath: "/tmp/wt/turn-2/api"}},
+	}}
+
+	report := learnFrom([]*Session{session}, LearnOptions{})
+	if len(report.Files) != 1 || report.Files[0].Path != "/src/api/auth/ttl.go" {
+		t.Fatalf("files = %+v", report.Files)
+	}
+}
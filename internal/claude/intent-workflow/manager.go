@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Session Manager
- * Block-UUID: a3345746-4e4c-4127-87b3-80d856a0db8c
- * Parent-UUID: f6077751-e2cf-4e32-9fe8-aff9ef1859be
- * Version: 1.49.0
- * Description: Core session manager struct and initialization logic. Refactored to delegate persistence, lifecycle, turn orchestration, resume logic, and prompt generation to specialized files. Removed fatal error path for missing brains - now supports hybrid discovery strategy with experts/generic modes. Added disableExperts parameter to InitializeSession to support --disable-experts flag for forcing generic discovery mode.
+ * Block-UUID: 9f916e4b-e547-4449-82dc-bce98bd751e0
+ * Parent-UUID: 3efffc39-11d9-4f1c-94dd-54ae7d2a5f2b
+ * Version: 1.52.0
+ * Description: Core session manager struct and initialization logic, delegating persistence, lifecycle, turn orchestration, resume, and prompts to specialized files. Added the lastTransition and stateEvent fields used by the state log.
  * Language: Go
  * Created-at: 2026-04-28T13:47:04.136Z
- * Authors: ..., GLM-4.7 (v1.44.0), GLM-4.7 (v1.45.0), GLM-4.7 (v1.46.0), GLM-4.7 (v1.47.0), GLM-4.7 (v1.47.1), GLM-4.7 (v1.48.0), GLM-4.7 (v1.49.0)
+ * Authors: ..., GLM-4.7 (v1.44.0), GLM-4.7 (v1.45.0), GLM-4.7 (v1.46.0), GLM-4.7 (v1.47.0), GLM-4.7 (v1.47.1), GLM-4.7 (v1.48.0), GLM-4.7 (v1.49.0), agent (v1.50.0), agent (v1.51.0), agent (v1.52.0)
  */
 
 
@@ -19,6 +19,9 @@ import (
 	"os"
 	"sync"
 	"time"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 )
 
 // FinalizedTurnResults represents the lightweight results for a completed turn
@@ -48,6 +51,11 @@ type Manager struct {
 	loggerMu    sync.Mutex
 	loggerClosed bool
 	lastAssistantMessage string // Stores the last assistant message for post-processing
+	backend     backend.AgentBackend // Resolved from session.Backend on first use unless set with SetBackend
+	budgetExceeded *budget.Exceeded // Set when the current turn was stopped or refused over budget
+	budgetKill  *time.Timer // Escalates a budget stop to SIGKILL; stopped when the turn finalizes
+	lastTransition string // Last state written to the state log, as JSON
+	stateEvent  string // Event of the next state log record; empty logs only changed states
 }
 
 // NewManager creates a new scout manager
@@ -98,6 +106,29 @@ func NewManagerWithDebug(sessionID string, debugEnabled bool) (*Manager, error)
 	}, nil
 }
 
+// SetBackend overrides the agent backend for every turn this manager spawns.
+func (m *Manager) SetBackend(b backend.AgentBackend) {
+	m.backend = b
+}
+
+// agentBackend returns the backend for this session, resolving the spec
+// recorded in session.json so resumed and background turns use the same one.
+func (m *Manager) agentBackend() (backend.AgentBackend, error) {
+	if m.backend != nil {
+		return m.backend, nil
+	}
+	spec := ""
+	if m.session != nil {
+		spec = m.session.Backend
+	}
+	b, err := backend.Resolve(spec)
+	if err != nil {
+		return nil, err
+	}
+	m.backend = b
+	return b, nil
+}
+
 // GetConfig returns the session configuration
 func (m *Manager) GetConfig() *SessionConfig {
 	return m.config
@@ -132,6 +163,7 @@ func (m *Manager) InitializeSession(intent string, workdirs []WorkingDirectory,
 		SessionID:             m.config.SessionID,
 		Intent:                intent,
 		Model:                 model,
+		Backend:               backend.DefaultSpec(),
 		WorkingDirectories:    workdirs,
 		ReferenceFilesContext: refFilesContext,
 		AutoReview:            autoReview,
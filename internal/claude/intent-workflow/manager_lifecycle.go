@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Manager Lifecycle
- * Block-UUID: f97e4b96-6b54-45f4-93d3-c68420ed8888
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Manages session and turn lifecycle, including state transitions (stopped, error, complete), process termination, and finalization of turn results.
+ * Block-UUID: 823349e3-fcbe-4695-8e55-462db019de66
+ * Parent-UUID: 9ceca4e0-697f-49cb-8f7c-0ccec41365f6
+ * Version: 1.2.0
+ * Description: Manages session and turn lifecycle, including state transitions, process termination, and finalization of turn results. A turn stopped over budget is finalized as budget_exceeded rather than error, and the pending budget SIGKILL is cancelled.
  * Language: Go
  * Created-at: 2026-04-28T13:35:00.000Z
- * Authors: GLM-4.7 (v1.0.0)
+ * Authors: GLM-4.7 (v1.0.0), agent (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -20,6 +20,8 @@ import (
 	"path/filepath"
 	"syscall"
 	"time"
+
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 )
 
 // CheckProcessStatus checks if the subprocess is still running
@@ -28,6 +30,11 @@ func (m *Manager) CheckProcessStatus() (bool, error) {
 		return false, fmt.Errorf("no process info available")
 	}
 
+	// Backends that do not run an OS process (scripted replay) report no PID
+	if m.processInfo.PID <= 0 {
+		return m.processInfo.Running, nil
+	}
+
 	process, err := os.FindProcess(m.processInfo.PID)
 	if err != nil {
 		m.debugLogger.LogError("Process not found", err)
@@ -52,7 +59,7 @@ func (m *Manager) StopSession() error {
 	m.debugLogger.Log("DEBUG", "StopSession called")
 
 	// Phase 1: Pre-Shutdown Validation
-	if m.processInfo == nil || !m.processInfo.Running {
+	if m.processInfo == nil || !m.processInfo.Running || m.processInfo.PID <= 0 {
 		// Already stopped, nothing to do
 		m.debugLogger.Log("DEBUG", "Process not running, nothing to stop")
 		m.closeDebugLogger()
@@ -205,6 +212,14 @@ func (m *Manager) forceKillProcess(process *os.Process) error {
 func (m *Manager) finalizeTurn(exitCode int, wasStopped bool) {
 	m.debugLogger.Log("DEBUG", fmt.Sprintf("Finalizing turn: exitCode=%d, wasStopped=%v", exitCode, wasStopped))
 
+	// A turn stopped over budget exits on SIGTERM; it is neither an error
+	// nor a user stop
+	if m.budgetKill != nil {
+		m.budgetKill.Stop()
+		m.budgetKill = nil
+	}
+	overBudget := m.budgetExceeded != nil
+
 	// Update turn state when process exits naturally
 	completedAt := time.Now()
 	if m.session != nil {
@@ -212,7 +227,9 @@ func (m *Manager) finalizeTurn(exitCode int, wasStopped bool) {
 		for i := range m.session.Turns {
 			if m.session.Turns[i].TurnNumber == m.currentTurn {
 				// Determine turn status based on exit code and stopped flag
-				if wasStopped {
+				if overBudget {
+					m.session.Turns[i].Status = budget.StatusExceeded
+				} else if wasStopped {
 					// Session was stopped by user
 					m.session.Turns[i].Status = "stopped"
 				} else if exitCode != 0 {
@@ -228,7 +245,7 @@ func (m *Manager) finalizeTurn(exitCode int, wasStopped bool) {
 				// Note: PID is already set in spawn.go
 
 				// Set error if process exited with non-zero code
-				if exitCode != 0 {
+				if exitCode != 0 && !overBudget {
 					errorMsg := fmt.Sprintf("Process exited with code %d", exitCode)
 					m.session.Turns[i].Error = &errorMsg
 				}
@@ -237,7 +254,9 @@ func (m *Manager) finalizeTurn(exitCode int, wasStopped bool) {
 		}
 
 		// Update overall session status
-		if wasStopped {
+		if overBudget {
+			m.session.Status = budget.StatusExceeded
+		} else if wasStopped {
 			m.session.Status = "stopped"
 		} else if exitCode != 0 {
 			m.session.Status = "error"
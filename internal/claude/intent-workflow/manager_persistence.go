@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Manager Persistence
- * Block-UUID: 5589944f-af47-4c24-822d-8d8f0228714e
- * Parent-UUID: 52820a86-0af6-400f-a118-2eaa569313b7
- * Version: 1.0.1
- * Description: Handles session state persistence, including loading sessions from disk, writing session state, and maintaining turn history files.
+ * Block-UUID: 3fb23efa-a069-442b-8cd5-911a45a2b433
+ * Parent-UUID: 5589944f-af47-4c24-822d-8d8f0228714e
+ * Version: 1.1.0
+ * Description: Handles session state persistence, including loading sessions from disk, writing session state, and maintaining turn history files. Session writes now append changed state transitions to the state log first and replace session.json atomically.
  * Language: Go
  * Created-at: 2026-04-28T13:47:41.531Z
- * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.0.1)
+ * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.0.1), agent (v1.1.0)
  */
 
 
@@ -61,6 +61,11 @@ func LoadSession(sessionID string) (*Manager, error) {
 		currentTurn: currentTurn,
 	}
 
+	// The loaded state is already in the state log, or predates it
+	if key, err := json.Marshal(transitionOf(&session)); err == nil {
+		mgr.lastTransition = string(key)
+	}
+
 	// CRITICAL FIX: Restore m.processInfo from the last running turn
 	// This allows StopSession() to send SIGTERM to the actual process
 	for i := len(session.Turns) - 1; i >= 0; i-- {
@@ -83,10 +88,18 @@ func (m *Manager) WriteSessionState() error {
 	return m.writeSessionState()
 }
 
-// writeSessionState writes the session state to disk
+// writeSessionState writes the session state to disk. A changed status,
+// turn state, or process ID is first appended to the state log, and
+// session.json is replaced atomically, so a crash leaves a consistent file
+// that recovery can bring up to date from the log.
 func (m *Manager) writeSessionState() error {
 	sessionPath := m.config.GetSessionFile()
 
+	if err := m.logTransition(); err != nil {
+		m.debugLogger.LogError("Failed to append to state log", err)
+		return err
+	}
+
 	// Create a deep copy of the session to avoid mutating the in-memory state
 	// We use JSON marshal/unmarshal as a simple deep copy mechanism
 	sessionCopy := &Session{}
@@ -107,7 +120,7 @@ func (m *Manager) writeSessionState() error {
 		return fmt.Errorf("failed to marshal session: %w", err)
 	}
 
-	if err := os.WriteFile(sessionPath, data, 0644); err != nil {
+	if err := writeFileAtomic(sessionPath, data); err != nil {
 		m.debugLogger.LogError("Failed to write status file", err)
 		return fmt.Errorf("failed to write session file: %w", err)
 	}
@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Manager Resume
- * Block-UUID: 00c0206f-a8b3-48ff-be84-41fbb3eb2d81
- * Parent-UUID: 506175ad-3c09-4f9b-81bd-fb84393bf62b
- * Version: 1.0.3
- * Description: Updated template paths from data/templates to cli/templates to separate CLI-specific data from app-specific data.
+ * Block-UUID: 7d4c3866-7e07-4fa1-a4a4-39eb5221e557
+ * Parent-UUID: 2b05f5ba-a978-45b0-acf2-2348e2669604
+ * Version: 1.2.0
+ * Description: Resumes intent workflow sessions from disk and spawns turns through spawnAgentSubprocess. Resumed change turns enforce the session budget like new ones.
  * Language: Go
  * Created-at: 2026-04-29T02:44:35.946Z
- * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.0.2), GLM-4.7 (v1.0.3)
+ * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.0.2), GLM-4.7 (v1.0.3), agent (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -51,6 +51,10 @@ func (m *Manager) StartResumeChangeTurn(failedTurnNumber int) error {
 		return fmt.Errorf("turn %d is not in error state", failedTurnNumber)
 	}
 
+	if err := m.checkTurnStart(); err != nil {
+		return err
+	}
+
 	// Calculate next turn number
 	nextTurn := m.GetNextTurnNumber()
 	m.currentTurn = nextTurn
@@ -133,7 +137,7 @@ func (m *Manager) StartResumeChangeTurn(failedTurnNumber int) error {
 	}
 
 	// Spawn subprocess for resume-change turn
-	if err = m.spawnClaudeSubprocess(m.currentTurn, "resume-change", []string{}); err != nil {
+	if err = m.spawnAgentSubprocess(m.currentTurn, "resume-change", []string{}); err != nil {
 		m.debugLogger.LogError("Failed to spawn subprocess", err)
 		m.markAsError("SPAWN_FAILED", fmt.Sprintf("Failed to spawn subprocess: %v", err))
 		return err
@@ -142,7 +146,13 @@ func (m *Manager) StartResumeChangeTurn(failedTurnNumber int) error {
 	// Wait for stream processing to complete (blocking for worker process)
 	m.wg.Wait()
 
-	return m.writeSessionState()
+	if err := m.writeSessionState(); err != nil {
+		return err
+	}
+	if m.budgetExceeded != nil {
+		return m.budgetExceeded
+	}
+	return nil
 }
 
 // readChangeMetadataJSONL reads the change-metadata.jsonl file from the turn directory.
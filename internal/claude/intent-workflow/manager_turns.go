@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Manager Turns
- * Block-UUID: 8463136c-a0d9-43ce-a178-a605e4effa3e
- * Parent-UUID: 8d1f6257-15b0-4f21-94e2-f71eb8b41d9e
- * Version: 1.6.0
- * Description: Orchestrates the lifecycle of agent turns including discovery, change, and correction phases. Handles turn initialization, state transitions, and subprocess spawning. Added pre-flight cleanup logic to remove orphaned .change-meta.json files before starting a new change turn, preventing ghost metadata from confusing the AI. Fixed variable shadowing issue with err declaration in StartChangeTurn. Added AddSkippedDiscoveryTurn() method to support skipping discovery turns when using --skip-discovery flag. Updated StartDiscoveryTurn() to allow starting from "discovery_complete" status to support Discovery -> Discovery flow.
+ * Block-UUID: afe1ad73-e913-43f1-9b0f-058a1e0c204f
+ * Parent-UUID: 129a4ef9-ccfd-4d48-817f-d6cf203fff49
+ * Version: 1.8.0
+ * Description: Orchestrates discovery, change, and correction turns including initialization, state transitions, and subprocess spawning. Discovery and change turns are refused when the session budget is spent and return the crossed limit when stopped mid-run.
  * Language: Go
  * Created-at: 2026-04-29T02:43:08.639Z
- * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0)
+ * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0), agent (v1.7.0), agent (v1.8.0)
  */
 
 
@@ -33,6 +33,10 @@ func (m *Manager) StartDiscoveryTurn() error {
 		return fmt.Errorf("cannot start discovery: session status is %s", m.session.Status)
 	}
 
+	if err := m.checkTurnStart(); err != nil {
+		return err
+	}
+
 	// Calculate next turn number dynamically
 	nextTurn := m.GetNextTurnNumber()
 	m.currentTurn = nextTurn
@@ -108,7 +112,7 @@ func (m *Manager) StartDiscoveryTurn() error {
 	}
 
 	// Spawn subprocess for discovery turn (defined in spawn.go)
-	if err := m.spawnClaudeSubprocess(m.currentTurn, "discovery", []string{}); err != nil {
+	if err := m.spawnAgentSubprocess(m.currentTurn, "discovery", []string{}); err != nil {
 		m.debugLogger.LogError("Failed to spawn subprocess", err)
 		m.markAsError("SPAWN_FAILED", fmt.Sprintf("Failed to spawn subprocess: %v", err))
 		return err
@@ -118,7 +122,13 @@ func (m *Manager) StartDiscoveryTurn() error {
 	// Wait for stream processing to complete (blocking for worker process)
 	m.wg.Wait()
 
-	return m.writeSessionState()
+	if err := m.writeSessionState(); err != nil {
+		return err
+	}
+	if m.budgetExceeded != nil {
+		return m.budgetExceeded
+	}
+	return nil
 }
 
 // AddSkippedDiscoveryTurn creates a virtual skipped discovery turn without spawning a subprocess.
@@ -200,6 +210,10 @@ func (m *Manager) StartChangeTurn(intent string) error {
 		return fmt.Errorf("cannot start change: discovery not complete (current status: %s)", m.session.Status)
 	}
 
+	if err := m.checkTurnStart(); err != nil {
+		return err
+	}
+
 	// Pre-flight cleanup: Remove orphaned .change-meta.json files
 	m.debugLogger.Log("DEBUG", "Performing pre-flight cleanup")
 	orphanedFiles, cleanupErr := m.cleanupOrphanedMetadata()
@@ -276,7 +290,7 @@ func (m *Manager) StartChangeTurn(intent string) error {
 	}
 
 	// Spawn subprocess for change turn (defined in spawn.go)
-	if err := m.spawnClaudeSubprocess(m.currentTurn, "change", orphanedFiles); err != nil {
+	if err := m.spawnAgentSubprocess(m.currentTurn, "change", orphanedFiles); err != nil {
 		m.debugLogger.LogError("Failed to spawn subprocess", err)
 		m.markAsError("SPAWN_FAILED", fmt.Sprintf("Failed to spawn subprocess: %v", err))
 		return err
@@ -286,7 +300,13 @@ func (m *Manager) StartChangeTurn(intent string) error {
 	// Wait for stream processing to complete (blocking for worker process)
 	m.wg.Wait()
 
-	return m.writeSessionState()
+	if err := m.writeSessionState(); err != nil {
+		return err
+	}
+	if m.budgetExceeded != nil {
+		return m.budgetExceeded
+	}
+	return nil
 }
 
 // cleanupOrphanedMetadata removes any orphaned .change-meta.json files from the working directories.
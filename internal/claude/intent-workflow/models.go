@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Models
- * Block-UUID: 5d15475e-413c-41f1-9ff9-f16e2c393b9f
- * Parent-UUID: 16d320cd-938d-41ed-a2f5-4edb12c4506e
- * Version: 2.29.0
- * Description: Core data structures and types for intent workflow sessions including session state, turn management, candidates, and event models. Added SuccinctNaturalLanguageResponse to DiscoveryResult to support AI-generated natural language summaries in discovery responses. Added EnableCodeProvenance to Session struct to persist provenance state across turns. Added OldVersion and NewVersion to GSCFileData and ChangelogEntry to support semantic version tracking in the provenance ledger and enriched metadata files. Added GitContext, Environment, and OtherChanges to ChangeResult for comprehensive audit trail and attribution. Added "skipped" status to TurnState to support skipping discovery turns when using --skip-discovery flag. Added BrainEntry, BrainEffectiveness structs and DiscoveryMode to DiscoveryResult for hybrid discovery strategy. Added DisableExperts to Session struct to support --disable-experts flag for forcing generic discovery mode.
+ * Block-UUID: 19305c9c-9139-4fda-ad1d-9caaa1e20fd9
+ * Parent-UUID: 70170a9f-a6e0-4aeb-b4ad-47f49dc5b871
+ * Version: 2.35.0
+ * Description: Core data structures for intent workflow sessions including session state, turn management, candidates, and event models. Added the session StateSeq, the sequence number of the last state log record applied to session.json.
  * Language: Go
  * Created-at: 2026-04-30T12:34:10.812Z
- * Authors: claude-haiku-4-5-20251001 (v1.0.6), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), GLM-4.7 (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), GLM-4.7 (v1.17.0), GLM-4.7 (v1.18.0), GLM-4.7 (v1.19.0), GLM-4.7 (v2.20.0), Gemini 3 Flash (v2.21.0), Gemini 3 Flash (v2.22.0), GLM-4.7 (v2.23.0), GLM-4.7 (v2.24.0), GLM-4.7 (v2.25.0), GLM-4.7 (v2.26.0), GLM-4.7 (v2.27.0), GLM-4.7 (v2.28.0), GLM-4.7 (v2.29.0)
+ * Authors: claude-haiku-4-5-20251001 (v1.0.6), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), GLM-4.7 (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), GLM-4.7 (v1.17.0), GLM-4.7 (v1.18.0), GLM-4.7 (v1.19.0), GLM-4.7 (v2.20.0), Gemini 3 Flash (v2.21.0), Gemini 3 Flash (v2.22.0), GLM-4.7 (v2.23.0), GLM-4.7 (v2.24.0), GLM-4.7 (v2.25.0), GLM-4.7 (v2.26.0), GLM-4.7 (v2.27.0), GLM-4.7 (v2.28.0), GLM-4.7 (v2.29.0), agent (v2.30.0), agent (v2.31.0), agent (v2.32.0), agent (v2.33.0), agent (v2.34.0), agent (v2.35.0)
  */
 
 
@@ -14,6 +14,8 @@ package intent_workflow
 
 import (
 	"time"
+
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 )
 
 // ErrorDetails provides structured error information with file-level details
@@ -34,23 +36,54 @@ type ErrorFile struct {
 
 // Session represents an agent discovery/validation/change/etc. session
 type Session struct {
-	SessionDir            string                 `json:"session_dir"`
-	SessionID             string                 `json:"session_id"`
-	Intent                string                 `json:"intent"`
-	Model                 string                 `json:"model"`
-	WorkingDirectories    []WorkingDirectory     `json:"working_directories"`
-	ReferenceFilesContext []ReferenceFileContext `json:"reference_files_context"`
-	AutoReview            bool                   `json:"auto_review"`
-	EnableCodeProvenance  bool                   `json:"enable_code_provenance"` // Persists provenance state
-	DisableExperts        bool                   `json:"disable_experts,omitempty"` // Force generic mode; skip experts context even if file exists
-	Status                string                 `json:"status"` // "discovery", "discovery_complete", "validation", "validation_complete", "change", "change_post_processing", "change_complete", "stopped", "error", "failed_metadata"
-	StartedAt             time.Time              `json:"started_at"`
-	CompletedAt           *time.Time             `json:"completed_at,omitempty"`
-	Error                 *string                `json:"error,omitempty"`
-	ErrorDetails          *ErrorDetails          `json:"error_details,omitempty"`
-	WatcherPID            *int 					 `json:"watcher_pid,omitempty"`
-	Stopped               bool                   `json:"stopped,omitempty"`
-	Turns                 []TurnState            `json:"turns"`
+	SessionDir            string                   `json:"session_dir"`
+	SessionID             string                   `json:"session_id"`
+	Intent                string                   `json:"intent"`
+	Model                 string                   `json:"model"`
+	Backend               string                   `json:"backend,omitempty"` // Agent backend spec (claude, scripted:<dir>); empty uses GSC_AGENT_BACKEND or claude
+	WorkingDirectories    []WorkingDirectory       `json:"working_directories"`
+	ReferenceFilesContext []ReferenceFileContext   `json:"reference_files_context"`
+	AutoReview            bool                     `json:"auto_review"`
+	EnableCodeProvenance  bool                     `json:"enable_code_provenance"`    // Persists provenance state
+	DisableExperts        bool                     `json:"disable_experts,omitempty"` // Force generic mode; skip experts context even if file exists
+	Status                string                   `json:"status"`                    // "discovery", "discovery_complete", "validation", "validation_complete", "change", "change_post_processing", "change_complete", "stopped", "error", "failed_metadata", "budget_exceeded"
+	StartedAt             time.Time                `json:"started_at"`
+	CompletedAt           *time.Time               `json:"completed_at,omitempty"`
+	Error                 *string                  `json:"error,omitempty"`
+	ErrorDetails          *ErrorDetails            `json:"error_details,omitempty"`
+	WatcherPID            *int                     `json:"watcher_pid,omitempty"`
+	Stopped               bool                     `json:"stopped,omitempty"`
+	Turns                 []TurnState              `json:"turns"`
+	IsolatedChanges       []IsolatedChange         `json:"isolated_changes,omitempty"`   // Change turns run in git worktrees (--isolated)
+	Budget                *budget.Limits           `json:"budget,omitempty"`             // Cost, token, and turn limits (--max-cost, --max-tokens, --max-turns)
+	BudgetExceeded        *budget.Exceeded         `json:"budget_exceeded,omitempty"`    // Limit that last stopped or refused a turn
+	PermissionProfile     *PermissionProfileRecord `json:"permission_profile,omitempty"` // Agent permission profile (--permission-profile); nil uses the built-in policy
+	StateSeq              int                      `json:"state_seq,omitempty"`          // Last state.wal record this file reflects
+}
+
+// IsolatedChange records a change turn that ran in git worktrees instead of
+// the working directories. Its patches wait for apply, cherry-pick, or reject.
+type IsolatedChange struct {
+	Turn             int                `json:"turn"`
+	Status           string             `json:"status"` // "running", "pending", "partial", "applied", "rejected"
+	OriginalWorkdirs []WorkingDirectory `json:"original_workdirs"`
+	DirtyRoots       []string           `json:"dirty_roots,omitempty"` // Repositories with uncommitted changes the worktrees left out
+	Worktrees        []IsolatedWorktree `json:"worktrees"`
+	CreatedAt        time.Time          `json:"created_at"`
+	ResolvedAt       *time.Time         `json:"resolved_at,omitempty"`
+}
+
+// IsolatedWorktree is the worktree of one repository used by an isolated
+// change turn, and the patch collected from it. Paths in Files are relative
+// to RepoRoot.
+type IsolatedWorktree struct {
+	RepoRoot     string   `json:"repo_root"`
+	WorktreePath string   `json:"worktree_path"`
+	BaseSHA      string   `json:"base_sha"`
+	PatchPath    string   `json:"patch_path,omitempty"`
+	Files        []string `json:"files,omitempty"`
+	Applied      []string `json:"applied,omitempty"`
+	ThreeWay     []string `json:"three_way,omitempty"` // Applied files that needed a three-way merge and were staged in the index
 }
 
 // WorkingDirectory represents a directory in the contract being searched
@@ -150,6 +183,14 @@ type StatusData struct {
 	CorrectionStatus     string               `json:"correction_status,omitempty"`
 	CorrectionCost       *float64             `json:"correction_cost,omitempty"`
 	TotalCost            *float64             `json:"total_cost,omitempty"`
+	Budget               *BudgetReport        `json:"budget,omitempty"`
+}
+
+// BudgetReport shows a session's limits next to what it has spent
+type BudgetReport struct {
+	Limits   budget.Limits    `json:"limits"`
+	Spent    budget.Spend     `json:"spent"`
+	Exceeded *budget.Exceeded `json:"exceeded,omitempty"`
 }
 
 // ProcessInfo contains process-level information
@@ -272,7 +313,7 @@ type SelectedCandidate struct {
 type TurnState struct {
 	TurnNumber           int                 `json:"turn_number"`
 	TurnType             string              `json:"turn_type"` // "discovery" or "validation"
-	Status               string              `json:"status"` // "pending", "running", "complete", "error", "failed_metadata", "skipped"
+	Status               string              `json:"status"` // "pending", "running", "complete", "error", "failed_metadata", "skipped", "budget_exceeded"
 	StartedAt            time.Time           `json:"started_at"`
 	CompletedAt          *time.Time          `json:"completed_at,omitempty"`
 	LogPath              string              `json:"log_path"`
@@ -289,6 +330,7 @@ type TurnState struct {
 	CorrectionCost       *float64            `json:"correction_cost,omitempty"`
 	TotalCost            *float64            `json:"total_cost,omitempty"`
 	ErrorDetails         *ErrorDetails       `json:"error_details,omitempty"`
+	Permissions          *PhasePermissions   `json:"permissions,omitempty"` // Tool policy the turn's agent ran with
 }
 
 // TurnResult wraps the specific result type for a turn.
@@ -0,0 +1,309 @@
+/**
+ * Component: Intent Workflow Permission Profiles
+ * Block-UUID: 3d8f1a64-5c27-4e9b-b0a2-71e6c4d95f18
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Loads, validates, and resolves named agent permission profiles from .gitsense/agent-profiles.json. A profile sets the tools and shell commands allowed in each phase (discovery, validation, change, correction); phases it omits keep the built-in policy. The resolved profile is recorded on the session, with a digest, so every turn and provenance entry can be audited against it.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intent_workflow
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+)
+
+// AgentProfilesFileName is the profiles file inside a project's .gitsense directory.
+const AgentProfilesFileName = "agent-profiles.json"
+
+// AgentProfilesVersion is the only supported profiles file version.
+const AgentProfilesVersion = 1
+
+// DefaultPermissionProfile names the built-in profile; it cannot be redefined.
+const DefaultPermissionProfile = "default"
+
+// Permission phases. Discovery, validation, and change match turn types;
+// correction covers format and metadata correction runs.
+const (
+	PhaseDiscovery  = "discovery"
+	PhaseValidation = "validation"
+	PhaseChange     = "change"
+	PhaseCorrection = "correction"
+)
+
+// permissionPhases lists the phases in a stable order for validation and digests.
+var permissionPhases = []string{PhaseDiscovery, PhaseValidation, PhaseChange, PhaseCorrection}
+
+// knownAgentTools are the tool names a profile may allow.
+var knownAgentTools = map[string]bool{
+	"Read":  true,
+	"Write": true,
+	"Edit":  true,
+	"Bash":  true,
+	"Glob":  true,
+	"Grep":  true,
+}
+
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// PhasePermissions is the tool policy of one phase, as written in the
+// profiles file.
+type PhasePermissions struct {
+	Tools       []string `json:"tools"`
+	Commands    []string `json:"commands,omitempty"`     // Shell command prefixes; require the Bash tool
+	SkipPrompts bool     `json:"skip_prompts,omitempty"` // Run without interactive approval of tool calls
+}
+
+// PermissionProfile is a named set of per-phase policies.
+type PermissionProfile struct {
+	Description string                      `json:"description,omitempty"`
+	Phases      map[string]PhasePermissions `json:"phases"`
+}
+
+// PermissionProfilesFile is the schema of .gitsense/agent-profiles.json.
+type PermissionProfilesFile struct {
+	Version  int                          `json:"version"`
+	Profiles map[string]PermissionProfile `json:"profiles"`
+}
+
+// PermissionProfileRecord is the resolved profile stored on a session. It
+// carries every phase, defaults included, so workers and audits never need
+// the profiles file again.
+type PermissionProfileRecord struct {
+	Name   string                      `json:"name"`
+	Source string                      `json:"source,omitempty"` // Profiles file it came from; empty for the built-in profile
+	Digest string                      `json:"digest"`           // sha256 of the resolved phases
+	Phases map[string]PhasePermissions `json:"phases"`
+}
+
+// toBackend converts a phase policy to the backend's runtime-neutral form.
+func (p PhasePermissions) toBackend() backend.Permissions {
+	return backend.Permissions{
+		Tools:       append([]string(nil), p.Tools...),
+		Commands:    append([]string(nil), p.Commands...),
+		SkipPrompts: p.SkipPrompts,
+	}
+}
+
+// phaseFromBackend converts a built-in policy to its profile form.
+func phaseFromBackend(perms backend.Permissions) PhasePermissions {
+	return PhasePermissions{
+		Tools:       append([]string(nil), perms.Tools...),
+		Commands:    append([]string(nil), perms.Commands...),
+		SkipPrompts: perms.SkipPrompts,
+	}
+}
+
+// defaultPhasePermissions returns the built-in policy of a phase.
+func defaultPhasePermissions(phase string) PhasePermissions {
+	if phase == PhaseCorrection {
+		return phaseFromBackend(correctionPermissions)
+	}
+	return phaseFromBackend(turnPermissions)
+}
+
+// phaseForTurn maps a turn type to its permission phase; resumed change
+// turns use the change policy.
+func phaseForTurn(turnType string) string {
+	baseType, _ := parseTurnType(turnType)
+	switch baseType {
+	case PhaseValidation, PhaseChange:
+		return baseType
+	}
+	return PhaseDiscovery
+}
+
+// PermissionProfilesPath returns the profiles file of a project.
+func PermissionProfilesPath(projectRoot string) string {
+	return filepath.Join(projectRoot, settings.GitSenseDir, AgentProfilesFileName)
+}
+
+// LoadPermissionProfiles reads and validates a profiles file. A missing file
+// yields an empty set.
+func LoadPermissionProfiles(path string) (*PermissionProfilesFile, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &PermissionProfilesFile{Version: AgentProfilesVersion}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read permission profiles: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var file PermissionProfilesFile
+	if err := decoder.Decode(&file); err != nil {
+		return nil, fmt.Errorf("failed to parse permission profiles %s: %w", path, err)
+	}
+	if errs := ValidatePermissionProfiles(&file); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid permission profiles %s:\n  - %s", path, strings.Join(errs, "\n  - "))
+	}
+	return &file, nil
+}
+
+// ValidatePermissionProfiles checks a profiles file against the schema and
+// returns one message per problem, in a stable order.
+func ValidatePermissionProfiles(file *PermissionProfilesFile) []string {
+	var errs []string
+	if file.Version != AgentProfilesVersion {
+		errs = append(errs, fmt.Sprintf("version must be %d (got %d)", AgentProfilesVersion, file.Version))
+	}
+
+	names := make([]string, 0, len(file.Profiles))
+	for name := range file.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		profile := file.Profiles[name]
+		if name == DefaultPermissionProfile {
+			errs = append(errs, fmt.Sprintf("profile %q is built in and cannot be redefined", name))
+			continue
+		}
+		if !profileNamePattern.MatchString(name) {
+			errs = append(errs, fmt.Sprintf("profile %q: name must be lowercase letters, digits, '-' or '_'", name))
+		}
+		if len(profile.Phases) == 0 {
+			errs = append(errs, fmt.Sprintf("profile %q: defines no phases", name))
+		}
+
+		phases := make([]string, 0, len(profile.Phases))
+		for phase := range profile.Phases {
+			phases = append(phases, phase)
+		}
+		sort.Strings(phases)
+		for _, phase := range phases {
+			if !isPermissionPhase(phase) {
+				errs = append(errs, fmt.Sprintf("profile %q: unknown phase %q (must be one of %s)", name, phase, strings.Join(permissionPhases, ", ")))
+				continue
+			}
+			for _, msg := range validatePhasePermissions(profile.Phases[phase]) {
+				errs = append(errs, fmt.Sprintf("profile %q, phase %s: %s", name, phase, msg))
+			}
+		}
+	}
+	return errs
+}
+
+// validatePhasePermissions checks the tools and commands of one phase.
+func validatePhasePermissions(perms PhasePermissions) []string {
+	var errs []string
+	if len(perms.Tools) == 0 {
+		errs = append(errs, "tools must list at least one tool")
+	}
+	seen := make(map[string]bool)
+	hasBash := false
+	for _, tool := range perms.Tools {
+		switch {
+		case !knownAgentTools[tool]:
+			errs = append(errs, fmt.Sprintf("unknown tool %q", tool))
+		case seen[tool]:
+			errs = append(errs, fmt.Sprintf("tool %q listed twice", tool))
+		}
+		seen[tool] = true
+		if tool == "Bash" {
+			hasBash = true
+		}
+	}
+	for _, command := range perms.Commands {
+		switch {
+		case strings.TrimSpace(command) == "":
+			errs = append(errs, "commands must not be empty")
+		case strings.Contains(command, "Bash("):
+			errs = append(errs, fmt.Sprintf("command %q: write the command prefix only, without Bash(...)", command))
+		}
+	}
+	if len(perms.Commands) > 0 && !hasBash {
+		errs = append(errs, "commands require the Bash tool")
+	}
+	return errs
+}
+
+func isPermissionPhase(phase string) bool {
+	for _, known := range permissionPhases {
+		if phase == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvePermissionProfile returns the named profile of a project with every
+// phase filled in. An empty name or "default" selects the built-in profile.
+func ResolvePermissionProfile(projectRoot, name string) (*PermissionProfileRecord, error) {
+	record := &PermissionProfileRecord{Name: DefaultPermissionProfile, Phases: make(map[string]PhasePermissions)}
+	for _, phase := range permissionPhases {
+		record.Phases[phase] = defaultPhasePermissions(phase)
+	}
+
+	if name != "" && name != DefaultPermissionProfile {
+		path := PermissionProfilesPath(projectRoot)
+		file, err := LoadPermissionProfiles(path)
+		if err != nil {
+			return nil, err
+		}
+		profile, ok := file.Profiles[name]
+		if !ok {
+			return nil, fmt.Errorf("permission profile %q not found in %s", name, path)
+		}
+		record.Name = name
+		record.Source = path
+		for phase, perms := range profile.Phases {
+			record.Phases[phase] = perms
+		}
+	}
+
+	record.Digest = permissionDigest(record.Phases)
+	return record, nil
+}
+
+// permissionDigest hashes the resolved phases, so an audit can tell whether
+// two sessions ran under the same policy even if the file changed since.
+func permissionDigest(phases map[string]PhasePermissions) string {
+	hash := sha256.New()
+	for _, phase := range permissionPhases {
+		data, _ := json.Marshal(phases[phase])
+		fmt.Fprintf(hash, "%s=%s\n", phase, data)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// SetPermissionProfile stores the resolved profile on the session, so
+// background workers and later turns apply it. A nil record keeps the
+// current profile.
+func (m *Manager) SetPermissionProfile(record *PermissionProfileRecord) error {
+	if m.session == nil {
+		return fmt.Errorf("session not initialized")
+	}
+	if record == nil {
+		return nil
+	}
+	m.session.PermissionProfile = record
+	return m.writeSessionState()
+}
+
+// permissionsFor returns the policy the session applies in a phase: its
+// profile's, or the built-in one for sessions without a profile.
+func (m *Manager) permissionsFor(phase string) PhasePermissions {
+	if m.session != nil && m.session.PermissionProfile != nil {
+		if perms, ok := m.session.PermissionProfile.Phases[phase]; ok {
+			return perms
+		}
+	}
+	return defaultPhasePermissions(phase)
+}
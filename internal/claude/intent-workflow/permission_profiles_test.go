@@ -0,0 +1,176 @@
+/**
+ * Component: Intent Workflow Permission Profiles Tests
+ * Block-UUID: 5e0b7d92-16a4-4c3f-8d25-a9f4e1c68b37
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests that profiles files are checked against the schema, that a named profile overrides only the phases it defines, and that discovery and correction runs use and record the session's profile.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intent_workflow
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+)
+
+// writeProfiles writes a profiles file into a new project and returns its root.
+func writeProfiles(t *testing.T, content string) string {
+	t.Helper()
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, settings.GitSenseDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(PermissionProfilesPath(root), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+func TestValidatePermissionProfiles(t *testing.T) {
+	file := &PermissionProfilesFile{
+		Version: 2,
+		Profiles: map[string]PermissionProfile{
+			"default": {Phases: map[string]PhasePermissions{PhaseChange: {Tools: []string{"Read"}}}},
+			"Go Repo": {Phases: map[string]PhasePermissions{
+				"review":        {Tools: []string{"Read"}},
+				PhaseCorrection: {Tools: []string{"Read", "Read"}, Commands: []string{"go test"}},
+				PhaseValidation: {Tools: []string{"Bash", "Shell"}, Commands: []string{"Bash(make lint)", " "}},
+			}},
+		},
+	}
+	want := []string{
+		"version must be 1 (got 2)",
+		`profile "Go Repo": name must be lowercase letters, digits, '-' or '_'`,
+		`profile "Go Repo", phase correction: tool "Read" listed twice`,
+		`profile "Go Repo", phase correction: commands require the Bash tool`,
+		`profile "Go Repo": unknown phase "review" (must be one of discovery, validation, change, correction)`,
+		`profile "Go Repo", phase validation: unknown tool "Shell"`,
+		`profile "Go Repo", phase validation: command "Bash(make lint)": write the command prefix only, without Bash(...)`,
+		`profile "Go Repo", phase validation: commands must not be empty`,
+		`profile "default" is built in and cannot be redefined`,
+	}
+	if got := ValidatePermissionProfiles(file); !reflect.DeepEqual(got, want) {
+		t.Fatalf("errors =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Unknown keys are schema errors too
+	root := writeProfiles(t, `{"version":1,"profiles":{"go":{"phases":{"change":{"tools":["Read"],"allow":["*"]}}}}}`)
+	if _, err := ResolvePermissionProfile(root, "go"); err == nil || !strings.Contains(err.Error(), `unknown field "allow"`) {
+		t.Fatalf("unknown field error = %v", err)
+	}
+}
+
+func TestResolvePermissionProfileFallsBackPerPhase(t *testing.T) {
+	root := writeProfiles(t, `{
+  "version": 1,
+  "profiles": {
+    "go": {
+      "description": "Go repository with tests in correction",
+      "phases": {
+        "correction": {"tools": ["Read", "Bash"], "commands": ["go test:*"]}
+      }
+    }
+  }
+}`)
+
+	record, err := ResolvePermissionProfile(root, "go")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+	if record.Name != "go" || record.Source != PermissionProfilesPath(root) {
+		t.Fatalf("record = %+v", record)
+	}
+	if got := record.Phases[PhaseCorrection].Commands; !reflect.DeepEqual(got, []string{"go test:*"}) {
+		t.Fatalf("correction commands = %v", got)
+	}
+	if got := record.Phases[PhaseDiscovery]; !reflect.DeepEqual(got, phaseFromBackend(turnPermissions)) {
+		t.Fatalf("discovery = %+v, want the built-in policy", got)
+	}
+
+	builtIn, err := ResolvePermissionProfile("", "")
+	if err != nil || builtIn.Name != DefaultPermissionProfile || builtIn.Source != "" {
+		t.Fatalf("built-in = %+v, %v", builtIn, err)
+	}
+	if builtIn.Digest == record.Digest || len(record.Digest) != 64 {
+		t.Fatalf("digests = %s, %s", builtIn.Digest, record.Digest)
+	}
+
+	if _, err := ResolvePermissionProfile(root, "python"); err == nil || !strings.Contains(err.Error(), `"python" not found`) {
+		t.Fatalf("missing profile error = %v", err)
+	}
+}
+
+func TestTurnsRunWithSessionPermissionProfile(t *testing.T) {
+	gscHome := t.TempDir()
+	t.Setenv("GSC_HOME", gscHome)
+	copyTemplates(t, gscHome)
+
+	recordings := t.TempDir()
+	candidate := `{"workdir_id":1,"workdir_name":"app","file_path":"main.go","score":0.9,"reasoning":"entry point"}`
+	// discovery_mode is missing, which forces a correction run
+	writeRecording(t, recordings, "discovery", `{"candidates":[`+candidate+`],"total_found":1,"coverage":"full"}`, 0.5)
+	writeRecording(t, recordings, "correction", `{"status":"success","corrected_output":{"candidates":[`+candidate+`],"total_found":1,"coverage":"full","discovery_mode":"generic"},"reasoning":"added discovery_mode","errors_fixed":["discovery_mode"],"errors_remaining":[]}`, 0.01)
+
+	root := writeProfiles(t, `{"version":1,"profiles":{"audit":{"phases":{
+  "discovery": {"tools": ["Read", "Grep", "Bash"], "commands": ["gsc:*", "rg"]},
+  "correction": {"tools": ["Read", "Bash"], "commands": ["jq"]}
+}}}}`)
+	record, err := ResolvePermissionProfile(root, "audit")
+	if err != nil {
+		t.Fatalf("resolve: %v", err)
+	}
+
+	manager, err := NewManager("profile-test")
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	agent := backend.NewScriptedBackend(recordings)
+	manager.SetBackend(agent)
+	workdirs := []WorkingDirectory{{ID: 1, Name: "app", Path: t.TempDir()}}
+	if err := manager.InitializeSession("find the entry point", workdirs, nil, false, "sonnet", true); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	if err := manager.SetPermissionProfile(record); err != nil {
+		t.Fatalf("set profile: %v", err)
+	}
+	if err := manager.StartDiscoveryTurn(); err != nil {
+		t.Fatalf("discovery: %v", err)
+	}
+
+	requests := agent.Requests()
+	if len(requests) != 2 || requests[0].Label != "discovery" || requests[1].Label != "correction" {
+		t.Fatalf("requests = %+v", requests)
+	}
+	if got := requests[0].Permissions.Commands; !reflect.DeepEqual(got, []string{"gsc:*", "rg"}) {
+		t.Fatalf("discovery commands = %v", got)
+	}
+	if got := requests[1].Permissions.Commands; !reflect.DeepEqual(got, []string{"jq"}) {
+		t.Fatalf("correction commands = %v", got)
+	}
+	if perms, ok := agent.Permissions(manager.GetConfig().GetTurnDir(1)); !ok || !reflect.DeepEqual(perms.Tools, []string{"Read", "Bash"}) {
+		t.Fatalf("turn directory permissions = %+v (last configured by the correction)", perms)
+	}
+
+	// The session and the turn keep what was applied, across reloads
+	loaded, err := LoadSession("profile-test")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	session := loaded.GetSession()
+	if session.PermissionProfile == nil || session.PermissionProfile.Name != "audit" || session.PermissionProfile.Digest != record.Digest {
+		t.Fatalf("session profile = %+v", session.PermissionProfile)
+	}
+	turn := loaded.getTurnState(1)
+	if turn.Permissions == nil || !reflect.DeepEqual(turn.Permissions.Tools, []string{"Read", "Grep", "Bash"}) {
+		t.Fatalf("turn permissions = %+v", turn.Permissions)
+	}
+}
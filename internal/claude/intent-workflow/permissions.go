@@ -1,58 +1,42 @@
 /**
  * Component: Intent Workflow Permissions Configuration
- * Block-UUID: ea8b432c-f463-4ce3-86c4-d9daa5406bf9
- * Parent-UUID: 7403c8f5-d7a0-4c7b-b16c-61cb1eb5d26b
- * Version: 1.2.0
- * Description: Generic permissions configuration for intent workflow sessions including restricted command execution and file access controls.
+ * Block-UUID: 9e5140b3-2aa0-4db6-9a48-962602f34093
+ * Parent-UUID: ea8b432c-f463-4ce3-86c4-d9daa5406bf9
+ * Version: 1.3.0
+ * Description: Generic permissions configuration for intent workflow sessions; defines runtime-neutral turn and correction permission policies applied through the agent backend, with WriteAgentPermissions delegating to the Claude Code backend.
  * Language: Go
  * Created-at: 2026-03-27T16:12:50.000Z
- * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0)
+ * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), agent (v1.3.0)
  */
 
 
 package intent_workflow
 
 import (
-	"encoding/json"
-	"fmt"
-	"os"
-	"path/filepath"
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
 )
 
+// turnPermissions is the tool policy for discovery, change, and resume turns:
+// the agent may read and write files, and its shell may run only gsc and a
+// few text filters.
+var turnPermissions = backend.Permissions{
+	Tools:       []string{"Read", "Write", "Bash"},
+	Commands:    []string{"gsc:*", "sort", "head", "tail"},
+	SkipPrompts: true,
+}
+
+// correctionPermissions limits correction turns to reading files in the
+// turn directory.
+var correctionPermissions = backend.Permissions{
+	Tools: []string{"Read"},
+}
+
 // WriteAgentPermissions creates a .claude/settings.json file with restricted permissions
 // for intent workflow sessions. This ensures Claude can only execute specific commands and read files.
+// Other backends configure their own permissions through AgentBackend.ConfigurePermissions.
 func WriteAgentPermissions(turnDir string) error {
-	// Create .claude directory
-	claudeDir := filepath.Join(turnDir, ".claude")
-	if err := os.MkdirAll(claudeDir, 0755); err != nil {
-		return fmt.Errorf("failed to create .claude directory: %w", err)
-	}
-
-	// Define permissions - allow only gsc commands and Read tool
-	permissions := map[string]interface{}{
-		"permissions": map[string]interface{}{
-			"allow": []string{
-				"Bash(gsc:*)",
-				"Bash(sort)",
-				"Bash(head)",
-				"Bash(tail)",
-				"Read(*)",
-			},
-			"defaultMode": "default",
-		},
-	}
-
-	// Marshal to JSON with indentation for readability
-	data, err := json.MarshalIndent(permissions, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal permissions: %w", err)
-	}
-
-	// Write to settings.json
-	settingsPath := filepath.Join(claudeDir, "settings.json")
-	if err := os.WriteFile(settingsPath, data, 0644); err != nil {
-		return fmt.Errorf("failed to write settings.json: %w", err)
-	}
-
-	return nil
+	return backend.NewClaudeBackend().ConfigurePermissions(turnDir, backend.Permissions{
+		Tools:    []string{"Read"},
+		Commands: []string{"gsc:*", "sort", "head", "tail"},
+	})
 }
@@ -1,12 +1,12 @@
 /**
  * Component: Change Post-Processor
- * Block-UUID: 1c1f3e43-595e-4255-a855-53382ee68c3c
- * Parent-UUID: 3e413119-345a-470b-9451-83c27884ea50
- * Version: 1.10.0
- * Description: Orchestrates the post-processing phase after a change turn completes. Added support for code provenance recording and ephemeral header injection. When enableProvenance is true, it extracts existing headers, generates new UUIDs, injects headers into modified files, and records entries in the worktree-level provenance ledger. Added version fallback to default to 1.0.0 if AI omits version. Added ModelID population for provenance entries. Added GitContext, OtherChanges, and Environment capture for comprehensive audit trail and UI attribution.
+ * Block-UUID: 1bbde7a0-79b1-4edb-bfde-0a14853dcc01
+ * Parent-UUID: 28f83ba6-67d8-49b1-96b7-18355f2fe030
+ * Version: 1.12.0
+ * Description: Orchestrates the post-processing phase after a change turn completes, including code provenance recording, ephemeral header injection, version fallback, ModelID population, and GitContext/OtherChanges/Environment capture. Provenance entries record the session's permission profile name and digest, and metadata correction runs under its correction phase.
  * Language: Go
  * Created-at: 2026-04-29T02:42:03.684Z
- * Authors: Gemini 3 Flash (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), Gemini 3 Flash (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0)
+ * Authors: Gemini 3 Flash (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), Gemini 3 Flash (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), agent (v1.11.0), agent (v1.12.0)
  */
 
 
@@ -259,6 +259,10 @@ func (p *PostProcessor) recordProvenance(metaFiles []string, gitChanges map[stri
 			Source:         "gsc-cli",
 			Description:    gscData.Description,
 		}
+		if profile := p.manager.session.PermissionProfile; profile != nil {
+			entry.PermissionProfile = profile.Name
+			entry.PermissionDigest = profile.Digest
+		}
 
 		// Populate line counts from gitChanges
 		if prov, ok := gitChanges[gscData.AbsolutePath]; ok {
@@ -351,7 +355,11 @@ func (p *PostProcessor) runMetadataCorrectionLoop(metaFiles []string) ([]Changel
 		badMetaData, _ := json.MarshalIndent(badFiles, "", "  ")
 		_ = os.WriteFile(badMetaPath, badMetaData, 0644)
 
-		if err := SpawnMetadataCorrectionSubprocess(turnDir, badMetaPath); err != nil {
+		agent, err := p.manager.agentBackend()
+		if err != nil {
+			return nil, fmt.Errorf("metadata correction subprocess failed: %w", err)
+		}
+		if err := SpawnMetadataCorrectionSubprocess(agent, turnDir, badMetaPath, p.manager.permissionsFor(PhaseCorrection).toBackend()); err != nil {
 			return nil, fmt.Errorf("metadata correction subprocess failed: %w", err)
 		}
 	}
@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Stream Event Processor
- * Block-UUID: 8ba8603e-7dd5-4f0e-b7b6-ae8db05f5892
- * Parent-UUID: 89227332-2fdd-4f3b-8f8e-6047012ae86a
- * Version: 1.3.2
- * Description: Generic stream event processor for intent workflow sessions including JSONL event writing, reading, and session status reconstruction.
+ * Block-UUID: a359177d-935d-4d70-b702-1132099ce120
+ * Parent-UUID: 8ba8603e-7dd5-4f0e-b7b6-ae8db05f5892
+ * Version: 1.4.0
+ * Description: Generic stream event processor for intent workflow sessions including JSONL event writing, reading, and session status reconstruction. Status data now reports the session budget, its spend, and any exceeded limit.
  * Language: Go
  * Created-at: 2026-04-26T18:14:58.246Z
- * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.0.1), Gemini 3 Flash (v1.0.2), GLM-4.7 (v1.0.3), GLM-4.7 (v1.0.4), GLM-4.7 (v1.0.5), GLM-4.7 (v1.0.6), GLM-4.7 (v1.0.7), GLM-4.7 (v1.1.0), GLM-4.7 (v1.1.1), GLM-4.7 (v1.1.2), GLM-4.7 (v1.1.3), GLM-4.7 (v1.1.4), claude-haiku-4-5-20251001 (v1.1.5), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.3.1), GLM-4.7 (v1.3.2)
+ * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.0.1), Gemini 3 Flash (v1.0.2), GLM-4.7 (v1.0.3), GLM-4.7 (v1.0.4), GLM-4.7 (v1.0.5), GLM-4.7 (v1.0.6), GLM-4.7 (v1.0.7), GLM-4.7 (v1.1.0), GLM-4.7 (v1.1.1), GLM-4.7 (v1.1.2), GLM-4.7 (v1.1.3), GLM-4.7 (v1.1.4), claude-haiku-4-5-20251001 (v1.1.5), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.3.1), GLM-4.7 (v1.3.2), agent (v1.4.0)
  */
 
 
@@ -506,6 +506,14 @@ func (ph *ProcessorHelper) GenerateStatusData(session *Session, currentTurn int)
 		status.ErrorDetails = session.ErrorDetails
 	}
 
+	if session.Budget != nil {
+		status.Budget = &BudgetReport{
+			Limits:   *session.Budget,
+			Spent:    spendOf(session, 0),
+			Exceeded: session.BudgetExceeded,
+		}
+	}
+
 	// Calculate elapsed time
 	if status.CompletedAt != nil {
 		status.ElapsedSeconds = int64(status.CompletedAt.Sub(status.StartedAt).Seconds())
@@ -0,0 +1,234 @@
+/**
+ * Component: Intent Workflow Session Recovery
+ * Block-UUID: 2f6a8d13-7e4b-4c95-a0d2-b81c5e36f749
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Brings a session left behind by a crashed CLI, worker, or machine back to a consistent, resumable state: replays state log records newer than session.json, clears a dead watcher PID, marks turns whose process is gone as interrupted errors, restores the checkout of an interrupted isolated change, and moves a session stuck in a running status (including change_post_processing) to error so retry can pick it up.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intent_workflow
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"time"
+)
+
+// Error codes recorded by recovery
+const (
+	ErrorCodeInterrupted               = "INTERRUPTED"
+	ErrorCodePostProcessingInterrupted = "POST_PROCESSING_INTERRUPTED"
+)
+
+// activeStatuses are the session statuses that only hold while a worker runs.
+var activeStatuses = map[string]bool{
+	"discovery":              true,
+	"validation":             true,
+	"change":                 true,
+	"resume-change":          true,
+	"change_post_processing": true,
+}
+
+// RecoveryReport describes what recovery found and did for one session.
+type RecoveryReport struct {
+	SessionID    string   `json:"session_id"`
+	StatusBefore string   `json:"status_before"`
+	StatusAfter  string   `json:"status_after"`
+	Running      bool     `json:"running"`                // A worker is still alive; nothing was changed
+	ReplayedSeq  int      `json:"replayed_seq,omitempty"` // State log record applied over session.json
+	TornRecords  int      `json:"torn_records,omitempty"` // Unreadable state log lines, skipped
+	Actions      []string `json:"actions,omitempty"`
+	Changed      bool     `json:"changed"`
+	DryRun       bool     `json:"dry_run,omitempty"`
+	Next         string   `json:"next,omitempty"` // Suggested command to resume
+}
+
+// RecoverSession loads a session and recovers it. With dryRun, it reports
+// what would change without writing anything.
+func RecoverSession(sessionID string, dryRun bool) (*RecoveryReport, error) {
+	manager, err := LoadSession(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return manager.Recover(dryRun)
+}
+
+// Recover replays the state log over the loaded session, then settles
+// whatever a dead process left running. A session whose worker or agent is
+// still alive is left alone.
+func (m *Manager) Recover(dryRun bool) (*RecoveryReport, error) {
+	if m.session == nil {
+		return nil, fmt.Errorf("session not initialized")
+	}
+	session := m.session
+	report := &RecoveryReport{SessionID: session.SessionID, StatusBefore: session.Status, DryRun: dryRun}
+
+	records, torn, err := ReadStateLog(m.config.GetStateLogFile())
+	if err != nil {
+		return nil, err
+	}
+	report.TornRecords = torn
+	if len(records) > 0 {
+		if last := records[len(records)-1]; last.Seq > session.StateSeq {
+			replayTransition(session, last.StateTransition)
+			session.StateSeq = last.Seq
+			report.ReplayedSeq = last.Seq
+			report.Actions = append(report.Actions, fmt.Sprintf("replayed state log record %d (status %s)", last.Seq, last.Status))
+		}
+	}
+
+	watcherAlive := session.WatcherPID != nil && pidAlive(*session.WatcherPID)
+	for _, turn := range session.Turns {
+		if turnProcessAlive(turn, watcherAlive) {
+			report.Running = true
+		}
+	}
+	if watcherAlive && activeStatuses[session.Status] {
+		report.Running = true
+	}
+	if report.Running {
+		report.StatusAfter = session.Status
+		report.Actions = []string{"worker is still running; nothing to recover"}
+		return report, nil
+	}
+
+	if session.WatcherPID != nil && !watcherAlive {
+		report.Actions = append(report.Actions, fmt.Sprintf("cleared stale watcher PID %d", *session.WatcherPID))
+		session.WatcherPID = nil
+	}
+
+	now := time.Now()
+	for i := range session.Turns {
+		turn := &session.Turns[i]
+		if turn.Status != "running" && !turn.ProcessInfo.Running {
+			continue
+		}
+		message := fmt.Sprintf("Turn %d was interrupted: its process is gone", turn.TurnNumber)
+		if turn.ProcessInfo.PID > 0 {
+			message = fmt.Sprintf("Turn %d was interrupted: process %d is gone", turn.TurnNumber, turn.ProcessInfo.PID)
+		}
+		if turn.Status == "running" {
+			markTurnInterrupted(turn, ErrorCodeInterrupted, message, now)
+			if !dryRun && turn.LogPath != "" {
+				m.writeErrorEventToLog(turn.LogPath, ErrorCodeInterrupted, message)
+			}
+			report.Actions = append(report.Actions, fmt.Sprintf("marked turn %d (%s) as interrupted", turn.TurnNumber, turn.TurnType))
+		} else {
+			report.Actions = append(report.Actions, fmt.Sprintf("cleared running flag of turn %d", turn.TurnNumber))
+		}
+		turn.ProcessInfo.Running = false
+	}
+
+	// An isolated change interrupted before its patches were collected still
+	// points the session at its worktrees
+	for _, ic := range session.IsolatedChanges {
+		if ic.Status != IsolatedStatusRunning {
+			continue
+		}
+		report.Actions = append(report.Actions, fmt.Sprintf("restored working directories of isolated turn %d and collected its patches", ic.Turn))
+		if !dryRun {
+			if err := m.FinishIsolatedChange(ic.Turn); err != nil {
+				report.Actions = append(report.Actions, fmt.Sprintf("warning: isolated turn %d: %v", ic.Turn, err))
+			}
+		}
+	}
+
+	if activeStatuses[session.Status] {
+		code, message := ErrorCodeInterrupted, fmt.Sprintf("Session was interrupted during %s", session.Status)
+		if session.Status == "change_post_processing" {
+			code, message = ErrorCodePostProcessingInterrupted, "Post-processing of the change turn was interrupted"
+			// The agent finished, so the turn is complete; mark it failed so
+			// retry runs it again
+			if n := len(session.Turns); n > 0 && session.Turns[n-1].Status == "complete" {
+				markTurnInterrupted(&session.Turns[n-1], code, message, now)
+				report.Actions = append(report.Actions, fmt.Sprintf("marked turn %d as failed in post-processing", session.Turns[n-1].TurnNumber))
+			}
+		}
+		session.Status = "error"
+		session.Error = &message
+		session.ErrorDetails = &ErrorDetails{ErrorCode: code, Message: message, ErrorFiles: []ErrorFile{}}
+		session.CompletedAt = &now
+		report.Actions = append(report.Actions, fmt.Sprintf("moved session from %s to error", report.StatusBefore))
+	}
+
+	report.StatusAfter = session.Status
+	report.Changed = len(report.Actions) > 0
+	if session.Status == "error" || session.Status == "stopped" {
+		report.Next = fmt.Sprintf("gsc claude intent-workflow retry --session %s", session.SessionID)
+	}
+	if !report.Changed || dryRun {
+		return report, nil
+	}
+
+	m.stateEvent = StateEventRecovered
+	if err := m.writeSessionState(); err != nil {
+		return nil, fmt.Errorf("failed to write recovered session state: %w", err)
+	}
+	return report, nil
+}
+
+// replayTransition applies a logged state to a session whose session.json
+// is older than the log. Turns missing from the file are added as stubs.
+func replayTransition(session *Session, t StateTransition) {
+	session.Status = t.Status
+	session.Stopped = t.Stopped
+	session.WatcherPID = nil
+	if t.WatcherPID != nil {
+		pid := *t.WatcherPID
+		session.WatcherPID = &pid
+	}
+	for _, logged := range t.Turns {
+		var turn *TurnState
+		for i := range session.Turns {
+			if session.Turns[i].TurnNumber == logged.Turn {
+				turn = &session.Turns[i]
+				break
+			}
+		}
+		if turn == nil {
+			session.Turns = append(session.Turns, TurnState{TurnNumber: logged.Turn, TurnType: logged.Type, StartedAt: time.Now()})
+			turn = &session.Turns[len(session.Turns)-1]
+		}
+		turn.Status = logged.Status
+		turn.ProcessInfo.PID = logged.PID
+		turn.ProcessInfo.Running = logged.Running
+	}
+}
+
+// markTurnInterrupted records why a turn stopped without an outcome.
+func markTurnInterrupted(turn *TurnState, code, message string, at time.Time) {
+	turn.Status = "error"
+	turn.Error = &message
+	turn.ErrorDetails = &ErrorDetails{ErrorCode: code, Message: message, ErrorFiles: []ErrorFile{}}
+	if turn.CompletedAt == nil {
+		turn.CompletedAt = &at
+	}
+}
+
+// turnProcessAlive reports whether a turn is still being worked on. Turns
+// without an OS process (scripted replay) live as long as their worker.
+func turnProcessAlive(turn TurnState, watcherAlive bool) bool {
+	if turn.Status != "running" && !turn.ProcessInfo.Running {
+		return false
+	}
+	if turn.ProcessInfo.PID <= 0 {
+		return watcherAlive
+	}
+	return pidAlive(turn.ProcessInfo.PID)
+}
+
+// pidAlive reports whether a process exists.
+func pidAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
@@ -0,0 +1,178 @@
+/**
+ * Component: Intent Workflow Session Recovery Tests
+ * Block-UUID: 0d8f3b67-a2c9-4e15-9b4d-71e6c5a29f83
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests that recovery moves sessions left running by a dead process to error, including interrupted post-processing, replays state log records newer than session.json past a torn line, leaves live sessions alone, and writes nothing in a dry run.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intent_workflow
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// deadPID is far above any pid_max, so no process has it.
+const deadPID = 99999999
+
+// newRecoverySession creates a session with one turn in the given states and
+// saves it, as a crashed worker would have left it.
+func newRecoverySession(t *testing.T, id, status, turnStatus string, pid int) *Manager {
+	t.Helper()
+	t.Setenv("GSC_HOME", t.TempDir())
+
+	manager, err := NewManager(id)
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	workdirs := []WorkingDirectory{{ID: 1, Name: "app", Path: t.TempDir()}}
+	if err := manager.InitializeSession("fix the bug", workdirs, nil, false, "sonnet", true); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+	session := manager.GetSession()
+	watcher := deadPID
+	session.Status = status
+	session.WatcherPID = &watcher
+	session.Turns = append(session.Turns, TurnState{
+		TurnNumber:  1,
+		TurnType:    "change",
+		Status:      turnStatus,
+		StartedAt:   time.Now(),
+		ProcessInfo: ProcessInfo{PID: pid, Running: turnStatus == "running"},
+	})
+	if err := manager.writeSessionState(); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	return manager
+}
+
+func TestRecoverInterruptedSession(t *testing.T) {
+	newRecoverySession(t, "recover-dead", "change", "running", deadPID)
+
+	// A dry run reports the same outcome and writes nothing
+	dry, err := RecoverSession("recover-dead", true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if !dry.Changed || dry.StatusAfter != "error" {
+		t.Fatalf("dry run report = %+v", dry)
+	}
+	if loaded, _ := LoadSession("recover-dead"); loaded.GetSession().Status != "change" {
+		t.Fatalf("dry run wrote status %s", loaded.GetSession().Status)
+	}
+
+	report, err := RecoverSession("recover-dead", false)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if report.Running || !report.Changed || report.StatusBefore != "change" || report.StatusAfter != "error" {
+		t.Fatalf("report = %+v", report)
+	}
+	if report.Next != "gsc claude intent-workflow retry --session recover-dead" {
+		t.Fatalf("next = %q", report.Next)
+	}
+
+	loaded, err := LoadSession("recover-dead")
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	session := loaded.GetSession()
+	if session.WatcherPID != nil || session.ErrorDetails == nil || session.ErrorDetails.ErrorCode != ErrorCodeInterrupted {
+		t.Fatalf("session = %+v", session)
+	}
+	turn := loaded.getTurnState(1)
+	if turn.Status != "error" || turn.ProcessInfo.Running || turn.ErrorDetails.ErrorCode != ErrorCodeInterrupted {
+		t.Fatalf("turn = %+v", turn)
+	}
+
+	// The recovery is logged, and a second pass finds nothing to do
+	records, _, err := ReadStateLog(loaded.GetConfig().GetStateLogFile())
+	if err != nil || len(records) == 0 {
+		t.Fatalf("state log = %v, %v", records, err)
+	}
+	if last := records[len(records)-1]; last.Event != StateEventRecovered || last.Seq != session.StateSeq {
+		t.Fatalf("last record = %+v, state seq %d", last, session.StateSeq)
+	}
+	again, err := RecoverSession("recover-dead", false)
+	if err != nil || again.Changed {
+		t.Fatalf("second recovery = %+v, %v", again, err)
+	}
+}
+
+func TestRecoverInterruptedPostProcessing(t *testing.T) {
+	newRecoverySession(t, "recover-post", "change_post_processing", "complete", deadPID)
+
+	report, err := RecoverSession("recover-post", false)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if report.StatusAfter != "error" {
+		t.Fatalf("report = %+v", report)
+	}
+	loaded, _ := LoadSession("recover-post")
+	if code := loaded.GetSession().ErrorDetails.ErrorCode; code != ErrorCodePostProcessingInterrupted {
+		t.Fatalf("session error code = %s", code)
+	}
+	if turn := loaded.getTurnState(1); turn.Status != "error" || turn.ErrorDetails.ErrorCode != ErrorCodePostProcessingInterrupted {
+		t.Fatalf("turn = %+v", turn)
+	}
+}
+
+func TestRecoverReplaysNewerStateLog(t *testing.T) {
+	manager := newRecoverySession(t, "recover-replay", "discovery_complete", "complete", 0)
+	session := manager.GetSession()
+
+	// The worker logged the start of a change turn, then died before
+	// session.json was rewritten and while appending its next record
+	next := transitionOf(session)
+	next.Status = "change"
+	next.Turns = append(next.Turns, TurnTransition{Turn: 2, Type: "change", Status: "running", PID: deadPID, Running: true})
+	logPath := manager.GetConfig().GetStateLogFile()
+	if err := appendStateLog(logPath, StateLogRecord{Seq: session.StateSeq + 1, Time: time.Now(), Event: StateEventTransition, StateTransition: next}); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	file, err := os.OpenFile(logPath, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"seq":99,"status":"chan`)
+	file.Close()
+
+	report, err := RecoverSession("recover-replay", false)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if report.ReplayedSeq != session.StateSeq+1 || report.TornRecords != 1 || report.StatusAfter != "error" {
+		t.Fatalf("report = %+v", report)
+	}
+	loaded, _ := LoadSession("recover-replay")
+	if turn := loaded.getTurnState(2); turn == nil || turn.Status != "error" {
+		t.Fatalf("turn 2 = %+v", turn)
+	}
+
+	// The next append starts on its own line despite the torn record
+	records, torn, err := ReadStateLog(logPath)
+	if err != nil || torn != 1 || records[len(records)-1].Event != StateEventRecovered {
+		t.Fatalf("records = %+v, torn %d, %v", records, torn, err)
+	}
+}
+
+func TestRecoverLeavesLiveSessionAlone(t *testing.T) {
+	newRecoverySession(t, "recover-live", "change", "running", os.Getpid())
+
+	report, err := RecoverSession("recover-live", false)
+	if err != nil {
+		t.Fatalf("recover: %v", err)
+	}
+	if !report.Running || report.Changed || report.StatusAfter != "change" {
+		t.Fatalf("report = %+v", report)
+	}
+	if loaded, _ := LoadSession("recover-live"); loaded.getTurnState(1).Status != "running" {
+		t.Fatalf("live turn was changed")
+	}
+}
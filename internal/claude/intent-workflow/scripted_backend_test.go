@@ -0,0 +1,150 @@
+/**
+ * Component: Intent Workflow Scripted Backend Tests
+ * Block-UUID: 9e4c1a73-6b20-4f85-8d39-0a7f2e5b6c14
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Drives the discovery, format-correction, and change state machine offline by replaying recorded stream-json through the scripted agent backend.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intent_workflow
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+)
+
+// copyTemplates installs the intent-workflow templates into gscHome the way
+// gsc init does.
+func copyTemplates(t *testing.T, gscHome string) {
+	t.Helper()
+	src := filepath.Join("..", "..", "..", "pkg", "settings", "templates", "claude")
+	dst := filepath.Join(gscHome, "cli", "templates", "claude")
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dst, rel), data, 0644)
+	})
+	if err != nil {
+		t.Fatalf("copy templates: %v", err)
+	}
+}
+
+// writeRecording writes a stream-json run whose assistant message and result
+// both carry text, as Claude Code emits them.
+func writeRecording(t *testing.T, dir, name, text string, cost float64) {
+	t.Helper()
+	assistant, _ := json.Marshal(map[string]interface{}{
+		"type":    "assistant",
+		"message": map[string]interface{}{"content": []map[string]string{{"type": "text", "text": text}}},
+	})
+	result, _ := json.Marshal(map[string]interface{}{
+		"type":           "result",
+		"subtype":        "success",
+		"is_error":       false,
+		"duration_ms":    1500,
+		"total_cost_usd": cost,
+		"session_id":     "agent-" + name,
+		"result":         text,
+		"usage":          map[string]int{"input_tokens": 100, "output_tokens": 20},
+	})
+	lines := []string{`{"type":"system","subtype":"init","session_id":"agent-` + name + `"}`, string(assistant), string(result)}
+	if err := os.WriteFile(filepath.Join(dir, name+".ndjson"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatalf("write recording: %v", err)
+	}
+}
+
+func TestScriptedBackendDrivesDiscoveryCorrectionChange(t *testing.T) {
+	gscHome := t.TempDir()
+	t.Setenv("GSC_HOME", gscHome)
+	copyTemplates(t, gscHome)
+
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Skipf("git init: %v %s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	recordings := t.TempDir()
+	candidate := `{"workdir_id":1,"workdir_name":"app","file_path":"main.go","score":0.9,"reasoning":"entry point"}`
+	// discovery_mode is missing, which forces a correction turn
+	malformed := `{"candidates":[` + candidate + `],"total_found":1,"coverage":"full"}`
+	corrected := `{"candidates":[` + candidate + `],"total_found":1,"coverage":"full","discovery_mode":"generic"}`
+	writeRecording(t, recordings, "discovery", malformed, 0.5)
+	writeRecording(t, recordings, "correction", `{"status":"success","corrected_output":`+corrected+`,"reasoning":"added discovery_mode","errors_fixed":["discovery_mode"],"errors_remaining":[]}`, 0.01)
+	writeRecording(t, recordings, "change", `{"change_request":"log startup","files_modified":{"total_count":1,"in_scope_count":1,"out_of_scope_count":0,"files":[{"working_dir":"app","path":"main.go","status":"modified","scope":"in_scope"}]},"discovery_gap":{"files_added":0,"files":[]},"changelog":[],"notes":"","errors":""}`, 0.25)
+
+	manager, err := NewManager("scripted-test")
+	if err != nil {
+		t.Fatalf("new manager: %v", err)
+	}
+	agent := backend.NewScriptedBackend(recordings)
+	manager.SetBackend(agent)
+	workdirs := []WorkingDirectory{{ID: 1, Name: "app", Path: repo}}
+	if err := manager.InitializeSession("find the entry point", workdirs, nil, false, "sonnet", true); err != nil {
+		t.Fatalf("initialize: %v", err)
+	}
+
+	if err := manager.StartDiscoveryTurn(); err != nil {
+		t.Fatalf("discovery: %v", err)
+	}
+	session := manager.GetSession()
+	if session.Status != "discovery_complete" {
+		t.Fatalf("status after discovery = %s (error %v)", session.Status, session.Error)
+	}
+	discovery := manager.getTurnState(1)
+	if discovery.CorrectionStatus != CorrectionStatusSuccess || discovery.CorrectionAttempts != 1 {
+		t.Fatalf("correction = %s after %d attempts", discovery.CorrectionStatus, discovery.CorrectionAttempts)
+	}
+	if discovery.Result == nil || discovery.Result.Discovery == nil || discovery.Result.Discovery.DiscoveryMode != "generic" {
+		t.Fatalf("corrected discovery = %+v", discovery.Result)
+	}
+	if discovery.Cost == nil || *discovery.Cost != 0.5 || discovery.CorrectionCost == nil || *discovery.CorrectionCost != 0.01 {
+		t.Fatalf("cost = %v, correction cost = %v", discovery.Cost, discovery.CorrectionCost)
+	}
+
+	if err := manager.StartChangeTurn("log startup"); err != nil {
+		t.Fatalf("change: %v", err)
+	}
+	if session.Status != "change_post_processing" {
+		t.Fatalf("status after change = %s (error %v)", session.Status, session.Error)
+	}
+	change := manager.getTurnState(2)
+	if change.Result == nil || change.Result.Change == nil || change.Result.Change.FilesModified.TotalCount != 1 {
+		t.Fatalf("change result = %+v", change.Result)
+	}
+	if change.Cost == nil || *change.Cost != 0.25 {
+		t.Fatalf("change cost = %v", change.Cost)
+	}
+
+	var labels []string
+	for _, req := range agent.Requests() {
+		labels = append(labels, req.Label)
+	}
+	if strings.Join(labels, ",") != "discovery,correction,change" {
+		t.Fatalf("agent runs = %v", labels)
+	}
+	perms, ok := agent.Permissions(manager.GetConfig().GetTurnDir(2))
+	if !ok || !perms.SkipPrompts || len(perms.Commands) == 0 {
+		t.Fatalf("change turn permissions = %+v", perms)
+	}
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Spawn
- * Block-UUID: 2655a789-1821-47bd-94f6-927a6929a398
- * Parent-UUID: 7a8f9c2d-4e5f-4a3b-8c7d-9e0f1a2b3c4d
- * Version: 1.9.0
- * Description: Updated template paths from data/templates to cli/templates to separate CLI-specific data from app-specific data.
+ * Block-UUID: d1b6e273-7233-418c-a56c-4a7ab997df36
+ * Parent-UUID: d6fb964a-62d1-47cc-9920-d01f1a688602
+ * Version: 1.11.0
+ * Description: Spawns the agent subprocess for a turn through the session's AgentBackend (Claude Code by default). The turn's tools and shell commands come from the session's permission profile for its phase, and are recorded on the turn for auditing.
  * Language: Go
  * Created-at: 2026-04-29T02:37:27.761Z
- * Authors: Gemini 2.5 Flash Lite (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.3.1), GLM-4.7 (v1.3.2), GLM-4.7 (v1.3.3), GLM-4.7 (v1.3.4), GLM-4.7 (v1.4.0), GLM-4.7 (v1.4.1), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0)
+ * Authors: Gemini 2.5 Flash Lite (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.3.1), GLM-4.7 (v1.3.2), GLM-4.7 (v1.3.3), GLM-4.7 (v1.3.4), GLM-4.7 (v1.4.0), GLM-4.7 (v1.4.1), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), agent (v1.10.0), agent (v1.11.0)
  */
 
 
@@ -15,17 +15,15 @@ package intent_workflow
 import (
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
-	"runtime"
-	"strings"
 
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
 	"github.com/gitsense/gsc-cli/pkg/settings"
 )
 
 
-// spawnClaudeSubprocess spawns the claude subprocess for a turn
-func (m *Manager) spawnClaudeSubprocess(turn int, turnType string, orphanedFiles []string) error {
+// spawnAgentSubprocess spawns the agent backend subprocess for a turn
+func (m *Manager) spawnAgentSubprocess(turn int, turnType string, orphanedFiles []string) error {
 	m.debugLogger.Log("DEBUG", fmt.Sprintf("Spawning subprocess for turn %d", turn))
 
 	// Get the Claude prompt template using absolute path
@@ -39,21 +37,28 @@ func (m *Manager) spawnClaudeSubprocess(turn int, turnType string, orphanedFiles
 	// Define agent templates path
 	agentTemplatesPath := filepath.Join(gscHome, "cli", "templates", "claude", "intent-workflow")
 
-	// Find gsc location to add to PATH
-	gscPath, err := exec.LookPath("gsc")
+	agent, err := m.agentBackend()
 	if err != nil {
-		m.debugLogger.LogError("Failed to find gsc in PATH", err)
-		return fmt.Errorf("gsc not found in PATH: %w", err)
+		m.debugLogger.LogError("Failed to resolve agent backend", err)
+		return fmt.Errorf("failed to resolve agent backend: %w", err)
 	}
-	gscDir := filepath.Dir(gscPath)
-	m.debugLogger.Log("DEBUG", fmt.Sprintf("Found gsc at: %s", gscPath))
-	m.debugLogger.Log("DEBUG", fmt.Sprintf("Adding to PATH: %s", gscDir))
+	m.debugLogger.Log("DEBUG", fmt.Sprintf("Agent backend: %s", agent.Name()))
 
 	// Write agent permissions to restrict Bash to gsc commands only
 	if err := m.WriteAgentPermissions(m.config.GetTurnDir(turn)); err != nil {
 		m.debugLogger.LogError("Failed to write permissions", err)
 		return fmt.Errorf("failed to write permissions: %w", err)
 	}
+	// The session's permission profile decides the phase's tools and commands;
+	// the turn records what it ran with for auditing
+	permissions := m.permissionsFor(phaseForTurn(turnType))
+	if turnState := m.getTurnState(turn); turnState != nil {
+		turnState.Permissions = &permissions
+	}
+	if err := agent.ConfigurePermissions(m.config.GetTurnDir(turn), permissions.toBackend()); err != nil {
+		m.debugLogger.LogError("Failed to configure backend permissions", err)
+		return fmt.Errorf("failed to write permissions: %w", err)
+	}
 	m.debugLogger.Log("DEBUG", "Permissions written successfully")
 
 	// Write reference files NDJSON to turn directory
@@ -174,199 +179,83 @@ func (m *Manager) spawnClaudeSubprocess(turn int, turnType string, orphanedFiles
 	}
 	m.debugLogger.Log("DEBUG", "Pre-flight verification complete")
 
-	var cmd *exec.Cmd
-
-	if runtime.GOOS == "windows" {
-		// Windows: Write prompt to file and use @file syntax
-		m.debugLogger.Log("DEBUG", "Using Windows file-based approach for prompt")
-
-		// Build claude command
-		args := []string{
-			"--allowedTools", "Read,Write,Bash",
-			"--verbose",
-			"--dangerously-skip-permissions",
-			"--include-partial-messages",
-			"--output-format", "stream-json",
-			"--append-system-prompt-file", systemPromptFile,
-		}
-
-		// Add add-dir flags
-		for _, wd := range m.session.WorkingDirectories {
-			args = append(args, "--add-dir", wd.Path)
-		}
-
-		if m.session.Model != "" {
-			args = append(args, "--model", m.session.Model)
-		}
-
-		// Read task.md and pass as string
-		taskPath := filepath.Join(m.config.GetTurnDir(turn), "task.md")
-		taskContent, err := os.ReadFile(taskPath)
-		if err != nil {
-			m.debugLogger.LogError("Failed to read task prompt", err)
-			m.markAsError("TASK_READ_FAILED", fmt.Sprintf("Failed to read task prompt: %v", err))
-			return fmt.Errorf("failed to read task prompt: %w", err)
-		}
-		args = append(args, "-p", fmt.Sprintf("%q", string(taskContent)))
-
-		cmd = exec.Command("claude", args...)
-		cmd.Dir = m.config.GetTurnDir(turn)
-		m.debugLogger.Log("DEBUG", fmt.Sprintf("Claude command: %s", cmd.String()))
-	} else {
-		// Unix: Use bash script with heredoc
-		m.debugLogger.Log("DEBUG", "Using Unix bash script with heredoc")
-
-		// Build add-dir flags
-		var addDirFlags []string
-		for _, wd := range m.session.WorkingDirectories {
-			addDirFlags = append(addDirFlags, fmt.Sprintf("--add-dir %s", wd.Path))
-		}
-		addDirFlagsStr := strings.Join(addDirFlags, " ")
-
-		// Build model flag if specified
-		modelFlag := ""
-		if m.session.Model != "" {
-			modelFlag = fmt.Sprintf("--model %s", m.session.Model)
-		}
-
-		// Read task.md for the script
-		taskPath := filepath.Join(m.config.GetTurnDir(turn), "task.md")
-		taskContent, err := os.ReadFile(taskPath)
-		if err != nil {
-			m.debugLogger.LogError("Failed to read task prompt", err)
-			m.markAsError("TASK_READ_FAILED", fmt.Sprintf("Failed to read task prompt: %v", err))
-			return fmt.Errorf("failed to read task prompt: %w", err)
-		}
-
-		// Create bash script content using heredoc for -p flag
-		// Add gsc directory to PATH
-		// IMPORTANT: Trap SIGTERM to forward to Claude process for graceful shutdown
-		scriptContent := fmt.Sprintf(`#!/bin/bash
-set -e
-
-# Set Claude Code file reading max tokens (default: 15000, user-overridable)
-export CLAUDE_CODE_FILE_READ_MAX_OUTPUT_TOKENS="${CLAUDE_CODE_FILE_READ_MAX_OUTPUT_TOKENS:-%d}"
-
-# Add gsc directory to PATH
-export PATH="%s:$PATH"
-
-# Trap SIGTERM and forward to child process for graceful shutdown
-trap 'echo "Received SIGTERM, forwarding to Claude process..."; kill -TERM $PID 2>/dev/null; wait $PID; exit 143' TERM
-
-# Verify gsc is available
-if ! command -v gsc &> /dev/null; then
-    echo "ERROR: gsc command not found in PATH"
-    echo "Current PATH: $PATH"
-    echo "Expected gsc at: %s"
-    exit 1
-fi
-
-echo "=== Starting Claude Agent subprocess ==="
-echo "Working directory: $(pwd)"
-echo "Turn: %d"
-echo "Turn Type: %s"
-echo "Session ID: %s"
-echo "gsc location: $(which gsc)"
-echo "=== Executing Claude command (PID: $$) ==="
-
-claude --allowedTools Read,Bash,Write \
---verbose \
---dangerously-skip-permissions \
---include-partial-messages \
---output-format stream-json \
---append-system-prompt-file system-prompt.md \
-%s \
-%s \
--p <<'__GSC_PROMPT_END__' &
-%s
-__GSC_PROMPT_END__
-
-PID=$!
-echo "Claude process started with PID: $PID"
-wait $PID
-CLAUDE_EXIT_CODE=$?
-echo "=== Claude subprocess completed with exit code: $CLAUDE_EXIT_CODE ==="
-exit $CLAUDE_EXIT_CODE
-`, defaultFileReadMaxTokens, gscDir, gscPath, turn, turnType, m.session.SessionID, addDirFlagsStr, modelFlag, string(taskContent))
-
-		// Write bash script to turn directory
-		scriptPath := filepath.Join(m.config.GetTurnDir(turn), "run-claude.sh")
-		if err := os.WriteFile(scriptPath, []byte(scriptContent), 0755); err != nil {
-			m.debugLogger.LogError("Failed to write bash script", err)
-			m.markAsError("SCRIPT_FAILED", fmt.Sprintf("Failed to write bash script: %v", err))
-			return fmt.Errorf("failed to write bash script: %w", err)
-		}
-		m.debugLogger.Log("DEBUG", fmt.Sprintf("Bash script written to: %s", scriptPath))
-
-		// Execute the bash script
-		cmd = exec.Command("/bin/bash", scriptPath)
-		cmd.Dir = m.config.GetTurnDir(turn)
-		m.debugLogger.Log("DEBUG", fmt.Sprintf("Working directory: %s", cmd.Dir))
-	}
-
-	// Create stderr pipe for error capture
-	// Create stdout pipe for stream processing
-	stdout, err := cmd.StdoutPipe()
+	// Read task.md; it becomes the agent's prompt
+	taskContent, err := os.ReadFile(filepath.Join(turnDir, "task.md"))
 	if err != nil {
-		m.debugLogger.LogError("Failed to create stdout pipe", err)
-		return fmt.Errorf("failed to create stdout pipe: %w", err)
+		m.debugLogger.LogError("Failed to read task prompt", err)
+		m.markAsError("TASK_READ_FAILED", fmt.Sprintf("Failed to read task prompt: %v", err))
+		return fmt.Errorf("failed to read task prompt: %w", err)
 	}
-	m.debugLogger.Log("DEBUG", "Stdout pipe created successfully")
 
-	stderr, err := cmd.StderrPipe()
-	if err != nil {
-		m.debugLogger.LogError("Failed to create stderr pipe", err)
-		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	var addDirs []string
+	for _, wd := range m.session.WorkingDirectories {
+		addDirs = append(addDirs, wd.Path)
 	}
-	m.debugLogger.Log("DEBUG", "Stderr pipe created successfully")
 
-	// Start the process
-	if err := cmd.Start(); err != nil {
+	// The backend writes run-claude.sh (or its equivalent) on Unix so the
+	// exact invocation can be re-run by hand; gsc must be on the agent's PATH
+	proc, err := agent.Start(backend.SpawnRequest{
+		Label:             turnType,
+		Turn:              turn,
+		SessionID:         m.session.SessionID,
+		Dir:               turnDir,
+		Prompt:            string(taskContent),
+		SystemPromptFile:  "system-prompt.md",
+		Model:             m.session.Model,
+		AddDirs:           addDirs,
+		Permissions:       permissions.toBackend(),
+		FileReadMaxTokens: defaultFileReadMaxTokens,
+		PathCommands:      []string{"gsc"},
+		ScriptName:        "run-claude.sh",
+	})
+	if err != nil {
 		m.debugLogger.LogError("Failed to start subprocess", err)
 		m.markAsError("START_FAILED", fmt.Sprintf("Failed to start subprocess: %v", err))
-		stdout.Close()
-		stderr.Close()
 		return fmt.Errorf("failed to start subprocess: %w", err)
 	}
 
 	m.processInfo = &ProcessInfo{
-		PID:     cmd.Process.Pid,
-		Command: cmd.String(),
+		PID:     proc.PID,
+		Command: proc.Command,
 		Running: true,
 	}
-	m.debugLogger.LogProcessSpawn(cmd.Process.Pid, cmd.String(), cmd.Dir)
+	m.debugLogger.LogProcessSpawn(proc.PID, proc.Command, turnDir)
 
 	// CRITICAL FIX: Persist PID to session.json immediately
 	// This allows StopSession() to recover the PID when called from CLI stop command
 	for i := range m.session.Turns {
 		if m.session.Turns[i].TurnNumber == turn {
-			m.session.Turns[i].ProcessInfo.PID = cmd.Process.Pid
-			m.session.Turns[i].ProcessInfo.Command = cmd.String()
+			m.session.Turns[i].ProcessInfo.PID = proc.PID
+			m.session.Turns[i].ProcessInfo.Command = proc.Command
 			break
 		}
 	}
 	m.writeSessionState()
 
+	m.session.Stopped = false // Initialize stopped flag before the goroutines read session state
+
 	// Start background goroutine to process stream
 	m.debugLogger.Log("DEBUG", "Starting stream processing goroutine")
 	m.wg.Add(1)
-	go m.processStream(stdout, turn)
+	streamDone := make(chan struct{})
+	go func() {
+		defer close(streamDone)
+		m.processStream(proc.Stdout, turn, agent)
+	}()
 
 	// Start background goroutine to reap zombie process
 	m.debugLogger.Log("DEBUG", "Starting process reaper goroutine")
 	m.wg.Add(1)
-	m.session.Stopped = false // Initialize stopped flag
 	go func() {
+		// Stdout must be drained before Wait, which closes the pipe; this also
+		// keeps finalization from racing the stream processor's state updates
+		<-streamDone
 		m.debugLogger.Log("DEBUG", "Process reaper: waiting for process to exit")
-		err := cmd.Wait()
-		exitCode := 0
-		if err != nil {
-			if exitErr, ok := err.(*exec.ExitError); ok {
-				exitCode = exitErr.ExitCode()
-				m.debugLogger.Log("DEBUG", fmt.Sprintf("Process reaper: process exited with code %d (stopped: %v)", exitCode, m.session.Stopped))
-			}
+		exitCode, err := proc.Wait()
+		if exitCode != 0 {
+			m.debugLogger.Log("DEBUG", fmt.Sprintf("Process reaper: process exited with code %d (stopped: %v)", exitCode, m.session.Stopped))
 		}
-		m.debugLogger.LogProcessExit(cmd.Process.Pid, exitCode, err)
+		m.debugLogger.LogProcessExit(proc.PID, exitCode, err)
 
 		// CRITICAL FIX: Check for .stopped file ONCE at the beginning of the goroutine
 		// This fixes bug where file was checked three times and removed after first check,
@@ -385,6 +274,6 @@ exit $CLAUDE_EXIT_CODE
 
 	// Start background goroutine to capture stderr
 	m.wg.Add(1)
-	go m.captureStderr(stderr)
+	go m.captureStderr(proc.Stderr)
 	return nil
 }
@@ -0,0 +1,215 @@
+/**
+ * Component: Intent Workflow State Log
+ * Block-UUID: 9b4c2e71-0d58-4a36-8f1e-5c7a3d92b604
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Write-ahead log of session state transitions. Every session write whose status, turn states, or process IDs changed first appends a sequenced record to state.wal and fsyncs it; session.json is then replaced atomically. Recovery replays records newer than session.json.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intent_workflow
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// State log events
+const (
+	StateEventTransition = "transition"
+	StateEventRecovered  = "recovered"
+)
+
+// StateTransition is the part of a session that decides where it stands and
+// what can run next: its status, worker, and the state of each turn.
+type StateTransition struct {
+	Status     string           `json:"status"`
+	WatcherPID *int             `json:"watcher_pid,omitempty"`
+	Stopped    bool             `json:"stopped,omitempty"`
+	ErrorCode  string           `json:"error_code,omitempty"`
+	Turns      []TurnTransition `json:"turns"`
+}
+
+// TurnTransition is the state of one turn in a StateTransition.
+type TurnTransition struct {
+	Turn    int    `json:"turn"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	PID     int    `json:"pid,omitempty"`
+	Running bool   `json:"running,omitempty"`
+}
+
+// StateLogRecord is one line of state.wal.
+type StateLogRecord struct {
+	Seq       int       `json:"seq"`
+	Time      time.Time `json:"time"`
+	Event     string    `json:"event"`      // "transition" or "recovered"
+	WriterPID int       `json:"writer_pid"` // Process that wrote the record
+	StateTransition
+}
+
+// transitionOf extracts the logged state of a session.
+func transitionOf(session *Session) StateTransition {
+	t := StateTransition{
+		Status:  session.Status,
+		Stopped: session.Stopped,
+		Turns:   make([]TurnTransition, 0, len(session.Turns)),
+	}
+	if session.WatcherPID != nil {
+		pid := *session.WatcherPID
+		t.WatcherPID = &pid
+	}
+	if session.ErrorDetails != nil {
+		t.ErrorCode = session.ErrorDetails.ErrorCode
+	}
+	for _, turn := range session.Turns {
+		t.Turns = append(t.Turns, TurnTransition{
+			Turn:    turn.TurnNumber,
+			Type:    turn.TurnType,
+			Status:  turn.Status,
+			PID:     turn.ProcessInfo.PID,
+			Running: turn.ProcessInfo.Running,
+		})
+	}
+	return t
+}
+
+// logTransition appends the session's state to the state log when it
+// differs from the last state this manager logged, and advances
+// Session.StateSeq. The record is on disk before session.json changes.
+func (m *Manager) logTransition() error {
+	transition := transitionOf(m.session)
+	key, err := json.Marshal(transition)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state transition: %w", err)
+	}
+	event := m.stateEvent
+	if event == "" {
+		if string(key) == m.lastTransition {
+			return nil
+		}
+		event = StateEventTransition
+	}
+
+	record := StateLogRecord{
+		Seq:             m.session.StateSeq + 1,
+		Time:            time.Now().UTC(),
+		Event:           event,
+		WriterPID:       os.Getpid(),
+		StateTransition: transition,
+	}
+	if err := appendStateLog(m.config.GetStateLogFile(), record); err != nil {
+		return err
+	}
+	m.session.StateSeq = record.Seq
+	m.lastTransition = string(key)
+	m.stateEvent = ""
+	return nil
+}
+
+// appendStateLog appends one record and syncs it to disk.
+func appendStateLog(path string, record StateLogRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal state log record: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open state log: %w", err)
+	}
+	// Start on a fresh line if a crash tore the previous record, so this one
+	// is not lost with it
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		last := make([]byte, 1)
+		if _, err := file.ReadAt(last, info.Size()-1); err == nil && last[0] != '\n' {
+			line = append([]byte{'\n'}, line...)
+		}
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to append to state log: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to sync state log: %w", err)
+	}
+	return file.Close()
+}
+
+// ReadStateLog returns the records of a state log in order, and how many
+// lines could not be parsed (a record torn by a crash mid-append). A missing
+// log has no records.
+func ReadStateLog(path string) ([]StateLogRecord, int, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open state log: %w", err)
+	}
+	defer file.Close()
+
+	var records []StateLogRecord
+	torn := 0
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), maxTokenSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var record StateLogRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.Seq == 0 {
+			torn++
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return records, torn, fmt.Errorf("failed to read state log: %w", err)
+	}
+	return records, torn, nil
+}
+
+// writeFileAtomic replaces path with data so readers and a crash see either
+// the old or the new content, never a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Chmod(tmpPath, 0644); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	// Persist the rename itself; not every platform can sync a directory
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
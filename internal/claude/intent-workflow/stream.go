@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow Stream Event Processor
- * Block-UUID: 67a2684d-168d-45fd-a967-f05510e7b2fc
- * Parent-UUID: fb49d352-31c3-4a07-9a4b-3212e1854671
- * Version: 3.8.0
- * Description: Stream event processor for intent workflow sessions that parses Claude's streaming JSONL output, handles events, and updates session state. Updated to access Candidates from TurnResult.Discovery and removed TurnState.Candidates/TotalFound fields.
+ * Block-UUID: c9aa6b23-e88c-4882-9dcf-7dfee808e0fa
+ * Parent-UUID: 0e28d007-b6c1-4524-96a2-dd2ce497b7ff
+ * Version: 3.10.0
+ * Description: Stream event processor for intent workflow sessions that parses the agent's streaming JSONL output through AgentBackend.ParseEvent and updates session state. Meters each event against the session budget, stopping the turn once a token limit is crossed, and refuses format correction attempts the budget cannot cover.
  * Language: Go
  * Created-at: 2026-04-27T03:32:21.946Z
- * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), claude-haiku-4-5-20251001 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), claude-haiku-4-5-20251001 (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v2.0.0), GLM-4.7 (v2.1.0), GLM-4.7 (v2.2.0), GLM-4.7 (v2.3.0), GLM-4.7 (v2.4.0), Gemini 2.5 Flash Lite (v2.5.0), GLM-4.7 (v2.6.0), GLM-4.7 (v2.7.0), GLM-4.7 (v2.8.0), GLM-4.7 (v2.9.0), GLM-4.7 (v3.0.0), GLM-4.7 (v3.1.0), GLM-4.7 (v3.2.0), GLM-4.7 (v3.3.0), GLM-4.7 (v3.4.0), GLM-4.7 (v3.5.0), GLM-4.7 (v3.6.0), GLM-4.7 (v3.7.0), GLM-4.7 (v3.8.0)
+ * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), claude-haiku-4-5-20251001 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), claude-haiku-4-5-20251001 (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v2.0.0), GLM-4.7 (v2.1.0), GLM-4.7 (v2.2.0), GLM-4.7 (v2.3.0), GLM-4.7 (v2.4.0), Gemini 2.5 Flash Lite (v2.5.0), GLM-4.7 (v2.6.0), GLM-4.7 (v2.7.0), GLM-4.7 (v2.8.0), GLM-4.7 (v2.9.0), GLM-4.7 (v3.0.0), GLM-4.7 (v3.1.0), GLM-4.7 (v3.2.0), GLM-4.7 (v3.3.0), GLM-4.7 (v3.4.0), GLM-4.7 (v3.5.0), GLM-4.7 (v3.6.0), GLM-4.7 (v3.7.0), GLM-4.7 (v3.8.0), agent (v3.9.0), agent (v3.10.0)
  */
 
 
@@ -21,10 +21,13 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
 )
 
-// processStream reads Claude's stdout and processes events
-func (m *Manager) processStream(stdout io.Reader, turn int) {
+// processStream reads the agent's stdout and processes events normalized by
+// its backend
+func (m *Manager) processStream(stdout io.Reader, turn int, agent backend.AgentBackend) {
 	m.debugLogger.Log("STREAM", "Stream processing started")
 	
 	// Open output.log for writing raw stdout
@@ -78,6 +81,7 @@ func (m *Manager) processStream(stdout io.Reader, turn int) {
 	var claudeSessionID string
 
 	lineCount := 0
+	meter := m.newTurnMeter(turn)
 
 	scannerLoop:
 	for scanner.Scan() {
@@ -100,18 +104,28 @@ func (m *Manager) processStream(stdout io.Reader, turn int) {
 		
 		m.debugLogger.LogStreamEvent("LINE_READ", fmt.Sprintf("line %d: %s", lineCount, m.truncateForLog(line, 200)))
 
-		// Parse as generic map
-		var result map[string]interface{}
-		if err := json.Unmarshal([]byte(line), &result); err != nil {
-			// Not valid JSON, skip
-			// Log as raw event for debugging
-			m.debugLogger.LogStreamEvent("JSON_PARSE_ERROR", fmt.Sprintf("line %d: %v", lineCount, err))
+		// Normalize the event through the backend
+		event, ok := agent.ParseEvent(line)
+		if !ok {
+			// Not a valid event, skip
+			m.debugLogger.LogStreamEvent("JSON_PARSE_ERROR", fmt.Sprintf("line %d: not a %s event", lineCount, agent.Name()))
 			continue
 		}
 		m.debugLogger.LogStreamEvent("JSON_PARSED", fmt.Sprintf("line %d: valid JSON", lineCount))
 
+		// Meter the turn against the session budget. A run still producing
+		// output is stopped; a result that crosses a cost limit is kept and
+		// blocks the next run instead
+		if meter != nil {
+			if exceeded := meter.Observe(event); exceeded != nil && event.Type != backend.EventResult {
+				m.eventWriter.WriteRawEvent(line)
+				m.stopForBudget(exceeded, usageFromBackend(meter.Usage()))
+				break scannerLoop
+			}
+		}
+
 		// Check event type
-		eventType, _ := result["type"].(string)
+		eventType := event.Type
 		m.debugLogger.LogStreamEvent("EVENT_TYPE", fmt.Sprintf("line %d: %s", lineCount, eventType))
 
 		switch eventType {
@@ -121,13 +135,11 @@ func (m *Manager) processStream(stdout io.Reader, turn int) {
 			continue
 
 		case "error":
-			// Handle error events from Claude CLI
-			if errorData, ok := result["error"].(map[string]interface{}); ok {
-				errorType, _ := errorData["type"].(string)
-				errorMsg, _ := errorData["message"].(string)
+			// Handle error events from the agent CLI
+			if event.ErrorType != "" || event.ErrorMessage != "" {
 				// Update session state
 				m.session.Status = "error"
-				errMsg := fmt.Sprintf("%s: %s", errorType, errorMsg)
+				errMsg := fmt.Sprintf("%s: %s", event.ErrorType, event.ErrorMessage)
 				m.session.Error = &errMsg
 				m.writeSessionState()
 			}
@@ -146,38 +158,19 @@ func (m *Manager) processStream(stdout io.Reader, turn int) {
 			// DEBUG: Log raw result event line
 			m.debugLogger.Log("METRICS", fmt.Sprintf("RAW RESULT EVENT (line %d): %s", lineCount, m.truncateForLog(line, 500)))
 
-			// Parse result event using a typed struct for safe metric extraction
-			var resultMeta struct {
-				IsError      bool    `json:"is_error"`
-				DurationMS   float64 `json:"duration_ms"`
-				TotalCostUSD float64 `json:"total_cost_usd"`
-				SessionID    string  `json:"session_id"`
-				Result       string  `json:"result"`
-				Usage        struct {
-					InputTokens              int `json:"input_tokens"`
-					OutputTokens             int `json:"output_tokens"`
-					CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
-					CacheReadInputTokens     int `json:"cache_read_input_tokens"`
-				} `json:"usage"`
-			}
-			if err := json.Unmarshal([]byte(line), &resultMeta); err != nil {
-				m.debugLogger.LogError("Failed to parse result event", err)
-				continue
-			} else {
-				// DEBUG: Log successful parsing
-				m.debugLogger.Log("METRICS", fmt.Sprintf(
-					"PARSED SUCCESSFULLY - Duration: %.0fms, Cost: $%.6f, InputTokens: %d, OutputTokens: %d, CacheCreation: %d, CacheRead: %d, ResultLen: %d, IsError: %v",
-					resultMeta.DurationMS,
-					resultMeta.TotalCostUSD,
-					resultMeta.Usage.InputTokens,
-					resultMeta.Usage.OutputTokens,
-					resultMeta.Usage.CacheCreationInputTokens,
-					resultMeta.Usage.CacheReadInputTokens,
-					len(resultMeta.Result),
-					resultMeta.IsError,
-				))
-			}
-			resultContent := resultMeta.Result
+			// Result metrics were extracted by the backend
+			m.debugLogger.Log("METRICS", fmt.Sprintf(
+				"PARSED SUCCESSFULLY - Duration: %dms, Cost: $%.6f, InputTokens: %d, OutputTokens: %d, CacheCreation: %d, CacheRead: %d, ResultLen: %d, IsError: %v",
+				event.DurationMS,
+				event.CostUSD,
+				event.Usage.InputTokens,
+				event.Usage.OutputTokens,
+				event.Usage.CacheCreationTokens,
+				event.Usage.CacheReadTokens,
+				len(event.Text),
+				event.IsError,
+			))
+			resultContent := event.Text
 			if resultContent != "" {
 				// Sanitize the content to remove markdown fences and conversational text
 				sanitizedContent := extractJSONPayload(resultContent)
@@ -193,10 +186,10 @@ func (m *Manager) processStream(stdout io.Reader, turn int) {
 				}
 
 				// Check for error in result event
-				if resultMeta.IsError {
-					m.debugLogger.LogError("Claude result error", fmt.Errorf("%v", result))
+				if event.IsError {
+					m.debugLogger.LogError("Agent result error", fmt.Errorf("%s", line))
 					m.session.Status = "error"
-					errMsg := fmt.Sprintf("Claude returned error: %v", result)
+					errMsg := fmt.Sprintf("%s returned error: %s", agent.Name(), line)
 					m.session.Error = &errMsg
 					m.writeSessionState()
 					continue
@@ -205,16 +198,16 @@ func (m *Manager) processStream(stdout io.Reader, turn int) {
 				// DEBUG: Log entering conditional block
 				m.debugLogger.Log("METRICS", "ENTERING resultContent processing block")
 
-				// Extract usage/cost/duration from outer event (type-safe via struct)
+				// Extract usage/cost/duration normalized by the backend
 				usage = Usage{
-					InputTokens:         resultMeta.Usage.InputTokens,
-					OutputTokens:        resultMeta.Usage.OutputTokens,
-					CacheCreationTokens: resultMeta.Usage.CacheCreationInputTokens,
-					CacheReadTokens:     resultMeta.Usage.CacheReadInputTokens,
+					InputTokens:         event.Usage.InputTokens,
+					OutputTokens:        event.Usage.OutputTokens,
+					CacheCreationTokens: event.Usage.CacheCreationTokens,
+					CacheReadTokens:     event.Usage.CacheReadTokens,
 				}
-				cost = resultMeta.TotalCostUSD
-				duration = int64(resultMeta.DurationMS)
-				claudeSessionID = resultMeta.SessionID
+				cost = event.CostUSD
+				duration = event.DurationMS
+				claudeSessionID = event.SessionID
 				
 				// DEBUG: Log extracted values
 				m.debugLogger.Log("METRICS", fmt.Sprintf(
@@ -341,8 +334,15 @@ func (m *Manager) processStream(stdout io.Reader, turn int) {
 		m.writeSessionState()
 	}
 
-	// Post-process the last assistant message to extract results
-	if m.lastAssistantMessage != "" {
+	if m.budgetExceeded != nil {
+		// Drain what the stopping agent still writes so it cannot block on
+		// a full pipe before it exits
+		io.Copy(io.Discard, stdout)
+	}
+
+	// Post-process the last assistant message to extract results. A turn
+	// stopped over budget has no final message to process.
+	if m.lastAssistantMessage != "" && m.budgetExceeded == nil {
 		// DEBUG: Log post-processing last assistant message
 		m.debugLogger.Log("METRICS", "Post-processing last assistant message")
 
@@ -757,6 +757,13 @@ func (m *Manager) runFormatCorrectionLoop(turn int, turnType string, resultConte
 
 	corrected := false
 	for attempt := 1; attempt <= DefaultCorrectionTries; attempt++ {
+		// Each attempt is another paid run; stop once the turn or session
+		// has no budget left for it
+		if exceeded := m.checkCorrectionBudget(turn, usage, cost); exceeded != nil {
+			m.recordBudgetExceeded(exceeded, nil)
+			break
+		}
+
 		startEvent := map[string]interface{}{
 			"type":      "correction_start",
 			"turn":      turn,
@@ -822,6 +829,9 @@ func (m *Manager) runFormatCorrectionLoop(turn int, turnType string, resultConte
 		m.lastAssistantMessage = ""
 		return
 	}
+	if m.budgetExceeded != nil {
+		return
+	}
 
 	m.setFormatCorrectionError(turn, fmt.Sprintf("format correction failed after %d attempts", DefaultCorrectionTries))
 }
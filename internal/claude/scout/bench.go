@@ -0,0 +1,438 @@
+/**
+ * Component: Scout Discovery Benchmark
+ * Block-UUID: 4f2a9c61-7e3b-4d85-b1a0-c6e8d93f5b27
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Benchmarks discovery quality. Loads a suite of intents paired with the files discovery should find, or learns one from past sessions' change results and discovery gaps, runs a discovery turn per case with and without experts context, and reports recall, precision, cost, and latency per configuration.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package scout
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+)
+
+// Bench configurations: discovery with the experts context (Manifests and
+// Brains) when the working directories have one, and generic grep/find only.
+const (
+	BenchConfigExperts = "experts"
+	BenchConfigGeneric = "generic"
+)
+
+// BenchSessionPrefix marks sessions created by a benchmark run so they are
+// never learned back into a suite.
+const BenchSessionPrefix = "bench-"
+
+// BenchSuite is a set of intents paired with the files discovery should find.
+type BenchSuite struct {
+	Name     string      `json:"name,omitempty"`
+	Workdirs []string    `json:"workdirs,omitempty"` // Default working directories for cases that set none
+	Cases    []BenchCase `json:"cases"`
+}
+
+// BenchCase is one intent and its gold-standard file set.
+type BenchCase struct {
+	ID       string      `json:"id"`
+	Intent   string      `json:"intent"`
+	Workdirs []string    `json:"workdirs,omitempty"`
+	Expected []BenchFile `json:"expected"`
+	Source   string      `json:"source,omitempty"` // Session the case was learned from
+}
+
+// BenchFile identifies a file by working directory name and path relative
+// to it, the way discovery candidates do.
+type BenchFile struct {
+	Workdir string `json:"workdir"`
+	Path    string `json:"path"`
+}
+
+func (f BenchFile) key() string {
+	return f.Workdir + "/" + filepath.ToSlash(filepath.Clean(f.Path))
+}
+
+// BenchOptions controls a benchmark run.
+type BenchOptions struct {
+	Configs []string       // Defaults to experts and generic
+	Model   string         // Model family passed to each discovery session
+	Budget  budget.Limits  // Applied to each discovery session
+	OnRun   func(BenchRun) // Called after each case and configuration finishes
+}
+
+// BenchRun is the outcome of one case under one configuration.
+type BenchRun struct {
+	Case          string      `json:"case"`
+	Config        string      `json:"config"`
+	SessionID     string      `json:"session_id"`
+	DiscoveryMode string      `json:"discovery_mode,omitempty"` // Mode the agent reported using
+	Expected      int         `json:"expected"`
+	Returned      int         `json:"returned"`
+	Matched       int         `json:"matched"`
+	Recall        float64     `json:"recall"`
+	Precision     float64     `json:"precision"`
+	Cost          float64     `json:"cost"`
+	LatencyMs     int64       `json:"latency_ms"`
+	Missed        []BenchFile `json:"missed,omitempty"`
+	Error         string      `json:"error,omitempty"`
+}
+
+// BenchSummary aggregates the runs of one configuration. Recall, precision,
+// and latency are means over runs that produced a discovery result; failed
+// runs are counted in Errors and still contribute their cost.
+type BenchSummary struct {
+	Config        string  `json:"config"`
+	Cases         int     `json:"cases"`
+	Errors        int     `json:"errors"`
+	Recall        float64 `json:"recall"`
+	Precision     float64 `json:"precision"`
+	TotalCost     float64 `json:"total_cost"`
+	MeanCost      float64 `json:"mean_cost"`
+	MeanLatencyMs int64   `json:"mean_latency_ms"`
+}
+
+// BenchReport is the result of a benchmark run.
+type BenchReport struct {
+	Suite     string         `json:"suite,omitempty"`
+	StartedAt time.Time      `json:"started_at"`
+	Runs      []BenchRun     `json:"runs"`
+	Summary   []BenchSummary `json:"summary"`
+}
+
+// LoadBenchSuite reads a suite file. Relative working directories are
+// resolved against the suite file's directory.
+func LoadBenchSuite(path string) (*BenchSuite, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read suite: %w", err)
+	}
+	var suite BenchSuite
+	if err := json.Unmarshal(data, &suite); err != nil {
+		return nil, fmt.Errorf("failed to parse suite %s: %w", path, err)
+	}
+	base := filepath.Dir(path)
+	resolve := func(dirs []string) {
+		for i, dir := range dirs {
+			if !filepath.IsAbs(dir) {
+				dirs[i] = filepath.Join(base, dir)
+			}
+		}
+	}
+	resolve(suite.Workdirs)
+	for i := range suite.Cases {
+		resolve(suite.Cases[i].Workdirs)
+	}
+	if err := suite.Validate(); err != nil {
+		return nil, err
+	}
+	return &suite, nil
+}
+
+// Validate checks that every case has an intent, working directories, and
+// at least one expected file, and assigns IDs to cases without one.
+func (s *BenchSuite) Validate() error {
+	if len(s.Cases) == 0 {
+		return fmt.Errorf("suite has no cases")
+	}
+	seen := make(map[string]bool)
+	for i := range s.Cases {
+		c := &s.Cases[i]
+		if c.ID == "" {
+			c.ID = fmt.Sprintf("case-%d", i+1)
+		}
+		if seen[c.ID] {
+			return fmt.Errorf("duplicate case id %q", c.ID)
+		}
+		seen[c.ID] = true
+		if strings.TrimSpace(c.Intent) == "" {
+			return fmt.Errorf("case %q has no intent", c.ID)
+		}
+		if len(c.Workdirs) == 0 && len(s.Workdirs) == 0 {
+			return fmt.Errorf("case %q has no working directories", c.ID)
+		}
+		if len(c.Expected) == 0 {
+			return fmt.Errorf("case %q has no expected files", c.ID)
+		}
+	}
+	return nil
+}
+
+// LearnBenchSuite builds a suite from past sessions: each session with a
+// completed change turn becomes a case whose intent is the session intent
+// and whose expected files are the files the change turns modified plus the
+// discovery gaps they reported. With no sessionIDs every readable session
+// under gscHome is considered; benchmark sessions are always skipped.
+func LearnBenchSuite(gscHome string, sessionIDs []string) (*BenchSuite, error) {
+	explicit := len(sessionIDs) > 0
+	if !explicit {
+		all, err := intent_workflow.ListSessions(gscHome)
+		if err != nil {
+			return nil, err
+		}
+		sessionIDs = all
+	}
+
+	suite := &BenchSuite{Name: "learned"}
+	for _, sessionID := range sessionIDs {
+		if strings.HasPrefix(sessionID, BenchSessionPrefix) {
+			continue
+		}
+		manager, err := intent_workflow.LoadSession(sessionID)
+		if err != nil {
+			if !explicit {
+				continue
+			}
+			return nil, fmt.Errorf("failed to load session %s: %w", sessionID, err)
+		}
+		if c, ok := learnCase(manager.GetSession()); ok {
+			suite.Cases = append(suite.Cases, c)
+		}
+	}
+	if len(suite.Cases) == 0 {
+		return nil, fmt.Errorf("no sessions with completed change turns to learn from")
+	}
+	return suite, nil
+}
+
+// learnCase derives a case from a session's change results.
+func learnCase(session *intent_workflow.Session) (BenchCase, bool) {
+	c := BenchCase{ID: session.SessionID, Intent: session.Intent, Source: session.SessionID}
+	for _, wd := range session.WorkingDirectories {
+		c.Workdirs = append(c.Workdirs, wd.Path)
+	}
+
+	seen := make(map[string]bool)
+	add := func(workingDir, path string) {
+		name := workdirName(session, workingDir)
+		if name == "" || path == "" {
+			return
+		}
+		file := BenchFile{Workdir: name, Path: filepath.ToSlash(filepath.Clean(path))}
+		if !seen[file.key()] {
+			seen[file.key()] = true
+			c.Expected = append(c.Expected, file)
+		}
+	}
+	for _, turn := range session.Turns {
+		if turn.TurnType != "change" || turn.Status != "complete" || turn.Result == nil || turn.Result.Change == nil {
+			continue
+		}
+		change := turn.Result.Change
+		for _, f := range change.FilesModified.Files {
+			if f.Status != "deleted" {
+				add(f.WorkingDir, f.Path)
+			}
+		}
+		for _, f := range change.DiscoveryGap.Files {
+			add(f.WorkingDir, f.Path)
+		}
+	}
+	sort.Slice(c.Expected, func(i, j int) bool { return c.Expected[i].key() < c.Expected[j].key() })
+	return c, len(c.Expected) > 0
+}
+
+// workdirName maps the working directory reported by a change turn, an
+// absolute path or a name, to the session's working directory name.
+// Isolated change turns report their worktree, which maps back through the
+// isolated change record.
+func workdirName(session *intent_workflow.Session, workingDir string) string {
+	clean := filepath.Clean(workingDir)
+	for _, wd := range session.WorkingDirectories {
+		if filepath.Clean(wd.Path) == clean || wd.Name == workingDir {
+			return wd.Name
+		}
+	}
+	for _, isolated := range session.IsolatedChanges {
+		for _, wt := range isolated.Worktrees {
+			if filepath.Clean(wt.WorktreePath) != clean {
+				continue
+			}
+			for _, wd := range isolated.OriginalWorkdirs {
+				if rel, err := filepath.Rel(wt.RepoRoot, wd.Path); err == nil && !strings.HasPrefix(rel, "..") {
+					return wd.Name
+				}
+			}
+		}
+	}
+	if len(session.WorkingDirectories) == 1 {
+		return session.WorkingDirectories[0].Name
+	}
+	return ""
+}
+
+// RunBench runs a discovery turn for every case under every configuration
+// and scores the candidates against the expected files. Each run is a
+// regular session, kept for inspection with 'gsc claude scout status'.
+func RunBench(suite *BenchSuite, opts BenchOptions) (*BenchReport, error) {
+	if err := suite.Validate(); err != nil {
+		return nil, err
+	}
+	configs := opts.Configs
+	if len(configs) == 0 {
+		configs = []string{BenchConfigExperts, BenchConfigGeneric}
+	}
+	for _, config := range configs {
+		if config != BenchConfigExperts && config != BenchConfigGeneric {
+			return nil, fmt.Errorf("unknown bench configuration %q (must be %s or %s)", config, BenchConfigExperts, BenchConfigGeneric)
+		}
+	}
+
+	report := &BenchReport{Suite: suite.Name, StartedAt: time.Now().UTC()}
+	stamp := report.StartedAt.Format("20060102-150405")
+	for _, c := range suite.Cases {
+		workdirs := c.Workdirs
+		if len(workdirs) == 0 {
+			workdirs = suite.Workdirs
+		}
+		for _, config := range configs {
+			sessionID := fmt.Sprintf("%s%s-%s-%s", BenchSessionPrefix, stamp, sessionSafe(c.ID), config)
+			run := runBenchCase(c, workdirs, config, sessionID, opts)
+			report.Runs = append(report.Runs, run)
+			if opts.OnRun != nil {
+				opts.OnRun(run)
+			}
+		}
+	}
+	report.Summary = summarizeBench(report.Runs, configs)
+	return report, nil
+}
+
+var unsafeSessionChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// sessionSafe turns a case ID into a session ID fragment, short enough that
+// the full bench session ID stays within the 64 character session ID limit.
+func sessionSafe(id string) string {
+	safe := strings.Trim(unsafeSessionChars.ReplaceAllString(strings.ToLower(id), "-"), "-")
+	if len(safe) > 32 {
+		safe = strings.TrimRight(safe[:32], "-")
+	}
+	return safe
+}
+
+// runBenchCase runs one discovery session and scores it.
+func runBenchCase(c BenchCase, workdirPaths []string, config, sessionID string, opts BenchOptions) BenchRun {
+	run := BenchRun{Case: c.ID, Config: config, SessionID: sessionID, Expected: len(c.Expected)}
+
+	workdirs := make([]intent_workflow.WorkingDirectory, len(workdirPaths))
+	for i, path := range workdirPaths {
+		workdirs[i] = intent_workflow.WorkingDirectory{ID: i + 1, Name: filepath.Base(path), Path: path}
+	}
+
+	manager, err := intent_workflow.NewManager(sessionID)
+	if err != nil {
+		run.Error = err.Error()
+		return run
+	}
+	if err := manager.InitializeSession(c.Intent, workdirs, nil, false, opts.Model, config == BenchConfigGeneric); err != nil {
+		run.Error = err.Error()
+		return run
+	}
+	if err := manager.SetBudget(opts.Budget); err != nil {
+		run.Error = err.Error()
+		return run
+	}
+
+	started := time.Now()
+	turnErr := manager.StartDiscoveryTurn()
+	run.LatencyMs = time.Since(started).Milliseconds()
+
+	session := manager.GetSession()
+	if len(session.Turns) == 0 {
+		run.Error = fmt.Sprintf("discovery did not start: %v", turnErr)
+		return run
+	}
+	turn := session.Turns[len(session.Turns)-1]
+	if turn.Cost != nil {
+		run.Cost += *turn.Cost
+	}
+	if turn.CorrectionCost != nil {
+		run.Cost += *turn.CorrectionCost
+	}
+	if turn.Result == nil || turn.Result.Discovery == nil {
+		switch {
+		case turnErr != nil:
+			run.Error = turnErr.Error()
+		case turn.Error != nil:
+			run.Error = *turn.Error
+		default:
+			run.Error = fmt.Sprintf("discovery ended with status %s and no result", turn.Status)
+		}
+		run.Missed = c.Expected
+		return run
+	}
+
+	discovery := turn.Result.Discovery
+	run.DiscoveryMode = discovery.DiscoveryMode
+	scoreBenchRun(&run, c.Expected, discovery.Candidates)
+	return run
+}
+
+// scoreBenchRun fills in recall and precision. Candidates are deduplicated
+// so a file returned twice is not counted twice.
+func scoreBenchRun(run *BenchRun, expected []BenchFile, candidates []intent_workflow.Candidate) {
+	returned := make(map[string]bool)
+	for _, candidate := range candidates {
+		returned[BenchFile{Workdir: candidate.WorkdirName, Path: candidate.FilePath}.key()] = true
+	}
+	run.Returned = len(returned)
+	run.Missed = nil
+	for _, file := range expected {
+		if returned[file.key()] {
+			run.Matched++
+		} else {
+			run.Missed = append(run.Missed, file)
+		}
+	}
+	if len(expected) > 0 {
+		run.Recall = float64(run.Matched) / float64(len(expected))
+	}
+	if run.Returned > 0 {
+		run.Precision = float64(run.Matched) / float64(run.Returned)
+	}
+}
+
+// summarizeBench aggregates runs per configuration, in configuration order.
+func summarizeBench(runs []BenchRun, configs []string) []BenchSummary {
+	summaries := make([]BenchSummary, 0, len(configs))
+	for _, config := range configs {
+		summary := BenchSummary{Config: config}
+		var scored int
+		var latency int64
+		for _, run := range runs {
+			if run.Config != config {
+				continue
+			}
+			summary.Cases++
+			summary.TotalCost += run.Cost
+			if run.Error != "" {
+				summary.Errors++
+				continue
+			}
+			scored++
+			summary.Recall += run.Recall
+			summary.Precision += run.Precision
+			latency += run.LatencyMs
+		}
+		if scored > 0 {
+			summary.Recall /= float64(scored)
+			summary.Precision /= float64(scored)
+			summary.MeanLatencyMs = latency / int64(scored)
+		}
+		if summary.Cases > 0 {
+			summary.MeanCost = summary.TotalCost / float64(summary.Cases)
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries
+}
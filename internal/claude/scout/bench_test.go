@@ -0,0 +1,147 @@
+/**
+ * Component: Scout Discovery Benchmark Tests
+ * Block-UUID: 9b64e0d2-1c3f-4a7e-8d59-2f0a7c4e6b13
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests that bench runs score replayed discovery candidates per configuration and that suites are learned from change results and discovery gaps.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package scout
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gitsense/gsc-cli/internal/claude/backend"
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+)
+
+// setupBench points GSC_HOME at a fresh directory with the agent templates
+// and replays discovery from recordings.
+func setupBench(t *testing.T, recordings string) {
+	t.Helper()
+	gscHome := t.TempDir()
+	t.Setenv("GSC_HOME", gscHome)
+	t.Setenv(backend.EnvBackend, "scripted:"+recordings)
+
+	src := filepath.Join("..", "..", "..", "pkg", "settings", "templates", "claude")
+	dst := filepath.Join(gscHome, "cli", "templates", "claude")
+	err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(filepath.Join(dst, rel), data, 0644)
+	})
+	if err != nil {
+		t.Fatalf("copy templates: %v", err)
+	}
+}
+
+func TestRunBenchScoresEachConfiguration(t *testing.T) {
+	recordings := t.TempDir()
+	workdir := filepath.Join(t.TempDir(), "app")
+	if err := os.MkdirAll(workdir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	text := `{"candidates":[` +
+		`{"workdir_id":1,"workdir_name":"app","file_path":"main.go","score":0.9,"reasoning":"entry point"},` +
+		`{"workdir_id":1,"workdir_name":"app","file_path":"util.go","score":0.6,"reasoning":"helpers"}` +
+		`],"total_found":2,"coverage":"full","discovery_mode":"generic"}`
+	result, _ := json.Marshal(map[string]interface{}{"type": "result", "result": text, "total_cost_usd": 0.2, "duration_ms": 1000})
+	assistant, _ := json.Marshal(map[string]interface{}{
+		"type":    "assistant",
+		"message": map[string]interface{}{"content": []map[string]string{{"type": "text", "text": text}}},
+	})
+	if err := os.WriteFile(filepath.Join(recordings, "discovery.ndjson"), []byte(string(assistant)+"\n"+string(result)+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	setupBench(t, recordings)
+
+	suite := &BenchSuite{
+		Workdirs: []string{workdir},
+		Cases: []BenchCase{{
+			Intent:   "where does the app start",
+			Expected: []BenchFile{{Workdir: "app", Path: "main.go"}, {Workdir: "app", Path: "./config.go"}},
+		}},
+	}
+	var progress []string
+	report, err := RunBench(suite, BenchOptions{OnRun: func(run BenchRun) { progress = append(progress, run.Config) }})
+	if err != nil {
+		t.Fatalf("run bench: %v", err)
+	}
+	if strings.Join(progress, ",") != "experts,generic" || len(report.Runs) != 2 {
+		t.Fatalf("runs = %v", progress)
+	}
+
+	for _, run := range report.Runs {
+		if run.Error != "" {
+			t.Fatalf("%s: %s", run.Config, run.Error)
+		}
+		if run.Matched != 1 || run.Recall != 0.5 || run.Precision != 0.5 || run.Cost != 0.2 {
+			t.Fatalf("%s run = %+v", run.Config, run)
+		}
+		if len(run.Missed) != 1 || run.Missed[0].Path != "./config.go" {
+			t.Fatalf("missed = %+v", run.Missed)
+		}
+		if !strings.HasPrefix(run.SessionID, BenchSessionPrefix) || !strings.HasSuffix(run.SessionID, "-case-1-"+run.Config) {
+			t.Fatalf("session id = %s", run.SessionID)
+		}
+	}
+	if summary := report.Summary[1]; summary.Config != BenchConfigGeneric || summary.Cases != 1 || summary.Recall != 0.5 || summary.TotalCost != 0.2 {
+		t.Fatalf("summary = %+v", summary)
+	}
+}
+
+func TestLearnCaseFromChangeResults(t *testing.T) {
+	session := &intent_workflow.Session{
+		SessionID: "abc123",
+		Intent:    "add request logging",
+		WorkingDirectories: []intent_workflow.WorkingDirectory{
+			{ID: 1, Name: "api", Path: "/src/api"},
+			{ID: 2, Name: "web", Path: "/src/web"},
+		},
+		Turns: []intent_workflow.TurnState{
+			{TurnNumber: 1, TurnType: "discovery", Status: "complete"},
+			{TurnNumber: 2, TurnType: "change", Status: "complete", Result: &intent_workflow.TurnResult{Change: &intent_workflow.ChangeResult{
+				FilesModified: intent_workflow.FilesModifiedSummary{Files: []intent_workflow.FileModified{
+					{WorkingDir: "/src/api", Path: "server.go", Status: "modified"},
+					{WorkingDir: "/src/api", Path: "old.go", Status: "deleted"},
+				}},
+				DiscoveryGap: intent_workflow.DiscoveryGap{Files: []intent_workflow.DiscoveryGapEntry{
+					{WorkingDir: "/src/web", Path: "log/client.ts"},
+					{WorkingDir: "/src/api", Path: "server.go"},
+				}},
+			}}},
+		},
+	}
+
+	c, ok := learnCase(session)
+	if !ok || c.Intent != "add request logging" || c.Source != "abc123" {
+		t.Fatalf("case = %+v", c)
+	}
+	want := []BenchFile{{Workdir: "api", Path: "server.go"}, {Workdir: "web", Path: "log/client.ts"}}
+	if len(c.Expected) != len(want) || c.Expected[0] != want[0] || c.Expected[1] != want[1] {
+		t.Fatalf("expected = %+v", c.Expected)
+	}
+
+	// A session without a completed change turn has nothing to learn
+	session.Turns[1].Status = "error"
+	if _, ok := learnCase(session); ok {
+		t.Fatal("learned from a failed change turn")
+	}
+}
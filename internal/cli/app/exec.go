@@ -1,12 +1,12 @@
 /**
  * Component: Exec CLI Command
- * Block-UUID: f9f1fccc-b59d-48d4-8652-88a149bbe64d
- * Parent-UUID: ec7c2ef2-1dc8-4ec9-986f-e21e22ae9c7d
- * Version: 2.1.0
- * Description: Updated to retrieve global flags (bridgeCode, forceInsert) from the command context instead of package-level variables to resolve scope issues after moving to the 'app' package.
+ * Block-UUID: 1d8e8315-d1cf-4e53-93ea-42321ed15670
+ * Parent-UUID: 72184a3e-40c8-410c-a993-17db1f351a26
+ * Version: 2.4.0
+ * Description: The help lists the history subcommand for filtering, searching, diffing, and pruning saved outputs.
  * Language: Go
  * Created-at: 2026-03-06T02:07:11.078Z
- * Authors: Gemini 3 Flash (v1.0.0), Gemini 3 Flash (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.2.1), GLM-4.7 (v2.0.0), GLM-4.7 (v2.1.0)
+ * Authors: Gemini 3 Flash (v1.0.0), Gemini 3 Flash (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.2.1), GLM-4.7 (v2.0.0), GLM-4.7 (v2.1.0), agent (v2.2.0), agent (v2.3.0), agent (v2.4.0)
  */
 
 
@@ -46,7 +46,8 @@ Modes:
   gsc app exec --list                       List saved outputs
   gsc app exec --send <id> --code 123456    Resend a saved output
   gsc app exec --delete <id>                Delete a specific output
-  gsc app exec --clear                      Delete all saved outputs`,
+  gsc app exec --clear                      Delete all saved outputs
+  gsc app exec history                      Filter, search, diff, and prune saved outputs`,
 	Args:  cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// 1. Handle Management Flags
@@ -159,7 +160,8 @@ func handleSend(cmd *cobra.Command, id string) error {
 	// 4. Send to Bridge
 	// Note: We use the saved output directly. The duration is 0 for resend.
 	forceInsert, _ := cmd.Flags().GetBool("force")
-	err = bridge.Execute(bridgeCode, result.Output, "text", cmdStr, 0, "N/A", 0, forceInsert)
+	oversizeMode, _ := cmd.Flags().GetString("oversize")
+	err = bridge.Execute(bridgeCode, result.Output, "text", cmdStr, 0, "N/A", 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 	
 	// 5. Handle Result
 	if err != nil {
@@ -241,8 +243,9 @@ func handleExecution(cmd *cobra.Command, commandStr string) error {
 	// 5. Send to Bridge
 	cmdStr := fmt.Sprintf("gsc app exec \"%s\" --code %s", commandStr, bridgeCode)
 	forceInsert, _ := cmd.Flags().GetBool("force")
+	oversizeMode, _ := cmd.Flags().GetString("oversize")
 	
-	err = bridge.Execute(bridgeCode, finalOutput, "text", cmdStr, result.Duration, "N/A", result.ExitCode, forceInsert)
+	err = bridge.Execute(bridgeCode, finalOutput, "text", cmdStr, result.Duration, "N/A", result.ExitCode, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 
 	// 6. Handle Bridge Result
 	if err != nil {
@@ -258,6 +261,9 @@ func handleExecution(cmd *cobra.Command, commandStr string) error {
 		// We keep the file for recovery as well, just in case.
 		fmt.Fprintf(os.Stderr, "\n[EXEC] ⚠️  Failed to send to chat. Output saved as ID: %s\n", result.ID)
 		fmt.Fprintf(os.Stderr, "[EXEC] Error: %v\n", err)
+		if bridgeErr, ok := err.(*bridge.BridgeError); ok && bridgeErr.ExitCode == 4 {
+			fmt.Fprintf(os.Stderr, "[EXEC] To send it in a smaller form, use: gsc app exec --send %s --code <new-code> --oversize chunk|summary|attach\n", result.ID)
+		}
 		return nil
 	}
 
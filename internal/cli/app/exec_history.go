@@ -0,0 +1,389 @@
+/**
+ * Component: Exec History CLI Commands
+ * Block-UUID: bf7ae3b9-2621-4463-8d63-47ac28c7e8ec
+ * Parent-UUID: 9d3a6f51-2c84-4e17-b0a9-e6f25c8d4713
+ * Version: 1.1.0
+ * Description: 'gsc app exec history' lists saved outputs with command, exit code, and date filters, searches their text, diffs two runs, prunes by age and size, and manages the retention policy applied after every run.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/exec"
+	"github.com/gitsense/gsc-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyCommand  string
+	historyExitCode int
+	historyFailed   bool
+	historySince    string
+	historyUntil    string
+	historyLimit    int
+	historyFormat   string
+
+	historySearchIgnoreCase bool
+	historySearchFixed      bool
+	historySearchMaxLines   int
+
+	historyDiffIgnoreTiming bool
+	historyDiffContext      int
+
+	historyPruneOlderThan string
+	historyPruneMaxSize   string
+	historyPruneDryRun    bool
+
+	historyRetentionMaxAge  string
+	historyRetentionMaxSize string
+	historyRetentionClear   bool
+)
+
+// historyCmd handles 'gsc app exec history'
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List, search, and compare saved exec outputs",
+	Long: `Lists saved outputs, newest first. Filter by command (a regular expression),
+exit code, and date; --since and --until take a date (2006-01-02), an RFC3339
+time, or an age such as 7d. A date given to --until includes that whole day.
+
+Subcommands search the saved output text, diff two runs, and apply age- and
+size-based retention.`,
+	Example: `  # Failed runs of go test in the last two days
+  gsc app exec history --command '^go test' --failed --since 2d
+
+  # Where did "connection refused" show up this week?
+  gsc app exec history search "connection refused" --since 7d
+
+  # What changed between two runs of the same build?
+  gsc app exec history diff 20261018-101500-a1b2c3 20261018-113000-d4e5f6 --ignore-timing`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter, err := historyFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		entries, err := exec.History(filter)
+		if err != nil {
+			return fmt.Errorf("failed to read exec history: %w", err)
+		}
+
+		if historyFormat == "json" {
+			output.FormatJSON(entries)
+			return nil
+		}
+		if len(entries) == 0 {
+			fmt.Println("No saved outputs match.")
+			return nil
+		}
+		rows := make([][]string, len(entries))
+		for i, e := range entries {
+			rows[i] = []string{
+				e.ID,
+				truncateCommand(e.Command, 40),
+				fmt.Sprintf("%d", e.ExitCode),
+				e.Timestamp.Local().Format("2006-01-02 15:04:05"),
+				e.Duration + "s",
+				formatExecBytes(e.Size),
+			}
+		}
+		fmt.Println(output.FormatTable([]string{"ID", "Command", "Exit Code", "Started", "Duration", "Size"}, rows))
+		return nil
+	},
+}
+
+// historySearchCmd handles 'gsc app exec history search'
+var historySearchCmd = &cobra.Command{
+	Use:   "search <pattern>",
+	Short: "Search the text of saved outputs",
+	Long: `Searches saved output logs for lines matching a regular expression (or a
+fixed string with --fixed-strings). The history filters narrow which outputs
+are searched.`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		pattern := args[0]
+		if historySearchFixed {
+			pattern = regexp.QuoteMeta(pattern)
+		}
+		if historySearchIgnoreCase {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+
+		filter, err := historyFilterFromFlags(cmd)
+		if err != nil {
+			return err
+		}
+		entries, err := exec.History(filter)
+		if err != nil {
+			return fmt.Errorf("failed to read exec history: %w", err)
+		}
+		hits, err := exec.SearchOutputs(entries, re, historySearchMaxLines)
+		if err != nil {
+			return err
+		}
+
+		if historyFormat == "json" {
+			output.FormatJSON(hits)
+			return nil
+		}
+		if len(hits) == 0 {
+			fmt.Printf("No matches in %d saved output(s).\n", len(entries))
+			return nil
+		}
+		for _, hit := range hits {
+			fmt.Printf("%s  exit %d  %s  %s\n", hit.ID, hit.ExitCode, hit.Timestamp.Local().Format("2006-01-02 15:04"), hit.Command)
+			for _, m := range hit.Matches {
+				fmt.Printf("  %6d: %s\n", m.Line, m.Text)
+			}
+			if more := hit.MatchCount - len(hit.Matches); more > 0 {
+				fmt.Printf("  ... %d more match(es)\n", more)
+			}
+			fmt.Println()
+		}
+		fmt.Printf("%d of %d saved output(s) match.\n", len(hits), len(entries))
+		return nil
+	},
+}
+
+// historyDiffCmd handles 'gsc app exec history diff'
+var historyDiffCmd = &cobra.Command{
+	Use:   "diff <old-id> <new-id>",
+	Short: "Diff the output of two saved runs",
+	Long: `Shows a unified diff from the first saved output to the second. Use
+--ignore-timing to mask timestamps and durations (e.g. "ok pkg 0.12s") so
+reruns of the same command only differ where their results do.`,
+	Args:         cobra.ExactArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		diff, err := exec.DiffOutputs(args[0], args[1], historyDiffIgnoreTiming, historyDiffContext)
+		if err != nil {
+			return err
+		}
+
+		if historyFormat == "json" {
+			output.FormatJSON(diff)
+			return nil
+		}
+		fmt.Printf("old: %s  exit %d  %s\n", diff.Old.ID, diff.Old.ExitCode, diff.Old.Command)
+		fmt.Printf("new: %s  exit %d  %s\n", diff.New.ID, diff.New.ExitCode, diff.New.Command)
+		if diff.Old.Command != diff.New.Command {
+			fmt.Println("Note: the two outputs are from different commands.")
+		}
+		fmt.Println()
+		if diff.Unified == "" {
+			fmt.Println("Outputs are identical.")
+			return nil
+		}
+		fmt.Print(diff.Unified)
+		fmt.Printf("\n%d line(s) added, %d removed\n", diff.Added, diff.Removed)
+		return nil
+	},
+}
+
+// historyPruneCmd handles 'gsc app exec history prune'
+var historyPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Delete saved outputs by age or total size",
+	Long: `Deletes saved outputs older than --older-than, then the oldest outputs until
+the rest fit in --max-size. Without flags, the saved retention policy is used
+(see 'gsc app exec history retention').`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		policy := exec.RetentionPolicy{MaxAge: historyPruneOlderThan, MaxSize: historyPruneMaxSize}
+		if policy.IsZero() {
+			saved, err := exec.LoadRetentionPolicy()
+			if err != nil {
+				return err
+			}
+			if saved.IsZero() {
+				return fmt.Errorf("no limits: pass --older-than or --max-size, or save a policy with 'gsc app exec history retention'")
+			}
+			policy = saved
+		}
+
+		result, err := exec.Prune(policy, "", historyPruneDryRun, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if historyFormat == "json" {
+			output.FormatJSON(result)
+			return nil
+		}
+		verb := "Deleted"
+		if result.DryRun {
+			verb = "Would delete"
+		}
+		for _, e := range result.Removed {
+			fmt.Printf("  %s  %s  %-10s %s\n", e.ID, e.Timestamp.Local().Format("2006-01-02 15:04"), formatExecBytes(e.Size), truncateCommand(e.Command, 50))
+		}
+		fmt.Printf("%s %d output(s) (%s); %d kept (%s)\n", verb, len(result.Removed), formatExecBytes(result.RemovedBytes), result.Kept, formatExecBytes(result.KeptBytes))
+		return nil
+	},
+}
+
+// historyRetentionCmd handles 'gsc app exec history retention'
+var historyRetentionCmd = &cobra.Command{
+	Use:   "retention",
+	Short: "Show or set the retention policy applied after every run",
+	Long: `Without flags, shows the saved retention policy. With --max-age or --max-size,
+saves a policy that is applied after every 'gsc app exec' run (the new output is
+always kept). --clear removes the policy.`,
+	Example: `  gsc app exec history retention --max-age 30d --max-size 500MB
+  gsc app exec history retention --clear`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if historyRetentionClear {
+			if err := exec.SaveRetentionPolicy(exec.RetentionPolicy{}); err != nil {
+				return err
+			}
+			fmt.Println("Retention policy cleared. Saved outputs are kept until deleted.")
+			return nil
+		}
+
+		if cmd.Flags().Changed("max-age") || cmd.Flags().Changed("max-size") {
+			policy, err := exec.LoadRetentionPolicy()
+			if err != nil {
+				return err
+			}
+			if cmd.Flags().Changed("max-age") {
+				policy.MaxAge = historyRetentionMaxAge
+			}
+			if cmd.Flags().Changed("max-size") {
+				policy.MaxSize = historyRetentionMaxSize
+			}
+			if err := exec.SaveRetentionPolicy(policy); err != nil {
+				return err
+			}
+		}
+
+		policy, err := exec.LoadRetentionPolicy()
+		if err != nil {
+			return err
+		}
+		if historyFormat == "json" {
+			output.FormatJSON(policy)
+			return nil
+		}
+		if policy.IsZero() {
+			fmt.Println("No retention policy. Saved outputs are kept until deleted.")
+			return nil
+		}
+		fmt.Printf("Max age:  %s\n", valueOrNone(policy.MaxAge))
+		fmt.Printf("Max size: %s\n", valueOrNone(policy.MaxSize))
+		return nil
+	},
+}
+
+// historyFilterFromFlags builds the history filter from the shared flags.
+func historyFilterFromFlags(cmd *cobra.Command) (exec.HistoryFilter, error) {
+	filter := exec.HistoryFilter{Failed: historyFailed, Limit: historyLimit}
+	if historyCommand != "" {
+		re, err := regexp.Compile(historyCommand)
+		if err != nil {
+			return filter, fmt.Errorf("invalid --command pattern: %w", err)
+		}
+		filter.Command = re
+	}
+	if cmd.Flags().Changed("exit-code") {
+		code := historyExitCode
+		filter.ExitCode = &code
+	}
+	now := time.Now()
+	if historySince != "" {
+		t, err := exec.ParseTimeBound(historySince, now, false)
+		if err != nil {
+			return filter, fmt.Errorf("--since: %w", err)
+		}
+		filter.Since = t
+	}
+	if historyUntil != "" {
+		t, err := exec.ParseTimeBound(historyUntil, now, true)
+		if err != nil {
+			return filter, fmt.Errorf("--until: %w", err)
+		}
+		filter.Until = t
+	}
+	return filter, nil
+}
+
+// truncateCommand shortens a command to one line of at most max characters.
+func truncateCommand(command string, max int) string {
+	command = strings.Join(strings.Fields(command), " ")
+	if len(command) <= max {
+		return command
+	}
+	return command[:max-3] + "..."
+}
+
+// valueOrNone shows an unset policy limit.
+func valueOrNone(value string) string {
+	if value == "" {
+		return "none"
+	}
+	return value
+}
+
+// formatExecBytes converts bytes to human-readable format
+func formatExecBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+func init() {
+	// Filters shared by history and its search subcommand
+	historyCmd.PersistentFlags().StringVar(&historyFormat, "format", "table", "Output format: table or json")
+	for _, c := range []*cobra.Command{historyCmd, historySearchCmd} {
+		c.Flags().StringVar(&historyCommand, "command", "", "Only outputs whose command matches this regular expression")
+		c.Flags().IntVar(&historyExitCode, "exit-code", 0, "Only outputs with this exit code")
+		c.Flags().BoolVar(&historyFailed, "failed", false, "Only outputs with a non-zero exit code")
+		c.Flags().StringVar(&historySince, "since", "", "Only outputs started at or after this date, time, or age (7d)")
+		c.Flags().StringVar(&historyUntil, "until", "", "Only outputs started at or before this date, time, or age")
+		c.Flags().IntVarP(&historyLimit, "limit", "n", 0, "Only the newest N matching outputs")
+	}
+
+	historySearchCmd.Flags().BoolVarP(&historySearchIgnoreCase, "ignore-case", "i", false, "Case-insensitive match")
+	historySearchCmd.Flags().BoolVarP(&historySearchFixed, "fixed-strings", "F", false, "Treat the pattern as a literal string")
+	historySearchCmd.Flags().IntVar(&historySearchMaxLines, "max-lines", 5, "Matching lines shown per output (0 for all)")
+
+	historyDiffCmd.Flags().BoolVar(&historyDiffIgnoreTiming, "ignore-timing", false, "Mask timestamps and durations before diffing")
+	historyDiffCmd.Flags().IntVarP(&historyDiffContext, "context", "U", 3, "Lines of context around each change")
+
+	historyPruneCmd.Flags().StringVar(&historyPruneOlderThan, "older-than", "", "Delete outputs older than this (30d, 2w, 36h)")
+	historyPruneCmd.Flags().StringVar(&historyPruneMaxSize, "max-size", "", "Delete the oldest outputs until the rest fit (500MB, 2G)")
+	historyPruneCmd.Flags().BoolVar(&historyPruneDryRun, "dry-run", false, "Show what would be deleted without deleting")
+
+	historyRetentionCmd.Flags().StringVar(&historyRetentionMaxAge, "max-age", "", "Delete outputs older than this after every run (empty to unset)")
+	historyRetentionCmd.Flags().StringVar(&historyRetentionMaxSize, "max-size", "", "Keep saved outputs under this total size (empty to unset)")
+	historyRetentionCmd.Flags().BoolVar(&historyRetentionClear, "clear", false, "Remove the retention policy")
+
+	historyCmd.AddCommand(historySearchCmd)
+	historyCmd.AddCommand(historyDiffCmd)
+	historyCmd.AddCommand(historyPruneCmd)
+	historyCmd.AddCommand(historyRetentionCmd)
+	execCmd.AddCommand(historyCmd)
+}
@@ -0,0 +1,123 @@
+/**
+ * Component: Formatters CLI Command
+ * Block-UUID: 6c17e9a3-4f28-4b50-8d96-a2e5f03b7c18
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: 'gsc app formatters' lists the built-in and external bridge output formatters and tests a formatter against a saved exec output.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gitsense/gsc-cli/internal/bridge/formatters"
+	"github.com/gitsense/gsc-cli/internal/exec"
+	"github.com/gitsense/gsc-cli/internal/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	formattersListFormat string
+	formattersTestName   string
+)
+
+// formattersCmd groups the formatter subcommands
+var formattersCmd = &cobra.Command{
+	Use:   "formatters",
+	Short: "List and test bridge output formatters",
+	Long: `Formatters turn the output of well-known commands into readable Markdown
+before it is sent to GitSense Chat. Built-in formatters cover cat, git diff/show,
+go test, gsc grep, and rg. An executable at $GSC_HOME/formatters/<command>
+overrides the built-in formatter for that command.`,
+}
+
+// formattersListCmd handles 'gsc app formatters list'
+var formattersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available formatters",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		infos := formatters.ListFormatters()
+		if formattersListFormat == "json" {
+			output.FormatJSON(infos)
+			return nil
+		}
+
+		rows := make([][]string, len(infos))
+		for i, info := range infos {
+			rows[i] = []string{info.Command, info.Source, info.Description}
+		}
+		fmt.Println(output.FormatTable([]string{"Command", "Source", "Description"}, rows))
+		return nil
+	},
+}
+
+// formattersTestCmd handles 'gsc app formatters test'
+var formattersTestCmd = &cobra.Command{
+	Use:   "test <exec-id>",
+	Short: "Run a formatter against a saved exec output",
+	Long: `Runs the formatter for a saved output's command (see 'gsc app exec --list')
+and prints the Markdown that would be sent to the chat. Use --formatter to try a
+different formatter on the same output.
+
+Formatters that rewrite the command, such as go test adding -json, only apply
+to outputs saved from the rewritten command.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+
+		result, err := exec.GetOutput(args[0])
+		if err != nil {
+			return err
+		}
+		fields := strings.Fields(result.Command)
+		if len(fields) == 0 {
+			return fmt.Errorf("output %s has no recorded command", args[0])
+		}
+
+		name := fields[0]
+		if formattersTestName != "" {
+			name = formattersTestName
+		}
+		formatter := formatters.ResolveFormatter(name)
+		if formatter == nil {
+			return fmt.Errorf("no formatter for '%s' (see 'gsc app formatters list')", name)
+		}
+		if ca, ok := formatter.(formatters.ContextAware); ok {
+			ca.SetContext(formatters.FormatterContext{Command: fields[0], Args: fields[1:], WorkDir: result.Workdir})
+		}
+
+		rewritten := formatter.PreProcess(fields[1:])
+		if want := strings.Join(append([]string{fields[0]}, rewritten...), " "); want != strings.Join(fields, " ") {
+			fmt.Printf("Note: in a contract exec the formatter would run: %s\n\n", want)
+		}
+
+		formatted, err := formatter.PostProcess(result.Output)
+		if errors.Is(err, formatters.ErrNotApplicable) {
+			return fmt.Errorf("formatter '%s' does not apply to '%s'; the raw output would be sent", name, result.Command)
+		}
+		if err != nil {
+			return fmt.Errorf("formatter '%s' failed: %w", name, err)
+		}
+		fmt.Print(formatted)
+		return nil
+	},
+}
+
+func init() {
+	formattersListCmd.Flags().StringVar(&formattersListFormat, "format", "table", "Output format: table or json")
+	formattersTestCmd.Flags().StringVar(&formattersTestName, "formatter", "", "Formatter to use instead of the one for the saved command")
+
+	formattersCmd.AddCommand(formattersListCmd)
+	formattersCmd.AddCommand(formattersTestCmd)
+}
+
+// RegisterFormattersCommand adds the formatters command to the parent command.
+func RegisterFormattersCommand(parentCmd *cobra.Command) {
+	parentCmd.AddCommand(formattersCmd)
+}
@@ -1,12 +1,12 @@
 /*
  * Component: Unified App CLI Root
- * Block-UUID: 9a05bc6a-9922-498d-99a3-a7119cbefc10
- * Parent-UUID: 05bf2c4c-e94d-4f70-8980-eb91d1aa0d87
- * Version: 1.5.0
- * Description: Registered 'contract', 'ws', and 'exec' commands as subcommands of 'app' to consolidate application-specific interfaces.
+ * Block-UUID: 107b8e37-9f1c-4067-950e-eda2b517af9c
+ * Parent-UUID: 9a05bc6a-9922-498d-99a3-a7119cbefc10
+ * Version: 1.6.0
+ * Description: Registered the 'formatters' command for listing and testing bridge output formatters.
  * Language: Go
  * Created-at: 2026-05-13T18:54:00.000Z
- * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), Gemini 3 Flash (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0)
+ * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), Gemini 3 Flash (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), agent (v1.6.0)
  */
 
 
@@ -40,7 +40,8 @@ Available deployment modes:
 Application operations:
   - contract: Manage traceability contracts between CLI and Chat
   - ws: Workspace management and entry
-  - exec: Execute a command and send output to GitSense Chat`,
+  - exec: Execute a command and send output to GitSense Chat
+  - formatters: List and test bridge output formatters`,
 }
 
 // RegisterCommand adds the app command and its deployment mode subcommands to the root CLI
@@ -62,6 +63,7 @@ func RegisterCommand(root *cobra.Command) {
 	contract.RegisterContractCommand(AppCmd)
 	ws.RegisterCommand(AppCmd)
 	RegisterExecCommand(AppCmd)
+	RegisterFormattersCommand(AppCmd)
 	
 	// Register app command to root
 	root.AddCommand(AppCmd)
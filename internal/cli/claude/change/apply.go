@@ -0,0 +1,188 @@
+/**
+ * Component: Change CLI Apply Command
+ * Block-UUID: f9eab669-80e5-4d4f-9451-ef497e599738
+ * Parent-UUID: 1e7b4c90-58d2-4f3a-b6e1-9a0c3d7f2e85
+ * Version: 1.1.0
+ * Description: Implements 'gsc claude change apply', which lands every pending file of an isolated change turn in the original checkout and removes its worktrees, listing files that needed a three-way merge, plus the flags and output shared with cherry-pick and reject.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package change
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/gitsense/gsc-cli/internal/cli/claude/shared"
+	"github.com/spf13/cobra"
+)
+
+// ReviewResult represents the JSON response for apply, cherry-pick, and reject
+type ReviewResult struct {
+	SessionID string   `json:"session_id"`
+	Turn      int      `json:"turn"`
+	Status    string   `json:"status"`
+	Applied   []string `json:"applied,omitempty"`
+	ThreeWay  []string `json:"three_way,omitempty"`
+	Remaining []string `json:"remaining,omitempty"`
+	Patches   []string `json:"patches,omitempty"`
+}
+
+// ReviewFlags contains flags for the apply, cherry-pick, and reject commands
+type ReviewFlags struct {
+	Session string
+	Format  string
+}
+
+// ApplyCmd creates the "change apply" subcommand
+func ApplyCmd() *cobra.Command {
+	flags := &ReviewFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Apply an isolated change turn to your checkout",
+		Long: `Apply the patches of a change turn started with --isolated.
+
+Every file not already cherry-picked is applied to the original working
+directories. When the checkout has moved on since the turn started, a
+three-way merge is attempted; files merged that way are also staged in the
+index and listed separately. The worktrees are removed once everything is
+applied; the patches stay in the turn directory.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReview(cmd, flags, func(m *intent_workflow.Manager) ([]string, error) {
+				return m.ApplyIsolatedChange(nil)
+			})
+		},
+	}
+
+	RegisterReviewFlags(cmd, flags)
+
+	return cmd
+}
+
+// runReview loads the session, runs action against its pending isolated
+// change, and prints the outcome
+func runReview(cmd *cobra.Command, flags *ReviewFlags, action func(m *intent_workflow.Manager) ([]string, error)) error {
+	if err := ValidateChangeFlags(cmd); err != nil {
+		return err
+	}
+	if err := ValidateReviewFlags(flags); err != nil {
+		return fmt.Errorf("invalid flags: %w", err)
+	}
+	cmd.SilenceUsage = true
+
+	manager, err := intent_workflow.LoadSession(flags.Session)
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	ic, err := manager.PendingIsolatedChange()
+	if err != nil {
+		return err
+	}
+
+	applied, err := action(manager)
+	if err != nil {
+		if len(applied) > 0 {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Applied before the failure:\n")
+			for _, f := range applied {
+				fmt.Fprintf(cmd.ErrOrStderr(), "  %s\n", f)
+			}
+		}
+		return err
+	}
+
+	result := ReviewResult{
+		SessionID: flags.Session,
+		Turn:      ic.Turn,
+		Status:    ic.Status,
+		Applied:   applied,
+	}
+	justApplied := make(map[string]bool, len(applied))
+	for _, f := range applied {
+		justApplied[f] = true
+	}
+	for _, wt := range ic.Worktrees {
+		for _, f := range wt.ThreeWay {
+			if abs := filepath.Join(wt.RepoRoot, f); justApplied[abs] {
+				result.ThreeWay = append(result.ThreeWay, abs)
+			}
+		}
+		if ic.Status != intent_workflow.IsolatedStatusRejected {
+			for _, f := range wt.Pending() {
+				result.Remaining = append(result.Remaining, filepath.Join(wt.RepoRoot, f))
+			}
+		}
+		if wt.PatchPath != "" {
+			result.Patches = append(result.Patches, wt.PatchPath)
+		}
+	}
+
+	if flags.Format == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON response: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Isolated change for turn %d: %s\n", result.Turn, result.Status)
+	if len(result.Applied) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nApplied:\n")
+		for _, f := range result.Applied {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", f)
+		}
+	}
+	if len(result.ThreeWay) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nApplied with a three-way merge (also staged in the index; see git diff --cached):\n")
+		for _, f := range result.ThreeWay {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", f)
+		}
+	}
+	if len(result.Remaining) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nStill pending:\n")
+		for _, f := range result.Remaining {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", f)
+		}
+	}
+	if len(result.Patches) > 0 {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nPatches:\n")
+		for _, p := range result.Patches {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", p)
+		}
+	}
+	return nil
+}
+
+// RegisterReviewFlags registers flags for the apply, cherry-pick, and reject commands
+func RegisterReviewFlags(cmd *cobra.Command, flags *ReviewFlags) {
+	cmd.Flags().StringVarP(
+		&flags.Session,
+		"session", "s",
+		"",
+		"Change session ID",
+	)
+	cmd.MarkFlagRequired("session")
+
+	cmd.Flags().StringVar(
+		&flags.Format,
+		"format",
+		"text",
+		"Output format: text or json",
+	)
+}
+
+// ValidateReviewFlags validates the apply, cherry-pick, and reject flags
+func ValidateReviewFlags(flags *ReviewFlags) error {
+	if flags.Session == "" {
+		return &shared.FlagError{Flag: "session", Message: "session ID is required"}
+	}
+	if flags.Format != "text" && flags.Format != "json" {
+		return &shared.FlagError{Flag: "format", Message: "format must be 'text' or 'json'"}
+	}
+	return nil
+}
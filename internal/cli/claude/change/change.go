@@ -1,12 +1,12 @@
 /**
  * Component: Change CLI Root Command
- * Block-UUID: 9d73587e-c4b1-4aa9-822c-a3c33ca2e64d
- * Parent-UUID: cff30d0e-4944-4e6e-931e-a1da2d322d26
- * Version: 1.1.0
- * Description: Parent command for Change CLI (start, stop subcommands). Change enables in-place code editing with git diff generation based on validated discovery results.
+ * Block-UUID: f8d544da-c81e-43ed-8e73-12dff8d462d7
+ * Parent-UUID: 9d73587e-c4b1-4aa9-822c-a3c33ca2e64d
+ * Version: 1.2.0
+ * Description: Parent command for Change CLI (start, stop, resume, apply, cherry-pick, reject subcommands). Change enables in-place code editing with git diff generation based on validated discovery results, or isolated editing in git worktrees with reviewable patches.
  * Language: Go
  * Created-at: 2026-04-23T16:50:12.298Z
- * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0)
+ * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -22,6 +22,9 @@ func GetAllChangeCommands() []*cobra.Command {
 		StartCmd(),
 		StopCmd(),
 		ResumeCmd(),
+		ApplyCmd(),
+		CherryPickCmd(),
+		RejectCmd(),
 	}
 }
 
@@ -35,7 +38,11 @@ func ChangeCmd() *cobra.Command {
 Change runs in one phase:
 1. Change: In-place code editing with git diff generation
 
-The change turn requires a completed validation turn to provide the list of validated files to modify.`,
+The change turn requires a completed validation turn to provide the list of validated files to modify.
+
+With 'change start --isolated' the turn runs in git worktrees instead; land or
+discard its patches with 'change apply', 'change cherry-pick <file>...', or
+'change reject'.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
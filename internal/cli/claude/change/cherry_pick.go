@@ -0,0 +1,41 @@
+/**
+ * Component: Change CLI Cherry-Pick Command
+ * Block-UUID: 7c3a9e15-2d84-4b60-8f1e-5b9d0a6c4e27
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements 'gsc claude change cherry-pick <file>...', which applies only the named files of an isolated change turn to the original checkout and leaves the rest pending.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package change
+
+import (
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/spf13/cobra"
+)
+
+// CherryPickCmd creates the "change cherry-pick" subcommand
+func CherryPickCmd() *cobra.Command {
+	flags := &ReviewFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "cherry-pick <file>...",
+		Short: "Apply selected files of an isolated change turn",
+		Long: `Apply only the named files from the patches of a change turn started
+with --isolated. Files may be given relative to a working directory or
+repository root, or as absolute paths. The remaining files stay pending for
+a later apply, cherry-pick, or reject.`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReview(cmd, flags, func(m *intent_workflow.Manager) ([]string, error) {
+				return m.ApplyIsolatedChange(args)
+			})
+		},
+	}
+
+	RegisterReviewFlags(cmd, flags)
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+/**
+ * Component: Change CLI Reject Command
+ * Block-UUID: 4b8e2d61-9a37-4c1f-a5d0-3e6f7b2c9d48
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements 'gsc claude change reject', which discards the pending files of an isolated change turn and removes its worktrees, keeping the patches in the turn directory.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package change
+
+import (
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/spf13/cobra"
+)
+
+// RejectCmd creates the "change reject" subcommand
+func RejectCmd() *cobra.Command {
+	flags := &ReviewFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "reject",
+		Short: "Discard an isolated change turn",
+		Long: `Discard the pending files of a change turn started with --isolated and
+remove its worktrees. Files already cherry-picked stay in your checkout. The
+patches are kept in the turn directory.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReview(cmd, flags, func(m *intent_workflow.Manager) ([]string, error) {
+				_, err := m.RejectIsolatedChange()
+				return nil, err
+			})
+		},
+	}
+
+	RegisterReviewFlags(cmd, flags)
+
+	return cmd
+}
@@ -1,12 +1,12 @@
 /*
  * Component: Change CLI Start Command
- * Block-UUID: c344d01d-5674-4fdf-8131-fb5b3f69083e
- * Parent-UUID: 34f4ca2d-1dd5-49ef-97a3-ece4ff6283bb
- * Version: 1.21.0
- * Description: Implements 'gsc claude change start' command for in-place code editing. Added --enable-code-provenance flag to support ephemeral header injection and provenance recording. Updated background worker orchestration to persist and propagate the provenance enablement state. Added --skip-discovery flag to support skipping discovery turns and proceeding directly to change. Fixed critical bug where --skip-discovery would fail for new sessions due to session-not-found check occurring before skip-discovery logic. Refactored to use shared agent helper utilities, removing duplicated code for intent resolution, working directory parsing, background worker spawning, and session initialization. Updated InitializeSession call to include disableExperts parameter (false by default for change turns).
+ * Block-UUID: 45b61c5b-08f9-4f5c-b7ac-8ba072572d9a
+ * Parent-UUID: eac67783-8ecf-4184-8075-1979e288ef76
+ * Version: 1.24.0
+ * Description: Implements 'gsc claude change start' for in-place or isolated code editing with shared agent helpers. Adds --permission-profile, stored on the session with the budget flags before the worker is spawned.
  * Language: Go
  * Created-at: 2026-04-29T02:47:10.446Z
- * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), GLM-4.7 (v1.11.0), Gemini 3 Flash (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), Gemini 3 Flash (v1.17.0), GLM-4.7 (v1.18.0), GLM-4.7 (v1.19.0), GLM-4.7 (v1.20.0), GLM-4.7 (v1.21.0)
+ * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), GLM-4.7 (v1.11.0), Gemini 3 Flash (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), Gemini 3 Flash (v1.17.0), GLM-4.7 (v1.18.0), GLM-4.7 (v1.19.0), GLM-4.7 (v1.20.0), GLM-4.7 (v1.21.0), agent (v1.22.0), agent (v1.23.0), agent (v1.24.0)
  */
 
 
@@ -17,6 +17,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
 	"github.com/gitsense/gsc-cli/internal/cli/claude/shared"
 	"github.com/spf13/cobra"
@@ -39,6 +40,11 @@ The change turn will:
 5. Enrich metadata with git provenance (SHAs, change type, language)
 6. Write result.json with change summary and changelog
 
+With --isolated, the turn runs in a fresh git worktree per repository
+(branched from HEAD) instead of your checkout. The patches are written to the
+turn directory and landed or discarded with 'gsc claude change apply',
+'gsc claude change cherry-pick <file>', or 'gsc claude change reject'.
+
 The session runs as a background subprocess and can be monitored with 'gsc claude scout status'.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runStartCommand(cmd, flags)
@@ -93,6 +99,13 @@ func runStartCommand(cmd *cobra.Command, flags *StartFlags) error {
 		return fmt.Errorf("failed to parse working directories: %w", err)
 	}
 
+	// Resolve the permission profile before a session is created for it
+	profile, err := shared.ResolvePermissionProfileFlag(flags.PermissionProfile)
+	if err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+
 	// Load or create session
 	var manager *intent_workflow.Manager
 	
@@ -166,6 +179,14 @@ func runStartCommand(cmd *cobra.Command, flags *StartFlags) error {
 		}
 	}
 
+	// Isolated turns need git repositories and no unreviewed isolated change
+	if flags.Isolated {
+		if err := manager.ValidateIsolation(); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+	}
+
 	// Validate session status
 	status, err := manager.GetSessionStatus()
 	if err != nil {
@@ -189,6 +210,24 @@ func runStartCommand(cmd *cobra.Command, flags *StartFlags) error {
 		}
 	}
 
+	// Store the permission profile on the session so the worker's turns and
+	// corrections run with it
+	if err := manager.SetPermissionProfile(profile); err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("failed to set permission profile: %w", err)
+	}
+
+	// Store the budget on the session so the worker enforces it, and refuse
+	// a turn the session can no longer afford before spawning one
+	if err := manager.SetBudget(flags.Budget); err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("failed to set budget: %w", err)
+	}
+	if err := manager.CheckBudget(); err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+
 	// Build args for background worker
 	args := []string{"claude", "change", "start"}
 	args = append(args, "--session", flags.Session)
@@ -208,6 +247,9 @@ func runStartCommand(cmd *cobra.Command, flags *StartFlags) error {
 	if flags.SkipDiscovery {
 		args = append(args, "--skip-discovery")
 	}
+	if flags.Isolated {
+		args = append(args, "--isolated")
+	}
 	if flags.Debug {
 		args = append(args, "--debug")
 	}
@@ -248,6 +290,14 @@ func runStartCommand(cmd *cobra.Command, flags *StartFlags) error {
 
 		fmt.Fprintf(cmd.OutOrStdout(), "\nStop the session with:\n")
 		fmt.Fprintf(cmd.OutOrStdout(), "  gsc claude change stop -s %s\n", sessionID)
+
+		if flags.Isolated {
+			fmt.Fprintf(cmd.OutOrStdout(), "\nIsolated: changes are made in git worktrees and your checkout is untouched.\n")
+			fmt.Fprintf(cmd.OutOrStdout(), "When the turn completes, review and land them with:\n")
+			fmt.Fprintf(cmd.OutOrStdout(), "  gsc claude change apply -s %s\n", sessionID)
+			fmt.Fprintf(cmd.OutOrStdout(), "  gsc claude change cherry-pick -s %s <file>...\n", sessionID)
+			fmt.Fprintf(cmd.OutOrStdout(), "  gsc claude change reject -s %s\n", sessionID)
+		}
 	}
 
 	return nil
@@ -327,6 +377,23 @@ func runBackgroundWorker(cmd *cobra.Command, flags *StartFlags) error {
 		return fmt.Errorf("failed to read intent file: %w", err)
 	}
 
+	// Move the turn into git worktrees; the original working directories are
+	// restored and the patches collected however the turn ends
+	if flags.Isolated {
+		debugLogger.Log("WORKER", "Creating isolated worktrees...")
+		if err := manager.BeginIsolatedChange(nextTurn); err != nil {
+			debugLogger.LogError("Failed to create isolated worktrees", err)
+			fmt.Fprintf(os.Stderr, "ERROR: Failed to create isolated worktrees: %v\n", err)
+			return fmt.Errorf("failed to create isolated worktrees: %w", err)
+		}
+		defer func() {
+			if err := manager.FinishIsolatedChange(nextTurn); err != nil {
+				debugLogger.LogError("Failed to collect isolated changes", err)
+				fmt.Fprintf(os.Stderr, "ERROR: Failed to collect isolated changes: %v\n", err)
+			}
+		}()
+	}
+
 	// Execute the change turn (this blocks until complete)
 	debugLogger.Log("WORKER", "Starting change turn...")
 	if err := manager.StartChangeTurn(intent); err != nil {
@@ -370,6 +437,9 @@ type StartFlags struct {
 	Model              string
 	WorkingDirectories []string
 	SkipDiscovery      bool
+	Isolated           bool
+	Budget             budget.Limits // Cost, token, and turn limits stored on the session
+	PermissionProfile  string        // Agent permission profile stored on the session
 }
 
 // RegisterStartFlags registers flags for the start command
@@ -432,6 +502,16 @@ func RegisterStartFlags(cmd *cobra.Command, flags *StartFlags) {
 		"Skip the discovery turn and proceed directly to change",
 	)
 
+	cmd.Flags().BoolVar(
+		&flags.Isolated,
+		"isolated",
+		false,
+		"Run the change turn in git worktrees and produce patches to apply, cherry-pick, or reject",
+	)
+
+	shared.RegisterBudgetFlags(cmd, &flags.Budget)
+	shared.RegisterPermissionProfileFlag(cmd, &flags.PermissionProfile)
+
 	cmd.Flags().BoolVar(
 		&flags.Debug,
 		"debug",
@@ -481,7 +561,7 @@ func ValidateStartFlags(flags *StartFlags) error {
 		return &shared.FlagError{Flag: "format", Message: "format must be 'text' or 'json'"}
 	}
 
-	return nil
+	return shared.ValidateBudgetFlags(flags.Budget)
 }
 
 // ValidateChangeFlags checks that unsupported flags are not set
@@ -1,12 +1,12 @@
 /**
  * Component: Claude Code Chat Command
- * Block-UUID: 19627792-a80e-4ba9-8d12-e3eb4a58cb31
- * Parent-UUID: 1a39a9f0-b1c9-4a3c-af24-4358cdf969c1
- * Version: 1.9.0
- * Description: Updated to pass Mode and NoEvents flags to the execution manager for multi-mode and batch processing support.
+ * Block-UUID: 04e93785-8064-4e40-b677-13b214260f54
+ * Parent-UUID: 19627792-a80e-4ba9-8d12-e3eb4a58cb31
+ * Version: 1.10.0
+ * Description: Chat command that passes Mode, NoEvents, and the budget limits to the execution manager.
  * Language: Go
  * Created-at: 2026-03-23T05:55:57.681Z
- * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.5.1), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0)
+ * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.5.1), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), agent (v1.10.0)
  */
 
 
@@ -82,7 +82,7 @@ file-based state, and streams the response back to stdout.`,
 
 		// 5. Execute Chat
 		logger.Info("Executing Claude Code chat", "uuid", chatUUID, "parent_id", chatParentID, "append", chatFlags.Append, "save", chatFlags.Save, "append_save", chatFlags.AppendSave, "model", chatFlags.Model, "thinking", chatFlags.ThinkingBudget, "mode", chatFlags.Mode, "no_events", chatFlags.NoEvents)
-		if err := chatint.ExecuteChat(chatUUID, chatParentID, userMessage, chatFlags.Format, chatFlags.Append, chatFlags.Save, chatFlags.AppendSave, chatFlags.Model, chatFlags.ThinkingBudget, chatFlags.Mode, chatFlags.NoEvents); err != nil {
+		if err := chatint.ExecuteChat(chatUUID, chatParentID, userMessage, chatFlags.Format, chatFlags.Append, chatFlags.Save, chatFlags.AppendSave, chatFlags.Model, chatFlags.ThinkingBudget, chatFlags.Mode, chatFlags.NoEvents, chatFlags.Budget); err != nil {
 			cmd.SilenceUsage = true
 			return fmt.Errorf("chat execution failed: %w", err)
 		}
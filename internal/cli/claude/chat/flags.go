@@ -1,12 +1,12 @@
 /**
  * Component: Chat CLI Flags and Options
- * Block-UUID: 8ecd7aa0-853d-42dd-a9e6-6c26d4ccc8b7
- * Parent-UUID: 8f7a3b2c-1d4e-4f5a-9b8c-7d6e5f4a3b2c
- * Version: 1.1.0
- * Description: Added Mode and NoEvents flags to support different chat types and batch processing.
+ * Block-UUID: 87302b9b-e2d1-4b25-807a-14444b97bd04
+ * Parent-UUID: 8ecd7aa0-853d-42dd-a9e6-6c26d4ccc8b7
+ * Version: 1.2.0
+ * Description: Chat CLI flags including Mode and NoEvents. Added the shared budget flags and their validation.
  * Language: Go
  * Created-at: 2026-04-01T15:31:15.123Z
- * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0)
+ * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -16,6 +16,8 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
+	"github.com/gitsense/gsc-cli/internal/cli/claude/shared"
 	"github.com/spf13/cobra"
 )
 
@@ -31,6 +33,7 @@ type ChatFlags struct {
 	ThinkingBudget int
 	Mode           string
 	NoEvents       bool
+	Budget         budget.Limits
 }
 
 // RegisterChatFlags registers all chat command flags
@@ -45,6 +48,7 @@ func RegisterChatFlags(cmd *cobra.Command, flags *ChatFlags) {
 	cmd.Flags().IntVar(&flags.ThinkingBudget, "thinking", 0, "Thinking budget in tokens (0 = disabled)")
 	cmd.Flags().StringVar(&flags.Mode, "mode", "coding-assistant", "The mode of the chat session (e.g., coding-assistant, analyze)")
 	cmd.Flags().BoolVar(&flags.NoEvents, "no-events", false, "Suppress streaming events and output only the final response text")
+	shared.RegisterBudgetFlags(cmd, &flags.Budget)
 }
 
 // ValidateChatFlags validates chat command flags
@@ -59,6 +63,10 @@ func ValidateChatFlags(flags *ChatFlags) error {
 		return fmt.Errorf("thinking budget must be >= 0 (got %d)", flags.ThinkingBudget)
 	}
 
+	if err := shared.ValidateBudgetFlags(flags.Budget); err != nil {
+		return err
+	}
+
 	// Validate file exists if provided
 	if flags.File != "" {
 		if _, err := os.Stat(flags.File); err != nil {
@@ -0,0 +1,320 @@
+/**
+ * Component: Intent Workflow CLI Learn Command
+ * Block-UUID: 5a0d7f36-2b91-4e48-a3c5-e6f1b8d40c29
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements 'gsc claude intent-workflow learn', which aggregates the keywords sessions suggested for files discovery missed, builds a validated Manifest patch against a local Brain, and writes it for review or export, or merges it into the Brain.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intentworkflowcli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/gitsense/gsc-cli/internal/git"
+	"github.com/gitsense/gsc-cli/internal/manifest"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+// LearnFlags holds flags for the learn command
+type LearnFlags struct {
+	Sessions    []string
+	MinSessions int
+	DBName      string
+	Field       string
+	Output      string
+	Apply       bool
+	Merge       string
+	NoBackup    bool
+	Format      string
+}
+
+// LearnResult represents the JSON response for the learn command
+type LearnResult struct {
+	Report  *intent_workflow.LearnReport `json:"report,omitempty"`
+	Outside []string                     `json:"outside_project,omitempty"` // Files outside the current project
+	Patch   *manifest.FieldPatch         `json:"patch,omitempty"`
+	Output  string                       `json:"output,omitempty"`
+	Merged  int                          `json:"merged"`
+}
+
+// LearnCmd creates the "intent-workflow learn" subcommand
+func LearnCmd() *cobra.Command {
+	flags := &LearnFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "learn",
+		Short: "Turn discovery gaps into Brain keyword suggestions",
+		Long: `Aggregate the keywords change turns suggested for files discovery missed
+(discovery_gap.suggested_keywords) across sessions, and propose them as new
+values of a Brain field.
+
+Without --db, learn only reports the suggestions. With --db, it builds a
+Manifest patch against that Brain: one data entry per analyzed file, carrying
+the file's existing values plus the suggested ones, validated with the same
+checks as 'gsc manifest import'. Then:
+
+  --output patch.json   Write the patch for review, or to import in the Chat app
+  --apply               Merge the patch into the Brain now (backed up first)
+  --merge patch.json    Merge a previously written, reviewed patch
+
+New keywords discovery and validation turns found but did not tie to a file
+are listed as unattributed for manual review. Files the Brain has not
+analyzed yet are listed but not patched.`,
+		Example: `  # Review what past sessions suggest
+  gsc claude intent-workflow learn
+
+  # Write a patch for the code-intent Brain, keeping keywords two sessions agree on
+  gsc claude intent-workflow learn --db code-intent --min-sessions 2 -o patch.json
+
+  # Merge the reviewed patch
+  gsc claude intent-workflow learn --merge patch.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runLearnCommand(cmd, flags)
+		},
+	}
+
+	RegisterLearnFlags(cmd, flags)
+
+	return cmd
+}
+
+// RegisterLearnFlags registers flags for the learn command
+func RegisterLearnFlags(cmd *cobra.Command, flags *LearnFlags) {
+	cmd.Flags().StringSliceVarP(&flags.Sessions, "session", "s", []string{}, "Session to learn from (can be specified multiple times; default: all sessions)")
+	cmd.Flags().IntVar(&flags.MinSessions, "min-sessions", 1, "Keep only keywords suggested by at least this many sessions")
+	cmd.Flags().StringVar(&flags.DBName, "db", "", "Brain database to build the patch against (e.g., code-intent)")
+	cmd.Flags().StringVar(&flags.Field, "field", "keywords", "Array field the suggested keywords are added to")
+	cmd.Flags().StringVarP(&flags.Output, "output", "o", "", "Write the Manifest patch to this file")
+	cmd.Flags().BoolVar(&flags.Apply, "apply", false, "Merge the patch into the Brain")
+	cmd.Flags().StringVar(&flags.Merge, "merge", "", "Validate and merge a reviewed patch file into the Brain (skips learning)")
+	cmd.Flags().BoolVar(&flags.NoBackup, "no-backup", false, "Skip backing up the Brain before merging")
+	cmd.Flags().StringVar(&flags.Format, "format", "text", "Output format (text, json)")
+}
+
+// ValidateLearnFlags validates learn command flags
+func ValidateLearnFlags(flags *LearnFlags) error {
+	if flags.Format != "text" && flags.Format != "json" {
+		return fmt.Errorf("invalid format: %s (must be text or json)", flags.Format)
+	}
+	if flags.MinSessions < 1 {
+		return fmt.Errorf("--min-sessions must be at least 1 (got %d)", flags.MinSessions)
+	}
+	if flags.Merge != "" {
+		if flags.Apply || flags.Output != "" || len(flags.Sessions) > 0 {
+			return fmt.Errorf("--merge cannot be combined with --apply, --output, or --session")
+		}
+		if _, err := os.Stat(flags.Merge); err != nil {
+			return fmt.Errorf("patch file not found: %s", flags.Merge)
+		}
+		return nil
+	}
+	if (flags.Apply || flags.Output != "") && flags.DBName == "" {
+		return fmt.Errorf("--apply and --output require --db")
+	}
+	return nil
+}
+
+// runLearnCommand executes the learn command logic
+func runLearnCommand(cmd *cobra.Command, flags *LearnFlags) error {
+	if err := ValidateLearnFlags(flags); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+	ctx := context.Background()
+
+	if flags.Merge != "" {
+		return runLearnMerge(ctx, cmd.OutOrStdout(), flags)
+	}
+
+	gscHome, err := settings.GetGSCHome(false)
+	if err != nil {
+		return fmt.Errorf("failed to resolve GSC_HOME: %w", err)
+	}
+	report, err := intent_workflow.LearnFromSessions(gscHome, flags.Sessions, intent_workflow.LearnOptions{MinSessions: flags.MinSessions})
+	if err != nil {
+		return err
+	}
+	result := LearnResult{Report: report}
+
+	if flags.DBName != "" {
+		additions, outside, err := projectAdditions(report)
+		if err != nil {
+			return err
+		}
+		result.Outside = outside
+
+		patch, err := manifest.BuildFieldPatch(ctx, flags.DBName, flags.Field, additions)
+		if err != nil {
+			return err
+		}
+		if len(patch.Manifest.Data) > 0 {
+			if err := manifest.ValidateManifest(patch.Manifest); err != nil {
+				return fmt.Errorf("patch validation failed: %w", err)
+			}
+		}
+		result.Patch = patch
+
+		if flags.Output != "" {
+			data, err := json.MarshalIndent(patch.Manifest, "", "  ")
+			if err != nil {
+				return fmt.Errorf("failed to marshal patch: %w", err)
+			}
+			if err := os.WriteFile(flags.Output, append(data, '\n'), 0644); err != nil {
+				return fmt.Errorf("failed to write patch: %w", err)
+			}
+			result.Output = flags.Output
+		}
+		if flags.Apply && len(patch.Manifest.Data) > 0 {
+			merged, err := manifest.MergeManifest(ctx, flags.DBName, patch.Manifest, flags.NoBackup)
+			if err != nil {
+				return err
+			}
+			result.Merged = merged
+		}
+	}
+
+	if flags.Format == "json" {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON response: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+	printLearnResult(cmd.OutOrStdout(), flags, result)
+	return nil
+}
+
+// runLearnMerge merges a reviewed patch file into the Brain it names, or
+// the one given with --db.
+func runLearnMerge(ctx context.Context, w io.Writer, flags *LearnFlags) error {
+	data, err := os.ReadFile(flags.Merge)
+	if err != nil {
+		return fmt.Errorf("failed to read patch: %w", err)
+	}
+	var patch manifest.ManifestFile
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return fmt.Errorf("failed to parse patch %s: %w", flags.Merge, err)
+	}
+	dbName := flags.DBName
+	if dbName == "" {
+		dbName = patch.Manifest.DatabaseName
+	}
+	if dbName == "" {
+		return fmt.Errorf("patch does not name a database; specify --db")
+	}
+
+	merged, err := manifest.MergeManifest(ctx, dbName, &patch, flags.NoBackup)
+	if err != nil {
+		return err
+	}
+
+	if flags.Format == "json" {
+		data, _ := json.MarshalIndent(LearnResult{Merged: merged}, "", "  ")
+		fmt.Fprintln(w, string(data))
+		return nil
+	}
+	fmt.Fprintf(w, "✓ Merged %s into %s (%d file(s) updated)\n", flags.Merge, dbName, merged)
+	return nil
+}
+
+// projectAdditions converts learned files to paths relative to the current
+// project root, the form Brains record. Files outside the project cannot be
+// patched into its Brains and are returned separately.
+func projectAdditions(report *intent_workflow.LearnReport) (map[string][]string, []string, error) {
+	root, err := git.FindProjectRoot()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find project root: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+
+	additions := make(map[string][]string)
+	var outside []string
+	for _, file := range report.Files {
+		path := file.Path
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			outside = append(outside, file.Path)
+			continue
+		}
+		for _, keyword := range file.Keywords {
+			additions[filepath.ToSlash(rel)] = append(additions[filepath.ToSlash(rel)], keyword.Keyword)
+		}
+	}
+	return additions, outside, nil
+}
+
+// printLearnResult writes the suggestions and what was done with the patch.
+func printLearnResult(w io.Writer, flags *LearnFlags, result LearnResult) {
+	report := result.Report
+	fmt.Fprintf(w, "Learned from %d session(s): %d file(s) with suggested keywords\n", report.SessionsScanned, len(report.Files))
+	for _, file := range report.Files {
+		fmt.Fprintf(w, "\n  %s\n", file.Path)
+		for _, keyword := range file.Keywords {
+			fmt.Fprintf(w, "    + %s (%s)\n", keyword.Keyword, pluralSessions(len(keyword.Sessions)))
+		}
+	}
+
+	if len(report.Unattributed) > 0 {
+		fmt.Fprintf(w, "\nUnattributed new keywords (not tied to a file; review manually):\n")
+		for _, keyword := range report.Unattributed {
+			fmt.Fprintf(w, "  %s (%s)\n", keyword.Keyword, pluralSessions(len(keyword.Sessions)))
+		}
+	}
+
+	if result.Patch == nil {
+		if len(report.Files) > 0 {
+			fmt.Fprintf(w, "\nBuild a Manifest patch with --db <brain> (add -o to write it, --apply to merge it).\n")
+		}
+		return
+	}
+
+	patch := result.Patch
+	fmt.Fprintf(w, "\nPatch against %s (field %s): %d file(s) gain values\n", flags.DBName, flags.Field, len(patch.Added))
+	paths := make([]string, 0, len(patch.Added))
+	for path := range patch.Added {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(w, "  %s: %s\n", path, strings.Join(patch.Added[path], ", "))
+	}
+	if len(patch.Unknown) > 0 {
+		fmt.Fprintf(w, "\nNot in the Brain (analyze first): %s\n", strings.Join(patch.Unknown, ", "))
+	}
+	if len(result.Outside) > 0 {
+		fmt.Fprintf(w, "\nOutside this project: %s\n", strings.Join(result.Outside, ", "))
+	}
+	if result.Output != "" {
+		fmt.Fprintf(w, "\n✓ Wrote patch to %s\n", result.Output)
+	}
+	if flags.Apply {
+		fmt.Fprintf(w, "✓ Merged into %s (%d file(s) updated)\n", flags.DBName, result.Merged)
+	}
+}
+
+// pluralSessions formats a session count.
+func pluralSessions(n int) string {
+	if n == 1 {
+		return "1 session"
+	}
+	return fmt.Sprintf("%d sessions", n)
+}
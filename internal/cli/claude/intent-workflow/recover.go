@@ -0,0 +1,175 @@
+/**
+ * Component: Intent Workflow CLI Recover Command
+ * Block-UUID: 6c3e9a48-1f75-4d02-b8e6-d4a2c7f05b91
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements 'gsc claude intent-workflow recover', which replays each session's state log, detects dead workers and agent processes, and moves sessions left running by a crash to a consistent state that retry can resume.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package intentworkflowcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+// RecoverFlags holds flags for the recover command
+type RecoverFlags struct {
+	Sessions []string
+	All      bool
+	DryRun   bool
+	Format   string
+}
+
+// RecoverCmd creates the "intent-workflow recover" subcommand
+func RecoverCmd() *cobra.Command {
+	flags := &RecoverFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "recover",
+		Short: "Repair sessions left running by a crash",
+		Long: `Bring sessions interrupted by a crashed CLI, worker, or machine back to a
+consistent, resumable state.
+
+Every status change of a session is appended to its state log (state.wal)
+before session.json is rewritten. Recover:
+
+1. Replays the latest state log record if session.json is older than it
+2. Leaves the session alone if its worker or agent process is still alive
+3. Clears a watcher PID whose process is gone
+4. Marks turns still "running" without a process as interrupted errors
+5. Restores the working directories of an interrupted --isolated change
+6. Moves a session stuck in discovery, change, or change_post_processing
+   to error, so 'gsc claude intent-workflow retry' can rerun the turn
+
+Use --dry-run to see what would change.`,
+		Example: `  # Recover one session
+  gsc claude intent-workflow recover --session abc123
+
+  # Check every session without changing anything
+  gsc claude intent-workflow recover --all --dry-run`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRecoverCommand(cmd, flags)
+		},
+	}
+
+	RegisterRecoverFlags(cmd, flags)
+
+	return cmd
+}
+
+// RegisterRecoverFlags registers flags for the recover command
+func RegisterRecoverFlags(cmd *cobra.Command, flags *RecoverFlags) {
+	cmd.Flags().StringSliceVarP(&flags.Sessions, "session", "s", []string{}, "Session to recover (can be specified multiple times)")
+	cmd.Flags().BoolVar(&flags.All, "all", false, "Recover every session")
+	cmd.Flags().BoolVar(&flags.DryRun, "dry-run", false, "Report what would change without writing")
+	cmd.Flags().StringVar(&flags.Format, "format", "text", "Output format (text, json)")
+}
+
+// ValidateRecoverFlags validates recover command flags
+func ValidateRecoverFlags(flags *RecoverFlags) error {
+	if flags.Format != "text" && flags.Format != "json" {
+		return fmt.Errorf("invalid format: %s (must be text or json)", flags.Format)
+	}
+	if flags.All == (len(flags.Sessions) > 0) {
+		return fmt.Errorf("specify either --session or --all")
+	}
+	return nil
+}
+
+// runRecoverCommand executes the recover command logic
+func runRecoverCommand(cmd *cobra.Command, flags *RecoverFlags) error {
+	if err := ValidateRecoverFlags(flags); err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true
+
+	sessionIDs := flags.Sessions
+	if flags.All {
+		gscHome, err := settings.GetGSCHome(false)
+		if err != nil {
+			return fmt.Errorf("failed to resolve GSC_HOME: %w", err)
+		}
+		sessionIDs, err = intent_workflow.ListSessions(gscHome)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Keep going past a broken session so one bad file does not block the rest
+	reports := []*intent_workflow.RecoveryReport{}
+	var failed []string
+	for _, id := range sessionIDs {
+		report, err := intent_workflow.RecoverSession(id, flags.DryRun)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %v", id, err))
+			continue
+		}
+		reports = append(reports, report)
+	}
+
+	if flags.Format == "json" {
+		data, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON response: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		printRecoveryReports(cmd.OutOrStdout(), reports, flags.All)
+	}
+
+	if len(failed) > 0 {
+		for _, msg := range failed {
+			fmt.Fprintf(cmd.ErrOrStderr(), "✗ %s\n", msg)
+		}
+		return fmt.Errorf("failed to recover %d session(s)", len(failed))
+	}
+	return nil
+}
+
+// printRecoveryReports writes one block per session. With --all, sessions
+// that needed nothing are summarized in a count.
+func printRecoveryReports(w io.Writer, reports []*intent_workflow.RecoveryReport, all bool) {
+	consistent := 0
+	for _, report := range reports {
+		if all && !report.Changed && !report.Running {
+			consistent++
+			continue
+		}
+
+		verb := "Recovered"
+		switch {
+		case report.Running:
+			verb = "Running"
+		case !report.Changed:
+			verb = "Consistent"
+		case report.DryRun:
+			verb = "Would recover"
+		}
+		status := report.StatusAfter
+		if report.StatusBefore != report.StatusAfter {
+			status = report.StatusBefore + " → " + report.StatusAfter
+		}
+		fmt.Fprintf(w, "%s %s (%s)\n", verb, report.SessionID, status)
+		for _, action := range report.Actions {
+			fmt.Fprintf(w, "  - %s\n", action)
+		}
+		if report.TornRecords > 0 {
+			fmt.Fprintf(w, "  - skipped %d unreadable state log line(s)\n", report.TornRecords)
+		}
+		if report.Changed && report.Next != "" {
+			fmt.Fprintf(w, "  Resume with: %s\n", report.Next)
+		}
+	}
+	if all {
+		fmt.Fprintf(w, "%d of %d session(s) already consistent\n", consistent, len(reports))
+	}
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow CLI Retry Command
- * Block-UUID: 416f5084-758b-47b9-8691-81c72de96516
- * Parent-UUID: 60e6adec-e561-4f54-9660-34140b9450f1
- * Version: 1.3.0
- * Description: Implements 'gsc claude agent retry' command for deleting and restarting the latest turn. Updated to allow retry on stopped and error states.
+ * Block-UUID: 28d54bb2-51a4-4d3b-8f8f-2bc849f9ec7b
+ * Parent-UUID: 416f5084-758b-47b9-8691-81c72de96516
+ * Version: 1.4.0
+ * Description: Implements 'gsc claude agent retry' for deleting and restarting the latest turn on stopped, error, and budget_exceeded sessions. Accepts budget flags so a retry can raise the limits, and checks the budget before deleting the turn.
  * Language: Go
  * Created-at: 2026-04-20T15:37:40.455Z
- * Authors: GLM-4.7 (v1.0.0), Gemini 2.5 Flash Lite (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0)
+ * Authors: GLM-4.7 (v1.0.0), Gemini 2.5 Flash Lite (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), agent (v1.4.0)
  */
 
 
@@ -20,7 +20,9 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/gitsense/gsc-cli/internal/cli/claude/shared"
 	"github.com/spf13/cobra"
 )
 
@@ -46,7 +48,10 @@ This will:
 1. Delete the latest turn directory
 2. Remove the turn from session state
 3. Reset session status to previous state
-4. Automatically restart the turn as a background process`,
+4. Automatically restart the turn as a background process
+
+A turn stopped with budget_exceeded can be retried once the budget allows
+another turn; pass --max-cost, --max-tokens, or --max-turns to raise it.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runRetryCommand(cmd, flags)
 		},
@@ -85,15 +90,26 @@ func runRetryCommand(cmd *cobra.Command, flags *RetryFlags) error {
 		return fmt.Errorf("cannot retry turn: turn is still running (status: %s)", lastTurn.Status)
 	}
 
+	// Apply new or raised limits before checking the retried turn fits
+	if err := shared.ValidateBudgetFlags(flags.Budget); err != nil {
+		return err
+	}
+	if err := manager.SetBudget(flags.Budget); err != nil {
+		return fmt.Errorf("failed to set budget: %w", err)
+	}
+
+	// Remove latest turn from session.Turns; its spend no longer counts
+	session.Turns = session.Turns[:turnCount-1]
+	if err := manager.CheckBudget(); err != nil {
+		return fmt.Errorf("cannot retry turn: %w (raise the limit with --max-cost, --max-tokens, or --max-turns)", err)
+	}
+
 	// Delete turn directory
 	turnDir := manager.GetConfig().GetTurnDir(lastTurn.TurnNumber)
 	if err := os.RemoveAll(turnDir); err != nil {
 		return fmt.Errorf("failed to delete turn directory: %w", err)
 	}
 
-	// Remove latest turn from session.Turns
-	session.Turns = session.Turns[:turnCount-1]
-
 	// Reset session state
 	if turnCount > 1 {
 		prevTurn := session.Turns[turnCount-2]
@@ -111,6 +127,7 @@ func runRetryCommand(cmd *cobra.Command, flags *RetryFlags) error {
 
 	// Clear error and completion fields
 	session.Error = nil
+	session.BudgetExceeded = nil
 	session.CompletedAt = nil
 	session.WatcherPID = nil
 
@@ -215,6 +232,7 @@ type RetryFlags struct {
 	Session     string
 	Format      string
 	WatchWorker bool
+	Budget      budget.Limits // Replaces the session's limits that are set
 }
 
 // RegisterRetryFlags registers flags for the retry command
@@ -241,4 +259,6 @@ func RegisterRetryFlags(cmd *cobra.Command, flags *RetryFlags) {
 		"Run as background worker process",
 	)
 	cmd.Flags().MarkHidden("watch-worker")
+
+	shared.RegisterBudgetFlags(cmd, &flags.Budget)
 }
@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow CLI Root Command
- * Block-UUID: 387accc0-4a0a-4cdf-9dfb-a887eaeb61e4
- * Parent-UUID: ef8e2ac9-78a6-46ae-b28f-488976877720
- * Version: 1.3.0
- * Description: Parent command for Agent CLI (status, stop, delete, retry). Agent provides generic session management for discovery and change turns.
+ * Block-UUID: e324d365-0870-44d7-8051-0b3a40d007bc
+ * Parent-UUID: 2141080a-98d1-4b2c-be5e-7ef5576df980
+ * Version: 1.5.0
+ * Description: Parent command for intent-workflow session management (status, stop, delete, retry, learn, recover subcommands). Recover repairs sessions left running by a crashed CLI, worker, or machine.
  * Language: Go
  * Created-at: 2026-04-20T15:19:34.988Z
- * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0)
+ * Authors: GLM-4.7 (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), agent (v1.4.0), agent (v1.5.0)
  */
 
 
@@ -23,6 +23,8 @@ func GetAllAgentCommands() []*cobra.Command {
 		StopCmd(),
 		DeleteCmd(),
 		RetryCmd(),
+		LearnCmd(),
+		RecoverCmd(),
 	}
 }
 
@@ -37,7 +39,9 @@ Intent workflow sessions support multiple turn types:
 1. Discovery: Searches working directories using contract insights and Code Intent brain
 2. Change: In-place code editing based on discovery results
 
-Sessions run as background subprocesses and can be monitored independently of the chat.`,
+Sessions run as background subprocesses and can be monitored independently of the chat.
+Use 'learn' to feed the keywords change turns suggest for missed files back into a Brain.
+Use 'recover' to repair sessions left running by a crashed CLI or machine.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
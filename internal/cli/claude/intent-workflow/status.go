@@ -1,12 +1,12 @@
 /**
  * Component: Intent Workflow CLI Status Command
- * Block-UUID: 9b0c1d2e-4f5a-6b7c-8d9e-0f1a2b3c4d5e
- * Parent-UUID: 482ce3f6-a964-40f4-9b36-39c4a22adf80
- * Version: 1.2.4
- * Description: Implements 'gsc claude agent status' command for monitoring Intent workflow sessions. Generic version that works with all intent workflow session types (discovery, validation, change). Added getSuccinctResponse helper function and display logic to show AI-generated natural language summaries from discovery turns.
+ * Block-UUID: c2565673-8880-48ed-baec-45f04e591509
+ * Parent-UUID: 9b0c1d2e-4f5a-6b7c-8d9e-0f1a2b3c4d5e
+ * Version: 1.3.0
+ * Description: Implements 'gsc claude agent status' for monitoring Intent workflow sessions of any type. Shows a Budget section with limits, spend, and the exceeded limit, and colors budget_exceeded as a failure.
  * Language: Go
  * Created-at: 2026-04-28T23:17:15.869Z
- * Authors: GLM-4.7 (v1.0.0), Gemini 2.5 Flash Lite (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.2.1), GLM-4.7 (v1.2.2), GLM-4.7 (v1.2.3), GLM-4.7 (v1.2.4)
+ * Authors: GLM-4.7 (v1.0.0), Gemini 2.5 Flash Lite (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.2.1), GLM-4.7 (v1.2.2), GLM-4.7 (v1.2.3), GLM-4.7 (v1.2.4), agent (v1.3.0)
  */
 
 
@@ -186,6 +186,13 @@ func displayStatusTable(cmd *cobra.Command, status *intent_workflow.StatusData,
 		}
 	}
 
+	if status.Budget != nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "\nBudget:\n")
+		for _, line := range budgetLines(status.Budget) {
+			fmt.Fprintf(cmd.OutOrStdout(), "  %s\n", line)
+		}
+	}
+
 	return nil
 }
 
@@ -379,6 +386,13 @@ func displayStatusPretty(cmd *cobra.Command, status *intent_workflow.StatusData,
 		}
 	}
 
+	if status.Budget != nil {
+		fmt.Fprintf(cmd.OutOrStdout(), "\n  Budget:\n")
+		for _, line := range budgetLines(status.Budget) {
+			fmt.Fprintf(cmd.OutOrStdout(), "    %s\n", line)
+		}
+	}
+
 	if status.Error != nil {
 		fmt.Fprintf(cmd.OutOrStdout(), "\n  ⚠ Error: %s\n", *status.Error)
 	}
@@ -392,6 +406,32 @@ func displayStatusPretty(cmd *cobra.Command, status *intent_workflow.StatusData,
 	return nil
 }
 
+// budgetLines describes each set limit next to the session's spend, and
+// the limit that stopped the session if any
+func budgetLines(report *intent_workflow.BudgetReport) []string {
+	var lines []string
+	limits, spent := report.Limits, report.Spent
+	if limits.MaxCost > 0 {
+		lines = append(lines, fmt.Sprintf("Cost: $%.4f of $%.4f", spent.Cost, limits.MaxCost))
+	}
+	if limits.MaxTokens > 0 {
+		lines = append(lines, fmt.Sprintf("Tokens: %d of %d", spent.Tokens, limits.MaxTokens))
+	}
+	if limits.MaxTurns > 0 {
+		lines = append(lines, fmt.Sprintf("Turns: %d of %d", spent.Turns, limits.MaxTurns))
+	}
+	if limits.MaxTurnCost > 0 {
+		lines = append(lines, fmt.Sprintf("Per-turn cost: $%.4f", limits.MaxTurnCost))
+	}
+	if limits.MaxTurnTokens > 0 {
+		lines = append(lines, fmt.Sprintf("Per-turn tokens: %d", limits.MaxTurnTokens))
+	}
+	if report.Exceeded != nil {
+		lines = append(lines, fmt.Sprintf("Exceeded in turn %d: %s", report.Exceeded.Turn, report.Exceeded.Error()))
+	}
+	return lines
+}
+
 // getPhaseDisplayName returns a friendly name for the phase
 func getPhaseDisplayName(phase string) string {
 	switch phase {
@@ -415,8 +455,8 @@ func colorizeStatus(status string) string {
 	case "stopped":
 		// Yellow for stopped state
 		return fmt.Sprintf("\033[33m%s\033[0m", status)
-	case "error":
-		// Red for error state
+	case "error", "budget_exceeded":
+		// Red for error and over-budget states
 		return fmt.Sprintf("\033[31m%s\033[0m", status)
 	default:
 		// No color for unknown states
@@ -0,0 +1,213 @@
+/**
+ * Component: Scout CLI Bench Command
+ * Block-UUID: 2d8e5b14-6a9f-4c37-9e02-b7f1c4a8d360
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements 'gsc claude scout bench', which runs discovery over a suite of intents with known expected files, or a suite learned from past sessions, and reports recall, precision, cost, and latency with and without experts context.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package scoutcli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gitsense/gsc-cli/internal/claude/scout"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+// BenchCmd creates the "scout bench" subcommand
+func BenchCmd() *cobra.Command {
+	flags := &BenchFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Measure discovery recall and precision against known file sets",
+		Long: `Run discovery over a suite of intents, each paired with the files it should
+find, and report recall, precision, cost, and latency per configuration.
+
+Configurations:
+  experts   Discovery with the experts context (Manifests and Brains)
+  generic   Discovery with grep/find only (--disable-experts)
+
+A suite is a JSON file:
+
+  {
+    "name": "checkout",
+    "workdirs": ["../shop"],
+    "cases": [
+      {
+        "id": "tax-rounding",
+        "intent": "Where is sales tax rounded?",
+        "expected": [{"workdir": "shop", "path": "billing/tax.go"}]
+      }
+    ]
+  }
+
+Relative workdirs are resolved against the suite file. Expected files name
+the working directory by its base name, like discovery candidates do.
+
+With --learn, the suite is built from past sessions instead: each session
+with a completed change turn becomes a case whose expected files are the
+files the change modified plus the discovery gaps it reported. Use
+--save-suite to write a learned suite, review it, and run it with --suite.
+
+Runs execute sequentially in the foreground. Each is a regular session
+named bench-<time>-<case>-<config> that can be inspected with
+'gsc claude agent status'.`,
+		Example: `  # Learn a suite from past change sessions and save it for review
+  gsc claude scout bench --learn --save-suite bench.json
+
+  # Compare experts and generic discovery on the suite
+  gsc claude scout bench --suite bench.json
+
+  # Generic discovery only, capped at $0.50 per run
+  gsc claude scout bench --suite bench.json --config generic --max-cost 0.5`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBenchCommand(cmd, flags)
+		},
+	}
+
+	RegisterBenchFlags(cmd, flags)
+
+	return cmd
+}
+
+// runBenchCommand executes the bench command logic
+func runBenchCommand(cmd *cobra.Command, flags *BenchFlags) error {
+	if err := ValidateScoutFlags(cmd); err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+	if err := ValidateBenchFlags(flags); err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("invalid flags: %w", err)
+	}
+	cmd.SilenceUsage = true
+
+	var suite *scout.BenchSuite
+	var err error
+	if flags.Learn {
+		gscHome, homeErr := settings.GetGSCHome(false)
+		if homeErr != nil {
+			return fmt.Errorf("failed to resolve GSC_HOME: %w", homeErr)
+		}
+		suite, err = scout.LearnBenchSuite(gscHome, flags.Sessions)
+	} else {
+		suite, err = scout.LoadBenchSuite(flags.Suite)
+	}
+	if err != nil {
+		return err
+	}
+
+	if flags.SaveSuite != "" {
+		data, err := json.MarshalIndent(suite, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal suite: %w", err)
+		}
+		if err := os.WriteFile(flags.SaveSuite, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write suite: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Wrote %d case(s) to %s\n", len(suite.Cases), flags.SaveSuite)
+		return nil
+	}
+
+	// Progress goes to stderr so JSON output stays parseable
+	progress := cmd.ErrOrStderr()
+	total := len(suite.Cases) * len(flags.Configs)
+	done := 0
+	report, err := scout.RunBench(suite, scout.BenchOptions{
+		Configs: flags.Configs,
+		Model:   flags.Model,
+		Budget:  flags.Budget,
+		OnRun: func(run scout.BenchRun) {
+			done++
+			if run.Error != "" {
+				fmt.Fprintf(progress, "[%d/%d] %s (%s): error: %s\n", done, total, run.Case, run.Config, run.Error)
+				return
+			}
+			fmt.Fprintf(progress, "[%d/%d] %s (%s): recall %.2f, precision %.2f\n", done, total, run.Case, run.Config, run.Recall, run.Precision)
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if flags.Format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON response: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	printBenchReport(cmd.OutOrStdout(), report)
+	return nil
+}
+
+// printBenchReport writes per-case results followed by the per-configuration
+// summary.
+func printBenchReport(w io.Writer, report *scout.BenchReport) {
+	fmt.Fprintln(w, "Runs:")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  CASE\tCONFIG\tMODE\tFOUND\tRECALL\tPRECISION\tCOST\tLATENCY\t")
+	for _, run := range report.Runs {
+		if run.Error != "" {
+			fmt.Fprintf(tw, "  %s\t%s\t-\t-\t-\t-\t$%.4f\t%s\t\n", run.Case, run.Config, run.Cost, formatLatency(run.LatencyMs))
+			continue
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%d/%d\t%.2f\t%.2f\t$%.4f\t%s\t\n",
+			run.Case, run.Config, run.DiscoveryMode, run.Matched, run.Expected,
+			run.Recall, run.Precision, run.Cost, formatLatency(run.LatencyMs))
+	}
+	tw.Flush()
+
+	fmt.Fprintln(w, "\nSummary:")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  CONFIG\tCASES\tERRORS\tRECALL\tPRECISION\tTOTAL COST\tMEAN COST\tMEAN LATENCY\t")
+	for _, summary := range report.Summary {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%.2f\t%.2f\t$%.4f\t$%.4f\t%s\t\n",
+			summary.Config, summary.Cases, summary.Errors, summary.Recall, summary.Precision,
+			summary.TotalCost, summary.MeanCost, formatLatency(summary.MeanLatencyMs))
+	}
+	tw.Flush()
+
+	var failed []string
+	for _, run := range report.Runs {
+		if run.Error != "" {
+			failed = append(failed, fmt.Sprintf("  %s (%s): %s", run.Case, run.Config, run.Error))
+		}
+	}
+	if len(failed) > 0 {
+		fmt.Fprintf(w, "\nFailed runs (excluded from recall and precision):\n%s\n", strings.Join(failed, "\n"))
+	}
+
+	fmt.Fprintln(w, "\nMissed files:")
+	missed := false
+	for _, run := range report.Runs {
+		if run.Error != "" || len(run.Missed) == 0 {
+			continue
+		}
+		missed = true
+		for _, file := range run.Missed {
+			fmt.Fprintf(w, "  %s (%s): %s/%s\n", run.Case, run.Config, file.Workdir, file.Path)
+		}
+	}
+	if !missed {
+		fmt.Fprintln(w, "  none")
+	}
+}
+
+// formatLatency renders milliseconds as seconds.
+func formatLatency(ms int64) string {
+	return fmt.Sprintf("%.1fs", float64(ms)/1000)
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Scout CLI Flags and Options
- * Block-UUID: 1e62d171-ece6-4194-9057-088690d8a35d
- * Parent-UUID: 735687ce-ce06-4bd0-895f-78863ff7b415
- * Version: 1.17.0
- * Description: Shared flag definitions for Scout CLI commands (start, status, stop). Updated for Intent Workflow with smart discovery (metadata search + code validation). Removed turn-type flag as discovery is now the only mode. Added DisableExperts flag to StartFlags to support --disable-experts flag for forcing generic discovery mode.
+ * Block-UUID: 28e5b875-94bc-42fe-84eb-3f55c5ac48ad
+ * Parent-UUID: c651e9cd-a23b-41c8-8c09-ae480873cd04
+ * Version: 1.20.0
+ * Description: Shared flag definitions for Scout CLI commands (start, status, stop, bench), including the shared budget flags. Added --permission-profile to scout start.
  * Language: Go
  * Created-at: 2026-04-12T03:15:13.862Z
- * Authors: claude-haiku-4-5-20251001 (v1.8.0), GLM-4.7 (v1.8.1), GLM-4.7 (v1.8.2), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), GLM-4.7 (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), GLM-4.7 (v1.17.0)
+ * Authors: claude-haiku-4-5-20251001 (v1.8.0), GLM-4.7 (v1.8.1), GLM-4.7 (v1.8.2), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), GLM-4.7 (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), GLM-4.7 (v1.17.0), agent (v1.18.0), agent (v1.19.0), agent (v1.20.0)
  */
 
 
@@ -22,7 +22,10 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
 	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/gitsense/gsc-cli/internal/claude/scout"
+	"github.com/gitsense/gsc-cli/internal/cli/claude/shared"
 )
 
 // StartFlags contains flags for the scout start command
@@ -40,6 +43,8 @@ type StartFlags struct {
 	Model              string // Claude model family: haiku, sonnet, opus
 	WatchWorker        bool   // Hidden: run as background worker process
 	DisableExperts     bool   // Force generic discovery; ignore experts context even if initialized
+	Budget             budget.Limits // Cost, token, and turn limits stored on the session
+	PermissionProfile  string        // Agent permission profile stored on the session
 }
 
 // StatusFlags contains flags for the scout status command
@@ -64,6 +69,18 @@ type ResultsFlags struct {
 	Format    string // json, text
 }
 
+// BenchFlags contains flags for the scout bench command
+type BenchFlags struct {
+	Suite     string        // Path to a JSON suite of intents and expected files
+	Learn     bool          // Build the suite from past sessions' change results
+	Sessions  []string      // Sessions to learn from (default: all)
+	SaveSuite string        // Write the suite to this path instead of running it
+	Configs   []string      // Configurations to run: experts, generic
+	Model     string        // Claude model family: haiku, sonnet, opus
+	Format    string        // Output format: table or json
+	Budget    budget.Limits // Limits applied to each discovery run
+}
+
 // RegisterStartFlags registers flags for the start command
 func RegisterStartFlags(cmd *cobra.Command, flags *StartFlags) {
 	cmd.Flags().StringVarP(
@@ -157,6 +174,9 @@ func RegisterStartFlags(cmd *cobra.Command, flags *StartFlags) {
 		false,
 		"Use generic discovery (grep/find only); ignore experts context even if initialized",
 	)
+
+	shared.RegisterBudgetFlags(cmd, &flags.Budget)
+	shared.RegisterPermissionProfileFlag(cmd, &flags.PermissionProfile)
 }
 
 // RegisterStatusFlags registers flags for the status command
@@ -228,6 +248,60 @@ func RegisterResultsFlags(cmd *cobra.Command, flags *ResultsFlags) {
 	)
 }
 
+// RegisterBenchFlags registers flags for the bench command
+func RegisterBenchFlags(cmd *cobra.Command, flags *BenchFlags) {
+	cmd.Flags().StringVar(
+		&flags.Suite,
+		"suite",
+		"",
+		"Path to a JSON suite of intents paired with the files discovery should find",
+	)
+
+	cmd.Flags().BoolVar(
+		&flags.Learn,
+		"learn",
+		false,
+		"Build the suite from past sessions: files changed by change turns plus reported discovery gaps",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&flags.Sessions,
+		"learn-session",
+		[]string{},
+		"Session to learn from (can be specified multiple times; default: all sessions)",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.SaveSuite,
+		"save-suite",
+		"",
+		"Write the suite to this path and exit without running it",
+	)
+
+	cmd.Flags().StringSliceVar(
+		&flags.Configs,
+		"config",
+		[]string{scout.BenchConfigExperts, scout.BenchConfigGeneric},
+		"Configurations to compare: experts (Manifests and Brains), generic (grep/find only)",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.Model,
+		"model",
+		"",
+		"Claude model family: haiku, sonnet, or opus",
+	)
+
+	cmd.Flags().StringVar(
+		&flags.Format,
+		"format",
+		"table",
+		"Output format: table or json",
+	)
+
+	shared.RegisterBudgetFlags(cmd, &flags.Budget)
+}
+
 // ValidateStartFlags validates the start command flags
 func ValidateStartFlags(flags *StartFlags) error {
 	// Ensure either --intent or --intent-file is provided (but not both)
@@ -302,7 +376,45 @@ func ValidateStartFlags(flags *StartFlags) error {
 		return &FlagError{Flag: "format", Message: "format must be 'text' or 'json'"}
 	}
 
-	return nil
+	return shared.ValidateBudgetFlags(flags.Budget)
+}
+
+// ValidateBenchFlags validates the bench command flags
+func ValidateBenchFlags(flags *BenchFlags) error {
+	if flags.Suite == "" && !flags.Learn {
+		return &FlagError{Flag: "suite", Message: "either --suite or --learn is required"}
+	}
+	if flags.Suite != "" && flags.Learn {
+		return &FlagError{Flag: "suite", Message: "cannot specify both --suite and --learn"}
+	}
+	if len(flags.Sessions) > 0 && !flags.Learn {
+		return &FlagError{Flag: "learn-session", Message: "--learn-session requires --learn"}
+	}
+	if flags.Suite != "" {
+		if _, err := os.Stat(flags.Suite); err != nil {
+			return &FlagError{Flag: "suite", Message: fmt.Sprintf("suite file not found: %s", flags.Suite)}
+		}
+	}
+	for _, sessionID := range flags.Sessions {
+		if err := ValidateSessionID(sessionID); err != nil {
+			return &FlagError{Flag: "learn-session", Message: err.Error()}
+		}
+	}
+
+	if len(flags.Configs) == 0 {
+		return &FlagError{Flag: "config", Message: "at least one configuration is required"}
+	}
+	for _, config := range flags.Configs {
+		if config != scout.BenchConfigExperts && config != scout.BenchConfigGeneric {
+			return &FlagError{Flag: "config", Message: fmt.Sprintf("unknown configuration %q (must be experts or generic)", config)}
+		}
+	}
+
+	if flags.Format != "table" && flags.Format != "json" {
+		return &FlagError{Flag: "format", Message: "format must be 'table' or 'json'"}
+	}
+
+	return shared.ValidateBudgetFlags(flags.Budget)
 }
 
 // ValidateStatusFlags validates the status command flags
@@ -1,12 +1,12 @@
 /**
  * Component: Scout CLI Root Command
- * Block-UUID: 072b0861-e21a-436c-99c6-3cb7e8e3fab6
- * Parent-UUID: 1ad22b86-0964-4228-8d9c-a3c8caf7c2d3
- * Version: 1.0.5
- * Description: Parent command for Scout CLI (start, stop subcommands). Scout supports multiple discovery turns followed by validation for iterative file discovery.
+ * Block-UUID: 32aa1eb1-69a9-47c3-ad90-9802d44e6327
+ * Parent-UUID: 072b0861-e21a-436c-99c6-3cb7e8e3fab6
+ * Version: 1.1.0
+ * Description: Parent command for Scout CLI (start, stop, bench subcommands). Scout supports multiple discovery turns followed by validation, and bench measures discovery recall and precision.
  * Language: Go
  * Created-at: 2026-04-08T23:11:57.135Z
- * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.0.1), claude-haiku-4-5-20251001 (v1.0.2), claude-haiku-4-5-20251001 (v1.0.3), GLM-4.7 (v1.0.4), GLM-4.7 (v1.0.5)
+ * Authors: claude-haiku-4-5-20251001 (v1.0.0), GLM-4.7 (v1.0.1), claude-haiku-4-5-20251001 (v1.0.2), claude-haiku-4-5-20251001 (v1.0.3), GLM-4.7 (v1.0.4), GLM-4.7 (v1.0.5), agent (v1.1.0)
  */
 
 
@@ -21,6 +21,7 @@ func GetAllScoutCommands() []*cobra.Command {
 	return []*cobra.Command{
 		StartCmd(),
 		StopCmd(),
+		BenchCmd(),
 	}
 }
 
@@ -35,7 +36,8 @@ Scout runs in two phases:
 1. Discovery: Searches working directories using contract insights and Code Intent brain (can run multiple discovery turns)
 2. Validation: Optional re-scoring of candidates with Claude for deeper analysis
 
-Sessions run as background subprocesses and can be monitored independently of the chat.`,
+Sessions run as background subprocesses and can be monitored independently of the chat.
+Use 'scout bench' to measure discovery recall and precision against known file sets.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return cmd.Help()
 		},
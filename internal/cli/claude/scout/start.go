@@ -1,12 +1,12 @@
 /*
  * Component: Scout CLI Start Command
- * Block-UUID: c0f0143d-d746-40ef-b447-021a4248af77
- * Parent-UUID: 737f2699-e093-4294-8828-5d88ae039f68
- * Version: 1.19.0
- * Description: Implements 'gsc claude scout start' command for Intent Workflow with smart discovery (metadata search + code validation). Simplified to discovery-only mode with discovery → change workflow. Refactored to use shared agent helper utilities, removing duplicated code for intent resolution, working directory parsing, background worker spawning, and session initialization. Updated to pass flags.DisableExperts to InitializeSession to support --disable-experts flag for forcing generic discovery mode.
+ * Block-UUID: 2b2ca1d9-74ca-457a-88ba-1a4853ae3a3c
+ * Parent-UUID: 18fb41df-66e9-44f4-9c26-7b154d0ed4ae
+ * Version: 1.21.0
+ * Description: Implements 'gsc claude scout start' for discovery-only Intent Workflow sessions. Stores the selected permission profile and the budget flags on the session, and refuses to spawn the worker when the budget is already spent.
  * Language: Go
  * Created-at: 2026-04-13T14:04:01.074Z
- * Authors: claude-haiku-4-5-20251001 (v1.2.1), GLM-4.7 (v1.2.2), GLM-4.7 (v1.2.3), GLM-4.7 (v1.2.4), GLM-4.7 (v1.3.0), GLM-4.7 (v1.3.1), GLM-4.7 (v1.3.2), GLM-4.7 (v1.4.0), claude-haiku-4-5-20251001 (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), GLM-4.7 (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), GLM-4.7 (v1.17.0), GLM-4.7 (v1.18.0), GLM-4.7 (v1.19.0)
+ * Authors: claude-haiku-4-5-20251001 (v1.2.1), GLM-4.7 (v1.2.2), GLM-4.7 (v1.2.3), GLM-4.7 (v1.2.4), GLM-4.7 (v1.3.0), GLM-4.7 (v1.3.1), GLM-4.7 (v1.3.2), GLM-4.7 (v1.4.0), claude-haiku-4-5-20251001 (v1.5.0), GLM-4.7 (v1.6.0), GLM-4.7 (v1.7.0), GLM-4.7 (v1.8.0), GLM-4.7 (v1.9.0), GLM-4.7 (v1.10.0), GLM-4.7 (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), GLM-4.7 (v1.17.0), GLM-4.7 (v1.18.0), GLM-4.7 (v1.19.0), agent (v1.20.0), agent (v1.21.0)
  */
 
 
@@ -97,6 +97,13 @@ func runStartCommand(cmd *cobra.Command, flags *StartFlags) error {
 		return fmt.Errorf("failed to parse reference files: %w", err)
 	}
 
+	// Resolve the permission profile before a session is created for it
+	profile, err := shared.ResolvePermissionProfileFlag(flags.PermissionProfile)
+	if err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+
 	// Create or load scout manager
 	var manager *intent_workflow.Manager
 	
@@ -124,6 +131,24 @@ func runStartCommand(cmd *cobra.Command, flags *StartFlags) error {
 		}
 	}
 
+	// Store the permission profile on the session so the worker's turns and
+	// corrections run with it
+	if err := manager.SetPermissionProfile(profile); err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("failed to set permission profile: %w", err)
+	}
+
+	// Store the budget on the session so the worker enforces it, and refuse
+	// a turn the session can no longer afford before spawning one
+	if err := manager.SetBudget(flags.Budget); err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("failed to set budget: %w", err)
+	}
+	if err := manager.CheckBudget(); err != nil {
+		cmd.SilenceUsage = true
+		return err
+	}
+
 	// Spawn background worker with --watch-worker flag
 	// The background worker will execute the discovery turn
 	args := []string{"claude", "scout", "start"}
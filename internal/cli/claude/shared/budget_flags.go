@@ -0,0 +1,76 @@
+/**
+ * Component: Agent Budget Flags
+ * Block-UUID: 627aaae5-1e38-4641-8035-19aeb5f33be9
+ * Parent-UUID: 6c1d8f3e-2a75-4b94-8e0f-b3d7a5c91e62
+ * Version: 1.1.0
+ * Description: Registers and validates the --max-cost, --max-tokens, --max-turns, --max-turn-cost, and --max-turn-tokens budget flags shared by scout, change, intent-workflow retry, and chat.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package shared
+
+import (
+	"github.com/gitsense/gsc-cli/internal/claude/budget"
+	"github.com/spf13/cobra"
+)
+
+// RegisterBudgetFlags registers the budget flags into limits. Zero leaves a
+// limit unset; on an existing session, set limits replace the stored ones.
+func RegisterBudgetFlags(cmd *cobra.Command, limits *budget.Limits) {
+	cmd.Flags().Float64Var(
+		&limits.MaxCost,
+		"max-cost",
+		0,
+		"Stop once the session has cost this many USD (estimated from model prices while a run streams)",
+	)
+
+	cmd.Flags().IntVar(
+		&limits.MaxTokens,
+		"max-tokens",
+		0,
+		"Stop once the session has used this many input, output, and cache-write tokens",
+	)
+
+	cmd.Flags().IntVar(
+		&limits.MaxTurns,
+		"max-turns",
+		0,
+		"Refuse to start more than this many agent turns in the session",
+	)
+
+	cmd.Flags().Float64Var(
+		&limits.MaxTurnCost,
+		"max-turn-cost",
+		0,
+		"Stop once a single turn, with its corrections, has cost this many USD (estimated while it runs)",
+	)
+
+	cmd.Flags().IntVar(
+		&limits.MaxTurnTokens,
+		"max-turn-tokens",
+		0,
+		"Stop a turn once it has used this many tokens",
+	)
+}
+
+// ValidateBudgetFlags rejects negative limits.
+func ValidateBudgetFlags(limits budget.Limits) error {
+	if limits.MaxCost < 0 {
+		return &FlagError{Flag: "max-cost", Message: "must not be negative"}
+	}
+	if limits.MaxTokens < 0 {
+		return &FlagError{Flag: "max-tokens", Message: "must not be negative"}
+	}
+	if limits.MaxTurns < 0 {
+		return &FlagError{Flag: "max-turns", Message: "must not be negative"}
+	}
+	if limits.MaxTurnCost < 0 {
+		return &FlagError{Flag: "max-turn-cost", Message: "must not be negative"}
+	}
+	if limits.MaxTurnTokens < 0 {
+		return &FlagError{Flag: "max-turn-tokens", Message: "must not be negative"}
+	}
+	return nil
+}
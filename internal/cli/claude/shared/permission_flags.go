@@ -0,0 +1,48 @@
+/**
+ * Component: Agent Permission Profile Flag
+ * Block-UUID: 7a2e9c05-4b18-4d63-9f71-c8d3b6e04a52
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Registers the --permission-profile flag shared by scout start and change start, and resolves the named profile from the current project's .gitsense/agent-profiles.json.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package shared
+
+import (
+	"fmt"
+
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/gitsense/gsc-cli/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// RegisterPermissionProfileFlag registers --permission-profile into name.
+func RegisterPermissionProfileFlag(cmd *cobra.Command, name *string) {
+	cmd.Flags().StringVar(
+		name,
+		"permission-profile",
+		"",
+		"Agent permission profile from .gitsense/agent-profiles.json (\"default\" for the built-in policy; kept for later turns)",
+	)
+}
+
+// ResolvePermissionProfileFlag resolves the profile named by
+// --permission-profile. It returns nil when the flag is not set, so an
+// existing session keeps its profile.
+func ResolvePermissionProfileFlag(name string) (*intent_workflow.PermissionProfileRecord, error) {
+	if name == "" {
+		return nil, nil
+	}
+	root := ""
+	if name != intent_workflow.DefaultPermissionProfile {
+		var err error
+		root, err = git.FindProjectRoot()
+		if err != nil {
+			return nil, fmt.Errorf("--permission-profile requires a project with .gitsense/%s: %w", intent_workflow.AgentProfilesFileName, err)
+		}
+	}
+	return intent_workflow.ResolvePermissionProfile(root, name)
+}
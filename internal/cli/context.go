@@ -0,0 +1,152 @@
+/**
+ * Component: Context Command
+ * Block-UUID: 44cb62f2-6a38-49dc-989e-b3a3abe9a43a
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: CLI command definition for 'gsc context', returning the rules, lessons, notes, and Brain metadata that apply to one or more files in a single ranked, token-budgeted view.
+ * Language: Go
+ * Created-at: 2026-10-18T12:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/gitsense/gsc-cli/internal/git"
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	knowledgepkg "github.com/gitsense/gsc-cli/internal/knowledge"
+	"github.com/gitsense/gsc-cli/internal/registry"
+)
+
+var (
+	contextScope           string
+	contextTypes           []string
+	contextDBs             []string
+	contextNoBrains        bool
+	contextBudget          int
+	contextIncludeArchived bool
+	contextFormat          string
+)
+
+// contextCmd represents the context command
+var contextCmd = &cobra.Command{
+	Use:   "context <path...>",
+	Short: "Show the rules, lessons, notes, and Brain metadata for files",
+	Long: `Gather everything an agent should know before editing one or more files.
+
+For each path, the command returns the applicable rules (with match provenance),
+lessons, and live notes from the repo and personal stores, plus the metadata
+every registered Brain holds for the file. Items that apply to several paths are
+listed once, ranked by importance, and capped to an approximate token budget.
+
+Paths may be absolute or relative to the current directory; they do not need to
+exist yet.`,
+	Example: `  # Context for a file before editing it
+  gsc context internal/manifest/importer.go
+
+  # Several files, JSON output for agents
+  gsc context internal/cli/root.go internal/cli/grep.go --format json
+
+  # Only rules and lessons, with a tighter budget
+  gsc context internal/db/schema.go --type rules,lessons --budget 1000`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		scope, err := gitsensescope.ParseScope(contextScope)
+		if err != nil {
+			return err
+		}
+
+		paths, err := repoRelativePaths(args)
+		if err != nil {
+			return err
+		}
+
+		var databases []string
+		for _, name := range contextDBs {
+			resolved, err := registry.ResolveDatabase(name)
+			if err != nil {
+				return err
+			}
+			databases = append(databases, resolved)
+		}
+
+		opts := knowledgepkg.ContextOptions{
+			Paths:           paths,
+			Scope:           scope,
+			Index:           knowledgepkg.IndexOptionsFromTypes(contextTypes),
+			IncludeBrains:   !contextNoBrains,
+			Databases:       databases,
+			TokenBudget:     contextBudget,
+			IncludeArchived: contextIncludeArchived,
+		}
+		resp, err := knowledgepkg.BuildFileContext(cmd.Context(), opts)
+		if err != nil {
+			return err
+		}
+
+		switch contextFormat {
+		case "", "markdown", "md":
+			fmt.Print(knowledgepkg.RenderContextMarkdown(resp))
+		case "json":
+			data, err := json.MarshalIndent(resp, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(data))
+		default:
+			return fmt.Errorf("unknown format %q (use markdown or json)", contextFormat)
+		}
+		return nil
+	},
+}
+
+func init() {
+	contextCmd.Flags().StringVar(&contextScope, "scope", "all", "Read scope: all, repo, or personal")
+	contextCmd.Flags().StringSliceVar(&contextTypes, "type", nil, "Knowledge types to include (rules, lessons, notes). Default: all")
+	contextCmd.Flags().StringArrayVarP(&contextDBs, "db", "d", nil, "Brain database to include (repeatable; default: every registered Brain)")
+	contextCmd.Flags().BoolVar(&contextNoBrains, "no-brains", false, "Skip Brain metadata")
+	contextCmd.Flags().IntVar(&contextBudget, "budget", 4000, "Approximate token budget for knowledge items (0 for no limit)")
+	contextCmd.Flags().BoolVar(&contextIncludeArchived, "include-archived", false, "Include expired and archived notes")
+	contextCmd.Flags().StringVarP(&contextFormat, "format", "f", "markdown", "Output format (markdown, json)")
+}
+
+// repoRelativePaths converts command-line paths to repo-relative, slash-separated paths.
+func repoRelativePaths(args []string) ([]string, error) {
+	root, offset, err := git.GetRepoContext()
+	if err != nil {
+		return nil, fmt.Errorf("gsc context must be run inside a git repository: %w", err)
+	}
+	seen := make(map[string]bool)
+	var paths []string
+	for _, arg := range args {
+		rel := filepath.Join(offset, arg)
+		if filepath.IsAbs(arg) {
+			abs := arg
+			if resolved, err := filepath.EvalSymlinks(arg); err == nil {
+				abs = resolved
+			}
+			if rel, err = filepath.Rel(root, abs); err != nil || strings.HasPrefix(rel, "..") {
+				return nil, fmt.Errorf("path %s is outside the repository %s", arg, root)
+			}
+		}
+		rel = filepath.ToSlash(filepath.Clean(rel))
+		if !seen[rel] {
+			seen[rel] = true
+			paths = append(paths, rel)
+		}
+	}
+	return paths, nil
+}
+
+// RegisterContextCommand registers the context command with the root command.
+func RegisterContextCommand(rootCmd *cobra.Command) {
+	rootCmd.AddCommand(contextCmd)
+}
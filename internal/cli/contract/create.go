@@ -1,12 +1,12 @@
 /**
  * Component: Contract CLI Create
- * Block-UUID: e05ffaec-bf52-439b-bd13-feedf8c8d2f6
- * Parent-UUID: 8c781331-aa47-4f7b-aa16-8cfb366f8762
- * Version: 1.2.0
- * Description: Auto-select default terminal based on platform instead of prompting user interactively.
+ * Block-UUID: 4986aef0-514d-4fbd-be55-3a09434b81f0
+ * Parent-UUID: 5af37098-2df4-412c-b09f-c9bbefcae7e6
+ * Version: 1.4.0
+ * Description: The --whitelist help mentions redirect rules.
  * Language: Go
  * Created-at: 2026-03-10T04:35:57.944Z
- * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.29.1), Gemini 3 Flash (v1.30.0), GLM-4.7 (v1.31.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.1.1), GLM-4.7 (v1.1.2), GLM-4.7 (v1.2.0)
+ * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.29.1), Gemini 3 Flash (v1.30.0), GLM-4.7 (v1.31.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.1.1), GLM-4.7 (v1.1.2), GLM-4.7 (v1.2.0), agent (v1.3.0), agent (v1.4.0)
  */
 
 
@@ -130,7 +130,7 @@ func init() {
 	createContractCmd.Flags().StringVar(&contractCode, "code", "", "6-digit handshake code from chat (required)")
 	createContractCmd.Flags().StringVar(&contractDescription, "description", "", "Description of the contract's purpose (required)")
 	createContractCmd.Flags().StringVar(&contractAuthcode, "authcode", "", "4-digit authorization code (optional, random if not set)")
-	createContractCmd.Flags().StringVar(&contractWhitelistFile, "whitelist", "", "Path to a file containing a list of allowed commands (optional)")
+	createContractCmd.Flags().StringVar(&contractWhitelistFile, "whitelist", "", "Path to a file of command policy rules, one per line: allowed commands with argument patterns, deny, env, and redirect rules (optional)")
 	createContractCmd.Flags().BoolVar(&contractNoWhitelist, "no-whitelist", false, "Disable whitelist checks (unrestricted mode)")
 	createContractCmd.Flags().IntVar(&contractExecTimeout, "exec-timeout", 60, "Execution timeout in seconds (default 60)")
 	createContractCmd.Flags().StringVar(&contractPreferredTerminal, "terminal", "", fmt.Sprintf("Preferred terminal for project access (Available: %s)", strings.Join(getMapKeys(settings.DefaultTerminalTemplates), ", ")))
@@ -1,12 +1,12 @@
 /**
  * Component: Contract CLI Execution
- * Block-UUID: 5606c33b-c6ff-45c3-b778-eab1419f522f
- * Parent-UUID: 635c038f-8763-4468-9cb0-b2b971099314
- * Version: 1.0.2
- * Description: CLI commands for executing commands within a contract context and launching workspace tools.
+ * Block-UUID: adf82290-1d1e-4324-8f62-85a8bd50d313
+ * Parent-UUID: 28df8a2b-e7de-4254-86bb-e527fb94ea22
+ * Version: 1.2.0
+ * Description: Exec prepares the command with its formatter before the policy check, so rewritten commands (e.g. go test -json) are checked and run, and sends formatter output as Markdown. Formatters that do not apply fall back to the raw output.
  * Language: Go
  * Created-at: 2026-04-27T18:08:22.532Z
- * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.0.2)
+ * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.0.0), GLM-4.7 (v1.0.1), GLM-4.7 (v1.0.2), agent (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -15,7 +15,6 @@ package contract
 import (
 	"database/sql"
 	"fmt"
-	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/gitsense/gsc-cli/internal/bridge/formatters"
@@ -44,31 +43,23 @@ var execContractCmd = &cobra.Command{
 			return fmt.Errorf("invalid authorization code")
 		}
 
-		fields := strings.Fields(contractExecCmd)
-		if len(fields) == 0 {
-			return fmt.Errorf("no command provided")
+		workdir := ""
+		if len(meta.Workdirs) > 0 {
+			workdir = meta.Workdirs[0].Path
 		}
-		binary := fields[0]
-
-		if !meta.NoWhitelist {
-			allowed := false
-			for _, w := range meta.Whitelist {
-				if w == binary {
-					allowed = true
-					break
-				}
-			}
-			if !allowed {
-				return fmt.Errorf("command '%s' is not whitelisted for this contract", binary)
-			}
+
+		// The formatter may rewrite the command (e.g. go test gains -json), so
+		// the policy check runs on the command that is actually executed
+		command, formatter := formatters.Prepare(contractExecCmd, workdir)
+
+		// Every command in the string is checked, not just the first word
+		if _, err := contract.CheckExecCommand(meta, command, contract.ExecSourceContractExec); err != nil {
+			return err
 		}
 
-		executor := exec.NewExecutor(contractExecCmd, exec.ExecFlags{
+		executor := exec.NewExecutor(command, exec.ExecFlags{
 			TimeoutSeconds: meta.ExecTimeout,
-		}, func() string {
-			if len(meta.Workdirs) > 0 { return meta.Workdirs[0].Path }
-			return ""
-		}(), nil)
+		}, workdir, nil)
 
 		result, err := executor.Run()
 		if err != nil {
@@ -76,12 +67,14 @@ var execContractCmd = &cobra.Command{
 		}
 
 		finalOutput := result.Output
-		formatter := formatters.ResolveFormatter(binary)
+		outputFormat := "text"
 		if formatter != nil {
-			formatter.PreProcess(fields[1:])
 			enriched, err := formatter.PostProcess(finalOutput)
 			if err == nil {
 				finalOutput = enriched
+				outputFormat = "markdown"
+			} else if err != formatters.ErrNotApplicable {
+				logger.Warning("Formatter failed, sending raw output", "command", command, "error", err)
 			}
 		}
 
@@ -98,7 +91,7 @@ var execContractCmd = &cobra.Command{
 				return fmt.Errorf("failed to find last message: %w", err)
 			}
 
-			markdown := output.FormatBridgeMarkdown(contractExecCmd, result.Duration, "N/A", "text", finalOutput, result.ExitCode)
+			markdown := output.FormatBridgeMarkdown(command, result.Duration, "N/A", outputFormat, finalOutput, result.ExitCode)
 
 			msg := &db.Message{
 				Type:       "gsc-cli-output",
@@ -1,12 +1,12 @@
 /**
  * Component: Grep/RG Command
- * Block-UUID: 7c935aed-ccff-41b1-a648-955f5a89add2
- * Parent-UUID: 93068c1b-d9bd-4545-9489-1baa8e3d5c3e
- * Version: 4.14.1
+ * Block-UUID: 25b01edf-1604-4eed-b798-28e11b7260ac
+ * Parent-UUID: 7c935aed-ccff-41b1-a648-955f5a89add2
+ * Version: 4.15.0
  * Description: Added 'rg' as a primary alias for the grep command to improve AI alignment with ripgrep syntax. Implemented fail-fast validation to detect POSIX-style alternation (\|) and provide helpful error messages. Updated help text to emphasize ripgrep syntax.
  * Language: Go
  * Created-at: 2026-06-02T14:57:27.776Z
- * Authors: GLM-4.7 (v4.8.0), Gemini 3 Flash (v4.9.0), GLM-4.7 (v4.10.0), GLM-4.7 (v4.11.0), GLM-4.7 (v4.12.0), GLM-4.7 (v4.13.0), GLM-4.7 (v4.14.0), DeepSeek V4 Pro (v4.14.1)
+ * Authors: GLM-4.7 (v4.8.0), Gemini 3 Flash (v4.9.0), GLM-4.7 (v4.10.0), GLM-4.7 (v4.11.0), GLM-4.7 (v4.12.0), GLM-4.7 (v4.13.0), GLM-4.7 (v4.14.0), DeepSeek V4 Pro (v4.14.1), agent (v4.15.0)
  */
 
 
@@ -367,7 +367,7 @@ Ripgrep-compatible flags:
 
 			// 3. Hand off to bridge orchestrator
 			cmdStr := filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " ")
-			return bridge.Execute(bridgeCode, outputStr, grepFormat, cmdStr, time.Since(startTime), dbName, 0, forceInsert)
+			return bridge.Execute(bridgeCode, outputStr, grepFormat, cmdStr, time.Since(startTime), dbName, 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 		}
 
 		// Standard Output Mode
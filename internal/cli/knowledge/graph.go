@@ -0,0 +1,182 @@
+/**
+ * Component: Knowledge Graph Command
+ * Block-UUID: 0bb0d91d-6fd8-4e13-b87a-a2731ce4c09f
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements gsc knowledge graph for exploring how topics, lessons, notes, rules, globs, and files connect, with DOT/Mermaid/JSON export.
+ * Language: Go
+ * Created-at: 2026-10-18T11:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package knowledge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gitsense/gsc-cli/internal/git"
+	knowledgepkg "github.com/gitsense/gsc-cli/internal/knowledge"
+	"github.com/spf13/cobra"
+)
+
+func graphCmd() *cobra.Command {
+	var (
+		hops            int
+		types           []string
+		tracked         bool
+		includeArchived bool
+		format          string
+	)
+	cmd := &cobra.Command{
+		Use:   "graph [node]",
+		Short: "Show how topics, files, lessons, notes, and rules connect",
+		Long: `Build a graph of topics, knowledge records, glob patterns, and repository
+paths, and show either the whole graph or the neighborhood of one node.
+
+A node can be a file path, a topic slug, a glob pattern, a lesson/note/rule ID
+(or unique prefix), or a full node ID such as "file:main.go" or "topic:cli".
+
+Edges:
+  topic, related_topic   record -> topic
+  applies_to, linked_file record -> file
+  glob                   record -> glob pattern
+  matches                glob -> file
+
+Use --hops to limit the neighborhood (default: 2). Use --tracked to match
+globs against every git-tracked file instead of only referenced paths.`,
+		Example: `  # Everything within 2 hops of a file
+  gsc knowledge graph internal/manifest/importer.go
+
+  # Neighborhood of a topic as a Mermaid diagram
+  gsc knowledge graph data-layer --hops 1 -o mermaid
+
+  # Full graph rendered with Graphviz
+  gsc knowledge graph -o dot | dot -Tsvg > knowledge.svg`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			opts := knowledgepkg.GraphOptions{Index: knowledgepkg.IndexOptionsFromTypes(types)}
+			opts.Index.IncludeArchivedNotes = includeArchived
+			ref := ""
+			if len(args) == 1 {
+				ref = repoRelativeArg(args[0])
+				if _, err := os.Stat(args[0]); err == nil {
+					opts.Files = append(opts.Files, ref)
+				}
+			}
+			if tracked {
+				root, err := git.FindGitRoot()
+				if err != nil {
+					return err
+				}
+				files, err := git.GetTrackedFiles(context.Background(), root)
+				if err != nil {
+					return err
+				}
+				opts.Files = append(opts.Files, files...)
+			}
+
+			graph, err := knowledgepkg.BuildGraph(opts)
+			if err != nil {
+				return err
+			}
+
+			focus := ""
+			if len(args) == 1 {
+				focus, err = graph.Resolve(ref)
+				if err != nil {
+					return err
+				}
+				graph = graph.Neighborhood(focus, hops)
+			}
+
+			switch format {
+			case "", "table":
+				renderGraphTable(graph, focus, hops)
+			case "json":
+				resp := graph.Response()
+				resp.Focus = focus
+				if focus != "" {
+					resp.Hops = hops
+				}
+				data, err := json.MarshalIndent(resp, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+			case "dot":
+				return graph.WriteDOT(os.Stdout)
+			case "mermaid":
+				return graph.WriteMermaid(os.Stdout)
+			default:
+				return fmt.Errorf("unknown format %q (use table, json, dot, or mermaid)", format)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().IntVar(&hops, "hops", 2, "Maximum distance from the focus node (-1 for the whole component)")
+	cmd.Flags().StringSliceVar(&types, "type", nil, "Entity types to include (lessons, notes, rules)")
+	cmd.Flags().BoolVar(&tracked, "tracked", false, "Match glob patterns against all git-tracked files")
+	cmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Include expired and archived notes")
+	cmd.Flags().StringVarP(&format, "format", "o", "table", "Output format (table, json, dot, mermaid)")
+	return cmd
+}
+
+func renderGraphTable(graph *knowledgepkg.Graph, focus string, hops int) {
+	resp := graph.Response()
+	if len(resp.Nodes) == 0 {
+		fmt.Println("Knowledge graph is empty.")
+		return
+	}
+
+	if focus == "" {
+		counts := graph.CountByKind()
+		fmt.Printf("%-8s %s\n", "KIND", "NODES")
+		fmt.Printf("%-8s %s\n", "--------", "-----")
+		for _, kind := range []knowledgepkg.NodeKind{
+			knowledgepkg.NodeTopic, knowledgepkg.NodeLesson, knowledgepkg.NodeNote,
+			knowledgepkg.NodeRule, knowledgepkg.NodeGlob, knowledgepkg.NodeFile,
+		} {
+			fmt.Printf("%-8s %d\n", kind, counts[kind])
+		}
+		fmt.Printf("\nTotal: %d nodes, %d edges\n", len(resp.Nodes), len(resp.Edges))
+		fmt.Println("Pass a node to explore its neighborhood, or use -o dot|mermaid|json to export.")
+		return
+	}
+
+	sort.SliceStable(resp.Nodes, func(i, j int) bool {
+		return *resp.Nodes[i].Distance < *resp.Nodes[j].Distance
+	})
+	fmt.Printf("Focus: %s (within %d hop(s))\n\n", focus, hops)
+	fmt.Printf("%-4s %-7s %-40s %s\n", "HOP", "KIND", "NODE", "SUMMARY")
+	fmt.Printf("%-4s %-7s %-40s %s\n", "----", "-------", "----------------------------------------", "-------")
+	for _, n := range resp.Nodes {
+		fmt.Printf("%-4d %-7s %-40s %s\n", *n.Distance, n.Kind, truncateTopic(n.Key, 40), truncateTopic(n.Label, 60))
+	}
+	fmt.Printf("\nTotal: %d nodes, %d edges\n", len(resp.Nodes), len(resp.Edges))
+}
+
+// repoRelativeArg converts an existing path argument to a repo-relative path so
+// it matches the paths stored in records. Other references are returned as-is.
+func repoRelativeArg(arg string) string {
+	if _, err := os.Stat(arg); err != nil {
+		return arg
+	}
+	root, offset, err := git.GetRepoContext()
+	if err != nil {
+		return arg
+	}
+	if filepath.IsAbs(arg) {
+		if rel, err := filepath.Rel(root, arg); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+		return arg
+	}
+	return filepath.ToSlash(filepath.Join(offset, arg))
+}
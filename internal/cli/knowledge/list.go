@@ -1,12 +1,12 @@
 /**
  * Component: Knowledge List Command
- * Block-UUID: a1b2c3d4-e5f6-7890-abcd-200000000007
- * Parent-UUID: N/A
- * Version: 1.1.0
- * Description: Added --sort and --asc flags, UPDATED column to table output.
+ * Block-UUID: aa385b7f-36c7-4ff0-bd83-2ef4d3fe983f
+ * Parent-UUID: a1b2c3d4-e5f6-7890-abcd-200000000007
+ * Version: 1.2.0
+ * Description: Added --include-archived to surface expired and archived notes.
  * Language: Go
  * Created-at: 2026-06-22T10:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -29,6 +29,7 @@ func listCmd() *cobra.Command {
 		format   string
 		sort     string
 		asc      bool
+		archived bool
 	)
 	cmd := &cobra.Command{
 		Use:   "list --topic <slug>",
@@ -38,7 +39,8 @@ func listCmd() *cobra.Command {
 Use --type to filter by entity type (lessons, notes, rules).
 Use --limit to cap the number of results.
 Use --sort to choose sort field (updated, importance, type). Default: updated.
-Use --asc to sort ascending (default: descending).`,
+Use --asc to sort ascending (default: descending).
+Expired and archived notes are excluded unless --include-archived is set.`,
 		Example: `  # List all items in a topic
   gsc knowledge list --topic data-layer
 
@@ -67,6 +69,8 @@ Use --asc to sort ascending (default: descending).`,
 				Limit: limit,
 				Sort:  knowledgepkg.SortField(sort),
 				Asc:   asc,
+
+				IncludeArchived: archived,
 			}
 
 			response, err := knowledgepkg.List(opts)
@@ -96,6 +100,7 @@ Use --asc to sort ascending (default: descending).`,
 	cmd.Flags().StringVarP(&format, "format", "o", "table", "Output format (table, json)")
 	cmd.Flags().StringVar(&sort, "sort", "updated", "Sort by field: updated, importance, type")
 	cmd.Flags().BoolVar(&asc, "asc", false, "Sort ascending (default: descending)")
+	cmd.Flags().BoolVar(&archived, "include-archived", false, "Include expired and archived notes")
 	return cmd
 }
 
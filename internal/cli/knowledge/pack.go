@@ -0,0 +1,226 @@
+/**
+ * Component: Knowledge Pack Commands
+ * Block-UUID: e57e5bf2-f455-43ce-842f-d4abc6c2204c
+ * Parent-UUID: N/A
+ * Version: 1.1.0
+ * Description: Implements gsc knowledge pack export/import for moving curated topics, lessons, notes, and rules between repositories. Documents that topic-scoped exports carry only the fixtures their rules use.
+ * Language: Go
+ * Created-at: 2026-10-18T09:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package knowledge
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	knowledgepkg "github.com/gitsense/gsc-cli/internal/knowledge"
+	"github.com/spf13/cobra"
+)
+
+func packCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pack",
+		Short: "Export and import knowledge packs between repositories",
+		Long: `Move a curated set of lessons, notes, and rules from one repository to another.
+
+A knowledge pack is a versioned tar.gz archive containing the referenced topics,
+the selected records, rule trigger files under rules/triggers/, and rule fixtures.`,
+		SilenceUsage: true,
+		RunE:         helpOrUnknown,
+	}
+	cmd.AddCommand(packExportCmd())
+	cmd.AddCommand(packImportCmd())
+	return cmd
+}
+
+func packExportCmd() *cobra.Command {
+	var (
+		topics     []string
+		types      []string
+		scopeValue string
+		output     string
+		format     string
+	)
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Write selected knowledge into a pack archive",
+		Long: `Export lessons, notes, and rules into a knowledge pack archive.
+
+Use --topic to select records whose primary or related topic matches (repeatable).
+With --topic, only rule fixtures used by the exported rules are included: those
+named in a trigger, or named after a rule ID or trigger file.
+Use --type to limit entity types (lessons, notes, rules). Default: all.
+Use --scope to choose which stores to read (repo, personal, all). Default: all.`,
+		Example: `  # Export rules and lessons for one topic
+  gsc knowledge pack export --topic data-layer --type rules,lessons
+
+  # Export everything to a specific file
+  gsc knowledge pack export -O team-knowledge.tar.gz`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, err := gitsensescope.ParseScope(scopeValue)
+			if err != nil {
+				return err
+			}
+			pack, err := knowledgepkg.BuildPack(knowledgepkg.PackExportOptions{
+				Topics: topics,
+				Types:  types,
+				Scope:  scope,
+			})
+			if err != nil {
+				return err
+			}
+			if output == "" {
+				output = defaultPackName(topics)
+			}
+			if err := knowledgepkg.WritePackFile(pack, output); err != nil {
+				return fmt.Errorf("failed to write pack: %w", err)
+			}
+
+			switch format {
+			case "", "table":
+				c := pack.Manifest.Counts
+				fmt.Printf("Exported %d lesson(s), %d note(s), %d rule(s) across %d topic(s).\n",
+					c["lessons"], c["notes"], c["rules"], c["topics"])
+				if c["triggers"] > 0 || c["fixtures"] > 0 {
+					fmt.Printf("Included %d trigger file(s) and %d fixture(s).\n", c["triggers"], c["fixtures"])
+				}
+				fmt.Printf("Pack: %s\n", output)
+			case "json":
+				data, err := json.MarshalIndent(struct {
+					Path     string                    `json:"path"`
+					Manifest knowledgepkg.PackManifest `json:"manifest"`
+				}{output, pack.Manifest}, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+			default:
+				return fmt.Errorf("unknown format %q (use table or json)", format)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&topics, "topic", nil, "Topic slug to include (repeatable; default: all)")
+	cmd.Flags().StringSliceVar(&types, "type", nil, "Entity types to include (lessons, notes, rules)")
+	cmd.Flags().StringVar(&scopeValue, "scope", "", "Read scope: repo, personal, all (default: all)")
+	cmd.Flags().StringVarP(&output, "output", "O", "", "Archive path (default: knowledge-pack-<topic>-<date>.tar.gz)")
+	cmd.Flags().StringVarP(&format, "format", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+func packImportCmd() *cobra.Command {
+	var (
+		targetValue string
+		mapFile     string
+		dryRun      bool
+		format      string
+	)
+	cmd := &cobra.Command{
+		Use:   "import <archive>",
+		Short: "Merge a knowledge pack into this repository",
+		Long: `Import a knowledge pack into the repo or personal store.
+
+Every imported record receives a new ID. Records whose summary or content hash
+matches an existing record are skipped as duplicates. Topics missing from the
+registry are registered, trigger files and fixtures are copied, and the affected
+Brains are rebuilt.
+
+Use --map to rewrite paths and globs from the source repository layout. The
+mapping file is JSON with prefix replacements:
+
+  {"mappings": [{"from": "src/api/", "to": "internal/api/"}]}`,
+		Example: `  # Preview an import
+  gsc knowledge pack import team-knowledge.tar.gz --target repo --dry-run
+
+  # Import with path rewriting
+  gsc knowledge pack import team-knowledge.tar.gz --target repo --map paths.json`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := gitsensescope.ParseTarget(targetValue)
+			if err != nil {
+				return err
+			}
+			pack, err := knowledgepkg.ReadPackFile(args[0])
+			if err != nil {
+				return err
+			}
+			opts := knowledgepkg.PackImportOptions{Target: target, DryRun: dryRun}
+			if mapFile != "" {
+				opts.PathMapping, err = knowledgepkg.LoadPathMapping(mapFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			report, err := knowledgepkg.ImportPack(pack, opts)
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case "", "table":
+				renderPackImportReport(report)
+			case "json":
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+			default:
+				return fmt.Errorf("unknown format %q (use table or json)", format)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&targetValue, "target", "", "Write target: repo or personal (required)")
+	cmd.Flags().StringVar(&mapFile, "map", "", "JSON path mapping file for rewriting paths and globs")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be imported without writing")
+	cmd.Flags().StringVarP(&format, "format", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+func renderPackImportReport(report *knowledgepkg.PackImportReport) {
+	verb := "Imported"
+	if report.DryRun {
+		verb = "Would import"
+	}
+	fmt.Printf("%s %d lesson(s), %d note(s), %d rule(s) into %s scope.\n",
+		verb, report.Imported["lessons"], report.Imported["notes"], report.Imported["rules"], report.Target)
+
+	if len(report.TopicsRegistered) > 0 {
+		fmt.Printf("Topics registered: %s\n", strings.Join(report.TopicsRegistered, ", "))
+	}
+	if len(report.TriggersWritten) > 0 {
+		fmt.Printf("Trigger files: %s\n", strings.Join(report.TriggersWritten, ", "))
+	}
+	if len(report.FixturesWritten) > 0 {
+		fmt.Printf("Fixtures: %d file(s)\n", len(report.FixturesWritten))
+	}
+	if len(report.Duplicates) > 0 {
+		fmt.Printf("\nSkipped %d duplicate(s):\n", len(report.Duplicates))
+		for _, d := range report.Duplicates {
+			fmt.Printf("  %-6s %s (matches %s by %s)\n", d.Type, truncateTopic(d.Summary, 60), d.ExistingID, d.MatchedBy)
+		}
+	}
+	for _, w := range report.Warnings {
+		fmt.Printf("  WARN %s\n", w)
+	}
+	if len(report.Rebuilt) > 0 {
+		fmt.Printf("Rebuilt: %s\n", strings.Join(report.Rebuilt, ", "))
+	}
+}
+
+func defaultPackName(topics []string) string {
+	name := "all"
+	if len(topics) > 0 {
+		name = strings.Join(topics, "+")
+	}
+	return fmt.Sprintf("knowledge-pack-%s-%s.tar.gz", name, time.Now().Format("20060102"))
+}
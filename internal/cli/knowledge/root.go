@@ -1,12 +1,12 @@
 /**
  * Component: Knowledge CLI Root Command
- * Block-UUID: a1b2c3d4-e5f6-7890-abcd-200000000005
- * Parent-UUID: N/A
- * Version: 1.1.0
- * Description: Added knowledge topics subcommand.
+ * Block-UUID: 29577bf0-3c23-4e1e-bd07-a42d2f326837
+ * Parent-UUID: 32b2ee6f-1830-41fc-9c74-cc9cab3c1036
+ * Version: 1.3.0
+ * Description: Added knowledge graph subcommand for exploring relationships among topics, records, and files.
  * Language: Go
  * Created-at: 2026-06-22T10:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0), agent (v1.2.0), agent (v1.3.0)
  */
 
 
@@ -33,6 +33,8 @@ Use 'knowledge list' to browse items in a specific topic.`,
 	cmd.AddCommand(searchCmd())
 	cmd.AddCommand(listCmd())
 	cmd.AddCommand(topicsCmd())
+	cmd.AddCommand(packCmd())
+	cmd.AddCommand(graphCmd())
 
 	return cmd
 }
@@ -1,12 +1,12 @@
 /**
  * Component: Knowledge Search Command
- * Block-UUID: a1b2c3d4-e5f6-7890-abcd-200000000006
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Implements gsc knowledge search for unified search across lessons, notes, and rules.
+ * Block-UUID: 080fa3ee-3c11-404f-9301-347ae95c74b3
+ * Parent-UUID: a1b2c3d4-e5f6-7890-abcd-200000000006
+ * Version: 1.1.0
+ * Description: Added --include-archived to surface expired and archived notes.
  * Language: Go
  * Created-at: 2026-06-22T10:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -28,6 +28,7 @@ func searchCmd() *cobra.Command {
 		limit    int
 		truncate int
 		format   string
+		archived bool
 	)
 	cmd := &cobra.Command{
 		Use:   "search <query>",
@@ -42,7 +43,8 @@ Results are ranked by relevance:
 
 Use --type to filter by entity type (lessons, notes, rules).
 Use --topic to filter by a specific topic.
-Use --limit to cap the number of results.`,
+Use --limit to cap the number of results.
+Expired and archived notes are excluded unless --include-archived is set.`,
 		Example: `  # Search all knowledge
   gsc knowledge search "manifest import performance"
 
@@ -66,6 +68,8 @@ Use --limit to cap the number of results.`,
 				Types: types,
 				Topic: topic,
 				Limit: limit,
+
+				IncludeArchived: archived,
 			}
 
 			response, err := knowledgepkg.Search(query, opts)
@@ -93,6 +97,7 @@ Use --limit to cap the number of results.`,
 	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of results (0 = all)")
 	cmd.Flags().IntVar(&truncate, "truncate", 50, "Truncate summary to N characters (0 = no truncation)")
 	cmd.Flags().StringVarP(&format, "format", "o", "table", "Output format (table, json)")
+	cmd.Flags().BoolVar(&archived, "include-archived", false, "Include expired and archived notes")
 	return cmd
 }
 
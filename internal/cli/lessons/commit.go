@@ -1,12 +1,12 @@
 /**
  * Component: Lessons Commit Command
- * Block-UUID: a512a45d-c455-4204-ad3f-396471d98cd2
- * Parent-UUID: 8d956c31-95d4-4c96-aa0b-54d6d7d24ba7
- * Version: 1.2.0
- * Description: Removed --yes flag and confirmation prompt. Commit is intentional by nature and runs unconditionally after validation.
+ * Block-UUID: 48807bb7-f00f-47e0-8763-f1cfa0961356
+ * Parent-UUID: a512a45d-c455-4204-ad3f-396471d98cd2
+ * Version: 1.3.0
+ * Description: Archives and links the source note when committing a lesson promoted from a note.
  * Language: Go
  * Created-at: 2026-06-12T12:44:13Z
- * Authors: Codex GPT-5 (v1.0.0), Codex GPT-5 (v1.1.0), claude-sonnet-4-6 (v1.2.0)
+ * Authors: Codex GPT-5 (v1.0.0), Codex GPT-5 (v1.1.0), claude-sonnet-4-6 (v1.2.0), agent (v1.3.0)
  */
 
 package lessons
@@ -16,6 +16,7 @@ import (
 
 	"github.com/gitsense/gsc-cli/internal/gitsensescope"
 	lessonspkg "github.com/gitsense/gsc-cli/internal/lessons"
+	notespkg "github.com/gitsense/gsc-cli/internal/notes"
 	"github.com/spf13/cobra"
 )
 
@@ -47,6 +48,13 @@ func commitCmd() *cobra.Command {
 			}
 			recordsPath, _ := lessonspkg.RecordsPathForTarget(target)
 			fmt.Printf("Committed lesson to %s scope: %s\n", target, record.ID)
+			if pf := record.PromotedFrom; pf != nil && pf.Type == "note" {
+				if err := archivePromotedNote(pf, record.ID); err != nil {
+					fmt.Printf("Warning: failed to archive promoted note %s: %v\n", pf.ID, err)
+				} else {
+					fmt.Printf("Archived note %s and linked it to this lesson.\n", pf.ID)
+				}
+			}
 			fmt.Println()
 			fmt.Println("The lesson is now available to future agent sessions.")
 			fmt.Println()
@@ -62,3 +70,16 @@ func commitCmd() *cobra.Command {
 	cmd.Flags().StringVar(&targetValue, "target", "", "Write target: repo or personal (required)")
 	return cmd
 }
+
+// archivePromotedNote archives the note a lesson was promoted from and records
+// the lesson ID on it, then rebuilds the notes Brain.
+func archivePromotedNote(pf *lessonspkg.PromotedFrom, lessonID string) error {
+	noteTarget, err := gitsensescope.ParseTarget(pf.Source)
+	if err != nil {
+		return err
+	}
+	if _, err := notespkg.MarkPromoted(pf.ID, noteTarget, lessonID); err != nil {
+		return err
+	}
+	return notespkg.RebuildAndImportForTarget(noteTarget)
+}
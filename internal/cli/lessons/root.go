@@ -1,12 +1,12 @@
 /**
  * Component: Lessons CLI Root Command
- * Block-UUID: cad50c17-069b-4398-a641-96e49b22cd76
- * Parent-UUID: c03980ae-2812-400e-b415-2c2e2db9d222
- * Version: 1.10.0
- * Description: Registered the add command, made the whole draft lifecycle deprecated at top level, and errored on unknown subcommands.
+ * Block-UUID: ae3d88ef-cf96-4fe6-bd03-acbb4525b654
+ * Parent-UUID: cad50c17-069b-4398-a641-96e49b22cd76
+ * Version: 1.11.0
+ * Description: Registered the verify command for running executable review checks.
  * Language: Go
  * Created-at: 2026-06-12T12:44:13Z
- * Authors: Codex GPT-5 (v1.0.0), Codex GPT-5 (v1.1.0), claude-sonnet-4-6 (v1.2.0), MiMo-v2.5-pro (v1.3.0), claude-opus-4-8 (v1.4.0), claude-opus-4-8 (v1.5.0), claude-opus-4-8 (v1.6.0), claude-opus-4-8 (v1.7.0), claude-opus-4-8 (v1.8.0), claude-opus-4-8 (v1.9.0), claude-opus-4-8 (v1.10.0)
+ * Authors: Codex GPT-5 (v1.0.0), Codex GPT-5 (v1.1.0), claude-sonnet-4-6 (v1.2.0), MiMo-v2.5-pro (v1.3.0), claude-opus-4-8 (v1.4.0), claude-opus-4-8 (v1.5.0), claude-opus-4-8 (v1.6.0), claude-opus-4-8 (v1.7.0), claude-opus-4-8 (v1.8.0), claude-opus-4-8 (v1.9.0), claude-opus-4-8 (v1.10.0), agent (v1.11.0)
  */
 
 
@@ -40,6 +40,7 @@ Knowledge is everything we could remember. Lessons are the parts worth carrying
 	cmd.AddCommand(tagsCmd())
 	cmd.AddCommand(overviewCmd())
 	cmd.AddCommand(showCmd())
+	cmd.AddCommand(verifyCmd())
 
 	// Maintenance.
 	cmd.AddCommand(deleteCmd())
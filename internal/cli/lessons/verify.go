@@ -0,0 +1,161 @@
+/**
+ * Component: Lessons Verify Command
+ * Block-UUID: b73464ef-3bd5-433b-8ccd-f771258c9463
+ * Parent-UUID: N/A
+ * Version: 1.1.0
+ * Description: Implements gsc lessons verify, which runs executable review checks and reports pass/fail per lesson so obsolete lessons can be flagged. Shell checks run only with --allow-shell.
+ * Language: Go
+ * Created-at: 2026-10-18T13:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package lessons
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	lessonspkg "github.com/gitsense/gsc-cli/internal/lessons"
+	"github.com/spf13/cobra"
+)
+
+func verifyCmd() *cobra.Command {
+	var (
+		all        bool
+		scopeValue string
+		allowShell bool
+		timeout    time.Duration
+		format     string
+	)
+	cmd := &cobra.Command{
+		Use:   "verify [lesson-id]",
+		Short: "Run executable review checks to confirm lessons still hold",
+		Long: `Run the executable review checks of one lesson (or all lessons with --all)
+against the current working tree and report pass/fail per lesson.
+
+Review checks stay free text unless they start with one of these prefixes:
+
+  sh: <command>              passes when the command exits 0 (run from the repo root;
+                             requires --allow-shell)
+  grep: <regex> [in <globs>] passes when ripgrep finds a match
+  !grep: <regex> [in <globs>] passes when ripgrep finds no match
+  exists: <path or glob>     passes when the path exists
+  !exists: <path or glob>    passes when the path is absent (alias: absent:)
+
+Globs after "in" are comma-separated. Quote the regex if it contains " in ".
+
+Shell checks are skipped unless --allow-shell is given, because lessons can
+come from imported knowledge packs. Lessons without executable checks are
+reported as unverified. The command exits non-zero when any lesson fails or a
+check errors.`,
+		Example: `  # Verify one lesson
+  gsc lessons verify lsn_01a2b3
+
+  # Verify every lesson, including shell commands
+  gsc lessons verify --all --allow-shell
+
+  # Example check: no direct registry imports in internal/cli
+  #   "!grep: \"internal/registry\" in internal/cli/**"`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if all == (len(args) == 1) {
+				return fmt.Errorf("provide a lesson ID or --all")
+			}
+			scope, err := gitsensescope.ParseScope(scopeValue)
+			if err != nil {
+				return err
+			}
+			records, err := lessonspkg.LoadRecordsFromScope(scope)
+			if err != nil {
+				return err
+			}
+			if !all {
+				sourced, err := lessonspkg.ResolveSourcedRecordFromRecords(args[0], records)
+				if err != nil {
+					return err
+				}
+				if sourced == nil {
+					return fmt.Errorf("lesson not found in %s scope: %s", scope, args[0])
+				}
+				records = []lessonspkg.SourcedLesson{*sourced}
+			}
+
+			opts := lessonspkg.VerifyOptions{Timeout: timeout, AllowShell: allowShell}
+			var results []lessonspkg.LessonVerification
+			for _, sl := range records {
+				result, err := lessonspkg.VerifyLesson(cmd.Context(), sl.Source, sl.Lesson, opts)
+				if err != nil {
+					return err
+				}
+				results = append(results, result)
+			}
+
+			counts := make(map[lessonspkg.CheckStatus]int)
+			for _, r := range results {
+				counts[r.Status]++
+			}
+
+			switch format {
+			case "", "table":
+				renderVerifyTable(results, counts)
+			case "json":
+				data, err := json.MarshalIndent(struct {
+					Lessons []lessonspkg.LessonVerification `json:"lessons"`
+					Summary map[lessonspkg.CheckStatus]int  `json:"summary"`
+				}{results, counts}, "", "  ")
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(data))
+			default:
+				return fmt.Errorf("unknown format %q (use table or json)", format)
+			}
+
+			if failed := counts[lessonspkg.StatusFail] + counts[lessonspkg.StatusError]; failed > 0 {
+				return fmt.Errorf("%d lesson(s) failed verification", failed)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&all, "all", false, "Verify every lesson in scope")
+	cmd.Flags().StringVar(&scopeValue, "scope", "all", "Read scope: all, repo, or personal")
+	cmd.Flags().BoolVar(&allowShell, "allow-shell", false, "Run sh: checks (skipped by default)")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Timeout per check")
+	cmd.Flags().StringVarP(&format, "format", "o", "table", "Output format (table, json)")
+	return cmd
+}
+
+func renderVerifyTable(results []lessonspkg.LessonVerification, counts map[lessonspkg.CheckStatus]int) {
+	if len(results) == 0 {
+		fmt.Println("No lessons found.")
+		return
+	}
+	fmt.Printf("%-10s %-8s %-40s %s\n", "STATUS", "SOURCE", "ID", "SUMMARY")
+	fmt.Printf("%-10s %-8s %-40s %s\n", "----------", "--------", "----------------------------------------", "-------")
+	for _, r := range results {
+		fmt.Printf("%-10s %-8s %-40s %s\n", r.Status, r.Source, r.ID, truncateLesson(r.Summary, 60))
+		for _, c := range r.Checks {
+			if c.Status == lessonspkg.StatusPass {
+				continue
+			}
+			line := fmt.Sprintf("  %-8s %s", c.Status, c.Check.Raw)
+			if c.Detail != "" {
+				line += " (" + c.Detail + ")"
+			}
+			fmt.Println(line)
+		}
+	}
+	fmt.Printf("\nPassed: %d, failed: %d, errors: %d, unverified: %d\n",
+		counts[lessonspkg.StatusPass], counts[lessonspkg.StatusFail],
+		counts[lessonspkg.StatusError], counts[lessonspkg.StatusUnverified])
+}
+
+func truncateLesson(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max-3] + "..."
+}
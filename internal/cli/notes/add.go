@@ -1,12 +1,12 @@
 /**
  * Component: Notes Add Command
- * Block-UUID: f2a3b4c5-d6e7-8901-fabc-012345678901
- * Parent-UUID: N/A
- * Version: 2.0.0
- * Description: Implements gsc notes add with --target flag for scoped writes.
+ * Block-UUID: ee9b9545-2f83-4ed7-a870-59ed8ffe92ff
+ * Parent-UUID: f2a3b4c5-d6e7-8901-fabc-012345678901
+ * Version: 2.1.0
+ * Description: Added --expires, --status, and --supersedes lifecycle flags; superseded notes are archived.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v2.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v2.0.0), agent (v2.1.0)
  */
 
 
@@ -48,6 +48,9 @@ func addCmd() *cobra.Command {
 		globs         []string
 		tags          []string
 		linkedFiles   []string
+		expires       string
+		status        string
+		supersedes    string
 		targetValue   string
 	)
 	cmd := &cobra.Command{
@@ -74,7 +77,15 @@ The content is validated, then committed to the notes store.`,
   gsc notes add --target repo --from-file /tmp/note.json
 
   # From stdin
-  cat note.json | gsc notes add --target personal --stdin`,
+  cat note.json | gsc notes add --target personal --stdin
+
+  # Scratch note that drops out of search after a week
+  gsc notes add --target personal --topic cli-workflow --expires 7d \
+    --summary "Investigating flaky watcher test"
+
+  # Replace an older note (the old note is archived)
+  gsc notes add --target repo --supersedes <old-id> --topic data-layer \
+    --summary "Updated migration notes"`,
 
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -122,6 +133,33 @@ The content is validated, then committed to the notes store.`,
 				}
 			}
 
+			now := time.Now().UTC()
+
+			// Lifecycle flags apply in both JSON and flag mode
+			if cmd.Flags().Changed("expires") {
+				expiresAt, err := notespkg.ParseExpiry(expires, now)
+				if err != nil {
+					return err
+				}
+				note.ExpiresAt = expiresAt
+			}
+			if cmd.Flags().Changed("status") {
+				note.Status = notespkg.Status(status)
+			}
+			if cmd.Flags().Changed("supersedes") {
+				note.Supersedes = supersedes
+			}
+			if note.Supersedes != "" {
+				superseded, err := notespkg.ResolveRecordFromTarget(note.Supersedes, target)
+				if err != nil {
+					return err
+				}
+				if superseded == nil {
+					return fmt.Errorf("superseded note not found in %s store: %s", target, note.Supersedes)
+				}
+				note.Supersedes = superseded.ID
+			}
+
 			// Validate and normalize
 			result := notespkg.ValidateAndNormalize(note)
 			if !result.Valid() {
@@ -133,7 +171,6 @@ The content is validated, then committed to the notes store.`,
 			}
 
 			// Generate ID and timestamps
-			now := time.Now().UTC()
 			id, err := notespkg.NewNoteID(now)
 			if err != nil {
 				return fmt.Errorf("failed to generate note ID: %w", err)
@@ -151,6 +188,11 @@ The content is validated, then committed to the notes store.`,
 			if err := notespkg.AppendRecordToTarget(note, target); err != nil {
 				return fmt.Errorf("failed to commit note: %w", err)
 			}
+			if note.Supersedes != "" {
+				if _, err := notespkg.ArchiveSuperseded(note, target); err != nil {
+					fmt.Printf("Warning: failed to archive superseded note %s: %v\n", note.Supersedes, err)
+				}
+			}
 
 			// Rebuild the Brain
 			if err := notespkg.RebuildAndImportForTarget(target); err != nil {
@@ -165,6 +207,12 @@ The content is validated, then committed to the notes store.`,
 			if len(note.Tags) > 0 {
 				fmt.Printf("Tags: %s\n", note.Tags)
 			}
+			if note.ExpiresAt != nil {
+				fmt.Printf("Expires: %s\n", note.ExpiresAt.Format("2006-01-02T15:04:05Z"))
+			}
+			if note.Supersedes != "" {
+				fmt.Printf("Supersedes (archived): %s\n", note.Supersedes)
+			}
 			return nil
 		},
 	}
@@ -179,6 +227,9 @@ The content is validated, then committed to the notes store.`,
 	cmd.Flags().StringArrayVar(&globs, "glob", nil, "Glob pattern (repeatable)")
 	cmd.Flags().StringArrayVar(&tags, "tag", nil, "Tag slug (repeatable)")
 	cmd.Flags().StringArrayVar(&linkedFiles, "linked-file", nil, "Repo-relative related file (repeatable)")
+	cmd.Flags().StringVar(&expires, "expires", "", "Expiry: duration (7d, 2w, 36h), date (2006-01-02), or RFC3339 time")
+	cmd.Flags().StringVar(&status, "status", "", "Lifecycle status: active, pinned, or archived (default active)")
+	cmd.Flags().StringVar(&supersedes, "supersedes", "", "ID of a note this note replaces; the old note is archived")
 	cmd.Flags().StringVar(&targetValue, "target", "", "Write target: repo or personal (required)")
 	return cmd
 }
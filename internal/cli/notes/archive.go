@@ -0,0 +1,116 @@
+/**
+ * Component: Notes Archive Command
+ * Block-UUID: 0c6a9f3e-5d27-4b8e-a1f4-92d7e3b6c815
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements gsc notes archive for archiving a single note or every expired note in a target store.
+ * Language: Go
+ * Created-at: 2026-10-18T10:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package notes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	notespkg "github.com/gitsense/gsc-cli/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+func archiveCmd() *cobra.Command {
+	var (
+		targetValue string
+		expired     bool
+		restore     bool
+		dryRun      bool
+	)
+	cmd := &cobra.Command{
+		Use:   "archive [id]",
+		Short: "Archive a note or all expired notes",
+		Long: `Archive notes so they drop out of search and 'notes get' while keeping
+their history in the notes store.
+
+Pass a note ID to archive one note, or --expired to archive every expired note.
+Use --restore to return an archived note to active status.`,
+		Example: `  # Archive one note
+  gsc notes archive note_0192 --target repo
+
+  # Archive every expired scratch note
+  gsc notes archive --expired --target personal
+
+  # Restore an archived note
+  gsc notes archive note_0192 --target repo --restore`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := gitsensescope.ParseTarget(targetValue)
+			if err != nil {
+				return err
+			}
+			if expired == (len(args) == 1) {
+				return fmt.Errorf("provide either a note ID or --expired")
+			}
+
+			if expired {
+				archived, err := notespkg.ArchiveExpiredInTarget(target, time.Now().UTC(), dryRun)
+				if err != nil {
+					return err
+				}
+				if len(archived) == 0 {
+					fmt.Printf("No expired notes in %s scope.\n", target)
+					return nil
+				}
+				verb := "Archived"
+				if dryRun {
+					verb = "Would archive"
+				}
+				fmt.Printf("%s %d expired note(s) in %s scope:\n", verb, len(archived), target)
+				fmt.Print(notespkg.RenderNotesTable(archived))
+				if !dryRun {
+					rebuildNotesBrain(target)
+				}
+				return nil
+			}
+
+			status := notespkg.StatusArchived
+			message := "Archived"
+			if restore {
+				status = notespkg.StatusActive
+				message = "Restored from archive"
+			}
+			if dryRun {
+				record, err := notespkg.ResolveRecordFromTarget(args[0], target)
+				if err != nil {
+					return err
+				}
+				if record == nil {
+					return fmt.Errorf("note not found in %s store: %s", target, args[0])
+				}
+				fmt.Printf("Would set %s to %s in %s scope.\n", record.ID, status, target)
+				return nil
+			}
+			note, err := notespkg.SetStatusInTarget(args[0], target, status, message)
+			if err != nil {
+				return err
+			}
+			rebuildNotesBrain(target)
+			fmt.Printf("Note %s is now %s in %s scope.\n", note.ID, note.EffectiveStatus(), target)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&targetValue, "target", "", "Write target: repo or personal (required)")
+	cmd.Flags().BoolVar(&expired, "expired", false, "Archive every expired note")
+	cmd.Flags().BoolVar(&restore, "restore", false, "Restore an archived note to active")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would change without writing")
+	return cmd
+}
+
+// rebuildNotesBrain rebuilds the notes Brain after a lifecycle change, warning on failure.
+func rebuildNotesBrain(target gitsensescope.Target) {
+	if err := notespkg.RebuildAndImportForTarget(target); err != nil {
+		fmt.Printf("Warning: failed to rebuild Brain: %v\n", err)
+	}
+}
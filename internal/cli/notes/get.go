@@ -1,12 +1,12 @@
 /**
  * Component: Notes Get Command
- * Block-UUID: c5d6e7f8-a9b0-1234-cdef-456789012345
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Implements gsc notes get, the agent-facing command for querying notes by file, glob, or tag.
+ * Block-UUID: 81d928c3-2bf2-4a60-a28e-dc6bbb73a9f2
+ * Parent-UUID: c5d6e7f8-a9b0-1234-cdef-456789012345
+ * Version: 1.1.0
+ * Description: Excludes expired and archived notes by default; added --include-archived.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), agent (v1.1.0)
  */
 
 package notes
@@ -16,6 +16,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	gitpkg "github.com/gitsense/gsc-cli/internal/git"
 	"github.com/gitsense/gsc-cli/internal/gitsensescope"
@@ -25,11 +26,12 @@ import (
 
 func getCmd() *cobra.Command {
 	var (
-		file       string
-		glob       string
-		tag        string
-		format     string
-		scopeValue string
+		file            string
+		glob            string
+		tag             string
+		format          string
+		scopeValue      string
+		includeArchived bool
 	)
 	cmd := &cobra.Command{
 		Use:   "get",
@@ -37,6 +39,7 @@ func getCmd() *cobra.Command {
 		Long: `Query notes that match a specific file, glob pattern, or tag.
 
 This is the primary command for coding agents to check for notes before modifying files.
+Expired and archived notes are excluded unless --include-archived is set.
 
 Exit codes:
   0 - Lookup succeeded (including "no notes found")
@@ -91,6 +94,9 @@ Exit codes:
 			if loadErr != nil {
 				return fmt.Errorf("failed to load notes: %w", loadErr)
 			}
+			if !includeArchived {
+				records = notespkg.FilterLiveSourced(records, time.Now().UTC())
+			}
 
 			var sourcedMatched []notespkg.SourcedMatchedNote
 			queryType := ""
@@ -123,6 +129,7 @@ Exit codes:
 	cmd.Flags().StringVar(&tag, "tag", "", "Tag to query")
 	cmd.Flags().StringVar(&scopeValue, "scope", "all", "Read scope: all, repo, or personal")
 	cmd.Flags().StringVarP(&format, "format", "o", "human", "Output format (human, json)")
+	cmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Include expired and archived notes")
 	return cmd
 }
 
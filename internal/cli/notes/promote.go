@@ -0,0 +1,116 @@
+/**
+ * Component: Notes Promote Command
+ * Block-UUID: e2a7c94d-1f38-4b6e-9c05-7a3d8b1f62e0
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements gsc notes promote, which stages a lesson draft from a note and links the two so the note is archived with history once the lesson is committed.
+ * Language: Go
+ * Created-at: 2026-10-18T10:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package notes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	lessonspkg "github.com/gitsense/gsc-cli/internal/lessons"
+	notespkg "github.com/gitsense/gsc-cli/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+func promoteCmd() *cobra.Command {
+	var (
+		targetValue string
+		replace     bool
+	)
+	cmd := &cobra.Command{
+		Use:   "promote <id>",
+		Short: "Turn a note into a lesson draft",
+		Long: `Stage a lesson draft from a note.
+
+The draft copies the note's summary, content, topics, tags, importance, and
+linked files, and records the note it came from. Review and commit the draft
+with the usual lessons workflow. When the lesson is committed, the note is
+archived and linked to the new lesson so its history is preserved.`,
+		Example: `  # Promote a note, then review and commit the lesson
+  gsc notes promote note_0192 --target repo
+  gsc lessons draft validate
+  gsc lessons draft commit --target repo`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := gitsensescope.ParseTarget(targetValue)
+			if err != nil {
+				return err
+			}
+			note, err := notespkg.ResolveRecordFromTarget(args[0], target)
+			if err != nil {
+				return err
+			}
+			if note == nil {
+				return fmt.Errorf("note not found in %s store: %s", target, args[0])
+			}
+			if note.PromotedTo != "" {
+				return fmt.Errorf("note %s was already promoted to lesson %s", note.ID, note.PromotedTo)
+			}
+
+			draft := lessonspkg.Draft{
+				Summary:       note.Summary,
+				Details:       note.Content,
+				Topic:         note.Topic,
+				RelatedTopics: note.RelatedTopics,
+				AppliesTo: lessonspkg.AppliesTo{
+					Files:       nonNil(note.LinkedFiles),
+					LinkedFiles: []string{},
+					Commands:    []string{},
+				},
+				Tags:         nonNil(note.Tags),
+				Importance:   note.Importance,
+				ReviewChecks: []string{},
+				AI: lessonspkg.AIProvenance{
+					Provider: "unknown",
+					ModelID:  "unknown",
+					Agent:    "unknown",
+				},
+				PromotedFrom: &lessonspkg.PromotedFrom{
+					Type:      "note",
+					ID:        note.ID,
+					Source:    string(target),
+					CreatedAt: time.Now().UTC(),
+				},
+			}
+			if draft.Importance == "" {
+				draft.Importance = "medium"
+			}
+
+			path, err := lessonspkg.WriteDraft(draft, replace)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Lesson draft created from note %s:\n  %s\n\n", note.ID, path)
+			if len(note.GlobPatterns) > 0 {
+				fmt.Printf("The note matched globs %v; add concrete files to applies_to.files if needed.\n\n", note.GlobPatterns)
+			}
+			fmt.Println("Next actions:")
+			fmt.Println("  gsc lessons draft validate")
+			fmt.Println("  gsc lessons draft review")
+			fmt.Println("  gsc lessons draft commit --target <repo|personal>")
+			fmt.Println()
+			fmt.Println("The note is archived and linked to the lesson when the draft is committed.")
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&targetValue, "target", "", "Store the note lives in: repo or personal (required)")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Replace an existing lesson draft")
+	return cmd
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
@@ -0,0 +1,86 @@
+/**
+ * Component: Notes Prune Command
+ * Block-UUID: 4b8e2d71-93c6-4f5a-8e0b-c1d2a7f69e34
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements gsc notes prune for permanently removing expired and, optionally, archived notes.
+ * Language: Go
+ * Created-at: 2026-10-18T10:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package notes
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	notespkg "github.com/gitsense/gsc-cli/internal/notes"
+	"github.com/spf13/cobra"
+)
+
+func pruneCmd() *cobra.Command {
+	var (
+		targetValue    string
+		archived       bool
+		archivedBefore string
+		dryRun         bool
+	)
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Permanently remove expired notes",
+		Long: `Remove expired notes from the notes store.
+
+Use --archived to also remove archived notes, optionally only those untouched
+for longer than --archived-before (e.g. 30d). Pinned notes and notes promoted
+to lessons are never pruned. Use 'gsc notes archive --expired' instead to keep
+expired notes for history.`,
+		Example: `  # Preview what would be pruned
+  gsc notes prune --target personal --dry-run
+
+  # Remove expired notes and archived notes older than 90 days
+  gsc notes prune --target repo --archived --archived-before 90d`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			target, err := gitsensescope.ParseTarget(targetValue)
+			if err != nil {
+				return err
+			}
+			now := time.Now().UTC()
+			opts := notespkg.PruneOptions{Archived: archived, DryRun: dryRun}
+			if archivedBefore != "" {
+				cutoff, err := notespkg.ParseExpiry(archivedBefore, now)
+				if err != nil || cutoff == nil {
+					return fmt.Errorf("invalid --archived-before %q: use a duration such as 30d", archivedBefore)
+				}
+				opts.Archived = true
+				opts.ArchivedBefore = cutoff.Sub(now)
+			}
+
+			removed, err := notespkg.PruneTarget(target, now, opts)
+			if err != nil {
+				return err
+			}
+			if len(removed) == 0 {
+				fmt.Printf("Nothing to prune in %s scope.\n", target)
+				return nil
+			}
+			verb := "Pruned"
+			if dryRun {
+				verb = "Would prune"
+			}
+			fmt.Printf("%s %d note(s) from %s scope:\n", verb, len(removed), target)
+			fmt.Print(notespkg.RenderNotesTable(removed))
+			if !dryRun {
+				rebuildNotesBrain(target)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&targetValue, "target", "", "Write target: repo or personal (required)")
+	cmd.Flags().BoolVar(&archived, "archived", false, "Also remove archived notes")
+	cmd.Flags().StringVar(&archivedBefore, "archived-before", "", "Only remove archived notes untouched for this long (implies --archived)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show what would be removed without writing")
+	return cmd
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Notes CLI Root Command
- * Block-UUID: e1f2a3b4-c5d6-7890-efab-901234567890
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Registers the notes command group with add, update, delete, get, list, search, tags, overview, show, and build subcommands.
+ * Block-UUID: 821c12e0-d905-4ea4-bcc3-4da1c57f0f2b
+ * Parent-UUID: e1f2a3b4-c5d6-7890-efab-901234567890
+ * Version: 1.1.0
+ * Description: Registered note lifecycle commands: archive, prune, and promote.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -37,6 +37,11 @@ agents understand the codebase. Unlike rules (guardrails) and lessons
 	cmd.AddCommand(deleteCmd())
 	cmd.AddCommand(showCmd())
 
+	// Lifecycle
+	cmd.AddCommand(archiveCmd())
+	cmd.AddCommand(pruneCmd())
+	cmd.AddCommand(promoteCmd())
+
 	// Agent-facing
 	cmd.AddCommand(getCmd())
 
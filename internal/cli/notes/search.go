@@ -1,18 +1,19 @@
 /**
  * Component: Notes Search Command
- * Block-UUID: e7f8a9b0-c1d2-3456-efab-678901234567
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Implements gsc notes search for full-text search across note fields.
+ * Block-UUID: 5c1a747b-1809-46ea-b002-ca2d12ff8656
+ * Parent-UUID: e7f8a9b0-c1d2-3456-efab-678901234567
+ * Version: 1.1.0
+ * Description: Excludes expired and archived notes by default; added --include-archived.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), agent (v1.1.0)
  */
 
 package notes
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gitsense/gsc-cli/internal/gitsensescope"
 	notespkg "github.com/gitsense/gsc-cli/internal/notes"
@@ -21,13 +22,17 @@ import (
 
 func searchCmd() *cobra.Command {
 	var (
-		format     string
-		limit      int
-		scopeValue string
+		format          string
+		limit           int
+		scopeValue      string
+		includeArchived bool
 	)
 	cmd := &cobra.Command{
-		Use:          "search <query>",
-		Short:        "Search notes by text",
+		Use:   "search <query>",
+		Short: "Search notes by text",
+		Long: `Search notes by text in summary, content, tags, and keywords.
+
+Expired and archived notes are excluded unless --include-archived is set.`,
 		Args:         cobra.ExactArgs(1),
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -41,6 +46,9 @@ func searchCmd() *cobra.Command {
 			if err != nil {
 				return err
 			}
+			if !includeArchived {
+				sourcedRecords = notespkg.FilterLiveSourced(sourcedRecords, time.Now().UTC())
+			}
 			matched := notespkg.SearchSourcedRecords(sourcedRecords, args[0])
 			if limit > 0 && len(matched) > limit {
 				matched = matched[:limit]
@@ -60,5 +68,6 @@ func searchCmd() *cobra.Command {
 	cmd.Flags().StringVar(&scopeValue, "scope", "all", "Read scope: all, repo, or personal")
 	cmd.Flags().StringVarP(&format, "format", "o", "table", "Output format (table, json)")
 	cmd.Flags().IntVar(&limit, "limit", 0, "Maximum number of notes to return (0 = all)")
+	cmd.Flags().BoolVar(&includeArchived, "include-archived", false, "Include expired and archived notes")
 	return cmd
 }
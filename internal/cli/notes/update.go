@@ -1,12 +1,12 @@
 /**
  * Component: Notes Update Command
- * Block-UUID: a3b4c5d6-e7f8-9012-abcd-123456789012
- * Parent-UUID: N/A
- * Version: 2.0.0
- * Description: Implements gsc notes update with --target flag for scoped writes.
+ * Block-UUID: 91efc2ea-785b-43a0-8cc6-cf124bd1ed2c
+ * Parent-UUID: a3b4c5d6-e7f8-9012-abcd-123456789012
+ * Version: 2.1.0
+ * Description: Added --expires, --status, and --supersedes lifecycle flags; preserves note history and records status changes.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v2.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v2.0.0), agent (v2.1.0)
  */
 
 
@@ -37,6 +37,9 @@ func updateCmd() *cobra.Command {
 		linkedFiles   []string
 		topic         string
 		relatedTopics []string
+		expires       string
+		status        string
+		supersedes    string
 		targetValue   string
 	)
 	cmd := &cobra.Command{
@@ -58,7 +61,13 @@ The note is validated, then the existing note is replaced.`,
   gsc notes update --target repo --id <id> --from-file /tmp/note.json
 
   # Update a note's content
-  gsc notes update --target personal --id <id> --content "Updated content"`,
+  gsc notes update --target personal --id <id> --content "Updated content"
+
+  # Pin a note so it never expires
+  gsc notes update --target repo --id <id> --status pinned
+
+  # Clear a note's expiry
+  gsc notes update --target personal --id <id> --expires never`,
 
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -126,13 +135,37 @@ The note is validated, then the existing note is replaced.`,
 				}
 			}
 
-			// Preserve identity
+			// Preserve identity and history
 			note.ID = existing.ID
 			note.SchemaVersion = existing.SchemaVersion
 			note.CreatedAt = existing.CreatedAt
+			note.PromotedTo = existing.PromotedTo
+			note.Changelog = existing.Changelog
 
 			// Update timestamp
-			note.UpdatedAt = time.Now().UTC()
+			now := time.Now().UTC()
+			note.UpdatedAt = now
+
+			// Lifecycle flags apply in both JSON and flag mode
+			if cmd.Flags().Changed("expires") {
+				expiresAt, err := notespkg.ParseExpiry(expires, now)
+				if err != nil {
+					return err
+				}
+				note.ExpiresAt = expiresAt
+			}
+			if cmd.Flags().Changed("status") {
+				note.Status = notespkg.Status(status)
+			}
+			if cmd.Flags().Changed("supersedes") {
+				note.Supersedes = supersedes
+			}
+			if note.EffectiveStatus() != existing.EffectiveStatus() {
+				note.Changelog = append(note.Changelog, notespkg.ChangelogEntry{
+					Timestamp: now,
+					Message:   fmt.Sprintf("Status changed from %s to %s", existing.EffectiveStatus(), note.EffectiveStatus()),
+				})
+			}
 
 			// Validate and normalize
 			result := notespkg.ValidateAndNormalize(note)
@@ -195,6 +228,9 @@ The note is validated, then the existing note is replaced.`,
 	cmd.Flags().StringArrayVar(&linkedFiles, "linked-file", nil, "Repo-relative related file (repeatable)")
 	cmd.Flags().StringVar(&topic, "topic", "", "Primary topic slug")
 	cmd.Flags().StringArrayVar(&relatedTopics, "related-topic", nil, "Related topic slug (max 2, repeatable)")
+	cmd.Flags().StringVar(&expires, "expires", "", "Expiry: duration (7d, 2w, 36h), date (2006-01-02), RFC3339 time, or never")
+	cmd.Flags().StringVar(&status, "status", "", "Lifecycle status: active, pinned, or archived")
+	cmd.Flags().StringVar(&supersedes, "supersedes", "", "ID of a note this note replaces")
 	cmd.Flags().StringVar(&targetValue, "target", "", "Write target: repo or personal (required)")
 	return cmd
 }
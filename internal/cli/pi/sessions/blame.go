@@ -0,0 +1,181 @@
+/**
+ * Component: Pi Sessions Blame Command
+ * Block-UUID: 2e8a5f61-0c37-4b9d-8d14-7f6b3a9c5e20
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements gsc pi sessions blame <file>, printing the agent activity timeline for a file with prompts, tool arguments, and matched git commits, or a per-line attribution with --lines.
+ * Language: Go
+ * Created-at: 2026-10-18T16:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	pisessions "github.com/gitsense/gsc-cli/internal/pi/sessions"
+	"github.com/spf13/cobra"
+)
+
+func blameCmd() *cobra.Command {
+	var options pisessions.BlameOptions
+	var dbPath string
+	var noCommits bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "blame <file>",
+		Short: "Show which agent sessions read or modified a file",
+		Long: `Show the agent activity history of a file from the sessions mirror: every
+session that read, edited, or wrote it, the prompt that led to each tool call,
+the tool-call arguments, and the git commits that followed each edit.
+
+A commit is listed under an edit when it touched the file within --window after
+the edit. It is marked "content" when it also added lines the edit wrote, and
+"time" when only the timing matches.
+
+With --lines, edits are mapped onto the file's current lines: each range is
+attributed to the latest edit whose written text still appears there.`,
+		Example: `  # Timeline for a file
+  gsc pi sessions blame internal/cli/root.go
+
+  # Which session wrote each part of the current file
+  gsc pi sessions blame internal/cli/root.go --lines`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := pisessions.ValidateRuntime(options.Runtime); err != nil {
+				return err
+			}
+			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
+			if err != nil {
+				return err
+			}
+			absPath, repoRoot, rel, err := pisessions.ResolveBlameTarget(args[0])
+			if err != nil {
+				return err
+			}
+			options.DBPath = resolvedDB
+			options.AbsPath = absPath
+			options.RepoRoot = repoRoot
+			options.FilePathRel = rel
+			options.Commits = !noCommits
+
+			result, err := pisessions.Blame(cmd.Context(), options)
+			if err != nil {
+				return err
+			}
+			return writeBlameResult(os.Stdout, result, format, options.Lines)
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
+	cmd.Flags().StringVar(&options.Runtime, "runtime", "", "Agent runtime filter (pi, claude)")
+	cmd.Flags().StringVar(&options.Since, "since", "", "Inclusive lower timestamp bound")
+	cmd.Flags().StringVar(&options.Until, "until", "", "Inclusive upper timestamp bound")
+	cmd.Flags().BoolVar(&options.EditsOnly, "edits-only", false, "Hide reads; show only edits and writes")
+	cmd.Flags().BoolVar(&noCommits, "no-commits", false, "Skip matching git commits")
+	cmd.Flags().DurationVar(&options.CommitWindow, "window", pisessions.DefaultBlameCommitWindow, "How long after an edit a commit can capture it")
+	cmd.Flags().BoolVar(&options.Lines, "lines", false, "Map edits onto current line ranges")
+	cmd.Flags().IntVar(&options.Limit, "limit", 0, "Show only the most recent N events (0 for all)")
+	cmd.Flags().StringVarP(&format, "format", "o", "human", "Output format: human, json")
+	return cmd
+}
+
+func writeBlameResult(w io.Writer, result *pisessions.BlameResult, format string, lines bool) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case "human", "":
+		if lines {
+			writeBlameLinesHuman(w, result)
+			return nil
+		}
+		writeBlameHuman(w, result)
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+func writeBlameHuman(w io.Writer, result *pisessions.BlameResult) {
+	fmt.Fprintf(w, "File: %s\n", homeRelative(result.AbsPath))
+	if len(result.Events) == 0 {
+		fmt.Fprintln(w, "No agent activity recorded for this file.")
+		return
+	}
+	sessions := map[string]bool{}
+	for _, event := range result.Events {
+		sessions[event.SessionID] = true
+	}
+	fmt.Fprintf(w, "%d event(s) across %d session(s)\n", len(result.Events), len(sessions))
+
+	lastSession := ""
+	for _, event := range result.Events {
+		if event.SessionID != lastSession {
+			fmt.Fprintf(w, "\n%s  [%s]  %s\n", shortSessionID(event.SessionID), event.Runtime, truncate(event.SessionTitle, 70))
+			lastSession = event.SessionID
+		}
+		status := ""
+		if event.IsError {
+			status = "  (failed)"
+		}
+		if event.Confidence == "heuristic" {
+			status += "  (heuristic)"
+		}
+		fmt.Fprintf(w, "  %s  %-5s  %s%s\n", formatBlameTime(event.Timestamp), event.Op, event.ToolName, status)
+		if event.Prompt != "" && event.Op != "read" {
+			fmt.Fprintf(w, "      prompt: %s\n", truncate(oneLine(event.Prompt), 100))
+		}
+		if event.ArgumentsJSON != "" && event.Op != "read" {
+			fmt.Fprintf(w, "      args:   %s\n", truncate(oneLine(event.ArgumentsJSON), 100))
+		}
+		for _, commit := range event.Commits {
+			fmt.Fprintf(w, "      commit: %s %s (%s)\n", shortHash(commit.Hash), truncate(commit.Subject, 60), commit.Match)
+		}
+	}
+}
+
+func writeBlameLinesHuman(w io.Writer, result *pisessions.BlameResult) {
+	fmt.Fprintf(w, "File: %s\n", homeRelative(result.AbsPath))
+	if len(result.Lines) == 0 {
+		fmt.Fprintln(w, "No current lines could be attributed to an agent edit.")
+	}
+	for _, r := range result.Lines {
+		span := fmt.Sprintf("%d", r.Start)
+		if r.End != r.Start {
+			span = fmt.Sprintf("%d-%d", r.Start, r.End)
+		}
+		fmt.Fprintf(w, "%9s  %s  %s  %-9s  %s\n", span, shortSessionID(r.SessionID), formatBlameTime(r.Timestamp),
+			r.ToolName, truncate(oneLine(r.Prompt), 70))
+	}
+	if result.UnplacedEdits > 0 {
+		fmt.Fprintf(w, "\n%d edit(s) wrote text that is no longer in the file.\n", result.UnplacedEdits)
+	}
+}
+
+func formatBlameTime(ts string) string {
+	t, err := time.Parse(time.RFC3339, ts)
+	if err != nil {
+		return ts
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 10 {
+		return hash[:10]
+	}
+	return hash
+}
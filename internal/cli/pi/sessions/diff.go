@@ -0,0 +1,234 @@
+/**
+ * Component: Pi Sessions Diff Command
+ * Block-UUID: 1d6a8f23-5c4e-4b97-8a30-9e2f7b5c1d84
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements gsc pi sessions diff <a> <b>, comparing two sessions or session@leaf branches side by side from their common ancestor.
+ * Language: Go
+ * Created-at: 2026-10-18T19:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	pisessions "github.com/gitsense/gsc-cli/internal/pi/sessions"
+	"github.com/spf13/cobra"
+)
+
+func diffCmd() *cobra.Command {
+	var options pisessions.DiffOptions
+	var dbPath string
+	var pricingPath string
+	var showEdits bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "diff <a> <b>",
+		Short: "Compare two sessions or two branches of a session",
+		Long: `Compare two attempts at a task side by side. Each argument is a session id,
+optionally followed by @<leaf-entry-id> to pick a branch; without a leaf the
+session's current branch is used.
+
+The branches are aligned at their common ancestor (the entries they share,
+as branches of one session and forked sessions do), and everything after it
+is compared: prompts and replies turn by turn, tool calls and errors, tokens
+and estimated cost, and the files each side touched with whether the final
+edits to each file match.`,
+		Example: `  # Two branches of one session
+  gsc pi sessions diff 019edbc4-8752-...@a1b2c3d4 019edbc4-8752-...@e5f6a7b8
+
+  # Two sessions that tried the same task with different models
+  gsc pi sessions diff 019edbc4-8752-... 019edc01-1f00-... --show-edits`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
+			if err != nil {
+				return err
+			}
+			resolvedPricing, err := resolvePricingPath(pricingPath)
+			if err != nil {
+				return err
+			}
+			pricing, err := pisessions.LoadPricingTable(resolvedPricing)
+			if err != nil {
+				return err
+			}
+			options.DBPath = resolvedDB
+			options.Pricing = pricing
+			options.A, options.B = args[0], args[1]
+
+			result, err := pisessions.Diff(cmd.Context(), options)
+			if err != nil {
+				return err
+			}
+			return writeDiffResult(os.Stdout, result, format, showEdits)
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
+	cmd.Flags().StringVar(&pricingPath, "pricing", "", "Pricing table path (default: GSC_HOME/data/pi/pricing.json)")
+	cmd.Flags().BoolVar(&showEdits, "show-edits", false, "Print the final edit diff for files that differ")
+	cmd.Flags().IntVar(&options.MaxDiffLines, "max-diff-lines", 40, "Truncate each final edit diff to N lines (0 for no limit)")
+	cmd.Flags().StringVarP(&format, "format", "o", "human", "Output format: human, json")
+	return cmd
+}
+
+func writeDiffResult(w io.Writer, result *pisessions.SessionDiff, format string, showEdits bool) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case "human", "":
+		writeDiffHuman(w, result, showEdits)
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+func writeDiffHuman(w io.Writer, r *pisessions.SessionDiff, showEdits bool) {
+	a, b := r.A, r.B
+	if r.CommonAncestor != "" {
+		fmt.Fprintf(w, "Common ancestor: %s (%d shared entries)\n\n", r.CommonAncestor, r.SharedEntries)
+	} else {
+		fmt.Fprintln(w, "Common ancestor: none (comparing whole branches)")
+		fmt.Fprintln(w)
+	}
+
+	row := func(label, left, right string) {
+		fmt.Fprintf(w, "%-18s %-30s %s\n", label, truncate(left, 30), truncate(right, 40))
+	}
+	row("", "A", "B")
+	row("Session", shortSessionID(a.SessionID)+" ["+a.Runtime+"]", shortSessionID(b.SessionID)+" ["+b.Runtime+"]")
+	row("Leaf", a.Leaf, b.Leaf)
+	row("Title", a.Title, b.Title)
+	row("Models", strings.Join(a.Models, ", "), strings.Join(b.Models, ", "))
+	row("Prompts", fmt.Sprint(a.Prompts), fmt.Sprint(b.Prompts))
+	row("Entries", fmt.Sprint(a.Entries), fmt.Sprint(b.Entries))
+	row("Tool calls", fmt.Sprint(a.ToolCalls), fmt.Sprint(b.ToolCalls))
+	row("Errors", fmt.Sprint(a.Errors), fmt.Sprint(b.Errors))
+	row("Tokens", formatTokenCount(a.Usage.TotalTokens), formatTokenCount(b.Usage.TotalTokens))
+	row("  input/output", formatTokenCount(a.Usage.InputTokens)+" / "+formatTokenCount(a.Usage.OutputTokens),
+		formatTokenCount(b.Usage.InputTokens)+" / "+formatTokenCount(b.Usage.OutputTokens))
+	row("Cost", formatUsageCost(a.Usage.Cost, r.Currency), formatUsageCost(b.Usage.Cost, r.Currency))
+	row("Duration", diffDuration(a.Started, a.Ended), diffDuration(b.Started, b.Ended))
+
+	names := map[string]int{}
+	for name, count := range a.ToolCallsByName {
+		names[name] += count
+	}
+	for name, count := range b.ToolCallsByName {
+		names[name] += count
+	}
+	if len(names) > 0 {
+		fmt.Fprintln(w, "\nTool calls by name:")
+		for _, name := range sortedFindingNames(names) {
+			row("  "+name, fmt.Sprint(a.ToolCallsByName[name]), fmt.Sprint(b.ToolCallsByName[name]))
+		}
+	}
+
+	if len(r.Files) > 0 {
+		fmt.Fprintln(w, "\nFiles (reads/edits/writes):")
+		for _, file := range r.Files {
+			fmt.Fprintf(w, "  %-44s %-9s %-9s %s\n", truncate(file.Path, 44), diffFileCounts(file.A), diffFileCounts(file.B), diffFileStatus(file))
+		}
+	}
+
+	turns := len(a.Turns)
+	if len(b.Turns) > turns {
+		turns = len(b.Turns)
+	}
+	if turns > 0 {
+		fmt.Fprintln(w, "\nTurns:")
+		for i := 0; i < turns; i++ {
+			fmt.Fprintf(w, "  %d.\n", i+1)
+			writeDiffTurn(w, "A", a.Turns, i)
+			writeDiffTurn(w, "B", b.Turns, i)
+		}
+	}
+
+	if showEdits {
+		for _, file := range r.Files {
+			if file.SameFinal || (file.A == nil || file.A.FinalEdit == "") && (file.B == nil || file.B.FinalEdit == "") {
+				continue
+			}
+			fmt.Fprintf(w, "\n=== %s\n", file.Path)
+			for _, side := range []struct {
+				label string
+				file  *pisessions.DiffFileSide
+			}{{"A", file.A}, {"B", file.B}} {
+				if side.file == nil || side.file.FinalEdit == "" {
+					fmt.Fprintf(w, "--- %s: no edit\n", side.label)
+					continue
+				}
+				fmt.Fprintf(w, "--- %s final edit\n%s", side.label, side.file.FinalEdit)
+			}
+		}
+	}
+}
+
+func writeDiffTurn(w io.Writer, label string, turns []pisessions.DiffTurn, i int) {
+	if i >= len(turns) {
+		fmt.Fprintf(w, "     %s  -\n", label)
+		return
+	}
+	turn := turns[i]
+	prompt := "(continued)"
+	if turn.Prompt != "" {
+		prompt = truncate(oneLine(turn.Prompt), 80)
+	}
+	fmt.Fprintf(w, "     %s  %s\n", label, prompt)
+	detail := fmt.Sprintf("%d tool call(s)", turn.ToolCalls)
+	if turn.Errors > 0 {
+		detail += fmt.Sprintf(", %d failed", turn.Errors)
+	}
+	if len(turn.Edited) > 0 {
+		detail += ", edited " + strings.Join(turn.Edited, ", ")
+	}
+	fmt.Fprintf(w, "        %s\n", truncate(detail, 100))
+	if turn.Reply != "" {
+		fmt.Fprintf(w, "        > %s\n", truncate(oneLine(turn.Reply), 96))
+	}
+}
+
+func diffFileCounts(side *pisessions.DiffFileSide) string {
+	if side == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d/%d/%d", side.Reads, side.Edits, side.Writes)
+}
+
+func diffFileStatus(file pisessions.DiffFile) string {
+	editedA := file.A != nil && file.A.Edits+file.A.Writes > 0
+	editedB := file.B != nil && file.B.Edits+file.B.Writes > 0
+	switch {
+	case file.SameFinal:
+		return "same final edit"
+	case editedA && editedB:
+		return "final edits differ"
+	case editedA:
+		return "edited only in A"
+	case editedB:
+		return "edited only in B"
+	default:
+		return ""
+	}
+}
+
+func diffDuration(start, end string) string {
+	s, err1 := time.Parse(time.RFC3339, start)
+	e, err2 := time.Parse(time.RFC3339, end)
+	if err1 != nil || err2 != nil {
+		return "-"
+	}
+	return e.Sub(s).Round(time.Second).String()
+}
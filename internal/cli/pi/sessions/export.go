@@ -0,0 +1,123 @@
+/**
+ * Component: Pi Sessions Export Command
+ * Block-UUID: 3f7d1a96-2b84-4e5c-9c07-6a8e4d2b1f35
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements gsc pi sessions export <id>, writing a session branch as self-contained Markdown, HTML, or JSON with redaction and truncation options.
+ * Language: Go
+ * Created-at: 2026-10-18T18:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	pisessions "github.com/gitsense/gsc-cli/internal/pi/sessions"
+	"github.com/spf13/cobra"
+)
+
+func exportCmd() *cobra.Command {
+	var options pisessions.ExportOptions
+	var dbPath string
+	var configPath string
+	var noRedact bool
+	var outPath string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "export <session-id>",
+		Short: "Export a session branch as Markdown, HTML, or JSON",
+		Long: `Export the active branch of a session as a transcript you can share or
+attach to a pull request.
+
+Markdown and HTML output include user and assistant messages, each tool call
+as a collapsible block with its arguments and output, a diff for edit and
+write calls, and a summary of the files the branch touched. The HTML page is
+self-contained.
+
+The branch ends at the session's current leaf unless --leaf names another
+entry. Secrets are scrubbed with the same detectors as gsc pi sessions redact
+(including GSC_HOME/data/pi/redact.json) unless --no-redact is given.`,
+		Example: `  # Markdown on stdout
+  gsc pi sessions export 019edbc4-8752-...
+
+  # Self-contained HTML of an earlier branch
+  gsc pi sessions export 019edbc4-8752-... --leaf 7f3a2c1d -o html --out transcript.html`,
+		SilenceUsage: true,
+		Args:         cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "markdown" && format != "md" && format != "html" && format != "json" {
+				return fmt.Errorf("unsupported output format %q (use markdown, html, or json)", format)
+			}
+			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
+			if err != nil {
+				return err
+			}
+			if !noRedact {
+				resolvedConfig, err := resolveRedactConfigPath(configPath)
+				if err != nil {
+					return err
+				}
+				config, err := pisessions.LoadRedactionConfig(resolvedConfig)
+				if err != nil {
+					return err
+				}
+				if options.Detectors, err = pisessions.CompileRedactionDetectors(config); err != nil {
+					return err
+				}
+			}
+			options.DBPath = resolvedDB
+			options.SessionID = args[0]
+
+			transcript, err := pisessions.Export(cmd.Context(), options)
+			if err != nil {
+				return err
+			}
+
+			var w io.Writer = os.Stdout
+			if outPath != "" {
+				file, err := os.Create(outPath)
+				if err != nil {
+					return fmt.Errorf("create %s: %w", outPath, err)
+				}
+				defer file.Close()
+				w = file
+			}
+			if err := writeExport(w, transcript, format); err != nil {
+				return err
+			}
+			if outPath != "" {
+				fmt.Fprintf(os.Stderr, "Wrote %d entries to %s\n", len(transcript.Entries), outPath)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
+	cmd.Flags().StringVar(&options.LeafID, "leaf", "", "Entry ID that ends the exported branch (default: current leaf)")
+	cmd.Flags().BoolVar(&options.IncludeThinking, "thinking", false, "Include assistant thinking blocks")
+	cmd.Flags().BoolVar(&noRedact, "no-redact", false, "Do not scrub secrets from the export")
+	cmd.Flags().StringVar(&configPath, "redact-config", "", "Detector config path (default: GSC_HOME/data/pi/redact.json)")
+	cmd.Flags().IntVar(&options.MaxOutput, "max-output", 4000, "Truncate each tool output to N characters (0 for no limit)")
+	cmd.Flags().IntVar(&options.MaxDiffLines, "max-diff-lines", 200, "Truncate each edit diff to N lines (0 for no limit)")
+	cmd.Flags().StringVar(&outPath, "out", "", "Write to this file instead of stdout")
+	cmd.Flags().StringVarP(&format, "format", "o", "markdown", "Output format: markdown, html, json")
+	return cmd
+}
+
+func writeExport(w io.Writer, transcript *pisessions.ExportTranscript, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(transcript)
+	case "html":
+		return pisessions.RenderExportHTML(w, transcript)
+	default:
+		return pisessions.RenderExportMarkdown(w, transcript)
+	}
+}
@@ -0,0 +1,201 @@
+/**
+ * Component: Pi Sessions GC Command
+ * Block-UUID: 2e7a4c91-8b3d-4f06-9d52-6a1c8e5f3b27
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements gsc pi sessions gc, archiving sessions past a retention age into compressed per-session bundles (honouring repo, keep-named, and keep-with-lessons rules) and compacting the mirror database.
+ * Language: Go
+ * Created-at: 2026-10-18T20:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	pisessions "github.com/gitsense/gsc-cli/internal/pi/sessions"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+func gcCmd() *cobra.Command {
+	var options pisessions.GCOptions
+	var dbPath string
+	var archiveDir string
+	var olderThan string
+	var noVacuum bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "gc",
+		Short: "Archive old sessions and compact the mirror",
+		Long: `Apply retention rules to the sessions mirror. Sessions whose last message is
+older than --older-than are archived: their raw transcript lines move into a
+compressed bundle in GSC_HOME/data/pi/archive/<session-id>.jsonl.gz and are
+dropped from the database. Everything derived from them stays queryable:
+list, show, query (full-text search), tool-calls, files, blame, and export.
+
+Usage totals, thinking blocks, and verify read raw lines, so archived
+sessions report no usage and export without thinking until restored
+(verify reads the bundle). Sync leaves archived sessions alone while their
+source file is unchanged and restores them automatically when it grows.
+
+Keep rules spare sessions that would otherwise be archived:
+  --keep-named         sessions with a name
+  --keep-with-lessons  sessions that ran gsc lessons, touched .gitsense/lessons,
+                       or cite a lesson id (lsn_...)
+  --keep-repo PATH     sessions in this repository (repeatable)
+
+After archiving, the full-text index is optimized and the database is
+vacuumed. Use gsc pi sessions restore to bring sessions back.`,
+		Example: `  # Preview what a 90-day retention would archive
+  gsc pi sessions gc --older-than 90d --keep-named --keep-with-lessons --dry-run
+
+  # Archive Claude Code sessions for one repository older than 8 weeks
+  gsc pi sessions gc --older-than 8w --runtime claude --repo ~/src/app`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := pisessions.ValidateRuntime(options.Runtime); err != nil {
+				return err
+			}
+			age, err := parseRetentionAge(olderThan)
+			if err != nil {
+				return err
+			}
+			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
+			if err != nil {
+				return err
+			}
+			resolvedArchive, err := resolveArchiveDir(archiveDir)
+			if err != nil {
+				return err
+			}
+			if options.Repo != "" {
+				if options.Repo, err = filepath.Abs(options.Repo); err != nil {
+					return err
+				}
+			}
+			for i, repo := range options.KeepRepos {
+				if options.KeepRepos[i], err = filepath.Abs(repo); err != nil {
+					return err
+				}
+			}
+			options.DBPath = resolvedDB
+			options.ArchiveDir = resolvedArchive
+			options.OlderThan = age
+			options.Vacuum = !noVacuum
+
+			result, err := pisessions.GC(cmd.Context(), options)
+			if err != nil {
+				return err
+			}
+			return writeGCResult(os.Stdout, result, format)
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
+	cmd.Flags().StringVar(&archiveDir, "archive-dir", "", "Bundle directory (default: GSC_HOME/data/pi/archive)")
+	cmd.Flags().StringVar(&olderThan, "older-than", "90d", "Archive sessions idle longer than this (90d, 8w, 720h)")
+	cmd.Flags().StringVar(&options.Runtime, "runtime", "", "Agent runtime filter (pi, claude)")
+	cmd.Flags().StringVar(&options.Repo, "repo", "", "Only consider sessions in this repository root")
+	cmd.Flags().StringArrayVar(&options.KeepRepos, "keep-repo", nil, "Never archive sessions in this repository root (repeatable)")
+	cmd.Flags().BoolVar(&options.KeepNamed, "keep-named", false, "Keep sessions that have a name")
+	cmd.Flags().BoolVar(&options.KeepWithLessons, "keep-with-lessons", false, "Keep sessions that created or cited repository lessons")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "Report what would be archived without changing anything")
+	cmd.Flags().BoolVar(&noVacuum, "no-vacuum", false, "Skip VACUUM after archiving")
+	cmd.Flags().StringVarP(&format, "format", "o", "human", "Output format: human, json")
+	return cmd
+}
+
+// parseRetentionAge accepts a d/w suffix or any time.ParseDuration value.
+func parseRetentionAge(value string) (time.Duration, error) {
+	v := strings.TrimSpace(strings.ToLower(value))
+	if len(v) > 1 && (strings.HasSuffix(v, "d") || strings.HasSuffix(v, "w")) {
+		if n, err := strconv.Atoi(v[:len(v)-1]); err == nil && n > 0 {
+			if strings.HasSuffix(v, "w") {
+				n *= 7
+			}
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	if d, err := time.ParseDuration(v); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid --older-than %q: use a duration such as 90d, 8w, or 720h", value)
+}
+
+func resolveArchiveDir(value string) (string, error) {
+	if value != "" {
+		return filepath.Abs(value)
+	}
+	gscHome, err := settings.GetGSCHome(false)
+	if err != nil {
+		return "", err
+	}
+	return settings.GetPiArchiveDirPath(gscHome), nil
+}
+
+func writeGCResult(w io.Writer, result *pisessions.GCResult, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case "human", "":
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+
+	verb := "Archived"
+	if result.DryRun {
+		verb = "Would archive"
+	}
+	fmt.Fprintf(w, "Sessions idle since before %s: %d\n", result.Cutoff, result.SessionsScanned)
+	for _, session := range result.Sessions {
+		status := "archive"
+		switch {
+		case session.Error != "":
+			status = "error"
+		case session.Kept != "":
+			status = "keep (" + session.Kept + ")"
+		}
+		fmt.Fprintf(w, "  %-16s %-14s %-7s %-10s %s\n", status, shortSessionID(session.SessionID), session.Runtime,
+			formatGCBytes(session.RawBytes), truncate(oneLine(session.Title), 60))
+		if session.Error != "" {
+			fmt.Fprintf(w, "    %s\n", session.Error)
+		}
+	}
+	fmt.Fprintf(w, "\n%s %d session(s), kept %d; raw lines: %s", verb, result.SessionsArchived, result.SessionsKept, formatGCBytes(result.RawBytesDropped))
+	if !result.DryRun && result.SessionsArchived > 0 {
+		fmt.Fprintf(w, " -> bundles: %s", formatGCBytes(result.ArchiveBytes))
+	}
+	fmt.Fprintln(w)
+	if !result.DryRun {
+		action := "Search index optimized"
+		if result.Vacuumed {
+			action += ", database vacuumed"
+		}
+		fmt.Fprintf(w, "%s: %s -> %s\n", action, formatGCBytes(result.DBBytesBefore), formatGCBytes(result.DBBytesAfter))
+	}
+	return nil
+}
+
+func formatGCBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions List Command
- * Block-UUID: 4e8f2a1c-9b3d-4e5f-8a7c-1d2e3f4a5b6c
- * Parent-UUID: N/A
- * Version: 1.1.0
- * Description: Lists Pi sessions with compact one-line-per-session format.
+ * Block-UUID: ce7cec63-d1b8-49c4-bd98-609b9d3a80ac
+ * Parent-UUID: 4e8f2a1c-9b3d-4e5f-8a7c-1d2e3f4a5b6c
+ * Version: 1.2.0
+ * Description: Lists Pi and Claude Code sessions with compact one-line-per-session format and an optional --runtime filter.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0, v1.1.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0, v1.1.0), agent (v1.2.0)
  */
 
 package sessions
@@ -32,6 +32,9 @@ func listCmd() *cobra.Command {
 		Short:        "List Pi sessions",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := pisessions.ValidateRuntime(options.Runtime); err != nil {
+				return err
+			}
 			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
 			if err != nil {
 				return err
@@ -50,6 +53,7 @@ func listCmd() *cobra.Command {
 	}
 	cmd.Flags().StringVar(&dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
 	cmd.Flags().StringVar(&options.Repo, "repo", "", "Repo root filter")
+	cmd.Flags().StringVar(&options.Runtime, "runtime", "", "Agent runtime filter (pi, claude)")
 	cmd.Flags().StringVar(&options.Since, "since", "", "Inclusive lower timestamp bound")
 	cmd.Flags().StringVar(&options.Until, "until", "", "Inclusive upper timestamp bound")
 	cmd.Flags().StringVar(&options.Provider, "provider", "", "Provider filter")
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Query Command
- * Block-UUID: 2d0e9316-204f-45dc-ab19-62041e8bba99
- * Parent-UUID: 28c28a9f-e833-4bdd-96dd-7a8c6ab25cf0
- * Version: 1.5.0
- * Description: Accepts a positional search term (like gsc rg) routed to full-text search, mutually exclusive with -q/--message; rejects extra positionals instead of silently ignoring them.
+ * Block-UUID: e26d806b-ccfe-4c0d-ac74-495ea6695359
+ * Parent-UUID: 2d0e9316-204f-45dc-ab19-62041e8bba99
+ * Version: 1.6.0
+ * Description: Accepts a positional search term (like gsc rg) routed to full-text search, mutually exclusive with -q/--message; rejects extra positionals instead of silently ignoring them. Adds --runtime to scope queries to Pi or Claude Code sessions.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0), MiMo-v2.5-pro (v1.1.0, v1.2.0, v1.3.0, v1.4.0), claude-opus-4-8 (v1.5.0)
+ * Authors: Codex GPT-5 (v1.0.0), MiMo-v2.5-pro (v1.1.0, v1.2.0, v1.3.0, v1.4.0), claude-opus-4-8 (v1.5.0), agent (v1.6.0)
  */
 
 package sessions
@@ -57,6 +57,9 @@ func queryCmd() *cobra.Command {
 				options.Text = args[0]
 			}
 
+			if err := pisessions.ValidateRuntime(options.Runtime); err != nil {
+				return err
+			}
 			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
 			if err != nil {
 				return err
@@ -91,6 +94,7 @@ func queryCmd() *cobra.Command {
 	cmd.Flags().StringVar(&options.File, "file", "", "Repo-root-relative file path to recall")
 	cmd.Flags().StringVar(&options.AbsFile, "abs-file", "", "Absolute file path to recall")
 	cmd.Flags().StringVar(&options.Repo, "repo", "", "Repo root filter")
+	cmd.Flags().StringVar(&options.Runtime, "runtime", "", "Agent runtime filter (pi, claude); default: both")
 	cmd.Flags().StringVar(&options.SessionID, "session-id", "", "Pi session ID filter")
 	cmd.Flags().StringVar(&options.SessionName, "session-name", "", "Exact session name match")
 	cmd.Flags().StringVar(&options.SessionNamePrefix, "session-name-starts-with", "", "Session name prefix match")
@@ -0,0 +1,205 @@
+/**
+ * Component: Pi Sessions Redact Command
+ * Block-UUID: 506a3ae3-98f0-41f2-8828-60771979410e
+ * Parent-UUID: 6c1f8b27-4d93-4e0a-a5b6-2e7d9f3c8a41
+ * Version: 1.1.0
+ * Description: Implements gsc pi sessions redact, scrubbing credentials from the sessions mirror (and optionally the source JSONL) with built-in and user-configured detectors, including archived sessions and their bundles, plus a dry-run report and a detector listing.
+ * Language: Go
+ * Created-at: 2026-10-18T17:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package sessions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pisessions "github.com/gitsense/gsc-cli/internal/pi/sessions"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+func redactCmd() *cobra.Command {
+	var options pisessions.RedactOptions
+	var dbPath string
+	var configPath string
+	var patterns []string
+	var listDetectors bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "redact",
+		Short: "Scrub secrets from mirrored sessions",
+		Long: `Find credentials in the sessions mirror and replace them with
+[REDACTED:<detector>] in raw transcript lines, the full-text index, tool
+arguments and output, and session summaries. Archived sessions are redacted
+too, and their archive bundle is rewritten.
+
+Built-in detectors cover private keys, AWS access and secret keys, GitHub
+tokens, sk- API keys, bearer tokens, and .env-style secret assignments. Add
+your own in GSC_HOME/data/pi/redact.json:
+
+  {
+    "detectors": [{"name": "internal_token", "pattern": "itk_[a-z0-9]{32}"}],
+    "disable": ["dotenv_value"]
+  }
+
+or pass --pattern name=regex. "group" selects a capture group to replace.
+
+Every rewritten line is recorded in pi_sync_events, so sync keeps appending
+and verify --lossless still passes while the source JSONL holds the original.
+Use --rewrite-source to scrub the JSONL too; run it when the session is idle.
+If a session file is later rewritten and re-imported, run redact again.`,
+		Example: `  # See what would be redacted
+  gsc pi sessions redact --dry-run
+
+  # Scrub the mirror and the source transcript of one session
+  gsc pi sessions redact --session 019edbc4-8752-... --rewrite-source`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := pisessions.ValidateRuntime(options.Runtime); err != nil {
+				return err
+			}
+			resolvedConfig, err := resolveRedactConfigPath(configPath)
+			if err != nil {
+				return err
+			}
+			config, err := pisessions.LoadRedactionConfig(resolvedConfig)
+			if err != nil {
+				return err
+			}
+			for _, pattern := range patterns {
+				name, expr, ok := strings.Cut(pattern, "=")
+				if !ok || name == "" || expr == "" {
+					return fmt.Errorf("invalid --pattern %q (use name=regex)", pattern)
+				}
+				config.Detectors = append(config.Detectors, pisessions.RedactionDetector{Name: name, Pattern: expr})
+			}
+			detectors, err := pisessions.CompileRedactionDetectors(config)
+			if err != nil {
+				return err
+			}
+			if listDetectors {
+				return writeRedactionDetectors(os.Stdout, detectors, format)
+			}
+
+			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
+			if err != nil {
+				return err
+			}
+			options.DBPath = resolvedDB
+			options.Detectors = detectors
+			result, err := pisessions.Redact(cmd.Context(), options)
+			if err != nil {
+				return err
+			}
+			return writeRedactResult(os.Stdout, result, format)
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
+	cmd.Flags().StringVar(&configPath, "config", "", "Detector config path (default: GSC_HOME/data/pi/redact.json)")
+	cmd.Flags().StringArrayVar(&patterns, "pattern", nil, "Extra detector as name=regex (repeatable)")
+	cmd.Flags().StringVar(&options.SessionID, "session", "", "Only redact this session")
+	cmd.Flags().StringVar(&options.Runtime, "runtime", "", "Agent runtime filter (pi, claude)")
+	cmd.Flags().BoolVar(&options.RewriteSource, "rewrite-source", false, "Also rewrite the source session JSONL")
+	cmd.Flags().BoolVar(&options.DryRun, "dry-run", false, "Report findings without changing anything")
+	cmd.Flags().BoolVar(&listDetectors, "list-detectors", false, "List active detectors and exit")
+	cmd.Flags().StringVarP(&format, "format", "o", "human", "Output format: human, json")
+	return cmd
+}
+
+func resolveRedactConfigPath(value string) (string, error) {
+	if value != "" {
+		return filepath.Abs(value)
+	}
+	gscHome, err := settings.GetGSCHome(false)
+	if err != nil {
+		return "", err
+	}
+	return settings.GetPiRedactConfigPath(gscHome), nil
+}
+
+func writeRedactionDetectors(w io.Writer, detectors []pisessions.RedactionDetector, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(detectors)
+	case "human", "":
+		for _, detector := range detectors {
+			fmt.Fprintf(w, "%-16s %s\n", detector.Name, detector.Pattern)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+func writeRedactResult(w io.Writer, result *pisessions.RedactResult, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	case "human", "":
+		writeRedactHuman(w, result)
+		return nil
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+func writeRedactHuman(w io.Writer, result *pisessions.RedactResult) {
+	verb := "Redacted"
+	if result.DryRun {
+		verb = "Would redact"
+	}
+	if result.SessionsAffected == 0 {
+		fmt.Fprintf(w, "No secrets found in %d session(s).\n", result.SessionsScanned)
+		return
+	}
+	fmt.Fprintf(w, "%s %d message(s) and %d tool call(s) in %d of %d session(s).\n", verb,
+		result.MessagesRedacted, result.ToolCallsRedacted, result.SessionsAffected, result.SessionsScanned)
+	if result.SourcesRewritten > 0 {
+		fmt.Fprintf(w, "Rewrote %d source file(s).\n", result.SourcesRewritten)
+	}
+	if len(result.Findings) > 0 {
+		fmt.Fprintln(w, "\nFindings:")
+		for _, name := range sortedFindingNames(result.Findings) {
+			fmt.Fprintf(w, "  %-16s %d\n", name, result.Findings[name])
+		}
+	}
+	fmt.Fprintln(w, "\nSessions:")
+	for _, session := range result.Sessions {
+		var parts []string
+		for _, name := range sortedFindingNames(session.Findings) {
+			parts = append(parts, fmt.Sprintf("%s=%d", name, session.Findings[name]))
+		}
+		line := fmt.Sprintf("  %s  %s", shortSessionID(session.SessionID), strings.Join(parts, " "))
+		if session.SourceRewritten {
+			line += "  (source rewritten)"
+		}
+		if session.ArchiveRewritten {
+			line += "  (archive rewritten)"
+		}
+		if session.Error != "" {
+			line += "  error: " + session.Error
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+func sortedFindingNames(findings map[string]int) []string {
+	names := make([]string, 0, len(findings))
+	for name := range findings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,96 @@
+/**
+ * Component: Pi Sessions Restore Command
+ * Block-UUID: 5d0b3f62-1a8e-4c79-b4e5-9f2d7a6c1e83
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements gsc pi sessions restore, moving the raw lines of sessions archived by gsc pi sessions gc back into the mirror and optionally recreating missing source transcripts.
+ * Language: Go
+ * Created-at: 2026-10-18T20:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	pisessions "github.com/gitsense/gsc-cli/internal/pi/sessions"
+	"github.com/spf13/cobra"
+)
+
+func restoreCmd() *cobra.Command {
+	var options pisessions.RestoreOptions
+	var dbPath string
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "restore [session-id...]",
+		Short: "Restore sessions archived by gc",
+		Long: `Move the raw transcript lines of archived sessions back from their bundles
+into the mirror. Each line is checked against the hash recorded when it was
+first imported, and the bundle is removed once the session is restored.
+
+Use --write-source to recreate the session JSONL when the original file has
+since been deleted.`,
+		Example: `  gsc pi sessions restore 019edbc4-8752-...
+  gsc pi sessions restore --all --write-source`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
+			if err != nil {
+				return err
+			}
+			options.DBPath = resolvedDB
+			options.SessionIDs = args
+			restored, err := pisessions.Restore(cmd.Context(), options)
+			if err != nil {
+				return err
+			}
+			if err := writeRestoreResult(os.Stdout, restored, format); err != nil {
+				return err
+			}
+			for _, session := range restored {
+				if session.Error != "" {
+					return fmt.Errorf("some sessions could not be restored")
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
+	cmd.Flags().BoolVar(&options.All, "all", false, "Restore every archived session")
+	cmd.Flags().BoolVar(&options.WriteSource, "write-source", false, "Recreate the source JSONL when it is missing")
+	cmd.Flags().StringVarP(&format, "format", "o", "human", "Output format: human, json")
+	return cmd
+}
+
+func writeRestoreResult(w io.Writer, restored []pisessions.RestoredSession, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(restored)
+	case "human", "":
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+	if len(restored) == 0 {
+		fmt.Fprintln(w, "No archived sessions.")
+		return nil
+	}
+	for _, session := range restored {
+		if session.Error != "" {
+			fmt.Fprintf(w, "failed    %s: %s\n", session.SessionID, session.Error)
+			continue
+		}
+		note := ""
+		if session.SourceWritten {
+			note = ", source recreated"
+		}
+		fmt.Fprintf(w, "restored  %s (%d lines%s)\n", session.SessionID, session.Lines, note)
+	}
+	return nil
+}
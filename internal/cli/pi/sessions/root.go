@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions CLI Root Command
- * Block-UUID: b9bc1d3a-b6fa-42a7-9c0b-795c2862ab2f
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Defines the gsc pi sessions command group for phase-one sync and query operations.
+ * Block-UUID: 1acacba2-18d1-4473-84b2-338c0952eca5
+ * Parent-UUID: 3b21c3b9-d14e-4191-b69f-5b5ffc2285d3
+ * Version: 1.7.0
+ * Description: Defines the gsc pi sessions command group; registers the usage analytics, per-file blame, secret redaction, transcript export, branch diff, retention (gc and restore), and live event tail commands.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0)
+ * Authors: Codex GPT-5 (v1.0.0), agent (v1.1.0), agent (v1.2.0), agent (v1.3.0), agent (v1.4.0), agent (v1.5.0), agent (v1.6.0), agent (v1.7.0)
  */
 
 
@@ -35,6 +35,14 @@ func NewCmd() *cobra.Command {
 	cmd.AddCommand(listCmd())
 	cmd.AddCommand(showCmd())
 	cmd.AddCommand(verifyCmd())
+	cmd.AddCommand(usageCmd())
+	cmd.AddCommand(blameCmd())
+	cmd.AddCommand(redactCmd())
+	cmd.AddCommand(exportCmd())
+	cmd.AddCommand(diffCmd())
+	cmd.AddCommand(gcCmd())
+	cmd.AddCommand(restoreCmd())
+	cmd.AddCommand(tailCmd())
 	
 	// Read-only helpers for triggers
 	cmd.AddCommand(branchCmd())
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Show Command
- * Block-UUID: 5f7a8b9c-0d1e-2f3a-4b5c-6d7e8f9a0b1c
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Shows detailed information about a specific Pi session.
+ * Block-UUID: aad9beca-1b58-4a13-bef7-c6b7451be953
+ * Parent-UUID: 5f7a8b9c-0d1e-2f3a-4b5c-6d7e8f9a0b1c
+ * Version: 1.1.0
+ * Description: Shows detailed information about a specific Pi or Claude Code session, including its runtime.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: MiMo-v2.5-pro
+ * Authors: MiMo-v2.5-pro, agent (v1.1.0)
  */
 
 package sessions
@@ -87,7 +87,10 @@ func writeShowHuman(r *pisessions.ShowResult) error {
 		fmt.Printf("  CWD:        ~/%s\n", homeRelative(r.CWD))
 	}
 
-	// Provider/Model
+	// Runtime/Provider/Model
+	if r.Runtime != "" {
+		fmt.Printf("  Runtime:    %s\n", r.Runtime)
+	}
 	if r.Provider != "" {
 		fmt.Printf("  Provider:   %s\n", r.Provider)
 	}
@@ -1,20 +1,22 @@
 /**
  * Component: Pi Sessions Sync Command
- * Block-UUID: c18409b8-dda6-4426-8b61-03eb43d1a1ce
- * Parent-UUID: N/A
- * Version: 1.2.0
- * Description: Defines shared sync flags and registers continuous sync lifecycle commands (start/stop/status). One-shot sync removed in favor of start/stop lifecycle.
+ * Block-UUID: 6270a948-fe80-48ed-b50f-0f366ff29de5
+ * Parent-UUID: c18409b8-dda6-4426-8b61-03eb43d1a1ce
+ * Version: 1.3.0
+ * Description: Defines shared sync flags and registers continuous sync lifecycle commands (start/stop/status); adds --claude-dir and --runtime so the watcher can mirror Claude Code transcripts alongside Pi sessions.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0, v1.1.0), MiMo-v2.5-Pro (v1.2.0)
+ * Authors: Codex GPT-5 (v1.0.0, v1.1.0), MiMo-v2.5-Pro (v1.2.0), agent (v1.3.0)
  */
 
 package sessions
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 
+	pisessions "github.com/gitsense/gsc-cli/internal/pi/sessions"
 	"github.com/gitsense/gsc-cli/pkg/settings"
 	"github.com/spf13/cobra"
 )
@@ -28,7 +30,7 @@ func syncCmdWithDependencies(startDependencies syncStartDependencies) *cobra.Com
 
 	cmd := &cobra.Command{
 		Use:          "sync",
-		Short:        "Manage continuous Pi session sync lifecycle (start/stop/status)",
+		Short:        "Manage continuous Pi and Claude Code session sync lifecycle (start/stop/status)",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
@@ -38,6 +40,8 @@ func syncCmdWithDependencies(startDependencies syncStartDependencies) *cobra.Com
 		},
 	}
 	cmd.PersistentFlags().StringVar(&config.sessionsDir, "sessions-dir", "", "Root directory containing Pi session JSONL files")
+	cmd.PersistentFlags().StringVar(&config.claudeDir, "claude-dir", "", "Root directory containing Claude Code project transcripts (default: ~/.claude/projects)")
+	cmd.PersistentFlags().StringVar(&config.runtime, "runtime", "all", "Transcripts to sync: all, pi, or claude")
 	cmd.PersistentFlags().StringVar(&config.dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
 	cmd.AddCommand(syncStartCmd(config, startDependencies))
 	cmd.AddCommand(syncStatusCmd(config))
@@ -47,9 +51,69 @@ func syncCmdWithDependencies(startDependencies syncStartDependencies) *cobra.Com
 
 type syncConfig struct {
 	sessionsDir string
+	claudeDir   string
+	runtime     string
 	dbPath      string
 }
 
+// syncTargets holds the resolved transcript roots; an empty root is not synced.
+type syncTargets struct {
+	runtime     string
+	sessionsDir string
+	claudeDir   string
+}
+
+// resolveSyncTargets resolves the roots selected by --runtime. With "all",
+// roots that do not exist are skipped so users of only one agent can run the
+// watcher unchanged; an explicitly selected runtime is always kept.
+func resolveSyncTargets(config *syncConfig) (syncTargets, error) {
+	targets := syncTargets{runtime: config.runtime}
+	if targets.runtime == "" {
+		targets.runtime = "all"
+	}
+	if targets.runtime != "all" {
+		if err := pisessions.ValidateRuntime(targets.runtime); err != nil {
+			return syncTargets{}, fmt.Errorf("invalid --runtime %q (use all, pi, or claude)", config.runtime)
+		}
+	}
+	keep := func(runtime string, dir string) bool {
+		if targets.runtime == runtime {
+			return true
+		}
+		if targets.runtime != "all" {
+			return false
+		}
+		_, err := os.Stat(dir)
+		return err == nil
+	}
+
+	sessionsDir, err := resolvePiSessionsDir(config.sessionsDir)
+	if err != nil {
+		return syncTargets{}, err
+	}
+	if keep(pisessions.RuntimePi, sessionsDir) {
+		targets.sessionsDir = sessionsDir
+	}
+	claudeDir, err := resolveClaudeProjectsDir(config.claudeDir)
+	if err != nil {
+		return syncTargets{}, err
+	}
+	if keep(pisessions.RuntimeClaude, claudeDir) {
+		targets.claudeDir = claudeDir
+	}
+	if targets.sessionsDir == "" && targets.claudeDir == "" {
+		return syncTargets{}, fmt.Errorf("no session directories found (looked in %s and %s)", sessionsDir, claudeDir)
+	}
+	return targets, nil
+}
+
+func resolveClaudeProjectsDir(value string) (string, error) {
+	if value != "" {
+		return filepath.Abs(value)
+	}
+	return pisessions.DefaultClaudeProjectsDir()
+}
+
 func resolvePiSessionsDBPath(value string) (string, error) {
 	if value != "" {
 		return filepath.Abs(value)
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Sync Start Command
- * Block-UUID: 2e36c783-d48f-407e-b5ae-e7ff9f674fa2
- * Parent-UUID: c18409b8-dda6-4426-8b61-03eb43d1a1ce
- * Version: 1.2.0
- * Description: Connects the foreground sync start command to continuous reconciliation with graceful signal cancellation, PID file management, and detach mode support.
+ * Block-UUID: fefbf97f-a4e9-4717-8673-49077e2fae07
+ * Parent-UUID: 2e36c783-d48f-407e-b5ae-e7ff9f674fa2
+ * Version: 1.3.0
+ * Description: Connects the foreground sync start command to continuous reconciliation of Pi and Claude Code transcript roots with graceful signal cancellation, PID file management, and detach mode support.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0), Codex GPT-5 (v1.1.0), MiMo-v2.5-Pro (v1.2.0)
+ * Authors: Codex GPT-5 (v1.0.0), Codex GPT-5 (v1.1.0), MiMo-v2.5-Pro (v1.2.0), agent (v1.3.0)
  */
 
 
@@ -29,7 +29,7 @@ import (
 type syncStartDependencies struct {
 	watch         func(context.Context, pisessions.WatchOptions) error
 	notifyContext func(context.Context, ...os.Signal) (context.Context, context.CancelFunc)
-	daemonize     func(cmd *cobra.Command, sessionsDir, dbPath string) error
+	daemonize     func(cmd *cobra.Command, targets syncTargets, dbPath string) error
 }
 
 func defaultSyncStartDependencies() syncStartDependencies {
@@ -45,24 +45,13 @@ func syncStartCmd(config *syncConfig, dependencies syncStartDependencies) *cobra
 	var debug bool
 	cmd := &cobra.Command{
 		Use:          "start",
-		Short:        "Continuously sync Pi session JSONL in the foreground",
+		Short:        "Continuously sync Pi and Claude Code session JSONL in the foreground",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				return fmt.Errorf("unknown argument %q for %q", args[0], cmd.CommandPath())
 			}
-			if detached {
-				sessionsDir, err := resolvePiSessionsDir(config.sessionsDir)
-				if err != nil {
-					return err
-				}
-				resolvedDB, err := resolvePiSessionsDBPath(config.dbPath)
-				if err != nil {
-					return err
-				}
-				return dependencies.daemonize(cmd, sessionsDir, resolvedDB)
-			}
-			sessionsDir, err := resolvePiSessionsDir(config.sessionsDir)
+			targets, err := resolveSyncTargets(config)
 			if err != nil {
 				return err
 			}
@@ -70,6 +59,9 @@ func syncStartCmd(config *syncConfig, dependencies syncStartDependencies) *cobra
 			if err != nil {
 				return err
 			}
+			if detached {
+				return dependencies.daemonize(cmd, targets, resolvedDB)
+			}
 
 			// Write PID file for status/stop commands
 			gscHome, err := settings.GetGSCHome(false)
@@ -92,12 +84,18 @@ func syncStartCmd(config *syncConfig, dependencies syncStartDependencies) *cobra
 			if debug {
 				debugLogPath = settings.GetPiSyncDebugLogPath(gscHome)
 			}
-			fmt.Fprintf(cmd.OutOrStdout(), "Watching Pi sessions in %s\n", sessionsDir)
+			if targets.sessionsDir != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Watching Pi sessions in %s\n", targets.sessionsDir)
+			}
+			if targets.claudeDir != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Watching Claude Code sessions in %s\n", targets.claudeDir)
+			}
 			return dependencies.watch(watchCtx, pisessions.WatchOptions{
-				SessionsDir:  sessionsDir,
-				DBPath:       resolvedDB,
-				LogPath:      logPath,
-				DebugLogPath: debugLogPath,
+				SessionsDir:       targets.sessionsDir,
+				ClaudeProjectsDir: targets.claudeDir,
+				DBPath:            resolvedDB,
+				LogPath:           logPath,
+				DebugLogPath:      debugLogPath,
 			})
 		},
 	}
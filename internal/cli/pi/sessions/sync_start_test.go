@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Sync Start CLI Tests
- * Block-UUID: 46bd5a67-b05b-43d9-9339-1b98c32b388e
- * Parent-UUID: 2e36c783-d48f-407e-b5ae-e7ff9f674fa2
- * Version: 1.1.0
- * Description: Verifies foreground watcher wiring, inherited flags, default paths, cancellation, and detach mode daemonization.
+ * Block-UUID: c8a4fc60-6b5a-465f-a28a-3992cabaa49d
+ * Parent-UUID: 46bd5a67-b05b-43d9-9339-1b98c32b388e
+ * Version: 1.2.0
+ * Description: Verifies foreground watcher wiring, inherited flags, default paths, cancellation, detach mode daemonization, and runtime root selection.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0), MiMo-v2.5-Pro (v1.1.0)
+ * Authors: Codex GPT-5 (v1.0.0), MiMo-v2.5-Pro (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -100,12 +100,12 @@ func TestSyncStartDetachedModeCallsDaemonize(t *testing.T) {
 			t.Fatal("notifyContext should not be called in daemonize path")
 			return parent, func() {}
 		},
-		daemonize: func(cmd *cobra.Command, gotSessionsDir, gotDBPath string) error {
+		daemonize: func(cmd *cobra.Command, targets syncTargets, gotDBPath string) error {
 			daemonizeCalled = true
 			absSessionsDir, _ := filepath.Abs(sessionsDir)
 			absDBPath, _ := filepath.Abs(dbPath)
-			if gotSessionsDir != absSessionsDir || gotDBPath != absDBPath {
-				t.Fatalf("daemonize args = (%q, %q), want (%q, %q)", gotSessionsDir, gotDBPath, absSessionsDir, absDBPath)
+			if targets.sessionsDir != absSessionsDir || gotDBPath != absDBPath {
+				t.Fatalf("daemonize args = (%q, %q), want (%q, %q)", targets.sessionsDir, gotDBPath, absSessionsDir, absDBPath)
 			}
 			return nil
 		},
@@ -123,3 +123,32 @@ func TestSyncStartDetachedModeCallsDaemonize(t *testing.T) {
 		t.Fatal("daemonize was not called")
 	}
 }
+
+func TestResolveSyncTargetsSkipsMissingRootsForAll(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	claudeDir := filepath.Join(home, ".claude", "projects")
+	if err := os.MkdirAll(claudeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	targets, err := resolveSyncTargets(&syncConfig{runtime: "all"})
+	if err != nil {
+		t.Fatalf("resolve all: %v", err)
+	}
+	if targets.sessionsDir != "" || targets.claudeDir != claudeDir {
+		t.Fatalf("all targets = %+v, want only %s", targets, claudeDir)
+	}
+
+	targets, err = resolveSyncTargets(&syncConfig{runtime: "pi"})
+	if err != nil {
+		t.Fatalf("resolve pi: %v", err)
+	}
+	if targets.sessionsDir == "" || targets.claudeDir != "" {
+		t.Fatalf("pi targets = %+v, want the Pi root even when missing", targets)
+	}
+
+	if _, err := resolveSyncTargets(&syncConfig{runtime: "codex"}); err == nil {
+		t.Fatal("expected error for unknown runtime")
+	}
+}
@@ -2,13 +2,13 @@
 
 /**
  * Component: Pi Sessions Sync Start Unix Daemonization
- * Block-UUID: [to-be-generated]
- * Parent-UUID: 2e36c783-d48f-407e-b5ae-e7ff9f674fa2
- * Version: 1.0.0
- * Description: Re-executes the current binary as a detached background process for Pi sessions sync on Unix systems. Uses _GSC_SYNC_CHILD=1 sentinel to break the re-exec cycle. Parent polls for PID file to confirm startup before exiting.
+ * Block-UUID: 55733683-8aef-409e-ba4d-22d00ad09317
+ * Parent-UUID: [to-be-generated]
+ * Version: 1.1.0
+ * Description: Re-executes the current binary as a detached background process for session sync on Unix systems, forwarding the selected runtime and transcript roots. Uses _GSC_SYNC_CHILD=1 sentinel to break the re-exec cycle. Parent polls for PID file to confirm startup before exiting.
  * Language: Go
  * Created-at: 2026-06-20T00:00:00Z
- * Authors: MiMo-v2.5-Pro (v1.0.0)
+ * Authors: MiMo-v2.5-Pro (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -28,7 +28,7 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func daemonizeSync(cmd *cobra.Command, sessionsDir, dbPath string) error {
+func daemonizeSync(cmd *cobra.Command, targets syncTargets, dbPath string) error {
 	exe, err := os.Executable()
 	if err != nil {
 		return fmt.Errorf("failed to resolve executable path: %w", err)
@@ -36,9 +36,15 @@ func daemonizeSync(cmd *cobra.Command, sessionsDir, dbPath string) error {
 
 	args := []string{
 		"pi", "sessions", "sync", "start",
-		"--sessions-dir", sessionsDir,
+		"--runtime", targets.runtime,
 		"--db", dbPath,
 	}
+	if targets.sessionsDir != "" {
+		args = append(args, "--sessions-dir", targets.sessionsDir)
+	}
+	if targets.claudeDir != "" {
+		args = append(args, "--claude-dir", targets.claudeDir)
+	}
 
 	child := exec.Command(exe, args...)
 	child.Env = append(os.Environ(), "_GSC_SYNC_CHILD=1")
@@ -88,7 +94,12 @@ func daemonizeSync(cmd *cobra.Command, sessionsDir, dbPath string) error {
 	out := cmd.OutOrStdout()
 	fmt.Fprintf(out, "Pi sessions sync daemon started\n")
 	fmt.Fprintf(out, "  PID:           %d\n", childPid)
-	fmt.Fprintf(out, "  Sessions Dir:  %s\n", sessionsDir)
+	if targets.sessionsDir != "" {
+		fmt.Fprintf(out, "  Sessions Dir:  %s\n", targets.sessionsDir)
+	}
+	if targets.claudeDir != "" {
+		fmt.Fprintf(out, "  Claude Dir:    %s\n", targets.claudeDir)
+	}
 	fmt.Fprintf(out, "  Database:      %s\n", dbPath)
 	fmt.Fprintf(out, "  Log:           %s\n", settings.GetPiSyncLogPath(gscHome))
 	fmt.Fprintf(out, "\n")
@@ -2,13 +2,13 @@
 
 /**
  * Component: Pi Sessions Sync Start Windows Stub
- * Block-UUID: [to-be-generated]
- * Parent-UUID: 2e36c783-d48f-407e-b5ae-e7ff9f674fa2
- * Version: 1.0.0
- * Description: Windows stub for Pi sessions sync daemonization. Detach mode is not supported on Windows.
+ * Block-UUID: 12003aef-8e9c-4c44-b3d5-b8e2b7dd9a69
+ * Parent-UUID: [to-be-generated]
+ * Version: 1.1.0
+ * Description: Windows stub for session sync daemonization. Detach mode is not supported on Windows.
  * Language: Go
  * Created-at: 2026-06-20T00:00:00Z
- * Authors: MiMo-v2.5-Pro (v1.0.0)
+ * Authors: MiMo-v2.5-Pro (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -21,6 +21,6 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func daemonizeSync(cmd *cobra.Command, sessionsDir, dbPath string) error {
+func daemonizeSync(cmd *cobra.Command, targets syncTargets, dbPath string) error {
 	return fmt.Errorf("detached sync is not supported on Windows")
 }
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Sync Status Command
- * Block-UUID: [to-be-generated]
- * Parent-UUID: c18409b8-dda6-4426-8b61-03eb43d1a1ce
- * Version: 1.0.0
- * Description: Displays the current status of the Pi sessions sync watcher including PID, uptime, and recent sync activity.
+ * Block-UUID: 97df13e1-2b85-4556-977e-b04b80a3ebd5
+ * Parent-UUID: [to-be-generated]
+ * Version: 1.1.0
+ * Description: Displays the current status of the session sync watcher including PID, uptime, Pi and Claude Code transcript roots, and recent sync activity.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0)
+ * Authors: Codex GPT-5 (v1.0.0), agent (v1.1.0)
  */
 
 package sessions
@@ -31,6 +31,7 @@ type syncStatusResult struct {
 	StartedAt  *string          `json:"started_at,omitempty"`
 	Uptime     *string          `json:"uptime,omitempty"`
 	SessionsDir string          `json:"sessions_dir"`
+	ClaudeDir  string           `json:"claude_dir"`
 	Database   string           `json:"database"`
 	LogFile    string           `json:"log_file"`
 	DebugLogFile string         `json:"debug_log_file"`
@@ -97,6 +98,7 @@ func runSyncStatus(cmd *cobra.Command, config *syncConfig, format string) error
 
 	result := syncStatusResult{
 		SessionsDir:   resolveSessionsDirDisplay(config.sessionsDir),
+		ClaudeDir:     resolveClaudeDirDisplay(config.claudeDir),
 		Database:      dbPath,
 		LogFile:       logPath,
 		DebugLogFile:  debugLogPath,
@@ -147,6 +149,13 @@ func resolveSessionsDirDisplay(value string) string {
 	return "~/.pi/agent/sessions"
 }
 
+func resolveClaudeDirDisplay(value string) string {
+	if value != "" {
+		return value
+	}
+	return "~/.claude/projects"
+}
+
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
 		return fmt.Sprintf("%ds", int(d.Seconds()))
@@ -314,6 +323,7 @@ func writeSyncStatusHuman(cmd *cobra.Command, result syncStatusResult) error {
 
 	fmt.Fprintf(out, "\n")
 	fmt.Fprintf(out, "  Sessions Dir:  %s\n", result.SessionsDir)
+	fmt.Fprintf(out, "  Claude Dir:    %s\n", result.ClaudeDir)
 	fmt.Fprintf(out, "  Database:      %s\n", result.Database)
 	fmt.Fprintf(out, "  Log File:      %s\n", result.LogFile)
 	fmt.Fprintf(out, "  Debug Log:     %s\n", result.DebugLogFile)
@@ -0,0 +1,258 @@
+/**
+ * Component: Pi Sessions Tail Command
+ * Block-UUID: ab0df51d-e963-4acc-a56c-5d3d3ff39a1e
+ * Parent-UUID: 7a4f1d86-3c29-4b5e-8e07-b9d2c6a5f013
+ * Version: 1.1.0
+ * Description: Implements gsc pi sessions tail [--follow], printing normalized NDJSON events (message, tool_call, tool_result, file_ref, error) as entries are ingested into the mirror, with repo, tool, op, and event filters and optional Unix socket fan-out to several subscribers.
+ * Language: Go
+ * Created-at: 2026-10-18T21:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package sessions
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	pisessions "github.com/gitsense/gsc-cli/internal/pi/sessions"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+// defaultTailSocket marks --socket or --connect given without a path.
+const defaultTailSocket = "default"
+
+func tailCmd() *cobra.Command {
+	var options pisessions.StreamOptions
+	var dbPath string
+	var follow bool
+	var interval time.Duration
+	var events string
+	var socketPath string
+	var connectPath string
+	var quiet bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "tail",
+		Short: "Stream session events as they are ingested",
+		Long: `Print normalized events for session entries as they reach the mirror, one
+JSON object per line:
+
+  message      user and assistant text, summaries, and other entries with text
+  tool_call    a tool invocation with its arguments
+  tool_result  the result of a tool call (is_error marks failures)
+  file_ref     a file read, edited, or written (op, path)
+  error        a failed tool call, an assistant error, or a sync error (kind)
+
+Every event carries session_id, runtime, repo_root, entry_id, seq, and
+timestamp. Events are produced from the mirror, so something must be
+syncing it: run gsc pi sessions sync start in another terminal or as a
+background service.
+
+Without --follow, the events of the last -n entries are printed and the
+command exits. --tool and --op drop events without a tool or op; tool_call
+and tool_result events of read, edit, and write tools carry that op.
+
+--socket serves the stream on a local Unix socket so several dashboards or
+scripts can subscribe without each polling the database; --connect
+subscribes to such a socket and applies its own filters.`,
+		Example: `  # Follow everything as NDJSON
+  gsc pi sessions tail -f
+
+  # Failed tool calls in one repository, human-readable
+  gsc pi sessions tail -f --repo ~/src/app --event error -o human
+
+  # Serve the stream, then subscribe from another process
+  gsc pi sessions tail -f --socket --quiet
+  gsc pi sessions tail --connect --tool bash
+
+  # A socket elsewhere (the path needs "=")
+  gsc pi sessions tail -f --socket=/tmp/agents.sock`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := pisessions.ValidateRuntime(options.Runtime); err != nil {
+				return err
+			}
+			if format != "ndjson" && format != "json" && format != "human" {
+				return fmt.Errorf("unsupported output format %q", format)
+			}
+			for _, name := range strings.Split(events, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					if err := pisessions.ValidateStreamEventType(name); err != nil {
+						return err
+					}
+					options.Events = append(options.Events, name)
+				}
+			}
+			if options.Repo != "" {
+				repo, err := filepath.Abs(options.Repo)
+				if err != nil {
+					return err
+				}
+				options.Repo = repo
+			}
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			emit := func(event pisessions.StreamEvent) error {
+				return writeTailEvent(os.Stdout, event, format)
+			}
+
+			if connectPath != "" {
+				path, err := resolveTailSocketPath(connectPath)
+				if err != nil {
+					return err
+				}
+				return pisessions.SubscribeEventHub(ctx, path, options, emit)
+			}
+
+			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
+			if err != nil {
+				return err
+			}
+			options.DBPath = resolvedDB
+			stream, err := pisessions.OpenEventStream(ctx, options)
+			if err != nil {
+				return err
+			}
+			defer stream.Close()
+
+			if !follow {
+				if socketPath != "" {
+					return fmt.Errorf("--socket requires --follow")
+				}
+				polled, err := stream.Poll(ctx)
+				if err != nil {
+					return err
+				}
+				for _, event := range polled {
+					if err := emit(event); err != nil {
+						return err
+					}
+				}
+				return nil
+			}
+
+			if socketPath != "" {
+				path, err := resolveTailSocketPath(socketPath)
+				if err != nil {
+					return err
+				}
+				hub, err := pisessions.ListenEventHub(path)
+				if err != nil {
+					return err
+				}
+				defer hub.Close()
+				fmt.Fprintf(os.Stderr, "Serving session events on %s\n", path)
+				print := emit
+				emit = func(event pisessions.StreamEvent) error {
+					if err := hub.Publish(event); err != nil {
+						return err
+					}
+					if quiet {
+						return nil
+					}
+					return print(event)
+				}
+			}
+			return stream.Follow(ctx, interval, emit)
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
+	cmd.Flags().BoolVarP(&follow, "follow", "f", false, "Keep streaming new events")
+	cmd.Flags().IntVarP(&options.Backlog, "lines", "n", 10, "Replay the events of the last N entries first (0 for none)")
+	cmd.Flags().DurationVar(&interval, "interval", 500*time.Millisecond, "How often to check the mirror for new entries")
+	cmd.Flags().StringVar(&options.SessionID, "session", "", "Only this session")
+	cmd.Flags().StringVar(&options.Runtime, "runtime", "", "Agent runtime filter (pi, claude)")
+	cmd.Flags().StringVar(&options.Repo, "repo", "", "Only sessions in this repository root")
+	cmd.Flags().StringVar(&options.Tool, "tool", "", "Only events for this tool")
+	cmd.Flags().StringVar(&options.Op, "op", "", "Only events with this file op (read, edit, write)")
+	cmd.Flags().StringVar(&events, "event", "", "Comma-separated event types: "+strings.Join(pisessions.StreamEventTypes, ", "))
+	cmd.Flags().IntVar(&options.MaxText, "max-text", 2000, "Truncate message and result text to N characters (-1 for no limit)")
+	cmd.Flags().StringVar(&socketPath, "socket", "", "Also serve events on a Unix socket (default: GSC_HOME/data/pi/sessions-tail.sock)")
+	cmd.Flags().Lookup("socket").NoOptDefVal = defaultTailSocket
+	cmd.Flags().StringVar(&connectPath, "connect", "", "Subscribe to a socket served by another tail instead of reading the mirror")
+	cmd.Flags().Lookup("connect").NoOptDefVal = defaultTailSocket
+	cmd.Flags().BoolVar(&quiet, "quiet", false, "With --socket, do not print events to stdout")
+	cmd.Flags().StringVarP(&format, "format", "o", "ndjson", "Output format: ndjson (or json, the same), human")
+	return cmd
+}
+
+func resolveTailSocketPath(value string) (string, error) {
+	if value != defaultTailSocket {
+		return filepath.Abs(value)
+	}
+	gscHome, err := settings.GetGSCHome(false)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(settings.GetPiGscDataDir(gscHome), 0755); err != nil {
+		return "", err
+	}
+	return settings.GetPiTailSocketPath(gscHome), nil
+}
+
+func writeTailEvent(w io.Writer, event pisessions.StreamEvent, format string) error {
+	if format != "human" {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", line)
+		return err
+	}
+
+	clock := event.Timestamp
+	if t, err := time.Parse(time.RFC3339Nano, event.Timestamp); err == nil {
+		clock = t.Local().Format("15:04:05")
+	}
+	name := event.Event
+	if event.Kind != "" && event.Event == pisessions.StreamEventError {
+		name += "/" + event.Kind
+	}
+	var detail string
+	switch event.Event {
+	case pisessions.StreamEventMessage:
+		detail = event.Role + ": " + oneLine(event.Text)
+	case pisessions.StreamEventToolCall:
+		detail = event.Tool
+		if path := tailArgumentSummary(event.Arguments); path != "" {
+			detail += " " + path
+		}
+	case pisessions.StreamEventToolResult:
+		status := "ok"
+		if event.IsError {
+			status = "failed"
+		}
+		detail = event.Tool + " " + status + ": " + oneLine(event.Text)
+	case pisessions.StreamEventFileRef:
+		detail = event.Op + " " + event.Path
+	default:
+		detail = strings.TrimSpace(event.Tool + " " + oneLine(event.Text))
+	}
+	_, err := fmt.Fprintf(w, "%s %-14s %-6s %-12s %s\n", clock, shortSessionID(event.SessionID), event.Runtime, name, truncate(detail, 120))
+	return err
+}
+
+// tailArgumentSummary picks the path or command out of tool arguments.
+func tailArgumentSummary(arguments json.RawMessage) string {
+	var fields map[string]any
+	if json.Unmarshal(arguments, &fields) != nil {
+		return ""
+	}
+	for _, key := range []string{"path", "file_path", "command", "pattern", "url"} {
+		if value, ok := fields[key].(string); ok && value != "" {
+			return oneLine(value)
+		}
+	}
+	return ""
+}
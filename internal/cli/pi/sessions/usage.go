@@ -0,0 +1,254 @@
+/**
+ * Component: Pi Sessions Usage Command
+ * Block-UUID: 87ace1e6-76eb-4599-a4d2-bde3b10fd045
+ * Parent-UUID: 3d9e6b14-7a20-4c58-91f3-b5c8e02a6d7f
+ * Version: 1.2.0
+ * Description: Implements gsc pi sessions usage for token and cost analytics, with a --session filter; a date passed to --until covers that whole day.
+ * Language: Go
+ * Created-at: 2026-10-18T15:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0)
+ */
+
+package sessions
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	pisessions "github.com/gitsense/gsc-cli/internal/pi/sessions"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+func usageCmd() *cobra.Command {
+	var options pisessions.UsageOptions
+	var dbPath string
+	var pricingPath string
+	var initPricing bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Report token usage and estimated cost across sessions",
+		Long: `Aggregate provider-reported input, output, and cache tokens from every
+assistant response in the sessions mirror and estimate cost from a local
+pricing table.
+
+The pricing table is JSON (currency units per million tokens) at
+GSC_HOME/data/pi/pricing.json. Run with --init-pricing to write the built-in
+defaults there for editing. Models missing from the table fall back to the cost
+the provider reported, when there is one.
+
+Sessions that hit context compaction are listed below the table.`,
+		Example: `  # Daily spend for the last week
+  gsc pi sessions usage --since 2026-10-11
+
+  # Most expensive sessions in a repo, as CSV
+  gsc pi sessions usage --by session --repo ~/src/app --limit 20 -o csv
+
+  # Write the default pricing table for editing
+  gsc pi sessions usage --init-pricing`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := pisessions.ValidateUsageGroup(options.GroupBy); err != nil {
+				return err
+			}
+			if err := pisessions.ValidateRuntime(options.Runtime); err != nil {
+				return err
+			}
+			resolvedPricing, err := resolvePricingPath(pricingPath)
+			if err != nil {
+				return err
+			}
+			if initPricing {
+				return initPricingTable(resolvedPricing)
+			}
+			pricing, err := pisessions.LoadPricingTable(resolvedPricing)
+			if err != nil {
+				return err
+			}
+			resolvedDB, err := resolvePiSessionsDBPath(dbPath)
+			if err != nil {
+				return err
+			}
+			options.DBPath = resolvedDB
+			options.Pricing = pricing
+
+			report, err := pisessions.Usage(cmd.Context(), options)
+			if err != nil {
+				return err
+			}
+			return writeUsageReport(os.Stdout, report, format)
+		},
+	}
+	cmd.Flags().StringVar(&dbPath, "db", "", "SQLite mirror path (default: GSC_HOME/data/pi/pi-sessions.sqlite3)")
+	cmd.Flags().StringVar(&pricingPath, "pricing", "", "Pricing table path (default: GSC_HOME/data/pi/pricing.json)")
+	cmd.Flags().BoolVar(&initPricing, "init-pricing", false, "Write the default pricing table for editing and exit")
+	cmd.Flags().StringVar(&options.GroupBy, "by", pisessions.UsageGroupDay, "Group by: "+strings.Join(pisessions.UsageGroups, ", "))
+	cmd.Flags().StringVar(&options.Repo, "repo", "", "Repo root filter")
+	cmd.Flags().StringVar(&options.SessionID, "session", "", "Only count this session")
+	cmd.Flags().StringVar(&options.Runtime, "runtime", "", "Agent runtime filter (pi, claude)")
+	cmd.Flags().StringVar(&options.Since, "since", "", "Inclusive lower bound on response timestamps")
+	cmd.Flags().StringVar(&options.Until, "until", "", "Inclusive upper bound on response timestamps; a date covers that whole UTC day")
+	cmd.Flags().StringVar(&options.Provider, "provider", "", "Provider filter")
+	cmd.Flags().StringVar(&options.Model, "model", "", "Model filter")
+	cmd.Flags().IntVar(&options.Limit, "limit", 0, "Maximum rows (0 for all)")
+	cmd.Flags().StringVarP(&format, "format", "o", "human", "Output format: human, json, csv")
+	return cmd
+}
+
+func resolvePricingPath(value string) (string, error) {
+	if value != "" {
+		return filepath.Abs(value)
+	}
+	gscHome, err := settings.GetGSCHome(false)
+	if err != nil {
+		return "", err
+	}
+	return settings.GetPiPricingPath(gscHome), nil
+}
+
+func initPricingTable(path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("pricing table already exists at %s", path)
+	}
+	if err := pisessions.WritePricingTable(path, pisessions.DefaultPricingTable()); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote default pricing table to %s\n", path)
+	return nil
+}
+
+func writeUsageReport(w io.Writer, report *pisessions.UsageReport, format string) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	case "csv":
+		return writeUsageCSV(w, report)
+	case "human", "":
+		return writeUsageHuman(w, report)
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+func writeUsageCSV(w io.Writer, report *pisessions.UsageReport) error {
+	writer := csv.NewWriter(w)
+	header := []string{report.GroupBy, "sessions", "messages", "input_tokens", "output_tokens",
+		"cache_read", "cache_write", "total_tokens", "cost", "reported_cost", "unpriced_messages", "compactions"}
+	if report.GroupBy == pisessions.UsageGroupSession {
+		header = append(header, "title", "repo_root")
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range report.Rows {
+		record := []string{
+			row.Key,
+			strconv.Itoa(row.Sessions),
+			strconv.Itoa(row.Messages),
+			strconv.FormatInt(row.InputTokens, 10),
+			strconv.FormatInt(row.OutputTokens, 10),
+			strconv.FormatInt(row.CacheRead, 10),
+			strconv.FormatInt(row.CacheWrite, 10),
+			strconv.FormatInt(row.TotalTokens, 10),
+			strconv.FormatFloat(row.Cost, 'f', 4, 64),
+			strconv.FormatFloat(row.ReportedCost, 'f', 4, 64),
+			strconv.Itoa(row.UnpricedMessages),
+			strconv.Itoa(row.Compactions),
+		}
+		if report.GroupBy == pisessions.UsageGroupSession {
+			record = append(record, row.Title, row.RepoRoot)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeUsageHuman(w io.Writer, report *pisessions.UsageReport) error {
+	if len(report.Rows) == 0 {
+		fmt.Fprintln(w, "No usage found.")
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\tSESSIONS\tMSGS\tINPUT\tOUTPUT\tCACHE R\tCACHE W\tCOST\t\n", strings.ToUpper(report.GroupBy))
+	for _, row := range report.Rows {
+		label := row.Key
+		if report.GroupBy == pisessions.UsageGroupSession {
+			label = formatUsageSessionLabel(row)
+		} else if report.GroupBy == pisessions.UsageGroupRepo {
+			label = formatListPath(row.Key, "")
+		}
+		if row.Compactions > 0 {
+			label += " *"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", label, row.Sessions, row.Messages,
+			formatTokenCount(row.InputTokens), formatTokenCount(row.OutputTokens),
+			formatTokenCount(row.CacheRead), formatTokenCount(row.CacheWrite),
+			formatUsageCost(row.Cost, report.Currency))
+	}
+	total := report.Total
+	fmt.Fprintf(tw, "TOTAL\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t\n", total.Sessions, total.Messages,
+		formatTokenCount(total.InputTokens), formatTokenCount(total.OutputTokens),
+		formatTokenCount(total.CacheRead), formatTokenCount(total.CacheWrite),
+		formatUsageCost(total.Cost, report.Currency))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if total.UnpricedMessages > 0 {
+		fmt.Fprintf(w, "\n%d response(s) had no price in the pricing table and no reported cost.\n", total.UnpricedMessages)
+	}
+	if len(report.Compacted) > 0 {
+		fmt.Fprintf(w, "\n* %d session(s) hit compaction:\n", len(report.Compacted))
+		for _, session := range report.Compacted {
+			fmt.Fprintf(w, "  %s  %2dx  %8s  %s  %s\n", shortSessionID(session.SessionID), session.Compactions,
+				formatTokenCount(session.TotalTokens), formatUsageCost(session.Cost, report.Currency),
+				truncateMiddle(session.Title, 50))
+		}
+	}
+	return nil
+}
+
+func formatUsageSessionLabel(row pisessions.UsageRow) string {
+	return shortSessionID(row.Key) + "  " + truncateMiddle(row.Title, 40)
+}
+
+func shortSessionID(id string) string {
+	parts := strings.SplitN(id, "-", 3)
+	if len(parts) >= 2 {
+		return parts[0] + "-" + parts[1]
+	}
+	return id
+}
+
+// formatTokenCount renders token counts compactly (950, 12.4k, 3.1M).
+func formatTokenCount(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return strconv.FormatInt(n, 10)
+	}
+}
+
+func formatUsageCost(cost float64, currency string) string {
+	if currency == "" || currency == "USD" {
+		return fmt.Sprintf("$%.2f", cost)
+	}
+	return fmt.Sprintf("%.2f %s", cost, currency)
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Verify Command
- * Block-UUID: 9b3c4d5e-6f7a-8b9c-0d1e-2f3a4b5c6d7e
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Implements gsc pi sessions verify --lossless for byte-for-byte round-trip verification.
+ * Block-UUID: e437dd37-085d-4549-b2c5-e124cd68a826
+ * Parent-UUID: 9b3c4d5e-6f7a-8b9c-0d1e-2f3a4b5c6d7e
+ * Version: 1.1.0
+ * Description: Implements gsc pi sessions verify --lossless for byte-for-byte round-trip verification; reports lines that differ only by recorded redactions.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: MiMo-v2.5-Pro (v1.0.0)
+ * Authors: MiMo-v2.5-Pro (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -84,6 +84,9 @@ func writeVerifyReport(report *pisessions.LosslessReport, format string) error {
 		fmt.Printf("  Source: %s\n", report.SessionFile)
 		fmt.Printf("  Bytes: %d\n", report.SourceBytes)
 		fmt.Printf("  SHA-256: %s\n", report.SourceSHA256)
+		if report.RedactedLines > 0 {
+			fmt.Printf("  Redacted lines: %d (recorded redactions, source not rewritten)\n", report.RedactedLines)
+		}
 		if !report.Match {
 			fmt.Printf("  Reconstructed bytes: %d\n", report.ReconstructedBytes)
 			fmt.Printf("  Reconstructed SHA-256: %s\n", report.ReconstructedSHA256)
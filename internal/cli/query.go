@@ -1,12 +1,12 @@
 /**
  * Component: Query Command
- * Block-UUID: 1c8fb385-b394-4448-b376-e0f2430b4cd5
- * Parent-UUID: c42a2fca-fecd-4117-a53a-819110c43f54
- * Version: 3.28.0
+ * Block-UUID: b6fbda41-3aa9-4543-b997-9a3bad10ca05
+ * Parent-UUID: 1c8fb385-b394-4448-b376-e0f2430b4cd5
+ * Version: 3.29.0
  * Description: Simplified 'gsc query' interface by hiding subcommands (list, insights, coverage, fields, brains) and legacy flags (--field, --value). Updated examples to promote the --filter syntax.
  * Language: Go
  * Created-at: 2026-04-05T20:54:52.564Z
- * Authors: GLM-4.7 (v1.0.0), ..., GLM-4.7 (v3.26.0), claude-haiku-4-5-20251001 (v3.27.0), MiMo-v2.5-Pro (v3.28.0)
+ * Authors: GLM-4.7 (v1.0.0), ..., GLM-4.7 (v3.26.0), claude-haiku-4-5-20251001 (v3.27.0), MiMo-v2.5-Pro (v3.28.0), agent (v3.29.0)
  */
 
 package cli
@@ -106,7 +106,7 @@ If no filters are provided, it displays the current workspace context.`,
 
 			// 2. Hand off to bridge orchestrator
 			cmdStr := filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " ")
-			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, forceInsert)
+			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 		}
 
 		// Standard Output Mode
@@ -177,7 +177,7 @@ var queryListCmd = &cobra.Command{
 
 			// 2. Hand off to bridge orchestrator
 			cmdStr := filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " ")
-			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, forceInsert)
+			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 		}
 
 		// Standard Output Mode
@@ -220,7 +220,7 @@ Useful for identifying common patterns or unanalyzed areas.`,
 
 			// 2. Hand off to bridge orchestrator
 			cmdStr := filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " ")
-			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, forceInsert)
+			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 		}
 
 		// Standard Output Mode
@@ -268,7 +268,7 @@ files that have not yet been analyzed within the current focus scope.`,
 
 			// 2. Hand off to bridge orchestrator
 			cmdStr := filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " ")
-			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, forceInsert)
+			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 		}
 
 		// Standard Output Mode
@@ -312,7 +312,7 @@ and the metadata fields available in each. This is equivalent to running
 
 			// 2. Hand off to bridge orchestrator
 			cmdStr := filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " ")
-			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, forceInsert)
+			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 		}
 
 		// Standard Output Mode
@@ -376,7 +376,7 @@ Use 'gsc brains help' for detailed help.`,
 
 			// 2. Hand off to bridge orchestrator
 			cmdStr := filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " ")
-			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, forceInsert)
+			return bridge.Execute(bridgeCode, outputStr, queryFormat, cmdStr, time.Since(startTime), resolvedDB, 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 		}
 
 		// Standard Output Mode
@@ -1,12 +1,12 @@
 /**
  * Component: Root CLI Command
- * Block-UUID: 31e4f003-b411-4160-8a8e-774fcc30fa85
- * Parent-UUID: f31e3478-033a-4dc4-be11-f93e57d3ee93
- * Version: 1.51.0
- * Description: Registered the top-level lessons command group and excluded it from workspace preflight so lesson drafts can initialize GitSense state.
+ * Block-UUID: c0155dfb-808d-4b82-ad85-5264bb00a87d
+ * Parent-UUID: 458b4e68-f1d1-40d6-b1f0-ed3ba9cb2767
+ * Version: 1.55.0
+ * Description: Added the global --oversize flag, which selects how bridge output over the chat size limit is sent, and validate it before any command runs.
  * Language: Go
  * Created-at: 2026-06-12T12:44:13Z
- * Authors: GLM-4.7 (v1.34.0), Gemini 3 Flash (v1.35.0), Gemini 3 Flash (v1.36.0), GLM-4.7 (v1.37.0), Gemini 3 Flash (v1.38.0), Gemini 3 Flash (v1.39.0), GLM-4.7 (v1.40.0), claude-haiku-4-5-20251001 (v1.40.1), GLM-4.7 (v1.41.0), GLM-4.7 (v1.42.0), GLM-4.7 (v1.43.0), GLM-4.7 (v1.44.0), GLM-4.7 (v1.45.0), GLM-4.7 (v1.46.0), GLM-4.7 (v1.47.0), GLM-4.7 (v1.48.0), GLM-4.7 (v1.49.0), GLM-4.7 (v1.50.0), Codex GPT-5 (v1.51.0)
+ * Authors: GLM-4.7 (v1.34.0), Gemini 3 Flash (v1.35.0), Gemini 3 Flash (v1.36.0), GLM-4.7 (v1.37.0), Gemini 3 Flash (v1.38.0), Gemini 3 Flash (v1.39.0), GLM-4.7 (v1.40.0), claude-haiku-4-5-20251001 (v1.40.1), GLM-4.7 (v1.41.0), GLM-4.7 (v1.42.0), GLM-4.7 (v1.43.0), GLM-4.7 (v1.44.0), GLM-4.7 (v1.45.0), GLM-4.7 (v1.46.0), GLM-4.7 (v1.47.0), GLM-4.7 (v1.48.0), GLM-4.7 (v1.49.0), GLM-4.7 (v1.50.0), Codex GPT-5 (v1.51.0), agent (v1.52.0), agent (v1.53.0), agent (v1.54.0), agent (v1.55.0)
  */
 
 
@@ -31,6 +31,7 @@ import (
 	"github.com/gitsense/gsc-cli/internal/cli/topics"
 	"github.com/gitsense/gsc-cli/internal/cli/manifest"
 	"github.com/gitsense/gsc-cli/internal/cli/pi"
+	"github.com/gitsense/gsc-cli/internal/cli/top"
 	docker_internal "github.com/gitsense/gsc-cli/internal/docker"
 	manifestpkg "github.com/gitsense/gsc-cli/internal/manifest"
 	"github.com/gitsense/gsc-cli/internal/version"
@@ -42,6 +43,7 @@ import (
 var (
 	bridgeCode   string
 	forceInsert  bool
+	oversizeMode string
 	showExamples bool
 	rootFormat   string
 )
@@ -83,6 +85,12 @@ AI ASSISTANT DISCOVERY:
 			}
 		}
 
+		// 1b. Oversized bridge output handling applies to every command with --code
+		if err := bridge.ValidateOversizeMode(oversizeMode); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
 		// 2. Smart Proxy Interceptor
 		// If a Docker context is active and the command is proxyable, redirect to the container.
 		// Check if we are already inside a container to prevent recursive loops.
@@ -125,7 +133,7 @@ AI ASSISTANT DISCOVERY:
 			if bridgeCode != "" {
 				fmt.Print(output)
 				cmdStr := "gsc --examples --format " + rootFormat
-				return bridge.Execute(bridgeCode, output, rootFormat, cmdStr, time.Since(startTime), "internal", 0, forceInsert)
+				return bridge.Execute(bridgeCode, output, rootFormat, cmdStr, time.Since(startTime), "internal", 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 			}
 
 			fmt.Print(output)
@@ -149,6 +157,7 @@ func init() {
 	RegisterGrepCommand(rootCmd)
 	RegisterTreeCommand(rootCmd)
 	RegisterInfoCommand(rootCmd)
+	RegisterContextCommand(rootCmd)
 
 	// Commands moved to 'app' group are now registered there
 	// contract.RegisterContractCommand(rootCmd) // REMOVED
@@ -169,6 +178,7 @@ func init() {
 	rootCmd.AddCommand(topics.NewCmd())
 	rootCmd.AddCommand(knowledge.NewCmd())
 	rootCmd.AddCommand(pi.NewCmd())
+	rootCmd.AddCommand(top.NewCmd())
 	rootCmd.AddCommand(newVersionCmd())
 
 	// Aliases removed
@@ -179,7 +189,8 @@ func init() {
 	rootCmd.PersistentFlags().CountP("verbose", "c", "Increase verbosity (-c for info, -cc for debug)")
 	rootCmd.PersistentFlags().Bool("quiet", false, "Suppress all output except errors")
 	rootCmd.PersistentFlags().StringVar(&bridgeCode, "code", "", "Bridge code for chat integration (6 digits)")
-	rootCmd.PersistentFlags().BoolVar(&forceInsert, "force", false, "Skip confirmation prompt")
+	rootCmd.PersistentFlags().BoolVar(&forceInsert, "force", false, "Skip confirmation prompts; oversized output is inserted in full unless --oversize is set")
+	rootCmd.PersistentFlags().StringVar(&oversizeMode, "oversize", bridge.OversizeAsk, "Output over the chat size limit: ask, chunk (linked messages), summary, attach (save to a file), force, abort; any mode but ask also skips the confirmation prompt")
 
 	// Examples Flag
 	rootCmd.Flags().BoolVar(&showExamples, "examples", false, "Show structured usage examples for humans and AI")
@@ -193,7 +204,7 @@ func init() {
 // as well as specific top-level commands (e.g., 'init', 'doctor').
 func isExcludedCommand(cmd *cobra.Command) bool {
 	// Removed "contract", "ws", "exec", "chats", "messages", "send" as they are now under "app"
-	excludedRoots := []string{"init", "doctor", "tree", "docker", "app", "claude", "import", "manifest", "docs", "gitignore", "lessons", "rules", "pi", "brains", "version", "experts"}
+	excludedRoots := []string{"init", "doctor", "tree", "docker", "app", "claude", "import", "manifest", "docs", "gitignore", "lessons", "rules", "pi", "brains", "version", "experts", "context", "top"}
 	current := cmd
 
 	for current != nil {
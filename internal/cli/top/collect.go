@@ -0,0 +1,346 @@
+/**
+ * Component: Top Session Collector
+ * Block-UUID: 4c1e8a27-9b53-4d6f-a2e0-7f3b5d9c1e84
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Collects one row per active or recent agent session for `gsc top`: intent-workflow sessions (labelled scout or change by their latest turn) and the Pi sync watcher, with liveness from the recorded PIDs, elapsed time, cost, and a one-line summary of the last logged event. Also maps each row to the existing stop and retry commands.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package top
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	app "github.com/gitsense/gsc-cli/internal/app"
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+)
+
+// Session kinds shown in the KIND column
+const (
+	KindScout          = "scout"
+	KindChange         = "change"
+	KindIntentWorkflow = "intent-workflow"
+	KindPiSync         = "pi-sync"
+)
+
+// piSyncID identifies the Pi sync watcher row; there is one per GSC_HOME.
+const piSyncID = "watcher"
+
+// tailReadBytes bounds how much of a log is read to find its last lines.
+const tailReadBytes = 64 * 1024
+
+// Entry is one row of the dashboard.
+type Entry struct {
+	Kind           string    `json:"kind"`
+	ID             string    `json:"id"`
+	Status         string    `json:"status"`
+	Running        bool      `json:"running"`
+	PID            int       `json:"pid,omitempty"`
+	Turn           int       `json:"turn,omitempty"`
+	TurnType       string    `json:"turn_type,omitempty"`
+	Intent         string    `json:"intent,omitempty"`
+	StartedAt      time.Time `json:"started_at"`
+	ElapsedSeconds int64     `json:"elapsed_seconds"`
+	Cost           *float64  `json:"cost,omitempty"`
+	LastEvent      string    `json:"last_event,omitempty"`
+	LastActivity   time.Time `json:"last_activity"`
+	LogPath        string    `json:"log_path,omitempty"`
+	Dir            string    `json:"dir"`
+}
+
+// Collect returns the running sessions plus those active within recent
+// (all sessions when recent is 0), running first and then most recently
+// active first. Unreadable sessions are skipped.
+func Collect(gscHome string, recent time.Duration, now time.Time) ([]Entry, error) {
+	ids, err := intent_workflow.ListSessions(gscHome)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, id := range ids {
+		manager, err := intent_workflow.LoadSession(id)
+		if err != nil {
+			continue
+		}
+		entries = append(entries, sessionEntry(manager.GetSession(), intent_workflow.BaseAgentDir(gscHome), now))
+	}
+	if entry, ok := piSyncEntry(gscHome, now); ok {
+		entries = append(entries, entry)
+	}
+
+	kept := entries[:0]
+	for _, entry := range entries {
+		if entry.Running || recent <= 0 || now.Sub(entry.LastActivity) <= recent {
+			kept = append(kept, entry)
+		}
+	}
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].Running != kept[j].Running {
+			return kept[i].Running
+		}
+		return kept[i].LastActivity.After(kept[j].LastActivity)
+	})
+	return kept, nil
+}
+
+// sessionEntry summarizes an intent-workflow session. The latest turn
+// decides the kind: discovery and validation turns are scout work, change
+// turns are change work.
+func sessionEntry(session *intent_workflow.Session, baseDir string, now time.Time) Entry {
+	entry := Entry{
+		Kind:         KindIntentWorkflow,
+		ID:           session.SessionID,
+		Status:       session.Status,
+		Intent:       session.Intent,
+		StartedAt:    session.StartedAt,
+		LastActivity: session.StartedAt,
+		Dir:          session.SessionDir,
+	}
+	if entry.Dir == "" {
+		entry.Dir = filepath.Join(baseDir, session.SessionID)
+	}
+	if spent := session.Spend(); spent.Turns > 0 {
+		entry.Cost = &spent.Cost
+	}
+
+	end := session.CompletedAt
+	if len(session.Turns) > 0 {
+		turn := session.Turns[len(session.Turns)-1]
+		entry.Turn = turn.TurnNumber
+		entry.TurnType = turn.TurnType
+		entry.LogPath = turn.LogPath
+		entry.PID = turn.ProcessInfo.PID
+		switch turn.TurnType {
+		case "discovery", "validation":
+			entry.Kind = KindScout
+		case "change", "resume-change":
+			entry.Kind = KindChange
+		}
+		if turn.StartedAt.After(entry.LastActivity) {
+			entry.LastActivity = turn.StartedAt
+		}
+		if turn.CompletedAt != nil && (end == nil || turn.CompletedAt.After(*end)) {
+			end = turn.CompletedAt
+		}
+		if turn.Status == "running" {
+			entry.Running = processAlive(turn.ProcessInfo)
+			if !entry.Running {
+				// The worker died without recording an outcome
+				entry.Status = "exited"
+			}
+		}
+	}
+
+	if entry.LogPath != "" {
+		if info, err := os.Stat(entry.LogPath); err == nil && info.ModTime().After(entry.LastActivity) {
+			entry.LastActivity = info.ModTime()
+		}
+		if lines := lastLines(entry.LogPath, 1); len(lines) > 0 {
+			entry.LastEvent = describeEvent(lines[0])
+		}
+	}
+	if end != nil && end.After(entry.LastActivity) {
+		entry.LastActivity = *end
+	}
+
+	switch {
+	case entry.Running:
+		entry.ElapsedSeconds = int64(now.Sub(session.StartedAt).Seconds())
+	case end != nil:
+		entry.ElapsedSeconds = int64(end.Sub(session.StartedAt).Seconds())
+	default:
+		entry.ElapsedSeconds = int64(entry.LastActivity.Sub(session.StartedAt).Seconds())
+	}
+	return entry
+}
+
+// piSyncEntry summarizes the Pi sync watcher. It is listed while running,
+// or when it has a log from an earlier run.
+func piSyncEntry(gscHome string, now time.Time) (Entry, bool) {
+	dataDir := settings.GetPiGscDataDir(gscHome)
+	logPath := settings.GetPiSyncLogPath(gscHome)
+	running, pid, _, _ := app.IsProcessRunning(dataDir)
+
+	entry := Entry{Kind: KindPiSync, ID: piSyncID, Status: "stopped", Running: running, Dir: dataDir, LogPath: logPath}
+	logInfo, logErr := os.Stat(logPath)
+	if !running && logErr != nil {
+		return Entry{}, false
+	}
+	if logErr == nil {
+		entry.LastActivity = logInfo.ModTime()
+		entry.StartedAt = logInfo.ModTime()
+		if lines := lastLines(logPath, 1); len(lines) > 0 {
+			entry.LastEvent = describeEvent(lines[0])
+		}
+	}
+	if running {
+		entry.Status = "running"
+		entry.PID = pid
+		// The PID file is written when the watcher starts
+		if info, err := os.Stat(settings.GetPiSyncPIDPath(gscHome)); err == nil {
+			entry.StartedAt = info.ModTime()
+			entry.ElapsedSeconds = int64(now.Sub(info.ModTime()).Seconds())
+		}
+	}
+	return entry, true
+}
+
+// processAlive reports whether a turn's worker process still exists.
+// Backends that do not run an OS process report no PID.
+func processAlive(info intent_workflow.ProcessInfo) bool {
+	if info.PID <= 0 {
+		return info.Running
+	}
+	process, err := os.FindProcess(info.PID)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// StopArgs returns the gsc arguments that stop the entry, the same command a
+// user would run for that subsystem.
+func StopArgs(entry Entry) []string {
+	switch entry.Kind {
+	case KindPiSync:
+		return []string{"pi", "sessions", "sync", "stop"}
+	case KindScout:
+		return []string{"claude", "scout", "stop", "--session", entry.ID}
+	case KindChange:
+		return []string{"claude", "change", "stop", "--session", entry.ID}
+	default:
+		return []string{"claude", "intent-workflow", "stop", "--session", entry.ID}
+	}
+}
+
+// RetryArgs returns the gsc arguments that retry the entry's last turn, or
+// restart the Pi sync watcher.
+func RetryArgs(entry Entry) []string {
+	if entry.Kind == KindPiSync {
+		return []string{"pi", "sessions", "sync", "start", "--detach"}
+	}
+	return []string{"claude", "intent-workflow", "retry", "--session", entry.ID}
+}
+
+// lastLines returns up to n non-empty lines from the end of a file.
+func lastLines(path string, n int) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil
+	}
+	offset := info.Size() - tailReadBytes
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil
+	}
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil
+	}
+
+	all := strings.Split(string(data), "\n")
+	if offset > 0 && len(all) > 0 {
+		all = all[1:] // partial first line
+	}
+	var lines []string
+	for i := len(all) - 1; i >= 0 && len(lines) < n; i-- {
+		if line := strings.TrimSpace(all[i]); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	for i, j := 0, len(lines)-1; i < j; i, j = i+1, j-1 {
+		lines[i], lines[j] = lines[j], lines[i]
+	}
+	return lines
+}
+
+// describeEvent renders a log line as a short summary. Turn logs mix the
+// workflow's own events ({timestamp, type, data}) with the agent's raw
+// stream-json; the sync log is plain "<time> <LEVEL> <message>" text.
+func describeEvent(line string) string {
+	var event struct {
+		Timestamp string          `json:"timestamp"`
+		Type      string          `json:"type"`
+		Subtype   string          `json:"subtype"`
+		Data      json.RawMessage `json:"data"`
+		Message   *struct {
+			Content []struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+				Text string `json:"text"`
+			} `json:"content"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal([]byte(line), &event); err != nil || event.Type == "" {
+		// Plain text: drop a leading RFC 3339 timestamp
+		if fields := strings.SplitN(line, " ", 2); len(fields) == 2 {
+			if _, err := time.Parse(time.RFC3339, fields[0]); err == nil {
+				return singleLine(fields[1])
+			}
+		}
+		return singleLine(line)
+	}
+
+	if event.Timestamp != "" && event.Data != nil {
+		var data struct {
+			Message   string `json:"message"`
+			ErrorCode string `json:"error_code"`
+			Status    string `json:"status"`
+		}
+		json.Unmarshal(event.Data, &data)
+		switch {
+		case event.Type == "error":
+			return singleLine("error: " + strings.TrimSpace(data.ErrorCode+" "+data.Message))
+		case data.Message != "":
+			return singleLine(event.Type + ": " + data.Message)
+		case data.Status != "":
+			return event.Type + ": " + data.Status
+		}
+		return event.Type
+	}
+
+	switch event.Type {
+	case "assistant":
+		if event.Message != nil && len(event.Message.Content) > 0 {
+			last := event.Message.Content[len(event.Message.Content)-1]
+			switch last.Type {
+			case "tool_use":
+				return "tool: " + last.Name
+			case "text":
+				return singleLine("assistant: " + last.Text)
+			}
+		}
+		return "assistant"
+	case "user":
+		return "tool result"
+	}
+	if event.Subtype != "" {
+		return event.Type + ": " + event.Subtype
+	}
+	return event.Type
+}
+
+// singleLine collapses whitespace so a summary fits on one row.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
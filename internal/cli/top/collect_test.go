@@ -0,0 +1,149 @@
+/**
+ * Component: Top Session Collector Tests
+ * Block-UUID: 6b9e3d15-7c42-4a80-8f6e-d1a5c7b2e093
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests that the top collector labels sessions by their latest turn, detects live and dead workers, filters by the recent window, lists the Pi sync watcher from its log, summarizes log lines, and routes stop and retry to each subsystem's command.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package top
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/claude/intent-workflow"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+)
+
+// writeSession stores a session with one turn and the given log lines.
+func writeSession(t *testing.T, gscHome string, session intent_workflow.Session, logLines ...string) {
+	t.Helper()
+	dir := filepath.Join(intent_workflow.BaseAgentDir(gscHome), session.SessionID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	session.SessionDir = dir
+	if len(logLines) > 0 {
+		logPath := filepath.Join(dir, "turn.ndjson")
+		if err := os.WriteFile(logPath, []byte(strings.Join(logLines, "\n")+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		session.Turns[len(session.Turns)-1].LogPath = logPath
+		mtime := session.Turns[len(session.Turns)-1].StartedAt
+		os.Chtimes(logPath, mtime, mtime)
+	}
+	data, err := json.Marshal(session)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "session.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCollectListsRunningAndRecentSessions(t *testing.T) {
+	gscHome := t.TempDir()
+	t.Setenv("GSC_HOME", gscHome)
+	now := time.Now()
+	cost := 0.25
+
+	// A discovery turn whose worker is this test process
+	writeSession(t, gscHome, intent_workflow.Session{
+		SessionID: "live", Status: "discovery", StartedAt: now.Add(-90 * time.Second),
+		Turns: []intent_workflow.TurnState{{TurnNumber: 1, TurnType: "discovery", Status: "running", StartedAt: now.Add(-90 * time.Second),
+			ProcessInfo: intent_workflow.ProcessInfo{PID: os.Getpid(), Running: true}}},
+	}, `{"timestamp":"2026-10-18T22:00:00Z","type":"status","data":{"phase":"discovery","message":"Searching   api"}}`,
+		`{"type":"assistant","message":{"content":[{"type":"text","text":"Looking"},{"type":"tool_use","name":"Grep"}]}}`)
+
+	// A change turn that still claims to run, but its worker is gone
+	writeSession(t, gscHome, intent_workflow.Session{
+		SessionID: "dead", Status: "change", StartedAt: now.Add(-time.Hour),
+		Turns: []intent_workflow.TurnState{{TurnNumber: 2, TurnType: "change", Status: "running", StartedAt: now.Add(-time.Hour), Cost: &cost,
+			ProcessInfo: intent_workflow.ProcessInfo{PID: 99999999, Running: true}}},
+	})
+
+	// Finished three days ago
+	completed := now.Add(-72 * time.Hour)
+	writeSession(t, gscHome, intent_workflow.Session{
+		SessionID: "old", Status: "change_complete", StartedAt: completed.Add(-time.Minute), CompletedAt: &completed,
+		Turns: []intent_workflow.TurnState{{TurnNumber: 1, TurnType: "change", Status: "complete", StartedAt: completed.Add(-time.Minute), CompletedAt: &completed}},
+	})
+
+	// A sync watcher that ran earlier
+	if err := os.MkdirAll(settings.GetPiGscDataDir(gscHome), 0755); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(settings.GetPiSyncLogPath(gscHome), []byte("2026-10-18T21:00:00Z INFO synced session.jsonl\n"), 0644)
+
+	entries, err := Collect(gscHome, 24*time.Hour, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	live := entries[0]
+	if live.ID != "live" || live.Kind != KindScout || !live.Running || live.LastEvent != "tool: Grep" || live.ElapsedSeconds < 90 {
+		t.Fatalf("live = %+v", live)
+	}
+	var dead, sync Entry
+	for _, entry := range entries[1:] {
+		switch entry.ID {
+		case "dead":
+			dead = entry
+		case piSyncID:
+			sync = entry
+		}
+	}
+	if dead.Kind != KindChange || dead.Running || dead.Status != "exited" || dead.Cost == nil || *dead.Cost != 0.25 {
+		t.Fatalf("dead = %+v", dead)
+	}
+	if sync.Kind != KindPiSync || sync.Running || sync.LastEvent != "INFO synced session.jsonl" {
+		t.Fatalf("sync = %+v", sync)
+	}
+
+	// A zero window lists everything
+	entries, err = Collect(gscHome, 0, now)
+	if err != nil || len(entries) != 4 || entries[len(entries)-1].ID != "old" {
+		t.Fatalf("all entries = %+v, %v", entries, err)
+	}
+	if entries[len(entries)-1].ElapsedSeconds != 60 {
+		t.Fatalf("old elapsed = %d", entries[len(entries)-1].ElapsedSeconds)
+	}
+}
+
+func TestDescribeEvent(t *testing.T) {
+	cases := map[string]string{
+		`{"timestamp":"t","type":"error","data":{"error_code":"BUDGET","message":"over"}}`: "error: BUDGET over",
+		`{"timestamp":"t","type":"done","data":{"status":"success"}}`:                      "done: success",
+		`{"type":"result","subtype":"success"}`:                                            "result: success",
+		`{"type":"user","message":{"content":[{"type":"tool_result"}]}}`:                   "tool result",
+		"not json at all": "not json at all",
+	}
+	for line, want := range cases {
+		if got := describeEvent(line); got != want {
+			t.Errorf("describeEvent(%s) = %q, want %q", line, got, want)
+		}
+	}
+}
+
+func TestActionsUseSubsystemCommands(t *testing.T) {
+	if got := strings.Join(StopArgs(Entry{Kind: KindScout, ID: "s1"}), " "); got != "claude scout stop --session s1" {
+		t.Fatalf("scout stop = %s", got)
+	}
+	if got := strings.Join(StopArgs(Entry{Kind: KindPiSync, ID: piSyncID}), " "); got != "pi sessions sync stop" {
+		t.Fatalf("sync stop = %s", got)
+	}
+	if got := strings.Join(RetryArgs(Entry{Kind: KindChange, ID: "c1"}), " "); got != "claude intent-workflow retry --session c1" {
+		t.Fatalf("change retry = %s", got)
+	}
+}
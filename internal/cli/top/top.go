@@ -0,0 +1,159 @@
+/**
+ * Component: Top Command
+ * Block-UUID: 1f7a4c93-6d28-4e5b-9a01-b3e8d5c2f746
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Defines `gsc top`, a dashboard of every active and recent intent-workflow, scout, and change session and the Pi sync watcher. Runs the Bubble Tea dashboard on a terminal, and prints a one-shot table or JSON snapshot with --once, --format json, or when output is not a terminal.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package top
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gitsense/gsc-cli/internal/output"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+	"github.com/spf13/cobra"
+)
+
+// NewCmd creates the top command
+func NewCmd() *cobra.Command {
+	var recent time.Duration
+	var once bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Dashboard of running and recent agent sessions",
+		Long: `Show every running intent-workflow, scout, and change session, plus those
+active within --recent, and the Pi sync watcher, with status, current turn,
+elapsed time, cost, and last event. The view refreshes every few seconds.
+
+Keys:
+  ↑/↓, j/k   Select a session
+  t, enter   Tail the session's events (t or esc to go back)
+  s          Stop the session (asks first); uses the subsystem's own stop
+  r          Retry the session's last turn, or restart the sync watcher
+  o          Open a shell in the session directory (exit to return)
+  q          Quit
+
+With --once, --format json, or when output is not a terminal, top prints a
+single snapshot instead.`,
+		Example: `  # Live dashboard
+  gsc top
+
+  # Everything from the past week, as JSON
+  gsc top --recent 168h --format json`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != "text" && format != "json" {
+				return fmt.Errorf("invalid format: %s (must be text or json)", format)
+			}
+			gscHome, err := settings.GetGSCHome(false)
+			if err != nil {
+				return fmt.Errorf("failed to resolve GSC_HOME: %w", err)
+			}
+
+			if once || format == "json" || !output.IsTerminal() {
+				return printSnapshot(cmd, gscHome, recent, format)
+			}
+
+			self, err := os.Executable()
+			if err != nil {
+				return fmt.Errorf("cannot locate gsc executable: %w", err)
+			}
+			_, err = tea.NewProgram(newTopModel(gscHome, recent, self), tea.WithAltScreen()).Run()
+			return err
+		},
+	}
+
+	cmd.Flags().DurationVar(&recent, "recent", 24*time.Hour, "Also list sessions active within this window (0 lists all)")
+	cmd.Flags().BoolVar(&once, "once", false, "Print a single snapshot and exit")
+	cmd.Flags().StringVar(&format, "format", "text", "Snapshot format (text, json)")
+
+	return cmd
+}
+
+// printSnapshot writes the current sessions once.
+func printSnapshot(cmd *cobra.Command, gscHome string, recent time.Duration, format string) error {
+	now := time.Now()
+	entries, err := Collect(gscHome, recent, now)
+	if err != nil {
+		return err
+	}
+
+	if format == "json" {
+		if entries == nil {
+			entries = []Entry{}
+		}
+		data, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal JSON response: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+
+	if len(entries) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No running or recent sessions.")
+		return nil
+	}
+	fmt.Fprintln(cmd.OutOrStdout(), strings.Join(FormatTable(entries, now), "\n"))
+	return nil
+}
+
+// FormatTable renders entries as aligned columns, header first.
+func FormatTable(entries []Entry, now time.Time) []string {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tID\tSTATUS\tTURN\tELAPSED\tCOST\tLAST EVENT")
+	for _, entry := range entries {
+		turn := "-"
+		if entry.Turn > 0 {
+			turn = fmt.Sprintf("%d %s", entry.Turn, entry.TurnType)
+		}
+		cost := "-"
+		if entry.Cost != nil {
+			cost = fmt.Sprintf("$%.4f", *entry.Cost)
+		}
+		status := entry.Status
+		if entry.Running && entry.PID > 0 {
+			status = fmt.Sprintf("%s (%d)", status, entry.PID)
+		}
+		lastEvent := entry.LastEvent
+		if lastEvent == "" {
+			lastEvent = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Kind, entry.ID, status, turn, formatElapsed(time.Duration(entry.ElapsedSeconds)*time.Second), cost, truncate(lastEvent, 80))
+	}
+	tw.Flush()
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
+
+// formatElapsed renders a duration compactly: 45s, 12m 5s, 3h 20m.
+func formatElapsed(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm %ds", int(d.Minutes()), int(d.Seconds())%60)
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	}
+}
@@ -0,0 +1,372 @@
+/**
+ * Component: Top Dashboard TUI
+ * Block-UUID: 8e5d2b71-3a6c-4f09-b1d4-c92e7a6f0b35
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Bubble Tea model for `gsc top`. Re-collects sessions on a tick and renders them as a table with status, current turn, elapsed time, cost, and last event. Keys tail the selected session's events, stop it (after confirmation) or retry it by running the subsystem's own gsc command, or open a shell in its directory.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package top
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// topRefresh is how often the dashboard re-reads session state and logs.
+const topRefresh = 2 * time.Second
+
+type topModel struct {
+	gscHome string
+	recent  time.Duration
+	self    string
+
+	entries []Entry
+	cursor  int
+	err     error
+	updated time.Time
+
+	tailing bool     // Showing the selected session's events
+	tail    []string // Last lines of the tailed log
+
+	confirmStop bool   // Waiting for y/n before stopping the selection
+	message     string // Result of the last action
+
+	width  int
+	height int
+}
+
+type topTickMsg time.Time
+
+type topDataMsg struct {
+	entries []Entry
+	tail    []string
+	err     error
+}
+
+type topActionMsg struct {
+	label  string
+	output string
+	err    error
+}
+
+func newTopModel(gscHome string, recent time.Duration, self string) topModel {
+	return topModel{gscHome: gscHome, recent: recent, self: self, width: 120, height: 30}
+}
+
+func (m topModel) Init() tea.Cmd {
+	return tea.Batch(m.fetchCmd(), topTick())
+}
+
+func topTick() tea.Cmd {
+	return tea.Tick(topRefresh, func(t time.Time) tea.Msg { return topTickMsg(t) })
+}
+
+// fetchCmd collects sessions and, while tailing, the selected log's last lines.
+func (m topModel) fetchCmd() tea.Cmd {
+	gscHome, recent := m.gscHome, m.recent
+	tailPath := ""
+	if entry, ok := m.selected(); ok && m.tailing {
+		tailPath = entry.LogPath
+	}
+	tailLines := m.tailLines()
+	return func() tea.Msg {
+		entries, err := Collect(gscHome, recent, time.Now())
+		msg := topDataMsg{entries: entries, err: err}
+		if tailPath != "" {
+			msg.tail = lastLines(tailPath, tailLines)
+		}
+		return msg
+	}
+}
+
+// tailLines is how many log lines fit below the tail header.
+func (m topModel) tailLines() int {
+	if n := m.height - 6; n > 5 {
+		return n
+	}
+	return 5
+}
+
+func (m topModel) selected() (Entry, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.entries) {
+		return Entry{}, false
+	}
+	return m.entries[m.cursor], true
+}
+
+// runGsc runs a gsc command for an action and reports its output.
+func (m topModel) runGsc(label string, args []string) tea.Cmd {
+	self := m.self
+	return func() tea.Msg {
+		out, err := exec.Command(self, args...).CombinedOutput()
+		return topActionMsg{label: label, output: strings.TrimSpace(string(out)), err: err}
+	}
+}
+
+func (m topModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case topDataMsg:
+		m.err = msg.err
+		if msg.err == nil {
+			// Keep the selection on the same session as rows reorder
+			current, ok := m.selected()
+			m.entries = msg.entries
+			m.cursor = 0
+			if ok {
+				for i, entry := range m.entries {
+					if entry.Kind == current.Kind && entry.ID == current.ID {
+						m.cursor = i
+						break
+					}
+				}
+			}
+			m.updated = time.Now()
+		}
+		if m.tailing {
+			m.tail = msg.tail
+		}
+		return m, nil
+	case topTickMsg:
+		return m, tea.Batch(m.fetchCmd(), topTick())
+	case topActionMsg:
+		m.message = actionMessage(msg)
+		return m, m.fetchCmd()
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	}
+	return m, nil
+}
+
+func (m topModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	if m.confirmStop {
+		m.confirmStop = false
+		entry, ok := m.selected()
+		if (key == "y" || key == "Y") && ok {
+			m.message = fmt.Sprintf("stopping %s…", entry.ID)
+			return m, m.runGsc("stop "+entry.ID, StopArgs(entry))
+		}
+		m.message = "stop cancelled"
+		return m, nil
+	}
+
+	entry, ok := m.selected()
+	switch key {
+	case "q":
+		return m, tea.Quit
+	case "esc":
+		if m.tailing {
+			m.tailing = false
+			return m, nil
+		}
+		return m, tea.Quit
+	case "up", "k":
+		if m.cursor > 0 && !m.tailing {
+			m.cursor--
+		}
+	case "down", "j":
+		if m.cursor < len(m.entries)-1 && !m.tailing {
+			m.cursor++
+		}
+	case "t", "enter":
+		if !ok {
+			return m, nil
+		}
+		if m.tailing {
+			m.tailing = false
+			return m, nil
+		}
+		if entry.LogPath == "" {
+			m.message = entry.ID + " has no event log yet"
+			return m, nil
+		}
+		m.tailing = true
+		m.tail = nil
+		return m, m.fetchCmd()
+	case "s":
+		if !ok {
+			return m, nil
+		}
+		if !entry.Running {
+			m.message = entry.ID + " is not running"
+			return m, nil
+		}
+		m.confirmStop = true
+	case "r":
+		if !ok {
+			return m, nil
+		}
+		if entry.Running {
+			m.message = entry.ID + " is still running; stop it before retrying"
+			return m, nil
+		}
+		m.message = fmt.Sprintf("retrying %s…", entry.ID)
+		return m, m.runGsc("retry "+entry.ID, RetryArgs(entry))
+	case "o":
+		if !ok {
+			return m, nil
+		}
+		if _, err := os.Stat(entry.Dir); err != nil {
+			m.message = "directory not found: " + entry.Dir
+			return m, nil
+		}
+		shell := os.Getenv("SHELL")
+		if shell == "" {
+			shell = "/bin/sh"
+		}
+		cmd := exec.Command(shell)
+		cmd.Dir = entry.Dir
+		return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+			return topActionMsg{label: "shell in " + entry.Dir, err: err}
+		})
+	}
+	return m, nil
+}
+
+// actionMessage summarizes an action's outcome for the status line.
+func actionMessage(msg topActionMsg) string {
+	if msg.err != nil {
+		detail := msg.err.Error()
+		if msg.output != "" {
+			detail = msg.output
+		}
+		return singleLine(fmt.Sprintf("%s failed: %s", msg.label, detail))
+	}
+	if msg.output == "" {
+		return msg.label + ": done"
+	}
+	lines := strings.Split(msg.output, "\n")
+	return singleLine(msg.label + ": " + lines[len(lines)-1])
+}
+
+// Styling follows the Pi HUD: flat greys, with color only for running
+// sessions, the selection, and errors.
+var (
+	topHeader   = lipgloss.NewStyle().Foreground(lipgloss.Color("250"))
+	topDim      = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	topRunning  = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	topSelected = lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
+	topErr      = lipgloss.NewStyle().Foreground(lipgloss.Color("131"))
+)
+
+func (m topModel) View() string {
+	var b strings.Builder
+	running := 0
+	for _, entry := range m.entries {
+		if entry.Running {
+			running++
+		}
+	}
+	title := fmt.Sprintf("GSC TOP  %d running · %d listed", running, len(m.entries))
+	if !m.updated.IsZero() {
+		title += " · updated " + m.updated.Format("15:04:05")
+	}
+	b.WriteString(topHeader.Render(title) + "\n")
+	b.WriteString(topDim.Render(strings.Repeat("─", m.width)) + "\n")
+
+	if m.err != nil {
+		b.WriteString(topErr.Render(truncate("error: "+m.err.Error(), m.width)) + "\n")
+	}
+
+	if m.tailing {
+		m.writeTail(&b)
+	} else {
+		m.writeTable(&b)
+	}
+
+	b.WriteString("\n")
+	if m.confirmStop {
+		if entry, ok := m.selected(); ok {
+			b.WriteString(topErr.Render(fmt.Sprintf("Stop %s %s? (y/n)", entry.Kind, entry.ID)) + "\n")
+		}
+	} else if m.message != "" {
+		b.WriteString(truncate(m.message, m.width) + "\n")
+	}
+	if m.tailing {
+		b.WriteString(topDim.Render("t/esc back · s stop · r retry · o open dir · q quit"))
+	} else {
+		b.WriteString(topDim.Render("↑/↓ select · t tail events · s stop · r retry · o open dir · q quit"))
+	}
+	return b.String()
+}
+
+func (m topModel) writeTable(b *strings.Builder) {
+	if len(m.entries) == 0 {
+		b.WriteString(topDim.Render("No running or recent sessions.") + "\n")
+		return
+	}
+
+	lines := FormatTable(m.entries, time.Now())
+	b.WriteString(topDim.Render(truncate("  "+lines[0], m.width)) + "\n")
+
+	// Keep the cursor visible when there are more rows than fit
+	rows := lines[1:]
+	visible := m.height - 7
+	if visible < 3 {
+		visible = 3
+	}
+	start := 0
+	if m.cursor >= visible {
+		start = m.cursor - visible + 1
+	}
+	for i := start; i < len(rows) && i < start+visible; i++ {
+		line := truncate(rows[i], m.width)
+		switch {
+		case i == m.cursor:
+			b.WriteString(topSelected.Render("▸ "+line) + "\n")
+		case m.entries[i].Running:
+			b.WriteString(topRunning.Render("  "+line) + "\n")
+		default:
+			b.WriteString("  " + line + "\n")
+		}
+	}
+}
+
+func (m topModel) writeTail(b *strings.Builder) {
+	entry, ok := m.selected()
+	if !ok {
+		return
+	}
+	b.WriteString(topHeader.Render(fmt.Sprintf("%s %s · turn %d %s · %s", entry.Kind, entry.ID, entry.Turn, entry.TurnType, entry.Status)) + "\n")
+	b.WriteString(topDim.Render(truncate(entry.LogPath, m.width)) + "\n\n")
+	if len(m.tail) == 0 {
+		b.WriteString(topDim.Render("no events yet") + "\n")
+		return
+	}
+	for _, line := range m.tail {
+		b.WriteString(truncate(describeEvent(line), m.width) + "\n")
+	}
+}
+
+// truncate shortens s to at most n display columns.
+func truncate(s string, n int) string {
+	if n <= 0 || lipgloss.Width(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if n > len(runes) {
+		n = len(runes)
+	}
+	if n <= 1 {
+		return string(runes[:n])
+	}
+	return string(runes[:n-1]) + "…"
+}
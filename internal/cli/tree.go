@@ -1,12 +1,12 @@
 /**
  * Component: Tree Command
- * Block-UUID: 9a46883d-b8c7-4229-b3e2-5417914ec929
- * Parent-UUID: 83d73414-ec64-4880-8c42-9eb7942a626a
- * Version: 1.12.0
+ * Block-UUID: a8b411da-b5a0-4847-8827-3f9bd9118ee3
+ * Parent-UUID: 9a46883d-b8c7-4229-b3e2-5417914ec929
+ * Version: 1.13.0
  * Description: Updated JSON and AI-portable rendering to pass repoTotalFiles parameter, enabling display of repository total alongside filtered count in JSON output.
  * Language: Go
  * Created-at: 2026-04-27T17:10:45.457Z
- * Authors: GLM-4.7 (v1.7.1), GLM-4.7 (v1.7.2), GLM-4.7 (v1.8.0), GLM-4.7 (v1.8.1), GLM-4.7 (v1.9.0), GLM-4.7 (v1.9.1), claude-haiku-4-5-20251001 (v1.10.0), GLM-4.7 (v1.10.1), GLM-4.7 (v1.11.0), GLM-4.7 (v1.12.0)
+ * Authors: GLM-4.7 (v1.7.1), GLM-4.7 (v1.7.2), GLM-4.7 (v1.8.0), GLM-4.7 (v1.8.1), GLM-4.7 (v1.9.0), GLM-4.7 (v1.9.1), claude-haiku-4-5-20251001 (v1.10.0), GLM-4.7 (v1.10.1), GLM-4.7 (v1.11.0), GLM-4.7 (v1.12.0), agent (v1.13.0)
  */
 
 
@@ -271,7 +271,7 @@ Filtering & Pruning:
 			}
 
 			fmt.Print(outputStr)
-			return bridge.Execute(bridgeCode, outputStr, treeFormat, cmdStr, time.Since(startTime), dbName, 0, forceInsert)
+			return bridge.Execute(bridgeCode, outputStr, treeFormat, cmdStr, time.Since(startTime), dbName, 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 		}
 
 		// Standard Output
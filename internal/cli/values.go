@@ -1,12 +1,12 @@
 /**
  * Component: Values Command
- * Block-UUID: 2a6be9f5-66bb-4729-a481-352ca80984b2
- * Parent-UUID: f87f9ef9-9b1c-4b21-bd17-56f8922c0b3d
- * Version: 1.2.0
+ * Block-UUID: 710f0c48-c766-4df7-8e70-a9de0cb6d0da
+ * Parent-UUID: 2a6be9f5-66bb-4729-a481-352ca80984b2
+ * Version: 1.3.0
  * Description: Provides a top-level shortcut for listing unique metadata values. This command maps directly to 'gsc query list --db <db> <field>'. Updated bridge.Execute calls to include the new exitCode argument.
  * Language: Go
  * Created-at: 2026-02-12T05:18:55.857Z
- * Authors: Gemini 3 Flash (v1.0.0), Gemini 3 Flash (v1.1.0), Gemini 3 Flash (v1.2.0)
+ * Authors: Gemini 3 Flash (v1.0.0), Gemini 3 Flash (v1.1.0), Gemini 3 Flash (v1.2.0), agent (v1.3.0)
  */
 
 
@@ -78,7 +78,7 @@ This is equivalent to running 'gsc query list --db <database> <field>'.`,
 
 			// 2. Hand off to bridge orchestrator
 			cmdStr := filepath.Base(os.Args[0]) + " " + strings.Join(os.Args[1:], " ")
-			return bridge.Execute(bridgeCode, outputStr, valuesFormat, cmdStr, time.Since(startTime), resolvedDB, 0, forceInsert)
+			return bridge.Execute(bridgeCode, outputStr, valuesFormat, cmdStr, time.Since(startTime), resolvedDB, 0, bridge.ResolveOversizeMode(forceInsert, oversizeMode))
 		}
 
 		// Standard Output Mode
@@ -0,0 +1,104 @@
+/**
+ * Component: Contract Command Policy
+ * Block-UUID: c81f4a26-9d37-4e0b-a5c2-3e6b9f17d084
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: The single policy check for commands run in a contract context. Builds the contract's command policy from its whitelist rules, checks the command, and appends every allowed and denied command to the contract's exec audit log.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/exec"
+	"github.com/gitsense/gsc-cli/pkg/logger"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+)
+
+// ExecAuditFileName is the exec audit log in a contract's home directory.
+const ExecAuditFileName = "exec-audit.jsonl"
+
+// Sources of contract commands, recorded in the audit log
+const (
+	ExecSourceContractExec = "contract exec"
+	ExecSourceLaunchExec   = "launch exec"
+)
+
+// ExecAuditEntry is one line of the exec audit log.
+type ExecAuditEntry struct {
+	Timestamp    time.Time `json:"timestamp"`
+	ContractUUID string    `json:"contract_uuid"`
+	Source       string    `json:"source"`
+	Command      string    `json:"command"`
+	Allowed      bool      `json:"allowed"`
+	Unrestricted bool      `json:"unrestricted,omitempty"`
+	Commands     []string  `json:"commands"`
+	Reason       string    `json:"reason,omitempty"`
+}
+
+// GetExecAuditPath resolves the exec audit log of a contract.
+func GetExecAuditPath(uuid string) string {
+	gscHome, _ := settings.GetGSCHome(false)
+	return filepath.Join(gscHome, settings.HomesRelPath, uuid, ExecAuditFileName)
+}
+
+// CheckExecCommand checks a command against the contract's policy and
+// records the decision. Every path that runs a caller-supplied command in a
+// contract goes through here. A denied command, or one whose decision could
+// not be recorded, returns an error.
+func CheckExecCommand(meta *ContractMetadata, command string, source string) (*exec.PolicyDecision, error) {
+	policy, err := exec.ParseCommandPolicy(meta.Whitelist, meta.NoWhitelist)
+	if err != nil {
+		return nil, fmt.Errorf("contract whitelist: %w", err)
+	}
+	decision := policy.Check(command)
+
+	entry := ExecAuditEntry{
+		Timestamp:    time.Now().UTC(),
+		ContractUUID: meta.UUID,
+		Source:       source,
+		Command:      command,
+		Allowed:      decision.Allowed,
+		Unrestricted: meta.NoWhitelist,
+		Commands:     decision.Commands,
+		Reason:       decision.Reason(),
+	}
+	if err := appendExecAudit(GetExecAuditPath(meta.UUID), entry); err != nil {
+		logger.Error("Failed to write exec audit log", "contract", meta.UUID, "error", err)
+		return decision, fmt.Errorf("command not run: %w", err)
+	}
+
+	if !decision.Allowed {
+		logger.Warning("Command denied by contract policy", "contract", meta.UUID, "source", source, "reason", entry.Reason)
+		return decision, fmt.Errorf("command denied by contract policy: %s", entry.Reason)
+	}
+	return decision, nil
+}
+
+// appendExecAudit appends one entry to the exec audit log.
+func appendExecAudit(path string, entry ExecAuditEntry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create exec audit directory: %w", err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal exec audit entry: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open exec audit log: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write exec audit log: %w", err)
+	}
+	return nil
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Contract Intent Handler
- * Block-UUID: eeeec5f7-25ba-43ec-986c-9bb738010768
- * Parent-UUID: fbd8af6d-36fd-4322-b23f-470d8eb767a8
- * Version: 1.27.0
- * Description: Simplified terminal intent handling to delegate shell spawning to 'gsc app ws'. Removed script generation and environment variable injection logic.
+ * Block-UUID: 8d6cdc12-4b0c-4cb5-bf16-8f61e74230b2
+ * Parent-UUID: eeeec5f7-25ba-43ec-986c-9bb738010768
+ * Version: 1.28.0
+ * Description: Simplified terminal intent handling to delegate shell spawning to 'gsc app ws'. Removed script generation and environment variable injection logic. The exec launch alias now goes through the contract command policy check.
  * Language: Go
  * Created-at: 2026-03-26T17:12:52.028Z
- * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.21.0), GLM-4.7 (v1.22.0), GLM-4.7 (v1.23.0), GLM-4.7 (v1.24.0), GLM-4.7 (v1.25.0), GLM-4.7 (v1.26.0), GLM-4.7 (v1.27.0)
+ * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.21.0), GLM-4.7 (v1.22.0), GLM-4.7 (v1.23.0), GLM-4.7 (v1.24.0), GLM-4.7 (v1.25.0), GLM-4.7 (v1.26.0), GLM-4.7 (v1.27.0), agent (v1.28.0)
  */
 
 
@@ -327,6 +327,10 @@ func handleExecIntent(meta *ContractMetadata, cmdStr string) (LaunchResult, erro
 		return LaunchResult{}, fmt.Errorf("no command provided")
 	}
 
+	if _, err := CheckExecCommand(meta, cmdStr, ExecSourceLaunchExec); err != nil {
+		return LaunchResult{}, err
+	}
+
 	executor := exec.NewExecutor(cmdStr, exec.ExecFlags{TimeoutSeconds: meta.ExecTimeout}, meta.Workdirs[0].Path, nil)
 	result, err := executor.Run()
 	if err != nil {
@@ -1,12 +1,12 @@
 /**
  * Component: Contract Manager
- * Block-UUID: 993b747d-3208-4145-9292-07088c31ac1f
- * Parent-UUID: f8f9b4cf-51c6-4b44-b563-766dd0ccf11b
- * Version: 1.24.6
- * Description: Add workdir management methods (AddWorkdir, RemoveWorkdir, SetPrimaryWorkdir) with conflict validation and event notifications.
+ * Block-UUID: b9d4aaf9-678d-41d4-a6a0-47220954b0ec
+ * Parent-UUID: 993b747d-3208-4145-9292-07088c31ac1f
+ * Version: 1.25.0
+ * Description: Add workdir management methods (AddWorkdir, RemoveWorkdir, SetPrimaryWorkdir) with conflict validation and event notifications. CreateContract rejects whitelist rules the command policy cannot parse.
  * Language: Go
  * Created-at: 2026-03-30T03:34:46.573Z
- * Authors: GLM-4.7 (v1.24.0), GLM-4.7 (v1.24.1), GLM-4.7 (v1.24.2), GLM-4.7 (v1.24.3), GLM-4.7 (v1.24.4), GLM-4.7 (v1.24.5), GLM-4.7 (v1.24.6)
+ * Authors: GLM-4.7 (v1.24.0), GLM-4.7 (v1.24.1), GLM-4.7 (v1.24.2), GLM-4.7 (v1.24.3), GLM-4.7 (v1.24.4), GLM-4.7 (v1.24.5), GLM-4.7 (v1.24.6), agent (v1.25.0)
  */
 
 
@@ -25,6 +25,7 @@ import (
 	"github.com/gitsense/gsc-cli/internal/git"
 	"github.com/gitsense/gsc-cli/internal/bridge"
 	"github.com/gitsense/gsc-cli/internal/db"
+	"github.com/gitsense/gsc-cli/internal/exec"
 	"github.com/gitsense/gsc-cli/internal/manifest"
 	"github.com/gitsense/gsc-cli/pkg/logger"
 	"github.com/gitsense/gsc-cli/pkg/settings"
@@ -60,6 +61,11 @@ func CreateContract(code string, description string, authcode string, workdir st
 		return nil, fmt.Errorf("an active contract already exists for this workspace: %s. Please cancel it before creating a new one", existing.UUID)
 	}
 
+	// Reject whitelist rules the command policy cannot parse
+	if _, err := exec.ParseCommandPolicy(whitelist, noWhitelist); err != nil {
+		return nil, err
+	}
+
 	// Claim the handshake immediately to prevent double-use
 	if err := h.UpdateStatus("running", nil); err != nil {
 		return nil, fmt.Errorf("failed to claim handshake: %w", err)
@@ -1,12 +1,12 @@
 /**
  * Component: Chat Database Operations
- * Block-UUID: 6c9d73e0-d3d3-4e04-913d-fb21f3bfa783
- * Parent-UUID: 5189acd4-beba-4096-ad93-aeb7b9b4cbbc
- * Version: 1.26.2
- * Description: Fixed DeleteChatAndDescendants to correctly handle recursive soft deletes. Split the single SQL execution into two separate statements: one for messages (must run first while chats are active) and one for chats. This ensures the CTE scope is correct and all descendant messages are marked deleted before their parent chats. v1.26.2: Fixed timestamp format in DeleteChatAndDescendants to use ISO 8601 format consistent with the rest of the codebase, replacing SQLite's datetime('now') with Go's time.Now().UTC().Format.
+ * Block-UUID: b2d8f2c2-454a-4aca-ab26-a46e7c576d1b
+ * Parent-UUID: 6c9d73e0-d3d3-4e04-913d-fb21f3bfa783
+ * Version: 1.27.0
+ * Description: InsertMessage accepts an Execer (*sql.DB or *sql.Tx) so callers can insert several messages in one transaction.
  * Language: Go
  * Created-at: 2026-05-17T13:11:16.616Z
- * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.23.0), GLM-4.7 (v1.24.0), GLM-4.7 (v1.25.0), GLM-4.7 (v1.26.0), GLM-4.7 (v1.26.1), GLM-4.7 (v1.26.2)
+ * Authors: Gemini 3 Flash (v1.0.0), ..., GLM-4.7 (v1.23.0), GLM-4.7 (v1.24.0), GLM-4.7 (v1.25.0), GLM-4.7 (v1.26.0), GLM-4.7 (v1.26.1), GLM-4.7 (v1.26.2), agent (v1.27.0)
  */
 
 
@@ -408,8 +408,13 @@ func UpdateMessage(db *sql.DB, id int64, content string) error {
 	return nil
 }
 
+// Execer is satisfied by *sql.DB and *sql.Tx, so inserts can join a transaction.
+type Execer interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+}
+
 // InsertMessage inserts a new message record.
-func InsertMessage(db *sql.DB, msg *Message) (int64, error) {
+func InsertMessage(db Execer, msg *Message) (int64, error) {
 	query := `
 		INSERT INTO messages (
 			type, 			-- 1
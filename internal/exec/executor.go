@@ -1,12 +1,12 @@
 /**
  * Component: Exec Command Executor
- * Block-UUID: be4e23fe-6e3a-424e-907c-f1b2035f6a17
- * Parent-UUID: ae689c93-9861-4030-8ea4-d09d43accbb5
- * Version: 1.8.0
- * Description: Refactored platform-specific process group and signaling logic into separate files to support cross-compilation for Windows.
+ * Block-UUID: 50d043f5-020f-4806-969c-ec52c37654bd
+ * Parent-UUID: a5e4fff5-f50c-4c3a-8a61-f0b659aff643
+ * Version: 1.10.0
+ * Description: Run applies the saved exec output retention policy after saving, always keeping the new output.
  * Language: Go
  * Created-at: 2026-03-19T13:55:19.643Z
- * Authors: Gemini 3 Flash (v1.0.0), Gemini 3 Flash (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), Gemini 3 Flash (v1.5.0), GLM-4.7 (v1.6.0), Gemini 3 Flash (v1.7.0), GLM-4.7 (v1.8.0)
+ * Authors: Gemini 3 Flash (v1.0.0), Gemini 3 Flash (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), Gemini 3 Flash (v1.5.0), GLM-4.7 (v1.6.0), Gemini 3 Flash (v1.7.0), GLM-4.7 (v1.8.0), agent (v1.9.0), agent (v1.10.0)
  */
 
 
@@ -41,6 +41,8 @@ type Executor struct {
 // Result contains the outcome of an execution.
 type Result struct {
 	ID        string
+	Command   string // Set when loaded from a saved output
+	Workdir   string // Set when loaded from a saved output
 	ExitCode  int
 	Duration  time.Duration
 	Output    string // Combined stdout/stderr
@@ -198,6 +200,7 @@ func (e *Executor) Run() (*Result, error) {
 		Timestamp: startTime.Format(time.RFC3339),
 		Duration:  fmt.Sprintf("%.2f", duration.Seconds()),
 		Flags:     e.Flags,
+		Workdir:   e.Workdir,
 	}
 
 	metaData, err := json.MarshalIndent(metadata, "", "  ")
@@ -209,7 +212,10 @@ func (e *Executor) Run() (*Result, error) {
 		}
 	}
 
-	// 10. Return Result
+	// 10. Apply the saved retention policy, sparing this run
+	applyRetention(id)
+
+	// 11. Return Result
 	combinedOutput := stdoutBuf.String() + stderrBuf.String()
 	logger.Debug("Execution result", "outputSize", len(combinedOutput), "duration", duration)
 
@@ -304,6 +310,8 @@ func GetOutput(id string) (*Result, error) {
 
 	return &Result{
 		ID:       id,
+		Command:  meta.Command,
+		Workdir:  meta.Workdir,
 		Output:   string(logData),
 		LogPath:  logPath,
 		MetaPath: metaPath,
@@ -0,0 +1,596 @@
+/**
+ * Component: Exec Output History
+ * Block-UUID: cad193c7-3ce8-4c0a-86f2-fbe6aa3914cf
+ * Parent-UUID: 4e8c2a17-9b50-4d36-a1f4-7c0d3e95b862
+ * Version: 1.1.0
+ * Description: Queries saved exec outputs: filtering by command, exit code, and date (a date given as an upper bound covers the whole day), full-text search across output logs, line diffs between two runs, and age- and size-based retention with an optional saved policy applied after every run.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package exec
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/pkg/logger"
+	"github.com/gitsense/gsc-cli/pkg/settings"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// HistoryEntry is a saved exec output with its metadata.
+type HistoryEntry struct {
+	ID        string    `json:"id"`
+	Command   string    `json:"command"`
+	ExitCode  int       `json:"exit_code"`
+	Timestamp time.Time `json:"timestamp"`
+	Duration  string    `json:"duration_seconds"`
+	Workdir   string    `json:"workdir,omitempty"`
+	Size      int64     `json:"size_bytes"`
+	LogPath   string    `json:"-"`
+	metaPath  string
+}
+
+// HistoryFilter selects saved outputs. Zero values match everything.
+type HistoryFilter struct {
+	Command  *regexp.Regexp // matched against the command string
+	ExitCode *int
+	Failed   bool // non-zero exit code
+	Since    time.Time
+	Until    time.Time
+	Limit    int // newest N after filtering
+}
+
+// History returns the saved outputs that match the filter, newest first.
+func History(filter HistoryFilter) ([]HistoryEntry, error) {
+	entries, err := loadHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []HistoryEntry
+	for _, e := range entries {
+		if filter.Command != nil && !filter.Command.MatchString(e.Command) {
+			continue
+		}
+		if filter.ExitCode != nil && e.ExitCode != *filter.ExitCode {
+			continue
+		}
+		if filter.Failed && e.ExitCode == 0 {
+			continue
+		}
+		if !filter.Since.IsZero() && e.Timestamp.Before(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && e.Timestamp.After(filter.Until) {
+			continue
+		}
+		matched = append(matched, e)
+		if filter.Limit > 0 && len(matched) == filter.Limit {
+			break
+		}
+	}
+	return matched, nil
+}
+
+// loadHistory reads the metadata of every saved output, newest first.
+func loadHistory() ([]HistoryEntry, error) {
+	outputDir, err := settings.GetExecOutputsDir()
+	if err != nil {
+		return nil, err
+	}
+	dirEntries, err := os.ReadDir(outputDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var entries []HistoryEntry
+	for _, de := range dirEntries {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), ".json") {
+			continue
+		}
+		id := strings.TrimSuffix(de.Name(), ".json")
+		entry, err := loadHistoryEntry(outputDir, id)
+		if err != nil {
+			logger.Debug("Skipping saved output", "id", id, "error", err)
+			continue
+		}
+		entries = append(entries, *entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].Timestamp.Equal(entries[j].Timestamp) {
+			return entries[i].Timestamp.After(entries[j].Timestamp)
+		}
+		return entries[i].ID > entries[j].ID
+	})
+	return entries, nil
+}
+
+// loadHistoryEntry reads one output's metadata and file sizes. Outputs with an
+// unreadable timestamp are dated by their metadata file.
+func loadHistoryEntry(outputDir string, id string) (*HistoryEntry, error) {
+	metaPath := filepath.Join(outputDir, id+".json")
+	logPath := filepath.Join(outputDir, id+".log")
+
+	data, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil, err
+	}
+	var meta ExecMetadata
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	entry := &HistoryEntry{
+		ID:       id,
+		Command:  meta.Command,
+		ExitCode: meta.ExitCode,
+		Duration: meta.Duration,
+		Workdir:  meta.Workdir,
+		Size:     int64(len(data)),
+		LogPath:  logPath,
+		metaPath: metaPath,
+	}
+	if info, err := os.Stat(logPath); err == nil {
+		entry.Size += info.Size()
+	}
+	if ts, err := time.Parse(time.RFC3339, meta.Timestamp); err == nil {
+		entry.Timestamp = ts
+	} else if info, err := os.Stat(metaPath); err == nil {
+		entry.Timestamp = info.ModTime()
+	}
+	return entry, nil
+}
+
+// LineMatch is one matching line of a saved output.
+type LineMatch struct {
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// SearchHit is a saved output whose log matches a search.
+type SearchHit struct {
+	HistoryEntry
+	MatchCount int         `json:"match_count"`
+	Matches    []LineMatch `json:"matches"`
+}
+
+// SearchOutputs scans the logs of the given outputs for lines matching the
+// pattern. At most maxLines matching lines are kept per output (0 keeps all),
+// but every match is counted.
+func SearchOutputs(entries []HistoryEntry, pattern *regexp.Regexp, maxLines int) ([]SearchHit, error) {
+	var hits []SearchHit
+	for _, e := range entries {
+		file, err := os.Open(e.LogPath)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to open output %s: %w", e.ID, err)
+		}
+
+		hit := SearchHit{HistoryEntry: e}
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+		for n := 1; scanner.Scan(); n++ {
+			line := scanner.Text()
+			if !pattern.MatchString(line) {
+				continue
+			}
+			hit.MatchCount++
+			if maxLines == 0 || len(hit.Matches) < maxLines {
+				hit.Matches = append(hit.Matches, LineMatch{Line: n, Text: line})
+			}
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read output %s: %w", e.ID, err)
+		}
+		if hit.MatchCount > 0 {
+			hits = append(hits, hit)
+		}
+	}
+	return hits, nil
+}
+
+// OutputDiff compares two saved outputs line by line.
+type OutputDiff struct {
+	Old     HistoryEntry `json:"old"`
+	New     HistoryEntry `json:"new"`
+	Added   int          `json:"added"`
+	Removed int          `json:"removed"`
+	Unified string       `json:"unified"`
+}
+
+// timingPatterns match values that change on every run of the same command.
+var timingPatterns = []struct {
+	re          *regexp.Regexp
+	placeholder string
+}{
+	{regexp.MustCompile(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`), "<time>"},
+	{regexp.MustCompile(`\b\d+(\.\d+)?(ns|µs|us|ms|s|m|h)\b`), "<duration>"},
+}
+
+// DiffOutputs builds a unified diff from the output of oldID to that of newID.
+// With ignoreTiming, timestamps and durations are masked first so reruns of
+// the same command only differ where their results do.
+func DiffOutputs(oldID string, newID string, ignoreTiming bool, context int) (*OutputDiff, error) {
+	outputDir, err := settings.GetExecOutputsDir()
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &OutputDiff{}
+	var texts [2]string
+	for i, id := range []string{oldID, newID} {
+		entry, err := loadHistoryEntry(outputDir, id)
+		if err != nil {
+			return nil, fmt.Errorf("output ID %s not found", id)
+		}
+		data, err := os.ReadFile(entry.LogPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read output %s: %w", id, err)
+		}
+		texts[i] = string(data)
+		if ignoreTiming {
+			for _, p := range timingPatterns {
+				texts[i] = p.re.ReplaceAllString(texts[i], p.placeholder)
+			}
+		}
+		if i == 0 {
+			diff.Old = *entry
+		} else {
+			diff.New = *entry
+		}
+	}
+
+	ops := lineDiff(texts[0], texts[1])
+	for _, op := range ops {
+		switch op.kind {
+		case '+':
+			diff.Added++
+		case '-':
+			diff.Removed++
+		}
+	}
+	diff.Unified = unifiedDiff(ops, "a/"+oldID, "b/"+newID, context)
+	return diff, nil
+}
+
+// diffOp is one line of a line diff: ' ' unchanged, '-' removed, '+' added.
+type diffOp struct {
+	kind byte
+	text string
+}
+
+// lineDiff diffs two texts by whole lines.
+func lineDiff(oldText string, newText string) []diffOp {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToChars(oldText, newText)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(a, b, false), lines)
+
+	var ops []diffOp
+	for _, d := range diffs {
+		kind := byte(' ')
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			kind = '+'
+		case diffmatchpatch.DiffDelete:
+			kind = '-'
+		}
+		for _, line := range strings.SplitAfter(d.Text, "\n") {
+			if line != "" {
+				ops = append(ops, diffOp{kind: kind, text: strings.TrimSuffix(line, "\n")})
+			}
+		}
+	}
+	return ops
+}
+
+// unifiedDiff renders line ops as unified diff hunks with the given number of
+// context lines. Identical inputs produce an empty string.
+func unifiedDiff(ops []diffOp, oldName string, newName string, context int) string {
+	// oldLine[i] and newLine[i] are the line numbers before op i
+	oldLine := make([]int, len(ops)+1)
+	newLine := make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldLine[i+1], newLine[i+1] = oldLine[i], newLine[i]
+		if op.kind != '+' {
+			oldLine[i+1]++
+		}
+		if op.kind != '-' {
+			newLine[i+1]++
+		}
+	}
+
+	var sb strings.Builder
+	end := 0
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+		start := i - context
+		if start < end {
+			start = end
+		}
+
+		// Extend the hunk while changes are close enough to share context
+		last := i
+		for j := i; j < len(ops) && j-last <= 2*context+1; j++ {
+			if ops[j].kind != ' ' {
+				last = j
+			}
+		}
+		end = last + 1 + context
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		if sb.Len() == 0 {
+			sb.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", oldName, newName))
+		}
+		sb.WriteString(fmt.Sprintf("@@ -%s +%s @@\n", hunkRange(oldLine[start], oldLine[end]), hunkRange(newLine[start], newLine[end])))
+		for _, op := range ops[start:end] {
+			sb.WriteByte(op.kind)
+			sb.WriteString(op.text)
+			sb.WriteByte('\n')
+		}
+		i = end
+	}
+	return sb.String()
+}
+
+// hunkRange formats the start,count of a hunk side from the line numbers
+// before its first and after its last line.
+func hunkRange(before int, after int) string {
+	count := after - before
+	if count == 0 {
+		return fmt.Sprintf("%d,0", before)
+	}
+	if count == 1 {
+		return strconv.Itoa(before + 1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// RetentionPolicy limits the saved outputs. Values use the flag syntax, e.g.
+// "30d" and "500MB"; an empty value means no limit.
+type RetentionPolicy struct {
+	MaxAge  string `json:"max_age,omitempty"`
+	MaxSize string `json:"max_size,omitempty"`
+}
+
+// IsZero reports whether the policy sets no limit.
+func (p RetentionPolicy) IsZero() bool {
+	return p.MaxAge == "" && p.MaxSize == ""
+}
+
+// Validate checks that the limits parse.
+func (p RetentionPolicy) Validate() error {
+	_, _, err := p.limits()
+	return err
+}
+
+// limits parses the policy values.
+func (p RetentionPolicy) limits() (time.Duration, int64, error) {
+	var age time.Duration
+	var size int64
+	var err error
+	if p.MaxAge != "" {
+		if age, err = ParseAge(p.MaxAge); err != nil {
+			return 0, 0, err
+		}
+	}
+	if p.MaxSize != "" {
+		if size, err = ParseSize(p.MaxSize); err != nil {
+			return 0, 0, err
+		}
+	}
+	return age, size, nil
+}
+
+// LoadRetentionPolicy reads the saved policy. No saved policy is a zero policy.
+func LoadRetentionPolicy() (RetentionPolicy, error) {
+	var policy RetentionPolicy
+	path, err := settings.GetExecRetentionPath()
+	if err != nil {
+		return policy, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return policy, nil
+		}
+		return policy, fmt.Errorf("failed to read retention policy: %w", err)
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return policy, fmt.Errorf("failed to parse retention policy: %w", err)
+	}
+	return policy, nil
+}
+
+// SaveRetentionPolicy stores the policy applied after every run. A zero
+// policy removes the saved one.
+func SaveRetentionPolicy(policy RetentionPolicy) error {
+	path, err := settings.GetExecRetentionPath()
+	if err != nil {
+		return err
+	}
+	if policy.IsZero() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove retention policy: %w", err)
+		}
+		return nil
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create retention policy directory: %w", err)
+	}
+	data, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal retention policy: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// PruneResult reports what a retention pass removed and kept.
+type PruneResult struct {
+	Removed      []HistoryEntry `json:"removed"`
+	RemovedBytes int64          `json:"removed_bytes"`
+	Kept         int            `json:"kept"`
+	KeptBytes    int64          `json:"kept_bytes"`
+	DryRun       bool           `json:"dry_run,omitempty"`
+}
+
+// Prune removes outputs older than the policy's max age, then the oldest
+// outputs until the rest fit in its max size. The output keepID (the run that
+// triggered the pass) is never removed.
+func Prune(policy RetentionPolicy, keepID string, dryRun bool, now time.Time) (*PruneResult, error) {
+	maxAge, maxSize, err := policy.limits()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := loadHistory()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PruneResult{DryRun: dryRun}
+	var kept []HistoryEntry
+	for _, e := range entries {
+		if maxAge > 0 && e.ID != keepID && now.Sub(e.Timestamp) > maxAge {
+			result.Removed = append(result.Removed, e)
+			continue
+		}
+		kept = append(kept, e)
+		result.KeptBytes += e.Size
+	}
+
+	// Entries are newest first, so trim from the end
+	for i := len(kept) - 1; maxSize > 0 && result.KeptBytes > maxSize && i >= 0; i-- {
+		if kept[i].ID == keepID {
+			continue
+		}
+		result.Removed = append(result.Removed, kept[i])
+		result.KeptBytes -= kept[i].Size
+		kept = append(kept[:i], kept[i+1:]...)
+	}
+	result.Kept = len(kept)
+
+	for _, e := range result.Removed {
+		result.RemovedBytes += e.Size
+		if dryRun {
+			continue
+		}
+		for _, path := range []string{e.LogPath, e.metaPath} {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return result, fmt.Errorf("failed to remove output %s: %w", e.ID, err)
+			}
+		}
+	}
+	return result, nil
+}
+
+// applyRetention runs the saved policy after a run. Failures are logged, not
+// returned, so retention never fails the command that was just saved.
+func applyRetention(keepID string) {
+	policy, err := LoadRetentionPolicy()
+	if err != nil {
+		logger.Warning("Skipping exec output retention", "error", err)
+		return
+	}
+	if policy.IsZero() {
+		return
+	}
+	result, err := Prune(policy, keepID, false, time.Now())
+	if err != nil {
+		logger.Warning("Exec output retention failed", "error", err)
+		return
+	}
+	if len(result.Removed) > 0 {
+		logger.Debug("Exec output retention removed outputs", "count", len(result.Removed), "bytes", result.RemovedBytes)
+	}
+}
+
+// ParseAge accepts a d/w suffix or any time.ParseDuration value.
+func ParseAge(value string) (time.Duration, error) {
+	v := strings.TrimSpace(strings.ToLower(value))
+	if len(v) > 1 && (strings.HasSuffix(v, "d") || strings.HasSuffix(v, "w")) {
+		if n, err := strconv.Atoi(v[:len(v)-1]); err == nil && n > 0 {
+			if strings.HasSuffix(v, "w") {
+				n *= 7
+			}
+			return time.Duration(n) * 24 * time.Hour, nil
+		}
+	}
+	if d, err := time.ParseDuration(v); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid age %q: use a duration such as 30d, 2w, or 36h", value)
+}
+
+// sizeUnits are the accepted size suffixes, longest first.
+var sizeUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"gb", 1 << 30}, {"mb", 1 << 20}, {"kb", 1 << 10},
+	{"g", 1 << 30}, {"m", 1 << 20}, {"k", 1 << 10}, {"b", 1},
+}
+
+// ParseSize accepts a byte count with an optional K, M, or G suffix (binary units).
+func ParseSize(value string) (int64, error) {
+	v := strings.TrimSpace(strings.ToLower(value))
+	multiplier := int64(1)
+	for _, unit := range sizeUnits {
+		if strings.HasSuffix(v, unit.suffix) {
+			v = strings.TrimSpace(strings.TrimSuffix(v, unit.suffix))
+			multiplier = unit.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || n <= 0 || math.IsInf(n, 1) {
+		return 0, fmt.Errorf("invalid size %q: use a byte count such as 500MB, 2G, or 800K", value)
+	}
+	return int64(n * float64(multiplier)), nil
+}
+
+// ParseTimeBound parses a --since/--until value: a date (2006-01-02), an
+// RFC3339 time, or an age such as 7d meaning that long before now. A date
+// is the start of that day, or its last instant when endOfDay is set so an
+// inclusive --until covers the whole day.
+func ParseTimeBound(value string, now time.Time, endOfDay bool) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		if endOfDay {
+			return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+		}
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if age, err := ParseAge(value); err == nil {
+		return now.Add(-age), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q: use a date (2006-01-02), an RFC3339 time, or an age such as 7d", value)
+}
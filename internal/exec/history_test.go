@@ -0,0 +1,213 @@
+/**
+ * Component: Exec Output History Tests
+ * Block-UUID: a675dbb4-f082-4a31-9f24-fccf44e3b01e
+ * Parent-UUID: 7b15e3c9-0d42-4f8a-96e7-2c4a8f1d5b30
+ * Version: 1.1.0
+ * Description: Tests history filters, full-text search, unified diffs with masked timing, age and size retention that spares the triggering run, and the size and time parsers, including whole-day --until dates.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package exec
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// seedOutput saves an output the way Executor.Run does.
+func seedOutput(t *testing.T, id string, command string, exitCode int, started time.Time, log string) {
+	t.Helper()
+	dir := filepath.Join(os.Getenv("GSC_HOME"), "data", "exec", "outputs")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	meta, _ := json.Marshal(ExecMetadata{Version: "1.0", Command: command, ExitCode: exitCode, Timestamp: started.Format(time.RFC3339), Duration: "1.00"})
+	os.WriteFile(filepath.Join(dir, id+".json"), meta, 0644)
+	os.WriteFile(filepath.Join(dir, id+".log"), []byte(log), 0644)
+}
+
+func ids(entries []HistoryEntry) string {
+	var out []string
+	for _, e := range entries {
+		out = append(out, e.ID)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestHistoryFiltersAndSearch(t *testing.T) {
+	t.Setenv("GSC_HOME", t.TempDir())
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	seedOutput(t, "old-test", "go test ./...", 1, now.AddDate(0, 0, -10), "ok  a 0.10s\nFAIL b\n--- FAIL: TestX\n")
+	seedOutput(t, "new-test", "go test ./...", 0, now.AddDate(0, 0, -1), "ok  a 0.20s\nok  b 0.30s\n")
+	seedOutput(t, "build", "make build", 2, now.Add(-time.Hour), "connection refused\n")
+
+	all, err := History(HistoryFilter{})
+	if err != nil || ids(all) != "build,new-test,old-test" {
+		t.Fatalf("all = %s, %v", ids(all), err)
+	}
+
+	code := 0
+	since, _ := ParseTimeBound("3d", now, false)
+	for _, tt := range []struct {
+		filter HistoryFilter
+		want   string
+	}{
+		{HistoryFilter{Command: regexp.MustCompile(`^go test`)}, "new-test,old-test"},
+		{HistoryFilter{Failed: true}, "build,old-test"},
+		{HistoryFilter{ExitCode: &code}, "new-test"},
+		{HistoryFilter{Since: since}, "build,new-test"},
+		{HistoryFilter{Until: now.AddDate(0, 0, -2)}, "old-test"},
+		{HistoryFilter{Command: regexp.MustCompile(`go`), Limit: 1}, "new-test"},
+	} {
+		got, _ := History(tt.filter)
+		if ids(got) != tt.want {
+			t.Errorf("filter %+v = %s, want %s", tt.filter, ids(got), tt.want)
+		}
+	}
+
+	hits, err := SearchOutputs(all, regexp.MustCompile(`(?i)fail|refused`), 1)
+	if err != nil || len(hits) != 2 {
+		t.Fatalf("hits = %+v, %v", hits, err)
+	}
+	if hits[1].ID != "old-test" || hits[1].MatchCount != 2 || len(hits[1].Matches) != 1 || hits[1].Matches[0] != (LineMatch{Line: 2, Text: "FAIL b"}) {
+		t.Errorf("old-test hit = %+v", hits[1])
+	}
+}
+
+func TestDiffOutputs(t *testing.T) {
+	t.Setenv("GSC_HOME", t.TempDir())
+	now := time.Now()
+	var before, after strings.Builder
+	for i := 1; i <= 20; i++ {
+		line := "line " + string(rune('a'+i)) + "\n"
+		before.WriteString(line)
+		switch i {
+		case 2:
+			after.WriteString("changed b\n")
+		case 15:
+		default:
+			after.WriteString(line)
+		}
+	}
+	seedOutput(t, "one", "make", 0, now, "started 2026-10-18T09:00:00Z in 1.5s\n"+before.String())
+	seedOutput(t, "two", "make", 0, now, "started 2026-10-18T10:30:00Z in 2.25s\n"+after.String())
+
+	diff, err := DiffOutputs("one", "two", true, 2)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	want := `--- a/one
++++ b/two
+@@ -1,5 +1,5 @@
+ started <time> in <duration>
+ line b
+-line c
++changed b
+ line d
+ line e
+@@ -14,5 +14,4 @@
+ line n
+ line o
+-line p
+ line q
+ line r
+`
+	if diff.Unified != want || diff.Added != 1 || diff.Removed != 2 {
+		t.Errorf("diff (+%d -%d):\n%s", diff.Added, diff.Removed, diff.Unified)
+	}
+
+	// Without masking the first line differs too
+	if diff, _ = DiffOutputs("one", "two", false, 2); !strings.Contains(diff.Unified, "-started 2026-10-18T09:00:00Z in 1.5s") {
+		t.Errorf("unmasked diff:\n%s", diff.Unified)
+	}
+	if diff, _ = DiffOutputs("one", "one", false, 3); diff.Unified != "" {
+		t.Errorf("identical outputs differ:\n%s", diff.Unified)
+	}
+	if _, err := DiffOutputs("one", "missing", false, 3); err == nil {
+		t.Error("missing output accepted")
+	}
+}
+
+func TestPruneByAgeAndSize(t *testing.T) {
+	t.Setenv("GSC_HOME", t.TempDir())
+	now := time.Now()
+	log := strings.Repeat("x", 1000)
+	seedOutput(t, "ancient", "a", 0, now.AddDate(0, 0, -40), log)
+	seedOutput(t, "older", "b", 0, now.AddDate(0, 0, -3), log)
+	seedOutput(t, "old", "c", 0, now.AddDate(0, 0, -2), log)
+	seedOutput(t, "recent", "d", 0, now.AddDate(0, 0, -1), log)
+
+	result, err := Prune(RetentionPolicy{MaxAge: "30d", MaxSize: "2.5K"}, "", true, now)
+	if err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if ids(result.Removed) != "ancient,older" || result.Kept != 2 {
+		t.Fatalf("dry run removed %s, kept %d", ids(result.Removed), result.Kept)
+	}
+	if all, _ := History(HistoryFilter{}); len(all) != 4 {
+		t.Fatal("dry run deleted outputs")
+	}
+
+	// The triggering run survives even when it alone exceeds the limit
+	if _, err := Prune(RetentionPolicy{MaxSize: "500"}, "old", false, now); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if all, _ := History(HistoryFilter{}); ids(all) != "old" {
+		t.Fatalf("kept %s, want old", ids(all))
+	}
+
+	// A saved policy is applied after every run
+	if err := SaveRetentionPolicy(RetentionPolicy{MaxAge: "forever"}); err == nil {
+		t.Fatal("invalid policy saved")
+	}
+	if err := SaveRetentionPolicy(RetentionPolicy{MaxAge: "1d"}); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	run, err := (&Executor{Command: "echo hi", Flags: ExecFlags{Silent: true}}).Run()
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if all, _ := History(HistoryFilter{}); ids(all) != run.ID {
+		t.Fatalf("after run: %s, want only %s", ids(all), run.ID)
+	}
+}
+
+func TestParseSizeAndTimeBound(t *testing.T) {
+	for in, want := range map[string]int64{"500": 500, "800K": 800 << 10, "1.5mb": 3 << 19, "2G": 2 << 30, "10 MB": 10 << 20} {
+		if got, err := ParseSize(in); err != nil || got != want {
+			t.Errorf("ParseSize(%q) = %d, %v; want %d", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "-1M", "big", "10x"} {
+		if _, err := ParseSize(in); err == nil {
+			t.Errorf("ParseSize(%q) accepted", in)
+		}
+	}
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	if got, _ := ParseTimeBound("2w", now, false); !got.Equal(now.AddDate(0, 0, -14)) {
+		t.Errorf("2w = %v", got)
+	}
+	if got, _ := ParseTimeBound("2026-10-01T08:00:00Z", now, true); !got.Equal(time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("RFC3339 = %v", got)
+	}
+	if _, err := ParseTimeBound("yesterday", now, false); err == nil {
+		t.Error("yesterday accepted")
+	}
+
+	start, _ := ParseTimeBound("2026-10-18", now, false)
+	end, _ := ParseTimeBound("2026-10-18", now, true)
+	if want := time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local); !start.Equal(want) {
+		t.Errorf("since date = %v, want %v", start, want)
+	}
+	if late := time.Date(2026, 10, 18, 23, 59, 59, 0, time.Local); end.Before(late) || !end.Before(start.AddDate(0, 0, 1)) {
+		t.Errorf("until date = %v, want the end of 2026-10-18", end)
+	}
+}
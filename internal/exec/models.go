@@ -1,12 +1,12 @@
 /**
  * Component: Exec Data Models
- * Block-UUID: 9f8e7d6c-5b4a-4a2d-8c1e-3f5a6b7c8d9e
- * Parent-UUID: e60a2595-3005-4007-88e3-dc8ac8267b37
- * Version: 1.2.0
- * Description: Defines the data structures for persisting execution metadata and flags, enabling the recovery and resend features of the exec command.
+ * Block-UUID: cc259ab3-4f9f-4e61-bde8-dbf1a3de0d4a
+ * Parent-UUID: 9f8e7d6c-5b4a-4a2d-8c1e-3f5a6b7c8d9e
+ * Version: 1.3.0
+ * Description: Defines the data structures for persisting execution metadata and flags, enabling the recovery and resend features of the exec command. Metadata now records the working directory.
  * Language: Go
  * Created-at: 2026-03-02T17:12:10.805Z
- * Authors: Gemini 3 Flash (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0)
+ * Authors: Gemini 3 Flash (v1.0.0), GLM-4.7 (v1.1.0), GLM-4.7 (v1.2.0), agent (v1.3.0)
  */
 
 
@@ -21,6 +21,7 @@ type ExecMetadata struct {
 	Timestamp string      `json:"timestamp"`
 	Duration  string      `json:"duration_seconds"`
 	Flags     ExecFlags   `json:"flags"`
+	Workdir   string      `json:"workdir,omitempty"`
 }
 
 // ExecFlags captures the specific flags used during the execution.
@@ -0,0 +1,910 @@
+/**
+ * Component: Exec Command Policy
+ * Block-UUID: 729be1a2-e312-4639-9e1f-2e1c52f8cb63
+ * Parent-UUID: 627b8431-fb25-4fec-8a0b-abe6209cb4cb
+ * Version: 1.4.0
+ * Description: Shells that read their script from stdin (sh, bash -s, sh < file) are refused when deny rules exist, unquoted brace expansion is treated as an expanded word, and setsid, stdbuf, ionice, doas, chroot, su -c, and script -c are checked as wrappers.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0), agent (v1.3.0), agent (v1.4.0)
+ */
+
+package exec
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"mvdan.cc/sh/v3/syntax"
+)
+
+// CommandPolicy decides which commands may run. It is built from rule lines
+// (a contract whitelist):
+//
+//	git                        allow git with any arguments
+//	git status|diff|log        allow git when the first argument is status, diff or log
+//	go test|vet ./...|*        patterns match leading arguments in order; '*' matches any one
+//	git push !--force*|-f      '!' patterns must not match any argument
+//	deny rm -rf                deny rm when every pattern matches some argument, in any order;
+//	                           -rf, -fr, -r -f, and --recursive --force are the same, and
+//	                           /bin/rm is rm
+//	env GIT_* LANG             allow setting these variables (FOO=1 cmd, export FOO=1)
+//	redirect build/*.log       allow output redirections (>, >>, >|, <>, &>) to these files
+//
+// Commands run through wrappers (busybox, command, env, exec, find -exec,
+// nice, nohup, timeout, sudo, xargs, sh -c, eval) are checked as well as the
+// wrapper itself.
+// Deny rules win over allow rules. Output redirections are refused unless a
+// redirect rule names the target; /dev/null is always allowed. An
+// unrestricted policy allows every command, variable, and redirection that no
+// deny rule matches.
+type CommandPolicy struct {
+	Unrestricted bool
+	allow        map[string][]commandRule
+	deny         map[string][]commandRule
+	env          []*regexp.Regexp
+	redirects    []*regexp.Regexp
+}
+
+// commandRule is one parsed allow or deny rule.
+type commandRule struct {
+	source    string
+	args      []argPattern // Leading arguments, in order (allow) or anywhere (deny)
+	forbidden []argPattern // Must match no argument (allow only)
+}
+
+// argPattern matches one argument against alternatives separated by '|'.
+type argPattern struct {
+	any          bool
+	alternatives []*regexp.Regexp
+}
+
+// PolicyDecision is the outcome of checking one command string.
+type PolicyDecision struct {
+	Allowed    bool     `json:"allowed"`
+	Commands   []string `json:"commands"`             // Every simple command found, as written
+	Violations []string `json:"violations,omitempty"` // Why each denied command was denied
+}
+
+// Reason summarizes why a command was denied.
+func (d *PolicyDecision) Reason() string {
+	return strings.Join(d.Violations, "; ")
+}
+
+var (
+	// validNameRe matches command names a rule may use
+	validNameRe = regexp.MustCompile(`^[A-Za-z0-9_./+-][A-Za-z0-9_./+:@-]*$`)
+	// envPatternRe matches variable names and patterns in env rules
+	envPatternRe = regexp.MustCompile(`^[A-Za-z_*][A-Za-z0-9_*]*$`)
+	// shortFlagsRe matches a cluster of single-letter flags such as -rf
+	shortFlagsRe = regexp.MustCompile(`^-[A-Za-z]{2,}$`)
+)
+
+// maxWrapperDepth bounds how deeply wrapped commands (eval eval ..., sh -c
+// "sh -c ...") are followed; anything deeper is denied.
+const maxWrapperDepth = 8
+
+// ParseCommandPolicy builds a policy from rule lines. Blank lines and lines
+// starting with '#' are ignored.
+func ParseCommandPolicy(rules []string, unrestricted bool) (*CommandPolicy, error) {
+	policy := &CommandPolicy{
+		Unrestricted: unrestricted,
+		allow:        map[string][]commandRule{},
+		deny:         map[string][]commandRule{},
+	}
+	for _, line := range rules {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err := policy.addRule(line); err != nil {
+			return nil, fmt.Errorf("invalid rule %q: %w", line, err)
+		}
+	}
+	return policy, nil
+}
+
+// addRule parses one rule line into the policy.
+func (p *CommandPolicy) addRule(line string) error {
+	fields := strings.Fields(line)
+	switch fields[0] {
+	case "env":
+		if len(fields) < 2 {
+			return fmt.Errorf("env needs at least one variable name")
+		}
+		for _, name := range fields[1:] {
+			if !envPatternRe.MatchString(name) {
+				return fmt.Errorf("%q is not a variable name or pattern", name)
+			}
+			p.env = append(p.env, globRegexp(name))
+		}
+		return nil
+	case "redirect":
+		if len(fields) < 2 {
+			return fmt.Errorf("redirect needs at least one file pattern")
+		}
+		for _, target := range fields[1:] {
+			p.redirects = append(p.redirects, globRegexp(target))
+		}
+		return nil
+	case "deny":
+		if len(fields) < 2 {
+			return fmt.Errorf("deny needs a command")
+		}
+		if !validNameRe.MatchString(fields[1]) {
+			return fmt.Errorf("%q is not a command name", fields[1])
+		}
+		// Deny rules match the command however it is named: rm, /bin/rm, ./rm
+		name := path.Base(fields[1])
+		tokens := make([]string, len(fields)-2)
+		for i, token := range fields[2:] {
+			tokens[i] = token
+			if short, ok := longFlagForms[name][token]; ok {
+				tokens[i] = short
+			}
+		}
+		rule, err := parseCommandRule(line, splitShortFlags(tokens), false)
+		if err != nil {
+			return err
+		}
+		p.deny[name] = append(p.deny[name], rule)
+		return nil
+	}
+	if !validNameRe.MatchString(fields[0]) {
+		return fmt.Errorf("%q is not a command name", fields[0])
+	}
+	rule, err := parseCommandRule(line, fields[1:], true)
+	if err != nil {
+		return err
+	}
+	p.allow[fields[0]] = append(p.allow[fields[0]], rule)
+	return nil
+}
+
+// parseCommandRule parses the argument patterns of a rule.
+func parseCommandRule(source string, tokens []string, allowForbids bool) (commandRule, error) {
+	rule := commandRule{source: source}
+	for _, token := range tokens {
+		forbid := strings.HasPrefix(token, "!")
+		if forbid {
+			if !allowForbids {
+				return rule, fmt.Errorf("'!' patterns are only valid in allow rules")
+			}
+			token = token[1:]
+		}
+		pattern := argPattern{any: token == "*"}
+		if !pattern.any {
+			for _, alt := range strings.Split(token, "|") {
+				if alt == "" {
+					return rule, fmt.Errorf("empty alternative in %q", token)
+				}
+				pattern.alternatives = append(pattern.alternatives, globRegexp(alt))
+			}
+		}
+		if forbid {
+			if pattern.any {
+				return rule, fmt.Errorf("'!*' would forbid every argument")
+			}
+			rule.forbidden = append(rule.forbidden, pattern)
+			continue
+		}
+		if len(rule.forbidden) > 0 {
+			return rule, fmt.Errorf("positional pattern %q must come before '!' patterns", token)
+		}
+		rule.args = append(rule.args, pattern)
+	}
+	return rule, nil
+}
+
+// globRegexp compiles a pattern where '*' matches any run of characters.
+func globRegexp(glob string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(glob)
+	return regexp.MustCompile("^" + strings.ReplaceAll(quoted, `\*`, ".*") + "$")
+}
+
+// matches reports whether a literal argument matches the pattern.
+func (a argPattern) matches(value string) bool {
+	if a.any {
+		return true
+	}
+	for _, alt := range a.alternatives {
+		if alt.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// shellWord is an argument as far as it can be known before the shell runs.
+type shellWord struct {
+	text    string // Source text, for messages
+	value   string // Value after quote removal, when literal
+	literal bool   // No expansions; value is exactly what the command sees
+	glob    bool   // Unquoted glob characters; the shell may replace it with file names
+	brace   bool   // Unquoted brace expansion; the shell may turn it into several words
+}
+
+// Check parses a command string and checks every command in it. A command
+// that cannot be parsed is denied.
+func (p *CommandPolicy) Check(command string) *PolicyDecision {
+	decision := &PolicyDecision{Commands: []string{}}
+	p.check(command, 0, decision)
+	if len(decision.Commands) == 0 && len(decision.Violations) == 0 {
+		decision.Violations = []string{"no command provided"}
+	}
+	decision.Allowed = len(decision.Violations) == 0
+	return decision
+}
+
+// check parses a command string and adds its commands and violations to the
+// decision. depth counts the wrappers (sh -c, eval) the string came through.
+func (p *CommandPolicy) check(command string, depth int, decision *PolicyDecision) {
+	file, err := syntax.NewParser(syntax.Variant(syntax.LangPOSIX)).Parse(strings.NewReader(command), "")
+	if err != nil {
+		decision.Violations = append(decision.Violations, fmt.Sprintf("cannot parse command: %v", err))
+		return
+	}
+
+	syntax.Walk(file, func(node syntax.Node) bool {
+		switch n := node.(type) {
+		case *syntax.Stmt:
+			for _, redir := range n.Redirs {
+				p.checkRedirect(redir, decision)
+			}
+		case *syntax.FuncDecl:
+			// A function can shadow an allowed command name. Its body is
+			// walked like any other command, so deny rules still apply to it.
+			if p.Unrestricted {
+				return true
+			}
+			decision.Violations = append(decision.Violations, fmt.Sprintf("defining shell functions is not allowed (%s)", n.Name.Value))
+		case *syntax.CallExpr:
+			p.checkCall(n, depth, decision)
+		}
+		return true
+	})
+}
+
+// checkCall checks one simple command: its variable assignments, its name,
+// and its arguments.
+func (p *CommandPolicy) checkCall(call *syntax.CallExpr, depth int, decision *PolicyDecision) {
+	for _, assign := range call.Assigns {
+		p.checkEnv(assign.Name.Value, decision)
+	}
+	if len(call.Args) == 0 {
+		return
+	}
+
+	words := make([]shellWord, len(call.Args))
+	texts := make([]string, len(call.Args))
+	for i, arg := range call.Args {
+		words[i] = readWord(arg)
+		texts[i] = words[i].text
+	}
+	decision.Commands = append(decision.Commands, strings.Join(texts, " "))
+
+	p.checkCommand(words, depth, decision)
+}
+
+// checkCommand checks a command name and its arguments, then any command it
+// wraps.
+func (p *CommandPolicy) checkCommand(words []shellWord, depth int, decision *PolicyDecision) {
+	texts := make([]string, len(words))
+	for i, word := range words {
+		texts[i] = word.text
+	}
+
+	name := words[0]
+	if !name.literal || name.glob {
+		// {rm,-rf,~} expands to its own arguments
+		hidden := words[1:]
+		if name.brace {
+			hidden = words
+		}
+		if p.Unrestricted && !p.couldHideDenied(hidden, depth) {
+			return
+		}
+		decision.Violations = append(decision.Violations, fmt.Sprintf("command name %s must be a plain word", name.text))
+		return
+	}
+	args := words[1:]
+
+	// Variables exported by 'export' and 'readonly' reach later commands
+	if name.value == "export" || name.value == "readonly" {
+		for _, arg := range args {
+			if variable, _, _ := strings.Cut(arg.value, "="); arg.literal && !strings.HasPrefix(variable, "-") {
+				p.checkEnv(variable, decision)
+			}
+		}
+	}
+
+	base := path.Base(name.value)
+	for _, rule := range p.deny[base] {
+		if rule.deniesArgs(base, args) {
+			decision.Violations = append(decision.Violations, fmt.Sprintf("%s matches deny rule '%s'", strings.Join(texts, " "), rule.source))
+			return
+		}
+	}
+
+	if isWrapper(base) {
+		if !p.checkWrapped(base, args, depth, decision) {
+			return
+		}
+	}
+	if p.Unrestricted {
+		return
+	}
+
+	rules := p.allow[name.value]
+	if len(rules) == 0 {
+		decision.Violations = append(decision.Violations, fmt.Sprintf("command '%s' is not whitelisted", name.value))
+		return
+	}
+	for _, rule := range rules {
+		if rule.allowsArgs(args) {
+			return
+		}
+	}
+	sources := make([]string, len(rules))
+	for i, rule := range rules {
+		sources[i] = "'" + rule.source + "'"
+	}
+	decision.Violations = append(decision.Violations, fmt.Sprintf("%s is not allowed by %s", strings.Join(texts, " "), strings.Join(sources, ", ")))
+}
+
+// couldHideDenied reports whether a command whose name is only known at run
+// time could be refused by a deny rule: whether any denied command, or any
+// wrapper, given the same arguments would be.
+func (p *CommandPolicy) couldHideDenied(args []shellWord, depth int) bool {
+	if len(p.deny) == 0 {
+		return false
+	}
+	candidates := make([]string, 0, len(p.deny)+len(wrapperValueOptions))
+	for name := range p.deny {
+		candidates = append(candidates, name)
+	}
+	for name := range wrapperValueOptions {
+		candidates = append(candidates, name)
+	}
+	for _, candidate := range candidates {
+		words := append([]shellWord{{text: candidate, value: candidate, literal: true}}, args...)
+		scratch := &PolicyDecision{}
+		p.checkCommand(words, depth, scratch)
+		if len(scratch.Violations) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// wrapperValueOptions lists, per wrapper, the options that take a value in
+// the next argument.
+var wrapperValueOptions = map[string][]string{
+	"busybox": {},
+	"command": {},
+	"exec":    {"-a"},
+	"env":     {"-u", "--unset", "-C", "--chdir"},
+	"nice":    {"-n", "--adjustment"},
+	"nohup":   {},
+	"timeout": {"-s", "--signal", "-k", "--kill-after"},
+	"sudo":    {"-u", "--user", "-g", "--group", "-h", "--host", "-p", "--prompt", "-C", "--close-from", "-D", "--chdir", "-r", "--role", "-t", "--type"},
+	"doas":    {"-u", "-C"},
+	"setsid":  {},
+	"stdbuf":  {"-i", "--input", "-o", "--output", "-e", "--error"},
+	"ionice":  {"-c", "--class", "-n", "--classdata", "-p", "--pid", "-P", "--pgid", "-u", "--uid"},
+	"chroot":  {"--userspec", "--groups"},
+	"su":      {"-s", "--shell", "-g", "--group", "-G", "--supp-group", "-w", "--whitelist-environment"},
+	"script":  {"-E", "--echo", "-I", "--log-in", "-O", "--log-out", "-B", "--log-io", "-T", "--log-timing", "-m", "--logging-format"},
+	"xargs":   {"-a", "--arg-file", "-d", "--delimiter", "-E", "-I", "-L", "-n", "--max-args", "-P", "--max-procs", "-s", "--max-chars"},
+	"eval":    {},
+	"find":    {},
+	"sh":      {"-o", "+o"},
+	"bash":    {"-o", "+o", "-O", "+O", "--rcfile", "--init-file"},
+	"dash":    {"-o", "+o"},
+	"zsh":     {"-o", "+o"},
+	"ksh":     {"-o", "+o"},
+}
+
+// isWrapper reports whether a command runs another command or a script.
+func isWrapper(base string) bool {
+	_, ok := wrapperValueOptions[base]
+	return ok
+}
+
+// isShell reports whether a wrapper is a shell that runs a -c script.
+func isShell(base string) bool {
+	switch base {
+	case "sh", "bash", "dash", "zsh", "ksh":
+		return true
+	}
+	return false
+}
+
+// checkWrapped checks the command or script a wrapper runs. A wrapped
+// command that cannot be known before the shell runs is denied whenever the
+// policy has rules it could break. It returns false when a violation was
+// added.
+func (p *CommandPolicy) checkWrapped(base string, args []shellWord, depth int, decision *PolicyDecision) bool {
+	before := len(decision.Violations)
+	unknown := func(what string) bool {
+		if p.Unrestricted && len(p.deny) == 0 {
+			return true
+		}
+		decision.Violations = append(decision.Violations, fmt.Sprintf("cannot check %s run by %s", what, base))
+		return false
+	}
+	if depth >= maxWrapperDepth {
+		return unknown("the command")
+	}
+
+	if base == "find" {
+		p.checkFindExec(args, depth, decision)
+		return len(decision.Violations) == before
+	}
+
+	if base == "eval" {
+		parts := make([]string, len(args))
+		for i, arg := range args {
+			if !arg.literal {
+				return unknown("the script " + arg.text)
+			}
+			parts[i] = arg.value
+		}
+		if len(parts) > 0 {
+			p.check(strings.Join(parts, " "), depth+1, decision)
+		}
+		return len(decision.Violations) == before
+	}
+
+	if base == "su" || base == "script" {
+		return p.checkCommandOption(base, args, depth, decision, unknown)
+	}
+
+	// Skip the wrapper's own options
+	valueOptions := wrapperValueOptions[base]
+	runsScript := false
+	readsStdin := false
+	i := 0
+	for ; i < len(args); i++ {
+		arg := args[i]
+		if !arg.literal {
+			return unknown("the command")
+		}
+		if arg.value == "--" {
+			i++
+			break
+		}
+		if base == "env" && strings.Contains(arg.value, "=") && !strings.HasPrefix(arg.value, "-") {
+			variable, _, _ := strings.Cut(arg.value, "=")
+			p.checkEnv(variable, decision)
+			continue
+		}
+		if base == "env" && (arg.value == "-S" || arg.value == "--split-string") {
+			if i+1 >= len(args) || !args[i+1].literal {
+				return unknown("the command")
+			}
+			p.check(args[i+1].value, depth+1, decision)
+			return len(decision.Violations) == before
+		}
+		if len(arg.value) < 2 || (arg.value[0] != '-' && arg.value[0] != '+') {
+			break
+		}
+		if base == "command" && arg.value[0] == '-' && strings.ContainsAny(arg.value, "vV") {
+			// command -v and -V describe the command without running it
+			return len(decision.Violations) == before
+		}
+		if isShell(base) && arg.value[0] == '-' && !strings.HasPrefix(arg.value, "--") {
+			runsScript = runsScript || strings.Contains(arg.value, "c")
+			readsStdin = readsStdin || strings.Contains(arg.value, "s")
+		}
+		if (base == "sudo" || base == "doas") && arg.value[0] == '-' && !strings.HasPrefix(arg.value, "--") && strings.ContainsAny(arg.value, "si") {
+			readsStdin = true // sudo -s and sudo -i start a shell
+		}
+		if base == "sudo" && (arg.value == "--shell" || arg.value == "--login") {
+			readsStdin = true
+		}
+		for _, option := range valueOptions {
+			if arg.value == option {
+				i++
+				break
+			}
+		}
+	}
+	if (base == "timeout" || base == "chroot") && i < len(args) {
+		i++ // the duration, or the new root
+	}
+
+	if isShell(base) {
+		if !runsScript && (readsStdin || i >= len(args)) {
+			// echo 'rm -rf ~' | sh and sh < script.sh run a script from stdin
+			return unknown("the script read from stdin")
+		}
+		if !runsScript {
+			// sh script.sh runs a file the policy cannot see
+			return unknown("the script " + args[i].text)
+		}
+		if !args[i].literal {
+			return unknown("the script " + args[i].text)
+		}
+		p.check(args[i].value, depth+1, decision)
+		return len(decision.Violations) == before
+	}
+
+	if i >= len(args) {
+		if readsStdin || base == "chroot" {
+			// A shell started without a command reads its commands from stdin
+			return unknown("the shell")
+		}
+		return len(decision.Violations) == before
+	}
+	wrapped := args[i:]
+	if base == "xargs" {
+		// xargs appends arguments read from stdin
+		wrapped = append(append([]shellWord{}, wrapped...), shellWord{text: "<stdin>"})
+	}
+	p.checkCommand(wrapped, depth+1, decision)
+	return len(decision.Violations) == before
+}
+
+// findExecActions are the find actions that run a command, up to a ';' or
+// '+' argument.
+var findExecActions = map[string]bool{"-exec": true, "-execdir": true, "-ok": true, "-okdir": true}
+
+// checkFindExec checks the commands find runs through -exec and its
+// checkCommandOption checks the command string given to su -c or script -c.
+// Both take options after their positional arguments, so every argument is
+// searched. Without -c they start an interactive shell that reads stdin.
+func (p *CommandPolicy) checkCommandOption(base string, args []shellWord, depth int, decision *PolicyDecision, unknown func(string) bool) bool {
+	before := len(decision.Violations)
+	valueOptions := wrapperValueOptions[base]
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !arg.literal {
+			return unknown("the command")
+		}
+		if arg.value == "--" {
+			break
+		}
+		if command, ok := strings.CutPrefix(arg.value, "--command="); ok {
+			p.check(command, depth+1, decision)
+			return len(decision.Violations) == before
+		}
+		if arg.value == "--command" {
+			if i+1 >= len(args) || !args[i+1].literal {
+				return unknown("the command")
+			}
+			p.check(args[i+1].value, depth+1, decision)
+			return len(decision.Violations) == before
+		}
+		if slices.Contains(valueOptions, arg.value) {
+			i++ // the option's value
+			continue
+		}
+		if len(arg.value) < 2 || arg.value[0] != '-' || arg.value[1] == '-' {
+			continue
+		}
+		flags := arg.value[1:]
+		for j := 0; j < len(flags); j++ {
+			if flags[j] == 'c' {
+				// -c takes the rest of the word, or the next argument
+				command := flags[j+1:]
+				if command == "" {
+					if i+1 >= len(args) || !args[i+1].literal {
+						return unknown("the command")
+					}
+					command = args[i+1].value
+				}
+				p.check(command, depth+1, decision)
+				return len(decision.Violations) == before
+			}
+			if slices.Contains(valueOptions, "-"+string(flags[j])) {
+				if j == len(flags)-1 {
+					i++ // the option's value
+				}
+				break
+			}
+		}
+	}
+	return unknown("the shell")
+}
+
+// variants. The {} placeholder stands for a file name.
+func (p *CommandPolicy) checkFindExec(args []shellWord, depth int, decision *PolicyDecision) {
+	for i := 0; i < len(args); i++ {
+		if !args[i].literal {
+			// An expanded argument could be -exec
+			if !(p.Unrestricted && len(p.deny) == 0) {
+				decision.Violations = append(decision.Violations, fmt.Sprintf("cannot check the command run by find (%s)", args[i].text))
+				return
+			}
+			continue
+		}
+		if !findExecActions[args[i].value] {
+			continue
+		}
+		end := i + 1
+		for end < len(args) && !(args[end].literal && (args[end].value == ";" || args[end].value == "+")) {
+			end++
+		}
+		if end > i+1 {
+			p.checkCommand(args[i+1:end], depth+1, decision)
+		}
+		i = end
+	}
+}
+
+// longFlagForms maps, per command, long options to the short flags deny
+// rules compare them as (rm --recursive is rm -r).
+var longFlagForms = map[string]map[string]string{
+	"rm":    {"--recursive": "-r", "--force": "-f", "--dir": "-d", "--interactive": "-i", "--verbose": "-v"},
+	"cp":    {"--recursive": "-r", "--force": "-f", "--link": "-l", "--symbolic-link": "-s", "--verbose": "-v"},
+	"mv":    {"--force": "-f", "--interactive": "-i", "--verbose": "-v"},
+	"ln":    {"--force": "-f", "--symbolic": "-s", "--verbose": "-v"},
+	"chmod": {"--recursive": "-R", "--verbose": "-v"},
+	"chown": {"--recursive": "-R", "--verbose": "-v"},
+	"chgrp": {"--recursive": "-R", "--verbose": "-v"},
+	"git":   {"--force": "-f"},
+}
+
+// shortFlagForms returns the short flags a long option stands for. Options
+// may be abbreviated to any prefix, so an abbreviation stands for every long
+// option it could be.
+func shortFlagForms(base, arg string) []string {
+	if !strings.HasPrefix(arg, "--") || len(arg) < 3 || strings.Contains(arg, "=") {
+		return nil
+	}
+	var shorts []string
+	for long, short := range longFlagForms[base] {
+		if strings.HasPrefix(long, arg) {
+			shorts = append(shorts, short)
+		}
+	}
+	return shorts
+}
+
+// splitShortFlags rewrites clusters of single-letter flags into one flag per
+// letter (-rf becomes -r -f), so flags match however they are combined.
+func splitShortFlags(tokens []string) []string {
+	var out []string
+	for _, token := range tokens {
+		if !shortFlagsRe.MatchString(token) {
+			out = append(out, token)
+			continue
+		}
+		for _, c := range token[1:] {
+			out = append(out, "-"+string(c))
+		}
+	}
+	return out
+}
+
+// devNull is the one output redirection target allowed without a rule.
+const devNull = "/dev/null"
+
+// checkRedirect checks that an output redirection writes to an allowed file.
+// Input redirections and file descriptor duplications such as 2>&1 are not
+// writes and always pass.
+func (p *CommandPolicy) checkRedirect(redir *syntax.Redirect, decision *PolicyDecision) {
+	if p.Unrestricted || redir.Word == nil {
+		return
+	}
+	target := readWord(redir.Word)
+	switch redir.Op {
+	case syntax.RdrOut, syntax.AppOut, syntax.ClbOut, syntax.RdrInOut, syntax.RdrAll, syntax.AppAll:
+	case syntax.DplOut:
+		// >&2 and >&- duplicate or close a descriptor; >&file writes a file
+		if target.literal && (target.value == "-" || strings.Trim(target.value, "0123456789") == "") {
+			return
+		}
+	default:
+		return
+	}
+
+	if target.literal && !target.glob {
+		if target.value == devNull {
+			return
+		}
+		for _, re := range p.redirects {
+			if re.MatchString(target.value) {
+				return
+			}
+		}
+	}
+	decision.Violations = append(decision.Violations, fmt.Sprintf("redirecting output to %s is not allowed (add a 'redirect %s' rule)", target.text, target.text))
+}
+
+// checkEnv checks that a command may set a variable.
+func (p *CommandPolicy) checkEnv(variable string, decision *PolicyDecision) {
+	if p.Unrestricted {
+		return
+	}
+	for _, re := range p.env {
+		if re.MatchString(variable) {
+			return
+		}
+	}
+	decision.Violations = append(decision.Violations, fmt.Sprintf("setting %s is not allowed (add an 'env %s' rule)", variable, variable))
+}
+
+// allowsArgs reports whether an allow rule admits the arguments. An argument
+// whose value is only known at run time matches only '*', and fails every
+// rule with '!' patterns.
+func (r commandRule) allowsArgs(args []shellWord) bool {
+	if len(args) < len(r.args) {
+		return false
+	}
+	for i, pattern := range r.args {
+		if pattern.any {
+			continue
+		}
+		if !args[i].literal || args[i].glob || !pattern.matches(args[i].value) {
+			return false
+		}
+	}
+	if len(r.forbidden) == 0 {
+		return true
+	}
+	for _, arg := range args {
+		if !arg.literal || arg.glob {
+			return false
+		}
+		for _, pattern := range r.forbidden {
+			if pattern.matches(arg.value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// deniesArgs reports whether a deny rule matches: every pattern matches some
+// argument. An argument only known at run time could match any pattern.
+func (r commandRule) deniesArgs(base string, args []shellWord) bool {
+	var expanded []shellWord
+	for _, arg := range args {
+		expanded = append(expanded, arg)
+		if !arg.literal {
+			continue
+		}
+		if shortFlagsRe.MatchString(arg.value) {
+			for _, flag := range splitShortFlags([]string{arg.value}) {
+				expanded = append(expanded, shellWord{text: arg.text, value: flag, literal: true})
+			}
+		}
+		for _, flag := range shortFlagForms(base, arg.value) {
+			expanded = append(expanded, shellWord{text: arg.text, value: flag, literal: true})
+		}
+	}
+	args = expanded
+
+	for _, pattern := range r.args {
+		found := false
+		for _, arg := range args {
+			if pattern.any || !arg.literal || arg.glob || pattern.matches(arg.value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// readWord resolves a word to its value when it contains only literal text
+// and quotes.
+func readWord(word *syntax.Word) shellWord {
+	var buf bytes.Buffer
+	syntax.NewPrinter().Print(&buf, word)
+	result := shellWord{text: buf.String(), literal: true}
+
+	var value strings.Builder
+	var unquoted strings.Builder // The word with quoted and escaped characters masked
+	for _, part := range word.Parts {
+		switch p := part.(type) {
+		case *syntax.Lit:
+			text, glob := unescapeLit(p.Value, false)
+			value.WriteString(text)
+			unquoted.WriteString(maskEscapes(p.Value))
+			result.glob = result.glob || glob
+		case *syntax.SglQuoted:
+			unquoted.WriteByte('x')
+			if p.Dollar {
+				result.literal = false
+			}
+			value.WriteString(p.Value)
+		case *syntax.DblQuoted:
+			unquoted.WriteByte('x')
+			if p.Dollar {
+				result.literal = false
+			}
+			for _, inner := range p.Parts {
+				lit, ok := inner.(*syntax.Lit)
+				if !ok {
+					result.literal = false
+					continue
+				}
+				text, _ := unescapeLit(lit.Value, true)
+				value.WriteString(text)
+			}
+		default:
+			unquoted.WriteByte('x')
+			result.literal = false
+		}
+	}
+	if hasBraceExpansion(unquoted.String()) {
+		result.literal = false
+		result.brace = true
+	}
+	result.value = value.String()
+	return result
+}
+
+// unescapeLit removes backslash escapes from literal text, and reports
+// unescaped glob characters outside double quotes.
+func unescapeLit(text string, quoted bool) (string, bool) {
+	var out strings.Builder
+	glob := false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c == '\\' && i+1 < len(text) {
+			next := text[i+1]
+			if !quoted || strings.IndexByte("$`\"\\\n", next) >= 0 {
+				i++
+				if next != '\n' {
+					out.WriteByte(next)
+				}
+				continue
+			}
+		}
+		if !quoted && (c == '*' || c == '?' || c == '[') {
+			glob = true
+		}
+		out.WriteByte(c)
+	}
+	return out.String(), glob
+}
+
+// maskEscapes replaces each backslash-escaped character of an unquoted literal
+// with a placeholder, so only characters the shell interprets remain.
+func maskEscapes(text string) string {
+	var out strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) {
+			i++
+			out.WriteByte('x')
+			continue
+		}
+		out.WriteByte(text[i])
+	}
+	return out.String()
+}
+
+// hasBraceExpansion reports whether unquoted text contains a brace expansion:
+// {a,b} or {a..b}. Braces without a comma or '..', such as {} or @{u}, are
+// kept as they are by the shell.
+func hasBraceExpansion(text string) bool {
+	var open []int
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			open = append(open, i)
+		case '}':
+			if len(open) == 0 {
+				continue
+			}
+			start := open[len(open)-1]
+			open = open[:len(open)-1]
+			inner := text[start+1 : i]
+			if strings.Contains(inner, ",") || strings.Contains(inner, "..") {
+				return true
+			}
+		}
+	}
+	return false
+}
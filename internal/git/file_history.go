@@ -0,0 +1,82 @@
+/**
+ * Component: Git File History
+ * Block-UUID: 5f7a2c90-1e64-4d3b-b8a9-6c0e4d2f9137
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Lists the commits that touched one file within a time range and reads the lines a commit added to that file, for correlating agent edits with commits.
+ * Language: Go
+ * Created-at: 2026-10-18T16:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// FileCommit is one commit that touched a file.
+type FileCommit struct {
+	Hash       string    `json:"hash"`
+	AuthorTime time.Time `json:"author_time"`
+	Author     string    `json:"author"`
+	Subject    string    `json:"subject"`
+}
+
+// FileCommits returns the commits that touched relPath between since and until
+// (inclusive, by committer date), newest first. Renames are followed.
+func FileCommits(ctx context.Context, repoRoot string, relPath string, since time.Time, until time.Time) ([]FileCommit, error) {
+	args := []string{"log", "--follow", "--format=%H%x1f%aI%x1f%an%x1f%s"}
+	if !since.IsZero() {
+		args = append(args, "--since="+since.Format(time.RFC3339))
+	}
+	if !until.IsZero() {
+		args = append(args, "--until="+until.Format(time.RFC3339))
+	}
+	args = append(args, "--", relPath)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = repoRoot
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s: %w: %s", relPath, err, strings.TrimSpace(stderr.String()))
+	}
+
+	var commits []FileCommit
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 {
+			continue
+		}
+		authorTime, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			continue
+		}
+		commits = append(commits, FileCommit{Hash: fields[0], AuthorTime: authorTime, Author: fields[2], Subject: fields[3]})
+	}
+	return commits, nil
+}
+
+// CommitAddedLines returns the lines a commit added to relPath.
+func CommitAddedLines(ctx context.Context, repoRoot string, hash string, relPath string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "show", "--format=", "--unified=0", "--no-color", hash, "--", relPath)
+	cmd.Dir = repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git show %s: %w", hash, err)
+	}
+	var added []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+			added = append(added, line[1:])
+		}
+	}
+	return added, nil
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Knowledge Document Model
- * Block-UUID: a1b2c3d4-e5f6-7890-abcd-200000000001
- * Parent-UUID: N/A
- * Version: 1.1.0
- * Description: Added UpdatedAt field for sorting by last modified date.
+ * Block-UUID: 5fd57f1d-3520-44b5-87de-ee6b1ba39cc0
+ * Parent-UUID: a1b2c3d4-e5f6-7890-abcd-200000000001
+ * Version: 1.2.0
+ * Description: Added LinkedFiles so graph and context views can follow explicit file links.
  * Language: Go
  * Created-at: 2026-06-22T10:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -34,6 +34,7 @@ type Document struct {
 	Body          string // Details for lessons, Content for notes, Details for rules
 	Importance    string
 	Files         []string // From AppliesTo
+	LinkedFiles   []string
 	GlobPatterns  []string
 	UpdatedAt     time.Time
 }
@@ -0,0 +1,444 @@
+/**
+ * Component: Knowledge File Context
+ * Block-UUID: 26066f1b-56b9-43f4-85d4-a8f2603fdc11
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Assembles per-file context for agents: applicable rules with match provenance, lessons, notes, and Brain metadata, deduplicated, ranked by importance, and capped to a token budget.
+ * Language: Go
+ * Created-at: 2026-10-18T12:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	lessonspkg "github.com/gitsense/gsc-cli/internal/lessons"
+	"github.com/gitsense/gsc-cli/internal/manifest"
+	notespkg "github.com/gitsense/gsc-cli/internal/notes"
+	"github.com/gitsense/gsc-cli/internal/registry"
+	rulespkg "github.com/gitsense/gsc-cli/internal/rules"
+)
+
+// ContextOptions controls what BuildFileContext gathers.
+type ContextOptions struct {
+	Paths           []string // Repo-relative file paths
+	Scope           gitsensescope.Scope
+	Index           IndexOptions // Which knowledge types to include
+	IncludeBrains   bool         // Include Brain metadata
+	Databases       []string     // Brain databases to query (default: every registered Brain)
+	TokenBudget     int          // Approximate token cap for knowledge items (0 = unlimited)
+	IncludeArchived bool         // Include expired and archived notes
+}
+
+// ContextMatch records why an item applies to a file.
+type ContextMatch struct {
+	File  string `json:"file"`
+	Kind  string `json:"kind"` // file, linked_file, glob
+	Value string `json:"value"`
+}
+
+// ContextItem is a deduplicated knowledge item that applies to one or more files.
+type ContextItem struct {
+	Type         DocumentType         `json:"type"`
+	ID           string               `json:"id"`
+	Source       gitsensescope.Source `json:"source"`
+	Topic        string               `json:"topic,omitempty"`
+	Importance   string               `json:"importance,omitempty"`
+	Summary      string               `json:"summary"`
+	Body         string               `json:"body,omitempty"`
+	Instructions []string             `json:"instructions,omitempty"`
+	Matches      []ContextMatch       `json:"matches"`
+	Score        int                  `json:"score"`
+	Tokens       int                  `json:"tokens"`
+	UpdatedAt    time.Time            `json:"updated_at"`
+}
+
+// BrainMetadata is the metadata a Brain database holds for one file.
+type BrainMetadata struct {
+	Database string                 `json:"database"`
+	Fields   map[string]interface{} `json:"fields"`
+}
+
+// FileContext lists the items and Brain metadata for one requested file.
+type FileContext struct {
+	Path    string          `json:"path"`
+	Rules   []string        `json:"rules"`
+	Lessons []string        `json:"lessons"`
+	Notes   []string        `json:"notes"`
+	Brains  []BrainMetadata `json:"brains,omitempty"`
+}
+
+// OmittedItem is an item dropped to stay within the token budget.
+type OmittedItem struct {
+	Type    DocumentType `json:"type"`
+	ID      string       `json:"id"`
+	Summary string       `json:"summary"`
+	Tokens  int          `json:"tokens"`
+}
+
+// ContextResponse is the assembled context for a set of files.
+type ContextResponse struct {
+	Files       []FileContext `json:"files"`
+	Items       []ContextItem `json:"items"`
+	Omitted     []OmittedItem `json:"omitted,omitempty"`
+	TokenBudget int           `json:"token_budget,omitempty"`
+	TokensUsed  int           `json:"tokens_used"`
+	Warnings    []string      `json:"warnings,omitempty"`
+}
+
+// BuildFileContext loads knowledge from every store in scope and the Brain
+// databases, then assembles context for opts.Paths.
+func BuildFileContext(ctx context.Context, opts ContextOptions) (*ContextResponse, error) {
+	if len(opts.Paths) == 0 {
+		return nil, fmt.Errorf("at least one path is required")
+	}
+	paths := make([]string, len(opts.Paths))
+	for i, p := range opts.Paths {
+		paths[i] = cleanGraphPath(p)
+	}
+
+	var (
+		sourcedRules   []rulespkg.SourcedRule
+		sourcedLessons []lessonspkg.SourcedLesson
+		sourcedNotes   []notespkg.SourcedNote
+		err            error
+	)
+	if opts.Index.IncludeRules {
+		if sourcedRules, err = rulespkg.LoadRecordsFromScope(opts.Scope); err != nil {
+			return nil, fmt.Errorf("failed to load rules: %w", err)
+		}
+	}
+	if opts.Index.IncludeLessons {
+		if sourcedLessons, err = lessonspkg.LoadRecordsFromScope(opts.Scope); err != nil {
+			return nil, fmt.Errorf("failed to load lessons: %w", err)
+		}
+	}
+	if opts.Index.IncludeNotes {
+		if sourcedNotes, err = notespkg.LoadRecordsFromScope(opts.Scope); err != nil {
+			return nil, fmt.Errorf("failed to load notes: %w", err)
+		}
+		if !opts.IncludeArchived {
+			sourcedNotes = notespkg.FilterLiveSourced(sourcedNotes, time.Now().UTC())
+		}
+	}
+
+	resp := AssembleFileContext(paths, sourcedRules, sourcedLessons, sourcedNotes, opts.TokenBudget)
+
+	if opts.IncludeBrains {
+		brains, warnings := loadBrainMetadata(ctx, paths, opts.Databases)
+		resp.Warnings = append(resp.Warnings, warnings...)
+		for i := range resp.Files {
+			resp.Files[i].Brains = brains[resp.Files[i].Path]
+		}
+	}
+	return resp, nil
+}
+
+// AssembleFileContext matches records against paths, merges items that apply
+// to several files, ranks them, and applies the token budget. Items are kept
+// greedily in rank order; an item that does not fit is omitted while smaller,
+// lower-ranked items may still be included.
+func AssembleFileContext(paths []string, sourcedRules []rulespkg.SourcedRule, sourcedLessons []lessonspkg.SourcedLesson, sourcedNotes []notespkg.SourcedNote, budget int) *ContextResponse {
+	items := make(map[string]*ContextItem)
+	var order []string
+	add := func(item ContextItem, m ContextMatch) {
+		key := string(item.Type) + ":" + item.ID
+		existing, ok := items[key]
+		if !ok {
+			existing = &item
+			items[key] = existing
+			order = append(order, key)
+		}
+		existing.Matches = append(existing.Matches, m)
+	}
+
+	for _, path := range paths {
+		for _, smr := range rulespkg.GetSourcedRulesForFile(sourcedRules, path, "", "") {
+			r := smr.MatchedRule.Rule
+			if !r.IsEnabled() {
+				continue
+			}
+			m := ContextMatch{File: path, Kind: "unknown", Value: smr.MatchedRule.MatchReason}
+			if p := smr.MatchedRule.Match; p != nil {
+				m.Kind, m.Value = p.Kind, p.Value
+			}
+			add(ContextItem{
+				Type: TypeRule, ID: r.ID, Source: smr.Source, Topic: r.Topic, Importance: r.Importance,
+				Summary: r.Summary, Body: r.Details, Instructions: r.Instructions, UpdatedAt: r.UpdatedAt,
+			}, m)
+		}
+		for _, sl := range sourcedLessons {
+			l := sl.Lesson
+			if kind, value := lessonMatch(l, path); kind != "" {
+				add(ContextItem{
+					Type: TypeLesson, ID: l.ID, Source: sl.Source, Topic: l.Topic, Importance: l.Importance,
+					Summary: l.Summary, Body: l.Details, UpdatedAt: l.UpdatedAt,
+				}, ContextMatch{File: path, Kind: kind, Value: value})
+			}
+		}
+		for _, smn := range notespkg.GetSourcedNotesForFile(sourcedNotes, path) {
+			n := smn.MatchedNote.Note
+			kind, value, _ := strings.Cut(smn.MatchedNote.MatchReason, ": ")
+			if kind == "file" {
+				kind = "linked_file"
+			}
+			add(ContextItem{
+				Type: TypeNote, ID: n.ID, Source: smn.Source, Topic: n.Topic, Importance: n.Importance,
+				Summary: n.Summary, Body: n.Content, UpdatedAt: n.UpdatedAt,
+			}, ContextMatch{File: path, Kind: kind, Value: value})
+		}
+	}
+
+	ranked := make([]ContextItem, 0, len(order))
+	for _, key := range order {
+		item := items[key]
+		item.Score = contextScore(*item)
+		item.Tokens = estimateTokens(item.Summary, item.Body, strings.Join(item.Instructions, "\n"))
+		ranked = append(ranked, *item)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if !ranked[i].UpdatedAt.Equal(ranked[j].UpdatedAt) {
+			return ranked[i].UpdatedAt.After(ranked[j].UpdatedAt)
+		}
+		return ranked[i].ID < ranked[j].ID
+	})
+
+	resp := &ContextResponse{Items: []ContextItem{}, TokenBudget: budget}
+	for _, item := range ranked {
+		if budget > 0 && resp.TokensUsed+item.Tokens > budget {
+			resp.Omitted = append(resp.Omitted, OmittedItem{Type: item.Type, ID: item.ID, Summary: item.Summary, Tokens: item.Tokens})
+			continue
+		}
+		resp.TokensUsed += item.Tokens
+		resp.Items = append(resp.Items, item)
+	}
+
+	for _, path := range paths {
+		fc := FileContext{Path: path, Rules: []string{}, Lessons: []string{}, Notes: []string{}}
+		for _, item := range resp.Items {
+			if !item.appliesTo(path) {
+				continue
+			}
+			switch item.Type {
+			case TypeRule:
+				fc.Rules = append(fc.Rules, item.ID)
+			case TypeLesson:
+				fc.Lessons = append(fc.Lessons, item.ID)
+			case TypeNote:
+				fc.Notes = append(fc.Notes, item.ID)
+			}
+		}
+		resp.Files = append(resp.Files, fc)
+	}
+	return resp
+}
+
+func (item ContextItem) appliesTo(path string) bool {
+	for _, m := range item.Matches {
+		if m.File == path {
+			return true
+		}
+	}
+	return false
+}
+
+// lessonMatch reports how a lesson applies to path. AppliesTo.Files entries
+// may be exact paths or globs; linked files must match exactly.
+func lessonMatch(l lessonspkg.Record, path string) (string, string) {
+	for _, f := range l.AppliesTo.Files {
+		if cleanGraphPath(f) == path {
+			return "file", f
+		}
+	}
+	for _, f := range l.AppliesTo.LinkedFiles {
+		if cleanGraphPath(f) == path {
+			return "linked_file", f
+		}
+	}
+	for _, f := range l.AppliesTo.Files {
+		if strings.ContainsAny(f, "*?[{") {
+			if ok, _ := doublestar.Match(f, path); ok {
+				return "glob", f
+			}
+		}
+	}
+	return "", ""
+}
+
+// contextScore ranks items by importance first, then by type (rules are
+// binding, lessons are reviewed, notes are scratch), then by how specific the
+// match is and how many requested files it covers.
+func contextScore(item ContextItem) int {
+	score := 0
+	switch strings.ToLower(item.Importance) {
+	case "high":
+		score += 300
+	case "medium":
+		score += 200
+	case "low":
+		score += 100
+	}
+	switch item.Type {
+	case TypeRule:
+		score += 30
+	case TypeLesson:
+		score += 20
+	case TypeNote:
+		score += 10
+	}
+	for _, m := range item.Matches {
+		if m.Kind == "file" || m.Kind == "linked_file" {
+			score += 5
+			break
+		}
+	}
+	files := make(map[string]bool)
+	for _, m := range item.Matches {
+		files[m.File] = true
+	}
+	return score + len(files) - 1
+}
+
+// estimateTokens approximates tokens as one per four characters.
+func estimateTokens(parts ...string) int {
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	return (n + 3) / 4
+}
+
+// loadBrainMetadata queries each Brain database for the given paths. The
+// knowledge Brains (lessons, notes, rules) are skipped because their records
+// are already included directly.
+func loadBrainMetadata(ctx context.Context, paths []string, databases []string) (map[string][]BrainMetadata, []string) {
+	result := make(map[string][]BrainMetadata)
+	var warnings []string
+
+	if len(databases) == 0 {
+		reg, err := registry.LoadRegistry()
+		if err != nil {
+			return result, []string{fmt.Sprintf("Brain metadata unavailable: %v", err)}
+		}
+		for _, entry := range reg.Databases {
+			switch entry.DatabaseName {
+			case lessonspkg.DatabaseName, notespkg.DatabaseName, rulespkg.DatabaseName:
+				continue
+			}
+			databases = append(databases, entry.DatabaseName)
+		}
+	}
+
+	for _, dbName := range databases {
+		metadata, err := manifest.GetMetadataForFiles(ctx, paths, dbName)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("Brain %s: %v", dbName, err))
+			continue
+		}
+		for _, path := range paths {
+			m, ok := metadata[path]
+			if !ok || m.Status != "found" || len(m.Fields) == 0 {
+				continue
+			}
+			result[path] = append(result[path], BrainMetadata{Database: dbName, Fields: m.Fields})
+		}
+	}
+	return result, warnings
+}
+
+// RenderContextMarkdown renders a context response as markdown for agents.
+func RenderContextMarkdown(resp *ContextResponse) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Context for %d file(s)\n\n", len(resp.Files))
+
+	for _, fc := range resp.Files {
+		fmt.Fprintf(&b, "## %s\n\n", fc.Path)
+		if len(fc.Rules)+len(fc.Lessons)+len(fc.Notes) == 0 && len(fc.Brains) == 0 {
+			b.WriteString("No rules, lessons, notes, or Brain metadata.\n\n")
+			continue
+		}
+		for _, brain := range fc.Brains {
+			fmt.Fprintf(&b, "- Brain `%s`:", brain.Database)
+			for _, key := range sortedKeys(brain.Fields) {
+				fmt.Fprintf(&b, " %s=%v;", key, brain.Fields[key])
+			}
+			b.WriteString("\n")
+		}
+		writeIDList(&b, "Rules", fc.Rules)
+		writeIDList(&b, "Lessons", fc.Lessons)
+		writeIDList(&b, "Notes", fc.Notes)
+		b.WriteString("\n")
+	}
+
+	for _, section := range []struct {
+		title string
+		kind  DocumentType
+	}{{"Rules", TypeRule}, {"Lessons", TypeLesson}, {"Notes", TypeNote}} {
+		var items []ContextItem
+		for _, item := range resp.Items {
+			if item.Type == section.kind {
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "## %s\n\n", section.title)
+		for _, item := range items {
+			importance := item.Importance
+			if importance == "" {
+				importance = "-"
+			}
+			fmt.Fprintf(&b, "### [%s] %s (%s)\n\n", importance, item.Summary, item.ID)
+			fmt.Fprintf(&b, "_Source: %s; matched %s_\n\n", item.Source, describeMatches(item.Matches))
+			for _, instr := range item.Instructions {
+				fmt.Fprintf(&b, "- %s\n", instr)
+			}
+			if len(item.Instructions) > 0 {
+				b.WriteString("\n")
+			}
+			if body := strings.TrimSpace(item.Body); body != "" {
+				b.WriteString(body)
+				b.WriteString("\n\n")
+			}
+		}
+	}
+
+	if len(resp.Omitted) > 0 {
+		fmt.Fprintf(&b, "_Omitted %d item(s) to stay within %d tokens:_\n\n", len(resp.Omitted), resp.TokenBudget)
+		for _, o := range resp.Omitted {
+			fmt.Fprintf(&b, "- %s %s: %s (~%d tokens)\n", o.Type, o.ID, o.Summary, o.Tokens)
+		}
+		b.WriteString("\n")
+	}
+	for _, w := range resp.Warnings {
+		fmt.Fprintf(&b, "> Warning: %s\n", w)
+	}
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func writeIDList(b *strings.Builder, label string, ids []string) {
+	if len(ids) > 0 {
+		fmt.Fprintf(b, "- %s: %s\n", label, strings.Join(ids, ", "))
+	}
+}
+
+func describeMatches(matches []ContextMatch) string {
+	parts := make([]string, len(matches))
+	for i, m := range matches {
+		parts[i] = fmt.Sprintf("%s by %s %s", filepath.ToSlash(m.File), m.Kind, m.Value)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,104 @@
+/**
+ * Component: Knowledge File Context Tests
+ * Block-UUID: 6c1b73ec-f043-4d6b-84ca-b7ad0d94384c
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests for per-file context assembly: match provenance, cross-file deduplication, importance ranking, and token budgeting.
+ * Language: Go
+ * Created-at: 2026-10-18T12:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package knowledge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	lessonspkg "github.com/gitsense/gsc-cli/internal/lessons"
+	notespkg "github.com/gitsense/gsc-cli/internal/notes"
+	rulespkg "github.com/gitsense/gsc-cli/internal/rules"
+)
+
+func contextFixture() ([]rulespkg.SourcedRule, []lessonspkg.SourcedLesson, []notespkg.SourcedNote) {
+	disabled := false
+	rules := []rulespkg.SourcedRule{
+		{Source: gitsensescope.SourceRepo, Rule: rulespkg.Rule{
+			ID: "rule_cli", Summary: "Keep commands thin", Importance: "medium",
+			GlobPatterns: []string{"internal/cli/**"}, Instructions: []string{"Move logic into internal packages"},
+		}},
+		{Source: gitsensescope.SourceRepo, Rule: rulespkg.Rule{
+			ID: "rule_off", Summary: "Disabled", Importance: "high",
+			GlobPatterns: []string{"internal/cli/**"}, Enabled: &disabled,
+		}},
+	}
+	lessons := []lessonspkg.SourcedLesson{
+		{Source: gitsensescope.SourceRepo, Lesson: lessonspkg.Record{
+			ID: "lsn_root", Summary: "Root preflight", Importance: "high",
+			AppliesTo: lessonspkg.AppliesTo{Files: []string{"internal/cli/root.go"}},
+		}},
+		{Source: gitsensescope.SourcePersonal, Lesson: lessonspkg.Record{
+			ID: "lsn_glob", Summary: "Flag naming", Importance: "low",
+			AppliesTo: lessonspkg.AppliesTo{Files: []string{"internal/cli/*.go"}},
+		}},
+	}
+	notes := []notespkg.SourcedNote{
+		{Source: gitsensescope.SourcePersonal, Note: notespkg.Note{
+			ID: "note_grep", Summary: "Investigating grep flags", Importance: "medium",
+			Content: strings.Repeat("x", 400), LinkedFiles: []string{"internal/cli/grep.go"},
+		}},
+	}
+	return rules, lessons, notes
+}
+
+func TestAssembleFileContextDedupAndRank(t *testing.T) {
+	rules, lessons, notes := contextFixture()
+	paths := []string{"internal/cli/root.go", "internal/cli/grep.go"}
+	resp := AssembleFileContext(paths, rules, lessons, notes, 0)
+
+	var ids []string
+	for _, item := range resp.Items {
+		ids = append(ids, item.ID)
+	}
+	want := []string{"lsn_root", "rule_cli", "note_grep", "lsn_glob"}
+	if strings.Join(ids, ",") != strings.Join(want, ",") {
+		t.Fatalf("ranked items = %v, want %v", ids, want)
+	}
+
+	rule := resp.Items[1]
+	if len(rule.Matches) != 2 || rule.Matches[0].Kind != "glob" || rule.Matches[0].Value != "internal/cli/**" {
+		t.Errorf("rule matches = %+v, want one glob match per file", rule.Matches)
+	}
+	if resp.Items[2].Matches[0].Kind != "linked_file" {
+		t.Errorf("note match kind = %q, want linked_file", resp.Items[2].Matches[0].Kind)
+	}
+
+	if got := resp.Files[0]; len(got.Rules) != 1 || len(got.Lessons) != 2 || len(got.Notes) != 0 {
+		t.Errorf("root.go context = %+v", got)
+	}
+	if got := resp.Files[1]; len(got.Rules) != 1 || len(got.Lessons) != 1 || len(got.Notes) != 1 {
+		t.Errorf("grep.go context = %+v", got)
+	}
+}
+
+func TestAssembleFileContextBudget(t *testing.T) {
+	rules, lessons, notes := contextFixture()
+	paths := []string{"internal/cli/root.go", "internal/cli/grep.go"}
+	resp := AssembleFileContext(paths, rules, lessons, notes, 30)
+
+	if resp.TokensUsed > 30 {
+		t.Errorf("tokens used = %d, want <= 30", resp.TokensUsed)
+	}
+	if len(resp.Omitted) != 1 || resp.Omitted[0].ID != "note_grep" {
+		t.Errorf("omitted = %+v, want the oversized note", resp.Omitted)
+	}
+	for _, fc := range resp.Files {
+		if len(fc.Notes) != 0 {
+			t.Errorf("%s still references omitted notes: %v", fc.Path, fc.Notes)
+		}
+	}
+	if md := RenderContextMarkdown(resp); !strings.Contains(md, "Omitted 1 item(s)") {
+		t.Errorf("markdown does not report omitted items:\n%s", md)
+	}
+}
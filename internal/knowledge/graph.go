@@ -0,0 +1,418 @@
+/**
+ * Component: Knowledge Graph
+ * Block-UUID: 1fe16e11-6dbe-41a3-bb3c-82252993e1a6
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Builds an in-memory graph of topics, knowledge records, globs, and repository paths, with hop-limited neighborhood queries and DOT/Mermaid export.
+ * Language: Go
+ * Created-at: 2026-10-18T11:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package knowledge
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+	topicspkg "github.com/gitsense/gsc-cli/internal/topics"
+)
+
+// NodeKind identifies the kind of a graph node.
+type NodeKind string
+
+const (
+	NodeTopic  NodeKind = "topic"
+	NodeLesson NodeKind = "lesson"
+	NodeNote   NodeKind = "note"
+	NodeRule   NodeKind = "rule"
+	NodeGlob   NodeKind = "glob"
+	NodeFile   NodeKind = "file"
+)
+
+// EdgeKind identifies how two graph nodes are related.
+type EdgeKind string
+
+const (
+	EdgeTopic        EdgeKind = "topic"         // record -> primary topic
+	EdgeRelatedTopic EdgeKind = "related_topic" // record -> related topic
+	EdgeAppliesTo    EdgeKind = "applies_to"    // record -> file
+	EdgeLinkedFile   EdgeKind = "linked_file"   // record -> file
+	EdgeGlob         EdgeKind = "glob"          // record -> glob pattern
+	EdgeMatches      EdgeKind = "matches"       // glob -> file
+)
+
+// GraphNode is a vertex in the knowledge graph. IDs are "<kind>:<key>".
+type GraphNode struct {
+	ID       string   `json:"id"`
+	Kind     NodeKind `json:"kind"`
+	Key      string   `json:"key"`
+	Label    string   `json:"label,omitempty"`
+	Distance *int     `json:"distance,omitempty"`
+}
+
+// GraphEdge is a directed relationship between two nodes.
+type GraphEdge struct {
+	From string   `json:"from"`
+	To   string   `json:"to"`
+	Kind EdgeKind `json:"kind"`
+}
+
+// Graph is an in-memory knowledge graph. Traversal treats edges as undirected.
+type Graph struct {
+	Nodes map[string]*GraphNode
+	Edges []GraphEdge
+
+	adj   map[string][]int
+	edges map[GraphEdge]bool
+}
+
+// GraphOptions controls which documents and paths are loaded into the graph.
+type GraphOptions struct {
+	Index IndexOptions
+
+	// Files are additional repository paths to connect to glob patterns.
+	// Only paths matched by at least one glob become nodes.
+	Files []string
+}
+
+// GraphResponse is the serialized form of a graph.
+type GraphResponse struct {
+	Focus string      `json:"focus,omitempty"`
+	Hops  int         `json:"hops,omitempty"`
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+}
+
+// NodeID returns the graph node ID for a kind and key.
+func NodeID(kind NodeKind, key string) string {
+	return string(kind) + ":" + key
+}
+
+// BuildGraph loads knowledge documents and the topic registry and returns the graph.
+func BuildGraph(opts GraphOptions) (*Graph, error) {
+	docs, err := BuildIndex(opts.Index)
+	if err != nil {
+		return nil, err
+	}
+	g := NewGraph(docs, opts.Files)
+
+	// Registered topics without records still appear as isolated nodes.
+	if registry, err := topicspkg.LoadRegistry(); err == nil {
+		for _, slug := range registry.Slugs() {
+			g.addNode(NodeTopic, slug, "")
+		}
+	}
+	return g, nil
+}
+
+// NewGraph builds a graph from documents. Globs are matched against every
+// file referenced by a document plus the extra files supplied.
+func NewGraph(docs []Document, files []string) *Graph {
+	g := &Graph{
+		Nodes: make(map[string]*GraphNode),
+		adj:   make(map[string][]int),
+		edges: make(map[GraphEdge]bool),
+	}
+
+	for _, d := range docs {
+		rec := g.addNode(recordKind(d.Type), d.ID, d.Summary)
+		if d.Topic != "" {
+			g.addEdge(rec, g.addNode(NodeTopic, d.Topic, ""), EdgeTopic)
+		}
+		for _, t := range d.RelatedTopics {
+			if t != "" && t != d.Topic {
+				g.addEdge(rec, g.addNode(NodeTopic, t, ""), EdgeRelatedTopic)
+			}
+		}
+		for _, f := range d.Files {
+			g.addEdge(rec, g.addNode(NodeFile, cleanGraphPath(f), ""), EdgeAppliesTo)
+		}
+		for _, f := range d.LinkedFiles {
+			g.addEdge(rec, g.addNode(NodeFile, cleanGraphPath(f), ""), EdgeLinkedFile)
+		}
+		for _, p := range d.GlobPatterns {
+			g.addEdge(rec, g.addNode(NodeGlob, p, ""), EdgeGlob)
+		}
+	}
+
+	// Connect globs to concrete paths.
+	candidates := make(map[string]bool)
+	for _, n := range g.Nodes {
+		if n.Kind == NodeFile {
+			candidates[n.Key] = true
+		}
+	}
+	for _, f := range files {
+		candidates[cleanGraphPath(f)] = true
+	}
+	var globs []*GraphNode
+	for _, n := range g.Nodes {
+		if n.Kind == NodeGlob {
+			globs = append(globs, n)
+		}
+	}
+	for _, path := range sortedKeys(candidates) {
+		for _, glob := range globs {
+			if ok, _ := doublestar.Match(glob.Key, path); ok {
+				g.addEdge(glob.ID, g.addNode(NodeFile, path, ""), EdgeMatches)
+			}
+		}
+	}
+	return g
+}
+
+// Resolve maps a user-supplied reference to a node ID. It accepts a full node
+// ID ("file:main.go"), a record ID or unique prefix, a topic slug, a path, or a
+// glob pattern.
+func (g *Graph) Resolve(ref string) (string, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return "", fmt.Errorf("empty node reference")
+	}
+	if _, ok := g.Nodes[ref]; ok {
+		return ref, nil
+	}
+	for _, kind := range []NodeKind{NodeFile, NodeTopic, NodeGlob} {
+		key := ref
+		if kind == NodeFile {
+			key = cleanGraphPath(ref)
+		}
+		if _, ok := g.Nodes[NodeID(kind, key)]; ok {
+			return NodeID(kind, key), nil
+		}
+	}
+
+	var matches []string
+	for id, n := range g.Nodes {
+		switch n.Kind {
+		case NodeLesson, NodeNote, NodeRule:
+			if n.Key == ref {
+				return id, nil
+			}
+			if strings.HasPrefix(n.Key, ref) {
+				matches = append(matches, id)
+			}
+		}
+	}
+	if len(matches) == 1 {
+		return matches[0], nil
+	}
+	if len(matches) > 1 {
+		sort.Strings(matches)
+		return "", fmt.Errorf("ambiguous reference %q matches %s", ref, strings.Join(matches, ", "))
+	}
+	return "", fmt.Errorf("no topic, record, or path in the knowledge graph matches %q", ref)
+}
+
+// Neighborhood returns the subgraph of nodes within hops of start, with each
+// node's distance from start. A negative hops value returns the connected
+// component.
+func (g *Graph) Neighborhood(start string, hops int) *Graph {
+	dist := map[string]int{start: 0}
+	queue := []string{start}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if hops >= 0 && dist[id] >= hops {
+			continue
+		}
+		for _, i := range g.adj[id] {
+			e := g.Edges[i]
+			next := e.To
+			if next == id {
+				next = e.From
+			}
+			if _, seen := dist[next]; !seen {
+				dist[next] = dist[id] + 1
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	sub := &Graph{
+		Nodes: make(map[string]*GraphNode),
+		adj:   make(map[string][]int),
+		edges: make(map[GraphEdge]bool),
+	}
+	for id, d := range dist {
+		n := *g.Nodes[id]
+		d := d
+		n.Distance = &d
+		sub.Nodes[id] = &n
+	}
+	for _, e := range g.Edges {
+		if sub.Nodes[e.From] != nil && sub.Nodes[e.To] != nil {
+			sub.addEdge(e.From, e.To, e.Kind)
+		}
+	}
+	return sub
+}
+
+// Response returns the graph with nodes and edges in a stable order.
+func (g *Graph) Response() *GraphResponse {
+	resp := &GraphResponse{Nodes: []GraphNode{}, Edges: []GraphEdge{}}
+	for _, id := range g.sortedNodeIDs() {
+		resp.Nodes = append(resp.Nodes, *g.Nodes[id])
+	}
+	resp.Edges = append(resp.Edges, g.Edges...)
+	sort.Slice(resp.Edges, func(i, j int) bool {
+		a, b := resp.Edges[i], resp.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.To != b.To {
+			return a.To < b.To
+		}
+		return a.Kind < b.Kind
+	})
+	return resp
+}
+
+// WriteDOT writes the graph in Graphviz DOT format.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	resp := g.Response()
+	var b strings.Builder
+	b.WriteString("digraph knowledge {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [fontname=\"Helvetica\", fontsize=10];\n")
+	for _, n := range resp.Nodes {
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", dotQuote(n.ID), dotQuote(nodeLabel(n)), dotShape(n.Kind))
+	}
+	for _, e := range resp.Edges {
+		fmt.Fprintf(&b, "  %s -> %s [label=%s];\n", dotQuote(e.From), dotQuote(e.To), dotQuote(string(e.Kind)))
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart.
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	resp := g.Response()
+	ids := make(map[string]string, len(resp.Nodes))
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, n := range resp.Nodes {
+		ids[n.ID] = fmt.Sprintf("n%d", i)
+		open, close := mermaidShape(n.Kind)
+		fmt.Fprintf(&b, "  %s%s\"%s\"%s\n", ids[n.ID], open, mermaidEscape(nodeLabel(n)), close)
+	}
+	for _, e := range resp.Edges {
+		fmt.Fprintf(&b, "  %s -->|%s| %s\n", ids[e.From], e.Kind, ids[e.To])
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// CountByKind returns the number of nodes of each kind.
+func (g *Graph) CountByKind() map[NodeKind]int {
+	counts := make(map[NodeKind]int)
+	for _, n := range g.Nodes {
+		counts[n.Kind]++
+	}
+	return counts
+}
+
+func (g *Graph) addNode(kind NodeKind, key, label string) string {
+	id := NodeID(kind, key)
+	if n, ok := g.Nodes[id]; ok {
+		if n.Label == "" {
+			n.Label = label
+		}
+		return id
+	}
+	g.Nodes[id] = &GraphNode{ID: id, Kind: kind, Key: key, Label: label}
+	return id
+}
+
+func (g *Graph) addEdge(from, to string, kind EdgeKind) {
+	e := GraphEdge{From: from, To: to, Kind: kind}
+	if g.edges[e] {
+		return
+	}
+	g.edges[e] = true
+	g.Edges = append(g.Edges, e)
+	g.adj[from] = append(g.adj[from], len(g.Edges)-1)
+	if to != from {
+		g.adj[to] = append(g.adj[to], len(g.Edges)-1)
+	}
+}
+
+func (g *Graph) sortedNodeIDs() []string {
+	ids := make([]string, 0, len(g.Nodes))
+	for id := range g.Nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func recordKind(t DocumentType) NodeKind {
+	switch t {
+	case TypeLesson:
+		return NodeLesson
+	case TypeNote:
+		return NodeNote
+	default:
+		return NodeRule
+	}
+}
+
+func cleanGraphPath(p string) string {
+	p = filepath.ToSlash(filepath.Clean(strings.TrimSpace(p)))
+	return strings.TrimPrefix(p, "./")
+}
+
+func nodeLabel(n GraphNode) string {
+	if n.Label == "" {
+		return n.ID
+	}
+	label := n.Label
+	if len(label) > 48 {
+		label = label[:45] + "..."
+	}
+	return n.Key + "\n" + label
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func dotShape(kind NodeKind) string {
+	switch kind {
+	case NodeTopic:
+		return "ellipse"
+	case NodeFile:
+		return "note"
+	case NodeGlob:
+		return "parallelogram"
+	default:
+		return "box"
+	}
+}
+
+func mermaidShape(kind NodeKind) (string, string) {
+	switch kind {
+	case NodeTopic:
+		return "((", "))"
+	case NodeFile:
+		return "[/", "/]"
+	case NodeGlob:
+		return "{{", "}}"
+	default:
+		return "[", "]"
+	}
+}
+
+func mermaidEscape(s string) string {
+	s = strings.ReplaceAll(s, `"`, "#quot;")
+	return strings.ReplaceAll(s, "\n", "<br/>")
+}
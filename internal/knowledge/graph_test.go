@@ -0,0 +1,126 @@
+/**
+ * Component: Knowledge Graph Tests
+ * Block-UUID: 4872cf11-d69c-4993-9fc8-616a84b0e7de
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests for knowledge graph construction, reference resolution, hop-limited neighborhoods, and DOT/Mermaid export.
+ * Language: Go
+ * Created-at: 2026-10-18T11:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package knowledge
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func graphFixture() *Graph {
+	docs := []Document{
+		{Type: TypeRule, ID: "rule_importer", Topic: "manifest", Summary: "Validate before import", GlobPatterns: []string{"internal/manifest/**/*.go"}},
+		{Type: TypeLesson, ID: "lsn_schema", Topic: "manifest", RelatedTopics: []string{"db"}, Summary: "Schema drift", Files: []string{"internal/manifest/importer.go"}},
+		{Type: TypeNote, ID: "note_db", Topic: "db", Summary: "WAL mode", LinkedFiles: []string{"./internal/db/schema.go"}},
+	}
+	return NewGraph(docs, []string{"internal/manifest/exporter.go", "README.md"})
+}
+
+func TestNewGraphEdges(t *testing.T) {
+	g := graphFixture()
+
+	want := []GraphEdge{
+		{From: "rule:rule_importer", To: "topic:manifest", Kind: EdgeTopic},
+		{From: "rule:rule_importer", To: "glob:internal/manifest/**/*.go", Kind: EdgeGlob},
+		{From: "glob:internal/manifest/**/*.go", To: "file:internal/manifest/importer.go", Kind: EdgeMatches},
+		{From: "glob:internal/manifest/**/*.go", To: "file:internal/manifest/exporter.go", Kind: EdgeMatches},
+		{From: "lesson:lsn_schema", To: "file:internal/manifest/importer.go", Kind: EdgeAppliesTo},
+		{From: "lesson:lsn_schema", To: "topic:db", Kind: EdgeRelatedTopic},
+		{From: "note:note_db", To: "file:internal/db/schema.go", Kind: EdgeLinkedFile},
+	}
+	for _, e := range want {
+		if !g.edges[e] {
+			t.Errorf("missing edge %+v", e)
+		}
+	}
+	if _, ok := g.Nodes["file:README.md"]; ok {
+		t.Error("unmatched extra file should not become a node")
+	}
+}
+
+func TestGraphResolve(t *testing.T) {
+	g := graphFixture()
+	tests := map[string]string{
+		"internal/manifest/importer.go":   "file:internal/manifest/importer.go",
+		"./internal/manifest/importer.go": "file:internal/manifest/importer.go",
+		"manifest":                        "topic:manifest",
+		"rule_imp":                        "rule:rule_importer",
+		"topic:db":                        "topic:db",
+	}
+	for ref, want := range tests {
+		got, err := g.Resolve(ref)
+		if err != nil || got != want {
+			t.Errorf("Resolve(%q) = (%q, %v), want %q", ref, got, err, want)
+		}
+	}
+	if _, err := g.Resolve("missing.go"); err == nil {
+		t.Error("Resolve(missing.go) should fail")
+	}
+}
+
+func TestGraphNeighborhood(t *testing.T) {
+	g := graphFixture()
+
+	sub := g.Neighborhood("file:internal/manifest/importer.go", 2)
+	for id, dist := range map[string]int{
+		"file:internal/manifest/importer.go": 0,
+		"lesson:lsn_schema":                  1,
+		"glob:internal/manifest/**/*.go":     1,
+		"topic:manifest":                     2,
+		"topic:db":                           2,
+		"rule:rule_importer":                 2,
+		"file:internal/manifest/exporter.go": 2,
+	} {
+		n, ok := sub.Nodes[id]
+		if !ok {
+			t.Errorf("node %s missing from 2-hop neighborhood", id)
+			continue
+		}
+		if *n.Distance != dist {
+			t.Errorf("distance(%s) = %d, want %d", id, *n.Distance, dist)
+		}
+	}
+	if _, ok := sub.Nodes["note:note_db"]; ok {
+		t.Error("note_db is 3 hops away and should be excluded")
+	}
+	for _, e := range sub.Edges {
+		if sub.Nodes[e.From] == nil || sub.Nodes[e.To] == nil {
+			t.Errorf("edge %+v references a node outside the subgraph", e)
+		}
+	}
+
+	if all := g.Neighborhood("file:internal/manifest/importer.go", -1); all.Nodes["note:note_db"] == nil {
+		t.Error("unlimited neighborhood should reach note_db")
+	}
+}
+
+func TestGraphExport(t *testing.T) {
+	g := graphFixture().Neighborhood("topic:db", 1)
+
+	var dot bytes.Buffer
+	if err := g.WriteDOT(&dot); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(dot.String(), "digraph knowledge {") ||
+		!strings.Contains(dot.String(), `"note:note_db" -> "topic:db" [label="topic"];`) {
+		t.Errorf("unexpected DOT output:\n%s", dot.String())
+	}
+
+	var mermaid bytes.Buffer
+	if err := g.WriteMermaid(&mermaid); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(mermaid.String(), "flowchart LR\n") || !strings.Contains(mermaid.String(), "-->|topic|") {
+		t.Errorf("unexpected Mermaid output:\n%s", mermaid.String())
+	}
+}
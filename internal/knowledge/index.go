@@ -1,18 +1,20 @@
 /**
  * Component: Knowledge Index Builder
- * Block-UUID: a1b2c3d4-e5f6-7890-abcd-200000000002
- * Parent-UUID: N/A
- * Version: 1.1.0
- * Description: Added UpdatedAt population for sorting by last modified date.
+ * Block-UUID: 2704aa54-8a4d-428f-bc4f-b01f7f27bd95
+ * Parent-UUID: 30530ebf-8afa-4527-9237-d46c3970c738
+ * Version: 1.3.0
+ * Description: Populates Document.LinkedFiles from lesson, note, and rule records.
  * Language: Go
  * Created-at: 2026-06-22T10:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0), agent (v1.2.0), agent (v1.3.0)
  */
 
 
 package knowledge
 
 import (
+	"time"
+
 	lessonspkg "github.com/gitsense/gsc-cli/internal/lessons"
 	notespkg "github.com/gitsense/gsc-cli/internal/notes"
 	rulespkg "github.com/gitsense/gsc-cli/internal/rules"
@@ -39,6 +41,7 @@ func BuildIndex(opts IndexOptions) ([]Document, error) {
 				Body:          l.Details,
 				Importance:    l.Importance,
 				Files:         l.AppliesTo.Files,
+				LinkedFiles:   l.AppliesTo.LinkedFiles,
 				UpdatedAt:     l.UpdatedAt,
 			})
 		}
@@ -50,6 +53,9 @@ func BuildIndex(opts IndexOptions) ([]Document, error) {
 		if err != nil {
 			return nil, err
 		}
+		if !opts.IncludeArchivedNotes {
+			notes = notespkg.FilterLive(notes, time.Now().UTC())
+		}
 		for _, n := range notes {
 			docs = append(docs, Document{
 				Type:          TypeNote,
@@ -60,6 +66,7 @@ func BuildIndex(opts IndexOptions) ([]Document, error) {
 				Summary:       n.Summary,
 				Body:          n.Content,
 				Importance:    n.Importance,
+				LinkedFiles:   n.LinkedFiles,
 				GlobPatterns:  n.GlobPatterns,
 				UpdatedAt:     n.UpdatedAt,
 			})
@@ -83,6 +90,7 @@ func BuildIndex(opts IndexOptions) ([]Document, error) {
 				Body:          r.Details,
 				Importance:    r.Importance,
 				Files:         r.AppliesTo.Files,
+				LinkedFiles:   r.AppliesTo.LinkedFiles,
 				GlobPatterns:  r.GlobPatterns,
 				UpdatedAt:     r.UpdatedAt,
 			})
@@ -97,6 +105,9 @@ type IndexOptions struct {
 	IncludeLessons bool
 	IncludeNotes   bool
 	IncludeRules   bool
+
+	// IncludeArchivedNotes keeps expired and archived notes in the index.
+	IncludeArchivedNotes bool
 }
 
 // DefaultIndexOptions includes all entity types.
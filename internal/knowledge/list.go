@@ -1,12 +1,12 @@
 /**
  * Component: Knowledge List
- * Block-UUID: a1b2c3d4-e5f6-7890-abcd-200000000004
- * Parent-UUID: N/A
- * Version: 1.1.0
- * Description: Added sorting by updated, importance, or type with --sort and --asc flags.
+ * Block-UUID: 401767e2-fb75-407c-8331-abf4ce641da5
+ * Parent-UUID: a1b2c3d4-e5f6-7890-abcd-200000000004
+ * Version: 1.2.0
+ * Description: Excludes expired and archived notes unless IncludeArchived is set.
  * Language: Go
  * Created-at: 2026-06-22T10:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), MiMo-v2.5-pro (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -50,12 +50,15 @@ type ListOptions struct {
 	Fields []string  // Fields to include in output
 	Sort   SortField // Sort field (updated, importance, type)
 	Asc    bool      // Sort ascending (default: descending)
+
+	IncludeArchived bool // Include expired and archived notes
 }
 
 // List returns knowledge items for a specific topic.
 func List(opts ListOptions) (*ListResponse, error) {
 	// Build index
 	indexOpts := IndexOptionsFromTypes(opts.Types)
+	indexOpts.IncludeArchivedNotes = opts.IncludeArchived
 	docs, err := BuildIndex(indexOpts)
 	if err != nil {
 		return nil, err
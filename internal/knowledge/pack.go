@@ -0,0 +1,493 @@
+/**
+ * Component: Knowledge Pack Model and Export
+ * Block-UUID: 51119ebb-c465-4421-bc4b-f242fc51637a
+ * Parent-UUID: 52c89fb9-7ec5-40a7-967d-00c3d9fcf0aa
+ * Version: 1.2.0
+ * Description: Topic-scoped exports include only the fixtures referenced by the exported rules.
+ * Language: Go
+ * Created-at: 2026-10-18T09:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0)
+ */
+
+package knowledge
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/git"
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	lessonspkg "github.com/gitsense/gsc-cli/internal/lessons"
+	notespkg "github.com/gitsense/gsc-cli/internal/notes"
+	rulespkg "github.com/gitsense/gsc-cli/internal/rules"
+	topicspkg "github.com/gitsense/gsc-cli/internal/topics"
+)
+
+// PackFormatVersion is the archive layout version written into pack.json.
+// Importers reject packs with a newer major version.
+const PackFormatVersion = "1"
+
+// Archive entry names. Trigger and fixture files keep the same relative layout
+// they have under .gitsense/rules/ so entries stay valid after import.
+const (
+	packManifestEntry = "pack.json"
+	packTopicsEntry   = "topics.jsonl"
+	packLessonsEntry  = "lessons.jsonl"
+	packNotesEntry    = "notes.jsonl"
+	packRulesEntry    = "rules.jsonl"
+	packTriggersDir   = "rules/triggers/"
+	packFixturesDir   = "rules/fixtures/"
+)
+
+// PackManifest describes the contents of a knowledge pack.
+type PackManifest struct {
+	FormatVersion string         `json:"format_version"`
+	CreatedAt     time.Time      `json:"created_at"`
+	SourceRepo    string         `json:"source_repo,omitempty"`
+	Topics        []string       `json:"topics"`
+	Types         []string       `json:"types"`
+	Counts        map[string]int `json:"counts"`
+}
+
+// Pack is the in-memory form of a knowledge pack archive.
+type Pack struct {
+	Manifest PackManifest
+	Topics   []topicspkg.Topic
+	Lessons  []lessonspkg.Record
+	Notes    []notespkg.Note
+	Rules    []rulespkg.Rule
+	Triggers map[string][]byte // entry path relative to rules/triggers/ -> contents
+	Fixtures map[string][]byte // path relative to rules/fixtures/ -> contents
+}
+
+// PackExportOptions controls which knowledge is written into a pack.
+type PackExportOptions struct {
+	Topics []string            // Topics to include (primary or related); empty means all
+	Types  []string            // Entity types: lessons, notes, rules; empty means all
+	Scope  gitsensescope.Scope // Read scope for records
+}
+
+// BuildPack collects the records matching opts into a Pack.
+func BuildPack(opts PackExportOptions) (*Pack, error) {
+	scope := opts.Scope
+	if scope == "" {
+		scope = gitsensescope.ScopeAll
+	}
+	indexOpts := IndexOptionsFromTypes(opts.Types)
+
+	pack := &Pack{
+		Triggers: map[string][]byte{},
+		Fixtures: map[string][]byte{},
+	}
+	usedTopics := map[string]bool{}
+	markTopics := func(topic string, related []string) {
+		if topic != "" {
+			usedTopics[topic] = true
+		}
+		for _, rt := range related {
+			usedTopics[rt] = true
+		}
+	}
+
+	if indexOpts.IncludeLessons {
+		sourced, err := lessonspkg.LoadRecordsFromScope(scope)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range lessonspkg.UnwrapSourcedLessons(sourced) {
+			if !matchesPackTopics(l.Topic, l.RelatedTopics, opts.Topics) {
+				continue
+			}
+			pack.Lessons = append(pack.Lessons, l)
+			markTopics(l.Topic, l.RelatedTopics)
+		}
+	}
+
+	if indexOpts.IncludeNotes {
+		sourced, err := notespkg.LoadRecordsFromScope(scope)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range notespkg.UnwrapSourcedNotes(sourced) {
+			if !matchesPackTopics(n.Topic, n.RelatedTopics, opts.Topics) {
+				continue
+			}
+			pack.Notes = append(pack.Notes, n)
+			markTopics(n.Topic, n.RelatedTopics)
+		}
+	}
+
+	if indexOpts.IncludeRules {
+		dirs, err := gitsensescope.GitSenseDirs(scope)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			sourced, err := rulespkg.LoadRecordsFromSourcedDir(dir)
+			if err != nil {
+				return nil, err
+			}
+			var exported []rulespkg.Rule
+			for _, s := range sourced {
+				r := s.Rule
+				if !matchesPackTopics(r.Topic, r.RelatedTopics, opts.Topics) {
+					continue
+				}
+				pack.Rules = append(pack.Rules, r)
+				exported = append(exported, r)
+				markTopics(r.Topic, r.RelatedTopics)
+				if r.Trigger != nil && r.Trigger.Entry != "" && !filepath.IsAbs(r.Trigger.Entry) {
+					data, err := os.ReadFile(filepath.Join(gitsensescope.RulesTriggersDir(dir), r.Trigger.Entry))
+					if err != nil {
+						return nil, fmt.Errorf("rule %s: failed to read trigger %s: %w", r.ID, r.Trigger.Entry, err)
+					}
+					pack.Triggers[filepath.ToSlash(r.Trigger.Entry)] = data
+				}
+			}
+			// A topic-scoped pack carries only the fixtures its rules use
+			keep := func(string) bool { return true }
+			if len(opts.Topics) > 0 {
+				keep = func(rel string) bool { return fixtureReferenced(rel, exported, pack.Triggers) }
+			}
+			if err := collectFixtures(gitsensescope.RulesFixturesDir(dir), keep, pack.Fixtures); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// The topic registry lives in the repo; exporting personal knowledge from
+	// outside a repo still works, with topics carried by slug only.
+	registry, err := topicspkg.LoadRegistry()
+	if err != nil {
+		registry = topicspkg.NewRegistry(nil)
+	}
+	var slugs []string
+	for slug := range usedTopics {
+		slugs = append(slugs, slug)
+	}
+	sort.Strings(slugs)
+	for _, slug := range slugs {
+		if t := registry.Get(slug); t != nil {
+			pack.Topics = append(pack.Topics, *t)
+		} else {
+			pack.Topics = append(pack.Topics, topicspkg.Topic{Slug: slug})
+		}
+	}
+
+	var types []string
+	if indexOpts.IncludeLessons {
+		types = append(types, "lessons")
+	}
+	if indexOpts.IncludeNotes {
+		types = append(types, "notes")
+	}
+	if indexOpts.IncludeRules {
+		types = append(types, "rules")
+	}
+
+	pack.Manifest = PackManifest{
+		FormatVersion: PackFormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		SourceRepo:    packRepoName(),
+		Topics:        slugs,
+		Types:         types,
+		Counts: map[string]int{
+			"topics":   len(pack.Topics),
+			"lessons":  len(pack.Lessons),
+			"notes":    len(pack.Notes),
+			"rules":    len(pack.Rules),
+			"triggers": len(pack.Triggers),
+			"fixtures": len(pack.Fixtures),
+		},
+	}
+	return pack, nil
+}
+
+// matchesPackTopics reports whether a record's primary or related topics
+// intersect the requested topics. An empty filter matches everything.
+func matchesPackTopics(topic string, related []string, filter []string) bool {
+	if len(filter) == 0 {
+		return true
+	}
+	for _, f := range filter {
+		if strings.EqualFold(topic, f) {
+			return true
+		}
+		for _, rt := range related {
+			if strings.EqualFold(rt, f) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// fixtureReferenced reports whether one of the rules uses a fixture: its
+// trigger source mentions the fixture's path or file name, or the fixture's
+// top directory or file stem is the rule ID or the trigger's name
+// (fixtures/check-env/basic.json or fixtures/check-env.json for
+// triggers/check-env.sh).
+func fixtureReferenced(rel string, rules []rulespkg.Rule, triggers map[string][]byte) bool {
+	base := path.Base(rel)
+	stem := strings.TrimSuffix(base, path.Ext(base))
+	top, _, nested := strings.Cut(rel, "/")
+	for _, r := range rules {
+		names := []string{r.ID}
+		if r.Trigger != nil && r.Trigger.Entry != "" {
+			entry := filepath.ToSlash(r.Trigger.Entry)
+			names = append(names, strings.TrimSuffix(path.Base(entry), path.Ext(entry)))
+			if source := string(triggers[entry]); source != "" && (strings.Contains(source, rel) || strings.Contains(source, base)) {
+				return true
+			}
+		}
+		for _, name := range names {
+			if name == stem || (nested && name == top) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// collectFixtures reads every regular file under dir that keep accepts into
+// out, keyed by its slash-separated path relative to dir. A missing directory
+// is not an error.
+func collectFixtures(dir string, keep func(rel string) bool, out map[string][]byte) error {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if !keep(rel) {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		out[rel] = data
+		return nil
+	})
+}
+
+// WritePack writes a pack as a gzip-compressed tar archive.
+func WritePack(pack *Pack, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifestData, err := json.MarshalIndent(pack.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeTarEntry(tw, packManifestEntry, append(manifestData, '\n')); err != nil {
+		return err
+	}
+	if err := writeJSONLEntry(tw, packTopicsEntry, pack.Topics); err != nil {
+		return err
+	}
+	if err := writeJSONLEntry(tw, packLessonsEntry, pack.Lessons); err != nil {
+		return err
+	}
+	if err := writeJSONLEntry(tw, packNotesEntry, pack.Notes); err != nil {
+		return err
+	}
+	if err := writeJSONLEntry(tw, packRulesEntry, pack.Rules); err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(pack.Triggers) {
+		if err := writeTarEntry(tw, packTriggersDir+name, pack.Triggers[name]); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(pack.Fixtures) {
+		if err := writeTarEntry(tw, packFixturesDir+name, pack.Fixtures[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// WritePackFile writes a pack to path, creating parent directories as needed.
+func WritePackFile(pack *Pack, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WritePack(pack, file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// ReadPack parses a knowledge pack archive.
+func ReadPack(r io.Reader) (*Pack, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a knowledge pack archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	pack := &Pack{
+		Triggers: map[string][]byte{},
+		Fixtures: map[string][]byte{},
+	}
+	sawManifest := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if strings.HasPrefix(name, "../") || path.IsAbs(name) {
+			return nil, fmt.Errorf("invalid archive entry %q", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case name == packManifestEntry:
+			if err := json.Unmarshal(data, &pack.Manifest); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", packManifestEntry, err)
+			}
+			sawManifest = true
+		case name == packTopicsEntry:
+			if err := readJSONLEntry(data, &pack.Topics); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+		case name == packLessonsEntry:
+			records, err := lessonspkg.LoadRecordsFromReader(strings.NewReader(string(data)))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			pack.Lessons = records
+		case name == packNotesEntry:
+			records, err := notespkg.LoadRecordsFromReader(strings.NewReader(string(data)))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			pack.Notes = records
+		case name == packRulesEntry:
+			records, err := rulespkg.LoadRecordsFromReader(strings.NewReader(string(data)))
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			pack.Rules = records
+		case strings.HasPrefix(name, packTriggersDir):
+			pack.Triggers[strings.TrimPrefix(name, packTriggersDir)] = data
+		case strings.HasPrefix(name, packFixturesDir):
+			pack.Fixtures[strings.TrimPrefix(name, packFixturesDir)] = data
+		}
+	}
+
+	if !sawManifest {
+		return nil, fmt.Errorf("not a knowledge pack archive: missing %s", packManifestEntry)
+	}
+	if major := strings.SplitN(pack.Manifest.FormatVersion, ".", 2)[0]; major != PackFormatVersion {
+		return nil, fmt.Errorf("unsupported knowledge pack format version %q (this gsc supports %s)", pack.Manifest.FormatVersion, PackFormatVersion)
+	}
+	return pack, nil
+}
+
+// ReadPackFile opens and parses a knowledge pack archive from disk.
+func ReadPackFile(path string) (*Pack, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadPack(file)
+}
+
+func writeTarEntry(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now().UTC(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+func writeJSONLEntry[T any](tw *tar.Writer, name string, items []T) error {
+	var b strings.Builder
+	for _, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		b.Write(data)
+		b.WriteByte('\n')
+	}
+	return writeTarEntry(tw, name, []byte(b.String()))
+}
+
+func readJSONLEntry[T any](data []byte, out *[]T) error {
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var item T
+		if err := json.Unmarshal([]byte(line), &item); err != nil {
+			return err
+		}
+		*out = append(*out, item)
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func packRepoName() string {
+	root, err := git.FindProjectRoot()
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(root)
+}
@@ -0,0 +1,508 @@
+/**
+ * Component: Knowledge Pack Import
+ * Block-UUID: 3494d5ce-d342-4a4c-a916-b645b9b3ab90
+ * Parent-UUID: N/A
+ * Version: 1.2.0
+ * Description: Imports a knowledge pack into a write target, remapping IDs, skipping duplicates by summary or content hash, registering missing topics, rewriting path globs through a mapping file, and rebuilding the affected Brains. Conflicting trigger files are renamed to a name that is not taken, and references between imported notes and lessons follow the new IDs.
+ * Language: Go
+ * Created-at: 2026-10-18T09:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0)
+ */
+
+package knowledge
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	lessonspkg "github.com/gitsense/gsc-cli/internal/lessons"
+	notespkg "github.com/gitsense/gsc-cli/internal/notes"
+	rulespkg "github.com/gitsense/gsc-cli/internal/rules"
+	topicspkg "github.com/gitsense/gsc-cli/internal/topics"
+)
+
+// PathMapping rewrites repository paths and globs from the source repository
+// layout to the destination layout. Mappings are prefix replacements; the
+// longest matching prefix wins.
+type PathMapping struct {
+	Mappings []PathMappingEntry `json:"mappings"`
+}
+
+// PathMappingEntry maps one path prefix to another.
+type PathMappingEntry struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// LoadPathMapping reads a JSON path mapping file.
+func LoadPathMapping(path string) (*PathMapping, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var m PathMapping
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid path mapping file %s: %w", path, err)
+	}
+	for i, entry := range m.Mappings {
+		if strings.TrimSpace(entry.From) == "" {
+			return nil, fmt.Errorf("invalid path mapping file %s: mapping %d has an empty \"from\"", path, i+1)
+		}
+	}
+	sort.SliceStable(m.Mappings, func(i, j int) bool {
+		return len(m.Mappings[i].From) > len(m.Mappings[j].From)
+	})
+	return &m, nil
+}
+
+// Rewrite applies the first (longest) matching prefix mapping to p.
+func (m *PathMapping) Rewrite(p string) string {
+	if m == nil {
+		return p
+	}
+	for _, entry := range m.Mappings {
+		if strings.HasPrefix(p, entry.From) {
+			return entry.To + strings.TrimPrefix(p, entry.From)
+		}
+	}
+	return p
+}
+
+// RewriteAll applies Rewrite to every value in paths.
+func (m *PathMapping) RewriteAll(paths []string) []string {
+	if m == nil || len(paths) == 0 {
+		return paths
+	}
+	out := make([]string, len(paths))
+	for i, p := range paths {
+		out[i] = m.Rewrite(p)
+	}
+	return out
+}
+
+// PackImportOptions controls how a pack is imported.
+type PackImportOptions struct {
+	Target      gitsensescope.Target
+	PathMapping *PathMapping
+	DryRun      bool // Report what would be imported without writing
+}
+
+// PackDuplicate records a pack item that was skipped because it already exists.
+type PackDuplicate struct {
+	Type       DocumentType `json:"type"`
+	PackID     string       `json:"pack_id"`
+	ExistingID string       `json:"existing_id"`
+	Summary    string       `json:"summary"`
+	MatchedBy  string       `json:"matched_by"` // "summary" or "hash"
+}
+
+// PackImportReport summarizes the outcome of an import.
+type PackImportReport struct {
+	Target           gitsensescope.Target `json:"target"`
+	DryRun           bool                 `json:"dry_run"`
+	Imported         map[string]int       `json:"imported"`
+	Duplicates       []PackDuplicate      `json:"duplicates"`
+	IDMap            map[string]string    `json:"id_map"`
+	TopicsRegistered []string             `json:"topics_registered"`
+	TriggersWritten  []string             `json:"triggers_written"`
+	FixturesWritten  []string             `json:"fixtures_written"`
+	Rebuilt          []string             `json:"rebuilt"`
+	Warnings         []string             `json:"warnings,omitempty"`
+}
+
+// ImportPack merges pack into the target store.
+func ImportPack(pack *Pack, opts PackImportOptions) (*PackImportReport, error) {
+	if opts.Target == "" {
+		return nil, fmt.Errorf("write target is required: must be one of repo, personal")
+	}
+	now := time.Now().UTC()
+	report := &PackImportReport{
+		Target:   opts.Target,
+		DryRun:   opts.DryRun,
+		Imported: map[string]int{"lessons": 0, "notes": 0, "rules": 0},
+		IDMap:    map[string]string{},
+	}
+	mapping := opts.PathMapping
+
+	if err := registerPackTopics(pack, opts, report); err != nil {
+		return nil, err
+	}
+
+	// Lessons and notes refer to each other, so both get their IDs before
+	// either is written. refs maps every pack ID to the ID it has in the
+	// target: re-issued, or the existing record it duplicates.
+	refs := map[string]string{}
+
+	// Lessons
+	var existingLessons, addedLessons []lessonspkg.Record
+	if len(pack.Lessons) > 0 {
+		existing, err := lessonspkg.LoadRecordsFromTarget(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		existingLessons = existing
+		seen := newDuplicateIndex()
+		for _, l := range existing {
+			seen.add(l.ID, l.Summary, l.Details)
+		}
+		for _, l := range pack.Lessons {
+			if id, by := seen.match(l.Summary, l.Details); id != "" {
+				report.Duplicates = append(report.Duplicates, PackDuplicate{Type: TypeLesson, PackID: l.ID, ExistingID: id, Summary: l.Summary, MatchedBy: by})
+				refs[l.ID] = id
+				continue
+			}
+			newID, err := lessonspkg.NewLessonID(now)
+			if err != nil {
+				return nil, err
+			}
+			report.IDMap[l.ID] = newID
+			refs[l.ID] = newID
+			l.ID = newID
+			l.AppliesTo.Files = mapping.RewriteAll(l.AppliesTo.Files)
+			l.AppliesTo.LinkedFiles = mapping.RewriteAll(l.AppliesTo.LinkedFiles)
+			seen.add(l.ID, l.Summary, l.Details)
+			addedLessons = append(addedLessons, l)
+		}
+		report.Imported["lessons"] = len(addedLessons)
+	}
+
+	// Notes
+	var existingNotes, addedNotes []notespkg.Note
+	if len(pack.Notes) > 0 {
+		existing, err := notespkg.LoadRecordsFromTarget(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		existingNotes = existing
+		seen := newDuplicateIndex()
+		for _, n := range existing {
+			seen.add(n.ID, n.Summary, n.Content)
+		}
+		for _, n := range pack.Notes {
+			if id, by := seen.match(n.Summary, n.Content); id != "" {
+				report.Duplicates = append(report.Duplicates, PackDuplicate{Type: TypeNote, PackID: n.ID, ExistingID: id, Summary: n.Summary, MatchedBy: by})
+				refs[n.ID] = id
+				continue
+			}
+			newID, err := notespkg.NewNoteID(now)
+			if err != nil {
+				return nil, err
+			}
+			report.IDMap[n.ID] = newID
+			refs[n.ID] = newID
+			n.ID = newID
+			n.GlobPatterns = mapping.RewriteAll(n.GlobPatterns)
+			n.LinkedFiles = mapping.RewriteAll(n.LinkedFiles)
+			seen.add(n.ID, n.Summary, n.Content)
+			addedNotes = append(addedNotes, n)
+		}
+		report.Imported["notes"] = len(addedNotes)
+	}
+
+	for i := range addedLessons {
+		if from := addedLessons[i].PromotedFrom; from != nil {
+			promoted := *from
+			if rewriteRef(&promoted.ID, refs, addedLessons[i].ID, "promoted_from", report) {
+				promoted.Source = string(opts.Target)
+			}
+			addedLessons[i].PromotedFrom = &promoted
+		}
+	}
+	for i := range addedNotes {
+		rewriteRef(&addedNotes[i].Supersedes, refs, addedNotes[i].ID, "supersedes", report)
+		rewriteRef(&addedNotes[i].PromotedTo, refs, addedNotes[i].ID, "promoted_to", report)
+	}
+	if len(addedLessons) > 0 && !opts.DryRun {
+		if err := lessonspkg.WriteRecordsToTarget(append(existingLessons, addedLessons...), opts.Target); err != nil {
+			return nil, err
+		}
+	}
+	if len(addedNotes) > 0 && !opts.DryRun {
+		if err := notespkg.WriteRecordsToTarget(append(existingNotes, addedNotes...), opts.Target); err != nil {
+			return nil, err
+		}
+	}
+
+	// Rules
+	if len(pack.Rules) > 0 {
+		existing, err := rulespkg.LoadRecordsFromTarget(opts.Target)
+		if err != nil {
+			return nil, err
+		}
+		seen := newDuplicateIndex()
+		for _, r := range existing {
+			seen.add(r.ID, r.Summary, ruleBody(r))
+		}
+		var added []rulespkg.Rule
+		for _, r := range pack.Rules {
+			if id, by := seen.match(r.Summary, ruleBody(r)); id != "" {
+				report.Duplicates = append(report.Duplicates, PackDuplicate{Type: TypeRule, PackID: r.ID, ExistingID: id, Summary: r.Summary, MatchedBy: by})
+				continue
+			}
+			newID, err := rulespkg.NewRuleID(now)
+			if err != nil {
+				return nil, err
+			}
+			oldID := r.ID
+			report.IDMap[oldID] = newID
+			r.ID = newID
+			r.GlobPatterns = mapping.RewriteAll(r.GlobPatterns)
+			r.ExcludeGlobs = mapping.RewriteAll(r.ExcludeGlobs)
+			r.AppliesTo.Files = mapping.RewriteAll(r.AppliesTo.Files)
+			r.AppliesTo.LinkedFiles = mapping.RewriteAll(r.AppliesTo.LinkedFiles)
+			if r.Trigger != nil && r.Trigger.Entry != "" {
+				entry, err := importTrigger(pack, r, opts, report)
+				if err != nil {
+					return nil, err
+				}
+				r.Trigger.Entry = entry
+			}
+			message := fmt.Sprintf("Imported from knowledge pack (original id %s)", oldID)
+			if pack.Manifest.SourceRepo != "" {
+				message = fmt.Sprintf("Imported from knowledge pack of %s (original id %s)", pack.Manifest.SourceRepo, oldID)
+			}
+			r.Changelog = append(r.Changelog, rulespkg.ChangelogEntry{Timestamp: now, Message: message})
+			seen.add(r.ID, r.Summary, ruleBody(r))
+			added = append(added, r)
+		}
+		report.Imported["rules"] = len(added)
+		if len(added) > 0 && !opts.DryRun {
+			if err := rulespkg.WriteRecordsToTarget(append(existing, added...), opts.Target); err != nil {
+				return nil, err
+			}
+		}
+		if report.Imported["rules"] > 0 {
+			if err := importFixtures(pack, opts, report); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if opts.DryRun {
+		return report, nil
+	}
+
+	// Rebuild Brains for every kind that received records.
+	if report.Imported["lessons"] > 0 {
+		if err := lessonspkg.RebuildAndImportForTarget(opts.Target); err != nil {
+			return report, fmt.Errorf("failed to rebuild gsc-lessons: %w", err)
+		}
+		report.Rebuilt = append(report.Rebuilt, lessonspkg.DatabaseName)
+	}
+	if report.Imported["notes"] > 0 {
+		if err := notespkg.RebuildAndImportForTarget(opts.Target); err != nil {
+			return report, fmt.Errorf("failed to rebuild gsc-notes: %w", err)
+		}
+		report.Rebuilt = append(report.Rebuilt, notespkg.DatabaseName)
+	}
+	if report.Imported["rules"] > 0 {
+		if err := rulespkg.RebuildAndImportForTarget(opts.Target); err != nil {
+			return report, fmt.Errorf("failed to rebuild gsc-rules: %w", err)
+		}
+		report.Rebuilt = append(report.Rebuilt, rulespkg.DatabaseName)
+	}
+	return report, nil
+}
+
+// rewriteRef points a reference to another pack record at that record's ID in
+// the target. A reference to a record outside the pack is kept and reported.
+// It reports whether the reference was rewritten.
+func rewriteRef(ref *string, refs map[string]string, owner, field string, report *PackImportReport) bool {
+	if *ref == "" {
+		return false
+	}
+	if id, ok := refs[*ref]; ok {
+		*ref = id
+		return true
+	}
+	report.Warnings = append(report.Warnings, fmt.Sprintf("%s: %s %s is not in the pack; kept as is", owner, field, *ref))
+	return false
+}
+
+// registerPackTopics adds topics referenced by the pack that are missing from
+// the shared registry. The registry is repo-scoped, so outside a repository
+// topics are reported as a warning instead.
+func registerPackTopics(pack *Pack, opts PackImportOptions, report *PackImportReport) error {
+	if len(pack.Topics) == 0 {
+		return nil
+	}
+	registry, err := topicspkg.LoadRegistry()
+	if err != nil {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("topic registry unavailable, topics not registered: %v", err))
+		return nil
+	}
+	existingSlugs := registry.Slugs()
+	now := time.Now().UTC()
+	for _, t := range pack.Topics {
+		if t.Slug == "" || registry.Exists(t.Slug) {
+			continue
+		}
+		description := t.Description
+		if strings.TrimSpace(description) == "" {
+			description = "Imported from knowledge pack"
+			if pack.Manifest.SourceRepo != "" {
+				description += " of " + pack.Manifest.SourceRepo
+			}
+		}
+		topic := topicspkg.Topic{
+			Slug:        topicspkg.Slugify(t.Slug),
+			Description: description,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if errs := topicspkg.ValidateTopic(topic, existingSlugs); len(errs) > 0 {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("topic %q not registered: %s", t.Slug, strings.Join(errs, "; ")))
+			continue
+		}
+		if !opts.DryRun {
+			if err := topicspkg.AppendRecord(topic); err != nil {
+				return fmt.Errorf("failed to register topic %q: %w", topic.Slug, err)
+			}
+		}
+		existingSlugs = append(existingSlugs, topic.Slug)
+		report.TopicsRegistered = append(report.TopicsRegistered, topic.Slug)
+	}
+	return nil
+}
+
+// importTrigger writes the trigger file for an imported rule and returns the
+// entry to store on the rule. An existing file with identical content is
+// reused; a conflicting file keeps its place and the import is renamed with
+// the random tail of the new rule ID, plus a counter if that name is taken too.
+func importTrigger(pack *Pack, r rulespkg.Rule, opts PackImportOptions, report *PackImportReport) (string, error) {
+	entry := filepath.ToSlash(r.Trigger.Entry)
+	data, ok := pack.Triggers[entry]
+	if !ok {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("rule %s: trigger %s missing from pack", r.ID, entry))
+		return r.Trigger.Entry, nil
+	}
+	triggersDir, err := rulespkg.TriggersDirForTarget(opts.Target)
+	if err != nil {
+		return "", err
+	}
+	dest := filepath.Join(triggersDir, filepath.FromSlash(entry))
+	if current, err := os.ReadFile(dest); err == nil {
+		if bytes.Equal(current, data) {
+			return r.Trigger.Entry, nil
+		}
+		ext := filepath.Ext(entry)
+		stem := strings.TrimSuffix(entry, ext) + "-" + r.ID[len(r.ID)-8:]
+		entry = stem + ext
+		for n := 2; triggerTaken(triggersDir, entry, report); n++ {
+			entry = fmt.Sprintf("%s-%d%s", stem, n, ext)
+		}
+		dest = filepath.Join(triggersDir, filepath.FromSlash(entry))
+	}
+	if !opts.DryRun {
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(dest, data, 0755); err != nil {
+			return "", err
+		}
+	}
+	report.TriggersWritten = append(report.TriggersWritten, entry)
+	return entry, nil
+}
+
+// triggerTaken reports whether a trigger entry already exists in the target or
+// was claimed earlier in this import (which a dry run does not write).
+func triggerTaken(triggersDir, entry string, report *PackImportReport) bool {
+	if _, err := os.Lstat(filepath.Join(triggersDir, filepath.FromSlash(entry))); err == nil {
+		return true
+	}
+	for _, written := range report.TriggersWritten {
+		if written == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// importFixtures copies fixture files that do not already exist in the target.
+func importFixtures(pack *Pack, opts PackImportOptions, report *PackImportReport) error {
+	if len(pack.Fixtures) == 0 {
+		return nil
+	}
+	fixturesDir, err := rulespkg.FixturesDirForTarget(opts.Target)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(pack.Fixtures) {
+		dest := filepath.Join(fixturesDir, filepath.FromSlash(name))
+		if current, err := os.ReadFile(dest); err == nil {
+			if !bytes.Equal(current, pack.Fixtures[name]) {
+				report.Warnings = append(report.Warnings, fmt.Sprintf("fixture %s already exists with different content; kept existing file", name))
+			}
+			continue
+		}
+		if !opts.DryRun {
+			if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(dest, pack.Fixtures[name], 0644); err != nil {
+				return err
+			}
+		}
+		report.FixturesWritten = append(report.FixturesWritten, name)
+	}
+	return nil
+}
+
+// ruleBody returns the text used for rule content hashing.
+func ruleBody(r rulespkg.Rule) string {
+	return r.Details + "\n" + strings.Join(r.Instructions, "\n")
+}
+
+// duplicateIndex detects records that already exist, either by normalized
+// summary or by a hash of their normalized body.
+type duplicateIndex struct {
+	bySummary map[string]string
+	byHash    map[string]string
+}
+
+func newDuplicateIndex() *duplicateIndex {
+	return &duplicateIndex{bySummary: map[string]string{}, byHash: map[string]string{}}
+}
+
+func (d *duplicateIndex) add(id, summary, body string) {
+	if key := normalizeForDuplicate(summary); key != "" {
+		d.bySummary[key] = id
+	}
+	if hash := contentHash(body); hash != "" {
+		d.byHash[hash] = id
+	}
+}
+
+func (d *duplicateIndex) match(summary, body string) (string, string) {
+	if id, ok := d.bySummary[normalizeForDuplicate(summary)]; ok {
+		return id, "summary"
+	}
+	if hash := contentHash(body); hash != "" {
+		if id, ok := d.byHash[hash]; ok {
+			return id, "hash"
+		}
+	}
+	return "", ""
+}
+
+func normalizeForDuplicate(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+func contentHash(body string) string {
+	normalized := normalizeForDuplicate(body)
+	if normalized == "" {
+		return ""
+	}
+	h := sha256.Sum256([]byte(normalized))
+	return fmt.Sprintf("sha256:%x", h)
+}
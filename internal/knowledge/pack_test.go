@@ -0,0 +1,314 @@
+/**
+ * Component: Knowledge Pack Tests
+ * Block-UUID: bf883590-6ac9-4cd2-8d7b-ebe19b9d2b38
+ * Parent-UUID: N/A
+ * Version: 1.1.0
+ * Description: Tests for knowledge pack archive round-trips, path mapping, duplicate detection, dry-run import, fixture selection for topic-scoped exports, renaming of conflicting triggers, and rewriting of note and lesson cross-references on import.
+ * Language: Go
+ * Created-at: 2026-10-18T09:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package knowledge
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+	lessonspkg "github.com/gitsense/gsc-cli/internal/lessons"
+	"github.com/gitsense/gsc-cli/internal/manifest"
+	notespkg "github.com/gitsense/gsc-cli/internal/notes"
+	rulespkg "github.com/gitsense/gsc-cli/internal/rules"
+	topicspkg "github.com/gitsense/gsc-cli/internal/topics"
+)
+
+func TestPackRoundTrip(t *testing.T) {
+	pack := &Pack{
+		Manifest: PackManifest{FormatVersion: PackFormatVersion, Topics: []string{"data-layer"}},
+		Topics:   []topicspkg.Topic{{Slug: "data-layer", Description: "Persistence"}},
+		Lessons:  []lessonspkg.Record{{ID: "lsn_1", Summary: "Use transactions", Topic: "data-layer"}},
+		Rules: []rulespkg.Rule{{
+			ID:      "rule_1",
+			Summary: "Check triggers",
+			Topic:   "data-layer",
+			Trigger: &rulespkg.TriggerConfig{Runtime: "bash", Entry: "check.sh"},
+		}},
+		Triggers: map[string][]byte{"check.sh": []byte("#!/bin/sh\nexit 0\n")},
+		Fixtures: map[string][]byte{"ctx/basic.json": []byte("{}")},
+	}
+
+	var buf bytes.Buffer
+	if err := WritePack(pack, &buf); err != nil {
+		t.Fatalf("WritePack() error: %v", err)
+	}
+	got, err := ReadPack(&buf)
+	if err != nil {
+		t.Fatalf("ReadPack() error: %v", err)
+	}
+	if len(got.Topics) != 1 || got.Topics[0].Description != "Persistence" {
+		t.Errorf("topics = %+v, want data-layer with description", got.Topics)
+	}
+	if len(got.Lessons) != 1 || got.Lessons[0].ID != "lsn_1" {
+		t.Errorf("lessons = %+v, want lsn_1", got.Lessons)
+	}
+	if len(got.Rules) != 1 || got.Rules[0].Trigger == nil || got.Rules[0].Trigger.Entry != "check.sh" {
+		t.Errorf("rules = %+v, want rule_1 with trigger", got.Rules)
+	}
+	if string(got.Triggers["check.sh"]) != "#!/bin/sh\nexit 0\n" {
+		t.Errorf("trigger contents = %q", got.Triggers["check.sh"])
+	}
+	if string(got.Fixtures["ctx/basic.json"]) != "{}" {
+		t.Errorf("fixture contents = %q", got.Fixtures["ctx/basic.json"])
+	}
+}
+
+func TestReadPackRejectsNewerFormat(t *testing.T) {
+	pack := &Pack{Manifest: PackManifest{FormatVersion: "2"}}
+	var buf bytes.Buffer
+	if err := WritePack(pack, &buf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadPack(&buf); err == nil {
+		t.Fatal("expected error for unsupported format version")
+	}
+}
+
+func TestPathMappingLongestPrefixWins(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "map.json")
+	data := `{"mappings": [{"from": "src/", "to": "lib/"}, {"from": "src/api/", "to": "internal/api/"}]}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := LoadPathMapping(path)
+	if err != nil {
+		t.Fatalf("LoadPathMapping() error: %v", err)
+	}
+
+	tests := map[string]string{
+		"src/api/**/*.go": "internal/api/**/*.go",
+		"src/util.go":     "lib/util.go",
+		"docs/README.md":  "docs/README.md",
+	}
+	for in, want := range tests {
+		if got := m.Rewrite(in); got != want {
+			t.Errorf("Rewrite(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFixtureReferenced(t *testing.T) {
+	rules := []rulespkg.Rule{
+		{ID: "rule_1", Trigger: &rulespkg.TriggerConfig{Runtime: "bash", Entry: "check-env.sh"}},
+		{ID: "rule_2"},
+	}
+	triggers := map[string][]byte{"check-env.sh": []byte("#!/bin/sh\ncat ../fixtures/ctx/env.json\n")}
+
+	tests := map[string]bool{
+		"check-env.json":        true,
+		"check-env/basic.json":  true,
+		"rule_2/input.json":     true,
+		"rule_2.json":           true,
+		"ctx/env.json":          true,
+		"other-rule/basic.json": false,
+		"unrelated.json":        false,
+	}
+	for rel, want := range tests {
+		if got := fixtureReferenced(rel, rules, triggers); got != want {
+			t.Errorf("fixtureReferenced(%q) = %v, want %v", rel, got, want)
+		}
+	}
+}
+
+func TestDuplicateIndex(t *testing.T) {
+	idx := newDuplicateIndex()
+	idx.add("lsn_1", "Use  Transactions", "Wrap writes in a transaction.")
+
+	if id, by := idx.match("use transactions", "different body"); id != "lsn_1" || by != "summary" {
+		t.Errorf("summary match = (%q, %q), want (lsn_1, summary)", id, by)
+	}
+	if id, by := idx.match("Another summary", "wrap writes   in a TRANSACTION."); id != "lsn_1" || by != "hash" {
+		t.Errorf("hash match = (%q, %q), want (lsn_1, hash)", id, by)
+	}
+	if id, _ := idx.match("Another summary", ""); id != "" {
+		t.Errorf("empty body should not match, got %q", id)
+	}
+}
+
+func TestImportPackDryRun(t *testing.T) {
+	repoDir := t.TempDir()
+	if out, err := exec.Command("git", "init", repoDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	t.Setenv("GSC_HOME", t.TempDir())
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(repoDir)
+
+	if err := lessonspkg.WriteRecordsToTarget([]lessonspkg.Record{{ID: "lsn_existing", Summary: "Use transactions"}}, gitsensescope.TargetRepo); err != nil {
+		t.Fatal(err)
+	}
+
+	pack := &Pack{
+		Manifest: PackManifest{FormatVersion: PackFormatVersion},
+		Topics:   []topicspkg.Topic{{Slug: "data-layer", Description: "Persistence"}},
+		Lessons: []lessonspkg.Record{
+			{ID: "lsn_dup", Summary: "use transactions", Topic: "data-layer"},
+			{ID: "lsn_new", Summary: "Close rows", Topic: "data-layer", AppliesTo: lessonspkg.AppliesTo{Files: []string{"src/db.go"}}},
+		},
+	}
+	report, err := ImportPack(pack, PackImportOptions{
+		Target:      gitsensescope.TargetRepo,
+		DryRun:      true,
+		PathMapping: &PathMapping{Mappings: []PathMappingEntry{{From: "src/", To: "internal/"}}},
+	})
+	if err != nil {
+		t.Fatalf("ImportPack() error: %v", err)
+	}
+	if report.Imported["lessons"] != 1 {
+		t.Errorf("imported lessons = %d, want 1", report.Imported["lessons"])
+	}
+	if len(report.Duplicates) != 1 || report.Duplicates[0].ExistingID != "lsn_existing" {
+		t.Errorf("duplicates = %+v, want lsn_existing", report.Duplicates)
+	}
+	if report.IDMap["lsn_new"] == "" || report.IDMap["lsn_new"] == "lsn_new" {
+		t.Errorf("lsn_new was not remapped: %v", report.IDMap)
+	}
+	if len(report.TopicsRegistered) != 1 || report.TopicsRegistered[0] != "data-layer" {
+		t.Errorf("topics registered = %v, want [data-layer]", report.TopicsRegistered)
+	}
+
+	// Dry run must not write.
+	records, err := lessonspkg.LoadRecordsFromTarget(gitsensescope.TargetRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Errorf("records after dry run = %d, want 1", len(records))
+	}
+	if registry, _ := topicspkg.LoadRegistry(); registry != nil && registry.Exists("data-layer") {
+		t.Error("dry run registered a topic")
+	}
+}
+
+func TestImportPackRenamesConflictingTriggers(t *testing.T) {
+	repoDir := t.TempDir()
+	if out, err := exec.Command("git", "init", repoDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	t.Setenv("GSC_HOME", t.TempDir())
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(repoDir)
+
+	triggersDir, err := rulespkg.TriggersDirForTarget(gitsensescope.TargetRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(triggersDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(triggersDir, "check.sh"), []byte("#!/bin/sh\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both rules conflict with the existing check.sh and are imported in the
+	// same millisecond, so their IDs share a timestamp prefix.
+	pack := &Pack{
+		Manifest: PackManifest{FormatVersion: PackFormatVersion},
+		Rules: []rulespkg.Rule{
+			{ID: "rule_a", Summary: "First check", Trigger: &rulespkg.TriggerConfig{Runtime: "bash", Entry: "check.sh"}},
+			{ID: "rule_b", Summary: "Second check", Trigger: &rulespkg.TriggerConfig{Runtime: "bash", Entry: "check.sh"}},
+		},
+		Triggers: map[string][]byte{"check.sh": []byte("#!/bin/sh\nexit 0\n")},
+	}
+	report, err := ImportPack(pack, PackImportOptions{Target: gitsensescope.TargetRepo, DryRun: true})
+	if err != nil {
+		t.Fatalf("ImportPack() error: %v", err)
+	}
+	if len(report.TriggersWritten) != 2 {
+		t.Fatalf("triggers written = %v, want 2", report.TriggersWritten)
+	}
+	first, second := report.TriggersWritten[0], report.TriggersWritten[1]
+	if first == second || first == "check.sh" || second == "check.sh" {
+		t.Errorf("triggers written = %v, want two distinct renamed entries", report.TriggersWritten)
+	}
+}
+
+func TestImportPackRewritesCrossReferences(t *testing.T) {
+	repoDir := t.TempDir()
+	if out, err := exec.Command("git", "init", repoDir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	t.Setenv("GSC_HOME", t.TempDir())
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(repoDir)
+
+	if err := manifest.InitializeGitSense(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if err := notespkg.WriteRecordsToTarget([]notespkg.Note{{ID: "note_existing", Summary: "Old cache layout", Content: "Cache lives in /tmp."}}, gitsensescope.TargetRepo); err != nil {
+		t.Fatal(err)
+	}
+
+	src := &Pack{
+		Manifest: PackManifest{FormatVersion: PackFormatVersion},
+		Lessons: []lessonspkg.Record{{
+			ID:           "lsn_src",
+			Summary:      "Cache under the data dir",
+			PromotedFrom: &lessonspkg.PromotedFrom{Type: "note", ID: "note_promoted", Source: "personal"},
+		}},
+		Notes: []notespkg.Note{
+			{ID: "note_dup", Summary: "old cache layout", Content: "Cache lives in /tmp."},
+			{ID: "note_promoted", Summary: "Cache path", Content: "Use the data dir.", PromotedTo: "lsn_src", Supersedes: "note_dup"},
+			{ID: "note_dangling", Summary: "Cache size", Content: "Keep it small.", Supersedes: "note_elsewhere"},
+		},
+	}
+	var buf bytes.Buffer
+	if err := WritePack(src, &buf); err != nil {
+		t.Fatal(err)
+	}
+	pack, err := ReadPack(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ImportPack(pack, PackImportOptions{Target: gitsensescope.TargetRepo})
+	if err != nil {
+		t.Fatalf("ImportPack() error: %v", err)
+	}
+
+	lessons, err := lessonspkg.LoadRecordsFromTarget(gitsensescope.TargetRepo)
+	if err != nil || len(lessons) != 1 {
+		t.Fatalf("lessons = %+v, %v", lessons, err)
+	}
+	notes, err := notespkg.LoadRecordsFromTarget(gitsensescope.TargetRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID := map[string]notespkg.Note{}
+	for _, n := range notes {
+		byID[n.ID] = n
+	}
+
+	promoted, ok := byID[report.IDMap["note_promoted"]]
+	if !ok {
+		t.Fatalf("promoted note not imported: %v", report.IDMap)
+	}
+	if promoted.PromotedTo != lessons[0].ID {
+		t.Errorf("promoted_to = %q, want %q", promoted.PromotedTo, lessons[0].ID)
+	}
+	if promoted.Supersedes != "note_existing" {
+		t.Errorf("supersedes = %q, want the existing duplicate note_existing", promoted.Supersedes)
+	}
+	if from := lessons[0].PromotedFrom; from == nil || from.ID != promoted.ID || from.Source != "repo" {
+		t.Errorf("promoted_from = %+v, want %s in repo", from, promoted.ID)
+	}
+	if dangling := byID[report.IDMap["note_dangling"]]; dangling.Supersedes != "note_elsewhere" || len(report.Warnings) == 0 {
+		t.Errorf("dangling supersedes = %q, warnings = %v", dangling.Supersedes, report.Warnings)
+	}
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Knowledge Search
- * Block-UUID: a1b2c3d4-e5f6-7890-abcd-200000000003
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Implements unified search across lessons, notes, and rules with tokenized matching and relevance ranking.
+ * Block-UUID: f2f19693-5cd1-431e-b0e6-bac10ac97b34
+ * Parent-UUID: a1b2c3d4-e5f6-7890-abcd-200000000003
+ * Version: 1.1.0
+ * Description: Excludes expired and archived notes unless IncludeArchived is set.
  * Language: Go
  * Created-at: 2026-06-22T10:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -22,12 +22,15 @@ type SearchOptions struct {
 	Types []string // Filter by entity types
 	Topic string   // Filter by topic
 	Limit int      // Max results (0 = unlimited)
+
+	IncludeArchived bool // Include expired and archived notes
 }
 
 // Search performs a unified search across all knowledge documents.
 func Search(query string, opts SearchOptions) (*SearchResponse, error) {
 	// Build index
 	indexOpts := IndexOptionsFromTypes(opts.Types)
+	indexOpts.IncludeArchivedNotes = opts.IncludeArchived
 	docs, err := BuildIndex(indexOpts)
 	if err != nil {
 		return nil, err
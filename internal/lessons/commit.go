@@ -1,12 +1,12 @@
 /**
  * Component: Lessons Commit Workflow
- * Block-UUID: a68214db-d160-4afd-a56d-69fe43cc293a
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Orchestrates lesson commit and refresh workflows, including validation, canonical storage, draft archival, manifest rebuild, and Brain import.
+ * Block-UUID: 46db308f-55df-4936-b5df-86e6076e2873
+ * Parent-UUID: a68214db-d160-4afd-a56d-69fe43cc293a
+ * Version: 1.1.0
+ * Description: Carries PromotedFrom provenance from the draft onto the committed record.
  * Language: Go
  * Created-at: 2026-06-12T12:44:13Z
- * Authors: Codex GPT-5 (v1.0.0)
+ * Authors: Codex GPT-5 (v1.0.0), agent (v1.1.0)
  */
 
 package lessons
@@ -63,6 +63,7 @@ func CommitDraftToTarget(confirmedBy string, target gitsensescope.Target) (*Reco
 		AI:             draft.AI,
 		ConfirmedBy:    confirmedBy,
 		ConfirmedAt:    now,
+		PromotedFrom:   draft.PromotedFrom,
 	}
 
 	if err := AppendRecordToTarget(record, target); err != nil {
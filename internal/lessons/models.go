@@ -1,12 +1,12 @@
 /**
  * Component: Lessons Domain Models
- * Block-UUID: dd456c02-7a9e-4092-8cf3-a428e686b660
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Defines lesson draft, committed record, applies-to, AI provenance, and validation result data structures.
+ * Block-UUID: e8d1ae86-cb6d-4442-8e8b-78cf68ceeafa
+ * Parent-UUID: dd456c02-7a9e-4092-8cf3-a428e686b660
+ * Version: 1.1.0
+ * Description: Added PromotedFrom provenance to lesson drafts and records.
  * Language: Go
  * Created-at: 2026-06-12T12:44:13Z
- * Authors: Codex GPT-5 (v1.0.0)
+ * Authors: Codex GPT-5 (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -31,36 +31,46 @@ type AIProvenance struct {
 	Agent    string `json:"agent"`
 }
 
+// PromotedFrom links a lesson to the knowledge item it was promoted from.
+type PromotedFrom struct {
+	Type      string    `json:"type"`   // "note"
+	ID        string    `json:"id"`     // Original item ID
+	Source    string    `json:"source"` // Store the item lives in: repo or personal
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type Draft struct {
-	Summary       string       `json:"summary"`
-	Details       string       `json:"details"`
-	Topic         string       `json:"topic"`
-	RelatedTopics []string     `json:"related_topics"`
-	AppliesTo     AppliesTo    `json:"applies_to"`
-	Tags          []string     `json:"tags"`
-	Importance    string       `json:"importance"`
-	ReviewChecks  []string     `json:"review_checks"`
-	AI            AIProvenance `json:"ai"`
+	Summary       string        `json:"summary"`
+	Details       string        `json:"details"`
+	Topic         string        `json:"topic"`
+	RelatedTopics []string      `json:"related_topics"`
+	AppliesTo     AppliesTo     `json:"applies_to"`
+	Tags          []string      `json:"tags"`
+	Importance    string        `json:"importance"`
+	ReviewChecks  []string      `json:"review_checks"`
+	AI            AIProvenance  `json:"ai"`
+	PromotedFrom  *PromotedFrom `json:"promoted_from,omitempty"`
 }
 
 type Record struct {
-	ID             string       `json:"id"`
-	SchemaVersion  string       `json:"schema_version"`
-	CreatedAt      time.Time    `json:"created_at"`
-	UpdatedAt      time.Time    `json:"updated_at"`
-	Summary        string       `json:"summary"`
-	Details        string       `json:"details"`
-	Topic          string       `json:"topic"`
-	RelatedTopics  []string     `json:"related_topics"`
-	AppliesTo      AppliesTo    `json:"applies_to"`
-	Tags           []string     `json:"tags"`
-	Keywords       []string     `json:"keywords"`
-	ParentKeywords []string     `json:"parent_keywords"`
-	Importance     string       `json:"importance"`
-	ReviewChecks   []string     `json:"review_checks"`
-	AI             AIProvenance `json:"ai"`
-	ConfirmedBy    string       `json:"confirmed_by"`
-	ConfirmedAt    time.Time    `json:"confirmed_at"`
+	ID             string        `json:"id"`
+	SchemaVersion  string        `json:"schema_version"`
+	CreatedAt      time.Time     `json:"created_at"`
+	UpdatedAt      time.Time     `json:"updated_at"`
+	Summary        string        `json:"summary"`
+	Details        string        `json:"details"`
+	Topic          string        `json:"topic"`
+	RelatedTopics  []string      `json:"related_topics"`
+	AppliesTo      AppliesTo     `json:"applies_to"`
+	Tags           []string      `json:"tags"`
+	Keywords       []string      `json:"keywords"`
+	ParentKeywords []string      `json:"parent_keywords"`
+	Importance     string        `json:"importance"`
+	ReviewChecks   []string      `json:"review_checks"`
+	AI             AIProvenance  `json:"ai"`
+	ConfirmedBy    string        `json:"confirmed_by"`
+	ConfirmedAt    time.Time     `json:"confirmed_at"`
+	PromotedFrom   *PromotedFrom `json:"promoted_from,omitempty"`
 }
 
 type ValidationResult struct {
@@ -1,12 +1,12 @@
 /**
  * Component: Lessons Draft Validator
- * Block-UUID: f340ff18-fff4-4b16-82ba-ea2542358756
- * Parent-UUID: N/A
- * Version: 1.2.0
- * Description: Validates lesson draft JSON shape, required fields, exact repo-relative file anchors, slug fields, command safety, and bounded text. Added ValidateDraftBytes and ValidateDraftValue shared with the add/update flows.
+ * Block-UUID: 8ea47734-ed37-4dee-85dd-7dbcf308ef51
+ * Parent-UUID: f340ff18-fff4-4b16-82ba-ea2542358756
+ * Version: 1.3.0
+ * Description: Validates the syntax of executable review checks (sh:, grep:, exists:).
  * Language: Go
  * Created-at: 2026-06-12T12:44:13Z
- * Authors: Codex GPT-5 (v1.0.0), claude-opus-4-8 (v1.1.0), claude-opus-4-8 (v1.2.0)
+ * Authors: Codex GPT-5 (v1.0.0), claude-opus-4-8 (v1.1.0), claude-opus-4-8 (v1.2.0), agent (v1.3.0)
  */
 
 
@@ -141,6 +141,9 @@ func ValidateDraft(d Draft) []string {
 		if len(check) > 300 {
 			errs = append(errs, "review checks must be 300 characters or fewer")
 		}
+		if err := ValidateReviewCheck(check); err != nil {
+			errs = append(errs, err.Error())
+		}
 	}
 
 	return errs
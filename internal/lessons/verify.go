@@ -0,0 +1,399 @@
+/**
+ * Component: Lessons Verification
+ * Block-UUID: a173850a-2aa1-406d-b84e-011c3ca1782a
+ * Parent-UUID: N/A
+ * Version: 1.1.0
+ * Description: Parses executable review checks (sh:, grep:, exists:) and runs them against the working tree to confirm that committed lessons still hold. Shell checks only run when allowed, since lessons may come from imported packs.
+ * Language: Go
+ * Created-at: 2026-10-18T13:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package lessons
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+)
+
+// CheckKind identifies how a review check is evaluated.
+type CheckKind string
+
+const (
+	CheckManual CheckKind = "manual" // Free text; reviewed by a human
+	CheckShell  CheckKind = "sh"     // Passes when the command exits 0
+	CheckGrep   CheckKind = "grep"   // Passes when the pattern matches (or not, when negated)
+	CheckExists CheckKind = "exists" // Passes when the path or glob exists (or not, when negated)
+)
+
+// CheckStatus is the outcome of one review check or one lesson.
+type CheckStatus string
+
+const (
+	StatusPass       CheckStatus = "pass"
+	StatusFail       CheckStatus = "fail"
+	StatusError      CheckStatus = "error"
+	StatusSkipped    CheckStatus = "skipped"
+	StatusUnverified CheckStatus = "unverified" // Lesson has no executable checks
+)
+
+// ReviewCheck is a parsed review check. Executable checks use a prefix:
+//
+//	sh: go test ./internal/manifest/...
+//	grep: RebuildAndImport in internal/notes/**
+//	!grep: "internal/registry" in internal/cli/**
+//	exists: internal/manifest/importer.go
+//	!exists: internal/legacy/**
+//
+// Any other text is a manual check.
+type ReviewCheck struct {
+	Raw     string    `json:"raw"`
+	Kind    CheckKind `json:"kind"`
+	Negate  bool      `json:"negate,omitempty"`
+	Pattern string    `json:"pattern,omitempty"` // sh command, grep regex, or exists path/glob
+	Globs   []string  `json:"globs,omitempty"`   // grep path filters
+}
+
+// Executable reports whether the check can be run automatically.
+func (c ReviewCheck) Executable() bool {
+	return c.Kind != CheckManual
+}
+
+// ParseReviewCheck parses a review check string.
+func ParseReviewCheck(raw string) ReviewCheck {
+	check := ReviewCheck{Raw: raw, Kind: CheckManual}
+	text := strings.TrimSpace(raw)
+	prefix, rest, ok := strings.Cut(text, ":")
+	if !ok {
+		return check
+	}
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	rest = strings.TrimSpace(rest)
+	if strings.HasPrefix(prefix, "!") {
+		check.Negate = true
+		prefix = prefix[1:]
+	}
+
+	switch prefix {
+	case "sh":
+		if check.Negate {
+			return ReviewCheck{Raw: raw, Kind: CheckManual}
+		}
+		check.Kind, check.Pattern = CheckShell, rest
+	case "grep":
+		check.Kind = CheckGrep
+		check.Pattern, check.Globs = splitGrepCheck(rest)
+	case "exists":
+		check.Kind, check.Pattern = CheckExists, rest
+	case "absent":
+		check.Kind, check.Pattern, check.Negate = CheckExists, rest, !check.Negate
+	default:
+		return ReviewCheck{Raw: raw, Kind: CheckManual}
+	}
+	return check
+}
+
+// splitGrepCheck splits `<pattern> [in <glob>[, <glob>...]]`. The pattern may
+// be double-quoted when it contains " in ".
+func splitGrepCheck(rest string) (string, []string) {
+	pattern, scope := rest, ""
+	if strings.HasPrefix(rest, `"`) {
+		if end := strings.Index(rest[1:], `"`); end >= 0 {
+			pattern = rest[1 : end+1]
+			scope = strings.TrimSpace(rest[end+2:])
+			scope = strings.TrimSpace(strings.TrimPrefix(scope, "in "))
+		}
+	} else if i := strings.LastIndex(rest, " in "); i >= 0 {
+		pattern, scope = strings.TrimSpace(rest[:i]), strings.TrimSpace(rest[i+4:])
+	}
+	var globs []string
+	for _, g := range strings.Split(scope, ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			globs = append(globs, g)
+		}
+	}
+	return pattern, globs
+}
+
+// ValidateReviewCheck reports syntax errors in an executable review check.
+func ValidateReviewCheck(raw string) error {
+	check := ParseReviewCheck(raw)
+	if !check.Executable() {
+		return nil
+	}
+	if check.Pattern == "" {
+		return fmt.Errorf("review check %q is missing its %s argument", raw, check.Kind)
+	}
+	if check.Kind == CheckGrep {
+		if _, err := regexp.Compile(check.Pattern); err != nil {
+			return fmt.Errorf("review check %q has an invalid pattern: %v", raw, err)
+		}
+	}
+	return nil
+}
+
+// VerifyOptions controls how review checks are executed.
+type VerifyOptions struct {
+	RepoRoot   string        // Working directory for checks (default: project root)
+	Timeout    time.Duration // Per-check timeout (default: 30s)
+	AllowShell bool          // Run sh: checks; lessons can come from imported packs, so they are skipped by default
+}
+
+// CheckResult is the outcome of one review check.
+type CheckResult struct {
+	Check      ReviewCheck `json:"check"`
+	Status     CheckStatus `json:"status"`
+	Detail     string      `json:"detail,omitempty"`
+	DurationMs int64       `json:"duration_ms"`
+}
+
+// LessonVerification is the outcome of verifying one lesson.
+type LessonVerification struct {
+	Source  gitsensescope.Source `json:"source"`
+	ID      string               `json:"id"`
+	Summary string               `json:"summary"`
+	Status  CheckStatus          `json:"status"`
+	Checks  []CheckResult        `json:"checks"`
+}
+
+// VerifyLesson runs every executable review check of a lesson. A lesson fails
+// if any check fails, errors if a check could not run, and is unverified when
+// it has no executable checks.
+func VerifyLesson(ctx context.Context, source gitsensescope.Source, record Record, opts VerifyOptions) (LessonVerification, error) {
+	if opts.RepoRoot == "" {
+		root, err := rootDir()
+		if err != nil {
+			return LessonVerification{}, err
+		}
+		opts.RepoRoot = root
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+
+	result := LessonVerification{Source: source, ID: record.ID, Summary: record.Summary, Status: StatusUnverified, Checks: []CheckResult{}}
+	executed := 0
+	for _, raw := range record.ReviewChecks {
+		check := ParseReviewCheck(raw)
+		if !check.Executable() {
+			continue
+		}
+		var cr CheckResult
+		if check.Kind == CheckShell && !opts.AllowShell {
+			cr = CheckResult{Check: check, Status: StatusSkipped, Detail: "shell checks not allowed"}
+		} else {
+			cr = RunReviewCheck(ctx, check, opts)
+			executed++
+		}
+		result.Checks = append(result.Checks, cr)
+	}
+
+	if executed > 0 {
+		result.Status = StatusPass
+	}
+	for _, cr := range result.Checks {
+		switch cr.Status {
+		case StatusFail:
+			result.Status = StatusFail
+		case StatusError:
+			if result.Status != StatusFail {
+				result.Status = StatusError
+			}
+		}
+	}
+	return result, nil
+}
+
+// RunReviewCheck evaluates one executable check in opts.RepoRoot.
+func RunReviewCheck(ctx context.Context, check ReviewCheck, opts VerifyOptions) CheckResult {
+	start := time.Now()
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	var status CheckStatus
+	var detail string
+	switch check.Kind {
+	case CheckShell:
+		status, detail = runShellCheck(ctx, check, opts.RepoRoot)
+	case CheckGrep:
+		status, detail = runGrepCheck(ctx, check, opts.RepoRoot)
+	case CheckExists:
+		status, detail = runExistsCheck(check, opts.RepoRoot)
+	default:
+		status, detail = StatusSkipped, "manual check"
+	}
+	return CheckResult{Check: check, Status: status, Detail: detail, DurationMs: time.Since(start).Milliseconds()}
+}
+
+func runShellCheck(ctx context.Context, check ReviewCheck, root string) (CheckStatus, string) {
+	shell, flag := "sh", "-c"
+	if os.PathSeparator == '\\' {
+		shell, flag = "cmd", "/c"
+	}
+	cmd := exec.CommandContext(ctx, shell, flag, check.Pattern)
+	cmd.Dir = root
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return StatusError, "timed out"
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return StatusFail, fmt.Sprintf("exit %d: %s", exitErr.ExitCode(), lastLine(out))
+	}
+	if err != nil {
+		return StatusError, err.Error()
+	}
+	return StatusPass, ""
+}
+
+func runGrepCheck(ctx context.Context, check ReviewCheck, root string) (CheckStatus, string) {
+	var files []string
+	var err error
+	if _, lookErr := exec.LookPath("rg"); lookErr == nil {
+		files, err = ripgrepFiles(ctx, check, root)
+	} else {
+		files, err = walkGrepFiles(ctx, check, root)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return StatusError, "timed out"
+	}
+	if err != nil {
+		return StatusError, err.Error()
+	}
+
+	switch {
+	case check.Negate && len(files) > 0:
+		return StatusFail, fmt.Sprintf("unexpected match in %s", summarizePaths(files))
+	case !check.Negate && len(files) == 0:
+		return StatusFail, "no matches"
+	case len(files) > 0:
+		return StatusPass, fmt.Sprintf("matched %s", summarizePaths(files))
+	default:
+		return StatusPass, ""
+	}
+}
+
+// ripgrepFiles returns the files matching the check, using the same ripgrep
+// engine as gsc grep so .gitignore is respected.
+func ripgrepFiles(ctx context.Context, check ReviewCheck, root string) ([]string, error) {
+	args := []string{"--files-with-matches", "--no-messages", "-e", check.Pattern}
+	for _, g := range check.Globs {
+		args = append(args, "-g", g)
+	}
+	cmd := exec.CommandContext(ctx, "rg", args...)
+	cmd.Dir = root
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		var files []string
+		for _, line := range strings.Split(stdout.String(), "\n") {
+			if line = strings.TrimRight(line, "\r"); line != "" {
+				files = append(files, line)
+			}
+		}
+		return files, nil
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+		return nil, nil // No matches
+	default:
+		return nil, fmt.Errorf("ripgrep failed: %s", strings.TrimSpace(stderr.String()))
+	}
+}
+
+// walkGrepFiles is the fallback when ripgrep is not installed. It skips
+// hidden directories but does not apply .gitignore.
+func walkGrepFiles(ctx context.Context, check ReviewCheck, root string) ([]string, error) {
+	re, err := regexp.Compile(check.Pattern)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if d.IsDir() {
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, _ := filepath.Rel(root, path)
+		rel = filepath.ToSlash(rel)
+		if len(check.Globs) > 0 && !matchesAnyGlob(check.Globs, rel) {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil || bytes.IndexByte(data, 0) >= 0 {
+			return nil
+		}
+		if re.Match(data) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
+
+// matchesAnyGlob follows ripgrep -g semantics closely enough for review
+// checks: globs without a slash match the file name anywhere in the tree.
+func matchesAnyGlob(globs []string, rel string) bool {
+	for _, g := range globs {
+		target := rel
+		if !strings.Contains(g, "/") {
+			target = filepath.Base(rel)
+		}
+		if ok, _ := doublestar.Match(g, target); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func runExistsCheck(check ReviewCheck, root string) (CheckStatus, string) {
+	matches, err := doublestar.Glob(os.DirFS(root), strings.TrimPrefix(check.Pattern, "./"))
+	if err != nil {
+		return StatusError, err.Error()
+	}
+	switch {
+	case check.Negate && len(matches) > 0:
+		return StatusFail, fmt.Sprintf("found %s", summarizePaths(matches))
+	case !check.Negate && len(matches) == 0:
+		return StatusFail, "not found"
+	default:
+		return StatusPass, ""
+	}
+}
+
+func summarizePaths(paths []string) string {
+	if len(paths) <= 3 {
+		return strings.Join(paths, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(paths[:3], ", "), len(paths)-3)
+}
+
+func lastLine(out []byte) string {
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,136 @@
+/**
+ * Component: Lessons Verification Tests
+ * Block-UUID: 5b732493-351b-41d0-94c0-2f712021fedf
+ * Parent-UUID: N/A
+ * Version: 1.1.0
+ * Description: Tests for review check parsing and lesson verification against a temporary tree, including opt-in shell checks and paths with spaces.
+ * Language: Go
+ * Created-at: 2026-10-18T13:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package lessons
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+)
+
+func TestParseReviewCheck(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want ReviewCheck
+	}{
+		{"Confirm the migration is idempotent", ReviewCheck{Kind: CheckManual}},
+		{"Note: keep this short", ReviewCheck{Kind: CheckManual}},
+		{"sh: go vet ./...", ReviewCheck{Kind: CheckShell, Pattern: "go vet ./..."}},
+		{"grep: RebuildAndImport in internal/notes/**, internal/rules/**", ReviewCheck{Kind: CheckGrep, Pattern: "RebuildAndImport", Globs: []string{"internal/notes/**", "internal/rules/**"}}},
+		{`!grep: "import .* in" in internal/cli/**`, ReviewCheck{Kind: CheckGrep, Negate: true, Pattern: "import .* in", Globs: []string{"internal/cli/**"}}},
+		{"exists: go.mod", ReviewCheck{Kind: CheckExists, Pattern: "go.mod"}},
+		{"absent: internal/legacy/**", ReviewCheck{Kind: CheckExists, Negate: true, Pattern: "internal/legacy/**"}},
+	}
+	for _, tt := range tests {
+		got := ParseReviewCheck(tt.raw)
+		tt.want.Raw = tt.raw
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseReviewCheck(%q) = %+v, want %+v", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestValidateReviewCheck(t *testing.T) {
+	if err := ValidateReviewCheck("grep: ("); err == nil {
+		t.Error("expected error for invalid regex")
+	}
+	if err := ValidateReviewCheck("exists:"); err == nil {
+		t.Error("expected error for missing path")
+	}
+	if err := ValidateReviewCheck("Free text review"); err != nil {
+		t.Errorf("manual check should be valid: %v", err)
+	}
+}
+
+func TestVerifyLesson(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "internal", "cli"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "internal", "cli", "root.go"), []byte("package cli\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	opts := VerifyOptions{RepoRoot: root}
+
+	pass, err := VerifyLesson(context.Background(), gitsensescope.SourceRepo, Record{
+		ID:           "lsn_pass",
+		ReviewChecks: []string{"Manual review", "exists: internal/cli/*.go", "!exists: internal/legacy"},
+	}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pass.Status != StatusPass || len(pass.Checks) != 2 {
+		t.Errorf("pass lesson = %+v, want pass with 2 executed checks", pass)
+	}
+
+	fail, _ := VerifyLesson(context.Background(), gitsensescope.SourceRepo, Record{
+		ID:           "lsn_fail",
+		ReviewChecks: []string{"exists: internal/manifest/importer.go"},
+	}, opts)
+	if fail.Status != StatusFail {
+		t.Errorf("fail lesson status = %s, want fail", fail.Status)
+	}
+
+	manual, _ := VerifyLesson(context.Background(), gitsensescope.SourceRepo, Record{
+		ID:           "lsn_manual",
+		ReviewChecks: []string{"Ask the data team"},
+	}, opts)
+	if manual.Status != StatusUnverified {
+		t.Errorf("manual lesson status = %s, want unverified", manual.Status)
+	}
+
+	if _, err := exec.LookPath("sh"); err == nil {
+		shell, _ := VerifyLesson(context.Background(), gitsensescope.SourceRepo, Record{
+			ID:           "lsn_shell",
+			ReviewChecks: []string{"sh: test -f internal/cli/root.go", "sh: exit 3"},
+		}, VerifyOptions{RepoRoot: root, AllowShell: true})
+		if shell.Status != StatusFail || shell.Checks[0].Status != StatusPass || shell.Checks[1].Status != StatusFail {
+			t.Errorf("shell lesson = %+v, want first pass and second fail", shell)
+		}
+
+		skipped, _ := VerifyLesson(context.Background(), gitsensescope.SourceRepo, Record{
+			ID:           "lsn_skip",
+			ReviewChecks: []string{"sh: exit 3"},
+		}, opts)
+		if skipped.Status != StatusUnverified || skipped.Checks[0].Status != StatusSkipped {
+			t.Errorf("skipped lesson = %+v, want unverified with skipped check", skipped)
+		}
+	}
+
+	grep, _ := VerifyLesson(context.Background(), gitsensescope.SourceRepo, Record{
+		ID:           "lsn_grep",
+		ReviewChecks: []string{"grep: ^package cli in internal/cli/**", "!grep: internal/registry in internal/cli/**"},
+	}, opts)
+	if grep.Status != StatusPass {
+		t.Errorf("grep lesson = %+v, want pass", grep)
+	}
+}
+
+func TestGrepCheckReportsPathsWithSpaces(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "my docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "my docs", "old notes.md"), []byte("legacy registry\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	check := ParseReviewCheck("!grep: legacy registry")
+	result := RunReviewCheck(context.Background(), check, VerifyOptions{RepoRoot: root})
+	if result.Status != StatusFail || result.Detail != "unexpected match in my docs/old notes.md" {
+		t.Errorf("grep result = %+v, want fail naming my docs/old notes.md", result)
+	}
+}
@@ -0,0 +1,287 @@
+/**
+ * Component: Manifest Field Patch Builder and Merger
+ * Block-UUID: 7c3e1a58-9d24-4b6f-8e05-a2f9b6c4d713
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Builds a Manifest patch that adds values to an array field for files already in a Brain, carrying the Brain's repository, branch, analyzer, and field references so it passes ValidateManifest, and merges a reviewed patch back into the Brain in place after a backup.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package manifest
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/db"
+	"github.com/gitsense/gsc-cli/pkg/logger"
+)
+
+// FieldPatch is a Manifest patch built against a Brain, with what it adds.
+type FieldPatch struct {
+	Manifest *ManifestFile       `json:"manifest"`
+	Added    map[string][]string `json:"added"`             // File path -> values new to the Brain
+	Unknown  []string            `json:"unknown,omitempty"` // Files the Brain has no entry for
+}
+
+// BuildFieldPatch builds a Manifest patch that adds values to the array
+// field fieldName for files in the Brain dbName. additions maps file paths,
+// relative to the project root, to proposed values. Each data entry carries
+// the file's existing values plus the new ones, so importing or merging the
+// patch never drops a value. Files the Brain has not analyzed are reported
+// in Unknown, and files with nothing new are left out.
+func BuildFieldPatch(ctx context.Context, dbName string, fieldName string, additions map[string][]string) (*FieldPatch, error) {
+	database, err := openBrain(dbName, true)
+	if err != nil {
+		return nil, err
+	}
+	defer db.CloseDB(database)
+
+	patch := &ManifestFile{SchemaVersion: "1.0", GeneratedAt: time.Now().UTC()}
+	var tags string
+	row := database.QueryRowContext(ctx, "SELECT name, COALESCE(description, ''), COALESCE(tags, ''), COALESCE(version, '') FROM manifest_info LIMIT 1")
+	if err := row.Scan(&patch.Manifest.ManifestName, &patch.Manifest.Description, &tags, &patch.SchemaVersion); err != nil {
+		return nil, fmt.Errorf("failed to read manifest info for %s: %w", dbName, err)
+	}
+	patch.Manifest.DatabaseName = dbName
+	json.Unmarshal([]byte(tags), &patch.Manifest.Tags)
+	if patch.SchemaVersion == "" {
+		patch.SchemaVersion = "1.0"
+	}
+
+	// The field and the analyzer that produced it
+	var field Field
+	var analyzer Analyzer
+	row = database.QueryRowContext(ctx, `
+		SELECT f.field_ref_id, f.field_name, COALESCE(f.field_display_name, ''), COALESCE(f.field_type, ''), COALESCE(f.field_description, ''),
+		       a.analyzer_ref_id, a.analyzer_id, COALESCE(a.analyzer_name, ''), COALESCE(a.analyzer_description, ''), COALESCE(a.analyzer_version, '')
+		FROM metadata_fields f
+		JOIN analyzers a ON a.analyzer_id = f.analyzer_id
+		WHERE f.field_name = ?
+		LIMIT 1`, fieldName)
+	if err := row.Scan(&field.Ref, &field.Name, &field.DisplayName, &field.Type, &field.Description,
+		&analyzer.Ref, &analyzer.ID, &analyzer.Name, &analyzer.Description, &analyzer.Version); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("field '%s' not found in database '%s'", fieldName, dbName)
+		}
+		return nil, fmt.Errorf("failed to read field '%s': %w", fieldName, err)
+	}
+	if field.Type != "array" {
+		return nil, fmt.Errorf("field '%s' is of type '%s'; only array fields can be extended", fieldName, field.Type)
+	}
+	field.AnalyzerRef = analyzer.Ref
+	patch.Analyzers = []Analyzer{analyzer}
+	patch.Fields = []Field{field}
+
+	// Brains record file paths without a repository column; the patch uses
+	// the Brain's first repository and branch, as single-repo Brains do
+	if err := scanRefs(ctx, database, "SELECT ref, name FROM repositories ORDER BY ref", func(ref, name string) {
+		patch.Repositories = append(patch.Repositories, Repository{Ref: ref, Name: name})
+	}); err != nil {
+		return nil, err
+	}
+	if err := scanRefs(ctx, database, "SELECT ref, name FROM branches ORDER BY ref", func(ref, name string) {
+		patch.Branches = append(patch.Branches, Branch{Ref: ref, Name: name})
+	}); err != nil {
+		return nil, err
+	}
+	if len(patch.Repositories) == 0 || len(patch.Branches) == 0 {
+		return nil, fmt.Errorf("database '%s' has no repositories or branches to reference", dbName)
+	}
+
+	result := &FieldPatch{Manifest: patch, Added: make(map[string][]string)}
+	paths := make([]string, 0, len(additions))
+	for path := range additions {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		var chatID int
+		var language, existing sql.NullString
+		err := database.QueryRowContext(ctx, `
+			SELECT f.chat_id, f.language, m.field_value
+			FROM files f
+			LEFT JOIN file_metadata m ON m.file_path = f.file_path AND m.field_id = ?
+			WHERE f.file_path = ?`, field.Ref, path).Scan(&chatID, &language, &existing)
+		if err == sql.ErrNoRows {
+			result.Unknown = append(result.Unknown, path)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		var values []string
+		if existing.Valid && existing.String != "" {
+			if err := json.Unmarshal([]byte(existing.String), &values); err != nil {
+				return nil, fmt.Errorf("failed to parse %s of %s: %w", fieldName, path, err)
+			}
+		}
+		merged, added := mergeValues(values, additions[path])
+		if len(added) == 0 {
+			continue
+		}
+		result.Added[path] = added
+		patch.Data = append(patch.Data, DataEntry{
+			RepoRef:   patch.Repositories[0].Ref,
+			BranchRef: patch.Branches[0].Ref,
+			FilePath:  path,
+			Language:  language.String,
+			ChatID:    chatID,
+			Fields:    map[string]interface{}{field.Ref: merged},
+		})
+	}
+
+	return result, nil
+}
+
+// mergeValues appends the proposed values missing from existing, comparing
+// case-insensitively, and returns the merged list and what was added.
+func mergeValues(existing, proposed []string) ([]string, []string) {
+	seen := make(map[string]bool, len(existing))
+	merged := append([]string{}, existing...)
+	for _, value := range existing {
+		seen[strings.ToLower(value)] = true
+	}
+	var added []string
+	for _, value := range proposed {
+		key := strings.ToLower(strings.TrimSpace(value))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, value)
+		added = append(added, value)
+	}
+	return merged, added
+}
+
+// MergeManifest merges the field values of a reviewed patch into the Brain
+// dbName in place, unlike ImportManifest which replaces the whole database.
+// The patch must pass ValidateManifest, every field it sets must exist in the
+// Brain, and every file must already be analyzed. The Brain is backed up
+// first unless noBackup is set. It returns the number of files updated.
+func MergeManifest(ctx context.Context, dbName string, patch *ManifestFile, noBackup bool) (int, error) {
+	if err := ValidateManifest(patch); err != nil {
+		return 0, fmt.Errorf("patch validation failed: %w", err)
+	}
+
+	lockPath, err := ResolveLockPath()
+	if err != nil {
+		return 0, fmt.Errorf("failed to resolve lock path: %w", err)
+	}
+	lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, fmt.Errorf("another import is already in progress (lock file exists): %w", err)
+	}
+	defer os.Remove(lockPath)
+	defer lockFile.Close()
+
+	dbPath, err := db.ResolveManifestDBPath(dbName)
+	if err != nil {
+		return 0, err
+	}
+	database, err := openBrain(dbName, false)
+	if err != nil {
+		return 0, err
+	}
+	defer db.CloseDB(database)
+
+	// Patch field refs must name fields the Brain already defines
+	for _, field := range patch.Fields {
+		var name string
+		err := database.QueryRowContext(ctx, "SELECT field_name FROM metadata_fields WHERE field_id = ?", field.Ref).Scan(&name)
+		if err != nil {
+			return 0, fmt.Errorf("field %s (%s) is not defined in database '%s'", field.Ref, field.Name, dbName)
+		}
+		if name != field.Name {
+			return 0, fmt.Errorf("field %s is '%s' in database '%s', not '%s'", field.Ref, name, dbName, field.Name)
+		}
+	}
+	for _, entry := range patch.Data {
+		var exists int
+		if err := database.QueryRowContext(ctx, "SELECT COUNT(*) FROM files WHERE file_path = ?", entry.FilePath).Scan(&exists); err != nil {
+			return 0, err
+		}
+		if exists == 0 {
+			return 0, fmt.Errorf("file %s is not in database '%s'; analyze it before merging", entry.FilePath, dbName)
+		}
+	}
+
+	if !noBackup {
+		if err := backupDatabase(dbName, dbPath); err != nil {
+			return 0, fmt.Errorf("backup failed and --no-backup was not specified: %w", err)
+		}
+	}
+
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, entry := range patch.Data {
+		for fieldRef, value := range entry.Fields {
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return 0, fmt.Errorf("failed to encode %s of %s: %w", fieldRef, entry.FilePath, err)
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO file_metadata (file_path, field_id, field_value) VALUES (?, ?, ?)
+				ON CONFLICT(file_path, field_id) DO UPDATE SET field_value = excluded.field_value`,
+				entry.FilePath, fieldRef, string(encoded)); err != nil {
+				return 0, fmt.Errorf("failed to update %s of %s: %w", fieldRef, entry.FilePath, err)
+			}
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE manifest_info SET updated_at = ?", time.Now()); err != nil {
+		return 0, fmt.Errorf("failed to update manifest info: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	logger.Success("Merged patch into database", "db", dbName, "files", len(patch.Data))
+	return len(patch.Data), nil
+}
+
+// openBrain opens an existing Brain database.
+func openBrain(dbName string, readOnly bool) (*sql.DB, error) {
+	dbPath, err := db.ResolveManifestDBPath(dbName)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.ValidateDBExists(dbPath); err != nil {
+		return nil, err
+	}
+	if readOnly {
+		return db.OpenReadOnlyDB(dbPath)
+	}
+	return db.OpenDB(dbPath)
+}
+
+// scanRefs reads (ref, name) rows.
+func scanRefs(ctx context.Context, database *sql.DB, query string, add func(ref, name string)) error {
+	rows, err := database.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var ref, name string
+		if err := rows.Scan(&ref, &name); err != nil {
+			return err
+		}
+		add(ref, name)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,223 @@
+/**
+ * Component: Notes Lifecycle
+ * Block-UUID: 7d1f0a52-8a1b-4f0e-9d0c-6b2e41c9a3f7
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Implements note expiry parsing, live-note filtering, archiving, pruning, supersession, and promotion bookkeeping.
+ * Language: Go
+ * Created-at: 2026-10-18T10:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package notes
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+)
+
+// ParseExpiry parses an expiry value relative to now. It accepts a duration
+// with a d/w suffix or any time.ParseDuration value ("7d", "2w", "36h"), a
+// date ("2026-11-01"), or an RFC3339 timestamp. "never" clears the expiry.
+func ParseExpiry(value string, now time.Time) (*time.Time, error) {
+	v := strings.TrimSpace(strings.ToLower(value))
+	if v == "" || v == "never" {
+		return nil, nil
+	}
+	if len(v) > 1 && (strings.HasSuffix(v, "d") || strings.HasSuffix(v, "w")) {
+		if n, err := strconv.Atoi(v[:len(v)-1]); err == nil && n > 0 {
+			days := n
+			if strings.HasSuffix(v, "w") {
+				days = n * 7
+			}
+			t := now.AddDate(0, 0, days).UTC()
+			return &t, nil
+		}
+	}
+	if d, err := time.ParseDuration(v); err == nil && d > 0 {
+		t := now.Add(d).UTC()
+		return &t, nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		t = t.UTC()
+		return &t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		t = t.UTC()
+		return &t, nil
+	}
+	return nil, fmt.Errorf("invalid expiry %q: use a duration (7d, 2w, 36h), a date (2006-01-02), an RFC3339 time, or never", value)
+}
+
+// IsValidStatus checks if the status is a valid note status.
+func IsValidStatus(status Status) bool {
+	if status == "" {
+		return true
+	}
+	for _, valid := range ValidStatuses {
+		if status == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterLive returns notes that are neither archived nor expired.
+func FilterLive(records []Note, now time.Time) []Note {
+	var out []Note
+	for _, n := range records {
+		if n.IsLive(now) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+// FilterLiveSourced returns sourced notes that are neither archived nor expired.
+func FilterLiveSourced(records []SourcedNote, now time.Time) []SourcedNote {
+	var out []SourcedNote
+	for _, sn := range records {
+		if sn.Note.IsLive(now) {
+			out = append(out, sn)
+		}
+	}
+	return out
+}
+
+// SetStatusInTarget changes the status of a note in the target store, records
+// the transition in the note changelog, and returns the updated note.
+func SetStatusInTarget(idOrPrefix string, target gitsensescope.Target, status Status, message string) (*Note, error) {
+	if !IsValidStatus(status) {
+		return nil, fmt.Errorf("invalid status %q: must be one of active, pinned, archived", status)
+	}
+	return mutateInTarget(idOrPrefix, target, func(n *Note, now time.Time) {
+		n.Status = status
+		if message == "" {
+			message = fmt.Sprintf("Status set to %s", status)
+		}
+		n.Changelog = append(n.Changelog, ChangelogEntry{Timestamp: now, Message: message})
+	})
+}
+
+// MarkPromoted archives a note that was promoted to a lesson and links the lesson.
+func MarkPromoted(idOrPrefix string, target gitsensescope.Target, lessonID string) (*Note, error) {
+	return mutateInTarget(idOrPrefix, target, func(n *Note, now time.Time) {
+		n.Status = StatusArchived
+		n.PromotedTo = lessonID
+		n.Changelog = append(n.Changelog, ChangelogEntry{Timestamp: now, Message: fmt.Sprintf("Promoted to lesson %s", lessonID)})
+	})
+}
+
+// ArchiveSuperseded archives the note replaced by successor, if it exists in
+// the target store. A missing superseded note is not an error.
+func ArchiveSuperseded(successor Note, target gitsensescope.Target) (*Note, error) {
+	if successor.Supersedes == "" {
+		return nil, nil
+	}
+	existing, err := ResolveRecordFromTarget(successor.Supersedes, target)
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	return mutateInTarget(existing.ID, target, func(n *Note, now time.Time) {
+		n.Status = StatusArchived
+		n.Changelog = append(n.Changelog, ChangelogEntry{Timestamp: now, Message: fmt.Sprintf("Superseded by %s", successor.ID)})
+	})
+}
+
+// ArchiveExpiredInTarget archives every expired note in the target store.
+func ArchiveExpiredInTarget(target gitsensescope.Target, now time.Time, dryRun bool) ([]Note, error) {
+	records, err := LoadRecordsFromTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	var archived []Note
+	for i := range records {
+		if records[i].EffectiveStatus() == StatusArchived || !records[i].IsExpired(now) {
+			continue
+		}
+		records[i].Status = StatusArchived
+		records[i].UpdatedAt = now
+		records[i].Changelog = append(records[i].Changelog, ChangelogEntry{Timestamp: now, Message: "Archived after expiry"})
+		archived = append(archived, records[i])
+	}
+	if len(archived) == 0 || dryRun {
+		return archived, nil
+	}
+	return archived, WriteRecordsToTarget(records, target)
+}
+
+// PruneOptions controls which notes PruneTarget removes.
+type PruneOptions struct {
+	Archived       bool          // Also remove archived notes
+	ArchivedBefore time.Duration // Only remove archived notes untouched for this long (0 = any age)
+	DryRun         bool
+}
+
+// PruneTarget permanently removes expired notes (and optionally archived
+// notes) from the target store and returns the removed notes. Pinned notes and
+// notes promoted to lessons are never pruned.
+func PruneTarget(target gitsensescope.Target, now time.Time, opts PruneOptions) ([]Note, error) {
+	records, err := LoadRecordsFromTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	var kept, removed []Note
+	for _, n := range records {
+		if shouldPrune(n, now, opts) {
+			removed = append(removed, n)
+			continue
+		}
+		kept = append(kept, n)
+	}
+	if len(removed) == 0 || opts.DryRun {
+		return removed, nil
+	}
+	return removed, WriteRecordsToTarget(kept, target)
+}
+
+func shouldPrune(n Note, now time.Time, opts PruneOptions) bool {
+	if n.EffectiveStatus() == StatusPinned || n.PromotedTo != "" {
+		return false
+	}
+	if n.IsExpired(now) {
+		return true
+	}
+	if opts.Archived && n.EffectiveStatus() == StatusArchived {
+		return opts.ArchivedBefore == 0 || now.Sub(n.UpdatedAt) >= opts.ArchivedBefore
+	}
+	return false
+}
+
+// mutateInTarget resolves a note in the target store, applies fn, bumps
+// UpdatedAt, and rewrites the store.
+func mutateInTarget(idOrPrefix string, target gitsensescope.Target, fn func(n *Note, now time.Time)) (*Note, error) {
+	existing, err := ResolveRecordFromTarget(idOrPrefix, target)
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, fmt.Errorf("note not found in %s store: %s", target, idOrPrefix)
+	}
+	records, err := LoadRecordsFromTarget(target)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	for i := range records {
+		if records[i].ID != existing.ID {
+			continue
+		}
+		fn(&records[i], now)
+		records[i].UpdatedAt = now
+		if err := WriteRecordsToTarget(records, target); err != nil {
+			return nil, err
+		}
+		updated := records[i]
+		return &updated, nil
+	}
+	return nil, fmt.Errorf("note not found in %s store: %s", target, existing.ID)
+}
@@ -0,0 +1,136 @@
+/**
+ * Component: Notes Lifecycle Tests
+ * Block-UUID: 9f4c1e27-6a8d-4b3f-b520-d7e8a1c3f096
+ * Parent-UUID: N/A
+ * Version: 1.1.0
+ * Description: Tests for note expiry parsing, live filtering, archiving expired notes, pruning, and keeping expired notes out of the Brain manifest.
+ * Language: Go
+ * Created-at: 2026-10-18T10:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package notes
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/gitsensescope"
+)
+
+func TestParseExpiry(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"7d", now.AddDate(0, 0, 7)},
+		{"2w", now.AddDate(0, 0, 14)},
+		{"36h", now.Add(36 * time.Hour)},
+		{"2026-11-01", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"2026-11-01T08:00:00Z", time.Date(2026, 11, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseExpiry(tt.in, now)
+		if err != nil {
+			t.Fatalf("ParseExpiry(%q) error: %v", tt.in, err)
+		}
+		if got == nil || !got.Equal(tt.want) {
+			t.Errorf("ParseExpiry(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+
+	if got, err := ParseExpiry("never", now); err != nil || got != nil {
+		t.Errorf("ParseExpiry(never) = %v, %v; want nil, nil", got, err)
+	}
+	if _, err := ParseExpiry("soon", now); err == nil {
+		t.Error("ParseExpiry(soon) expected error")
+	}
+}
+
+func TestNoteIsLive(t *testing.T) {
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name string
+		note Note
+		want bool
+	}{
+		{"no lifecycle fields", Note{}, true},
+		{"not yet expired", Note{ExpiresAt: &future}, true},
+		{"expired", Note{ExpiresAt: &past}, false},
+		{"pinned never expires", Note{Status: StatusPinned, ExpiresAt: &past}, true},
+		{"archived", Note{Status: StatusArchived}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.note.IsLive(now); got != tt.want {
+			t.Errorf("%s: IsLive() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestBuildManifestSkipsExpiredNotes(t *testing.T) {
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour)
+	file := BuildManifest([]Note{
+		{ID: "note_live", Summary: "live", LinkedFiles: []string{"live.go"}},
+		{ID: "note_expired", Summary: "expired", LinkedFiles: []string{"expired.go"}, ExpiresAt: &past},
+		{ID: "note_pinned", Summary: "pinned", LinkedFiles: []string{"pinned.go"}, Status: StatusPinned, ExpiresAt: &past},
+		{ID: "note_archived", Summary: "archived", LinkedFiles: []string{"archived.go"}, Status: StatusArchived},
+	})
+	var paths []string
+	for _, entry := range file.Data {
+		paths = append(paths, entry.FilePath)
+	}
+	if strings.Join(paths, ",") != "live.go,pinned.go" {
+		t.Errorf("projected paths = %v, want [live.go pinned.go]", paths)
+	}
+}
+
+func TestArchiveExpiredAndPrune(t *testing.T) {
+	repoDir := initTempGitRepo(t)
+	t.Setenv("GSC_HOME", t.TempDir())
+	origDir, _ := os.Getwd()
+	defer os.Chdir(origDir)
+	os.Chdir(repoDir)
+
+	now := time.Now().UTC()
+	past := now.Add(-time.Hour)
+	records := []Note{
+		{ID: "note_live", Summary: "live"},
+		{ID: "note_expired", Summary: "expired", ExpiresAt: &past},
+		{ID: "note_pinned", Summary: "pinned", Status: StatusPinned, ExpiresAt: &past},
+		{ID: "note_promoted", Summary: "promoted", Status: StatusArchived, PromotedTo: "lsn_1"},
+	}
+	if err := WriteRecordsToTarget(records, gitsensescope.TargetRepo); err != nil {
+		t.Fatal(err)
+	}
+
+	archived, err := ArchiveExpiredInTarget(gitsensescope.TargetRepo, now, false)
+	if err != nil {
+		t.Fatalf("ArchiveExpiredInTarget() error: %v", err)
+	}
+	if len(archived) != 1 || archived[0].ID != "note_expired" {
+		t.Fatalf("archived = %+v, want note_expired only", archived)
+	}
+
+	removed, err := PruneTarget(gitsensescope.TargetRepo, now, PruneOptions{Archived: true})
+	if err != nil {
+		t.Fatalf("PruneTarget() error: %v", err)
+	}
+	if len(removed) != 1 || removed[0].ID != "note_expired" {
+		t.Fatalf("removed = %+v, want note_expired only", removed)
+	}
+
+	remaining, err := LoadRecordsFromTarget(gitsensescope.TargetRepo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 3 {
+		t.Errorf("remaining = %d notes, want 3", len(remaining))
+	}
+}
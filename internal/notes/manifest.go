@@ -1,12 +1,12 @@
 /**
  * Component: Notes Manifest Projection
- * Block-UUID: d0e1f2a3-b4c5-6789-defa-890123456789
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Projects notes into the gsc-notes Brain manifest for file-level querying.
+ * Block-UUID: 80a8e810-f198-416b-a93c-f4c441587ae9
+ * Parent-UUID: d0e1f2a3-b4c5-6789-defa-890123456789
+ * Version: 1.2.0
+ * Description: Skips archived and expired notes when projecting the gsc-notes manifest.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), agent (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -98,7 +98,10 @@ func BuildManifest(records []Note) manifest.ManifestFile {
 	repoName := repoName()
 	projections := map[string]*projection{}
 
-	for _, record := range records {
+	// Archived and expired notes stay in records.jsonl for history but are not
+	// projected, matching what notes get and search show by default. A note
+	// that expires after this build leaves the Brain at the next rebuild.
+	for _, record := range FilterLive(records, now) {
 		// For notes, project onto glob patterns and linked files
 		var targets []string
 		targets = append(targets, record.GlobPatterns...)
@@ -1,12 +1,12 @@
 /**
  * Component: Notes Domain Models
- * Block-UUID: b2c3d4e5-f6a7-8901-bcde-f01234567890
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Defines note data structures for searchable scratchpad notes.
+ * Block-UUID: d8c1ae5f-8396-4bdc-8183-b9b36ca2a533
+ * Parent-UUID: b2c3d4e5-f6a7-8901-bcde-f01234567890
+ * Version: 1.1.0
+ * Description: Added lifecycle status (active/pinned/archived), expires_at, supersedes, promoted_to, and changelog fields to notes.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -18,6 +18,24 @@ const (
 	DatabaseName = "gsc-notes"
 )
 
+// Status is the lifecycle state of a note. An empty status is treated as active.
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusPinned   Status = "pinned"
+	StatusArchived Status = "archived"
+)
+
+// ValidStatuses is the list of valid note statuses.
+var ValidStatuses = []Status{StatusActive, StatusPinned, StatusArchived}
+
+// ChangelogEntry records a lifecycle transition on a note.
+type ChangelogEntry struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
 type Note struct {
 	ID             string    `json:"id"`
 	SchemaVersion  string    `json:"schema_version"`
@@ -33,6 +51,34 @@ type Note struct {
 	Keywords       []string  `json:"keywords,omitempty"`
 	ParentKeywords []string  `json:"parent_keywords,omitempty"`
 	Importance     string    `json:"importance,omitempty"`
+
+	// Lifecycle fields
+	Status     Status           `json:"status,omitempty"`      // active (default), pinned, archived
+	ExpiresAt  *time.Time       `json:"expires_at,omitempty"`  // Scratch notes drop out of search after this time
+	Supersedes string           `json:"supersedes,omitempty"`  // ID of the note this note replaces
+	PromotedTo string           `json:"promoted_to,omitempty"` // ID of the lesson this note was promoted to
+	Changelog  []ChangelogEntry `json:"changelog,omitempty"`
+}
+
+// EffectiveStatus returns the note status, defaulting to active.
+func (n Note) EffectiveStatus() Status {
+	if n.Status == "" {
+		return StatusActive
+	}
+	return n.Status
+}
+
+// IsExpired reports whether the note has passed its expiry. Pinned notes never expire.
+func (n Note) IsExpired(now time.Time) bool {
+	if n.EffectiveStatus() == StatusPinned || n.ExpiresAt == nil {
+		return false
+	}
+	return !now.Before(*n.ExpiresAt)
+}
+
+// IsLive reports whether the note should appear in default search and get results.
+func (n Note) IsLive(now time.Time) bool {
+	return n.EffectiveStatus() != StatusArchived && !n.IsExpired(now)
 }
 
 type ValidationResult struct {
@@ -1,12 +1,12 @@
 /**
  * Component: Notes Normalization Helpers
- * Block-UUID: a7b8c9d0-e1f2-3456-abcd-456789012345
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Normalizes note strings, slugs, arrays, globs, and generated keywords.
+ * Block-UUID: 962209ad-9824-4f7e-b002-f9cc01e12d9f
+ * Parent-UUID: a7b8c9d0-e1f2-3456-abcd-456789012345
+ * Version: 1.1.0
+ * Description: Normalizes note lifecycle status and supersedes fields.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -102,6 +102,8 @@ func normalizeDraft(n Note) Note {
 	n.Tags = normalizeSlugList(n.Tags)
 	n.GlobPatterns = normalizeStringList(n.GlobPatterns)
 	n.LinkedFiles = normalizeStringList(n.LinkedFiles)
+	n.Status = Status(slugify(string(n.Status)))
+	n.Supersedes = cleanString(n.Supersedes)
 	return n
 }
 
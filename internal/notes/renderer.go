@@ -2,14 +2,15 @@
  * Component: Notes Renderer
  * Block-UUID: c9d0e1f2-a3b4-5678-cdef-789012345678
  * Parent-UUID: N/A
- * Version: 1.1.0
+ * Version: 1.2.0
  * Description: Renders notes as human-readable tables, JSON, and detailed views.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
  * Updated-at: 2026-06-24T12:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0), terrchen (v1.1.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), terrchen (v1.1.0), agent (v1.2.0)
  * Changelog:
  *   v1.1.0 - Expand ShortID from 8 to 12 chars; replace TAGS column with TOPICS
+ *   v1.2.0 - Show lifecycle status, expiry, supersession, promotion, and history in note detail
  */
 
 
@@ -78,11 +79,35 @@ func RenderNoteDetail(n Note) string {
 	writeList(&sb, "Linked Files", n.LinkedFiles)
 	writeList(&sb, "Tags", n.Tags)
 	writeList(&sb, "Topics", formatTopics(n))
+	fmt.Fprintf(&sb, "Status: %s\n", statusDisplay(n, time.Now().UTC()))
+	if n.ExpiresAt != nil {
+		fmt.Fprintf(&sb, "Expires: %s\n", n.ExpiresAt.Format("2006-01-02T15:04:05Z"))
+	}
+	if n.Supersedes != "" {
+		fmt.Fprintf(&sb, "Supersedes: %s\n", n.Supersedes)
+	}
+	if n.PromotedTo != "" {
+		fmt.Fprintf(&sb, "Promoted to: %s\n", n.PromotedTo)
+	}
 	fmt.Fprintf(&sb, "Created: %s\n", n.CreatedAt.Format("2006-01-02T15:04:05Z"))
 	fmt.Fprintf(&sb, "Updated: %s\n", n.UpdatedAt.Format("2006-01-02T15:04:05Z"))
+	if len(n.Changelog) > 0 {
+		sb.WriteString("\nHistory:\n")
+		for _, entry := range n.Changelog {
+			fmt.Fprintf(&sb, "  %s  %s\n", entry.Timestamp.Format("2006-01-02T15:04:05Z"), entry.Message)
+		}
+	}
 	return sb.String()
 }
 
+// statusDisplay returns the lifecycle status, noting expiry for active notes.
+func statusDisplay(n Note, now time.Time) string {
+	if n.IsExpired(now) {
+		return fmt.Sprintf("%s (expired)", n.EffectiveStatus())
+	}
+	return string(n.EffectiveStatus())
+}
+
 func formatTopics(n Note) []string {
 	var topics []string
 	if n.Topic != "" {
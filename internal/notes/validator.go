@@ -1,12 +1,12 @@
 /**
  * Component: Notes Validator
- * Block-UUID: b8c9d0e1-f2a3-4567-bcde-567890123456
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Validates note shape, required fields, glob patterns, and bounded text.
+ * Block-UUID: 5628fb17-8706-4dda-ab63-4a6fff38e8d7
+ * Parent-UUID: b8c9d0e1-f2a3-4567-bcde-567890123456
+ * Version: 1.1.0
+ * Description: Added validation for note lifecycle status and supersedes.
  * Language: Go
  * Created-at: 2026-06-21T00:00:00Z
- * Authors: MiMo-v2.5-pro (v1.0.0)
+ * Authors: MiMo-v2.5-pro (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -88,6 +88,14 @@ func ValidateNote(n Note) []string {
 		errs = append(errs, "importance must be one of: low, medium, high")
 	}
 
+	// Validate lifecycle
+	if !IsValidStatus(n.Status) {
+		errs = append(errs, "status must be one of: active, pinned, archived")
+	}
+	if n.Supersedes != "" && n.Supersedes == n.ID {
+		errs = append(errs, "a note cannot supersede itself")
+	}
+
 	// Validate tags
 	for _, tag := range n.Tags {
 		if tag != slugify(tag) {
@@ -1,12 +1,12 @@
 /**
  * Component: Output Formatter
- * Block-UUID: 8fcb5580-1ca1-45fd-96e1-918713c3f1f2
- * Parent-UUID: 024969de-ce14-4b2c-9082-25bb43166c43
- * Version: 1.17.0
- * Description: Removed FormatContractInfo and FormatContractTest to resolve import cycle. These functions have been moved to internal/contract/manager.go.
+ * Block-UUID: 512c1dce-29a7-487f-951e-ce3593849146
+ * Parent-UUID: edc2bb29-10a9-4f81-813d-ac1b0e26f9fb
+ * Version: 1.19.0
+ * Description: FormatBridgeMarkdown writes output in the markdown format without wrapping it in a code block, for formatter output that carries its own blocks.
  * Language: Go
  * Created-at: 2026-03-26T20:42:52.215Z
- * Authors: GLM-4.7 (v1.0.0), Claude Haiku 4.5 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0), Gemini 3 Flash (v1.7.0), Gemini 3 Flash (v1.8.0), GLM-4.7 (v1.9.0), Gemini 3 Flash (v1.10.0), Gemini 3 Flash (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), GLM-4.7 (v1.17.0)
+ * Authors: GLM-4.7 (v1.0.0), Claude Haiku 4.5 (v1.1.0), GLM-4.7 (v1.2.0), GLM-4.7 (v1.3.0), GLM-4.7 (v1.4.0), GLM-4.7 (v1.5.0), GLM-4.7 (v1.6.0), Gemini 3 Flash (v1.7.0), Gemini 3 Flash (v1.8.0), GLM-4.7 (v1.9.0), Gemini 3 Flash (v1.10.0), Gemini 3 Flash (v1.11.0), GLM-4.7 (v1.12.0), GLM-4.7 (v1.13.0), GLM-4.7 (v1.14.0), GLM-4.7 (v1.15.0), GLM-4.7 (v1.16.0), GLM-4.7 (v1.17.0), agent (v1.18.0), agent (v1.19.0)
  */
 
 
@@ -151,11 +151,12 @@ func IsTerminal() bool {
 	return isatty.IsTerminal(os.Stdout.Fd())
 }
 
-// FormatBridgeMarkdown constructs the Markdown message for the CLI Bridge.
-func FormatBridgeMarkdown(command string, duration time.Duration, dbName string, format string, output string, exitCode int) string {
+// FormatBridgeHeader renders the title and property table that open a bridge
+// message, without the output block.
+func FormatBridgeHeader(title string, command string, duration time.Duration, dbName string, format string, exitCode int) string {
 	var sb strings.Builder
 
-	sb.WriteString("## GSC CLI Output\n\n")
+	sb.WriteString(fmt.Sprintf("## %s\n\n", title))
 	sb.WriteString("| Property | Value |\n")
 	sb.WriteString("| :--- | :--- |\n")
 	sb.WriteString(fmt.Sprintf("| **Command** | `%s` |\n", command))
@@ -178,6 +179,24 @@ func FormatBridgeMarkdown(command string, duration time.Duration, dbName string,
 	sb.WriteString(fmt.Sprintf("| **Format** | %s |\n", strings.ToUpper(format)))
 	sb.WriteString("\n")
 
+	return sb.String()
+}
+
+// FormatBridgeMarkdown constructs the Markdown message for the CLI Bridge.
+func FormatBridgeMarkdown(command string, duration time.Duration, dbName string, format string, output string, exitCode int) string {
+	var sb strings.Builder
+
+	sb.WriteString(FormatBridgeHeader("GSC CLI Output", command, duration, dbName, format, exitCode))
+
+	// Formatter output is already Markdown and carries its own code blocks
+	if strings.ToLower(format) == "markdown" {
+		sb.WriteString(output)
+		if !strings.HasSuffix(output, "\n") {
+			sb.WriteString("\n")
+		}
+		return sb.String()
+	}
+
 	lang := "text"
 	if strings.ToLower(format) == "json" {
 		lang = "json"
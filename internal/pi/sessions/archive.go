@@ -0,0 +1,375 @@
+/**
+ * Component: Session Archive Bundles
+ * Block-UUID: bd0cf0a3-060a-4825-ac1d-40f156268605
+ * Parent-UUID: 1723c238-c18e-4bb9-b747-938946eb0691
+ * Version: 1.2.0
+ * Description: Moves a session's raw transcript lines out of the mirror into a compressed per-session bundle and back; archived chats keep their derived rows (text, FTS, tool calls, file refs) and the usage fields of assistant messages (pi_archived_usage) while raw_line is emptied, and every line is checked against raw_hash on the way in and out.
+ * Language: Go
+ * Created-at: 2026-10-18T20:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0)
+ */
+
+package sessions
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const archiveBundleType = "gsc_session_archive"
+const archiveBundleVersion = 1
+
+// archiveManifest is the first line of a bundle. The raw lines follow in seq
+// order, one per line; SHA256 covers them joined with newlines.
+type archiveManifest struct {
+	Type        string `json:"type"`
+	Version     int    `json:"version"`
+	SessionID   string `json:"session_id"`
+	Runtime     string `json:"runtime"`
+	SessionFile string `json:"session_file"`
+	RawHeader   string `json:"raw_header"`
+	ArchivedAt  string `json:"archived_at"`
+	Lines       int    `json:"lines"`
+	SHA256      string `json:"sha256"`
+}
+
+type archivedMessage struct {
+	id      int64
+	seq     int
+	rawLine string
+	rawHash string
+}
+
+// archiveBundlePath is where GC writes a session's bundle.
+func archiveBundlePath(archiveDir string, sessionID string) string {
+	return filepath.Join(archiveDir, sessionID+".jsonl.gz")
+}
+
+func archiveLinesHash(lines []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// writeArchiveBundle writes the bundle through a temporary file so a crash
+// never leaves a truncated bundle at the final path.
+func writeArchiveBundle(path string, manifest archiveManifest, lines []string) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return 0, err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".archive-*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	manifest.Type = archiveBundleType
+	manifest.Version = archiveBundleVersion
+	manifest.Lines = len(lines)
+	manifest.SHA256 = archiveLinesHash(lines)
+	header, err := json.Marshal(manifest)
+	if err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	zw := gzip.NewWriter(tmp)
+	writer := bufio.NewWriter(zw)
+	writer.Write(header)
+	writer.WriteByte('\n')
+	for _, line := range lines {
+		writer.WriteString(line)
+		writer.WriteByte('\n')
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := zw.Close(); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return info.Size(), nil
+}
+
+// readArchiveBundle reads and checks a bundle written by writeArchiveBundle.
+func readArchiveBundle(path string) (archiveManifest, []string, error) {
+	var manifest archiveManifest
+	file, err := os.Open(path)
+	if err != nil {
+		return manifest, nil, err
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return manifest, nil, fmt.Errorf("read archive %s: %w", path, err)
+	}
+	defer zr.Close()
+	reader := bufio.NewReader(zr)
+
+	first, err := reader.ReadString('\n')
+	if err != nil {
+		return manifest, nil, fmt.Errorf("read archive %s: %w", path, err)
+	}
+	if err := json.Unmarshal([]byte(strings.TrimSuffix(first, "\n")), &manifest); err != nil || manifest.Type != archiveBundleType {
+		return manifest, nil, fmt.Errorf("%s is not a session archive", path)
+	}
+	if manifest.Version > archiveBundleVersion {
+		return manifest, nil, fmt.Errorf("%s: unsupported archive version %d", path, manifest.Version)
+	}
+	lines := make([]string, 0, manifest.Lines)
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			if line != "" {
+				return manifest, nil, fmt.Errorf("%s: truncated archive", path)
+			}
+			break
+		}
+		if err != nil {
+			return manifest, nil, fmt.Errorf("read archive %s: %w", path, err)
+		}
+		lines = append(lines, strings.TrimSuffix(line, "\n"))
+	}
+	if len(lines) != manifest.Lines || archiveLinesHash(lines) != manifest.SHA256 {
+		return manifest, nil, fmt.Errorf("%s: archive checksum mismatch", path)
+	}
+	return manifest, lines, nil
+}
+
+func loadArchivedMessages(ctx context.Context, database *sql.DB, chatID int64) ([]archivedMessage, error) {
+	rows, err := database.QueryContext(ctx, `
+		SELECT id, seq, raw_line, raw_hash FROM pi_messages
+		WHERE chat_id = ? ORDER BY seq`, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var messages []archivedMessage
+	for rows.Next() {
+		var message archivedMessage
+		if err := rows.Scan(&message.id, &message.seq, &message.rawLine, &message.rawHash); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+// archiveChat writes the chat's raw lines to a bundle, reads the bundle back,
+// and only then empties raw_line in the mirror. It returns the bundle size.
+func archiveChat(ctx context.Context, database *sql.DB, chatID int64, archivePath string) (int64, error) {
+	var manifest archiveManifest
+	var syncedSeq int
+	if err := database.QueryRowContext(ctx, `
+		SELECT uuid, runtime, session_file, raw_header, synced_seq FROM pi_chats WHERE id = ?`, chatID).Scan(
+		&manifest.SessionID, &manifest.Runtime, &manifest.SessionFile, &manifest.RawHeader, &syncedSeq); err != nil {
+		return 0, err
+	}
+	messages, err := loadArchivedMessages(ctx, database, chatID)
+	if err != nil {
+		return 0, err
+	}
+	lines := make([]string, len(messages))
+	var rawBytes int64
+	for i, message := range messages {
+		if hashText(message.rawLine) != message.rawHash {
+			return 0, fmt.Errorf("seq %d does not match its raw hash; re-sync the session before archiving", message.seq)
+		}
+		lines[i] = message.rawLine
+		rawBytes += int64(len(message.rawLine))
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	manifest.ArchivedAt = now
+	size, err := writeArchiveBundle(archivePath, manifest, lines)
+	if err != nil {
+		return 0, err
+	}
+	if _, check, err := readArchiveBundle(archivePath); err != nil || len(check) != len(lines) {
+		os.Remove(archivePath)
+		return 0, fmt.Errorf("verify archive: %v", err)
+	}
+
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	// Usage reports read these fields out of raw_line; keep them before it is emptied.
+	if _, err := tx.ExecContext(ctx, `
+		INSERT OR REPLACE INTO pi_archived_usage (message_id, chat_id, usage_line)
+		SELECT id, chat_id, json_object('message', json_object(
+			'id', json_extract(raw_line, '$.message.id'),
+			'role', json_extract(raw_line, '$.message.role'),
+			'stopReason', json_extract(raw_line, '$.message.stopReason'),
+			'usage', json(json_extract(raw_line, '$.message.usage'))))
+		FROM pi_messages
+		WHERE chat_id = ? AND json_valid(raw_line) AND json_extract(raw_line, '$.message.usage') IS NOT NULL`, chatID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE pi_messages SET raw_line = '' WHERE chat_id = ?`, chatID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE pi_chats SET archived_at = ?, archive_path = ? WHERE id = ?`, now, archivePath, chatID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO pi_sync_events (
+			chat_id, session_file, event_type, detected_at, old_size, new_size, old_seq, new_seq, note
+		) VALUES (?, ?, 'archived', ?, ?, ?, ?, ?, ?)`,
+		chatID, manifest.SessionFile, now, rawBytes, size, syncedSeq, syncedSeq, archivePath); err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return size, nil
+}
+
+// restoreArchivedChat puts the bundle's raw lines back into the mirror. Each
+// line must hash to the stored raw_hash of the message with the same seq, so
+// a stale or foreign bundle is rejected. The bundle is removed afterwards.
+func restoreArchivedChat(ctx context.Context, database *sql.DB, chatID int64, writeSource bool) (RestoredSession, error) {
+	var restored RestoredSession
+	var sessionFile string
+	var archivePath sql.NullString
+	if err := database.QueryRowContext(ctx, `
+		SELECT uuid, session_file, archive_path FROM pi_chats WHERE id = ?`, chatID).Scan(
+		&restored.SessionID, &sessionFile, &archivePath); err != nil {
+		return restored, err
+	}
+	if !archivePath.Valid || archivePath.String == "" {
+		return restored, fmt.Errorf("session %s is not archived", restored.SessionID)
+	}
+	restored.ArchivePath = archivePath.String
+	manifest, lines, err := readArchiveBundle(archivePath.String)
+	if err != nil {
+		return restored, err
+	}
+	if manifest.SessionID != restored.SessionID {
+		return restored, fmt.Errorf("%s belongs to session %s", archivePath.String, manifest.SessionID)
+	}
+	messages, err := loadArchivedMessages(ctx, database, chatID)
+	if err != nil {
+		return restored, err
+	}
+	if len(messages) != len(lines) {
+		return restored, fmt.Errorf("%s has %d lines, mirror has %d", archivePath.String, len(lines), len(messages))
+	}
+	for i, message := range messages {
+		if hashText(lines[i]) != message.rawHash {
+			return restored, fmt.Errorf("%s: seq %d does not match the mirror", archivePath.String, message.seq)
+		}
+	}
+
+	if writeSource {
+		if _, err := os.Stat(sessionFile); os.IsNotExist(err) {
+			if err := writeRestoredSource(sessionFile, manifest, lines); err != nil {
+				return restored, err
+			}
+			restored.SourceWritten = true
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return restored, err
+	}
+	defer tx.Rollback()
+	for i, message := range messages {
+		if _, err := tx.ExecContext(ctx, `UPDATE pi_messages SET raw_line = ? WHERE id = ?`, lines[i], message.id); err != nil {
+			return restored, err
+		}
+	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM pi_archived_usage WHERE chat_id = ?`, chatID); err != nil {
+		return restored, err
+	}
+	if _, err := tx.ExecContext(ctx, `UPDATE pi_chats SET archived_at = NULL, archive_path = NULL WHERE id = ?`, chatID); err != nil {
+		return restored, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO pi_sync_events (chat_id, session_file, event_type, detected_at, note)
+		VALUES (?, ?, 'unarchived', ?, ?)`, chatID, sessionFile, now, archivePath.String); err != nil {
+		return restored, err
+	}
+	if err := tx.Commit(); err != nil {
+		return restored, err
+	}
+	restored.Lines = len(lines)
+	if err := os.Remove(archivePath.String); err != nil && !os.IsNotExist(err) {
+		return restored, err
+	}
+	return restored, nil
+}
+
+// discardArchiveBundle removes a bundle that could not be restored once its
+// chat has been rebuilt from the source, and records the restore error as an
+// 'archive_discarded' sync event.
+func discardArchiveBundle(ctx context.Context, database *sql.DB, chatID int64, sessionFile string, archivePath string, cause error) error {
+	if err := os.Remove(archivePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("discard archive %s: %w", archivePath, err)
+	}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	_, err := database.ExecContext(ctx, `
+		INSERT INTO pi_sync_events (chat_id, session_file, event_type, detected_at, note)
+		VALUES (?, ?, 'archive_discarded', ?, ?)`, chatID, sessionFile, now, fmt.Sprintf("%s: %v", archivePath, cause))
+	return err
+}
+
+// writeRestoredSource recreates a session file that was deleted after it was
+// archived. Claude Code transcripts have no header line.
+func writeRestoredSource(path string, manifest archiveManifest, lines []string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	var content []string
+	if manifest.Runtime != RuntimeClaude {
+		content = append(content, manifest.RawHeader)
+	}
+	content = append(content, lines...)
+	if len(content) == 0 {
+		return nil
+	}
+	return os.WriteFile(path, []byte(strings.Join(content, "\n")+"\n"), 0600)
+}
+
+// archivedRawLines returns the raw lines of an archived chat from its bundle.
+func archivedRawLines(ctx context.Context, database *sql.DB, chatID int64) ([]string, bool, error) {
+	var archivePath sql.NullString
+	if err := database.QueryRowContext(ctx, `SELECT archive_path FROM pi_chats WHERE id = ?`, chatID).Scan(&archivePath); err != nil {
+		return nil, false, err
+	}
+	if !archivePath.Valid || archivePath.String == "" {
+		return nil, false, nil
+	}
+	_, lines, err := readArchiveBundle(archivePath.String)
+	if err != nil {
+		return nil, true, err
+	}
+	return lines, true, nil
+}
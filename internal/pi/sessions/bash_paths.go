@@ -0,0 +1,214 @@
+/**
+ * Component: Bash Command File Reference Heuristics
+ * Block-UUID: 248c01cd-8aad-41e1-93bd-f82b261b8166
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Guesses which files a shell command reads or writes (cat/sed/grep arguments, cp/mv/tee targets, redirections) so bash tool calls produce heuristic-confidence file references.
+ * Language: Go
+ * Created-at: 2026-10-18T14:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// bashPathRef is a file a shell command appears to touch.
+type bashPathRef struct {
+	path string
+	op   string // read, edit, or write
+}
+
+// bashReaders lists commands whose path arguments are read. The value is the
+// number of leading positional arguments that are not paths (patterns,
+// scripts, filters).
+var bashReaders = map[string]int{
+	"cat": 0, "head": 0, "tail": 0, "less": 0, "more": 0, "wc": 0, "nl": 0,
+	"sort": 0, "uniq": 0, "diff": 0, "cmp": 0, "stat": 0, "file": 0,
+	"grep": 1, "egrep": 1, "rg": 1, "sed": 1, "awk": 1, "jq": 1,
+}
+
+// bashCommandPaths splits a command line into simple commands and returns the
+// file paths each one reads or writes. It is deliberately conservative: only
+// arguments that look like file paths are returned, and anything built from
+// variables, globs, or substitutions is ignored.
+func bashCommandPaths(command string) []bashPathRef {
+	var refs []bashPathRef
+	for _, words := range splitShellCommands(command) {
+		refs = append(refs, simpleCommandPaths(words)...)
+	}
+	return refs
+}
+
+func simpleCommandPaths(words []string) []bashPathRef {
+	var refs []bashPathRef
+	var args []string
+	for i := 0; i < len(words); i++ {
+		switch words[i] {
+		case ">", ">>":
+			if i+1 < len(words) {
+				refs = appendPathRef(refs, words[i+1], "write")
+				i++
+			}
+		case "<":
+			if i+1 < len(words) {
+				refs = appendPathRef(refs, words[i+1], "read")
+				i++
+			}
+		default:
+			args = append(args, words[i])
+		}
+	}
+
+	// Skip env assignments and wrappers to reach the program name.
+	for len(args) > 0 && (strings.Contains(args[0], "=") || args[0] == "sudo" || args[0] == "command" || args[0] == "time") {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return refs
+	}
+	program := filepath.Base(args[0])
+	inPlace := false
+	var positional []string
+	for _, arg := range args[1:] {
+		if strings.HasPrefix(arg, "-") {
+			if arg == "-i" || strings.HasPrefix(arg, "-i.") || arg == "--in-place" {
+				inPlace = true
+			}
+			continue
+		}
+		positional = append(positional, arg)
+	}
+
+	switch program {
+	case "touch", "tee":
+		for _, arg := range positional {
+			refs = appendPathRef(refs, arg, "write")
+		}
+	case "cp", "mv":
+		if len(positional) >= 2 {
+			for _, arg := range positional[:len(positional)-1] {
+				refs = appendPathRef(refs, arg, "read")
+			}
+			refs = appendPathRef(refs, positional[len(positional)-1], "write")
+		}
+	default:
+		skip, ok := bashReaders[program]
+		if !ok {
+			return refs
+		}
+		op := "read"
+		if inPlace && program == "sed" {
+			op = "edit"
+		}
+		if skip > len(positional) {
+			skip = len(positional)
+		}
+		for _, arg := range positional[skip:] {
+			refs = appendPathRef(refs, arg, op)
+		}
+	}
+	return refs
+}
+
+func appendPathRef(refs []bashPathRef, word string, op string) []bashPathRef {
+	if !looksLikeFilePath(word) {
+		return refs
+	}
+	return append(refs, bashPathRef{path: word, op: op})
+}
+
+// looksLikeFilePath accepts words with a directory separator or a file
+// extension and rejects devices, URLs, globs, and shell expansions.
+func looksLikeFilePath(word string) bool {
+	if word == "" || word == "." || word == ".." || strings.HasPrefix(word, "/dev/") {
+		return false
+	}
+	if strings.ContainsAny(word, "*?[]{}$`()<>|&;") || strings.Contains(word, "://") {
+		return false
+	}
+	if strings.Contains(word, "/") {
+		return true
+	}
+	ext := filepath.Ext(word)
+	return len(ext) > 1 && len(ext) <= 10 && ext != word
+}
+
+// splitShellCommands tokenizes a command line into simple commands. Quotes and
+// backslashes are honoured; &&, ||, ;, |, & and newlines separate commands;
+// redirection operators are emitted as their own words.
+func splitShellCommands(command string) [][]string {
+	var commands [][]string
+	var words []string
+	var word strings.Builder
+	inWord := false
+	flushWord := func() {
+		if inWord {
+			words = append(words, word.String())
+			word.Reset()
+			inWord = false
+		}
+	}
+	flushCommand := func() {
+		flushWord()
+		if len(words) > 0 {
+			commands = append(commands, words)
+			words = nil
+		}
+	}
+
+	runes := []rune(command)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes):
+			i++
+			word.WriteRune(runes[i])
+			inWord = true
+		case r == '\'' || r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != r {
+				if r == '"' && runes[end] == '\\' && end+1 < len(runes) {
+					end++
+				}
+				end++
+			}
+			word.WriteString(string(runes[i+1 : min(end, len(runes))]))
+			inWord = true
+			i = end
+		case r == ' ' || r == '\t':
+			flushWord()
+		case r == '\n' || r == ';' || r == '|' || r == '&':
+			flushCommand()
+		case r == '>' || r == '<':
+			// A bare file descriptor (2>) belongs to the operator.
+			if inWord && strings.Trim(word.String(), "0123456789") == "" {
+				word.Reset()
+				inWord = false
+			}
+			flushWord()
+			op := string(r)
+			if r == '>' && i+1 < len(runes) && runes[i+1] == '>' {
+				op = ">>"
+				i++
+			}
+			if i+1 < len(runes) && runes[i+1] == '&' {
+				// 2>&1 duplicates a descriptor; skip the target.
+				i++
+				for i+1 < len(runes) && runes[i+1] >= '0' && runes[i+1] <= '9' {
+					i++
+				}
+				continue
+			}
+			words = append(words, op)
+		default:
+			word.WriteRune(r)
+			inWord = true
+		}
+	}
+	flushCommand()
+	return commands
+}
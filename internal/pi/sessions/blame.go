@@ -0,0 +1,382 @@
+/**
+ * Component: Per-File Agent Activity History
+ * Block-UUID: 9c4d1e38-6b72-4a05-a3f9-1d8e5c7b2064
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Builds a blame-style timeline for one file from pi_file_refs: which sessions read or modified it, the prompt behind each tool call, the call arguments, git commits matched by time window and added content, and an optional mapping of edits onto current line ranges.
+ * Language: Go
+ * Created-at: 2026-10-18T16:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/db"
+	"github.com/gitsense/gsc-cli/internal/git"
+)
+
+// DefaultBlameCommitWindow is how long after an edit a commit still counts as
+// capturing it when matched by time alone.
+const DefaultBlameCommitWindow = 4 * time.Hour
+
+// ResolveBlameTarget turns a user-supplied path into the absolute path, repo
+// root, and repo-relative path used to match pi_file_refs rows.
+func ResolveBlameTarget(path string) (absPath string, repoRoot string, filePathRel string, err error) {
+	absPath, err = filepath.Abs(path)
+	if err != nil {
+		return "", "", "", err
+	}
+	repoRoot = findRepoRoot(filepath.Dir(absPath))
+	if repoRoot != "" {
+		filePathRel = normalizePath(absPath, "", repoRoot).filePathRel
+	}
+	return absPath, repoRoot, filePathRel, nil
+}
+
+// Blame returns the agent activity history for one file, oldest first.
+func Blame(ctx context.Context, options BlameOptions) (*BlameResult, error) {
+	if options.DBPath == "" {
+		return nil, fmt.Errorf("db path is required")
+	}
+	if options.AbsPath == "" {
+		return nil, fmt.Errorf("file path is required")
+	}
+	database, err := OpenQueryMirror(options.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.CloseDB(database)
+
+	events, err := loadBlameEvents(ctx, database, options)
+	if err != nil {
+		return nil, err
+	}
+	result := &BlameResult{
+		AbsPath:     options.AbsPath,
+		RepoRoot:    options.RepoRoot,
+		FilePathRel: options.FilePathRel,
+		Events:      events,
+	}
+
+	if options.Commits && options.RepoRoot != "" && options.FilePathRel != "" {
+		window := options.CommitWindow
+		if window <= 0 {
+			window = DefaultBlameCommitWindow
+		}
+		if err := matchBlameCommits(ctx, options.RepoRoot, options.FilePathRel, result.Events, window); err != nil {
+			return nil, err
+		}
+	}
+	if options.Lines {
+		content, err := os.ReadFile(options.AbsPath)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", options.AbsPath, err)
+		}
+		result.Lines, result.UnplacedEdits = mapBlameLines(string(content), result.Events)
+	}
+	if options.Limit > 0 && len(result.Events) > options.Limit {
+		result.Events = result.Events[len(result.Events)-options.Limit:]
+	}
+	return result, nil
+}
+
+func loadBlameEvents(ctx context.Context, database *sql.DB, options BlameOptions) ([]BlameEvent, error) {
+	query := `
+		SELECT c.id, c.uuid, c.runtime, COALESCE(c.name, ''), COALESCE(c.first_user_text, ''),
+		       r.entry_id, COALESCE(r.tool_call_id, ''), COALESCE(r.tool_name, ''), r.op, r.confidence, r.timestamp,
+		       COALESCE(t.arguments_json, ''), COALESCE(t.is_error, 0)
+		FROM pi_file_refs r
+		JOIN pi_chats c ON c.id = r.chat_id
+		LEFT JOIN pi_tool_calls t ON t.chat_id = r.chat_id AND t.tool_call_id = r.tool_call_id
+		WHERE c.file_deleted_at IS NULL AND r.source = 'tool_call'`
+	var args []interface{}
+	if options.RepoRoot != "" && options.FilePathRel != "" {
+		query += " AND (r.abs_path = ? OR (r.repo_root = ? AND r.file_path_rel = ?))"
+		args = append(args, options.AbsPath, options.RepoRoot, options.FilePathRel)
+	} else {
+		query += " AND r.abs_path = ?"
+		args = append(args, options.AbsPath)
+	}
+	if options.EditsOnly {
+		query += " AND r.op IN ('edit', 'write')"
+	}
+	if options.Runtime != "" {
+		query += " AND c.runtime = ?"
+		args = append(args, options.Runtime)
+	}
+	if options.Since != "" {
+		query += " AND r.timestamp >= ?"
+		args = append(args, options.Since)
+	}
+	if options.Until != "" {
+		query += " AND r.timestamp <= ?"
+		args = append(args, options.Until)
+	}
+	query += " ORDER BY r.timestamp, r.id"
+
+	rows, err := database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	type blameRow struct {
+		chatID int64
+		event  BlameEvent
+	}
+	var loaded []blameRow
+	for rows.Next() {
+		var row blameRow
+		var name, firstUser string
+		var isError int
+		e := &row.event
+		if err := rows.Scan(&row.chatID, &e.SessionID, &e.Runtime, &name, &firstUser,
+			&e.EntryID, &e.ToolCallID, &e.ToolName, &e.Op, &e.Confidence, &e.Timestamp,
+			&e.ArgumentsJSON, &isError); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		e.SessionTitle = sessionTitle(name, firstUser)
+		e.IsError = isError != 0
+		loaded = append(loaded, row)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+
+	events := make([]BlameEvent, 0, len(loaded))
+	for _, row := range loaded {
+		prompt, err := promptForEntry(ctx, database, row.chatID, row.event.EntryID)
+		if err != nil {
+			return nil, err
+		}
+		row.event.Prompt = prompt
+		events = append(events, row.event)
+	}
+	return events, nil
+}
+
+// promptForEntry walks the parent chain from a tool-call entry back to the
+// nearest user message, so the prompt is correct even on branched sessions.
+func promptForEntry(ctx context.Context, database *sql.DB, chatID int64, entryID string) (string, error) {
+	const q = `
+		WITH RECURSIVE chain(entry_id, parent_entry_id, role, text, depth) AS (
+			SELECT entry_id, parent_entry_id, role, text, 0
+			FROM pi_messages WHERE chat_id = ? AND entry_id = ?
+			UNION ALL
+			SELECT m.entry_id, m.parent_entry_id, m.role, m.text, chain.depth + 1
+			FROM pi_messages m
+			JOIN chain ON m.chat_id = ? AND m.entry_id = chain.parent_entry_id
+			WHERE chain.depth < 10000
+		)
+		SELECT COALESCE(text, '') FROM chain
+		WHERE role = 'user' AND COALESCE(text, '') != ''
+		ORDER BY depth LIMIT 1`
+	var prompt string
+	err := database.QueryRowContext(ctx, q, chatID, entryID, chatID).Scan(&prompt)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	return prompt, err
+}
+
+// matchBlameCommits attaches to each edit or write the commits that touched
+// the file within window after it. A commit is a content match when it added a
+// substantial line the edit wrote.
+func matchBlameCommits(ctx context.Context, repoRoot string, relPath string, events []BlameEvent, window time.Duration) error {
+	var first, last time.Time
+	for _, event := range events {
+		if event.Op != "edit" && event.Op != "write" {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, event.Timestamp)
+		if err != nil {
+			continue
+		}
+		if first.IsZero() || ts.Before(first) {
+			first = ts
+		}
+		if ts.After(last) {
+			last = ts
+		}
+	}
+	if first.IsZero() {
+		return nil
+	}
+	commits, err := git.FileCommits(ctx, repoRoot, relPath, first.Add(-time.Minute), last.Add(window))
+	if err != nil {
+		return err
+	}
+
+	added := map[string]map[string]bool{}
+	addedLines := func(hash string) map[string]bool {
+		if lines, ok := added[hash]; ok {
+			return lines
+		}
+		lines := map[string]bool{}
+		if raw, err := git.CommitAddedLines(ctx, repoRoot, hash, relPath); err == nil {
+			for _, line := range raw {
+				if isSubstantialLine(line) {
+					lines[strings.TrimSpace(line)] = true
+				}
+			}
+		}
+		added[hash] = lines
+		return lines
+	}
+
+	for i := range events {
+		event := &events[i]
+		if event.Op != "edit" && event.Op != "write" {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, event.Timestamp)
+		if err != nil {
+			continue
+		}
+		written := editedTexts(event.ArgumentsJSON)
+		// git log is newest first; report the earliest matching commit first.
+		for j := len(commits) - 1; j >= 0; j-- {
+			commit := commits[j]
+			if commit.AuthorTime.Before(ts.Add(-time.Minute)) || commit.AuthorTime.After(ts.Add(window)) {
+				continue
+			}
+			match := "time"
+			if textsOverlap(written, addedLines(commit.Hash)) {
+				match = "content"
+			}
+			event.Commits = append(event.Commits, BlameCommit{
+				Hash:       commit.Hash,
+				AuthorTime: commit.AuthorTime.Format(time.RFC3339),
+				Author:     commit.Author,
+				Subject:    commit.Subject,
+				Match:      match,
+			})
+		}
+	}
+	return nil
+}
+
+func textsOverlap(texts []string, lines map[string]bool) bool {
+	for _, text := range texts {
+		for _, line := range strings.Split(text, "\n") {
+			if isSubstantialLine(line) && lines[strings.TrimSpace(line)] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isSubstantialLine rejects blank lines and lone punctuation such as "}" that
+// would match almost any commit.
+func isSubstantialLine(line string) bool {
+	return len(strings.TrimSpace(line)) >= 4
+}
+
+// editedTexts returns the text an edit or write tool call put into the file:
+// new_string/newText (Claude Code and Pi edits, including multi-edit lists) or
+// content (writes).
+func editedTexts(argumentsJSON string) []string {
+	if argumentsJSON == "" {
+		return nil
+	}
+	var args struct {
+		NewString string `json:"new_string"`
+		NewText   string `json:"newText"`
+		Content   string `json:"content"`
+		Edits     []struct {
+			NewString string `json:"new_string"`
+			NewText   string `json:"newText"`
+		} `json:"edits"`
+	}
+	if json.Unmarshal([]byte(argumentsJSON), &args) != nil {
+		return nil
+	}
+	var texts []string
+	for _, text := range []string{args.NewString, args.NewText, args.Content} {
+		if text != "" {
+			texts = append(texts, text)
+		}
+	}
+	for _, edit := range args.Edits {
+		for _, text := range []string{edit.NewString, edit.NewText} {
+			if text != "" {
+				texts = append(texts, text)
+			}
+		}
+	}
+	return texts
+}
+
+// mapBlameLines attributes current lines to the latest edit whose written text
+// still appears verbatim in the file. Events are oldest first, so later edits
+// overwrite earlier ones. It returns the collapsed ranges and the number of
+// edits none of whose text could be placed.
+func mapBlameLines(content string, events []BlameEvent) ([]BlameLineRange, int) {
+	lineCount := strings.Count(content, "\n")
+	if !strings.HasSuffix(content, "\n") && content != "" {
+		lineCount++
+	}
+	owner := make([]int, lineCount+1) // 1-based event index; -1 means unattributed
+	for i := range owner {
+		owner[i] = -1
+	}
+
+	unplaced := 0
+	for i, event := range events {
+		if event.Op != "edit" && event.Op != "write" {
+			continue
+		}
+		placed := false
+		for _, text := range editedTexts(event.ArgumentsJSON) {
+			trimmed := strings.TrimRight(text, "\n")
+			if !isSubstantialLine(trimmed) {
+				continue
+			}
+			index := strings.Index(content, trimmed)
+			if index < 0 {
+				continue
+			}
+			start := strings.Count(content[:index], "\n") + 1
+			end := start + strings.Count(trimmed, "\n")
+			for line := start; line <= end && line <= lineCount; line++ {
+				owner[line] = i
+			}
+			placed = true
+		}
+		if !placed {
+			unplaced++
+		}
+	}
+
+	var ranges []BlameLineRange
+	for line := 1; line <= lineCount; line++ {
+		if owner[line] < 0 {
+			continue
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].End == line-1 && owner[line-1] == owner[line] {
+			ranges[n-1].End = line
+			continue
+		}
+		event := events[owner[line]]
+		ranges = append(ranges, BlameLineRange{
+			Start:      line,
+			End:        line,
+			SessionID:  event.SessionID,
+			ToolCallID: event.ToolCallID,
+			ToolName:   event.ToolName,
+			Timestamp:  event.Timestamp,
+			Prompt:     event.Prompt,
+		})
+	}
+	return ranges, unplaced
+}
@@ -0,0 +1,117 @@
+/**
+ * Component: Per-File Agent Activity History Tests
+ * Block-UUID: 4a6f2d85-3e19-4c70-9b2e-8d1c7f5a6e93
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Verifies blame timelines (prompts from the parent chain, tool arguments, edits-only filtering), commit matching by time window and added content, and mapping edits onto current line ranges.
+ * Language: Go
+ * Created-at: 2026-10-18T16:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var blameFixtureLines = []string{
+	`{"type":"session","version":3,"id":"019edff0-0000-7000-8000-00000000b001","timestamp":"2026-10-18T09:00:00.000Z","cwd":"/work/blame-demo"}`,
+	`{"type":"message","id":"b1","parentId":null,"timestamp":"2026-10-18T09:00:01.000Z","message":{"role":"user","content":[{"type":"text","text":"look at main.go"}]}}`,
+	`{"type":"message","id":"b2","parentId":"b1","timestamp":"2026-10-18T09:00:02.000Z","message":{"role":"assistant","content":[{"type":"toolCall","id":"call_r","name":"read","arguments":{"path":"main.go"}}]}}`,
+	`{"type":"message","id":"b3","parentId":"b2","timestamp":"2026-10-18T09:00:03.000Z","message":{"role":"toolResult","toolCallId":"call_r","toolName":"read","content":[{"type":"text","text":"package main"}]}}`,
+	`{"type":"message","id":"b4","parentId":"b3","timestamp":"2026-10-18T09:01:00.000Z","message":{"role":"user","content":[{"type":"text","text":"add a greeting"}]}}`,
+	`{"type":"message","id":"b5","parentId":"b4","timestamp":"2026-10-18T09:01:01.000Z","message":{"role":"assistant","content":[{"type":"toolCall","id":"call_e","name":"edit","arguments":{"path":"main.go","oldText":"func main() {}","newText":"func main() {\n\tfmt.Println(\"hello\")\n}"}}]}}`,
+}
+
+func TestBlameTimelineAndLines(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	sessionsDir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "sessions.sqlite3")
+	writeFixtureLines(t, filepath.Join(sessionsDir, "blame.jsonl"), blameFixtureLines)
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: sessionsDir, DBPath: dbPath}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	result, err := Blame(ctx, BlameOptions{DBPath: dbPath, AbsPath: "/work/blame-demo/main.go"})
+	if err != nil {
+		t.Fatalf("blame: %v", err)
+	}
+	if len(result.Events) != 2 {
+		t.Fatalf("events = %+v, want read and edit", result.Events)
+	}
+	read, edit := result.Events[0], result.Events[1]
+	if read.Op != "read" || read.Prompt != "look at main.go" {
+		t.Fatalf("read event = %+v", read)
+	}
+	if edit.Op != "edit" || edit.ToolCallID != "call_e" || edit.Prompt != "add a greeting" || edit.ArgumentsJSON == "" {
+		t.Fatalf("edit event = %+v", edit)
+	}
+
+	editsOnly, err := Blame(ctx, BlameOptions{DBPath: dbPath, AbsPath: "/work/blame-demo/main.go", EditsOnly: true})
+	if err != nil || len(editsOnly.Events) != 1 {
+		t.Fatalf("edits-only = %+v, %v", editsOnly, err)
+	}
+
+	current := "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n"
+	ranges, unplaced := mapBlameLines(current, result.Events)
+	if unplaced != 0 || len(ranges) != 1 || ranges[0].Start != 5 || ranges[0].End != 7 || ranges[0].ToolCallID != "call_e" {
+		t.Fatalf("ranges = %+v, unplaced = %d", ranges, unplaced)
+	}
+	if _, unplaced := mapBlameLines("package main\n", result.Events); unplaced != 1 {
+		t.Fatalf("unplaced = %d, want 1 after the edit was reverted", unplaced)
+	}
+}
+
+func TestMatchBlameCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Parallel()
+	repo := t.TempDir()
+	runGit := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=dev", "GIT_AUTHOR_EMAIL=dev@example.com",
+			"GIT_COMMITTER_NAME=dev", "GIT_COMMITTER_EMAIL=dev@example.com",
+			"GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, output)
+		}
+	}
+	commitFile := func(date string, content string, subject string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repo, "main.go"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		runGit(date, "add", "main.go")
+		runGit(date, "commit", "-q", "-m", subject)
+	}
+	runGit("2026-10-18T08:00:00Z", "init", "-q")
+	commitFile("2026-10-18T08:00:00Z", "package main\n", "initial")
+	commitFile("2026-10-18T09:30:00Z", "package main\n\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n", "Add greeting")
+	commitFile("2026-10-18T10:00:00Z", "package main\n\n// docs\nfunc main() {\n\tfmt.Println(\"hello\")\n}\n", "Document main")
+	commitFile("2026-10-19T09:00:00Z", "package main\n", "Much later")
+
+	events := []BlameEvent{{
+		Op:            "edit",
+		Timestamp:     "2026-10-18T09:01:01Z",
+		ArgumentsJSON: `{"path":"main.go","newText":"func main() {\n\tfmt.Println(\"hello\")\n}"}`,
+	}}
+	if err := matchBlameCommits(context.Background(), repo, "main.go", events, 4*time.Hour); err != nil {
+		t.Fatalf("match commits: %v", err)
+	}
+	commits := events[0].Commits
+	if len(commits) != 2 || commits[0].Subject != "Add greeting" || commits[0].Match != "content" ||
+		commits[1].Subject != "Document main" || commits[1].Match != "time" {
+		t.Fatalf("commits = %+v", commits)
+	}
+}
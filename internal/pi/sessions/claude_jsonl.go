@@ -0,0 +1,304 @@
+/**
+ * Component: Claude Code Transcript Parser
+ * Block-UUID: d86c1cf7-7fd9-4d9c-91c0-b8dd6a69bd7a
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Parses Claude Code project transcripts (~/.claude/projects/<project>/<session>.jsonl) losslessly into the entry shapes used by the Pi importer, mapping tool_use/tool_result blocks onto tool calls and results.
+ * Language: Go
+ * Created-at: 2026-10-18T14:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// claudeLine is one line of a Claude Code transcript. Unlike Pi, there is no
+// header line: session identity comes from the file name and the working
+// directory from the first line that records one.
+type claudeLine struct {
+	Type             string          `json:"type"`
+	UUID             string          `json:"uuid"`
+	ParentUUID       *string         `json:"parentUuid"`
+	SessionID        string          `json:"sessionId"`
+	Timestamp        string          `json:"timestamp"`
+	CWD              string          `json:"cwd"`
+	Summary          string          `json:"summary"`
+	Content          json.RawMessage `json:"content"`
+	IsCompactSummary bool            `json:"isCompactSummary"`
+	Message          *claudeMessage  `json:"message"`
+}
+
+type claudeMessage struct {
+	Role    string          `json:"role"`
+	Model   string          `json:"model"`
+	Content json.RawMessage `json:"content"`
+}
+
+type claudeBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text"`
+	Thinking  string          `json:"thinking"`
+	ID        string          `json:"id"`
+	Name      string          `json:"name"`
+	Input     json.RawMessage `json:"input"`
+	ToolUseID string          `json:"tool_use_id"`
+	Content   json.RawMessage `json:"content"`
+	IsError   bool            `json:"is_error"`
+}
+
+// toolResultBlock is one tool_result carried by a Claude user line. A single
+// line may answer several parallel tool calls.
+type toolResultBlock struct {
+	toolCallID string
+	isError    bool
+	text       string
+}
+
+// claudeHeader is the synthetic header stored in pi_chats.raw_header.
+type claudeHeader struct {
+	Type      string `json:"type"`
+	Runtime   string `json:"runtime"`
+	UUID      string `json:"id"`
+	Timestamp string `json:"timestamp,omitempty"`
+	CWD       string `json:"cwd,omitempty"`
+}
+
+// parseClaudeHeader derives a session header from the file name and the first
+// line that records a cwd. It consumes no bytes: every line is an entry.
+func parseClaudeHeader(path string) (sessionHeader, int64, error) {
+	id := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if id == "" {
+		return sessionHeader{}, 0, fmt.Errorf("invalid Claude transcript name")
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return sessionHeader{}, 0, err
+	}
+	defer file.Close()
+
+	header := claudeHeader{Type: "session", Runtime: RuntimeClaude, UUID: id}
+	reader := bufio.NewReaderSize(file, 1024*1024)
+	for {
+		line, _, complete, err := readCompleteLine(reader)
+		if err != nil {
+			return sessionHeader{}, 0, err
+		}
+		if !complete {
+			break
+		}
+		var parsed claudeLine
+		if json.Unmarshal([]byte(line), &parsed) != nil {
+			continue
+		}
+		if header.Timestamp == "" {
+			header.Timestamp = parsed.Timestamp
+		}
+		if parsed.CWD != "" {
+			header.CWD = parsed.CWD
+			break
+		}
+	}
+	raw, err := json.Marshal(header)
+	if err != nil {
+		return sessionHeader{}, 0, err
+	}
+	return sessionHeader{
+		Type:      header.Type,
+		UUID:      header.UUID,
+		Timestamp: header.Timestamp,
+		CWD:       header.CWD,
+		RawLine:   string(raw),
+	}, 0, nil
+}
+
+func parseClaudeFile(path string) (parsedSession, error) {
+	header, _, err := parseClaudeHeader(path)
+	if err != nil {
+		return parsedSession{}, err
+	}
+	entries, consumed, partial, err := parseClaudeTail(path, 0, 0)
+	if err != nil {
+		return parsedSession{}, err
+	}
+	return parsedSession{
+		header:           header,
+		entries:          entries,
+		syncedByteOffset: consumed,
+		hasPartialTail:   partial,
+	}, nil
+}
+
+func parseClaudeTail(path string, offset int64, seq int) ([]parsedEntry, int64, bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, offset, false, err
+	}
+	defer file.Close()
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, offset, false, err
+	}
+	reader := bufio.NewReaderSize(file, 1024*1024)
+	var entries []parsedEntry
+	var consumed int64
+	for {
+		line, lineBytes, complete, err := readCompleteLine(reader)
+		if err != nil {
+			return nil, offset + consumed, false, err
+		}
+		if !complete {
+			return entries, offset + consumed, line != "", nil
+		}
+		consumed += lineBytes
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		var parsed claudeLine
+		if err := json.Unmarshal([]byte(line), &parsed); err != nil {
+			return nil, offset + consumed, false, fmt.Errorf("parse entry seq %d: %w", seq, err)
+		}
+		entry, err := convertClaudeLine(parsed, seq)
+		if err != nil {
+			return nil, offset + consumed, false, fmt.Errorf("parse entry seq %d: %w", seq, err)
+		}
+		entry.RawLine = line
+		entry.RawHash = hashText(line)
+		entry.Seq = seq
+		entries = append(entries, entry)
+		seq++
+	}
+}
+
+// convertClaudeLine maps a transcript line onto the Pi entry model:
+//   - user/assistant lines become "message" entries; tool_use blocks become
+//     toolCall blocks and user lines that only carry tool_result blocks become
+//     toolResult messages, so pairing and file-ref extraction are shared
+//   - compact summaries become "compaction" entries
+//   - summary lines become "session_info" entries that name the session
+//   - system lines keep their type and text; other bookkeeping lines (queue
+//     operations, snapshots, modes) keep their type without text so prompts
+//     are not indexed twice
+//
+// Lines without a uuid get a stable synthetic id derived from their sequence.
+func convertClaudeLine(line claudeLine, seq int) (parsedEntry, error) {
+	entry := parsedEntry{
+		Type:      line.Type,
+		ID:        line.UUID,
+		ParentID:  line.ParentUUID,
+		Timestamp: line.Timestamp,
+	}
+	if entry.ID == "" {
+		entry.ID = fmt.Sprintf("line-%d", seq)
+	}
+
+	switch line.Type {
+	case "user", "assistant":
+		entry.Type = "message"
+		if line.Message == nil {
+			return entry, nil
+		}
+		message, results, err := convertClaudeMessage(line.Message)
+		if err != nil {
+			return entry, err
+		}
+		if line.IsCompactSummary {
+			entry.Type = "compaction"
+			message.Role = ""
+		}
+		entry.Message = message
+		entry.toolResults = results
+	case "summary":
+		entry.Type = "session_info"
+		entry.Name = line.Summary
+		entry.Message = textPayload(line.Summary)
+	case "system":
+		var text string
+		if json.Unmarshal(line.Content, &text) == nil {
+			entry.Message = textPayload(text)
+		}
+	}
+	return entry, nil
+}
+
+func convertClaudeMessage(message *claudeMessage) (*messagePayload, []toolResultBlock, error) {
+	payload := &messagePayload{Role: message.Role, Model: message.Model}
+	if message.Role == "assistant" {
+		payload.Provider = "anthropic"
+	}
+
+	var text string
+	if json.Unmarshal(message.Content, &text) == nil {
+		payload.Content = []contentBlock{{Type: "text", Text: text}}
+		return payload, nil, nil
+	}
+	var blocks []claudeBlock
+	if len(message.Content) > 0 {
+		if err := json.Unmarshal(message.Content, &blocks); err != nil {
+			return nil, nil, fmt.Errorf("parse message content: %w", err)
+		}
+	}
+
+	var results []toolResultBlock
+	hasText := false
+	for _, block := range blocks {
+		switch block.Type {
+		case "text":
+			payload.Content = append(payload.Content, contentBlock{Type: "text", Text: block.Text})
+			hasText = true
+		case "thinking":
+			payload.Content = append(payload.Content, contentBlock{Type: "thinking", Thinking: block.Thinking})
+		case "tool_use":
+			payload.Content = append(payload.Content, contentBlock{Type: "toolCall", ID: block.ID, Name: block.Name, Arguments: block.Input})
+		case "tool_result":
+			results = append(results, toolResultBlock{
+				toolCallID: block.ToolUseID,
+				isError:    block.IsError,
+				text:       claudeResultText(block.Content),
+			})
+		}
+	}
+	if len(results) > 0 && !hasText {
+		payload.Role = "toolResult"
+		for _, result := range results {
+			if result.text != "" {
+				payload.Content = append(payload.Content, contentBlock{Type: "text", Text: result.text})
+			}
+		}
+	}
+	return payload, results, nil
+}
+
+// claudeResultText flattens tool_result content, which is either a string or
+// a list of blocks of which only text is kept.
+func claudeResultText(content json.RawMessage) string {
+	var text string
+	if json.Unmarshal(content, &text) == nil {
+		return text
+	}
+	var blocks []claudeBlock
+	if json.Unmarshal(content, &blocks) != nil {
+		return ""
+	}
+	var parts []string
+	for _, block := range blocks {
+		if block.Type == "text" && block.Text != "" {
+			parts = append(parts, block.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func textPayload(text string) *messagePayload {
+	if text == "" {
+		return nil
+	}
+	return &messagePayload{Content: []contentBlock{{Type: "text", Text: text}}}
+}
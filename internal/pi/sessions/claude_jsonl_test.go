@@ -0,0 +1,135 @@
+/**
+ * Component: Claude Code Transcript Import Tests
+ * Block-UUID: c7f38ea3-0133-40bd-9590-43347bf75c25
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Verifies Claude Code transcript import into the shared mirror: runtime tagging, tool_use/tool_result pairing, file references (including bash heuristics), append sync, lossless reconstruction, and runtime-scoped reconciliation.
+ * Language: Go
+ * Created-at: 2026-10-18T14:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const claudeFixtureUUID = "4b0c8d52-1f6e-4c1a-9d7e-000000000001"
+
+var claudeFixtureLines = []string{
+	`{"type":"summary","summary":"Fix the config loader","leafUuid":"a4"}`,
+	`{"type":"user","uuid":"a1","parentUuid":null,"sessionId":"4b0c8d52-1f6e-4c1a-9d7e-000000000001","timestamp":"2026-10-18T10:00:01.000Z","cwd":"/work/claude-demo","message":{"role":"user","content":"why does config.go panic?"}}`,
+	`{"type":"assistant","uuid":"a2","parentUuid":"a1","timestamp":"2026-10-18T10:00:02.000Z","cwd":"/work/claude-demo","message":{"role":"assistant","model":"claude-test","content":[{"type":"text","text":"Let me look."},{"type":"tool_use","id":"toolu_1","name":"Read","input":{"file_path":"/work/claude-demo/config.go"}},{"type":"tool_use","id":"toolu_2","name":"Bash","input":{"command":"cat go.mod && go test ./... > test.log 2>&1"}}]}}`,
+	`{"type":"user","uuid":"a3","parentUuid":"a2","timestamp":"2026-10-18T10:00:03.000Z","cwd":"/work/claude-demo","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"package config"},{"type":"tool_result","tool_use_id":"toolu_2","is_error":true,"content":[{"type":"text","text":"FAIL"}]}]}}`,
+}
+
+var claudeAppendLines = []string{
+	`{"type":"assistant","uuid":"a4","parentUuid":"a3","timestamp":"2026-10-18T10:00:04.000Z","cwd":"/work/claude-demo","message":{"role":"assistant","model":"claude-test","content":[{"type":"tool_use","id":"toolu_3","name":"MultiEdit","input":{"file_path":"config.go","edits":[]}}]}}`,
+	`{"type":"user","uuid":"a5","parentUuid":"a4","timestamp":"2026-10-18T10:00:05.000Z","cwd":"/work/claude-demo","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_3","content":"ok"}]}}`,
+}
+
+func TestSyncImportsClaudeTranscripts(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	projectsDir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "sessions.sqlite3")
+	sessionPath := filepath.Join(projectsDir, "-work-claude-demo", claudeFixtureUUID+".jsonl")
+	if err := os.MkdirAll(filepath.Dir(sessionPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixtureLines(t, sessionPath, claudeFixtureLines)
+
+	first, err := Sync(ctx, SyncOptions{SessionsDir: projectsDir, DBPath: dbPath, Runtime: RuntimeClaude})
+	if err != nil {
+		t.Fatalf("initial sync: %v", err)
+	}
+	if len(first.Errors) != 0 || first.MessagesImported != 4 || first.Runtime != RuntimeClaude {
+		t.Fatalf("initial result = %+v", first)
+	}
+
+	database := openIncrementalTestDB(t, dbPath)
+	defer database.Close()
+	var runtime, name, cwd, firstUser string
+	if err := database.QueryRow(`SELECT runtime, name, cwd, first_user_text FROM pi_chats WHERE uuid = ?`, claudeFixtureUUID).Scan(&runtime, &name, &cwd, &firstUser); err != nil {
+		t.Fatalf("query chat: %v", err)
+	}
+	if runtime != RuntimeClaude || name != "Fix the config loader" || cwd != "/work/claude-demo" || firstUser != "why does config.go panic?" {
+		t.Fatalf("chat = (%q, %q, %q, %q)", runtime, name, cwd, firstUser)
+	}
+
+	var resultEntry string
+	var isError int
+	if err := database.QueryRow(`SELECT result_entry_id, is_error FROM pi_tool_calls WHERE tool_call_id = 'toolu_2' AND tool_name = 'bash'`).Scan(&resultEntry, &isError); err != nil {
+		t.Fatalf("query bash tool call: %v", err)
+	}
+	if resultEntry != "a3" || isError != 1 {
+		t.Fatalf("bash result = (%q, %d)", resultEntry, isError)
+	}
+	if got := countRowsWhere(t, database, "pi_messages", "role = 'toolResult'"); got != 1 {
+		t.Fatalf("toolResult messages = %d, want 1", got)
+	}
+	if got := countRowsWhere(t, database, "pi_file_refs", "file_path_rel IS NULL AND op = 'read' AND confidence = 'high' AND abs_path = '/work/claude-demo/config.go'"); got != 1 {
+		t.Fatalf("Read file refs = %d, want 1", got)
+	}
+	if got := countRowsWhere(t, database, "pi_file_refs", "confidence = 'heuristic' AND tool_name = 'bash'"); got != 2 {
+		t.Fatalf("bash heuristic refs = %d, want 2 (go.mod read, test.log write)", got)
+	}
+
+	for _, line := range claudeAppendLines {
+		appendFixtureLine(t, sessionPath, line)
+	}
+	second, err := Sync(ctx, SyncOptions{SessionsDir: projectsDir, DBPath: dbPath, Runtime: RuntimeClaude})
+	if err != nil {
+		t.Fatalf("append sync: %v", err)
+	}
+	if len(second.Errors) != 0 || second.MessagesImported != 2 {
+		t.Fatalf("append result = %+v", second)
+	}
+	if err := database.QueryRow(`SELECT result_entry_id FROM pi_tool_calls WHERE tool_call_id = 'toolu_3'`).Scan(&resultEntry); err != nil || resultEntry != "a5" {
+		t.Fatalf("appended tool result = %q, %v", resultEntry, err)
+	}
+	if got := countRowsWhere(t, database, "pi_file_refs", "op = 'edit' AND tool_name = 'multiedit'"); got != 1 {
+		t.Fatalf("MultiEdit refs = %d, want 1", got)
+	}
+
+	report, err := VerifyLossless(ctx, database, claudeFixtureUUID)
+	if err != nil {
+		t.Fatalf("verify lossless: %v", err)
+	}
+	if !report.Match {
+		t.Fatalf("claude reconstruction mismatch: %+v", report)
+	}
+
+	// A Pi sync against another root must not treat Claude sessions as missing.
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: t.TempDir(), DBPath: dbPath}); err != nil {
+		t.Fatalf("pi sync: %v", err)
+	}
+	if got := countRowsWhere(t, database, "pi_chats", "runtime = 'claude' AND file_deleted_at IS NULL"); got != 1 {
+		t.Fatalf("live claude chats after pi sync = %d, want 1", got)
+	}
+}
+
+func TestBashCommandPaths(t *testing.T) {
+	tests := []struct {
+		command string
+		want    []bashPathRef
+	}{
+		{"cat internal/cli/root.go", []bashPathRef{{"internal/cli/root.go", "read"}}},
+		{"grep -n 'func main' cmd/gsc/main.go | head -5", []bashPathRef{{"cmd/gsc/main.go", "read"}}},
+		{"sed -i 's/a/b/' go.mod", []bashPathRef{{"go.mod", "edit"}}},
+		{"cp a.txt docs/b.txt", []bashPathRef{{"a.txt", "read"}, {"docs/b.txt", "write"}}},
+		{"go build ./... 2>/dev/null > build.log", []bashPathRef{{"build.log", "write"}}},
+		{"echo \"$HOME\" > ~/notes.md; ls src/*.go", []bashPathRef{{"~/notes.md", "write"}}},
+		{"git status", nil},
+	}
+	for _, tt := range tests {
+		if got := bashCommandPaths(tt.command); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("bashCommandPaths(%q) = %+v, want %+v", tt.command, got, tt.want)
+		}
+	}
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Database
- * Block-UUID: 5d20850f-7d10-4128-b086-bcf030b0d7bd
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Creates and resets the SQLite mirror used by gsc pi sessions.
+ * Block-UUID: d3c08e5b-608c-40b8-8a20-632764ef4d0b
+ * Parent-UUID: 56a088f7-8dc0-46f1-93a2-238e9d1e7977
+ * Version: 1.4.0
+ * Description: Creates and resets the SQLite mirror used by gsc pi sessions; migrates older mirrors by adding the pi_chats columns (runtime, archived_at, archive_path) and the pi_archived_usage table introduced since the first schema, both when syncing and when opening the mirror for queries.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0)
+ * Authors: Codex GPT-5 (v1.0.0), agent (v1.1.0), agent (v1.2.0), agent (v1.3.0), agent (v1.4.0)
  */
 
 
@@ -45,8 +45,9 @@ func openMirror(ctx context.Context, dbPath string) (*sql.DB, error) {
 	return database, nil
 }
 
-// OpenQueryMirror opens a read-only connection to the Pi sessions database.
-// Used by CLI commands that need to query without modifying.
+// OpenQueryMirror opens a query connection to the Pi sessions database.
+// Used by CLI commands that need to query without syncing. Mirrors created by
+// an older schema are migrated first, since the queries read the new columns.
 func OpenQueryMirror(dbPath string) (*sql.DB, error) {
 	if err := db.ValidateDBExists(dbPath); err != nil {
 		return nil, err
@@ -62,6 +63,10 @@ func OpenQueryMirror(dbPath string) (*sql.DB, error) {
 	}
 	database.SetMaxOpenConns(1)
 	database.SetMaxIdleConns(1)
+	if err := migrateSchema(context.Background(), database); err != nil {
+		database.Close()
+		return nil, fmt.Errorf("migrate pi sessions schema at %s: %w", dbPath, err)
+	}
 	return database, nil
 }
 
@@ -73,6 +78,70 @@ func createSchema(ctx context.Context, database *sql.DB) error {
 	if _, err := database.ExecContext(ctx, string(schema)); err != nil {
 		return fmt.Errorf("create pi sessions schema: %w", err)
 	}
+	if err := migrateSchema(ctx, database); err != nil {
+		return fmt.Errorf("migrate pi sessions schema: %w", err)
+	}
+	return nil
+}
+
+// chatColumnMigrations lists pi_chats columns added after the first schema,
+// in the order they were introduced. Existing rows take the column default.
+var chatColumnMigrations = []struct {
+	name       string
+	definition string
+}{
+	// Added when Claude Code transcripts were imported; existing rows are Pi sessions.
+	{"runtime", "TEXT NOT NULL DEFAULT 'pi'"},
+	// Added for gsc pi sessions gc; set while a chat's raw lines live in an archive bundle.
+	{"archived_at", "DATETIME"},
+	{"archive_path", "TEXT"},
+}
+
+// migrateSchema adds the pi_chats columns and the tables missing from mirrors
+// created by an older schema and records the current schema version.
+func migrateSchema(ctx context.Context, database *sql.DB) error {
+	rows, err := database.QueryContext(ctx, `SELECT name FROM pragma_table_info('pi_chats')`)
+	if err != nil {
+		return err
+	}
+	existing := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		existing[name] = true
+	}
+	if err := rows.Close(); err != nil {
+		return err
+	}
+	if len(existing) == 0 {
+		return nil // Not a sessions mirror; queries report their own error
+	}
+	for _, column := range chatColumnMigrations {
+		if existing[column.name] {
+			continue
+		}
+		if _, err := database.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE pi_chats ADD COLUMN %s %s`, column.name, column.definition)); err != nil {
+			return err
+		}
+	}
+	for _, statement := range []string{
+		`CREATE INDEX IF NOT EXISTS idx_pi_chats_runtime ON pi_chats(runtime, updated_at DESC)`,
+		// Added for gsc pi sessions gc; usage and archive queries join it.
+		`CREATE TABLE IF NOT EXISTS pi_archived_usage (
+			message_id INTEGER PRIMARY KEY,
+			chat_id INTEGER NOT NULL,
+			usage_line TEXT NOT NULL
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_pi_archived_usage_chat ON pi_archived_usage(chat_id)`,
+		`UPDATE pi_meta SET value = '3.6' WHERE key = 'schema_version' AND value < '3.6'`,
+	} {
+		if _, err := database.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,82 @@
+/**
+ * Component: Pi Sessions Database Tests
+ * Block-UUID: 5b7e2c41-9d3a-4f08-b6e1-27c9a4d8f310
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Tests that mirrors created by an older schema can be listed and reported on without a re-sync.
+ * Language: Go
+ * Created-at: 2026-10-18T22:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// createBaselineMirror writes a mirror with the 3.4 schema, from before the
+// runtime and archive columns, holding one chat.
+func createBaselineMirror(t *testing.T) string {
+	t.Helper()
+	schema, err := os.ReadFile(testDataPath("schema_v3.4.sql"))
+	if err != nil {
+		t.Fatalf("read baseline schema: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "sessions.db")
+	database, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("open baseline db: %v", err)
+	}
+	defer database.Close()
+	if _, err := database.Exec(string(schema)); err != nil {
+		t.Fatalf("create baseline schema: %v", err)
+	}
+	if _, err := database.Exec(`INSERT INTO pi_chats (uuid, cwd, session_file, raw_header, created_at, updated_at, last_message_at, message_count)
+		VALUES ('session-001', '/tmp/repo', '/tmp/session.jsonl', '{}', '2026-06-01T00:00:00Z', '2026-06-01T00:01:00Z', '2026-06-01T00:01:00Z', 2)`); err != nil {
+		t.Fatalf("insert baseline chat: %v", err)
+	}
+	return path
+}
+
+func TestQueryMirrorMigratesBaselineSchema(t *testing.T) {
+	path := createBaselineMirror(t)
+
+	results, err := List(context.Background(), ListOptions{DBPath: path})
+	if err != nil {
+		t.Fatalf("List() on a baseline mirror: %v", err)
+	}
+	if len(results) != 1 || results[0].SessionID != "session-001" || results[0].Runtime != "pi" {
+		t.Fatalf("List() = %+v, want session-001 with runtime pi", results)
+	}
+
+	database, err := OpenQueryMirror(path)
+	if err != nil {
+		t.Fatalf("reopen migrated mirror: %v", err)
+	}
+	defer database.Close()
+	var version string
+	if err := database.QueryRow(`SELECT value FROM pi_meta WHERE key = 'schema_version'`).Scan(&version); err != nil {
+		t.Fatalf("read schema version: %v", err)
+	}
+	if version != "3.6" {
+		t.Errorf("schema_version = %s, want 3.6", version)
+	}
+}
+
+func TestUsageOnBaselineMirror(t *testing.T) {
+	path := createBaselineMirror(t)
+
+	// Usage joins pi_archived_usage, which the baseline schema lacks
+	report, err := Usage(context.Background(), UsageOptions{DBPath: path})
+	if err != nil {
+		t.Fatalf("Usage() on a baseline mirror: %v", err)
+	}
+	if report.Total.Messages != 0 {
+		t.Errorf("Usage() counted %d messages, want 0", report.Total.Messages)
+	}
+}
@@ -0,0 +1,307 @@
+/**
+ * Component: Session Branch Diff
+ * Block-UUID: 7e2b9c54-3a1f-4d86-b0e7-5c9d2f8a4e13
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Compares two session branches (or two sessions) from their common ancestor: prompts and turns side by side, tool-call counts and errors, token usage and cost, and the files each branch touched with its final edit per file.
+ * Language: Go
+ * Created-at: 2026-10-18T19:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gitsense/gsc-cli/internal/db"
+)
+
+// ParseSessionRef splits "<session-id>[@<leaf-entry-id>]".
+func ParseSessionRef(ref string) (string, string) {
+	sessionID, leafID, _ := strings.Cut(ref, "@")
+	return sessionID, leafID
+}
+
+type diffBranch struct {
+	chatID   int64
+	side     DiffSide
+	messages []exportMessage
+}
+
+// Diff aligns two branches at their common ancestor (the longest shared
+// prefix of identical entries, which forks and in-session branches have) and
+// summarizes what each did afterwards.
+func Diff(ctx context.Context, options DiffOptions) (*SessionDiff, error) {
+	if options.DBPath == "" {
+		return nil, fmt.Errorf("db path is required")
+	}
+	if options.A == "" || options.B == "" {
+		return nil, fmt.Errorf("two sessions are required")
+	}
+	pricing := options.Pricing
+	if pricing == nil {
+		pricing = DefaultPricingTable()
+	}
+	database, err := OpenQueryMirror(options.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.CloseDB(database)
+
+	a, err := loadDiffBranch(ctx, database, options.A)
+	if err != nil {
+		return nil, err
+	}
+	b, err := loadDiffBranch(ctx, database, options.B)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SessionDiff{Currency: pricing.Currency}
+	for result.SharedEntries < len(a.messages) && result.SharedEntries < len(b.messages) {
+		ma, mb := a.messages[result.SharedEntries], b.messages[result.SharedEntries]
+		if ma.entryID != mb.entryID || ma.rawLine != mb.rawLine {
+			break
+		}
+		result.SharedEntries++
+	}
+	if result.SharedEntries > 0 {
+		result.CommonAncestor = a.messages[result.SharedEntries-1].entryID
+	}
+
+	filesA, err := summarizeDiffSide(ctx, database, a, result.SharedEntries, pricing, options)
+	if err != nil {
+		return nil, err
+	}
+	filesB, err := summarizeDiffSide(ctx, database, b, result.SharedEntries, pricing, options)
+	if err != nil {
+		return nil, err
+	}
+	result.A, result.B = a.side, b.side
+	result.Files = mergeDiffFiles(filesA, filesB)
+	return result, nil
+}
+
+func loadDiffBranch(ctx context.Context, database *sql.DB, ref string) (*diffBranch, error) {
+	sessionID, leafID := ParseSessionRef(ref)
+	branch := &diffBranch{}
+	var name, firstUser, currentLeaf sql.NullString
+	err := database.QueryRowContext(ctx, `
+		SELECT id, uuid, runtime, name, first_user_text, current_leaf_id
+		FROM pi_chats
+		WHERE uuid = ? AND file_deleted_at IS NULL`, sessionID).Scan(
+		&branch.chatID, &branch.side.SessionID, &branch.side.Runtime, &name, &firstUser, &currentLeaf)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session not found: %s", sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	branch.side.Title = sessionTitle(name.String, firstUser.String)
+
+	messages, err := loadExportMessages(ctx, database, branch.chatID)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("session %s has no entries", sessionID)
+	}
+	if leafID == "" {
+		leafID = defaultExportLeaf(messages, currentLeaf.String)
+	}
+	branch.side.Leaf = leafID
+	if branch.messages, err = exportBranch(messages, leafID); err != nil {
+		return nil, fmt.Errorf("%s: %w", sessionID, err)
+	}
+	return branch, nil
+}
+
+type diffToolCall struct {
+	name      string
+	isError   bool
+	arguments string
+}
+
+// summarizeDiffSide fills branch.side from the entries after the shared
+// prefix and returns the files that part of the branch touched.
+func summarizeDiffSide(ctx context.Context, database *sql.DB, branch *diffBranch, shared int, pricing *PricingTable, options DiffOptions) (map[string]*DiffFileSide, error) {
+	side := &branch.side
+	side.Models = []string{}
+	side.ToolCallsByName = map[string]int{}
+	side.Turns = []DiffTurn{}
+	after := branch.messages[shared:]
+	onSide := make(map[string]bool, len(after))
+	for _, message := range after {
+		onSide[message.entryID] = true
+	}
+	side.Entries = len(after)
+
+	calls, err := loadDiffToolCalls(ctx, database, branch.chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	models := map[string]bool{}
+	var turn *DiffTurn
+	for _, message := range after {
+		if side.Started == "" || message.timestamp < side.Started {
+			side.Started = message.timestamp
+		}
+		if message.timestamp > side.Ended {
+			side.Ended = message.timestamp
+		}
+		if message.role == "assistant" && message.model != "" && !models[message.model] {
+			models[message.model] = true
+			side.Models = append(side.Models, message.model)
+		}
+		entry, ok := exportEntry(side.Runtime, message, nil, false)
+		isPrompt := ok && entry.Role == "user" && entry.Text != ""
+		if isPrompt {
+			side.Prompts++
+		}
+		if turn == nil || isPrompt {
+			side.Turns = append(side.Turns, DiffTurn{EntryID: message.entryID})
+			turn = &side.Turns[len(side.Turns)-1]
+		}
+		if isPrompt {
+			turn.Prompt = entry.Text
+		} else if ok && entry.Role == "assistant" && entry.Text != "" {
+			turn.Reply = entry.Text
+		}
+		for _, call := range calls[message.entryID] {
+			side.ToolCalls++
+			side.ToolCallsByName[call.name]++
+			turn.ToolCalls++
+			if call.isError {
+				side.Errors++
+				turn.Errors++
+			}
+		}
+	}
+
+	samples, err := loadUsageSamples(ctx, database, UsageOptions{SessionID: side.SessionID})
+	if err != nil {
+		return nil, err
+	}
+	var branchSamples []usageSample
+	for _, sample := range samples {
+		if onSide[sample.entryID] {
+			branchSamples = append(branchSamples, sample)
+		}
+	}
+	side.Usage = aggregateUsage(branchSamples, UsageOptions{GroupBy: UsageGroupSession}, pricing).Total
+
+	return loadDiffFiles(ctx, database, branch.chatID, onSide, side.Turns, after, options.MaxDiffLines)
+}
+
+func loadDiffToolCalls(ctx context.Context, database *sql.DB, chatID int64) (map[string][]diffToolCall, error) {
+	rows, err := database.QueryContext(ctx, `
+		SELECT entry_id, tool_name, COALESCE(is_error, 0), arguments_json
+		FROM pi_tool_calls
+		WHERE chat_id = ?
+		ORDER BY seq, block_index`, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string][]diffToolCall{}
+	for rows.Next() {
+		var entryID string
+		var call diffToolCall
+		if err := rows.Scan(&entryID, &call.name, &call.isError, &call.arguments); err != nil {
+			return nil, err
+		}
+		out[entryID] = append(out[entryID], call)
+	}
+	return out, rows.Err()
+}
+
+// loadDiffFiles counts file references on the branch and keeps the last
+// edit or write per file. Edited paths are also recorded on the turn that
+// made the edit.
+func loadDiffFiles(ctx context.Context, database *sql.DB, chatID int64, onSide map[string]bool, turns []DiffTurn, after []exportMessage, maxDiffLines int) (map[string]*DiffFileSide, error) {
+	turnOf := map[string]int{}
+	turn := -1
+	for _, message := range after {
+		if turn+1 < len(turns) && turns[turn+1].EntryID == message.entryID {
+			turn++
+		}
+		turnOf[message.entryID] = turn
+	}
+
+	rows, err := database.QueryContext(ctx, `
+		SELECT r.entry_id, r.op, COALESCE(NULLIF(r.file_path_rel, ''), r.abs_path, r.raw_path),
+		       COALESCE(t.arguments_json, '')
+		FROM pi_file_refs r
+		LEFT JOIN pi_tool_calls t ON t.chat_id = r.chat_id AND t.tool_call_id = r.tool_call_id
+		WHERE r.chat_id = ? AND r.source = 'tool_call'
+		ORDER BY r.timestamp, r.id`, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	files := map[string]*DiffFileSide{}
+	for rows.Next() {
+		var entryID, op, path, arguments string
+		if err := rows.Scan(&entryID, &op, &path, &arguments); err != nil {
+			return nil, err
+		}
+		if !onSide[entryID] {
+			continue
+		}
+		file := files[path]
+		if file == nil {
+			file = &DiffFileSide{}
+			files[path] = file
+		}
+		switch op {
+		case "read":
+			file.Reads++
+			continue
+		case "edit":
+			file.Edits++
+		case "write":
+			file.Writes++
+		}
+		if texts := editedTexts(arguments); len(texts) > 0 {
+			file.finalText = strings.Join(texts, "\n")
+			file.FinalEdit, _ = truncateLines(toolCallDiff(arguments), maxDiffLines)
+		}
+		if i := turnOf[entryID]; i >= 0 && !containsString(turns[i].Edited, path) {
+			turns[i].Edited = append(turns[i].Edited, path)
+		}
+	}
+	return files, rows.Err()
+}
+
+func mergeDiffFiles(a, b map[string]*DiffFileSide) []DiffFile {
+	paths := map[string]bool{}
+	for path := range a {
+		paths[path] = true
+	}
+	for path := range b {
+		paths[path] = true
+	}
+	files := make([]DiffFile, 0, len(paths))
+	for path := range paths {
+		file := DiffFile{Path: path, A: a[path], B: b[path]}
+		file.SameFinal = file.A != nil && file.B != nil && file.A.finalText != "" && file.A.finalText == file.B.finalText
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+/**
+ * Component: Session Branch Diff Tests
+ * Block-UUID: 6f3a1c89-4e27-4d5b-9b08-2a7e5d1f3c96
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Verifies that two branches of one session are aligned at their common ancestor and compared by turns, tool calls, errors, token usage, and final edits per file.
+ * Language: Go
+ * Created-at: 2026-10-18T19:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+const diffFixtureUUID = "019edff0-0000-7000-8000-00000000e001"
+
+var diffFixtureLines = []string{
+	`{"type":"session","version":3,"id":"019edff0-0000-7000-8000-00000000e001","timestamp":"2026-10-18T09:00:00.000Z","cwd":"/work/diff-demo"}`,
+	`{"type":"message","id":"d1","parentId":null,"timestamp":"2026-10-18T09:00:01.000Z","message":{"role":"user","content":[{"type":"text","text":"fix the greeting"}]}}`,
+	// Branch A: one failed and one successful edit.
+	`{"type":"message","id":"a1","parentId":"d1","timestamp":"2026-10-18T09:00:02.000Z","message":{"role":"assistant","model":"model-a","usage":{"input":100,"output":20,"cacheRead":0,"cacheWrite":0,"cost":{"total":0.5}},"content":[{"type":"toolCall","id":"call_a1","name":"edit","arguments":{"path":"main.go","oldText":"hi","newText":"hello"}}]}}`,
+	`{"type":"message","id":"a2","parentId":"a1","timestamp":"2026-10-18T09:00:03.000Z","message":{"role":"toolResult","toolCallId":"call_a1","toolName":"edit","isError":true,"content":[{"type":"text","text":"oldText not found"}]}}`,
+	`{"type":"message","id":"a3","parentId":"a2","timestamp":"2026-10-18T09:00:04.000Z","message":{"role":"assistant","model":"model-a","usage":{"input":150,"output":30,"cacheRead":0,"cacheWrite":0,"cost":{"total":0.5}},"content":[{"type":"toolCall","id":"call_a2","name":"edit","arguments":{"path":"main.go","oldText":"hey","newText":"hello"}}]}}`,
+	`{"type":"message","id":"a4","parentId":"a3","timestamp":"2026-10-18T09:00:05.000Z","message":{"role":"toolResult","toolCallId":"call_a2","toolName":"edit","content":[{"type":"text","text":"ok"}]}}`,
+	`{"type":"message","id":"a5","parentId":"a4","timestamp":"2026-10-18T09:00:06.000Z","message":{"role":"assistant","model":"model-a","content":[{"type":"text","text":"Fixed."}]}}`,
+	// Branch B: the same task retried with another model.
+	`{"type":"message","id":"b1","parentId":"d1","timestamp":"2026-10-18T09:10:00.000Z","message":{"role":"assistant","model":"model-b","usage":{"input":80,"output":10,"cacheRead":0,"cacheWrite":0,"cost":{"total":0.25}},"content":[{"type":"toolCall","id":"call_b1","name":"edit","arguments":{"path":"main.go","oldText":"hey","newText":"hello"}},{"type":"toolCall","id":"call_b2","name":"write","arguments":{"path":"README.md","content":"docs\n"}}]}}`,
+	`{"type":"message","id":"b2","parentId":"b1","timestamp":"2026-10-18T09:10:01.000Z","message":{"role":"toolResult","toolCallId":"call_b1","toolName":"edit","content":[{"type":"text","text":"ok"}]}}`,
+	`{"type":"message","id":"b3","parentId":"b2","timestamp":"2026-10-18T09:10:02.000Z","message":{"role":"toolResult","toolCallId":"call_b2","toolName":"write","content":[{"type":"text","text":"ok"}]}}`,
+}
+
+func TestDiffBranches(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	sessionsDir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "sessions.sqlite3")
+	writeFixtureLines(t, filepath.Join(sessionsDir, "diff.jsonl"), diffFixtureLines)
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: sessionsDir, DBPath: dbPath}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	result, err := Diff(ctx, DiffOptions{DBPath: dbPath, A: diffFixtureUUID + "@a5", B: diffFixtureUUID})
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if result.CommonAncestor != "d1" || result.SharedEntries != 1 {
+		t.Fatalf("ancestor = %q (%d shared)", result.CommonAncestor, result.SharedEntries)
+	}
+	a, b := result.A, result.B
+	if b.Leaf != "b3" || a.Entries != 5 || b.Entries != 3 {
+		t.Fatalf("sides = %+v / %+v", a, b)
+	}
+	if a.ToolCalls != 2 || a.Errors != 1 || b.ToolCalls != 2 || b.Errors != 0 || b.ToolCallsByName["write"] != 1 {
+		t.Fatalf("tool calls: a=%+v b=%+v", a, b)
+	}
+	if a.Usage.InputTokens != 250 || a.Usage.OutputTokens != 50 || b.Usage.InputTokens != 80 {
+		t.Fatalf("usage: a=%+v b=%+v", a.Usage, b.Usage)
+	}
+	if len(a.Models) != 1 || a.Models[0] != "model-a" || len(b.Models) != 1 || b.Models[0] != "model-b" {
+		t.Fatalf("models: %v / %v", a.Models, b.Models)
+	}
+	if len(a.Turns) != 1 || a.Turns[0].Prompt != "" || a.Turns[0].Reply != "Fixed." || a.Turns[0].Errors != 1 {
+		t.Fatalf("turns a = %+v", a.Turns)
+	}
+	if len(result.Files) != 2 {
+		t.Fatalf("files = %+v", result.Files)
+	}
+	mainGo, readme := result.Files[1], result.Files[0]
+	if mainGo.Path != "/work/diff-demo/main.go" || !mainGo.SameFinal || mainGo.A.Edits != 2 || mainGo.B.Edits != 1 {
+		t.Fatalf("main.go = %+v a=%+v b=%+v", mainGo, mainGo.A, mainGo.B)
+	}
+	if readme.A != nil || readme.B == nil || readme.B.Writes != 1 || readme.SameFinal {
+		t.Fatalf("README.md = %+v", readme)
+	}
+
+	if _, err := Diff(ctx, DiffOptions{DBPath: dbPath, A: diffFixtureUUID + "@nope", B: diffFixtureUUID}); err == nil {
+		t.Fatal("unknown leaf accepted")
+	}
+}
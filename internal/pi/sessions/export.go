@@ -0,0 +1,484 @@
+/**
+ * Component: Session Transcript Export
+ * Block-UUID: 5b9e2c47-1d6a-4f83-a0c8-3e7f1d9b6a52
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Builds a shareable transcript of a session's active branch from the sessions mirror: user and assistant text, paired tool calls with results, diffs of edit and write calls, and a file summary, with optional redaction and truncation.
+ * Language: Go
+ * Created-at: 2026-10-18T18:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gitsense/gsc-cli/internal/db"
+)
+
+// diffContext is the number of unchanged lines kept around an edit hunk.
+const diffContext = 3
+
+type exportMessage struct {
+	entryID   string
+	parentID  string
+	entryType string
+	role      string
+	model     string
+	text      string
+	rawLine   string
+	timestamp string
+}
+
+// Export walks the session's branch from LeafID back to the root in the
+// mirror and returns it root first. Tool results are folded into the calls
+// they answer rather than listed as separate entries.
+func Export(ctx context.Context, options ExportOptions) (*ExportTranscript, error) {
+	if options.DBPath == "" {
+		return nil, fmt.Errorf("db path is required")
+	}
+	if options.SessionID == "" {
+		return nil, fmt.Errorf("session id is required")
+	}
+	database, err := OpenQueryMirror(options.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.CloseDB(database)
+
+	var transcript ExportTranscript
+	var chatID int64
+	var name, firstUser, cwd, repoRoot, provider, model, leaf, createdAt, lastMessageAt sql.NullString
+	err = database.QueryRowContext(ctx, `
+		SELECT id, uuid, runtime, name, first_user_text, cwd, repo_root, provider, model,
+		       current_leaf_id, created_at, last_message_at
+		FROM pi_chats
+		WHERE uuid = ? AND file_deleted_at IS NULL`, options.SessionID).Scan(
+		&chatID, &transcript.SessionID, &transcript.Runtime, &name, &firstUser, &cwd, &repoRoot,
+		&provider, &model, &leaf, &createdAt, &lastMessageAt)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("session not found: %s", options.SessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	transcript.Title = sessionTitle(name.String, firstUser.String)
+	transcript.CWD = cwd.String
+	transcript.RepoRoot = repoRoot.String
+	transcript.Provider = provider.String
+	transcript.Model = model.String
+	transcript.CreatedAt = createdAt.String
+	transcript.LastMessageAt = lastMessageAt.String
+
+	messages, err := loadExportMessages(ctx, database, chatID)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("session %s has no entries", options.SessionID)
+	}
+	transcript.Leaf = options.LeafID
+	if transcript.Leaf == "" {
+		transcript.Leaf = defaultExportLeaf(messages, leaf.String)
+	}
+	branch, err := exportBranch(messages, transcript.Leaf)
+	if err != nil {
+		return nil, err
+	}
+	onBranch := make(map[string]bool, len(branch))
+	for _, message := range branch {
+		onBranch[message.entryID] = true
+	}
+
+	calls, err := loadExportToolCalls(ctx, database, chatID, options)
+	if err != nil {
+		return nil, err
+	}
+	transcript.Files, err = loadExportFiles(ctx, database, chatID, onBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, message := range branch {
+		entry, ok := exportEntry(transcript.Runtime, message, calls[message.entryID], options.IncludeThinking)
+		if ok {
+			transcript.Entries = append(transcript.Entries, entry)
+		}
+	}
+	if len(options.Detectors) > 0 {
+		transcript.Redacted = redactTranscript(&transcript, options.Detectors)
+	}
+	return &transcript, nil
+}
+
+func loadExportMessages(ctx context.Context, database *sql.DB, chatID int64) ([]exportMessage, error) {
+	rows, err := database.QueryContext(ctx, `
+		SELECT entry_id, parent_entry_id, type, role, model, text, raw_line, timestamp
+		FROM pi_messages
+		WHERE chat_id = ?
+		ORDER BY seq`, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []exportMessage
+	for rows.Next() {
+		var m exportMessage
+		var parentID, role, model, text sql.NullString
+		if err := rows.Scan(&m.entryID, &parentID, &m.entryType, &role, &model, &text, &m.rawLine, &m.timestamp); err != nil {
+			return nil, err
+		}
+		m.parentID = parentID.String
+		m.role = role.String
+		m.model = model.String
+		m.text = text.String
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+// defaultExportLeaf returns the recorded current leaf unless it is a
+// bookkeeping line outside the parent chain (Claude Code appends those after
+// the last message), in which case the latest chained entry is used.
+func defaultExportLeaf(messages []exportMessage, currentLeaf string) string {
+	chained := func(message exportMessage) bool {
+		return message.parentID != "" || message.entryType == "message"
+	}
+	for _, message := range messages {
+		if message.entryID == currentLeaf && chained(message) {
+			return currentLeaf
+		}
+	}
+	for i := len(messages) - 1; i >= 0; i-- {
+		if chained(messages[i]) {
+			return messages[i].entryID
+		}
+	}
+	return messages[len(messages)-1].entryID
+}
+
+// exportBranch returns the entries from the root to leafID.
+func exportBranch(messages []exportMessage, leafID string) ([]exportMessage, error) {
+	byID := make(map[string]exportMessage, len(messages))
+	for _, message := range messages {
+		byID[message.entryID] = message
+	}
+	if _, ok := byID[leafID]; !ok {
+		return nil, fmt.Errorf("leaf ID %q not found in session", leafID)
+	}
+	var branch []exportMessage
+	visited := map[string]bool{}
+	for current := leafID; current != ""; {
+		if visited[current] {
+			return nil, fmt.Errorf("cycle detected at entry %q", current)
+		}
+		visited[current] = true
+		message, ok := byID[current]
+		if !ok {
+			break // parent outside the mirror (e.g. a forked session); stop at the oldest known entry
+		}
+		branch = append(branch, message)
+		current = message.parentID
+	}
+	for i, j := 0, len(branch)-1; i < j; i, j = i+1, j-1 {
+		branch[i], branch[j] = branch[j], branch[i]
+	}
+	return branch, nil
+}
+
+func loadExportToolCalls(ctx context.Context, database *sql.DB, chatID int64, options ExportOptions) (map[string][]ExportToolCall, error) {
+	rows, err := database.QueryContext(ctx, `
+		SELECT entry_id, tool_call_id, tool_name, arguments_json, result_text, is_error
+		FROM pi_tool_calls
+		WHERE chat_id = ?
+		ORDER BY seq, block_index`, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	out := map[string][]ExportToolCall{}
+	for rows.Next() {
+		var entryID string
+		var call ExportToolCall
+		var result sql.NullString
+		var isError sql.NullInt64
+		if err := rows.Scan(&entryID, &call.ID, &call.Name, &call.Arguments, &result, &isError); err != nil {
+			return nil, err
+		}
+		call.IsError = isError.Int64 != 0
+		call.Path = toolArgumentPath(json.RawMessage(call.Arguments))
+		call.Result, call.ResultTruncated = truncateRunes(result.String, options.MaxOutput)
+		if diff := toolCallDiff(call.Arguments); diff != "" {
+			call.Diff, call.DiffTruncated = truncateLines(diff, options.MaxDiffLines)
+			call.Arguments = withoutEditArguments(call.Arguments)
+		}
+		call.Arguments, _ = truncateRunes(call.Arguments, options.MaxOutput)
+		out[entryID] = append(out[entryID], call)
+	}
+	return out, rows.Err()
+}
+
+func loadExportFiles(ctx context.Context, database *sql.DB, chatID int64, onBranch map[string]bool) ([]ExportFile, error) {
+	rows, err := database.QueryContext(ctx, `
+		SELECT entry_id, op, COALESCE(NULLIF(file_path_rel, ''), abs_path, raw_path)
+		FROM pi_file_refs
+		WHERE chat_id = ? AND source = 'tool_call'`, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	byPath := map[string]*ExportFile{}
+	for rows.Next() {
+		var entryID, op, path string
+		if err := rows.Scan(&entryID, &op, &path); err != nil {
+			return nil, err
+		}
+		if !onBranch[entryID] {
+			continue
+		}
+		file := byPath[path]
+		if file == nil {
+			file = &ExportFile{Path: path}
+			byPath[path] = file
+		}
+		switch op {
+		case "read":
+			file.Reads++
+		case "edit":
+			file.Edits++
+		case "write":
+			file.Writes++
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	files := make([]ExportFile, 0, len(byPath))
+	for _, file := range byPath {
+		files = append(files, *file)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// exportEntry converts a mirrored entry. Message content is read from the raw
+// line so text and thinking stay separate; the flattened text column is the
+// fallback when the line cannot be parsed.
+func exportEntry(runtime string, message exportMessage, calls []ExportToolCall, includeThinking bool) (ExportEntry, bool) {
+	entry := ExportEntry{
+		EntryID:   message.entryID,
+		Type:      message.entryType,
+		Role:      message.role,
+		Timestamp: message.timestamp,
+		Model:     message.model,
+		ToolCalls: calls,
+	}
+	switch message.entryType {
+	case "compaction", "branch_summary":
+		entry.Role = message.entryType
+		entry.Text = message.text
+		return entry, entry.Text != ""
+	case "message":
+	default:
+		return entry, false
+	}
+	if entry.Role == "toolResult" {
+		return entry, false
+	}
+
+	payload := parseMirroredMessage(runtime, message.rawLine)
+	if payload == nil {
+		entry.Text = message.text
+	} else {
+		var text, thinking []string
+		for _, block := range payload.Content {
+			switch block.Type {
+			case "text", "custom":
+				if block.Text != "" {
+					text = append(text, block.Text)
+				}
+			case "thinking":
+				if block.Thinking != "" {
+					thinking = append(thinking, block.Thinking)
+				}
+			}
+		}
+		if payload.Role == "bashExecution" && payload.Command != "" {
+			text = append([]string{"$ " + payload.Command}, text...)
+		}
+		entry.Text = strings.Join(text, "\n\n")
+		if includeThinking {
+			entry.Thinking = strings.Join(thinking, "\n\n")
+		}
+	}
+	return entry, entry.Text != "" || entry.Thinking != "" || len(entry.ToolCalls) > 0
+}
+
+func parseMirroredMessage(runtime string, rawLine string) *messagePayload {
+	if rawLine == "" {
+		return nil
+	}
+	if runtime == RuntimeClaude {
+		var line claudeLine
+		if json.Unmarshal([]byte(rawLine), &line) != nil {
+			return nil
+		}
+		entry, err := convertClaudeLine(line, 0)
+		if err != nil {
+			return nil
+		}
+		return entry.Message
+	}
+	var entry parsedEntry
+	if json.Unmarshal([]byte(rawLine), &entry) != nil {
+		return nil
+	}
+	return entry.Message
+}
+
+// toolCallDiff renders edit arguments (Pi oldText/newText, Claude Code
+// old_string/new_string, and edits[] of either) as diff hunks, and write
+// arguments (content) as an all-added file.
+func toolCallDiff(argumentsJSON string) string {
+	type replacement struct {
+		OldString string `json:"old_string"`
+		NewString string `json:"new_string"`
+		OldText   string `json:"oldText"`
+		NewText   string `json:"newText"`
+	}
+	var args struct {
+		replacement
+		Content *string       `json:"content"`
+		Edits   []replacement `json:"edits"`
+	}
+	if argumentsJSON == "" || json.Unmarshal([]byte(argumentsJSON), &args) != nil {
+		return ""
+	}
+	edits := args.Edits
+	if args.OldString != "" || args.NewString != "" || args.OldText != "" || args.NewText != "" {
+		edits = append([]replacement{args.replacement}, edits...)
+	}
+	var hunks []string
+	for _, edit := range edits {
+		hunks = append(hunks, lineDiff(edit.OldString+edit.OldText, edit.NewString+edit.NewText))
+	}
+	if len(hunks) == 0 && args.Content != nil {
+		hunks = append(hunks, lineDiff("", *args.Content))
+	}
+	return strings.Join(hunks, "@@\n")
+}
+
+// withoutEditArguments drops the fields already shown in the diff so the
+// arguments block keeps only the path and options.
+func withoutEditArguments(argumentsJSON string) string {
+	var values map[string]json.RawMessage
+	if json.Unmarshal([]byte(argumentsJSON), &values) != nil {
+		return argumentsJSON
+	}
+	for _, key := range []string{"old_string", "new_string", "oldText", "newText", "content", "edits"} {
+		delete(values, key)
+	}
+	stripped, err := json.Marshal(values)
+	if err != nil {
+		return argumentsJSON
+	}
+	return string(stripped)
+}
+
+// lineDiff trims the lines old and new share at both ends to diffContext
+// lines of context and marks the rest as removed and added.
+func lineDiff(old, new string) string {
+	oldLines := splitDiffLines(old)
+	newLines := splitDiffLines(new)
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+	var b strings.Builder
+	for _, line := range oldLines[max(0, prefix-diffContext):prefix] {
+		b.WriteString(" " + line + "\n")
+	}
+	for _, line := range oldLines[prefix : len(oldLines)-suffix] {
+		b.WriteString("-" + line + "\n")
+	}
+	for _, line := range newLines[prefix : len(newLines)-suffix] {
+		b.WriteString("+" + line + "\n")
+	}
+	for _, line := range oldLines[len(oldLines)-suffix : min(len(oldLines), len(oldLines)-suffix+diffContext)] {
+		b.WriteString(" " + line + "\n")
+	}
+	return b.String()
+}
+
+func splitDiffLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func truncateRunes(text string, limit int) (string, bool) {
+	if limit <= 0 || utf8.RuneCountInString(text) <= limit {
+		return text, false
+	}
+	count := 0
+	for i := range text {
+		if count == limit {
+			return text[:i], true
+		}
+		count++
+	}
+	return text, false
+}
+
+func truncateLines(text string, limit int) (string, bool) {
+	if limit <= 0 || text == "" {
+		return text, false
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) <= limit {
+		return text, false
+	}
+	return strings.Join(lines[:limit], ""), true
+}
+
+// redactTranscript applies the detectors to every exported string and
+// returns the number of replacements.
+func redactTranscript(transcript *ExportTranscript, detectors []RedactionDetector) int {
+	total := 0
+	scrub := func(value *string) {
+		redacted, found := redactText(*value, detectors)
+		*value = redacted
+		for _, count := range found {
+			total += count
+		}
+	}
+	scrub(&transcript.Title)
+	for i := range transcript.Entries {
+		entry := &transcript.Entries[i]
+		scrub(&entry.Text)
+		scrub(&entry.Thinking)
+		for j := range entry.ToolCalls {
+			call := &entry.ToolCalls[j]
+			scrub(&call.Arguments)
+			scrub(&call.Diff)
+			scrub(&call.Result)
+		}
+	}
+	return total
+}
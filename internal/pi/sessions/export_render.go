@@ -0,0 +1,265 @@
+/**
+ * Component: Session Transcript Renderers
+ * Block-UUID: 9a4c7e13-6f28-4b5d-8e91-2c0b5f7d3a64
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Renders an exported session transcript as GitHub-flavored Markdown or as a self-contained HTML page, with collapsible tool calls, edit diffs, and a files-touched summary.
+ * Language: Go
+ * Created-at: 2026-10-18T18:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"strings"
+)
+
+// RenderExportMarkdown writes the transcript as Markdown. Tool calls are
+// wrapped in <details> blocks, which GitHub and most viewers collapse.
+func RenderExportMarkdown(w io.Writer, t *ExportTranscript) error {
+	var b strings.Builder
+	title := t.Title
+	if title == "" {
+		title = "Session " + t.SessionID
+	}
+	fmt.Fprintf(&b, "# %s\n\n", oneLineText(title))
+	for _, field := range exportMetadata(t) {
+		fmt.Fprintf(&b, "- **%s:** %s\n", field[0], field[1])
+	}
+	b.WriteString("\n")
+
+	if len(t.Files) > 0 {
+		b.WriteString("## Files touched\n\n| File | Reads | Edits | Writes |\n|---|---:|---:|---:|\n")
+		for _, file := range t.Files {
+			fmt.Fprintf(&b, "| `%s` | %d | %d | %d |\n", strings.ReplaceAll(file.Path, "|", `\|`), file.Reads, file.Edits, file.Writes)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("## Transcript\n")
+	for _, entry := range t.Entries {
+		fmt.Fprintf(&b, "\n### %s\n\n", exportHeading(entry))
+		if entry.Role == "compaction" || entry.Role == "branch_summary" {
+			fmt.Fprintf(&b, "<details>\n<summary>Summary</summary>\n\n%s\n\n</details>\n", entry.Text)
+			continue
+		}
+		if entry.Thinking != "" {
+			fmt.Fprintf(&b, "<details>\n<summary>Thinking</summary>\n\n%s\n\n</details>\n\n", entry.Thinking)
+		}
+		if entry.Text != "" {
+			b.WriteString(entry.Text + "\n\n")
+		}
+		for _, call := range entry.ToolCalls {
+			fmt.Fprintf(&b, "<details>\n<summary>%s</summary>\n\n", html.EscapeString(toolCallSummary(call)))
+			writeFenced(&b, "json", call.Arguments, false)
+			if call.Diff != "" {
+				writeFenced(&b, "diff", call.Diff, call.DiffTruncated)
+			}
+			if call.Result != "" {
+				label := "Output"
+				if call.IsError {
+					label = "Error"
+				}
+				fmt.Fprintf(&b, "%s:\n\n", label)
+				writeFenced(&b, "", call.Result, call.ResultTruncated)
+			}
+			b.WriteString("</details>\n\n")
+		}
+	}
+	_, err := io.WriteString(w, strings.TrimRight(b.String(), "\n")+"\n")
+	return err
+}
+
+// writeFenced writes a code block whose fence is longer than any backtick run
+// in the body, so transcripts that contain Markdown stay intact.
+func writeFenced(b *strings.Builder, lang string, body string, truncated bool) {
+	fence := "```"
+	for strings.Contains(body, fence) {
+		fence += "`"
+	}
+	body = strings.TrimRight(body, "\n")
+	fmt.Fprintf(b, "%s%s\n%s\n%s\n\n", fence, lang, body, fence)
+	if truncated {
+		b.WriteString("*(truncated)*\n\n")
+	}
+}
+
+func exportMetadata(t *ExportTranscript) [][2]string {
+	fields := [][2]string{{"Session", "`" + t.SessionID + "`"}, {"Runtime", t.Runtime}}
+	if t.Model != "" {
+		model := t.Model
+		if t.Provider != "" {
+			model = t.Provider + "/" + t.Model
+		}
+		fields = append(fields, [2]string{"Model", model})
+	}
+	if t.RepoRoot != "" {
+		fields = append(fields, [2]string{"Repository", "`" + t.RepoRoot + "`"})
+	} else if t.CWD != "" {
+		fields = append(fields, [2]string{"Directory", "`" + t.CWD + "`"})
+	}
+	if t.CreatedAt != "" {
+		fields = append(fields, [2]string{"Started", t.CreatedAt})
+	}
+	if t.LastMessageAt != "" {
+		fields = append(fields, [2]string{"Last message", t.LastMessageAt})
+	}
+	fields = append(fields, [2]string{"Leaf", "`" + t.Leaf + "`"})
+	if t.Redacted > 0 {
+		fields = append(fields, [2]string{"Redactions", fmt.Sprintf("%d", t.Redacted)})
+	}
+	return fields
+}
+
+func exportHeading(entry ExportEntry) string {
+	label := map[string]string{
+		"user":           "User",
+		"assistant":      "Assistant",
+		"bashExecution":  "Shell",
+		"compaction":     "Compaction",
+		"branch_summary": "Branch summary",
+	}[entry.Role]
+	if label == "" {
+		label = entry.Role
+	}
+	if label == "" {
+		label = entry.Type
+	}
+	parts := []string{label}
+	if entry.Timestamp != "" {
+		parts = append(parts, entry.Timestamp)
+	}
+	if entry.Model != "" && entry.Role == "assistant" {
+		parts = append(parts, entry.Model)
+	}
+	return strings.Join(parts, " · ")
+}
+
+func toolCallSummary(call ExportToolCall) string {
+	summary := call.Name
+	if call.Path != "" {
+		summary += " " + call.Path
+	} else if command := toolArgumentCommand([]byte(call.Arguments)); command != "" {
+		summary += ": " + oneLineText(command)
+		if len([]rune(summary)) > 100 {
+			summary = string([]rune(summary)[:99]) + "…"
+		}
+	}
+	if call.IsError {
+		summary += " (failed)"
+	}
+	return summary
+}
+
+func oneLineText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// RenderExportHTML writes the transcript as a single HTML page with inline
+// styles and no external assets.
+func RenderExportHTML(w io.Writer, t *ExportTranscript) error {
+	title := t.Title
+	if title == "" {
+		title = "Session " + t.SessionID
+	}
+	return exportHTMLTemplate.Execute(w, struct {
+		Title      string
+		Metadata   [][2]string
+		Transcript *ExportTranscript
+	}{oneLineText(title), exportMetadata(t), t})
+}
+
+var exportHTMLTemplate = template.Must(template.New("export").Funcs(template.FuncMap{
+	"heading": exportHeading,
+	"summary": toolCallSummary,
+	"prose":   proseHTML,
+	"diff":    diffHTML,
+	"plain":   func(s string) string { return strings.Trim(s, "`") },
+	"isSummary": func(role string) bool {
+		return role == "compaction" || role == "branch_summary"
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body{font:15px/1.5 -apple-system,BlinkMacSystemFont,"Segoe UI",Helvetica,Arial,sans-serif;max-width:960px;margin:2em auto;padding:0 1em;color:#1f2328}
+h1{font-size:1.6em}h2{border-bottom:1px solid #d0d7de;padding-bottom:.3em}
+.meta{color:#57606a}.meta dt{font-weight:600;float:left;clear:left;width:8em}.meta dd{margin-left:8em}
+table{border-collapse:collapse}td,th{border:1px solid #d0d7de;padding:4px 10px}td.n{text-align:right}
+.entry{border:1px solid #d0d7de;border-radius:6px;margin:1em 0;padding:.5em 1em}
+.entry.user{background:#f6f8fa}.entry h3{font-size:.9em;color:#57606a;margin:.3em 0}
+.text{white-space:pre-wrap;word-wrap:break-word}
+pre{background:#f6f8fa;border-radius:6px;padding:.6em;overflow-x:auto;font:13px/1.45 ui-monospace,SFMono-Regular,Menlo,monospace}
+details{margin:.5em 0}summary{cursor:pointer;font-family:ui-monospace,SFMono-Regular,Menlo,monospace;font-size:13px}
+.failed summary{color:#cf222e}.add{background:#e6ffec;display:block}.del{background:#ffebe9;display:block}
+.truncated{color:#57606a;font-style:italic}
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<dl class="meta">{{range .Metadata}}<dt>{{index . 0}}</dt><dd>{{plain (index . 1)}}</dd>{{end}}</dl>
+{{with .Transcript.Files}}<h2>Files touched</h2>
+<table><tr><th>File</th><th>Reads</th><th>Edits</th><th>Writes</th></tr>
+{{range .}}<tr><td><code>{{.Path}}</code></td><td class="n">{{.Reads}}</td><td class="n">{{.Edits}}</td><td class="n">{{.Writes}}</td></tr>
+{{end}}</table>{{end}}
+<h2>Transcript</h2>
+{{range .Transcript.Entries}}<div class="entry {{.Role}}">
+<h3>{{heading .}}</h3>
+{{if isSummary .Role}}<details><summary>Summary</summary><div class="text">{{.Text}}</div></details>
+{{else}}{{with .Thinking}}<details><summary>Thinking</summary><div class="text">{{.}}</div></details>
+{{end}}{{with .Text}}{{prose .}}
+{{end}}{{range .ToolCalls}}<details{{if .IsError}} class="failed"{{end}}><summary>{{summary .}}</summary>
+<pre>{{.Arguments}}</pre>
+{{with .Diff}}<pre>{{diff .}}</pre>{{end}}{{if .DiffTruncated}}<div class="truncated">diff truncated</div>{{end}}
+{{with .Result}}<pre>{{.}}</pre>{{end}}{{if .ResultTruncated}}<div class="truncated">output truncated</div>{{end}}
+</details>
+{{end}}{{end}}</div>
+{{end}}</body>
+</html>
+`))
+
+// proseHTML escapes message text, keeping fenced code blocks as <pre> and
+// everything else as pre-wrapped text.
+func proseHTML(text string) template.HTML {
+	var b strings.Builder
+	parts := strings.Split(text, "```")
+	for i, part := range parts {
+		if i%2 == 1 && i < len(parts)-1 {
+			if newline := strings.IndexByte(part, '\n'); newline >= 0 && !strings.ContainsAny(part[:newline], " \t") {
+				part = part[newline+1:] // drop the info string
+			}
+			b.WriteString("<pre>" + html.EscapeString(strings.TrimRight(part, "\n")) + "</pre>")
+			continue
+		}
+		if i%2 == 1 {
+			part = "```" + part // unterminated fence
+		}
+		if strings.TrimSpace(part) != "" {
+			b.WriteString(`<div class="text">` + html.EscapeString(strings.Trim(part, "\n")) + "</div>")
+		}
+	}
+	return template.HTML(b.String())
+}
+
+func diffHTML(diff string) template.HTML {
+	var b strings.Builder
+	for _, line := range strings.Split(strings.TrimSuffix(diff, "\n"), "\n") {
+		escaped := html.EscapeString(line)
+		switch {
+		case strings.HasPrefix(line, "+"):
+			b.WriteString(`<span class="add">` + escaped + "</span>")
+		case strings.HasPrefix(line, "-"):
+			b.WriteString(`<span class="del">` + escaped + "</span>")
+		default:
+			b.WriteString(escaped + "\n")
+		}
+	}
+	return template.HTML(b.String())
+}
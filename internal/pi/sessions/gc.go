@@ -0,0 +1,279 @@
+/**
+ * Component: Session Retention and Compaction
+ * Block-UUID: 9c5f1e38-2d7b-4a60-8e94-b3a6d0f2c715
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Applies retention rules (age, repository, keep-named, keep-with-lessons) to the sessions mirror, archives matching sessions into restorable per-session bundles, optimizes the FTS index and vacuums the database, and restores archived sessions on request.
+ * Language: Go
+ * Created-at: 2026-10-18T20:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/db"
+)
+
+const (
+	gcKeptNamed   = "named"
+	gcKeptLessons = "lessons"
+	gcKeptRepo    = "repo"
+)
+
+type gcCandidate struct {
+	id      int64
+	session GCSession
+}
+
+// GC archives sessions selected by the retention rules and compacts the
+// mirror. Archived sessions stay listed and searchable; only their raw lines
+// move into the bundle.
+func GC(ctx context.Context, options GCOptions) (*GCResult, error) {
+	if options.DBPath == "" {
+		return nil, fmt.Errorf("db path is required")
+	}
+	if options.OlderThan <= 0 {
+		return nil, fmt.Errorf("a positive retention age is required")
+	}
+	archiveDir := options.ArchiveDir
+	if archiveDir == "" {
+		archiveDir = filepath.Join(filepath.Dir(options.DBPath), "archive")
+	}
+	cutoff := time.Now().UTC().Add(-options.OlderThan)
+	result := &GCResult{DryRun: options.DryRun, Cutoff: cutoff.Format(time.RFC3339), Sessions: []GCSession{}}
+	result.DBBytesBefore = databaseFileSize(options.DBPath)
+
+	database, err := openMirror(ctx, options.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.CloseDB(database)
+
+	candidates, err := loadGCCandidates(ctx, database, options, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	result.SessionsScanned = len(candidates)
+	for _, candidate := range candidates {
+		session := candidate.session
+		kept, err := gcKeepRule(ctx, database, candidate, options)
+		if err != nil {
+			return nil, err
+		}
+		if kept != "" {
+			session.Kept = kept
+			result.SessionsKept++
+			result.Sessions = append(result.Sessions, session)
+			continue
+		}
+		session.ArchivePath = archiveBundlePath(archiveDir, session.SessionID)
+		if !options.DryRun {
+			size, err := archiveChat(ctx, database, candidate.id, session.ArchivePath)
+			if err != nil {
+				session.Error = err.Error()
+				session.ArchivePath = ""
+				result.Sessions = append(result.Sessions, session)
+				continue
+			}
+			session.ArchiveBytes = size
+			result.ArchiveBytes += size
+		}
+		result.SessionsArchived++
+		result.RawBytesDropped += session.RawBytes
+		result.Sessions = append(result.Sessions, session)
+	}
+
+	if !options.DryRun {
+		if _, err := database.ExecContext(ctx, `INSERT INTO fts_pi_messages(fts_pi_messages) VALUES ('optimize')`); err != nil {
+			return nil, fmt.Errorf("optimize search index: %w", err)
+		}
+		if options.Vacuum {
+			for _, statement := range []string{"VACUUM", "PRAGMA wal_checkpoint(TRUNCATE)", "PRAGMA optimize"} {
+				if _, err := database.ExecContext(ctx, statement); err != nil {
+					return nil, fmt.Errorf("%s: %w", statement, err)
+				}
+			}
+			result.Vacuumed = true
+		}
+	}
+	result.DBBytesAfter = databaseFileSize(options.DBPath)
+	return result, nil
+}
+
+// loadGCCandidates returns live, unarchived sessions whose last activity is
+// before the cutoff and that match the runtime and repository filters.
+func loadGCCandidates(ctx context.Context, database *sql.DB, options GCOptions, cutoff time.Time) ([]gcCandidate, error) {
+	query := `
+		SELECT c.id, c.uuid, c.runtime, COALESCE(c.name, ''), COALESCE(c.first_user_text, ''),
+		       COALESCE(c.repo_root, ''), COALESCE(c.last_message_at, ''), c.updated_at,
+		       COUNT(m.id), COALESCE(SUM(length(CAST(m.raw_line AS BLOB))), 0)
+		FROM pi_chats c
+		LEFT JOIN pi_messages m ON m.chat_id = c.id
+		WHERE c.file_deleted_at IS NULL AND c.archived_at IS NULL`
+	var args []any
+	if options.Runtime != "" {
+		query += ` AND c.runtime = ?`
+		args = append(args, options.Runtime)
+	}
+	if options.Repo != "" {
+		query += ` AND c.repo_root = ?`
+		args = append(args, filepath.Clean(options.Repo))
+	}
+	query += ` GROUP BY c.id ORDER BY COALESCE(c.last_message_at, c.updated_at)`
+	rows, err := database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var candidates []gcCandidate
+	for rows.Next() {
+		var candidate gcCandidate
+		var name, firstUser, updatedAt string
+		session := &candidate.session
+		if err := rows.Scan(&candidate.id, &session.SessionID, &session.Runtime, &name, &firstUser,
+			&session.RepoRoot, &session.LastMessageAt, &updatedAt, &session.Lines, &session.RawBytes); err != nil {
+			return nil, err
+		}
+		if session.Lines == 0 {
+			continue
+		}
+		last := session.LastMessageAt
+		if last == "" {
+			last = updatedAt
+		}
+		lastAt, err := time.Parse(time.RFC3339Nano, last)
+		if err != nil || !lastAt.Before(cutoff) {
+			continue
+		}
+		session.Title = sessionTitle(name, firstUser)
+		candidates = append(candidates, candidate)
+	}
+	return candidates, rows.Err()
+}
+
+// gcKeepRule returns the keep rule that protects a candidate, if any.
+func gcKeepRule(ctx context.Context, database *sql.DB, candidate gcCandidate, options GCOptions) (string, error) {
+	for _, repo := range options.KeepRepos {
+		if candidate.session.RepoRoot != "" && candidate.session.RepoRoot == filepath.Clean(repo) {
+			return gcKeptRepo, nil
+		}
+	}
+	if options.KeepNamed {
+		var named bool
+		if err := database.QueryRowContext(ctx, `SELECT COALESCE(name, '') != '' FROM pi_chats WHERE id = ?`, candidate.id).Scan(&named); err != nil {
+			return "", err
+		}
+		if named {
+			return gcKeptNamed, nil
+		}
+	}
+	if options.KeepWithLessons {
+		lessons, err := hasLessonActivity(ctx, database, candidate.id)
+		if err != nil {
+			return "", err
+		}
+		if lessons {
+			return gcKeptLessons, nil
+		}
+	}
+	return "", nil
+}
+
+// hasLessonActivity reports whether a session drafted, committed, or cited
+// repository lessons: gsc lessons commands, files under .gitsense/lessons,
+// or lesson ids (lsn_...) in message text.
+func hasLessonActivity(ctx context.Context, database *sql.DB, chatID int64) (bool, error) {
+	var found bool
+	err := database.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM pi_tool_calls
+			WHERE chat_id = ? AND (arguments_json LIKE '%gsc lessons%' OR arguments_json LIKE '%.gitsense/lessons%')
+		) OR EXISTS (
+			SELECT 1 FROM pi_file_refs
+			WHERE chat_id = ? AND (file_path_rel LIKE '.gitsense/lessons/%' OR raw_path LIKE '%.gitsense/lessons/%')
+		) OR EXISTS (
+			SELECT 1 FROM pi_messages
+			WHERE chat_id = ? AND text LIKE '%lsn\_%' ESCAPE '\'
+		)`, chatID, chatID, chatID).Scan(&found)
+	return found, err
+}
+
+// Restore brings archived sessions back into the mirror.
+func Restore(ctx context.Context, options RestoreOptions) ([]RestoredSession, error) {
+	if options.DBPath == "" {
+		return nil, fmt.Errorf("db path is required")
+	}
+	if !options.All && len(options.SessionIDs) == 0 {
+		return nil, fmt.Errorf("a session id or --all is required")
+	}
+	database, err := openMirror(ctx, options.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.CloseDB(database)
+
+	type target struct {
+		id   int64
+		uuid string
+	}
+	var targets []target
+	if options.All {
+		rows, err := database.QueryContext(ctx, `SELECT id, uuid FROM pi_chats WHERE archived_at IS NOT NULL ORDER BY id`)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var t target
+			if err := rows.Scan(&t.id, &t.uuid); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			targets = append(targets, t)
+		}
+		if err := rows.Close(); err != nil {
+			return nil, err
+		}
+	} else {
+		for _, sessionID := range options.SessionIDs {
+			t := target{uuid: sessionID}
+			err := database.QueryRowContext(ctx, `SELECT id FROM pi_chats WHERE uuid = ?`, sessionID).Scan(&t.id)
+			if err == sql.ErrNoRows {
+				return nil, fmt.Errorf("session not found: %s", sessionID)
+			}
+			if err != nil {
+				return nil, err
+			}
+			targets = append(targets, t)
+		}
+	}
+
+	restored := make([]RestoredSession, 0, len(targets))
+	for _, t := range targets {
+		session, err := restoreArchivedChat(ctx, database, t.id, options.WriteSource)
+		if err != nil {
+			session.SessionID = t.uuid
+			session.Error = err.Error()
+		}
+		restored = append(restored, session)
+	}
+	return restored, nil
+}
+
+func databaseFileSize(dbPath string) int64 {
+	var total int64
+	for _, path := range []string{dbPath, dbPath + "-wal"} {
+		if info, err := os.Stat(path); err == nil {
+			total += info.Size()
+		}
+	}
+	return total
+}
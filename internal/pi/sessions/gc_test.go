@@ -0,0 +1,206 @@
+/**
+ * Component: Session Retention Tests
+ * Block-UUID: 88c84eb0-0949-4076-9597-c58eab59d202
+ * Parent-UUID: b0ff6fe1-c767-42b0-a06b-9032700650b0
+ * Version: 1.2.0
+ * Description: Verifies that gc archives old sessions into bundles while keeping derived rows searchable and usage reportable, honours keep rules, leaves unchanged archived sessions alone on sync, restores them when the source grows, restores them on request, and discards a bundle that no longer restores.
+ * Language: Go
+ * Created-at: 2026-10-18T20:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const (
+	gcPlainUUID   = "019edff0-0000-7000-8000-00000000f001"
+	gcNamedUUID   = "019edff0-0000-7000-8000-00000000f002"
+	gcLessonsUUID = "019edff0-0000-7000-8000-00000000f003"
+)
+
+var gcPlainLines = []string{
+	`{"type":"session","version":3,"id":"019edff0-0000-7000-8000-00000000f001","timestamp":"2025-01-10T09:00:00.000Z","cwd":"/work/gc-demo"}`,
+	`{"type":"message","id":"p1","parentId":null,"timestamp":"2025-01-10T09:00:01.000Z","message":{"role":"user","content":[{"type":"text","text":"find the flaky retry test"}]}}`,
+	`{"type":"message","id":"p2","parentId":"p1","timestamp":"2025-01-10T09:00:02.000Z","message":{"role":"assistant","model":"m1","content":[{"type":"toolCall","id":"call_p","name":"read","arguments":{"path":"retry_test.go"}}],"stopReason":"toolUse","usage":{"input":100,"output":20,"cacheRead":0,"cacheWrite":0,"totalTokens":120,"cost":{"total":0.01}}}}`,
+	`{"type":"message","id":"p3","parentId":"p2","timestamp":"2025-01-10T09:00:03.000Z","message":{"role":"toolResult","toolCallId":"call_p","toolName":"read","content":[{"type":"text","text":"package retry"}]}}`,
+}
+
+var gcNamedLines = []string{
+	`{"type":"session","version":3,"id":"019edff0-0000-7000-8000-00000000f002","timestamp":"2025-01-10T10:00:00.000Z","cwd":"/work/gc-demo"}`,
+	`{"type":"message","id":"n1","parentId":null,"timestamp":"2025-01-10T10:00:01.000Z","message":{"role":"user","content":[{"type":"text","text":"design the cache"}]}}`,
+	`{"type":"session_info","id":"n2","parentId":"n1","timestamp":"2025-01-10T10:00:02.000Z","name":"cache design"}`,
+}
+
+var gcLessonsLines = []string{
+	`{"type":"session","version":3,"id":"019edff0-0000-7000-8000-00000000f003","timestamp":"2025-01-10T11:00:00.000Z","cwd":"/work/gc-demo"}`,
+	`{"type":"message","id":"l1","parentId":null,"timestamp":"2025-01-10T11:00:01.000Z","message":{"role":"assistant","model":"m1","content":[{"type":"toolCall","id":"call_l","name":"bash","arguments":{"command":"gsc lessons commit"}}]}}`,
+}
+
+func TestGCArchiveAndRestore(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	sessionsDir := t.TempDir()
+	dataDir := t.TempDir()
+	dbPath := filepath.Join(dataDir, "sessions.sqlite3")
+	plainPath := filepath.Join(sessionsDir, "plain.jsonl")
+	writeFixtureLines(t, plainPath, gcPlainLines)
+	writeFixtureLines(t, filepath.Join(sessionsDir, "named.jsonl"), gcNamedLines)
+	writeFixtureLines(t, filepath.Join(sessionsDir, "lessons.jsonl"), gcLessonsLines)
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: sessionsDir, DBPath: dbPath}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	options := GCOptions{DBPath: dbPath, OlderThan: 24 * time.Hour, KeepNamed: true, KeepWithLessons: true, Vacuum: true}
+	dry := options
+	dry.DryRun = true
+	preview, err := GC(ctx, dry)
+	if err != nil {
+		t.Fatalf("gc dry run: %v", err)
+	}
+	if preview.SessionsScanned != 3 || preview.SessionsArchived != 1 || preview.SessionsKept != 2 {
+		t.Fatalf("dry run = %+v", preview)
+	}
+	kept := map[string]string{}
+	for _, session := range preview.Sessions {
+		kept[session.SessionID] = session.Kept
+	}
+	if kept[gcNamedUUID] != gcKeptNamed || kept[gcLessonsUUID] != gcKeptLessons || kept[gcPlainUUID] != "" {
+		t.Fatalf("keep rules = %v", kept)
+	}
+
+	result, err := GC(ctx, options)
+	if err != nil {
+		t.Fatalf("gc: %v", err)
+	}
+	archivePath := filepath.Join(dataDir, "archive", gcPlainUUID+".jsonl.gz")
+	if result.SessionsArchived != 1 || !result.Vacuumed || result.Sessions[0].Error != "" {
+		t.Fatalf("gc = %+v", result)
+	}
+	if _, err := os.Stat(archivePath); err != nil {
+		t.Fatalf("bundle: %v", err)
+	}
+
+	database := openIncrementalTestDB(t, dbPath)
+	var rawBytes int
+	if err := database.QueryRow(`
+		SELECT SUM(length(m.raw_line)) FROM pi_messages m JOIN pi_chats c ON c.id = m.chat_id
+		WHERE c.uuid = ?`, gcPlainUUID).Scan(&rawBytes); err != nil || rawBytes != 0 {
+		t.Fatalf("raw bytes after archive = %d (%v)", rawBytes, err)
+	}
+	report, err := VerifyLossless(ctx, database, gcPlainUUID)
+	if err != nil || !report.Match {
+		t.Fatalf("verify archived = %+v (%v)", report, err)
+	}
+	database.Close()
+
+	hits, err := Query(ctx, QueryOptions{DBPath: dbPath, Text: "flaky"})
+	if err != nil || len(hits) == 0 {
+		t.Fatalf("search archived session = %d hits (%v)", len(hits), err)
+	}
+	hits, err = Query(ctx, QueryOptions{DBPath: dbPath, Tool: "read"})
+	if err != nil || len(hits) == 0 {
+		t.Fatalf("tool calls of archived session = %d (%v)", len(hits), err)
+	}
+	usage, err := Usage(ctx, UsageOptions{DBPath: dbPath, SessionID: gcPlainUUID})
+	if err != nil || usage.Total.Messages != 1 || usage.Total.TotalTokens != 120 {
+		t.Fatalf("usage of archived session = %+v (%v)", usage, err)
+	}
+	last, err := LastUsage(ctx, dbPath, gcPlainUUID)
+	if err != nil || last == nil || last.TotalTokens != 120 || last.CostTotal != 0.01 {
+		t.Fatalf("last usage of archived session = %+v (%v)", last, err)
+	}
+
+	// Unchanged source: sync leaves the archive in place.
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: sessionsDir, DBPath: dbPath}); err != nil {
+		t.Fatalf("resync: %v", err)
+	}
+	if _, err := os.Stat(archivePath); err != nil {
+		t.Fatalf("bundle removed by unchanged sync: %v", err)
+	}
+
+	// Appended source: sync restores the lines and appends.
+	appendFixtureLine(t, plainPath, `{"type":"message","id":"p4","parentId":"p3","timestamp":"2025-01-11T09:00:00.000Z","message":{"role":"assistant","model":"m1","content":[{"type":"text","text":"found it"}]}}`)
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: sessionsDir, DBPath: dbPath}); err != nil {
+		t.Fatalf("sync after append: %v", err)
+	}
+	database = openIncrementalTestDB(t, dbPath)
+	var archived int
+	var messages int
+	if err := database.QueryRow(`
+		SELECT COUNT(c.archived_at), (SELECT COUNT(*) FROM pi_messages m WHERE m.chat_id = c.id AND m.raw_line != '')
+		FROM pi_chats c WHERE c.uuid = ?`, gcPlainUUID).Scan(&archived, &messages); err != nil {
+		t.Fatalf("state after append: %v", err)
+	}
+	if archived != 0 || messages != 4 {
+		t.Fatalf("after append archived=%d messages=%d", archived, messages)
+	}
+	if _, err := os.Stat(archivePath); !os.IsNotExist(err) {
+		t.Fatalf("bundle still present after auto-restore: %v", err)
+	}
+	report, err = VerifyLossless(ctx, database, gcPlainUUID)
+	if err != nil || !report.Match {
+		t.Fatalf("verify after append = %+v (%v)", report, err)
+	}
+	database.Close()
+
+	// Explicit restore, recreating a deleted source.
+	if _, err := GC(ctx, options); err != nil {
+		t.Fatalf("gc again: %v", err)
+	}
+	original, err := os.ReadFile(plainPath)
+	if err != nil {
+		t.Fatalf("read source: %v", err)
+	}
+	if err := os.Remove(plainPath); err != nil {
+		t.Fatalf("remove source: %v", err)
+	}
+	restored, err := Restore(ctx, RestoreOptions{DBPath: dbPath, SessionIDs: []string{gcPlainUUID}, WriteSource: true})
+	if err != nil || len(restored) != 1 || restored[0].Error != "" || restored[0].Lines != 4 || !restored[0].SourceWritten {
+		t.Fatalf("restore = %+v (%v)", restored, err)
+	}
+	recreated, err := os.ReadFile(plainPath)
+	if err != nil || string(recreated) != string(original) {
+		t.Fatalf("recreated source differs (%v)", err)
+	}
+	again, err := Restore(ctx, RestoreOptions{DBPath: dbPath, SessionIDs: []string{gcPlainUUID}})
+	if err != nil || len(again) != 1 || again[0].Error == "" {
+		t.Fatalf("restoring a live session = %+v (%v)", again, err)
+	}
+	if left, err := Restore(ctx, RestoreOptions{DBPath: dbPath, All: true}); err != nil || len(left) != 0 {
+		t.Fatalf("archived sessions left = %+v (%v)", left, err)
+	}
+
+	// A bundle that no longer restores is discarded after the rebuild.
+	if _, err := GC(ctx, options); err != nil {
+		t.Fatalf("gc a third time: %v", err)
+	}
+	if err := os.WriteFile(archivePath, []byte("not a bundle"), 0644); err != nil {
+		t.Fatalf("corrupt bundle: %v", err)
+	}
+	appendFixtureLine(t, plainPath, `{"type":"message","id":"p5","parentId":"p4","timestamp":"2025-01-11T09:00:01.000Z","message":{"role":"user","content":[{"type":"text","text":"thanks"}]}}`)
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: sessionsDir, DBPath: dbPath}); err != nil {
+		t.Fatalf("sync with corrupt bundle: %v", err)
+	}
+	if _, err := os.Stat(archivePath); !os.IsNotExist(err) {
+		t.Fatalf("unusable bundle left behind: %v", err)
+	}
+	database = openIncrementalTestDB(t, dbPath)
+	defer database.Close()
+	var discarded int
+	if err := database.QueryRow(`
+		SELECT COUNT(*) FROM pi_sync_events WHERE event_type = 'archive_discarded' AND note LIKE ?`, archivePath+": %").Scan(&discarded); err != nil || discarded != 1 {
+		t.Fatalf("archive_discarded events = %d (%v)", discarded, err)
+	}
+	if err := database.QueryRow(`
+		SELECT COUNT(*) FROM pi_messages m JOIN pi_chats c ON c.id = m.chat_id
+		WHERE c.uuid = ? AND c.archived_at IS NULL AND m.raw_line != ''`, gcPlainUUID).Scan(&messages); err != nil || messages != 5 {
+		t.Fatalf("rebuilt messages = %d (%v)", messages, err)
+	}
+}
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Importer
- * Block-UUID: 4e8a1c70-2d96-4b53-9a18-5f0c7b3e9d62
- * Parent-UUID: 96bb35de-5c03-4ced-9ec7-18df3db71aec
- * Version: 1.2.0
- * Description: Reconciles Pi session JSONL files into SQLite using atomic append-or-rebuild ingestion; skips Pi's ephemeral pi-runtime-cwd sessions so they never enter the mirror.
+ * Block-UUID: c7bec842-dff7-4b87-adcd-e25b2d4509dd
+ * Parent-UUID: 65e42605-7627-43a5-a7fd-c1b9177e02db
+ * Version: 1.7.0
+ * Description: Reconciles agent session JSONL files (Pi and Claude Code) into SQLite using atomic append-or-rebuild ingestion with a per-runtime parser; pairs batched tool results, records the runtime per chat, adds heuristic bash file references for Claude Code, and skips ephemeral pi-runtime-cwd sessions. Tail anchors accept source lines whose mirror copy was redacted. Archived sessions are skipped while unchanged and restored from their bundle when the source grows; a bundle that fails to restore is discarded after the rebuild and logged as an archive_discarded event.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0, v1.1.0), claude-opus-4-8 (v1.2.0)
+ * Authors: Codex GPT-5 (v1.0.0, v1.1.0), claude-opus-4-8 (v1.2.0), agent (v1.3.0), agent (v1.4.0), agent (v1.5.0), agent (v1.6.0), agent (v1.7.0)
  */
 
 package sessions
@@ -32,6 +32,10 @@ func Sync(ctx context.Context, options SyncOptions) (SyncResult, error) {
 	if options.DBPath == "" {
 		return SyncResult{}, fmt.Errorf("db path is required")
 	}
+	format, err := formatForRuntime(options.Runtime)
+	if err != nil {
+		return SyncResult{}, err
+	}
 	sessionsDir, err := filepath.Abs(options.SessionsDir)
 	if err != nil {
 		return SyncResult{}, err
@@ -43,8 +47,8 @@ func Sync(ctx context.Context, options SyncOptions) (SyncResult, error) {
 	defer db.CloseDB(database)
 
 	logger := options.Logger
-	result := SyncResult{SessionsDir: sessionsDir, DBPath: options.DBPath}
-	files, err := discoverSessionFiles(sessionsDir)
+	result := SyncResult{Runtime: format.runtime, SessionsDir: sessionsDir, DBPath: options.DBPath}
+	files, err := format.discover(sessionsDir)
 	if err != nil {
 		return result, err
 	}
@@ -58,7 +62,7 @@ func Sync(ctx context.Context, options SyncOptions) (SyncResult, error) {
 		if logger != nil {
 			logger.LogDebug(fmt.Sprintf("importing session file: %s", path))
 		}
-		imported, err := importSessionFile(ctx, database, path, logger)
+		imported, err := importSessionFile(ctx, database, format, path, logger)
 		if err != nil {
 			if logger != nil {
 				logger.LogError(fmt.Sprintf("%s: %s", path, err.Error()))
@@ -79,7 +83,7 @@ func Sync(ctx context.Context, options SyncOptions) (SyncResult, error) {
 	} else if purged > 0 && logger != nil {
 		logger.LogInfo(fmt.Sprintf("purged %d ephemeral runtime session(s) from the mirror", purged))
 	}
-	if err := reconcileMissingSessions(ctx, database, sessionsDir, files); err != nil {
+	if err := reconcileMissingSessions(ctx, database, format, sessionsDir, files); err != nil {
 		return result, err
 	}
 	if err := resolveParentChats(ctx, database); err != nil {
@@ -172,6 +176,7 @@ type chatSyncState struct {
 	headerHash       string
 	contentHash      string
 	fileDeleted      bool
+	archived         bool
 }
 
 func discoverSessionFiles(root string) ([]string, error) {
@@ -194,7 +199,7 @@ func discoverSessionFiles(root string) ([]string, error) {
 	return files, nil
 }
 
-func importSessionFile(ctx context.Context, database *sql.DB, path string, logger SyncLogger) (importCounts, error) {
+func importSessionFile(ctx context.Context, database *sql.DB, format sessionFormat, path string, logger SyncLogger) (importCounts, error) {
 	info, err := os.Stat(path)
 	if err != nil {
 		return importCounts{}, err
@@ -203,7 +208,7 @@ func importSessionFile(ctx context.Context, database *sql.DB, path string, logge
 	if err != nil {
 		return importCounts{}, err
 	}
-	header, headerBytes, err := parseSessionHeader(path)
+	header, headerBytes, err := format.parseHeader(path)
 	if err != nil {
 		if logger != nil {
 			logger.LogDebug(fmt.Sprintf("parse header failed for %s: %v", path, err))
@@ -230,14 +235,35 @@ func importSessionFile(ctx context.Context, database *sql.DB, path string, logge
 			return importCounts{}, err
 		}
 	}
-	if found && !state.fileDeleted && state.headerHash == hashText(header.RawLine) && state.syncedByteOffset >= headerBytes && state.syncedByteOffset <= info.Size() {
+	// An archived chat keeps its raw lines in a bundle. Leave it alone while
+	// the source is unchanged; otherwise bring the lines back so the usual
+	// append path can run, and fall back to a rebuild if the bundle is gone
+	// or unusable. The rebuild clears archive_path, so such a bundle is
+	// discarded once the rebuild succeeds.
+	var unusableBundle string
+	var restoreErr error
+	if found && state.archived {
+		if info.Size() == state.fileSize && info.ModTime().UnixMilli() == state.fileMtimeMS {
+			return importCounts{}, nil
+		}
+		restored, err := restoreArchivedChat(ctx, database, state.id, false)
+		if err != nil {
+			unusableBundle, restoreErr = restored.ArchivePath, err
+			if logger != nil {
+				logger.LogInfo(fmt.Sprintf("restore archived session %s failed, rebuilding from source: %v", header.UUID, err))
+			}
+		} else {
+			state.archived = false
+		}
+	}
+	if found && !state.fileDeleted && !state.archived && state.headerHash == hashText(header.RawLine) && state.syncedByteOffset >= headerBytes && state.syncedByteOffset <= info.Size() {
 		anchorOK, err := validateTailAnchor(ctx, database, path, state)
 		if err != nil {
 			return importCounts{}, err
 		}
 		if anchorOK {
 			if state.syncedByteOffset < info.Size() {
-				entries, newOffset, partial, err := parseSessionTail(path, state.syncedByteOffset, state.syncedSeq+1)
+				entries, newOffset, partial, err := format.parseTail(path, state.syncedByteOffset, state.syncedSeq+1)
 				if err != nil {
 					return importCounts{}, err
 				}
@@ -247,7 +273,7 @@ func importSessionFile(ctx context.Context, database *sql.DB, path string, logge
 					}
 					return importCounts{}, nil
 				}
-				return appendSessionEntries(ctx, database, state, header, sessionFile, info, entries, newOffset, partial)
+				return appendSessionEntries(ctx, database, format, state, header, sessionFile, info, entries, newOffset, partial)
 			}
 			if info.Size() == state.fileSize {
 				if info.ModTime().UnixMilli() == state.fileMtimeMS {
@@ -269,7 +295,7 @@ func importSessionFile(ctx context.Context, database *sql.DB, path string, logge
 	if logger != nil {
 		logger.LogDebug(fmt.Sprintf("rebuilding session file: %s", path))
 	}
-	counts, err := rebuildSessionFile(ctx, database, path, sessionFile, info, logger)
+	counts, err := rebuildSessionFile(ctx, database, format, path, sessionFile, info, logger)
 	if err != nil {
 		if logger != nil {
 			logger.LogDebug(fmt.Sprintf("rebuild failed for %s: %v", path, err))
@@ -281,11 +307,19 @@ func importSessionFile(ctx context.Context, database *sql.DB, path string, logge
 			return importCounts{}, err
 		}
 	}
+	if unusableBundle != "" {
+		if err := discardArchiveBundle(ctx, database, state.id, sessionFile, unusableBundle, restoreErr); err != nil {
+			return importCounts{}, err
+		}
+		if logger != nil {
+			logger.LogInfo(fmt.Sprintf("discarded archive bundle %s of rebuilt session %s", unusableBundle, header.UUID))
+		}
+	}
 	return counts, nil
 }
 
-func rebuildSessionFile(ctx context.Context, database *sql.DB, path string, sessionFile string, info os.FileInfo, logger SyncLogger) (importCounts, error) {
-	parsed, err := parseSessionFile(path)
+func rebuildSessionFile(ctx context.Context, database *sql.DB, format sessionFormat, path string, sessionFile string, info os.FileInfo, logger SyncLogger) (importCounts, error) {
+	parsed, err := format.parseFile(path)
 	if err != nil {
 		return importCounts{}, err
 	}
@@ -306,7 +340,7 @@ func rebuildSessionFile(ctx context.Context, database *sql.DB, path string, sess
 	if logger != nil {
 		logger.LogDebug("upserting chat record")
 	}
-	chatID, oldChatID, err := upsertChat(ctx, tx, parsed, sessionFile, repoRoot, info, contentHash, now)
+	chatID, oldChatID, err := upsertChat(ctx, tx, format.runtime, parsed, sessionFile, repoRoot, info, contentHash, now)
 	if err != nil {
 		if logger != nil {
 			logger.LogDebug(fmt.Sprintf("upsertChat failed: %v", err))
@@ -332,7 +366,7 @@ func rebuildSessionFile(ctx context.Context, database *sql.DB, path string, sess
 	if logger != nil {
 		logger.LogDebug("inserting derived rows")
 	}
-	counts, err := insertDerivedRows(ctx, tx, chatID, parsed.header.CWD, repoRoot, messages, results)
+	counts, err := insertDerivedRows(ctx, tx, format, chatID, parsed.header.CWD, repoRoot, messages, results)
 	if err != nil {
 		if logger != nil {
 			logger.LogDebug(fmt.Sprintf("insertDerivedRows failed: %v", err))
@@ -357,14 +391,14 @@ func rebuildSessionFile(ctx context.Context, database *sql.DB, path string, sess
 func loadChatSyncState(ctx context.Context, database *sql.DB, uuid string, sessionFile string) (chatSyncState, bool, error) {
 	var state chatSyncState
 	var syncedSeq, syncedOffset, fileSize, fileMtime sql.NullInt64
-	var headerHash, contentHash, fileDeletedAt sql.NullString
+	var headerHash, contentHash, fileDeletedAt, archivedAt sql.NullString
 	err := database.QueryRowContext(ctx, `
 		SELECT id, session_file, synced_seq, synced_byte_offset, file_size, file_mtime_ms,
-			header_hash, content_hash, file_deleted_at
+			header_hash, content_hash, file_deleted_at, archived_at
 		FROM pi_chats WHERE uuid = ? OR session_file = ?
 		ORDER BY CASE WHEN uuid = ? THEN 0 ELSE 1 END, id LIMIT 1`, uuid, sessionFile, uuid).Scan(
 		&state.id, &state.sessionFile, &syncedSeq, &syncedOffset, &fileSize, &fileMtime,
-		&headerHash, &contentHash, &fileDeletedAt,
+		&headerHash, &contentHash, &fileDeletedAt, &archivedAt,
 	)
 	if err == sql.ErrNoRows {
 		return chatSyncState{}, false, nil
@@ -379,6 +413,7 @@ func loadChatSyncState(ctx context.Context, database *sql.DB, uuid string, sessi
 	state.headerHash = headerHash.String
 	state.contentHash = contentHash.String
 	state.fileDeleted = fileDeletedAt.Valid
+	state.archived = archivedAt.Valid
 	return state, true, nil
 }
 
@@ -397,9 +432,7 @@ func validateTailAnchor(ctx context.Context, database *sql.DB, path string, stat
 	if err != nil {
 		return false, err
 	}
-	anchorSize := int64(len(rawLine) + 1)
-	anchorStart := state.syncedByteOffset - anchorSize
-	if anchorStart < 0 {
+	if hashText(rawLine) != rawHash {
 		return false, nil
 	}
 	file, err := os.Open(path)
@@ -407,11 +440,40 @@ func validateTailAnchor(ctx context.Context, database *sql.DB, path string, stat
 		return false, err
 	}
 	defer file.Close()
-	anchor := make([]byte, anchorSize)
-	if _, err := file.ReadAt(anchor, anchorStart); err != nil {
-		return false, nil
+	if anchor, ok := readAnchorLine(file, state.syncedByteOffset, int64(len(rawLine))); ok && anchor == rawLine {
+		return true, nil
+	}
+
+	// A mirror-only redaction changes the stored line but not the file. Accept
+	// the file line when it is a recorded original of the stored one.
+	origins, err := redactionOrigins(ctx, database, state.id)
+	if err != nil {
+		return false, err
+	}
+	for hash, depth := rawHash, 0; depth <= len(origins); depth++ {
+		origin, ok := origins[hash]
+		if !ok {
+			break
+		}
+		if anchor, ok := readAnchorLine(file, state.syncedByteOffset, origin.size); ok && hashText(anchor) == origin.hash {
+			return true, nil
+		}
+		hash = origin.hash
 	}
-	return string(anchor) == rawLine+"\n" && hashText(rawLine) == rawHash, nil
+	return false, nil
+}
+
+// readAnchorLine reads the size-byte line that ends, with its newline, at end.
+func readAnchorLine(file *os.File, end int64, size int64) (string, bool) {
+	start := end - size - 1
+	if start < 0 {
+		return "", false
+	}
+	anchor := make([]byte, size+1)
+	if _, err := file.ReadAt(anchor, start); err != nil || anchor[size] != '\n' {
+		return "", false
+	}
+	return string(anchor[:size]), true
 }
 
 func hashFileContent(header string, entries []parsedEntry) string {
@@ -423,7 +485,7 @@ func hashFileContent(header string, entries []parsedEntry) string {
 	return hashText(strings.Join(lines, "\n") + "\n")
 }
 
-func appendSessionEntries(ctx context.Context, database *sql.DB, state chatSyncState, header sessionHeader, sessionFile string, info os.FileInfo, entries []parsedEntry, newOffset int64, partial bool) (importCounts, error) {
+func appendSessionEntries(ctx context.Context, database *sql.DB, format sessionFormat, state chatSyncState, header sessionHeader, sessionFile string, info os.FileInfo, entries []parsedEntry, newOffset int64, partial bool) (importCounts, error) {
 	now := time.Now().UTC().Format(time.RFC3339Nano)
 	repoRoot := findRepoRoot(header.CWD)
 	tx, err := database.BeginTx(ctx, nil)
@@ -439,7 +501,7 @@ func appendSessionEntries(ctx context.Context, database *sql.DB, state chatSyncS
 	if err := applyToolResults(ctx, tx, state.id, results); err != nil {
 		return importCounts{}, err
 	}
-	counts, err := insertDerivedRows(ctx, tx, state.id, header.CWD, repoRoot, messages, results)
+	counts, err := insertDerivedRows(ctx, tx, format, state.id, header.CWD, repoRoot, messages, results)
 	if err != nil {
 		return importCounts{}, err
 	}
@@ -518,7 +580,7 @@ func updateIncompleteTailState(ctx context.Context, database *sql.DB, chatID int
 	return err
 }
 
-func upsertChat(ctx context.Context, tx *sql.Tx, parsed parsedSession, sessionFile string, repoRoot string, info os.FileInfo, contentHash string, now string) (int64, int64, error) {
+func upsertChat(ctx context.Context, tx *sql.Tx, runtime string, parsed parsedSession, sessionFile string, repoRoot string, info os.FileInfo, contentHash string, now string) (int64, int64, error) {
 	var existingID int64
 	err := tx.QueryRowContext(ctx, "SELECT id FROM pi_chats WHERE uuid = ? OR session_file = ? ORDER BY id LIMIT 1", parsed.header.UUID, sessionFile).Scan(&existingID)
 	if err != nil && err != sql.ErrNoRows {
@@ -537,12 +599,13 @@ func upsertChat(ctx context.Context, tx *sql.Tx, parsed parsedSession, sessionFi
 	if existingID == 0 {
 		result, err := tx.ExecContext(ctx, `
 			INSERT INTO pi_chats (
-				uuid, version, cwd, session_file, parent_session_file, repo_root, file_size,
+				uuid, runtime, version, cwd, session_file, parent_session_file, repo_root, file_size,
 				file_mtime_ms, header_hash, content_hash, synced_seq, synced_byte_offset,
 				last_synced_at, sync_status, last_ingest_started_at, last_ingest_completed_at,
 				raw_header, created_at, updated_at
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			parsed.header.UUID,
+			runtime,
 			parsed.header.Version,
 			nullString(parsed.header.CWD),
 			sessionFile,
@@ -571,14 +634,16 @@ func upsertChat(ctx context.Context, tx *sql.Tx, parsed parsedSession, sessionFi
 
 	_, err = tx.ExecContext(ctx, `
 		UPDATE pi_chats SET
-				uuid = ?, version = ?, cwd = ?, name = NULL, session_file = ?, parent_session_file = ?,
+				uuid = ?, runtime = ?, version = ?, cwd = ?, name = NULL, session_file = ?, parent_session_file = ?,
 			parent_chat_id = NULL, repo_root = ?, file_size = ?, file_mtime_ms = ?,
 			header_hash = ?, content_hash = ?, file_deleted_at = NULL,
+			archived_at = NULL, archive_path = NULL,
 		synced_seq = ?, synced_byte_offset = ?, last_synced_at = ?,
 			sync_status = ?, sync_error = NULL, last_ingest_started_at = ?,
 			last_ingest_completed_at = ?, raw_header = ?, created_at = ?, updated_at = ?
 		WHERE id = ?`,
 		parsed.header.UUID,
+		runtime,
 		parsed.header.Version,
 		nullString(parsed.header.CWD),
 		sessionFile,
@@ -610,6 +675,7 @@ func deleteChatDerivedRows(ctx context.Context, tx *sql.Tx, chatID int64) error
 		"DELETE FROM pi_file_refs WHERE chat_id = ?",
 		"DELETE FROM pi_tool_calls WHERE chat_id = ?",
 		"DELETE FROM pi_messages WHERE chat_id = ?",
+		"DELETE FROM pi_archived_usage WHERE chat_id = ?",
 	} {
 		if _, err := tx.ExecContext(ctx, statement, chatID); err != nil {
 			return err
@@ -666,6 +732,17 @@ func insertMessages(ctx context.Context, tx *sql.Tx, chatID int64, entries []par
 				text:      text,
 			}
 		}
+		for _, block := range entry.toolResults {
+			if block.toolCallID == "" {
+				continue
+			}
+			results[block.toolCallID] = toolResultInfo{
+				messageID: messageID,
+				entryID:   entry.ID,
+				isError:   block.isError,
+				text:      block.text,
+			}
+		}
 	}
 	return messages, results, nil
 }
@@ -675,7 +752,7 @@ type derivedCounts struct {
 	fileRefs  int
 }
 
-func insertDerivedRows(ctx context.Context, tx *sql.Tx, chatID int64, cwd string, repoRoot string, messages []messageInsert, results map[string]toolResultInfo) (derivedCounts, error) {
+func insertDerivedRows(ctx context.Context, tx *sql.Tx, format sessionFormat, chatID int64, cwd string, repoRoot string, messages []messageInsert, results map[string]toolResultInfo) (derivedCounts, error) {
 	counts := derivedCounts{}
 	seenRefs := make(map[string]struct{})
 	for _, message := range messages {
@@ -728,10 +805,21 @@ func insertDerivedRows(ctx context.Context, tx *sql.Tx, chatID int64, cwd string
 					return counts, err
 				}
 				counts.toolCalls++
-				if isFileRefTool(block.Name) {
+				toolName := strings.ToLower(block.Name)
+				if op := fileRefToolOp(toolName); op != "" {
 					rawPath := toolArgumentPath(block.Arguments)
 					if rawPath != "" {
-						inserted, err := insertFileRef(ctx, tx, seenRefs, chatID, message.rowID, entry.ID, block.ID, "tool_call", strings.ToLower(block.Name), strings.ToLower(block.Name), rawPath, cwd, repoRoot, entry.Timestamp)
+						inserted, err := insertFileRef(ctx, tx, seenRefs, chatID, message.rowID, entry.ID, block.ID, "tool_call", op, toolName, rawPath, cwd, repoRoot, "high", entry.Timestamp)
+						if err != nil {
+							return counts, err
+						}
+						if inserted {
+							counts.fileRefs++
+						}
+					}
+				} else if toolName == "bash" && format.bashFileRefs {
+					for _, ref := range bashCommandPaths(toolArgumentCommand(block.Arguments)) {
+						inserted, err := insertFileRef(ctx, tx, seenRefs, chatID, message.rowID, entry.ID, block.ID, "tool_call", ref.op, toolName, ref.path, cwd, repoRoot, "heuristic", entry.Timestamp)
 						if err != nil {
 							return counts, err
 						}
@@ -760,12 +848,16 @@ func insertDerivedRows(ctx context.Context, tx *sql.Tx, chatID int64, cwd string
 	return counts, nil
 }
 
-func isFileRefTool(name string) bool {
+// fileRefToolOp maps a file tool to the op recorded in pi_file_refs. Claude
+// Code's MultiEdit and NotebookEdit count as edits.
+func fileRefToolOp(name string) string {
 	switch strings.ToLower(name) {
 	case "read", "edit", "write":
-		return true
+		return strings.ToLower(name)
+	case "multiedit", "notebookedit":
+		return "edit"
 	default:
-		return false
+		return ""
 	}
 }
 
@@ -777,7 +869,7 @@ func toolArgumentPath(arguments json.RawMessage) string {
 	if err := json.Unmarshal(arguments, &values); err != nil {
 		return ""
 	}
-	for _, key := range []string{"path", "file_path"} {
+	for _, key := range []string{"path", "file_path", "notebook_path"} {
 		if value, ok := values[key].(string); ok {
 			return value
 		}
@@ -785,12 +877,22 @@ func toolArgumentPath(arguments json.RawMessage) string {
 	return ""
 }
 
+func toolArgumentCommand(arguments json.RawMessage) string {
+	var values struct {
+		Command string `json:"command"`
+	}
+	if len(arguments) == 0 || json.Unmarshal(arguments, &values) != nil {
+		return ""
+	}
+	return values.Command
+}
+
 func insertAggregateRefs(ctx context.Context, tx *sql.Tx, seen map[string]struct{}, chatID int64, messageID int64, entry parsedEntry, source string, cwd string, repoRoot string) (int, error) {
 	count := 0
 	readOp := source + "_read"
 	modifiedOp := source + "_modified"
 	for _, rawPath := range entry.Details.ReadFiles {
-		inserted, err := insertFileRef(ctx, tx, seen, chatID, messageID, entry.ID, "", source, readOp, "", rawPath, cwd, repoRoot, entry.Timestamp)
+		inserted, err := insertFileRef(ctx, tx, seen, chatID, messageID, entry.ID, "", source, readOp, "", rawPath, cwd, repoRoot, "high", entry.Timestamp)
 		if err != nil {
 			return count, err
 		}
@@ -799,7 +901,7 @@ func insertAggregateRefs(ctx context.Context, tx *sql.Tx, seen map[string]struct
 		}
 	}
 	for _, rawPath := range entry.Details.ModifiedFiles {
-		inserted, err := insertFileRef(ctx, tx, seen, chatID, messageID, entry.ID, "", source, modifiedOp, "", rawPath, cwd, repoRoot, entry.Timestamp)
+		inserted, err := insertFileRef(ctx, tx, seen, chatID, messageID, entry.ID, "", source, modifiedOp, "", rawPath, cwd, repoRoot, "high", entry.Timestamp)
 		if err != nil {
 			return count, err
 		}
@@ -810,7 +912,7 @@ func insertAggregateRefs(ctx context.Context, tx *sql.Tx, seen map[string]struct
 	return count, nil
 }
 
-func insertFileRef(ctx context.Context, tx *sql.Tx, seen map[string]struct{}, chatID int64, messageID int64, entryID string, toolCallID string, source string, op string, toolName string, rawPath string, cwd string, repoRoot string, timestamp string) (bool, error) {
+func insertFileRef(ctx context.Context, tx *sql.Tx, seen map[string]struct{}, chatID int64, messageID int64, entryID string, toolCallID string, source string, op string, toolName string, rawPath string, cwd string, repoRoot string, confidence string, timestamp string) (bool, error) {
 	normalized := normalizePath(rawPath, cwd, repoRoot)
 	key := fmt.Sprintf("%d\x00%d\x00%s\x00%s\x00%s\x00%s\x00%s", chatID, messageID, source, op, toolCallID, normalized.absPath, normalized.filePathRel)
 	if _, ok := seen[key]; ok {
@@ -821,7 +923,7 @@ func insertFileRef(ctx context.Context, tx *sql.Tx, seen map[string]struct{}, ch
 		INSERT INTO pi_file_refs (
 			chat_id, message_id, entry_id, tool_call_id, source, op, tool_name,
 			raw_path, abs_path, repo_root, file_path_rel, cwd_rel_path, confidence, timestamp
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, 'high', ?)`,
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		chatID,
 		messageID,
 		entryID,
@@ -834,6 +936,7 @@ func insertFileRef(ctx context.Context, tx *sql.Tx, seen map[string]struct{}, ch
 		nullString(normalized.repoRoot),
 		nullString(normalized.filePathRel),
 		nullString(normalized.cwdRelPath),
+		confidence,
 		timestamp,
 	)
 	return err == nil, err
@@ -862,7 +965,7 @@ func refreshChatDerivedMetadata(ctx context.Context, tx *sql.Tx, chatID int64, e
 			message_count = (SELECT COUNT(*) FROM pi_messages WHERE chat_id = ?),
 			tool_call_count = (SELECT COUNT(*) FROM pi_tool_calls WHERE chat_id = ?),
 			file_ref_count = (SELECT COUNT(*) FROM pi_file_refs WHERE chat_id = ?),
-			last_message_at = (SELECT timestamp FROM pi_messages WHERE chat_id = ? ORDER BY seq DESC LIMIT 1),
+			last_message_at = (SELECT timestamp FROM pi_messages WHERE chat_id = ? AND timestamp != '' ORDER BY seq DESC LIMIT 1),
 			updated_at = ?
 		WHERE id = ?`,
 		nameSet,
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Session JSONL Parser
- * Block-UUID: 0f9da76e-5b96-4339-89d6-4d6a20e86254
- * Parent-UUID: N/A
- * Version: 1.1.0
- * Description: Parses complete Pi session JSONL lines losslessly from the file start or a committed byte offset.
+ * Block-UUID: c775570f-5fad-4c04-b8c0-f2c7c1ed50e2
+ * Parent-UUID: 0f9da76e-5b96-4339-89d6-4d6a20e86254
+ * Version: 1.2.0
+ * Description: Parses complete Pi session JSONL lines losslessly from the file start or a committed byte offset; entries can carry several tool results for runtimes that batch them in one line.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0, v1.1.0)
+ * Authors: Codex GPT-5 (v1.0.0, v1.1.0), agent (v1.2.0)
  */
 
 
@@ -54,6 +54,9 @@ type parsedEntry struct {
 	RawLine   string
 	RawHash   string
 	Seq       int
+	// toolResults holds tool results for runtimes that answer several tool
+	// calls in one line (Claude Code); Pi uses Message.ToolCallID instead.
+	toolResults []toolResultBlock
 }
 
 type messagePayload struct {
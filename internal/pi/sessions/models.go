@@ -1,19 +1,25 @@
 /**
  * Component: Pi Sessions Data Models
- * Block-UUID: 5f2b9c84-7d31-4e0a-9b6c-1a8e3f4d2c70
- * Parent-UUID: f8de6294-a1da-472a-9aca-48aa22f5022e
- * Version: 1.6.0
- * Description: Defines phase-one sync and query data structures for the Pi sessions mirror; adds message-preview models for the resume picker's split-pane view, a FirstUserText field on ListResult for resume-picker row titles, and SessionUsage/TouchedFile models that back the HUD sidebar's token gauge and touched-file tree. ContextTokens now includes output tokens to match Pi's context window calculation.
+ * Block-UUID: 564021c6-e6bc-47e0-96ab-989908893b6c
+ * Parent-UUID: 5dcfb391-b08b-4344-a40e-356f1579baa3
+ * Version: 1.13.0
+ * Description: Defines sync and query data structures for the agent sessions mirror; adds message-preview models for the resume picker, SessionUsage/TouchedFile models for the HUD sidebar, a runtime (pi or claude) on sync options and results, usage report, blame timeline, redaction, transcript export, branch diff, and retention/archive models, plus StreamOptions and StreamEvent for the live event stream.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0), MiMo-v2.5-pro (v1.1.0, v1.2.0), claude-opus-4-8 (v1.3.0, v1.4.0, v1.5.0)
+ * Authors: Codex GPT-5 (v1.0.0), MiMo-v2.5-pro (v1.1.0, v1.2.0), claude-opus-4-8 (v1.3.0, v1.4.0, v1.5.0), agent (v1.7.0), agent (v1.8.0), agent (v1.9.0), agent (v1.10.0), agent (v1.11.0), agent (v1.12.0), agent (v1.13.0)
  */
 
 package sessions
 
+import (
+	"encoding/json"
+	"time"
+)
+
 type SyncOptions struct {
 	SessionsDir string
 	DBPath      string
+	Runtime     string // "pi" (default) or "claude"; selects the transcript format of SessionsDir
 	Logger      SyncLogger
 }
 
@@ -25,6 +31,7 @@ type SyncLogger interface {
 }
 
 type SyncResult struct {
+	Runtime           string      `json:"runtime"`
 	SessionsDir       string      `json:"sessions_dir"`
 	DBPath            string      `json:"db_path"`
 	FilesScanned      int         `json:"files_scanned"`
@@ -45,6 +52,7 @@ type QueryOptions struct {
 	File              string
 	AbsFile           string
 	Repo              string
+	Runtime           string // "pi" or "claude"; empty matches both
 	SessionID         string
 	Tool              string
 	Op                string
@@ -113,6 +121,7 @@ type MatchRange struct {
 // SessionQueryResult represents an aggregated session-level query result.
 type SessionQueryResult struct {
 	SessionID            string   `json:"session_id"`
+	Runtime              string   `json:"runtime"`
 	Title                string   `json:"title"`
 	Name                 string   `json:"name,omitempty"`
 	CWD                  string   `json:"cwd"`
@@ -135,6 +144,7 @@ type SessionQueryResult struct {
 type ListOptions struct {
 	DBPath   string
 	Repo     string
+	Runtime  string
 	Since    string
 	Until    string
 	Provider string
@@ -146,6 +156,7 @@ type ListOptions struct {
 // ListResult represents a single session in list output.
 type ListResult struct {
 	SessionID        string `json:"session_id"`
+	Runtime          string `json:"runtime"`
 	Name             string `json:"name,omitempty"`
 	CWD              string `json:"cwd"`
 	RepoRoot         string `json:"repo_root,omitempty"`
@@ -205,6 +216,258 @@ type TouchedFile struct {
 	Op          string `json:"op,omitempty"`
 }
 
+// Usage groupings accepted by UsageOptions.GroupBy.
+const (
+	UsageGroupDay      = "day"
+	UsageGroupRepo     = "repo"
+	UsageGroupModel    = "model"
+	UsageGroupProvider = "provider"
+	UsageGroupSession  = "session"
+	UsageGroupRuntime  = "runtime"
+)
+
+// UsageGroups lists every accepted grouping, in help-text order.
+var UsageGroups = []string{UsageGroupDay, UsageGroupRepo, UsageGroupModel, UsageGroupProvider, UsageGroupSession, UsageGroupRuntime}
+
+// UsageOptions configures token and cost aggregation over the mirror. Since and
+// Until bound assistant message timestamps, not session creation, so a long
+// session is split correctly across days; a plain date as Until includes that
+// whole (UTC) day. Model and Provider match the values
+// recorded on each assistant message.
+type UsageOptions struct {
+	DBPath    string
+	GroupBy   string
+	SessionID string
+	Repo      string
+	Runtime   string
+	Since    string
+	Until    string
+	Provider string
+	Model    string
+	Limit    int
+	Pricing  *PricingTable
+}
+
+// UsageTotals is the token and cost sum for one group (or the whole report).
+// Cost is the pricing-table estimate; messages whose model has no price fall
+// back to the provider-reported cost (ReportedCost) and are counted in
+// UnpricedMessages when neither is available.
+type UsageTotals struct {
+	Sessions         int     `json:"sessions"`
+	Messages         int     `json:"messages"`
+	InputTokens      int64   `json:"input_tokens"`
+	OutputTokens     int64   `json:"output_tokens"`
+	CacheRead        int64   `json:"cache_read"`
+	CacheWrite       int64   `json:"cache_write"`
+	TotalTokens      int64   `json:"total_tokens"`
+	Cost             float64 `json:"cost"`
+	ReportedCost     float64 `json:"reported_cost"`
+	UnpricedMessages int     `json:"unpriced_messages"`
+	Compactions      int     `json:"compactions"`
+}
+
+// UsageRow is one group in a usage report. Title and RepoRoot are set when
+// grouping by session.
+type UsageRow struct {
+	Key      string `json:"key"`
+	Title    string `json:"title,omitempty"`
+	RepoRoot string `json:"repo_root,omitempty"`
+	UsageTotals
+}
+
+// CompactedSession is a session that hit context compaction at least once.
+type CompactedSession struct {
+	SessionID   string  `json:"session_id"`
+	Runtime     string  `json:"runtime"`
+	Title       string  `json:"title,omitempty"`
+	RepoRoot    string  `json:"repo_root,omitempty"`
+	Compactions int     `json:"compactions"`
+	TotalTokens int64   `json:"total_tokens"`
+	Cost        float64 `json:"cost"`
+}
+
+// UsageReport is the result of Usage.
+type UsageReport struct {
+	GroupBy   string             `json:"group_by"`
+	Currency  string             `json:"currency"`
+	Rows      []UsageRow         `json:"rows"`
+	Total     UsageTotals        `json:"total"`
+	Compacted []CompactedSession `json:"compacted_sessions"`
+}
+
+// BlameOptions configures per-file agent activity history. AbsPath is always
+// matched; RepoRoot/FilePathRel additionally match references recorded from
+// sessions that ran in another checkout path of the same repo layout.
+type BlameOptions struct {
+	DBPath       string
+	AbsPath      string
+	RepoRoot     string
+	FilePathRel  string
+	Runtime      string
+	Since        string
+	Until        string
+	EditsOnly    bool
+	Commits      bool
+	CommitWindow time.Duration
+	Lines        bool
+	Limit        int
+}
+
+// BlameCommit is a git commit that plausibly captured an agent edit. Match is
+// "time" when the commit only falls inside the window after the edit and
+// "content" when it also added lines the edit wrote.
+type BlameCommit struct {
+	Hash       string `json:"hash"`
+	AuthorTime string `json:"author_time"`
+	Author     string `json:"author"`
+	Subject    string `json:"subject"`
+	Match      string `json:"match"`
+}
+
+// BlameEvent is one read, edit, or write of the file by an agent tool call.
+type BlameEvent struct {
+	SessionID     string        `json:"session_id"`
+	Runtime       string        `json:"runtime"`
+	SessionTitle  string        `json:"session_title,omitempty"`
+	EntryID       string        `json:"entry_id"`
+	ToolCallID    string        `json:"tool_call_id,omitempty"`
+	ToolName      string        `json:"tool_name,omitempty"`
+	Op            string        `json:"op"`
+	Confidence    string        `json:"confidence"`
+	Timestamp     string        `json:"timestamp"`
+	Prompt        string        `json:"prompt,omitempty"`
+	ArgumentsJSON string        `json:"arguments_json,omitempty"`
+	IsError       bool          `json:"is_error,omitempty"`
+	Commits       []BlameCommit `json:"commits,omitempty"`
+}
+
+// BlameLineRange attributes a range of current lines (1-based, inclusive) to
+// the most recent edit whose written text is still present there.
+type BlameLineRange struct {
+	Start      int    `json:"start"`
+	End        int    `json:"end"`
+	SessionID  string `json:"session_id"`
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	ToolName   string `json:"tool_name,omitempty"`
+	Timestamp  string `json:"timestamp"`
+	Prompt     string `json:"prompt,omitempty"`
+}
+
+// BlameResult is the result of Blame.
+type BlameResult struct {
+	AbsPath       string           `json:"abs_path"`
+	RepoRoot      string           `json:"repo_root,omitempty"`
+	FilePathRel   string           `json:"file_path_rel,omitempty"`
+	Events        []BlameEvent     `json:"events"`
+	Lines         []BlameLineRange `json:"lines,omitempty"`
+	UnplacedEdits int              `json:"unplaced_edits,omitempty"`
+}
+
+// RedactOptions configures a secret redaction pass. Detectors come from
+// CompileRedactionDetectors. RewriteSource also rewrites the session JSONL;
+// DryRun reports findings without changing anything.
+type RedactOptions struct {
+	DBPath        string
+	SessionID     string
+	Runtime       string
+	Detectors     []RedactionDetector
+	RewriteSource bool
+	DryRun        bool
+}
+
+// RedactedSession reports what a redaction pass changed in one session.
+// Findings counts matches per detector in raw transcript lines.
+// ArchiveRewritten is set when an archived session's bundle was rewritten.
+type RedactedSession struct {
+	SessionID        string         `json:"session_id"`
+	SessionFile      string         `json:"session_file"`
+	Messages         int            `json:"messages"`
+	ToolCalls        int            `json:"tool_calls"`
+	Findings         map[string]int `json:"findings"`
+	SourceRewritten  bool           `json:"source_rewritten,omitempty"`
+	ArchiveRewritten bool           `json:"archive_rewritten,omitempty"`
+	Error            string         `json:"error,omitempty"`
+
+	summaryChanged bool
+}
+
+// RedactResult is the result of Redact.
+type RedactResult struct {
+	DryRun            bool              `json:"dry_run"`
+	SessionsScanned   int               `json:"sessions_scanned"`
+	SessionsAffected  int               `json:"sessions_affected"`
+	MessagesRedacted  int               `json:"messages_redacted"`
+	ToolCallsRedacted int               `json:"tool_calls_redacted"`
+	SourcesRewritten  int               `json:"sources_rewritten"`
+	Findings          map[string]int    `json:"findings"`
+	Sessions          []RedactedSession `json:"sessions"`
+}
+
+// ExportOptions configures a transcript export. LeafID defaults to the
+// session's current leaf. Detectors, when set, are applied to every exported
+// string. MaxOutput and MaxDiffLines truncate tool output and edit diffs
+// (0 keeps everything).
+type ExportOptions struct {
+	DBPath          string
+	SessionID       string
+	LeafID          string
+	IncludeThinking bool
+	Detectors       []RedactionDetector
+	MaxOutput       int
+	MaxDiffLines    int
+}
+
+// ExportToolCall is a tool call on the exported branch with its result. Diff
+// is a unified-style diff for edit and write calls.
+type ExportToolCall struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Path            string `json:"path,omitempty"`
+	Arguments       string `json:"arguments"`
+	Diff            string `json:"diff,omitempty"`
+	Result          string `json:"result,omitempty"`
+	IsError         bool   `json:"is_error"`
+	ResultTruncated bool   `json:"result_truncated,omitempty"`
+	DiffTruncated   bool   `json:"diff_truncated,omitempty"`
+}
+
+// ExportEntry is one user, assistant, or summary entry on the branch.
+type ExportEntry struct {
+	EntryID   string           `json:"entry_id"`
+	Type      string           `json:"type"`
+	Role      string           `json:"role"`
+	Timestamp string           `json:"timestamp"`
+	Model     string           `json:"model,omitempty"`
+	Text      string           `json:"text,omitempty"`
+	Thinking  string           `json:"thinking,omitempty"`
+	ToolCalls []ExportToolCall `json:"tool_calls,omitempty"`
+}
+
+// ExportFile summarizes how the branch touched one file.
+type ExportFile struct {
+	Path   string `json:"path"`
+	Reads  int    `json:"reads"`
+	Edits  int    `json:"edits"`
+	Writes int    `json:"writes"`
+}
+
+// ExportTranscript is the active branch of a session ready to render.
+type ExportTranscript struct {
+	SessionID     string        `json:"session_id"`
+	Runtime       string        `json:"runtime"`
+	Title         string        `json:"title,omitempty"`
+	CWD           string        `json:"cwd,omitempty"`
+	RepoRoot      string        `json:"repo_root,omitempty"`
+	Provider      string        `json:"provider,omitempty"`
+	Model         string        `json:"model,omitempty"`
+	Leaf          string        `json:"leaf"`
+	CreatedAt     string        `json:"created_at,omitempty"`
+	LastMessageAt string        `json:"last_message_at,omitempty"`
+	Redacted      int           `json:"redacted"`
+	Entries       []ExportEntry `json:"entries"`
+	Files         []ExportFile  `json:"files"`
+}
+
 // ShowOptions configures session detail view.
 type ShowOptions struct {
 	DBPath    string
@@ -214,6 +477,7 @@ type ShowOptions struct {
 // ShowResult represents detailed session information.
 type ShowResult struct {
 	SessionID     string `json:"session_id"`
+	Runtime       string `json:"runtime"`
 	Name          string `json:"name,omitempty"`
 	CWD           string `json:"cwd"`
 	RepoRoot      string `json:"repo_root,omitempty"`
@@ -228,3 +492,178 @@ type ShowResult struct {
 	LastUserText  string `json:"last_user_text,omitempty"`
 	LastText      string `json:"last_text,omitempty"`
 }
+
+// DiffOptions configures a comparison of two session branches. A and B are
+// session ids, optionally suffixed with @<leaf-entry-id>.
+type DiffOptions struct {
+	DBPath       string
+	A            string
+	B            string
+	Pricing      *PricingTable
+	MaxDiffLines int
+}
+
+// DiffTurn is one prompt after the common ancestor and what the agent did in
+// response. The first turn has no prompt when the branches diverge mid-turn.
+type DiffTurn struct {
+	EntryID   string   `json:"entry_id"`
+	Prompt    string   `json:"prompt,omitempty"`
+	Reply     string   `json:"reply,omitempty"`
+	ToolCalls int      `json:"tool_calls"`
+	Errors    int      `json:"errors"`
+	Edited    []string `json:"edited,omitempty"`
+}
+
+// DiffFileSide is how one branch touched a file. FinalEdit is the diff of the
+// last edit or write call.
+type DiffFileSide struct {
+	Reads     int    `json:"reads"`
+	Edits     int    `json:"edits"`
+	Writes    int    `json:"writes"`
+	FinalEdit string `json:"final_edit,omitempty"`
+	finalText string
+}
+
+// DiffFile compares one file across both branches. SameFinal is true when
+// both branches' last edits wrote the same text.
+type DiffFile struct {
+	Path      string        `json:"path"`
+	A         *DiffFileSide `json:"a,omitempty"`
+	B         *DiffFileSide `json:"b,omitempty"`
+	SameFinal bool          `json:"same_final"`
+}
+
+// DiffSide summarizes one branch after the common ancestor.
+type DiffSide struct {
+	SessionID       string         `json:"session_id"`
+	Runtime         string         `json:"runtime"`
+	Title           string         `json:"title,omitempty"`
+	Leaf            string         `json:"leaf"`
+	Models          []string       `json:"models"`
+	Entries         int            `json:"entries"`
+	Prompts         int            `json:"prompts"`
+	ToolCalls       int            `json:"tool_calls"`
+	Errors          int            `json:"errors"`
+	ToolCallsByName map[string]int `json:"tool_calls_by_name"`
+	Usage           UsageTotals    `json:"usage"`
+	Started         string         `json:"started,omitempty"`
+	Ended           string         `json:"ended,omitempty"`
+	Turns           []DiffTurn     `json:"turns"`
+}
+
+// SessionDiff is the result of Diff.
+type SessionDiff struct {
+	CommonAncestor string     `json:"common_ancestor,omitempty"`
+	SharedEntries  int        `json:"shared_entries"`
+	Currency       string     `json:"currency"`
+	A              DiffSide   `json:"a"`
+	B              DiffSide   `json:"b"`
+	Files          []DiffFile `json:"files"`
+}
+
+// GCOptions configures a retention pass over the mirror. Sessions whose last
+// message is older than OlderThan are archived unless a keep rule applies.
+// Repo limits the pass to one repository root; KeepRepos are never archived.
+// ArchiveDir defaults to an "archive" directory beside the database.
+type GCOptions struct {
+	DBPath          string
+	ArchiveDir      string
+	OlderThan       time.Duration
+	Runtime         string
+	Repo            string
+	KeepRepos       []string
+	KeepNamed       bool
+	KeepWithLessons bool
+	DryRun          bool
+	Vacuum          bool
+}
+
+// GCSession is one session considered by a retention pass. Kept is the keep
+// rule that spared it, empty when the session was archived.
+type GCSession struct {
+	SessionID     string `json:"session_id"`
+	Runtime       string `json:"runtime"`
+	Title         string `json:"title"`
+	RepoRoot      string `json:"repo_root,omitempty"`
+	LastMessageAt string `json:"last_message_at,omitempty"`
+	Lines         int    `json:"lines"`
+	RawBytes      int64  `json:"raw_bytes"`
+	ArchivePath   string `json:"archive_path,omitempty"`
+	ArchiveBytes  int64  `json:"archive_bytes,omitempty"`
+	Kept          string `json:"kept,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// GCResult is the result of GC. Database sizes include the WAL file.
+type GCResult struct {
+	DryRun           bool        `json:"dry_run"`
+	Cutoff           string      `json:"cutoff"`
+	SessionsScanned  int         `json:"sessions_scanned"`
+	SessionsArchived int         `json:"sessions_archived"`
+	SessionsKept     int         `json:"sessions_kept"`
+	RawBytesDropped  int64       `json:"raw_bytes_dropped"`
+	ArchiveBytes     int64       `json:"archive_bytes"`
+	Vacuumed         bool        `json:"vacuumed"`
+	DBBytesBefore    int64       `json:"db_bytes_before"`
+	DBBytesAfter     int64       `json:"db_bytes_after"`
+	Sessions         []GCSession `json:"sessions"`
+}
+
+// RestoreOptions configures restoring archived sessions into the mirror.
+// WriteSource recreates a missing source JSONL from the bundle.
+type RestoreOptions struct {
+	DBPath      string
+	SessionIDs  []string
+	All         bool
+	WriteSource bool
+}
+
+// RestoredSession reports one restored session.
+type RestoredSession struct {
+	SessionID     string `json:"session_id"`
+	ArchivePath   string `json:"archive_path"`
+	Lines         int    `json:"lines"`
+	SourceWritten bool   `json:"source_written,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// StreamOptions configures a live event stream. Backlog replays the events of
+// the last N entries before following. MaxText truncates message and tool
+// result text (0 uses the default, negative keeps everything).
+type StreamOptions struct {
+	DBPath    string
+	SessionID string
+	Runtime   string
+	Repo      string
+	Tool      string
+	Op        string
+	Events    []string
+	Backlog   int
+	MaxText   int
+}
+
+// StreamEvent is one normalized NDJSON event. Kind refines Event: the origin
+// of an error (tool, assistant, sync) or the source of a file reference
+// (tool_call, compaction, branch_summary).
+type StreamEvent struct {
+	Event      string          `json:"event"`
+	Kind       string          `json:"kind,omitempty"`
+	SessionID  string          `json:"session_id"`
+	Runtime    string          `json:"runtime"`
+	RepoRoot   string          `json:"repo_root,omitempty"`
+	EntryID    string          `json:"entry_id,omitempty"`
+	Seq        int             `json:"seq"`
+	Timestamp  string          `json:"timestamp"`
+	EntryType  string          `json:"entry_type,omitempty"`
+	Role       string          `json:"role,omitempty"`
+	Model      string          `json:"model,omitempty"`
+	ToolCallID string          `json:"tool_call_id,omitempty"`
+	Tool       string          `json:"tool,omitempty"`
+	Op         string          `json:"op,omitempty"`
+	Path       string          `json:"path,omitempty"`
+	AbsPath    string          `json:"abs_path,omitempty"`
+	Arguments  json.RawMessage `json:"arguments,omitempty"`
+	IsError    bool            `json:"is_error,omitempty"`
+	Text       string          `json:"text,omitempty"`
+	Truncated  bool            `json:"truncated,omitempty"`
+}
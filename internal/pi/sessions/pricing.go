@@ -0,0 +1,135 @@
+/**
+ * Component: Session Usage Pricing Table
+ * Block-UUID: 6a1f0c2e-93d4-4b57-8e61-2f7c9b0d4a18
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Loads, saves, and looks up the local, editable per-model token price table used to estimate session costs, with built-in defaults for common models.
+ * Language: Go
+ * Created-at: 2026-10-18T15:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ModelPrice is the price of one model in currency units per million tokens.
+type ModelPrice struct {
+	Input      float64 `json:"input"`
+	Output     float64 `json:"output"`
+	CacheRead  float64 `json:"cache_read"`
+	CacheWrite float64 `json:"cache_write"`
+}
+
+// Cost returns the price of the given token counts.
+func (p ModelPrice) Cost(input, output, cacheRead, cacheWrite int64) float64 {
+	return (float64(input)*p.Input +
+		float64(output)*p.Output +
+		float64(cacheRead)*p.CacheRead +
+		float64(cacheWrite)*p.CacheWrite) / 1_000_000
+}
+
+// PricingTable maps model names to prices. Keys match a model exactly or as a
+// prefix ("claude-sonnet-4" prices "claude-sonnet-4-20250514"); the longest
+// matching key wins. The table is plain JSON so teams can edit it to match
+// their negotiated rates.
+type PricingTable struct {
+	Currency string                `json:"currency"`
+	Models   map[string]ModelPrice `json:"models"`
+}
+
+// DefaultPricingTable returns list prices (USD per million tokens) for common
+// models. They are a starting point; edit the pricing file to override them.
+func DefaultPricingTable() *PricingTable {
+	return &PricingTable{
+		Currency: "USD",
+		Models: map[string]ModelPrice{
+			"claude-opus-4":     {Input: 15, Output: 75, CacheRead: 1.5, CacheWrite: 18.75},
+			"claude-opus-4-5":   {Input: 5, Output: 25, CacheRead: 0.5, CacheWrite: 6.25},
+			"claude-sonnet-4":   {Input: 3, Output: 15, CacheRead: 0.3, CacheWrite: 3.75},
+			"claude-3-7-sonnet": {Input: 3, Output: 15, CacheRead: 0.3, CacheWrite: 3.75},
+			"claude-3-5-sonnet": {Input: 3, Output: 15, CacheRead: 0.3, CacheWrite: 3.75},
+			"claude-haiku-4-5":  {Input: 1, Output: 5, CacheRead: 0.1, CacheWrite: 1.25},
+			"claude-3-5-haiku":  {Input: 0.8, Output: 4, CacheRead: 0.08, CacheWrite: 1},
+			"gpt-5":             {Input: 1.25, Output: 10, CacheRead: 0.125},
+			"gpt-5-mini":        {Input: 0.25, Output: 2, CacheRead: 0.025},
+			"gpt-4.1":           {Input: 2, Output: 8, CacheRead: 0.5},
+			"gpt-4.1-mini":      {Input: 0.4, Output: 1.6, CacheRead: 0.1},
+			"gpt-4o":            {Input: 2.5, Output: 10, CacheRead: 1.25},
+			"gpt-4o-mini":       {Input: 0.15, Output: 0.6, CacheRead: 0.075},
+			"o3":                {Input: 2, Output: 8, CacheRead: 0.5},
+			"o4-mini":           {Input: 1.1, Output: 4.4, CacheRead: 0.275},
+			"gemini-2.5-pro":    {Input: 1.25, Output: 10, CacheRead: 0.31},
+			"gemini-2.5-flash":  {Input: 0.3, Output: 2.5, CacheRead: 0.075},
+		},
+	}
+}
+
+// LoadPricingTable reads a pricing file. A missing file yields the defaults so
+// usage reports work before anyone has created one.
+func LoadPricingTable(path string) (*PricingTable, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return DefaultPricingTable(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read pricing table: %w", err)
+	}
+	var table PricingTable
+	if err := json.Unmarshal(data, &table); err != nil {
+		return nil, fmt.Errorf("parse pricing table %s: %w", path, err)
+	}
+	if table.Currency == "" {
+		table.Currency = "USD"
+	}
+	if table.Models == nil {
+		table.Models = map[string]ModelPrice{}
+	}
+	return &table, nil
+}
+
+// WritePricingTable saves a pricing table as indented JSON with sorted keys.
+func WritePricingTable(path string, table *PricingTable) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("create pricing directory: %w", err)
+	}
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Lookup returns the price for a model. Provider prefixes such as
+// "anthropic/claude-sonnet-4" are ignored.
+func (t *PricingTable) Lookup(model string) (ModelPrice, bool) {
+	if t == nil || model == "" {
+		return ModelPrice{}, false
+	}
+	model = strings.ToLower(model)
+	if i := strings.LastIndex(model, "/"); i >= 0 {
+		model = model[i+1:]
+	}
+	if price, ok := t.Models[model]; ok {
+		return price, true
+	}
+	keys := make([]string, 0, len(t.Models))
+	for key := range t.Models {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+	for _, key := range keys {
+		if strings.HasPrefix(model, strings.ToLower(key)) {
+			return t.Models[key], true
+		}
+	}
+	return ModelPrice{}, false
+}
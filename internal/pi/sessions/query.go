@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Query Engine
- * Block-UUID: 9a4c7e21-3b58-4d6f-8c12-6e0d9b5a1f44
- * Parent-UUID: 068cac86-56fe-46d2-b8f0-e57cee17216f
- * Version: 1.8.0
- * Description: Executes phase-one discovery queries over imported Pi sessions; sanitizes full-text terms as FTS5 phrases for literal matching, fixes FTS table aliasing in the sessions match counter, adds Messages for first/last-N preview rows, returns first_user_text from List for resume-picker row titles, adds LastUsage/TouchedFiles for the HUD sidebar's token gauge and touched-file tree, and adds CurrentBranchUsage for current conversation path context.
+ * Block-UUID: 2cb37ce0-8614-464d-b7b6-fb169f5798a9
+ * Parent-UUID: 609bf073-3c4f-4501-a75e-30f86a6caca0
+ * Version: 1.11.0
+ * Description: Executes discovery queries over imported agent sessions; sanitizes full-text terms as FTS5 phrases, adds Messages for preview rows, LastUsage/TouchedFiles/CurrentBranchUsage for the HUD sidebar, and filters and reports sessions by runtime (pi or claude). Usage lookups read archived messages from pi_archived_usage.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0), MiMo-v2.5-pro (v1.1.0, v1.2.0, v1.4.0), claude-opus-4-8 (v1.3.0, v1.5.0, v1.6.0, v1.7.0)
+ * Authors: Codex GPT-5 (v1.0.0), MiMo-v2.5-pro (v1.1.0, v1.2.0, v1.4.0), claude-opus-4-8 (v1.3.0, v1.5.0, v1.6.0, v1.7.0), agent (v1.9.0), agent (v1.10.0), agent (v1.11.0)
  */
 
 package sessions
@@ -315,7 +315,7 @@ func QuerySessions(ctx context.Context, options QueryOptions) ([]SessionQueryRes
 
 func querySessionsBasic(ctx context.Context, database *sql.DB, options QueryOptions) ([]SessionQueryResult, error) {
 	query := `
-		SELECT c.uuid, c.name, c.cwd, c.repo_root, c.provider, c.model,
+		SELECT c.uuid, c.runtime, c.name, c.cwd, c.repo_root, c.provider, c.model,
 		       c.created_at, c.last_message_at, c.message_count,
 		       c.tool_call_count, c.file_ref_count, c.first_user_text
 		FROM pi_chats c
@@ -345,7 +345,7 @@ func querySessionsBasic(ctx context.Context, database *sql.DB, options QueryOpti
 		var name, cwd, repoRoot, provider, model sql.NullString
 		var createdAt, lastMessageAt, firstUserText sql.NullString
 		if err := rows.Scan(
-			&r.SessionID, &name, &cwd, &repoRoot, &provider, &model,
+			&r.SessionID, &r.Runtime, &name, &cwd, &repoRoot, &provider, &model,
 			&createdAt, &lastMessageAt, &r.MessageCount,
 			&r.ToolCallCount, &r.FileRefCount, &firstUserText,
 		); err != nil {
@@ -367,7 +367,7 @@ func querySessionsBasic(ctx context.Context, database *sql.DB, options QueryOpti
 func querySessionsWithMatches(ctx context.Context, database *sql.DB, options QueryOptions) ([]SessionQueryResult, error) {
 	// First get all sessions matching session-level filters
 	baseQuery := `
-		SELECT c.id, c.uuid, c.name, c.cwd, c.repo_root, c.provider, c.model,
+		SELECT c.id, c.uuid, c.runtime, c.name, c.cwd, c.repo_root, c.provider, c.model,
 		       c.created_at, c.last_message_at, c.message_count,
 		       c.tool_call_count, c.file_ref_count, c.first_user_text
 		FROM pi_chats c
@@ -401,7 +401,7 @@ func querySessionsWithMatches(ctx context.Context, database *sql.DB, options Que
 		var name, cwd, repoRoot, provider, model sql.NullString
 		var createdAt, lastMessageAt, firstUserText sql.NullString
 		if err := rows.Scan(
-			&sr.id, &sr.r.SessionID, &name, &cwd, &repoRoot, &provider, &model,
+			&sr.id, &sr.r.SessionID, &sr.r.Runtime, &name, &cwd, &repoRoot, &provider, &model,
 			&createdAt, &lastMessageAt, &sr.r.MessageCount,
 			&sr.r.ToolCallCount, &sr.r.FileRefCount, &firstUserText,
 		); err != nil {
@@ -558,7 +558,7 @@ func List(ctx context.Context, options ListOptions) ([]ListResult, error) {
 	defer db.CloseDB(database)
 
 	query := `
-		SELECT c.uuid, c.name, c.cwd, c.repo_root, c.created_at, c.last_message_at,
+		SELECT c.uuid, c.runtime, c.name, c.cwd, c.repo_root, c.created_at, c.last_message_at,
 		       c.message_count, c.last_display_text, c.first_user_text
 		FROM pi_chats c
 		WHERE c.file_deleted_at IS NULL`
@@ -568,6 +568,10 @@ func List(ctx context.Context, options ListOptions) ([]ListResult, error) {
 		query += " AND c.repo_root = ?"
 		args = append(args, options.Repo)
 	}
+	if options.Runtime != "" {
+		query += " AND c.runtime = ?"
+		args = append(args, options.Runtime)
+	}
 	if options.Provider != "" {
 		query += " AND c.provider = ?"
 		args = append(args, options.Provider)
@@ -609,7 +613,7 @@ func List(ctx context.Context, options ListOptions) ([]ListResult, error) {
 		var name, cwd, repoRoot sql.NullString
 		var createdAt, lastMessageAt, lastDisplayText, firstUserText sql.NullString
 		if err := rows.Scan(
-			&r.SessionID, &name, &cwd, &repoRoot, &createdAt, &lastMessageAt,
+			&r.SessionID, &r.Runtime, &name, &cwd, &repoRoot, &createdAt, &lastMessageAt,
 			&r.MessageCount, &lastDisplayText, &firstUserText,
 		); err != nil {
 			return nil, err
@@ -638,7 +642,7 @@ func Show(ctx context.Context, dbPath string, sessionID string) (*ShowResult, er
 	defer db.CloseDB(database)
 
 	query := `
-		SELECT c.uuid, c.name, c.cwd, c.repo_root, c.provider, c.model,
+		SELECT c.uuid, c.runtime, c.name, c.cwd, c.repo_root, c.provider, c.model,
 		       c.created_at, c.last_message_at, c.message_count,
 		       c.tool_call_count, c.file_ref_count,
 		       c.first_user_text, c.last_user_text, c.last_text
@@ -651,7 +655,7 @@ func Show(ctx context.Context, dbPath string, sessionID string) (*ShowResult, er
 	var firstUserText, lastUserText, lastText sql.NullString
 
 	err = database.QueryRowContext(ctx, query, sessionID).Scan(
-		&r.SessionID, &name, &cwd, &repoRoot, &provider, &model,
+		&r.SessionID, &r.Runtime, &name, &cwd, &repoRoot, &provider, &model,
 		&createdAt, &lastMessageAt, &r.MessageCount,
 		&r.ToolCallCount, &r.FileRefCount,
 		&firstUserText, &lastUserText, &lastText,
@@ -743,7 +747,8 @@ func Messages(ctx context.Context, options PreviewOptions) ([]MessagePreview, er
 }
 
 // LastUsage returns the provider-reported token usage from the most recent
-// assistant message in a session, read out of pi_messages.raw_line via JSON1.
+// assistant message in a session, read out of pi_messages.raw_line via JSON1
+// (pi_archived_usage once the session is archived).
 // Returns (nil, nil) when the session has no assistant message carrying usage
 // (e.g. before the first response, or right after compaction).
 func LastUsage(ctx context.Context, dbPath string, sessionID string) (*SessionUsage, error) {
@@ -762,19 +767,19 @@ func LastUsage(ctx context.Context, dbPath string, sessionID string) (*SessionUs
 	// Sum all assistant messages with valid usage data
 	const q = `
 		SELECT
-			COALESCE(SUM(json_extract(m.raw_line, '$.message.usage.input')), 0),
-			COALESCE(SUM(json_extract(m.raw_line, '$.message.usage.output')), 0),
-			COALESCE(SUM(json_extract(m.raw_line, '$.message.usage.cacheRead')), 0),
-			COALESCE(SUM(json_extract(m.raw_line, '$.message.usage.cacheWrite')), 0),
-			COALESCE(SUM(json_extract(m.raw_line, '$.message.usage.totalTokens')), 0),
-			COALESCE(SUM(json_extract(m.raw_line, '$.message.usage.cost.total')), 0)
-		FROM pi_messages m
+			COALESCE(SUM(json_extract(m.usage_line, '$.message.usage.input')), 0),
+			COALESCE(SUM(json_extract(m.usage_line, '$.message.usage.output')), 0),
+			COALESCE(SUM(json_extract(m.usage_line, '$.message.usage.cacheRead')), 0),
+			COALESCE(SUM(json_extract(m.usage_line, '$.message.usage.cacheWrite')), 0),
+			COALESCE(SUM(json_extract(m.usage_line, '$.message.usage.totalTokens')), 0),
+			COALESCE(SUM(json_extract(m.usage_line, '$.message.usage.cost.total')), 0)
+		FROM ` + usageMessages + ` m
 		JOIN pi_chats c ON c.id = m.chat_id
 		WHERE c.uuid = ? AND c.file_deleted_at IS NULL
-			AND json_extract(m.raw_line, '$.message.role') = 'assistant'
-			AND json_extract(m.raw_line, '$.message.usage.totalTokens') IS NOT NULL
-			AND json_extract(m.raw_line, '$.message.usage.totalTokens') > 0
-			AND json_extract(m.raw_line, '$.message.stopReason') NOT IN ('aborted', 'error')`
+			AND json_extract(m.usage_line, '$.message.role') = 'assistant'
+			AND json_extract(m.usage_line, '$.message.usage.totalTokens') IS NOT NULL
+			AND json_extract(m.usage_line, '$.message.usage.totalTokens') > 0
+			AND json_extract(m.usage_line, '$.message.stopReason') NOT IN ('aborted', 'error')`
 
 	var input, output, cacheRead, cacheWrite, total sql.NullInt64
 	var cost sql.NullFloat64
@@ -812,19 +817,19 @@ func CurrentBranchUsage(ctx context.Context, dbPath string, sessionID string) (*
 	// Get the usage from the last assistant message with valid usage data
 	const q = `
 		SELECT
-			json_extract(m.raw_line, '$.message.usage.input'),
-			json_extract(m.raw_line, '$.message.usage.output'),
-			json_extract(m.raw_line, '$.message.usage.cacheRead'),
-			json_extract(m.raw_line, '$.message.usage.cacheWrite'),
-			json_extract(m.raw_line, '$.message.usage.totalTokens'),
-			json_extract(m.raw_line, '$.message.usage.cost.total')
-		FROM pi_messages m
+			json_extract(m.usage_line, '$.message.usage.input'),
+			json_extract(m.usage_line, '$.message.usage.output'),
+			json_extract(m.usage_line, '$.message.usage.cacheRead'),
+			json_extract(m.usage_line, '$.message.usage.cacheWrite'),
+			json_extract(m.usage_line, '$.message.usage.totalTokens'),
+			json_extract(m.usage_line, '$.message.usage.cost.total')
+		FROM ` + usageMessages + ` m
 		JOIN pi_chats c ON c.id = m.chat_id
 		WHERE c.uuid = ? AND c.file_deleted_at IS NULL
-			AND json_extract(m.raw_line, '$.message.role') = 'assistant'
-			AND json_extract(m.raw_line, '$.message.usage.totalTokens') IS NOT NULL
-			AND json_extract(m.raw_line, '$.message.usage.totalTokens') > 0
-			AND json_extract(m.raw_line, '$.message.stopReason') NOT IN ('aborted', 'error')
+			AND json_extract(m.usage_line, '$.message.role') = 'assistant'
+			AND json_extract(m.usage_line, '$.message.usage.totalTokens') IS NOT NULL
+			AND json_extract(m.usage_line, '$.message.usage.totalTokens') > 0
+			AND json_extract(m.usage_line, '$.message.stopReason') NOT IN ('aborted', 'error')
 		ORDER BY m.seq DESC
 		LIMIT 1`
 
@@ -906,6 +911,10 @@ func appendSessionFilters(query string, args []interface{}, options QueryOptions
 		query += " AND c.repo_root = ?"
 		args = append(args, options.Repo)
 	}
+	if options.Runtime != "" {
+		query += " AND c.runtime = ?"
+		args = append(args, options.Runtime)
+	}
 	if options.Provider != "" {
 		query += " AND c.provider = ?"
 		args = append(args, options.Provider)
@@ -1319,6 +1328,10 @@ func appendCommonFilters(query string, args []interface{}, options QueryOptions,
 		query += " AND " + chatAlias + ".repo_root = ?"
 		args = append(args, options.Repo)
 	}
+	if options.Runtime != "" {
+		query += " AND " + chatAlias + ".runtime = ?"
+		args = append(args, options.Runtime)
+	}
 	if options.Provider != "" {
 		query += " AND " + chatAlias + ".provider = ?"
 		args = append(args, options.Provider)
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Reconciliation
- * Block-UUID: 9b4d03cd-7390-496b-89f6-0d9986049b79
- * Parent-UUID: 96bb35de-5c03-4ced-9ec7-18df3db71aec
- * Version: 1.0.0
- * Description: Reassociates moved Pi sessions and purges content after guarded missing-file confirmation.
+ * Block-UUID: 745a277e-de92-4e53-836a-8d86912989a6
+ * Parent-UUID: 32a09a7b-b54d-47d3-83b7-7a09460ba434
+ * Version: 1.2.0
+ * Description: Reassociates moved sessions and purges content after guarded missing-file confirmation; missing-file detection is scoped to the runtime being synced and skips archived sessions, whose mirror rows outlive their source files.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0)
+ * Authors: Codex GPT-5 (v1.0.0), agent (v1.1.0), agent (v1.2.0)
  */
 
 
@@ -78,7 +78,7 @@ func recordRestoredSession(ctx context.Context, database *sql.DB, state chatSync
 	return err
 }
 
-func reconcileMissingSessions(ctx context.Context, database *sql.DB, sessionsDir string, files []string) error {
+func reconcileMissingSessions(ctx context.Context, database *sql.DB, format sessionFormat, sessionsDir string, files []string) error {
 	seenPaths := make(map[string]struct{}, len(files))
 	for _, path := range files {
 		absolute, err := filepath.Abs(path)
@@ -90,7 +90,7 @@ func reconcileMissingSessions(ctx context.Context, database *sql.DB, sessionsDir
 
 	rows, err := database.QueryContext(ctx, `
 		SELECT id, uuid, session_file, file_size, file_mtime_ms, content_hash, synced_seq
-		FROM pi_chats WHERE file_deleted_at IS NULL`)
+		FROM pi_chats WHERE file_deleted_at IS NULL AND archived_at IS NULL AND runtime = ?`, format.runtime)
 	if err != nil {
 		return err
 	}
@@ -130,7 +130,7 @@ func reconcileMissingSessions(ctx context.Context, database *sql.DB, sessionsDir
 	case <-timer.C:
 	}
 
-	currentUUIDs, err := discoverSessionUUIDs(sessionsDir)
+	currentUUIDs, err := discoverSessionUUIDs(format, sessionsDir)
 	if err != nil {
 		return err
 	}
@@ -159,14 +159,14 @@ func reconcileMissingSessions(ctx context.Context, database *sql.DB, sessionsDir
 	return nil
 }
 
-func discoverSessionUUIDs(root string) (map[string]string, error) {
-	files, err := discoverSessionFiles(root)
+func discoverSessionUUIDs(format sessionFormat, root string) (map[string]string, error) {
+	files, err := format.discover(root)
 	if err != nil {
 		return nil, err
 	}
 	result := make(map[string]string, len(files))
 	for _, path := range files {
-		header, _, err := parseSessionHeader(path)
+		header, _, err := format.parseHeader(path)
 		if err != nil {
 			continue
 		}
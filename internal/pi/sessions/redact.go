@@ -0,0 +1,694 @@
+/**
+ * Component: Session Secret Redaction
+ * Block-UUID: 732b2f8f-d6be-4e2b-9ab2-b61aab02c611
+ * Parent-UUID: 180110b6-f13c-43cd-91f5-a39cbf96e316
+ * Version: 1.4.0
+ * Description: Scrubs credentials from the sessions mirror with built-in and user-configured regex detectors, rewriting raw lines, FTS text, tool arguments and output, and optionally the source JSONL, and records each rewritten line as a redaction event so sync anchors and lossless verification stay valid. Archived sessions are redacted from their bundle, which is rewritten after the mirror commit. Raw lines and tool arguments are redacted string value by string value after decoding, so secrets behind JSON escapes are found, and re-encoded in place.
+ * Language: Go
+ * Created-at: 2026-10-18T17:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0), agent (v1.3.0), agent (v1.4.0)
+ */
+
+package sessions
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/db"
+)
+
+// redactionMarker prefixes every replacement. Matches that already start with
+// it are left alone so redaction is idempotent.
+const redactionMarker = "[REDACTED:"
+
+// RedactionDetector is a named pattern. Group selects the capture group to
+// replace (0 replaces the whole match), so context such as "Bearer " or
+// "API_KEY=" can be kept.
+type RedactionDetector struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+	Group   int    `json:"group,omitempty"`
+	re      *regexp.Regexp
+}
+
+// RedactionConfig is the user-editable detector file. Detectors are added to
+// the built-ins; Disable removes built-ins by name.
+type RedactionConfig struct {
+	Detectors []RedactionDetector `json:"detectors"`
+	Disable   []string            `json:"disable,omitempty"`
+}
+
+// BuiltinRedactionDetectors returns the detectors that are always available.
+// Character classes exclude quotes and backslashes so replacements never cut
+// a JSON escape in half.
+func BuiltinRedactionDetectors() []RedactionDetector {
+	return []RedactionDetector{
+		{Name: "private_key", Pattern: `-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`},
+		{Name: "aws_access_key", Pattern: `\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`},
+		{Name: "aws_secret_key", Pattern: `(?i)aws_?secret_?access_?key[\s"':=\\]+([A-Za-z0-9/+=]{40})`, Group: 1},
+		{Name: "github_token", Pattern: `\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{40,})\b`},
+		{Name: "api_key", Pattern: `\bsk-(?:ant-|proj-)?[A-Za-z0-9_\-]{20,}`},
+		{Name: "bearer_token", Pattern: `(?i)\bbearer\s+([A-Za-z0-9\-._~+/]{16,}=*)`, Group: 1},
+		{Name: "dotenv_value", Pattern: `(?i)\b[A-Z0-9_]*(?:SECRET|TOKEN|PASSWORD|PASSWD|API_?KEY|ACCESS_?KEY|CREDENTIALS?)[A-Z0-9_]*[ \t]*=[ \t]*["']?([^\s"'\\\[][^\s"'\\]{5,})`, Group: 1},
+	}
+}
+
+// LoadRedactionConfig reads a detector file. A missing file is an empty
+// config, so only the built-ins apply.
+func LoadRedactionConfig(path string) (*RedactionConfig, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &RedactionConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read redaction config: %w", err)
+	}
+	var config RedactionConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("parse redaction config %s: %w", path, err)
+	}
+	return &config, nil
+}
+
+// CompileRedactionDetectors returns the built-ins (minus disabled ones) plus
+// the configured detectors, compiled and validated.
+func CompileRedactionDetectors(config *RedactionConfig) ([]RedactionDetector, error) {
+	disabled := map[string]bool{}
+	var custom []RedactionDetector
+	if config != nil {
+		for _, name := range config.Disable {
+			disabled[name] = true
+		}
+		custom = config.Detectors
+	}
+	var detectors []RedactionDetector
+	for _, detector := range append(BuiltinRedactionDetectors(), custom...) {
+		if disabled[detector.Name] {
+			continue
+		}
+		if detector.Name == "" || strings.ContainsAny(detector.Name, "[]") {
+			return nil, fmt.Errorf("redaction detector name %q is invalid", detector.Name)
+		}
+		re, err := regexp.Compile(detector.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redaction detector %s: %w", detector.Name, err)
+		}
+		if detector.Group < 0 || detector.Group > re.NumSubexp() {
+			return nil, fmt.Errorf("redaction detector %s: group %d out of range", detector.Name, detector.Group)
+		}
+		detector.re = re
+		detectors = append(detectors, detector)
+	}
+	return detectors, nil
+}
+
+// redactText applies every detector in order and returns the scrubbed text
+// with the number of replacements per detector.
+func redactText(text string, detectors []RedactionDetector) (string, map[string]int) {
+	var found map[string]int
+	for _, detector := range detectors {
+		matches := detector.re.FindAllStringSubmatchIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+		var b strings.Builder
+		last := 0
+		for _, match := range matches {
+			start, end := match[2*detector.Group], match[2*detector.Group+1]
+			if start < 0 || start == end || start < last || strings.HasPrefix(text[start:], redactionMarker) {
+				continue
+			}
+			b.WriteString(text[last:start])
+			b.WriteString(redactionMarker + detector.Name + "]")
+			last = end
+			if found == nil {
+				found = map[string]int{}
+			}
+			found[detector.Name]++
+		}
+		if last == 0 {
+			continue
+		}
+		b.WriteString(text[last:])
+		text = b.String()
+	}
+	return text, found
+}
+
+// redactJSONText redacts a JSON document and returns the number of
+// replacements per detector. Every string value is decoded, redacted, and
+// encoded again in place, so a secret behind an escape such as
+// API_TOKEN=\"...\" is found and the rest of the document keeps its layout.
+// The raw text is then redacted as well, for patterns that span a key and its
+// value, unless that would break the JSON. Text that is not JSON is redacted
+// as plain text.
+func redactJSONText(text string, detectors []RedactionDetector) (string, map[string]int) {
+	if !json.Valid([]byte(text)) {
+		return redactText(text, detectors)
+	}
+	var found map[string]int
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(text); i++ {
+		if text[i] != '"' {
+			continue
+		}
+		end := i + 1
+		for text[end] != '"' {
+			if text[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		literal := text[i : end+1]
+		var value string
+		if err := json.Unmarshal([]byte(literal), &value); err == nil {
+			if redacted, counts := redactText(value, detectors); redacted != value {
+				b.WriteString(text[last:i])
+				b.WriteString(encodeJSONString(redacted))
+				last = end + 1
+				found = mergeFindings(found, counts)
+			}
+		}
+		i = end
+	}
+	if last > 0 {
+		b.WriteString(text[last:])
+		text = b.String()
+	}
+
+	if redacted, counts := redactText(text, detectors); redacted != text && json.Valid([]byte(redacted)) {
+		text = redacted
+		found = mergeFindings(found, counts)
+	}
+	return text, found
+}
+
+// encodeJSONString encodes a string as a JSON literal without HTML escaping.
+func encodeJSONString(value string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// mergeFindings adds the replacement counts in src to dst.
+func mergeFindings(dst, src map[string]int) map[string]int {
+	for name, count := range src {
+		if dst == nil {
+			dst = map[string]int{}
+		}
+		dst[name] += count
+	}
+	return dst
+}
+
+// Redact scrubs secrets from every live or archived session in the mirror (or
+// one session). Raw lines that change are recorded as redaction events
+// carrying the old and new line hashes; archived sessions have their bundle
+// rewritten, and with RewriteSource the same lines are replaced in the
+// session JSONL.
+func Redact(ctx context.Context, options RedactOptions) (*RedactResult, error) {
+	if options.DBPath == "" {
+		return nil, fmt.Errorf("db path is required")
+	}
+	if len(options.Detectors) == 0 {
+		return nil, fmt.Errorf("no redaction detectors configured")
+	}
+	database, err := openMirror(ctx, options.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.CloseDB(database)
+
+	query := `
+		SELECT id, uuid, session_file, COALESCE(archive_path, ''), file_deleted_at IS NOT NULL
+		FROM pi_chats WHERE (file_deleted_at IS NULL OR archive_path IS NOT NULL)`
+	var args []interface{}
+	if options.SessionID != "" {
+		query += " AND uuid = ?"
+		args = append(args, options.SessionID)
+	}
+	if options.Runtime != "" {
+		query += " AND runtime = ?"
+		args = append(args, options.Runtime)
+	}
+	query += " ORDER BY id"
+	rows, err := database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	type chatRef struct {
+		id            int64
+		uuid          string
+		sessionFile   string
+		archivePath   string
+		sourceDeleted bool
+	}
+	var chats []chatRef
+	for rows.Next() {
+		var chat chatRef
+		if err := rows.Scan(&chat.id, &chat.uuid, &chat.sessionFile, &chat.archivePath, &chat.sourceDeleted); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		chats = append(chats, chat)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if options.SessionID != "" && len(chats) == 0 {
+		return nil, fmt.Errorf("session %q not found", options.SessionID)
+	}
+
+	result := &RedactResult{DryRun: options.DryRun, Findings: map[string]int{}, Sessions: []RedactedSession{}}
+	for _, chat := range chats {
+		result.SessionsScanned++
+		session, err := redactChat(ctx, database, chat.id, chat.uuid, chat.sessionFile, chat.archivePath, options)
+		if err != nil {
+			return nil, fmt.Errorf("redact session %s: %w", chat.uuid, err)
+		}
+		// Rewriting also covers lines redacted by earlier mirror-only runs.
+		if options.RewriteSource && !options.DryRun && !chat.sourceDeleted {
+			replaced, err := rewriteSessionSource(ctx, database, chat.id, chat.sessionFile, chat.archivePath)
+			if err != nil && session.Error == "" {
+				session.Error = err.Error()
+			} else if err == nil && replaced > 0 {
+				session.SourceRewritten = true
+				result.SourcesRewritten++
+			}
+		}
+		if session.Messages == 0 && session.ToolCalls == 0 && !session.summaryChanged && !session.SourceRewritten && !session.ArchiveRewritten && session.Error == "" {
+			continue
+		}
+		result.SessionsAffected++
+		result.MessagesRedacted += session.Messages
+		result.ToolCallsRedacted += session.ToolCalls
+		for name, count := range session.Findings {
+			result.Findings[name] += count
+		}
+		result.Sessions = append(result.Sessions, session)
+	}
+
+	if !options.DryRun && result.SessionsAffected > 0 {
+		// Merge FTS segments so deleted tokens are dropped, then checkpoint so
+		// the WAL no longer holds pre-redaction pages.
+		if _, err := database.ExecContext(ctx, `INSERT INTO fts_pi_messages(fts_pi_messages) VALUES('optimize')`); err != nil {
+			return nil, fmt.Errorf("optimize message index: %w", err)
+		}
+		if _, err := database.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`); err != nil {
+			return nil, fmt.Errorf("checkpoint: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// redactChat scrubs one chat in a single transaction. An archived chat's raw
+// lines are read from its bundle; the mirror keeps raw_line empty and only
+// raw_hash moves, and the bundle is rewritten after the commit.
+func redactChat(ctx context.Context, database *sql.DB, chatID int64, uuid string, sessionFile string, archivePath string, options RedactOptions) (RedactedSession, error) {
+	session := RedactedSession{SessionID: uuid, SessionFile: sessionFile, Findings: map[string]int{}}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+
+	archived := archivePath != ""
+	var manifest archiveManifest
+	var archivedLines []string
+	var origins map[string]redactionOrigin
+	if archived {
+		var err error
+		if manifest, archivedLines, err = readArchiveBundle(archivePath); err != nil {
+			return session, err
+		}
+		if manifest.SessionID != uuid {
+			return session, fmt.Errorf("%s belongs to session %s", archivePath, manifest.SessionID)
+		}
+		if origins, err = redactionOrigins(ctx, database, chatID); err != nil {
+			return session, err
+		}
+	}
+
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return session, err
+	}
+	defer tx.Rollback()
+
+	type messageRow struct {
+		id      int64
+		seq     int
+		rawLine string
+		rawHash string
+		text    sql.NullString
+	}
+	rows, err := tx.QueryContext(ctx, `SELECT id, seq, raw_line, raw_hash, text FROM pi_messages WHERE chat_id = ? ORDER BY seq`, chatID)
+	if err != nil {
+		return session, err
+	}
+	var messages []messageRow
+	for rows.Next() {
+		var row messageRow
+		if err := rows.Scan(&row.id, &row.seq, &row.rawLine, &row.rawHash, &row.text); err != nil {
+			rows.Close()
+			return session, err
+		}
+		messages = append(messages, row)
+	}
+	if err := rows.Close(); err != nil {
+		return session, err
+	}
+	if archived && len(archivedLines) != len(messages) {
+		return session, fmt.Errorf("%s has %d lines, mirror has %d", archivePath, len(archivedLines), len(messages))
+	}
+
+	bundleChanged := false
+	for i, message := range messages {
+		rawLine := message.rawLine
+		unchanged := func(newRaw string) bool { return newRaw == rawLine }
+		if archived {
+			// A bundle line may predate a redaction whose bundle rewrite failed.
+			rawLine = archivedLines[i]
+			if lineHash := hashText(rawLine); lineHash != message.rawHash && !redactedFrom(origins, message.rawHash, lineHash) {
+				return session, fmt.Errorf("%s: seq %d does not match the mirror", archivePath, message.seq)
+			}
+			unchanged = func(newRaw string) bool { return hashText(newRaw) == message.rawHash }
+		}
+
+		// Findings are counted on raw lines only; text and tool columns are
+		// derived from them and would double-count.
+		newRaw, found := redactJSONText(rawLine, options.Detectors)
+		newText, _ := redactText(message.text.String, options.Detectors)
+		if archived && newRaw != rawLine {
+			archivedLines[i] = newRaw
+			bundleChanged = true
+		}
+		if unchanged(newRaw) && newText == message.text.String {
+			continue
+		}
+		if !json.Valid([]byte(newRaw)) {
+			return session, fmt.Errorf("redacting seq %d produced invalid JSON; check custom detector patterns", message.seq)
+		}
+		session.Messages++
+		names := make([]string, 0, len(found))
+		for name, count := range found {
+			session.Findings[name] += count
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		if options.DryRun {
+			continue
+		}
+		storedRaw := newRaw
+		if archived {
+			storedRaw = ""
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE pi_messages SET raw_line = ?, raw_hash = ?, text = ? WHERE id = ?`,
+			storedRaw, hashText(newRaw), nullableText(message.text, newText), message.id); err != nil {
+			return session, err
+		}
+		if unchanged(newRaw) {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO pi_sync_events (
+				chat_id, session_file, event_type, detected_at, old_size, new_size,
+				old_hash, new_hash, old_seq, new_seq, note
+			) VALUES (?, ?, 'redaction', ?, ?, ?, ?, ?, ?, ?, ?)`,
+			chatID, sessionFile, now, len(rawLine), len(newRaw),
+			hashText(rawLine), hashText(newRaw), message.seq, message.seq,
+			"detectors="+strings.Join(names, ",")); err != nil {
+			return session, err
+		}
+	}
+
+	type toolRow struct {
+		id        int64
+		arguments string
+		result    sql.NullString
+	}
+	toolRows, err := tx.QueryContext(ctx, `SELECT id, arguments_json, result_text FROM pi_tool_calls WHERE chat_id = ?`, chatID)
+	if err != nil {
+		return session, err
+	}
+	var tools []toolRow
+	for toolRows.Next() {
+		var row toolRow
+		if err := toolRows.Scan(&row.id, &row.arguments, &row.result); err != nil {
+			toolRows.Close()
+			return session, err
+		}
+		tools = append(tools, row)
+	}
+	if err := toolRows.Close(); err != nil {
+		return session, err
+	}
+	for _, tool := range tools {
+		newArgs, _ := redactJSONText(tool.arguments, options.Detectors)
+		newResult, _ := redactText(tool.result.String, options.Detectors)
+		if newArgs == tool.arguments && newResult == tool.result.String {
+			continue
+		}
+		session.ToolCalls++
+		if options.DryRun {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE pi_tool_calls SET arguments_json = ?, result_text = ? WHERE id = ?`,
+			newArgs, nullableText(tool.result, newResult), tool.id); err != nil {
+			return session, err
+		}
+	}
+
+	var summary [5]sql.NullString
+	if err := tx.QueryRowContext(ctx, `
+		SELECT name, first_user_text, last_user_text, last_text, last_display_text
+		FROM pi_chats WHERE id = ?`, chatID).Scan(&summary[0], &summary[1], &summary[2], &summary[3], &summary[4]); err != nil {
+		return session, err
+	}
+	var redacted [5]interface{}
+	for i, value := range summary {
+		text, _ := redactText(value.String, options.Detectors)
+		if text != value.String {
+			session.summaryChanged = true
+		}
+		redacted[i] = nullableText(value, text)
+	}
+	if session.summaryChanged && !options.DryRun {
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE pi_chats SET name = ?, first_user_text = ?, last_user_text = ?, last_text = ?, last_display_text = ?, updated_at = ?
+			WHERE id = ?`, redacted[0], redacted[1], redacted[2], redacted[3], redacted[4], now, chatID); err != nil {
+			return session, err
+		}
+	}
+	if options.DryRun {
+		return session, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return session, err
+	}
+	if bundleChanged {
+		// The mirror already holds the redacted hashes; a failed rewrite leaves
+		// the old lines as recorded origins, so running redact again finishes it.
+		if _, err := writeArchiveBundle(archivePath, manifest, archivedLines); err != nil {
+			session.Error = fmt.Sprintf("rewrite archive bundle (run redact again): %v", err)
+			return session, nil
+		}
+		session.ArchiveRewritten = true
+	}
+	return session, nil
+}
+
+func nullableText(original sql.NullString, text string) interface{} {
+	if !original.Valid {
+		return nil
+	}
+	return text
+}
+
+// rewriteSessionSource replaces source lines whose redacted form is in the
+// mirror (or, for an archived chat, in its bundle), within the synced prefix
+// of the session file, leaving any unsynced tail untouched. It moves the sync
+// bookmark so the next sync appends instead of rebuilding, and returns the
+// number of lines replaced.
+func rewriteSessionSource(ctx context.Context, database *sql.DB, chatID int64, sessionFile string, archivePath string) (int, error) {
+	origins, err := redactionOrigins(ctx, database, chatID)
+	if err != nil || len(origins) == 0 {
+		return 0, err
+	}
+	var redactedLines []string
+	if archivePath != "" {
+		if _, redactedLines, err = readArchiveBundle(archivePath); err != nil {
+			return 0, err
+		}
+	} else {
+		rows, err := database.QueryContext(ctx, `SELECT raw_line FROM pi_messages WHERE chat_id = ?`, chatID)
+		if err != nil {
+			return 0, err
+		}
+		for rows.Next() {
+			var rawLine string
+			if err := rows.Scan(&rawLine); err != nil {
+				rows.Close()
+				return 0, err
+			}
+			redactedLines = append(redactedLines, rawLine)
+		}
+		if err := rows.Close(); err != nil {
+			return 0, err
+		}
+	}
+	// Map every ancestor hash of a redacted line to that line.
+	rewrites := map[string]string{}
+	for _, rawLine := range redactedLines {
+		for hash, depth := hashText(rawLine), 0; depth <= len(origins); depth++ {
+			origin, ok := origins[hash]
+			if !ok {
+				break
+			}
+			rewrites[origin.hash] = rawLine
+			hash = origin.hash
+		}
+	}
+
+	var syncedOffset int64
+	var contentHash sql.NullString
+	if err := database.QueryRowContext(ctx, `SELECT synced_byte_offset, content_hash FROM pi_chats WHERE id = ?`, chatID).Scan(&syncedOffset, &contentHash); err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(sessionFile)
+	if err != nil {
+		return 0, err
+	}
+	content, err := os.ReadFile(sessionFile)
+	if err != nil {
+		return 0, err
+	}
+	if syncedOffset > int64(len(content)) {
+		return 0, fmt.Errorf("session file is shorter than the synced offset; run sync first")
+	}
+	prefix, tail := string(content[:syncedOffset]), string(content[syncedOffset:])
+	lines := strings.Split(prefix, "\n")
+	replaced := 0
+	for i, line := range lines {
+		if redacted, ok := rewrites[hashText(line)]; ok {
+			lines[i] = redacted
+			replaced++
+		}
+	}
+	if replaced == 0 {
+		return 0, nil
+	}
+	newPrefix := strings.Join(lines, "\n")
+	newContent := newPrefix + tail
+
+	temp, err := os.CreateTemp(filepath.Dir(sessionFile), "."+filepath.Base(sessionFile)+".redact-*")
+	if err != nil {
+		return 0, err
+	}
+	tempPath := temp.Name()
+	defer os.Remove(tempPath)
+	if _, err := temp.WriteString(newContent); err != nil {
+		temp.Close()
+		return 0, err
+	}
+	if err := temp.Chmod(info.Mode().Perm()); err != nil {
+		temp.Close()
+		return 0, err
+	}
+	if err := temp.Close(); err != nil {
+		return 0, err
+	}
+	// Refuse to clobber lines the agent appended while we were rewriting.
+	if current, err := os.Stat(sessionFile); err != nil || current.Size() != info.Size() {
+		return 0, fmt.Errorf("session file changed during redaction; retry when the session is idle")
+	}
+	if err := os.Rename(tempPath, sessionFile); err != nil {
+		return 0, err
+	}
+	newInfo, err := os.Stat(sessionFile)
+	if err != nil {
+		return 0, err
+	}
+
+	newHash := contentHash
+	if contentHash.Valid && contentHash.String == hashText(string(content)) {
+		newHash = sql.NullString{String: hashText(newContent), Valid: true}
+	}
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+	tx, err := database.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE pi_chats SET file_size = ?, file_mtime_ms = ?, synced_byte_offset = ?, content_hash = ?, updated_at = ?
+		WHERE id = ?`, newInfo.Size(), newInfo.ModTime().UnixMilli(), int64(len(newPrefix)), newHash, now, chatID); err != nil {
+		return 0, err
+	}
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO pi_sync_events (
+			chat_id, session_file, event_type, detected_at, old_size, new_size,
+			old_mtime_ms, new_mtime_ms, old_hash, new_hash, note
+		) VALUES (?, ?, 'redaction_source_rewrite', ?, ?, ?, ?, ?, ?, ?, ?)`,
+		chatID, sessionFile, now, info.Size(), newInfo.Size(), info.ModTime().UnixMilli(), newInfo.ModTime().UnixMilli(),
+		hashText(string(content)), hashText(newContent), fmt.Sprintf("rewrote %d line(s)", replaced)); err != nil {
+		return 0, err
+	}
+	return replaced, tx.Commit()
+}
+
+// redactionOrigins maps each redacted line hash to the hash and size of the
+// line it replaced, for one chat. Chains (a line redacted twice) are resolved
+// by following the map.
+func redactionOrigins(ctx context.Context, database *sql.DB, chatID int64) (map[string]redactionOrigin, error) {
+	rows, err := database.QueryContext(ctx, `
+		SELECT old_hash, new_hash, old_size FROM pi_sync_events
+		WHERE chat_id = ? AND event_type = 'redaction'`, chatID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	origins := map[string]redactionOrigin{}
+	for rows.Next() {
+		var oldHash, newHash string
+		var oldSize int64
+		if err := rows.Scan(&oldHash, &newHash, &oldSize); err != nil {
+			return nil, err
+		}
+		origins[newHash] = redactionOrigin{hash: oldHash, size: oldSize}
+	}
+	return origins, rows.Err()
+}
+
+type redactionOrigin struct {
+	hash string
+	size int64
+}
+
+// redactedFrom reports whether the mirror line hashed as mirrorHash is a
+// recorded redaction (possibly several deep) of the source line hashed as
+// sourceHash.
+func redactedFrom(origins map[string]redactionOrigin, mirrorHash string, sourceHash string) bool {
+	hash := mirrorHash
+	for i := 0; i <= len(origins); i++ {
+		origin, ok := origins[hash]
+		if !ok {
+			return false
+		}
+		if origin.hash == sourceHash {
+			return true
+		}
+		hash = origin.hash
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+/**
+ * Component: Agent Session Runtimes
+ * Block-UUID: ef628dde-cd64-4340-a566-c0ee085c8073
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Names the agent runtimes whose transcripts share the sessions mirror (Pi and Claude Code) and binds each to its file discovery and JSONL parsers.
+ * Language: Go
+ * Created-at: 2026-10-18T14:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	// RuntimePi marks sessions imported from Pi (~/.pi/agent/sessions).
+	RuntimePi = "pi"
+	// RuntimeClaude marks sessions imported from Claude Code (~/.claude/projects).
+	RuntimeClaude = "claude"
+)
+
+// Runtimes lists every runtime the mirror can import, in display order.
+var Runtimes = []string{RuntimePi, RuntimeClaude}
+
+// sessionFormat binds a runtime to the functions that find and parse its
+// transcripts. Each parser yields the same sessionHeader/parsedEntry shapes so
+// the import, append, and reconciliation paths are shared across runtimes.
+type sessionFormat struct {
+	runtime     string
+	discover    func(root string) ([]string, error)
+	parseHeader func(path string) (sessionHeader, int64, error)
+	parseFile   func(path string) (parsedSession, error)
+	parseTail   func(path string, offset int64, seq int) ([]parsedEntry, int64, bool, error)
+	// bashFileRefs enables heuristic file references from bash commands.
+	bashFileRefs bool
+}
+
+var piFormat = sessionFormat{
+	runtime:     RuntimePi,
+	discover:    discoverSessionFiles,
+	parseHeader: parseSessionHeader,
+	parseFile:   parseSessionFile,
+	parseTail:   parseSessionTail,
+}
+
+var claudeFormat = sessionFormat{
+	runtime:      RuntimeClaude,
+	discover:     discoverClaudeSessionFiles,
+	parseHeader:  parseClaudeHeader,
+	parseFile:    parseClaudeFile,
+	parseTail:    parseClaudeTail,
+	bashFileRefs: true,
+}
+
+func formatForRuntime(runtime string) (sessionFormat, error) {
+	switch runtime {
+	case "", RuntimePi:
+		return piFormat, nil
+	case RuntimeClaude:
+		return claudeFormat, nil
+	default:
+		return sessionFormat{}, fmt.Errorf("unknown session runtime %q (use %s)", runtime, strings.Join(Runtimes, " or "))
+	}
+}
+
+// ValidateRuntime reports an error when runtime is not empty and not a known runtime.
+func ValidateRuntime(runtime string) error {
+	if runtime == "" {
+		return nil
+	}
+	_, err := formatForRuntime(runtime)
+	return err
+}
+
+// DefaultClaudeProjectsDir returns ~/.claude/projects, where Claude Code keeps
+// one directory of session transcripts per working directory.
+func DefaultClaudeProjectsDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("resolve home directory: %w", err)
+	}
+	return filepath.Join(home, ".claude", "projects"), nil
+}
+
+// discoverClaudeSessionFiles returns <root>/*/*.jsonl. Deeper files (subagent
+// sidechains) are not separate sessions and are skipped.
+func discoverClaudeSessionFiles(root string) ([]string, error) {
+	if _, err := os.Stat(root); err != nil {
+		return nil, err
+	}
+	files, err := filepath.Glob(filepath.Join(root, "*", "*.jsonl"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -5,11 +5,12 @@ CREATE TABLE IF NOT EXISTS pi_meta (
     value TEXT
 );
 
-INSERT OR IGNORE INTO pi_meta(key, value) VALUES ('schema_version', '3.4');
+INSERT OR IGNORE INTO pi_meta(key, value) VALUES ('schema_version', '3.6');
 
 CREATE TABLE IF NOT EXISTS pi_chats (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     uuid TEXT NOT NULL,
+    runtime TEXT NOT NULL DEFAULT 'pi',
     version INTEGER,
     cwd TEXT,
     name TEXT,
@@ -26,6 +27,8 @@ CREATE TABLE IF NOT EXISTS pi_chats (
     content_hash TEXT,
     last_full_verify_at DATETIME,
     file_deleted_at DATETIME,
+    archived_at DATETIME,
+    archive_path TEXT,
     synced_seq INTEGER NOT NULL DEFAULT -1,
     synced_byte_offset INTEGER NOT NULL DEFAULT 0,
     last_synced_at DATETIME,
@@ -83,6 +86,16 @@ CREATE INDEX IF NOT EXISTS idx_pi_messages_parent ON pi_messages(chat_id, parent
 CREATE INDEX IF NOT EXISTS idx_pi_messages_seq ON pi_messages(chat_id, seq);
 CREATE INDEX IF NOT EXISTS idx_pi_messages_type ON pi_messages(chat_id, type);
 
+-- Usage fields of archived assistant messages, kept so usage and cost reports
+-- still cover a chat after its raw lines move to an archive bundle.
+CREATE TABLE IF NOT EXISTS pi_archived_usage (
+    message_id INTEGER PRIMARY KEY,
+    chat_id INTEGER NOT NULL,
+    usage_line TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_pi_archived_usage_chat ON pi_archived_usage(chat_id);
+
 CREATE TABLE IF NOT EXISTS pi_tool_calls (
     id INTEGER PRIMARY KEY AUTOINCREMENT,
     chat_id INTEGER NOT NULL,
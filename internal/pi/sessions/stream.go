@@ -0,0 +1,423 @@
+/**
+ * Component: Session Event Stream
+ * Block-UUID: 3f9a6c28-7d41-4e85-b2c0-1e8d5a7b9f34
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Turns entries as they are ingested into the sessions mirror into normalized events (message, tool_call, tool_result, file_ref, error) by following each chat's synced sequence, with repo, tool, op, and event-type filters and an optional replay of recent entries.
+ * Language: Go
+ * Created-at: 2026-10-18T21:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/db"
+)
+
+// Stream event types.
+const (
+	StreamEventMessage    = "message"
+	StreamEventToolCall   = "tool_call"
+	StreamEventToolResult = "tool_result"
+	StreamEventFileRef    = "file_ref"
+	StreamEventError      = "error"
+)
+
+// StreamEventTypes lists every event type in the order they are emitted for
+// one entry.
+var StreamEventTypes = []string{StreamEventMessage, StreamEventToolCall, StreamEventToolResult, StreamEventFileRef, StreamEventError}
+
+const defaultStreamMaxText = 2000
+
+// EventStream follows the mirror and yields events for entries ingested since
+// the last poll. It keeps a per-chat cursor on the synced sequence, so a
+// session rebuilt in place is not replayed.
+type EventStream struct {
+	database   *sql.DB
+	options    StreamOptions
+	cursors    map[int64]int
+	syncErrors map[int64]string
+}
+
+type streamChat struct {
+	id        int64
+	uuid      string
+	runtime   string
+	repoRoot  string
+	syncedSeq int
+	syncError string
+}
+
+// ValidateStreamEventType reports whether name is a known event type.
+func ValidateStreamEventType(name string) error {
+	if containsString(StreamEventTypes, name) {
+		return nil
+	}
+	return fmt.Errorf("unknown event type %q (use %s)", name, strings.Join(StreamEventTypes, ", "))
+}
+
+// OpenEventStream positions a stream at the current end of every session, or
+// Backlog entries earlier when a replay is requested.
+func OpenEventStream(ctx context.Context, options StreamOptions) (*EventStream, error) {
+	if options.DBPath == "" {
+		return nil, fmt.Errorf("db path is required")
+	}
+	for _, name := range options.Events {
+		if err := ValidateStreamEventType(name); err != nil {
+			return nil, err
+		}
+	}
+	if options.Repo != "" {
+		options.Repo = filepath.Clean(options.Repo)
+	}
+	if options.MaxText == 0 {
+		options.MaxText = defaultStreamMaxText
+	}
+	database, err := OpenQueryMirror(options.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	stream := &EventStream{database: database, options: options, cursors: map[int64]int{}, syncErrors: map[int64]string{}}
+	chats, err := stream.loadChats(ctx)
+	if err != nil {
+		db.CloseDB(database)
+		return nil, err
+	}
+	for _, chat := range chats {
+		stream.cursors[chat.id] = chat.syncedSeq
+		stream.syncErrors[chat.id] = chat.syncError
+	}
+	if options.Backlog > 0 {
+		if err := stream.rewind(ctx, options.Backlog); err != nil {
+			db.CloseDB(database)
+			return nil, err
+		}
+	}
+	return stream, nil
+}
+
+// Close releases the database connection.
+func (s *EventStream) Close() error {
+	return db.CloseDB(s.database)
+}
+
+// Poll returns the events for entries ingested since the previous poll,
+// ordered by entry timestamp.
+func (s *EventStream) Poll(ctx context.Context) ([]StreamEvent, error) {
+	chats, err := s.loadChats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var events []StreamEvent
+	for _, chat := range chats {
+		cursor, known := s.cursors[chat.id]
+		if !known {
+			// A session that appeared after the stream opened is new in full.
+			cursor = -1
+		}
+		if chat.syncedSeq > cursor {
+			chatEvents, err := s.loadChatEvents(ctx, chat, cursor, chat.syncedSeq)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, chatEvents...)
+		}
+		s.cursors[chat.id] = chat.syncedSeq
+		if chat.syncError != "" && chat.syncError != s.syncErrors[chat.id] {
+			events = append(events, StreamEvent{
+				Event: StreamEventError, Kind: "sync", SessionID: chat.uuid, Runtime: chat.runtime,
+				RepoRoot: chat.repoRoot, Seq: chat.syncedSeq, Text: chat.syncError,
+				Timestamp: time.Now().UTC().Format(time.RFC3339Nano),
+			})
+		}
+		s.syncErrors[chat.id] = chat.syncError
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Timestamp < events[j].Timestamp })
+
+	filtered := events[:0]
+	for _, event := range events {
+		if s.options.Match(event) {
+			filtered = append(filtered, event)
+		}
+	}
+	return filtered, nil
+}
+
+// Follow polls every interval and passes each event to emit until ctx is
+// cancelled or emit fails.
+func (s *EventStream) Follow(ctx context.Context, interval time.Duration, emit func(StreamEvent) error) error {
+	if interval <= 0 {
+		interval = 500 * time.Millisecond
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		events, err := s.Poll(ctx)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := emit(event); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Match applies the session, runtime, repo, tool, op, and event-type filters.
+// Tool and op filters drop events that carry no tool or op.
+func (o StreamOptions) Match(event StreamEvent) bool {
+	if len(o.Events) > 0 && !containsString(o.Events, event.Event) {
+		return false
+	}
+	if o.SessionID != "" && event.SessionID != o.SessionID {
+		return false
+	}
+	if o.Runtime != "" && event.Runtime != o.Runtime {
+		return false
+	}
+	if o.Repo != "" && event.RepoRoot != filepath.Clean(o.Repo) {
+		return false
+	}
+	if o.Tool != "" && !strings.EqualFold(event.Tool, o.Tool) {
+		return false
+	}
+	if o.Op != "" && event.Op != o.Op {
+		return false
+	}
+	return true
+}
+
+func (s *EventStream) loadChats(ctx context.Context) ([]streamChat, error) {
+	query := `
+		SELECT id, uuid, runtime, COALESCE(repo_root, ''), synced_seq, COALESCE(sync_error, '')
+		FROM pi_chats WHERE file_deleted_at IS NULL`
+	var args []any
+	if s.options.SessionID != "" {
+		query += ` AND uuid = ?`
+		args = append(args, s.options.SessionID)
+	}
+	if s.options.Runtime != "" {
+		query += ` AND runtime = ?`
+		args = append(args, s.options.Runtime)
+	}
+	if s.options.Repo != "" {
+		query += ` AND repo_root = ?`
+		args = append(args, s.options.Repo)
+	}
+	rows, err := s.database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var chats []streamChat
+	for rows.Next() {
+		var chat streamChat
+		if err := rows.Scan(&chat.id, &chat.uuid, &chat.runtime, &chat.repoRoot, &chat.syncedSeq, &chat.syncError); err != nil {
+			return nil, err
+		}
+		chats = append(chats, chat)
+	}
+	return chats, rows.Err()
+}
+
+// rewind moves cursors back so the next poll replays the last n entries
+// across the selected sessions.
+func (s *EventStream) rewind(ctx context.Context, n int) error {
+	if len(s.cursors) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(s.cursors))
+	for id := range s.cursors {
+		ids = append(ids, fmt.Sprint(id))
+	}
+	rows, err := s.database.QueryContext(ctx, `
+		SELECT chat_id, MIN(seq) FROM (
+			SELECT chat_id, seq FROM pi_messages
+			WHERE chat_id IN (`+strings.Join(ids, ",")+`)
+			ORDER BY timestamp DESC, id DESC LIMIT ?
+		) GROUP BY chat_id`, n)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var chatID int64
+		var seq int
+		if err := rows.Scan(&chatID, &seq); err != nil {
+			return err
+		}
+		s.cursors[chatID] = seq - 1
+	}
+	return rows.Err()
+}
+
+type streamToolCall struct {
+	messageID       int64
+	resultMessageID int64
+	event           StreamEvent
+	isError         bool
+	resultText      string
+}
+
+// loadChatEvents builds the events for entries with from < seq <= to. Each
+// entry yields its message, then its tool calls, the results it carries,
+// and its file references.
+func (s *EventStream) loadChatEvents(ctx context.Context, chat streamChat, from int, to int) ([]StreamEvent, error) {
+	base := StreamEvent{SessionID: chat.uuid, Runtime: chat.runtime, RepoRoot: chat.repoRoot}
+
+	calls, err := s.loadStreamToolCalls(ctx, chat.id, from, to, base)
+	if err != nil {
+		return nil, err
+	}
+	callsByMessage := map[int64][]int{}
+	resultsByMessage := map[int64][]int{}
+	for i, call := range calls {
+		callsByMessage[call.messageID] = append(callsByMessage[call.messageID], i)
+		if call.resultMessageID != 0 {
+			resultsByMessage[call.resultMessageID] = append(resultsByMessage[call.resultMessageID], i)
+		}
+	}
+	refs, err := s.loadStreamFileRefs(ctx, chat.id, from, to, base)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.database.QueryContext(ctx, `
+		SELECT id, entry_id, seq, type, COALESCE(role, ''), COALESCE(model, ''), COALESCE(text, ''), timestamp,
+		       CASE WHEN raw_line != '' AND json_valid(raw_line)
+		            THEN COALESCE(json_extract(raw_line, '$.message.stopReason'), '') ELSE '' END,
+		       CASE WHEN raw_line != '' AND json_valid(raw_line)
+		            THEN COALESCE(json_extract(raw_line, '$.message.errorMessage'), '') ELSE '' END
+		FROM pi_messages
+		WHERE chat_id = ? AND seq > ? AND seq <= ?
+		ORDER BY seq`, chat.id, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var events []StreamEvent
+	for rows.Next() {
+		var id int64
+		var entryType, text, stopReason, errorMessage string
+		event := base
+		if err := rows.Scan(&id, &event.EntryID, &event.Seq, &entryType, &event.Role, &event.Model, &text,
+			&event.Timestamp, &stopReason, &errorMessage); err != nil {
+			return nil, err
+		}
+		// Tool-result entries are reported as tool_result events only.
+		carriesResults := len(resultsByMessage[id]) > 0 || event.Role == "toolResult"
+		if text != "" && !(carriesResults && event.Role != "assistant") {
+			message := event
+			message.Event = StreamEventMessage
+			message.EntryType = entryType
+			message.Text, message.Truncated = truncateRunes(text, s.options.MaxText)
+			events = append(events, message)
+		}
+		for _, i := range callsByMessage[id] {
+			events = append(events, calls[i].event)
+		}
+		for _, i := range resultsByMessage[id] {
+			call := calls[i]
+			result := call.event
+			result.Event = StreamEventToolResult
+			result.EntryID, result.Seq, result.Timestamp = event.EntryID, event.Seq, event.Timestamp
+			result.Arguments = nil
+			result.IsError = call.isError
+			result.Text, result.Truncated = truncateRunes(call.resultText, s.options.MaxText)
+			events = append(events, result)
+			if call.isError {
+				failure := result
+				failure.Event = StreamEventError
+				failure.Kind = "tool"
+				events = append(events, failure)
+			}
+		}
+		events = append(events, refs[id]...)
+		if stopReason == "error" {
+			failure := event
+			failure.Event = StreamEventError
+			failure.Kind = "assistant"
+			failure.Text, failure.Truncated = truncateRunes(errorMessage, s.options.MaxText)
+			events = append(events, failure)
+		}
+	}
+	return events, rows.Err()
+}
+
+func (s *EventStream) loadStreamToolCalls(ctx context.Context, chatID int64, from int, to int, base StreamEvent) ([]streamToolCall, error) {
+	rows, err := s.database.QueryContext(ctx, `
+		SELECT t.message_id, COALESCE(t.result_message_id, 0), t.entry_id, t.seq, t.timestamp,
+		       t.tool_call_id, t.tool_name, t.arguments_json, COALESCE(t.is_error, 0), COALESCE(t.result_text, '')
+		FROM pi_tool_calls t
+		WHERE t.chat_id = ? AND ((t.seq > ? AND t.seq <= ?) OR t.result_message_id IN (
+			SELECT id FROM pi_messages WHERE chat_id = ? AND seq > ? AND seq <= ?))
+		ORDER BY t.seq, t.block_index`, chatID, from, to, chatID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var calls []streamToolCall
+	for rows.Next() {
+		var call streamToolCall
+		var arguments string
+		event := base
+		if err := rows.Scan(&call.messageID, &call.resultMessageID, &event.EntryID, &event.Seq, &event.Timestamp,
+			&event.ToolCallID, &event.Tool, &arguments, &call.isError, &call.resultText); err != nil {
+			return nil, err
+		}
+		event.Event = StreamEventToolCall
+		event.Op = fileRefToolOp(event.Tool)
+		if json.Valid([]byte(arguments)) {
+			event.Arguments = json.RawMessage(arguments)
+		}
+		call.event = event
+		// A call from an earlier poll only contributes its result.
+		if event.Seq <= from {
+			call.messageID = 0
+		}
+		calls = append(calls, call)
+	}
+	return calls, rows.Err()
+}
+
+func (s *EventStream) loadStreamFileRefs(ctx context.Context, chatID int64, from int, to int, base StreamEvent) (map[int64][]StreamEvent, error) {
+	rows, err := s.database.QueryContext(ctx, `
+		SELECT r.message_id, r.entry_id, m.seq, r.timestamp, COALESCE(r.tool_call_id, ''), COALESCE(r.tool_name, ''),
+		       r.op, r.source, COALESCE(NULLIF(r.file_path_rel, ''), r.abs_path, r.raw_path), COALESCE(r.abs_path, '')
+		FROM pi_file_refs r
+		JOIN pi_messages m ON m.id = r.message_id
+		WHERE r.chat_id = ? AND m.seq > ? AND m.seq <= ?
+		ORDER BY m.seq, r.id`, chatID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	refs := map[int64][]StreamEvent{}
+	for rows.Next() {
+		var messageID int64
+		event := base
+		if err := rows.Scan(&messageID, &event.EntryID, &event.Seq, &event.Timestamp, &event.ToolCallID, &event.Tool,
+			&event.Op, &event.Kind, &event.Path, &event.AbsPath); err != nil {
+			return nil, err
+		}
+		event.Event = StreamEventFileRef
+		refs[messageID] = append(refs[messageID], event)
+	}
+	return refs, rows.Err()
+}
@@ -0,0 +1,190 @@
+/**
+ * Component: Session Event Socket Hub
+ * Block-UUID: 6b2e8d53-9a14-4f70-a6c3-4d1f7e0b8a92
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Fans the session event stream out to several local subscribers over a Unix socket as NDJSON, dropping subscribers that fall behind, and reads such a socket back as a filtered event stream.
+ * Language: Go
+ * Created-at: 2026-10-18T21:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// hubClientBuffer is how many events a subscriber may lag behind before it is
+// disconnected; a stalled reader must not block the stream.
+const hubClientBuffer = 1024
+
+// EventHub serves stream events to every process connected to a Unix socket.
+type EventHub struct {
+	path     string
+	listener net.Listener
+	mu       sync.Mutex
+	clients  map[*hubClient]struct{}
+	closed   bool
+}
+
+type hubClient struct {
+	conn net.Conn
+	send chan []byte
+	once sync.Once
+}
+
+// ListenEventHub listens on path. A socket left behind by a process that
+// exited is replaced; one that still accepts connections is an error.
+func ListenEventHub(path string) (*EventHub, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("%s is already served by another process", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	hub := &EventHub{path: path, listener: listener, clients: map[*hubClient]struct{}{}}
+	go hub.accept()
+	return hub, nil
+}
+
+func (h *EventHub) accept() {
+	for {
+		conn, err := h.listener.Accept()
+		if err != nil {
+			return
+		}
+		client := &hubClient{conn: conn, send: make(chan []byte, hubClientBuffer)}
+		h.mu.Lock()
+		if h.closed {
+			h.mu.Unlock()
+			conn.Close()
+			return
+		}
+		h.clients[client] = struct{}{}
+		h.mu.Unlock()
+		go h.write(client)
+	}
+}
+
+func (h *EventHub) write(client *hubClient) {
+	defer h.drop(client)
+	for line := range client.send {
+		if _, err := client.conn.Write(line); err != nil {
+			return
+		}
+	}
+}
+
+func (h *EventHub) drop(client *hubClient) {
+	h.mu.Lock()
+	delete(h.clients, client)
+	h.mu.Unlock()
+	client.once.Do(func() {
+		close(client.send)
+		client.conn.Close()
+	})
+}
+
+// Publish sends one event to every subscriber.
+func (h *EventHub) Publish(event StreamEvent) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+	h.mu.Lock()
+	var lagging []*hubClient
+	for client := range h.clients {
+		select {
+		case client.send <- line:
+		default:
+			lagging = append(lagging, client)
+		}
+	}
+	h.mu.Unlock()
+	for _, client := range lagging {
+		h.drop(client)
+	}
+	return nil
+}
+
+// Subscribers returns the number of connected subscribers.
+func (h *EventHub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
+
+// Close disconnects every subscriber and removes the socket.
+func (h *EventHub) Close() error {
+	h.mu.Lock()
+	h.closed = true
+	clients := make([]*hubClient, 0, len(h.clients))
+	for client := range h.clients {
+		clients = append(clients, client)
+	}
+	h.mu.Unlock()
+	err := h.listener.Close()
+	for _, client := range clients {
+		h.drop(client)
+	}
+	os.Remove(h.path)
+	return err
+}
+
+// SubscribeEventHub reads events from a hub socket and passes those matching
+// filter to emit until ctx is cancelled, the hub closes, or emit fails.
+func SubscribeEventHub(ctx context.Context, path string, filter StreamOptions, emit func(StreamEvent) error) error {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return fmt.Errorf("connect to %s: %w", path, err)
+	}
+	defer conn.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var event StreamEvent
+			if jsonErr := json.Unmarshal(line, &event); jsonErr == nil && filter.Match(event) {
+				if emitErr := emit(event); emitErr != nil {
+					return emitErr
+				}
+			}
+		}
+		if err != nil {
+			if err == io.EOF || ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+	}
+}
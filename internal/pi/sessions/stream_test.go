@@ -0,0 +1,168 @@
+/**
+ * Component: Session Event Stream Tests
+ * Block-UUID: 0d7c4a19-2b86-4e53-9f1e-8a5b3c6d2e70
+ * Parent-UUID: N/A
+ * Version: 1.0.0
+ * Description: Verifies that the event stream emits message, tool_call, tool_result, file_ref, and error events for newly ingested entries only, applies filters and backlog replay, and fans events out over the Unix socket hub.
+ * Language: Go
+ * Created-at: 2026-10-18T21:00:00Z
+ * Authors: agent (v1.0.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var streamFixtureLines = []string{
+	`{"type":"session","version":3,"id":"019edff0-0000-7000-8000-00000000a001","timestamp":"2026-10-18T09:00:00.000Z","cwd":"/work/stream-demo"}`,
+	`{"type":"message","id":"s1","parentId":null,"timestamp":"2026-10-18T09:00:01.000Z","message":{"role":"user","content":[{"type":"text","text":"fix main"}]}}`,
+}
+
+func streamEventNames(events []StreamEvent) []string {
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.Event
+		if event.Kind != "" {
+			names[i] += ":" + event.Kind
+		}
+	}
+	return names
+}
+
+func TestEventStreamFollowsIngestedEntries(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	sessionsDir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "sessions.sqlite3")
+	sessionPath := filepath.Join(sessionsDir, "stream.jsonl")
+	writeFixtureLines(t, sessionPath, streamFixtureLines)
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: sessionsDir, DBPath: dbPath}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	stream, err := OpenEventStream(ctx, StreamOptions{DBPath: dbPath})
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+	defer stream.Close()
+	edits, err := OpenEventStream(ctx, StreamOptions{DBPath: dbPath, Op: "edit"})
+	if err != nil {
+		t.Fatalf("open filtered stream: %v", err)
+	}
+	defer edits.Close()
+	if events, err := stream.Poll(ctx); err != nil || len(events) != 0 {
+		t.Fatalf("initial poll = %v (%v)", streamEventNames(events), err)
+	}
+
+	appendFixtureLine(t, sessionPath, `{"type":"message","id":"s2","parentId":"s1","timestamp":"2026-10-18T09:00:02.000Z","message":{"role":"assistant","model":"m1","content":[{"type":"text","text":"Editing."},{"type":"toolCall","id":"call_s","name":"edit","arguments":{"path":"main.go","oldText":"a","newText":"b"}}]}}`)
+	appendFixtureLine(t, sessionPath, `{"type":"message","id":"s3","parentId":"s2","timestamp":"2026-10-18T09:00:03.000Z","message":{"role":"toolResult","toolCallId":"call_s","toolName":"edit","isError":true,"content":[{"type":"text","text":"oldText not found"}]}}`)
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: sessionsDir, DBPath: dbPath}); err != nil {
+		t.Fatalf("sync append: %v", err)
+	}
+
+	events, err := stream.Poll(ctx)
+	if err != nil {
+		t.Fatalf("poll: %v", err)
+	}
+	got := streamEventNames(events)
+	want := []string{"message", "tool_call", "file_ref:tool_call", "tool_result", "error:tool"}
+	if len(got) != len(want) {
+		t.Fatalf("events = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("events = %v, want %v", got, want)
+		}
+	}
+	call, result := events[1], events[3]
+	if call.Tool != "edit" || call.Op != "edit" || call.EntryID != "s2" || len(call.Arguments) == 0 {
+		t.Fatalf("tool_call = %+v", call)
+	}
+	if !result.IsError || result.Text != "oldText not found" || result.EntryID != "s3" || result.ToolCallID != "call_s" {
+		t.Fatalf("tool_result = %+v", result)
+	}
+	if events[2].Path != "/work/stream-demo/main.go" {
+		t.Fatalf("file_ref = %+v", events[2])
+	}
+	if events, err := stream.Poll(ctx); err != nil || len(events) != 0 {
+		t.Fatalf("repeat poll = %v (%v)", streamEventNames(events), err)
+	}
+
+	filtered, err := edits.Poll(ctx)
+	if err != nil {
+		t.Fatalf("filtered poll: %v", err)
+	}
+	for _, event := range filtered {
+		if event.Op != "edit" {
+			t.Fatalf("op filter passed %+v", event)
+		}
+	}
+	if len(filtered) != 4 {
+		t.Fatalf("filtered events = %v", streamEventNames(filtered))
+	}
+
+	replay, err := OpenEventStream(ctx, StreamOptions{DBPath: dbPath, Backlog: 1, Events: []string{StreamEventToolResult}})
+	if err != nil {
+		t.Fatalf("open replay: %v", err)
+	}
+	defer replay.Close()
+	if events, err := replay.Poll(ctx); err != nil || len(events) != 1 || events[0].EntryID != "s3" {
+		t.Fatalf("replay = %+v (%v)", events, err)
+	}
+	if _, err := OpenEventStream(ctx, StreamOptions{DBPath: dbPath, Events: []string{"bogus"}}); err == nil {
+		t.Fatal("unknown event type accepted")
+	}
+}
+
+func TestEventHubFanOut(t *testing.T) {
+	t.Parallel()
+	dir, err := os.MkdirTemp("", "hub")
+	if err != nil {
+		t.Fatalf("temp dir: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "tail.sock")
+	hub, err := ListenEventHub(socket)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer hub.Close()
+	if _, err := ListenEventHub(socket); err == nil {
+		t.Fatal("second hub on a live socket accepted")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	received := make(chan StreamEvent, 4)
+	for i := 0; i < 2; i++ {
+		go SubscribeEventHub(ctx, socket, StreamOptions{Tool: "bash"}, func(event StreamEvent) error {
+			received <- event
+			return nil
+		})
+	}
+	for hub.Subscribers() < 2 {
+		select {
+		case <-ctx.Done():
+			t.Fatal("subscribers did not connect")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	hub.Publish(StreamEvent{Event: StreamEventToolCall, Tool: "read", SessionID: "x"})
+	hub.Publish(StreamEvent{Event: StreamEventToolCall, Tool: "bash", SessionID: "x"})
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-received:
+			if event.Tool != "bash" {
+				t.Fatalf("subscriber filter passed %+v", event)
+			}
+		case <-ctx.Done():
+			t.Fatal("event not delivered")
+		}
+	}
+}
@@ -0,0 +1,322 @@
+/**
+ * Component: Session Token and Cost Analytics
+ * Block-UUID: 909eadba-096a-441e-8987-b96413225d8b
+ * Parent-UUID: 7651d12a-dc10-41f6-ab4d-1a5a776fa462
+ * Version: 1.4.0
+ * Description: Aggregates provider-reported token usage from assistant messages in the sessions mirror into day, repo, model, provider, session, or runtime groups, estimates cost from the pricing table, and flags sessions that hit compaction; samples carry their entry id and can be limited to one session. Archived messages are read from the usage fields kept in pi_archived_usage. A plain date passed as Until covers that whole day.
+ * Language: Go
+ * Created-at: 2026-10-18T15:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0), agent (v1.2.0), agent (v1.3.0), agent (v1.4.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gitsense/gsc-cli/internal/db"
+)
+
+// usageSample is one assistant response (or compaction marker) read from the
+// mirror. Pi records usage as {input, output, cacheRead, cacheWrite, cost};
+// Claude Code as {input_tokens, output_tokens, cache_read_input_tokens,
+// cache_creation_input_tokens}. Both are read with COALESCE.
+type usageSample struct {
+	sessionID  string
+	entryID    string
+	runtime    string
+	title      string
+	repoRoot   string
+	entryType  string
+	model      string
+	provider   string
+	timestamp  string
+	messageID  string
+	input      int64
+	output     int64
+	cacheRead  int64
+	cacheWrite int64
+	reported   float64
+}
+
+// Usage aggregates token usage and estimated cost over the mirror.
+func Usage(ctx context.Context, options UsageOptions) (*UsageReport, error) {
+	if options.DBPath == "" {
+		return nil, fmt.Errorf("db path is required")
+	}
+	if err := ValidateUsageGroup(options.GroupBy); err != nil {
+		return nil, err
+	}
+	if options.GroupBy == "" {
+		options.GroupBy = UsageGroupDay
+	}
+	pricing := options.Pricing
+	if pricing == nil {
+		pricing = DefaultPricingTable()
+	}
+	database, err := OpenQueryMirror(options.DBPath)
+	if err != nil {
+		return nil, err
+	}
+	defer db.CloseDB(database)
+
+	samples, err := loadUsageSamples(ctx, database, options)
+	if err != nil {
+		return nil, err
+	}
+	return aggregateUsage(samples, options, pricing), nil
+}
+
+// ValidateUsageGroup reports an error for an unknown grouping. Empty means day.
+func ValidateUsageGroup(group string) error {
+	if group == "" {
+		return nil
+	}
+	for _, known := range UsageGroups {
+		if group == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown usage grouping %q (use %s)", group, strings.Join(UsageGroups, ", "))
+}
+
+// usageMessages stands in for pi_messages in usage queries. usage_line is the
+// raw line, or for an archived chat the usage fields kept in pi_archived_usage
+// (NULL for archived messages without usage).
+const usageMessages = `(
+	SELECT m.*, COALESCE(u.usage_line, NULLIF(m.raw_line, '')) AS usage_line
+	FROM pi_messages m
+	LEFT JOIN pi_archived_usage u ON u.message_id = m.id)`
+
+func loadUsageSamples(ctx context.Context, database *sql.DB, options UsageOptions) ([]usageSample, error) {
+	query := `
+		SELECT c.uuid, c.runtime, COALESCE(c.name, ''), COALESCE(c.first_user_text, ''),
+		       COALESCE(c.repo_root, c.cwd, ''), m.entry_id, m.type,
+		       COALESCE(m.model, c.model, ''), COALESCE(m.provider, c.provider, ''), m.timestamp,
+		       COALESCE(json_extract(m.usage_line, '$.message.id'), ''),
+		       COALESCE(json_extract(m.usage_line, '$.message.usage.input'), json_extract(m.usage_line, '$.message.usage.input_tokens'), 0),
+		       COALESCE(json_extract(m.usage_line, '$.message.usage.output'), json_extract(m.usage_line, '$.message.usage.output_tokens'), 0),
+		       COALESCE(json_extract(m.usage_line, '$.message.usage.cacheRead'), json_extract(m.usage_line, '$.message.usage.cache_read_input_tokens'), 0),
+		       COALESCE(json_extract(m.usage_line, '$.message.usage.cacheWrite'), json_extract(m.usage_line, '$.message.usage.cache_creation_input_tokens'), 0),
+		       COALESCE(json_extract(m.usage_line, '$.message.usage.cost.total'), 0)
+		FROM ` + usageMessages + ` m
+		JOIN pi_chats c ON c.id = m.chat_id
+		WHERE c.file_deleted_at IS NULL
+			AND (m.type = 'compaction' OR (m.role = 'assistant' AND json_extract(m.usage_line, '$.message.usage') IS NOT NULL))`
+	var args []interface{}
+	if options.SessionID != "" {
+		query += " AND c.uuid = ?"
+		args = append(args, options.SessionID)
+	}
+	if options.Repo != "" {
+		query += " AND c.repo_root = ?"
+		args = append(args, options.Repo)
+	}
+	if options.Runtime != "" {
+		query += " AND c.runtime = ?"
+		args = append(args, options.Runtime)
+	}
+	if options.Provider != "" {
+		query += " AND COALESCE(m.provider, c.provider) = ?"
+		args = append(args, options.Provider)
+	}
+	if options.Model != "" {
+		query += " AND COALESCE(m.model, c.model) = ?"
+		args = append(args, options.Model)
+	}
+	if options.Since != "" {
+		query += " AND m.timestamp >= ?"
+		args = append(args, options.Since)
+	}
+	if options.Until != "" {
+		op, bound := untilBound(options.Until)
+		query += " AND m.timestamp " + op + " ?"
+		args = append(args, bound)
+	}
+	query += " ORDER BY c.id, m.seq"
+
+	rows, err := database.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var samples []usageSample
+	// Claude Code writes one line per content block of a response, each
+	// repeating the response's usage. Keep only the last line per message id.
+	lastByMessage := map[string]int{}
+	for rows.Next() {
+		var s usageSample
+		var name, firstUser string
+		if err := rows.Scan(&s.sessionID, &s.runtime, &name, &firstUser, &s.repoRoot, &s.entryID, &s.entryType,
+			&s.model, &s.provider, &s.timestamp, &s.messageID,
+			&s.input, &s.output, &s.cacheRead, &s.cacheWrite, &s.reported); err != nil {
+			return nil, err
+		}
+		s.title = sessionTitle(name, firstUser)
+		if s.entryType != "compaction" && s.input+s.output+s.cacheRead+s.cacheWrite == 0 && s.reported == 0 {
+			continue
+		}
+		if s.messageID != "" {
+			key := s.sessionID + "\x00" + s.messageID
+			if i, ok := lastByMessage[key]; ok {
+				samples[i] = s
+				continue
+			}
+			lastByMessage[key] = len(samples)
+		}
+		samples = append(samples, s)
+	}
+	return samples, rows.Err()
+}
+
+func aggregateUsage(samples []usageSample, options UsageOptions, pricing *PricingTable) *UsageReport {
+	report := &UsageReport{GroupBy: options.GroupBy, Currency: pricing.Currency, Rows: []UsageRow{}, Compacted: []CompactedSession{}}
+	groups := map[string]*UsageRow{}
+	groupSessions := map[string]map[string]bool{}
+	allSessions := map[string]bool{}
+	compacted := map[string]*CompactedSession{}
+	sessionCost := map[string]*CompactedSession{}
+
+	for _, s := range samples {
+		key := usageGroupKey(options.GroupBy, s)
+		row, ok := groups[key]
+		if !ok {
+			row = &UsageRow{Key: key}
+			if options.GroupBy == UsageGroupSession {
+				row.Title = s.title
+				row.RepoRoot = s.repoRoot
+			}
+			groups[key] = row
+			groupSessions[key] = map[string]bool{}
+		}
+		if !groupSessions[key][s.sessionID] {
+			groupSessions[key][s.sessionID] = true
+			row.Sessions++
+		}
+		if !allSessions[s.sessionID] {
+			allSessions[s.sessionID] = true
+			report.Total.Sessions++
+		}
+		perSession, ok := sessionCost[s.sessionID]
+		if !ok {
+			perSession = &CompactedSession{SessionID: s.sessionID, Runtime: s.runtime, Title: s.title, RepoRoot: s.repoRoot}
+			sessionCost[s.sessionID] = perSession
+		}
+
+		if s.entryType == "compaction" {
+			row.Compactions++
+			report.Total.Compactions++
+			perSession.Compactions++
+			compacted[s.sessionID] = perSession
+			continue
+		}
+
+		sample := UsageTotals{
+			Messages:     1,
+			InputTokens:  s.input,
+			OutputTokens: s.output,
+			CacheRead:    s.cacheRead,
+			CacheWrite:   s.cacheWrite,
+			TotalTokens:  s.input + s.output + s.cacheRead + s.cacheWrite,
+			ReportedCost: s.reported,
+		}
+		if price, ok := pricing.Lookup(s.model); ok {
+			sample.Cost = price.Cost(s.input, s.output, s.cacheRead, s.cacheWrite)
+		} else if s.reported > 0 {
+			sample.Cost = s.reported
+		} else {
+			sample.UnpricedMessages = 1
+		}
+		addUsage(&row.UsageTotals, sample)
+		addUsage(&report.Total, sample)
+		perSession.TotalTokens += sample.TotalTokens
+		perSession.Cost += sample.Cost
+	}
+
+	for _, row := range groups {
+		report.Rows = append(report.Rows, *row)
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		a, b := report.Rows[i], report.Rows[j]
+		if options.GroupBy == UsageGroupDay {
+			return a.Key < b.Key
+		}
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		if a.TotalTokens != b.TotalTokens {
+			return a.TotalTokens > b.TotalTokens
+		}
+		return a.Key < b.Key
+	})
+	if options.Limit > 0 && len(report.Rows) > options.Limit {
+		report.Rows = report.Rows[:options.Limit]
+	}
+
+	for _, session := range compacted {
+		report.Compacted = append(report.Compacted, *session)
+	}
+	sort.Slice(report.Compacted, func(i, j int) bool {
+		a, b := report.Compacted[i], report.Compacted[j]
+		if a.Compactions != b.Compactions {
+			return a.Compactions > b.Compactions
+		}
+		if a.TotalTokens != b.TotalTokens {
+			return a.TotalTokens > b.TotalTokens
+		}
+		return a.SessionID < b.SessionID
+	})
+	return report
+}
+
+func usageGroupKey(group string, s usageSample) string {
+	var key string
+	switch group {
+	case UsageGroupDay:
+		if len(s.timestamp) >= 10 {
+			key = s.timestamp[:10]
+		}
+	case UsageGroupRepo:
+		key = s.repoRoot
+	case UsageGroupModel:
+		key = s.model
+	case UsageGroupProvider:
+		key = s.provider
+	case UsageGroupSession:
+		key = s.sessionID
+	case UsageGroupRuntime:
+		key = s.runtime
+	}
+	if key == "" {
+		return "(unknown)"
+	}
+	return key
+}
+
+func addUsage(total *UsageTotals, sample UsageTotals) {
+	total.Messages += sample.Messages
+	total.InputTokens += sample.InputTokens
+	total.OutputTokens += sample.OutputTokens
+	total.CacheRead += sample.CacheRead
+	total.CacheWrite += sample.CacheWrite
+	total.TotalTokens += sample.TotalTokens
+	total.Cost += sample.Cost
+	total.ReportedCost += sample.ReportedCost
+	total.UnpricedMessages += sample.UnpricedMessages
+}
+
+// untilBound returns the comparison for an inclusive Until value. Stored
+// timestamps are UTC RFC3339 strings, so a plain date (2006-01-02) becomes an
+// exclusive bound at the start of the next day and covers the whole day.
+func untilBound(value string) (string, string) {
+	if day, err := time.Parse("2006-01-02", value); err == nil {
+		return "<", day.AddDate(0, 0, 1).Format("2006-01-02")
+	}
+	return "<=", value
+}
@@ -0,0 +1,125 @@
+/**
+ * Component: Session Usage Analytics Tests
+ * Block-UUID: 8b2c4e71-5d09-4f36-a1e8-7c3f96d0b245
+ * Parent-UUID: N/A
+ * Version: 1.1.0
+ * Description: Verifies usage aggregation across Pi and Claude Code sessions: pricing-table estimates, reported-cost fallback, Claude per-block usage deduplication, grouping, compaction highlighting, and a date as --until covering the whole day.
+ * Language: Go
+ * Created-at: 2026-10-18T15:00:00Z
+ * Authors: agent (v1.0.0), agent (v1.1.0)
+ */
+
+package sessions
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var usagePiLines = []string{
+	`{"type":"session","version":3,"id":"019edff0-0000-7000-8000-00000000u001","timestamp":"2026-10-16T09:00:00.000Z","cwd":"/work/usage-pi"}`,
+	`{"type":"message","id":"u1","parentId":null,"timestamp":"2026-10-16T09:00:01.000Z","message":{"role":"user","content":[{"type":"text","text":"hi"}]}}`,
+	`{"type":"message","id":"u2","parentId":"u1","timestamp":"2026-10-16T09:00:02.000Z","message":{"role":"assistant","provider":"custom","model":"house-model","content":[{"type":"text","text":"hello"}],"usage":{"input":1000,"output":500,"cacheRead":0,"cacheWrite":0,"totalTokens":1500,"cost":{"total":0.25}}}}`,
+	`{"type":"compaction","id":"u3","parentId":"u2","timestamp":"2026-10-17T09:00:00.000Z","summary":"compacted","details":{"readFiles":[],"modifiedFiles":[]}}`,
+	`{"type":"message","id":"u4","parentId":"u3","timestamp":"2026-10-17T09:00:01.000Z","message":{"role":"assistant","provider":"anthropic","model":"claude-sonnet-4-20250514","content":[{"type":"text","text":"again"}],"usage":{"input":1000000,"output":0,"cacheRead":0,"cacheWrite":0,"totalTokens":1000000,"cost":{"total":9}}}}`,
+}
+
+var usageClaudeLines = []string{
+	`{"type":"user","uuid":"c1","parentUuid":null,"timestamp":"2026-10-17T10:00:00.000Z","cwd":"/work/usage-claude","message":{"role":"user","content":"go"}}`,
+	`{"type":"assistant","uuid":"c2","parentUuid":"c1","timestamp":"2026-10-17T10:00:01.000Z","cwd":"/work/usage-claude","message":{"id":"msg_1","role":"assistant","model":"claude-sonnet-4-20250514","content":[{"type":"thinking","thinking":"hm"}],"usage":{"input_tokens":10,"output_tokens":1,"cache_read_input_tokens":0,"cache_creation_input_tokens":0}}}`,
+	`{"type":"assistant","uuid":"c3","parentUuid":"c2","timestamp":"2026-10-17T10:00:02.000Z","cwd":"/work/usage-claude","message":{"id":"msg_1","role":"assistant","model":"claude-sonnet-4-20250514","content":[{"type":"text","text":"done"}],"usage":{"input_tokens":10,"output_tokens":100000,"cache_read_input_tokens":1000000,"cache_creation_input_tokens":0}}}`,
+}
+
+func TestUsageAggregatesAcrossRuntimes(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	piDir := t.TempDir()
+	claudeDir := t.TempDir()
+	dbPath := filepath.Join(t.TempDir(), "sessions.sqlite3")
+	writeFixtureLines(t, filepath.Join(piDir, "usage.jsonl"), usagePiLines)
+	claudePath := filepath.Join(claudeDir, "-work-usage-claude", "5c1d9e63-2a7f-4d2b-8e8f-000000000002.jsonl")
+	if err := os.MkdirAll(filepath.Dir(claudePath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFixtureLines(t, claudePath, usageClaudeLines)
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: piDir, DBPath: dbPath}); err != nil {
+		t.Fatalf("pi sync: %v", err)
+	}
+	if _, err := Sync(ctx, SyncOptions{SessionsDir: claudeDir, DBPath: dbPath, Runtime: RuntimeClaude}); err != nil {
+		t.Fatalf("claude sync: %v", err)
+	}
+
+	report, err := Usage(ctx, UsageOptions{DBPath: dbPath, GroupBy: UsageGroupDay})
+	if err != nil {
+		t.Fatalf("usage: %v", err)
+	}
+	// house-model is unpriced and falls back to its reported 0.25; sonnet is
+	// priced at $3/M input, $15/M output, $0.30/M cache reads.
+	wantTotal := 0.25 + 3.0 + (10*3.0+100000*15.0+1000000*0.3)/1_000_000
+	if report.Total.Messages != 3 || report.Total.Sessions != 2 || math.Abs(report.Total.Cost-wantTotal) > 1e-9 {
+		t.Fatalf("total = %+v, want 3 messages, 2 sessions, cost %.6f", report.Total, wantTotal)
+	}
+	if report.Total.Compactions != 1 || report.Total.UnpricedMessages != 0 || report.Total.ReportedCost != 9.25 {
+		t.Fatalf("total = %+v", report.Total)
+	}
+	if len(report.Rows) != 2 || report.Rows[0].Key != "2026-10-16" || report.Rows[1].Key != "2026-10-17" || report.Rows[1].Compactions != 1 {
+		t.Fatalf("day rows = %+v", report.Rows)
+	}
+	if len(report.Compacted) != 1 || report.Compacted[0].Runtime != RuntimePi || report.Compacted[0].Compactions != 1 {
+		t.Fatalf("compacted = %+v", report.Compacted)
+	}
+
+	// A date as --until covers that whole day
+	untilDay, err := Usage(ctx, UsageOptions{DBPath: dbPath, GroupBy: UsageGroupDay, Until: "2026-10-16"})
+	if err != nil {
+		t.Fatalf("usage until a date: %v", err)
+	}
+	if len(untilDay.Rows) != 1 || untilDay.Rows[0].Key != "2026-10-16" || untilDay.Total.Messages != 1 {
+		t.Fatalf("until 2026-10-16 = %+v", untilDay.Rows)
+	}
+
+	byModel, err := Usage(ctx, UsageOptions{DBPath: dbPath, GroupBy: UsageGroupModel, Runtime: RuntimeClaude})
+	if err != nil {
+		t.Fatalf("usage by model: %v", err)
+	}
+	if len(byModel.Rows) != 1 || byModel.Rows[0].Key != "claude-sonnet-4-20250514" || byModel.Rows[0].OutputTokens != 100000 {
+		t.Fatalf("claude model rows = %+v", byModel.Rows)
+	}
+
+	unpriced, err := Usage(ctx, UsageOptions{DBPath: dbPath, GroupBy: UsageGroupProvider, Pricing: &PricingTable{Currency: "EUR"}, Runtime: RuntimeClaude})
+	if err != nil {
+		t.Fatalf("usage with empty pricing: %v", err)
+	}
+	if unpriced.Currency != "EUR" || unpriced.Total.UnpricedMessages != 1 || unpriced.Rows[0].Key != "anthropic" {
+		t.Fatalf("empty pricing report = %+v", unpriced)
+	}
+}
+
+func TestPricingTableLookup(t *testing.T) {
+	table := DefaultPricingTable()
+	tests := []struct {
+		model string
+		want  float64
+		ok    bool
+	}{
+		{"claude-opus-4-5-20251101", 5, true},
+		{"claude-opus-4-1-20250805", 15, true},
+		{"anthropic/claude-sonnet-4", 3, true},
+		{"gpt-5-mini", 0.25, true},
+		{"house-model", 0, false},
+	}
+	for _, tt := range tests {
+		price, ok := table.Lookup(tt.model)
+		if ok != tt.ok || price.Input != tt.want {
+			t.Errorf("Lookup(%q) = (%v, %v), want input %v ok %v", tt.model, price.Input, ok, tt.want, tt.ok)
+		}
+	}
+
+	missing, err := LoadPricingTable(filepath.Join(t.TempDir(), "pricing.json"))
+	if err != nil || len(missing.Models) != len(table.Models) {
+		t.Fatalf("missing pricing file = %d models, %v", len(missing.Models), err)
+	}
+}
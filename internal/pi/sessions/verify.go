@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Verify
- * Block-UUID: 8a2f3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Reconstructs Pi session JSONL from SQLite and verifies lossless round-trip fidelity.
+ * Block-UUID: 9aa44680-8fe4-4e5e-b8e4-084c5d738e2d
+ * Parent-UUID: baa14379-df74-4c87-a33a-d63f7ec66116
+ * Version: 1.3.0
+ * Description: Reconstructs session JSONL from the mirror, reading archived sessions from their bundle, and verifies it against the source file; lines that differ only by recorded redaction events still count as a match.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: MiMo-v2.5-Pro (v1.0.0)
+ * Authors: MiMo-v2.5-Pro (v1.0.0), agent (v1.1.0), agent (v1.2.0), agent (v1.3.0)
  */
 
 
@@ -33,6 +33,7 @@ type LosslessReport struct {
 	SourceSHA256      string `json:"source_sha256"`
 	ReconstructedSHA256 string `json:"reconstructed_sha256"`
 	Match             bool   `json:"match"`
+	RedactedLines     int    `json:"redacted_lines,omitempty"`
 	SourceMissing     bool   `json:"source_missing,omitempty"`
 	Error             string `json:"error,omitempty"`
 }
@@ -40,13 +41,16 @@ type LosslessReport struct {
 // ReconstructJSONL reads raw_header and raw_lines from SQLite and returns the
 // reconstructed JSONL bytes. The format matches the original file:
 // raw_header + "\n" + join(raw_lines, "\n") + "\n"
+// Claude Code transcripts have no header line; their raw_header is synthetic
+// and is left out of the reconstruction. Archived sessions are read from
+// their bundle.
 func ReconstructJSONL(ctx context.Context, database *sql.DB, sessionUUID string) ([]byte, error) {
 	// Look up chat by uuid to get chat_id and raw_header
 	var chatID int64
-	var rawHeader string
+	var rawHeader, runtime string
 	err := database.QueryRowContext(ctx,
-		"SELECT id, raw_header FROM pi_chats WHERE uuid = ?", sessionUUID,
-	).Scan(&chatID, &rawHeader)
+		"SELECT id, raw_header, runtime FROM pi_chats WHERE uuid = ?", sessionUUID,
+	).Scan(&chatID, &rawHeader, &runtime)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("session %q not found", sessionUUID)
 	}
@@ -74,9 +78,28 @@ func ReconstructJSONL(ctx context.Context, database *sql.DB, sessionUUID string)
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("iterate messages: %w", err)
 	}
+	// Archived chats keep their raw lines in a bundle; the mirror rows are
+	// emptied. Real transcript lines are never empty.
+	if len(rawLines) > 0 && rawLines[0] == "" {
+		lines, archived, err := archivedRawLines(ctx, database, chatID)
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		if archived {
+			rawLines = lines
+		}
+	}
 
 	// Reconstruct: header + newline + joined lines + newline
-	reconstructed := rawHeader + "\n" + strings.Join(rawLines, "\n") + "\n"
+	var lines []string
+	if runtime != RuntimeClaude {
+		lines = append(lines, rawHeader)
+	}
+	lines = append(lines, rawLines...)
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	reconstructed := strings.Join(lines, "\n") + "\n"
 	return []byte(reconstructed), nil
 }
 
@@ -86,10 +109,11 @@ func VerifyLossless(ctx context.Context, database *sql.DB, sessionUUID string) (
 	report := &LosslessReport{SessionID: sessionUUID}
 
 	// Get session_file path from pi_chats
+	var chatID int64
 	var sessionFile string
 	err := database.QueryRowContext(ctx,
-		"SELECT session_file FROM pi_chats WHERE uuid = ?", sessionUUID,
-	).Scan(&sessionFile)
+		"SELECT id, session_file FROM pi_chats WHERE uuid = ?", sessionUUID,
+	).Scan(&chatID, &sessionFile)
 	if err == sql.ErrNoRows {
 		report.Error = fmt.Sprintf("session %q not found", sessionUUID)
 		return report, fmt.Errorf("session %q not found", sessionUUID)
@@ -127,9 +151,44 @@ func VerifyLossless(ctx context.Context, database *sql.DB, sessionUUID string) (
 
 	// Compare
 	report.Match = report.SourceSHA256 == report.ReconstructedSHA256
+	if !report.Match {
+		redacted, ok, err := matchWithRedactions(ctx, database, chatID, string(sourceBytes), string(reconstructedBytes))
+		if err != nil {
+			report.Error = fmt.Sprintf("load redactions: %v", err)
+			return report, fmt.Errorf("load redactions: %w", err)
+		}
+		report.Match = ok
+		report.RedactedLines = redacted
+	}
 	return report, nil
 }
 
+// matchWithRedactions compares source and reconstruction line by line and
+// accepts differing lines only when the mirror line is a recorded redaction of
+// the source line. It returns the number of such lines.
+func matchWithRedactions(ctx context.Context, database *sql.DB, chatID int64, source string, reconstructed string) (int, bool, error) {
+	origins, err := redactionOrigins(ctx, database, chatID)
+	if err != nil || len(origins) == 0 {
+		return 0, false, err
+	}
+	sourceLines := strings.Split(source, "\n")
+	mirrorLines := strings.Split(reconstructed, "\n")
+	if len(sourceLines) != len(mirrorLines) {
+		return 0, false, nil
+	}
+	redacted := 0
+	for i := range sourceLines {
+		if sourceLines[i] == mirrorLines[i] {
+			continue
+		}
+		if !redactedFrom(origins, hashText(mirrorLines[i]), hashText(sourceLines[i])) {
+			return redacted, false, nil
+		}
+		redacted++
+	}
+	return redacted, true, nil
+}
+
 // VerifyLosslessWithDB opens the database and runs verification.
 func VerifyLosslessWithDB(ctx context.Context, dbPath string, sessionUUID string) (*LosslessReport, error) {
 	database, err := OpenQueryMirror(dbPath)
@@ -1,12 +1,12 @@
 /**
  * Component: Pi Sessions Continuous Watcher
- * Block-UUID: 617d28e4-2d83-43f3-8a2c-700d8cf9fdf3
- * Parent-UUID: N/A
- * Version: 1.0.0
- * Description: Runs foreground continuous Pi session reconciliation using recursive filesystem events, debounce coalescing, and periodic scans.
+ * Block-UUID: 1f60cbc8-4d38-47d1-b134-cd9f83cd1104
+ * Parent-UUID: 617d28e4-2d83-43f3-8a2c-700d8cf9fdf3
+ * Version: 1.1.0
+ * Description: Runs foreground continuous session reconciliation for Pi and Claude Code transcript roots using recursive filesystem events, debounce coalescing, and periodic scans.
  * Language: Go
  * Created-at: 2026-06-18T00:00:00Z
- * Authors: Codex GPT-5 (v1.0.0)
+ * Authors: Codex GPT-5 (v1.0.0), agent (v1.1.0)
  */
 
 
@@ -30,7 +30,8 @@ const (
 )
 
 type WatchOptions struct {
-	SessionsDir       string
+	SessionsDir       string // Pi sessions root; empty skips Pi
+	ClaudeProjectsDir string // Claude Code projects root; empty skips Claude Code
 	DBPath            string
 	LogPath           string
 	DebugLogPath      string
@@ -45,16 +46,35 @@ type watchEventSource interface {
 	Close() error
 }
 
+// watchTarget is one runtime's transcript root.
+type watchTarget struct {
+	runtime string
+	root    string
+}
+
 func Watch(ctx context.Context, options WatchOptions) error {
-	if options.SessionsDir == "" {
+	if options.SessionsDir == "" && options.ClaudeProjectsDir == "" {
 		return fmt.Errorf("sessions dir is required")
 	}
 	if options.DBPath == "" {
 		return fmt.Errorf("db path is required")
 	}
-	sessionsDir, err := filepath.Abs(options.SessionsDir)
-	if err != nil {
-		return err
+	var targets []watchTarget
+	var roots []string
+	for _, target := range []watchTarget{
+		{runtime: RuntimePi, root: options.SessionsDir},
+		{runtime: RuntimeClaude, root: options.ClaudeProjectsDir},
+	} {
+		if target.root == "" {
+			continue
+		}
+		root, err := filepath.Abs(target.root)
+		if err != nil {
+			return err
+		}
+		target.root = root
+		targets = append(targets, target)
+		roots = append(roots, root)
 	}
 	if options.DebounceInterval <= 0 {
 		options.DebounceInterval = defaultWatchDebounceInterval
@@ -65,7 +85,7 @@ func Watch(ctx context.Context, options WatchOptions) error {
 	if options.PollInterval <= 0 {
 		options.PollInterval = defaultDirectoryRefreshPeriod
 	}
-	source, err := newFSNotifyEventSource(sessionsDir, options.PollInterval)
+	source, err := newFSNotifyEventSource(roots, options.PollInterval)
 	if err != nil {
 		return err
 	}
@@ -73,19 +93,21 @@ func Watch(ctx context.Context, options WatchOptions) error {
 	logger := newSyncLogger(options.LogPath, options.DebugLogPath)
 	defer logger.Close()
 	reconcile := func(reconcileCtx context.Context) error {
-		logger.LogDebug("starting reconciliation")
-		result, err := Sync(reconcileCtx, SyncOptions{SessionsDir: sessionsDir, DBPath: options.DBPath, Logger: logger})
-		if err != nil {
-			logger.LogError("reconciliation failed: " + err.Error())
-			return err
-		}
-		for _, syncErr := range result.Errors {
-			logger.LogError(fmt.Sprintf("%s: %s", syncErr.Path, syncErr.Error))
-		}
-		if result.SessionsImported > 0 || result.MessagesImported > 0 {
-			logger.LogInfo(fmt.Sprintf("synced %d sessions, %d messages", result.SessionsImported, result.MessagesImported))
+		for _, target := range targets {
+			logger.LogDebug(fmt.Sprintf("starting %s reconciliation", target.runtime))
+			result, err := Sync(reconcileCtx, SyncOptions{SessionsDir: target.root, DBPath: options.DBPath, Runtime: target.runtime, Logger: logger})
+			if err != nil {
+				logger.LogError(fmt.Sprintf("%s reconciliation failed: %s", target.runtime, err.Error()))
+				return err
+			}
+			for _, syncErr := range result.Errors {
+				logger.LogError(fmt.Sprintf("%s: %s", syncErr.Path, syncErr.Error))
+			}
+			if result.SessionsImported > 0 || result.MessagesImported > 0 {
+				logger.LogInfo(fmt.Sprintf("synced %d %s sessions, %d messages", result.SessionsImported, target.runtime, result.MessagesImported))
+			}
+			logger.LogDebug(fmt.Sprintf("%s reconciliation complete: %d files scanned, %d sessions imported, %d messages", target.runtime, result.FilesScanned, result.SessionsImported, result.MessagesImported))
 		}
-		logger.LogDebug(fmt.Sprintf("reconciliation complete: %d files scanned, %d sessions imported, %d messages", result.FilesScanned, result.SessionsImported, result.MessagesImported))
 		return nil
 	}
 	return runWatchLoop(ctx, options.DebounceInterval, options.ReconcileInterval, source, reconcile, logger)
@@ -173,7 +195,7 @@ func runWatchLoop(ctx context.Context, debounceInterval time.Duration, reconcile
 
 type fsnotifyEventSource struct {
 	watcher *fsnotify.Watcher
-	root    string
+	roots   []string
 	events  chan string
 	errors  chan error
 	done    chan struct{}
@@ -181,7 +203,7 @@ type fsnotifyEventSource struct {
 	once    sync.Once
 }
 
-func newFSNotifyEventSource(root string, refreshInterval time.Duration) (*fsnotifyEventSource, error) {
+func newFSNotifyEventSource(roots []string, refreshInterval time.Duration) (*fsnotifyEventSource, error) {
 	watcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return nil, fmt.Errorf("create Pi sessions filesystem watcher: %w", err)
@@ -189,7 +211,7 @@ func newFSNotifyEventSource(root string, refreshInterval time.Duration) (*fsnoti
 	ctx, cancel := context.WithCancel(context.Background())
 	source := &fsnotifyEventSource{
 		watcher: watcher,
-		root:    root,
+		roots:   roots,
 		events:  make(chan string, 256),
 		errors:  make(chan error, 16),
 		done:    make(chan struct{}),
@@ -255,7 +277,16 @@ func (source *fsnotifyEventSource) run(ctx context.Context, refreshInterval time
 }
 
 func (source *fsnotifyEventSource) addDirectories() error {
-	return filepath.WalkDir(source.root, func(path string, entry os.DirEntry, walkErr error) error {
+	for _, root := range source.roots {
+		if err := source.addDirectoriesUnder(root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (source *fsnotifyEventSource) addDirectoriesUnder(root string) error {
+	return filepath.WalkDir(root, func(path string, entry os.DirEntry, walkErr error) error {
 		if walkErr != nil {
 			return walkErr
 		}
@@ -1,12 +1,12 @@
 /**
  * Component: Provenance Recorder
- * Block-UUID: 5b9d50de-417b-4599-ab2c-c38f22e8d138
- * Parent-UUID: 94e22627-d8f9-43b8-9f9a-c56fa7c0b75b
- * Version: 1.3.0
- * Description: Handles the recording of code provenance to the worktree-level ledger (.gitsense/provenance.jsonl) and the injection of ephemeral code block headers into source files. Updated ReadExistingHeader to ensure all metadata fields (Block-UUID, Version, Authors, Created-at) are captured before exiting the loop, preventing premature termination when Version is found before history fields. Updated generateHeader to display "N/A" for Parent-UUID when it is empty, ensuring consistency with the planned convention.
+ * Block-UUID: d69829d5-2c1b-44be-aed6-b72450f48888
+ * Parent-UUID: 5b9d50de-417b-4599-ab2c-c38f22e8d138
+ * Version: 1.4.0
+ * Description: Handles the recording of code provenance to the worktree-level ledger (.gitsense/provenance.jsonl) and the injection of ephemeral code block headers into source files. ProvenanceEntry now carries the agent permission profile and digest a change was made under.
  * Language: Go
  * Created-at: 2026-04-28T12:45:20.087Z
- * Authors: Gemini 3 Flash (v1.0.0), GLM-4.7 (v1.1.0), Gemini 3 Flash (v1.2.0), GLM-4.7 (v1.3.0)
+ * Authors: Gemini 3 Flash (v1.0.0), GLM-4.7 (v1.1.0), Gemini 3 Flash (v1.2.0), GLM-4.7 (v1.3.0), agent (v1.4.0)
  */
 
 
@@ -25,26 +25,28 @@ import (
 
 // ProvenanceEntry represents a single line in the .gitsense/provenance.jsonl file.
 type ProvenanceEntry struct {
-	Timestamp      time.Time `json:"timestamp"`
-	ContractUUID   string    `json:"contract_uuid,omitempty"`
-	SessionID      string    `json:"session_id"`
-	TurnID         int       `json:"turn_id"`
-	BlockUUID      string    `json:"block_uuid"`
-	ParentUUID     string    `json:"parent_uuid,omitempty"`
-	OldVersion     string    `json:"old_version,omitempty"`
-	NewVersion     string    `json:"new_version"`
-	Path           string    `json:"path"`            // Relative path from worktree root
-	WorkingDirPath string    `json:"working_dir_path"` // Absolute path to worktree root
-	OldBlobSHA     string    `json:"old_blob_sha,omitempty"`
-	NewBlobSHA     string    `json:"new_blob_sha"`
-	ChangeType     string    `json:"change_type"` // "added", "modified", "deleted"
-	AuthorType     string    `json:"author_type"` // "ai" or "human"
-	AuthorName     string    `json:"author_name"`
-	ModelID        string    `json:"model_id,omitempty"`
-	Source         string    `json:"source"` // "gsc-cli" | "gitsense-chat-app"
-	Description    string    `json:"description"`
-	LinesAdded     int       `json:"lines_added"`
-	LinesDeleted   int       `json:"lines_deleted"`
+	Timestamp         time.Time `json:"timestamp"`
+	ContractUUID      string    `json:"contract_uuid,omitempty"`
+	SessionID         string    `json:"session_id"`
+	TurnID            int       `json:"turn_id"`
+	BlockUUID         string    `json:"block_uuid"`
+	ParentUUID        string    `json:"parent_uuid,omitempty"`
+	OldVersion        string    `json:"old_version,omitempty"`
+	NewVersion        string    `json:"new_version"`
+	Path              string    `json:"path"`             // Relative path from worktree root
+	WorkingDirPath    string    `json:"working_dir_path"` // Absolute path to worktree root
+	OldBlobSHA        string    `json:"old_blob_sha,omitempty"`
+	NewBlobSHA        string    `json:"new_blob_sha"`
+	ChangeType        string    `json:"change_type"` // "added", "modified", "deleted"
+	AuthorType        string    `json:"author_type"` // "ai" or "human"
+	AuthorName        string    `json:"author_name"`
+	ModelID           string    `json:"model_id,omitempty"`
+	Source            string    `json:"source"` // "gsc-cli" | "gitsense-chat-app"
+	Description       string    `json:"description"`
+	LinesAdded        int       `json:"lines_added"`
+	LinesDeleted      int       `json:"lines_deleted"`
+	PermissionProfile string    `json:"permission_profile,omitempty"` // Agent permission profile the change was made under
+	PermissionDigest  string    `json:"permission_digest,omitempty"`  // Digest of that profile's resolved phases
 }
 
 // RecordChange appends a ProvenanceEntry to the .gitsense/provenance.jsonl file.
@@ -1,12 +1,12 @@
 /**
  * Component: Settings and Configuration Manager
- * Block-UUID: a1cd89d6-6054-42f8-a495-d1a17ede02d7
- * Parent-UUID: c10ec227-8f31-4d69-a11d-ea7d6f74df11
- * Version: 3.29.0
- * Description: Added ShadowReposRelPath constant to define the location of shadow repositories within the data directory structure.
+ * Block-UUID: 63828e5b-a9e1-47e7-9241-31ba57234bac
+ * Parent-UUID: 5829fefe-63fe-44a7-b3b0-4a79b31d5bb3
+ * Version: 3.35.0
+ * Description: Added ExecRetentionRelPath and GetExecRetentionPath for the saved exec output retention policy.
  * Language: Go
  * Created-at: 2026-05-22T15:21:42.971Z
- * Authors: ..., claude-haiku-4-5-20251001 (v3.20.0), claude-haiku-4-5-20251001 (v3.21.0), GLM-4.7 (v3.22.0), GLM-4.7 (v3.23.0), GLM-4.7 (v3.24.0), GLM-4.7 (v3.25.0), Gemini 3 Flash (v3.26.0), GLM-4.7 (v3.27.0), GLM-4.7 (v3.27.1), GLM-4.7 (v3.28.0), GLM-4.7 (v3.29.0)
+ * Authors: ..., claude-haiku-4-5-20251001 (v3.20.0), claude-haiku-4-5-20251001 (v3.21.0), GLM-4.7 (v3.22.0), GLM-4.7 (v3.23.0), GLM-4.7 (v3.24.0), GLM-4.7 (v3.25.0), Gemini 3 Flash (v3.26.0), GLM-4.7 (v3.27.0), GLM-4.7 (v3.27.1), GLM-4.7 (v3.28.0), GLM-4.7 (v3.29.0), agent (v3.30.0), agent (v3.31.0), agent (v3.32.0), agent (v3.33.0), agent (v3.34.0), agent (v3.35.0)
  */
 
 
@@ -82,11 +82,22 @@ const BridgeHandshakeDir = "data/codes"
 const ManifestStorageDir = "data/storage/manifests"
 const ChatDatabaseRelPath = "data/chats.sqlite3"
 const ExecOutputsRelPath = "data/exec/outputs"
+
+// ExecRetentionRelPath is the relative path within GSC_HOME for the saved
+// exec output retention policy
+const ExecRetentionRelPath = "data/exec/retention.json"
 const ContractsRelPath = "data/contracts"
 
 // HomesRelPath is the relative path within GSC_HOME for contract homes
 const HomesRelPath = "data/homes"
 
+// BridgeAttachmentsRelPath is the relative path within GSC_HOME for oversized
+// bridge output saved outside a contract home
+const BridgeAttachmentsRelPath = "data/bridge/attachments"
+
+// AttachmentsDirName is the directory in a contract home for oversized bridge output
+const AttachmentsDirName = "attachments"
+
 // ReviewStagingRelPath is the relative path within GSC_HOME for temporary review files
 const ReviewStagingRelPath = "data/review"
 
@@ -119,6 +130,10 @@ const PiSyncPIDFileName = "sync.pid"
 const PiSyncLogFileName = "sync.log"
 const PiSyncDebugLogFileName = "sync-debug.log"
 const PiSessionsDatabaseFileName = "pi-sessions.sqlite3"
+const PiPricingFileName = "pricing.json"
+const PiRedactConfigFileName = "redact.json"
+const PiArchiveDirName = "archive"
+const PiTailSocketFileName = "sessions-tail.sock"
 const ClaudeChatsDirRelPath = "chats"
 const DefaultClaudeChunkSize = 5
 const DefaultClaudeMaxFiles = 5
@@ -333,6 +348,30 @@ func GetPiSessionsDatabasePath(gscHome string) string {
 	return filepath.Join(GetPiGscDataDir(gscHome), PiSessionsDatabaseFileName)
 }
 
+// GetPiPricingPath returns the absolute path to the editable per-model token
+// price table used by gsc pi sessions usage.
+func GetPiPricingPath(gscHome string) string {
+	return filepath.Join(GetPiGscDataDir(gscHome), PiPricingFileName)
+}
+
+// GetPiRedactConfigPath returns the absolute path to the user-configurable
+// secret detectors used by gsc pi sessions redact.
+func GetPiRedactConfigPath(gscHome string) string {
+	return filepath.Join(GetPiGscDataDir(gscHome), PiRedactConfigFileName)
+}
+
+// GetPiArchiveDirPath returns the absolute path to the directory holding the
+// compressed per-session bundles written by gsc pi sessions gc.
+func GetPiArchiveDirPath(gscHome string) string {
+	return filepath.Join(GetPiGscDataDir(gscHome), PiArchiveDirName)
+}
+
+// GetPiTailSocketPath returns the absolute path to the Unix socket that
+// gsc pi sessions tail --follow --socket serves events on.
+func GetPiTailSocketPath(gscHome string) string {
+	return filepath.Join(GetPiGscDataDir(gscHome), PiTailSocketFileName)
+}
+
 // GetPiSyncPIDPath returns the absolute path to the Pi sync watcher PID file.
 func GetPiSyncPIDPath(gscHome string) string {
 	return filepath.Join(GetPiGscDataDir(gscHome), PiSyncPIDFileName)
@@ -362,6 +401,15 @@ func GetExecOutputsDir() (string, error) {
 	return filepath.Join(gscHome, ExecOutputsRelPath), nil
 }
 
+// GetExecRetentionPath returns the absolute path to the exec output retention policy.
+func GetExecRetentionPath() (string, error) {
+	gscHome, err := GetGSCHome(false)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve GSC_HOME for exec retention: %w", err)
+	}
+	return filepath.Join(gscHome, ExecRetentionRelPath), nil
+}
+
 // GetReviewStagingDir returns the absolute path to the review staging directory.
 func GetReviewStagingDir() (string, error) {
 	gscHome, err := GetGSCHome(false)